@@ -10,6 +10,14 @@ type AlertConfigSpec struct {
 	// +optional
 	Slack *SlackConfig `json:"slack,omitempty"`
 
+	// PagerDuty configuration for alert notifications
+	// +optional
+	PagerDuty *PagerDutyConfig `json:"pagerDuty,omitempty"`
+
+	// Teams configuration for Microsoft Teams alert notifications
+	// +optional
+	Teams *TeamsConfig `json:"teams,omitempty"`
+
 	// Webhooks is a list of generic webhook configurations
 	// +optional
 	Webhooks []WebhookConfig `json:"webhooks,omitempty"`
@@ -66,6 +74,59 @@ type SlackConfig struct {
 	// Possible values: DriftDetected, ComplianceFailure, PolicyViolation, CircuitBreakerTripped, RemediationPerformed
 	// +optional
 	Events []string `json:"events,omitempty"`
+
+	// Template is a Go template replacing the default Slack message payload
+	// Template data is the same as for webhook templates
+	// +optional
+	Template string `json:"template,omitempty"`
+}
+
+// PagerDutyConfig defines PagerDuty notification settings
+type PagerDutyConfig struct {
+	// Enabled enables or disables PagerDuty notifications
+	// +kubebuilder:default:=true
+	Enabled bool `json:"enabled"`
+
+	// RoutingKey is the Events API v2 integration key of the PagerDuty service
+	// This should be stored in a Secret and referenced via RoutingKeySecretRef
+	// +optional
+	RoutingKey string `json:"routingKey,omitempty"`
+
+	// RoutingKeySecretRef references a Secret containing the routing key
+	// The secret should have a key 'routingKey' unless Key is set
+	// +optional
+	RoutingKeySecretRef *SecretReference `json:"routingKeySecretRef,omitempty"`
+
+	// EventsURL overrides the Events API endpoint (default: https://events.pagerduty.com/v2/enqueue)
+	// +optional
+	EventsURL string `json:"eventsURL,omitempty"`
+
+	// Events is a list of event types to send to PagerDuty
+	// If empty, all events are sent
+	// +optional
+	Events []string `json:"events,omitempty"`
+}
+
+// TeamsConfig defines Microsoft Teams notification settings
+type TeamsConfig struct {
+	// Enabled enables or disables Teams notifications
+	// +kubebuilder:default:=true
+	Enabled bool `json:"enabled"`
+
+	// WebhookURL is the Teams incoming webhook URL
+	// This should be stored in a Secret and referenced via WebhookURLSecretRef
+	// +optional
+	WebhookURL string `json:"webhookURL,omitempty"`
+
+	// WebhookURLSecretRef references a Secret containing the webhook URL
+	// The secret should have a key 'url' with the webhook URL
+	// +optional
+	WebhookURLSecretRef *SecretReference `json:"webhookURLSecretRef,omitempty"`
+
+	// Events is a list of event types to send to Teams
+	// If empty, all events are sent
+	// +optional
+	Events []string `json:"events,omitempty"`
 }
 
 // WebhookConfig defines a generic webhook notification
@@ -98,7 +159,9 @@ type WebhookConfig struct {
 
 	// Template is a Go template for the request body
 	// If not specified, a default JSON payload is used
-	// Template data includes: .Level, .Title, .Description, .Source, .Timestamp, .Labels, .Metadata
+	// Template data includes: .Level, .Severity, .Title, .Description, .Source, .EventType, .Timestamp,
+	// .Cluster, .Spec, .Counts, .FailedChecks, .Labels, .Metadata
+	// Functions: toJson, join, upper, lower, rfc3339
 	// +optional
 	Template string `json:"template,omitempty"`
 
@@ -129,7 +192,7 @@ type AlertRoute struct {
 	Match map[string]string `json:"match"`
 
 	// Notifiers is a list of notifier names to send matching alerts to
-	// Names can be "slack" or webhook names
+	// Names can be "slack", "pagerduty", "teams" or webhook names
 	Notifiers []string `json:"notifiers"`
 
 	// Continue indicates whether to continue matching other routes after this one
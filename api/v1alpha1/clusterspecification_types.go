@@ -13,6 +13,12 @@ type ClusterSpecificationSpec struct {
 	// +optional
 	ClusterRef *ClusterReference `json:"clusterRef,omitempty"`
 
+	// ClusterRefs references further ClusterTargets scanned against this specification.
+	// The clusters are scanned concurrently with the cluster above, each getting its own
+	// ComplianceReport. Drift remediation and enforcement apply to the cluster above only.
+	// +optional
+	ClusterRefs []ClusterReference `json:"clusterRefs,omitempty"`
+
 	// Enforcement defines enforcement behavior for this specification
 	// +optional
 	Enforcement *EnforcementSpec `json:"enforcement,omitempty"`
@@ -41,6 +47,11 @@ type ClusterSpecificationSpec struct {
 	// +optional
 	PolicyExemptions []PolicyExemptionSpec `json:"policyExemptions,omitempty"`
 
+	// ExemptionTicketPattern is a regular expression every exemption ticket reference
+	// must match. Exemptions whose ticket does not match are not honored.
+	// +optional
+	ExemptionTicketPattern string `json:"exemptionTicketPattern,omitempty"`
+
 	spec.SpecFields `json:",inline"`
 }
 
@@ -152,6 +163,10 @@ type PolicyExemptionSpec struct {
 	// Approver who approved this exemption
 	// +optional
 	Approver string `json:"approver,omitempty"`
+
+	// TicketRef references the change ticket that approved this exemption
+	// +optional
+	TicketRef string `json:"ticketRef,omitempty"`
 }
 
 // ResourceSelectorSpec selects specific resources
@@ -192,12 +207,13 @@ type EnforcementSpec struct {
 	// +kubebuilder:default=false
 	Enabled bool `json:"enabled,omitempty"`
 
-	// Mode defines the enforcement mode: monitor, audit, enforce
+	// Mode defines the enforcement mode: monitor, audit, enforce, mutate
 	// monitor: no enforcement, only monitoring
 	// audit: log violations but don't block
 	// enforce: actively block violations
+	// mutate: patch missing required security context fields, block other violations
 	// +optional
-	// +kubebuilder:validation:Enum=monitor;audit;enforce
+	// +kubebuilder:validation:Enum=monitor;audit;enforce;mutate
 	// +kubebuilder:default=monitor
 	Mode string `json:"mode,omitempty"`
 
@@ -232,6 +248,42 @@ type WebhooksSpec struct {
 	// Certificate configures TLS certificate for webhooks
 	// +optional
 	Certificate *CertificateSpec `json:"certificate,omitempty"`
+
+	// CircuitBreaker configures when the webhook stops validating after repeated
+	// errors and how requests are answered until it recovers
+	// +optional
+	CircuitBreaker *CircuitBreakerSpec `json:"circuitBreaker,omitempty"`
+}
+
+// CircuitBreakerSpec defines webhook circuit breaker configuration
+type CircuitBreakerSpec struct {
+	// ErrorRatePercent is the percentage of failed recent requests that trips the breaker
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=50
+	ErrorRatePercent int32 `json:"errorRatePercent,omitempty"`
+
+	// MinRequests is the number of recent requests needed before the breaker can trip
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=10
+	MinRequests int32 `json:"minRequests,omitempty"`
+
+	// CooldownSeconds is how long the breaker stays tripped before requests are validated again
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=300
+	CooldownSeconds int32 `json:"cooldownSeconds,omitempty"`
+
+	// FailureMode defines how requests are answered while the breaker is tripped
+	// Open: allow requests with a warning (default)
+	// Closed: deny requests
+	// +optional
+	// +kubebuilder:validation:Enum=Open;Closed
+	// +kubebuilder:default=Open
+	FailureMode string `json:"failureMode,omitempty"`
 }
 
 // CertificateSpec defines certificate configuration
@@ -261,12 +313,23 @@ type ClusterSpecificationStatus struct {
 	// +optional
 	LastScanTime *metav1.Time `json:"lastScanTime,omitempty"`
 
+	// LastHandledReconcileAt is the value of the kspec.io/reconcile-now annotation
+	// handled by the most recent on-demand reconciliation
+	// +optional
+	LastHandledReconcileAt string `json:"lastHandledReconcileAt,omitempty"`
+
 	// ComplianceScore is the overall compliance score (0-100)
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=100
 	// +optional
 	ComplianceScore int `json:"complianceScore,omitempty"`
 
+	// WeightedComplianceScore is the compliance score weighted by spec.scoring.weights (0-100)
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	WeightedComplianceScore int `json:"weightedComplianceScore,omitempty"`
+
 	// Conditions represent the latest available observations of the ClusterSpecification's state
 	// +optional
 	// +patchMergeKey=type
@@ -279,6 +342,16 @@ type ClusterSpecificationStatus struct {
 	// +optional
 	Summary *ComplianceSummary `json:"summary,omitempty"`
 
+	// RecentScans holds summaries of the most recent compliance scans, newest first
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	RecentScans []ScanSummary `json:"recentScans,omitempty"`
+
+	// ObservedSpec records the spec the last ComplianceReport was created against,
+	// so the next spec edit can be summarized on the following report
+	// +optional
+	ObservedSpec *ObservedSpecStatus `json:"observedSpec,omitempty"`
+
 	// Enforcement tracks enforcement state
 	// +optional
 	Enforcement *EnforcementStatus `json:"enforcement,omitempty"`
@@ -308,6 +381,36 @@ type ComplianceSummary struct {
 	DriftEvents int `json:"driftEvents,omitempty"`
 }
 
+// ScanSummary summarizes a single compliance scan
+type ScanSummary struct {
+	// Timestamp is when the scan completed
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// Score is the compliance score (0-100) of the scan
+	Score int `json:"score"`
+
+	// Passed is the number of checks that passed
+	Passed int `json:"passed"`
+
+	// Failed is the number of checks that failed
+	Failed int `json:"failed"`
+}
+
+// ObservedSpecStatus identifies an observed spec by generation and by a hash of
+// each field, which is enough to list the fields a later spec edit changed
+type ObservedSpecStatus struct {
+	// Generation is the spec generation that was observed
+	Generation int64 `json:"generation"`
+
+	// FieldHashes maps each spec field path to a hash of its value
+	// +optional
+	FieldHashes map[string]string `json:"fieldHashes,omitempty"`
+
+	// LastChanges summarizes the spec changes the most recent edit made
+	// +optional
+	LastChanges string `json:"lastChanges,omitempty"`
+}
+
 // EnforcementStatus tracks enforcement state
 type EnforcementStatus struct {
 	// Active indicates if enforcement is currently active
@@ -32,6 +32,42 @@ type ComplianceReportSpec struct {
 	// Results contains the detailed compliance check results
 	// +optional
 	Results []CheckResult `json:"results,omitempty"`
+
+	// Exemptions lists the policy exemptions configured when the scan ran
+	// +optional
+	Exemptions []ExemptionRecord `json:"exemptions,omitempty"`
+
+	// Warnings lists issues found during the scan that are not check failures,
+	// such as expired exemptions that are still configured
+	// +optional
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ExemptionRecord records a policy exemption for audit purposes
+type ExemptionRecord struct {
+	// Name of the exemption
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// TicketRef references the change ticket that approved the exemption
+	// +optional
+	TicketRef string `json:"ticketRef,omitempty"`
+
+	// Approver who approved the exemption
+	// +optional
+	Approver string `json:"approver,omitempty"`
+
+	// Reason for the exemption
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// ExpiresAt is when the exemption expires
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// Expired is true when the exemption had expired at scan time
+	// +optional
+	Expired bool `json:"expired,omitempty"`
 }
 
 // ObjectReference contains enough information to locate a referenced object
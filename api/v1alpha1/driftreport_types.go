@@ -41,8 +41,8 @@ type DriftReportSpec struct {
 
 // DriftEvent represents a single drift event
 type DriftEvent struct {
-	// Type of drift (Policy, Compliance, Configuration)
-	// +kubebuilder:validation:Enum=Policy;Compliance;Configuration
+	// Type of drift (Policy, Compliance, Configuration, RBAC)
+	// +kubebuilder:validation:Enum=Policy;Compliance;Configuration;RBAC
 	// +kubebuilder:validation:Required
 	Type string `json:"type"`
 
@@ -0,0 +1,77 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PolicyLibrarySpec defines a reusable base policy that ClusterSpecifications
+// inherit through policyInheritance.basePolicies
+type PolicyLibrarySpec struct {
+	// Description explains what the base policy enforces
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// RequiredFields lists fields that workloads must set
+	// +optional
+	RequiredFields []PolicyFieldRequirement `json:"requiredFields,omitempty"`
+
+	// ForbiddenFields lists field values that workloads must not use
+	// +optional
+	ForbiddenFields []PolicyFieldRequirement `json:"forbiddenFields,omitempty"`
+
+	// Validations lists custom validation rules
+	// +optional
+	Validations []PolicyValidationRule `json:"validations,omitempty"`
+}
+
+// PolicyFieldRequirement defines a required or forbidden field
+type PolicyFieldRequirement struct {
+	// Key is the field path (e.g., securityContext.runAsNonRoot)
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+
+	// Value is the required or forbidden value
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// PolicyValidationRule defines custom validation logic
+type PolicyValidationRule struct {
+	// Name identifies the rule
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Expression is the CEL expression to evaluate
+	// +kubebuilder:validation:Required
+	Expression string `json:"expression"`
+
+	// Message is shown when the validation fails
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PolicyLibrary publishes a named base policy; the object name is the name
+// referenced in policyInheritance.basePolicies
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=plib
+// +kubebuilder:printcolumn:name="Description",type=string,JSONPath=`.spec.description`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type PolicyLibrary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PolicyLibrarySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PolicyLibraryList contains a list of PolicyLibrary
+type PolicyLibraryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PolicyLibrary `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PolicyLibrary{}, &PolicyLibraryList{})
+}
@@ -92,6 +92,16 @@ func (in *AlertConfigSpec) DeepCopyInto(out *AlertConfigSpec) {
 		*out = new(SlackConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PagerDuty != nil {
+		in, out := &in.PagerDuty, &out.PagerDuty
+		*out = new(PagerDutyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = new(TeamsConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Webhooks != nil {
 		in, out := &in.Webhooks, &out.Webhooks
 		*out = make([]WebhookConfig, len(*in))
@@ -218,6 +228,21 @@ func (in *CheckResult) DeepCopy() *CheckResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreakerSpec) DeepCopyInto(out *CircuitBreakerSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreakerSpec.
+func (in *CircuitBreakerSpec) DeepCopy() *CircuitBreakerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreakerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterReference) DeepCopyInto(out *ClusterReference) {
 	*out = *in
@@ -300,6 +325,11 @@ func (in *ClusterSpecificationSpec) DeepCopyInto(out *ClusterSpecificationSpec)
 		*out = new(ClusterReference)
 		**out = **in
 	}
+	if in.ClusterRefs != nil {
+		in, out := &in.ClusterRefs, &out.ClusterRefs
+		*out = make([]ClusterReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.Enforcement != nil {
 		in, out := &in.Enforcement, &out.Enforcement
 		*out = new(EnforcementSpec)
@@ -369,6 +399,18 @@ func (in *ClusterSpecificationStatus) DeepCopyInto(out *ClusterSpecificationStat
 		*out = new(ComplianceSummary)
 		**out = **in
 	}
+	if in.RecentScans != nil {
+		in, out := &in.RecentScans, &out.RecentScans
+		*out = make([]ScanSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ObservedSpec != nil {
+		in, out := &in.ObservedSpec, &out.ObservedSpec
+		*out = new(ObservedSpecStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Enforcement != nil {
 		in, out := &in.Enforcement, &out.Enforcement
 		*out = new(EnforcementStatus)
@@ -588,6 +630,18 @@ func (in *ComplianceReportSpec) DeepCopyInto(out *ComplianceReportSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Exemptions != nil {
+		in, out := &in.Exemptions, &out.Exemptions
+		*out = make([]ExemptionRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceReportSpec.
@@ -797,6 +851,25 @@ func (in *EnforcementStatus) DeepCopy() *EnforcementStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExemptionRecord) DeepCopyInto(out *ExemptionRecord) {
+	*out = *in
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExemptionRecord.
+func (in *ExemptionRecord) DeepCopy() *ExemptionRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ExemptionRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceScopeSpec) DeepCopyInto(out *NamespaceScopeSpec) {
 	*out = *in
@@ -861,6 +934,53 @@ func (in *ObjectReference) DeepCopy() *ObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObservedSpecStatus) DeepCopyInto(out *ObservedSpecStatus) {
+	*out = *in
+	if in.FieldHashes != nil {
+		in, out := &in.FieldHashes, &out.FieldHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObservedSpecStatus.
+func (in *ObservedSpecStatus) DeepCopy() *ObservedSpecStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObservedSpecStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PagerDutyConfig) DeepCopyInto(out *PagerDutyConfig) {
+	*out = *in
+	if in.RoutingKeySecretRef != nil {
+		in, out := &in.RoutingKeySecretRef, &out.RoutingKeySecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerDutyConfig.
+func (in *PagerDutyConfig) DeepCopy() *PagerDutyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PagerDutyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyExemptionSpec) DeepCopyInto(out *PolicyExemptionSpec) {
 	*out = *in
@@ -892,6 +1012,21 @@ func (in *PolicyExemptionSpec) DeepCopy() *PolicyExemptionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyFieldRequirement) DeepCopyInto(out *PolicyFieldRequirement) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyFieldRequirement.
+func (in *PolicyFieldRequirement) DeepCopy() *PolicyFieldRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyFieldRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyInheritanceSpec) DeepCopyInto(out *PolicyInheritanceSpec) {
 	*out = *in
@@ -912,6 +1047,94 @@ func (in *PolicyInheritanceSpec) DeepCopy() *PolicyInheritanceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyLibrary) DeepCopyInto(out *PolicyLibrary) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyLibrary.
+func (in *PolicyLibrary) DeepCopy() *PolicyLibrary {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyLibrary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicyLibrary) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyLibraryList) DeepCopyInto(out *PolicyLibraryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PolicyLibrary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyLibraryList.
+func (in *PolicyLibraryList) DeepCopy() *PolicyLibraryList {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyLibraryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicyLibraryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyLibrarySpec) DeepCopyInto(out *PolicyLibrarySpec) {
+	*out = *in
+	if in.RequiredFields != nil {
+		in, out := &in.RequiredFields, &out.RequiredFields
+		*out = make([]PolicyFieldRequirement, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForbiddenFields != nil {
+		in, out := &in.ForbiddenFields, &out.ForbiddenFields
+		*out = make([]PolicyFieldRequirement, len(*in))
+		copy(*out, *in)
+	}
+	if in.Validations != nil {
+		in, out := &in.Validations, &out.Validations
+		*out = make([]PolicyValidationRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyLibrarySpec.
+func (in *PolicyLibrarySpec) DeepCopy() *PolicyLibrarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyLibrarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyTemplateRef) DeepCopyInto(out *PolicyTemplateRef) {
 	*out = *in
@@ -934,6 +1157,21 @@ func (in *PolicyTemplateRef) DeepCopy() *PolicyTemplateRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyValidationRule) DeepCopyInto(out *PolicyValidationRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyValidationRule.
+func (in *PolicyValidationRule) DeepCopy() *PolicyValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemediationAction) DeepCopyInto(out *RemediationAction) {
 	*out = *in
@@ -1005,6 +1243,22 @@ func (in *ResourceSelectorSpec) DeepCopy() *ResourceSelectorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScanSummary) DeepCopyInto(out *ScanSummary) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScanSummary.
+func (in *ScanSummary) DeepCopy() *ScanSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ScanSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
@@ -1045,6 +1299,31 @@ func (in *SlackConfig) DeepCopy() *SlackConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamsConfig) DeepCopyInto(out *TeamsConfig) {
+	*out = *in
+	if in.WebhookURLSecretRef != nil {
+		in, out := &in.WebhookURLSecretRef, &out.WebhookURLSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamsConfig.
+func (in *TeamsConfig) DeepCopy() *TeamsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TimeBasedActivationSpec) DeepCopyInto(out *TimeBasedActivationSpec) {
 	*out = *in
@@ -1140,6 +1419,11 @@ func (in *WebhooksSpec) DeepCopyInto(out *WebhooksSpec) {
 		*out = new(CertificateSpec)
 		**out = **in
 	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(CircuitBreakerSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhooksSpec.
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/cloudcwfranck/kspec/pkg/alerts"
+	"github.com/cloudcwfranck/kspec/pkg/drift"
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+)
+
+// loadAlertManager builds the notifiers of an --alert-config file. It returns
+// nil when no file is given.
+func loadAlertManager(path string) (*alerts.Manager, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	configSpec, err := alerts.LoadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return alerts.NewManagerFromConfig(configSpec, logr.Discard())
+}
+
+// sendSummaryAlert dispatches a run summary. Delivery failures are reported on
+// stderr but do not change the command's outcome.
+func sendSummaryAlert(ctx context.Context, manager *alerts.Manager, alert alerts.Alert) {
+	if manager == nil {
+		return
+	}
+
+	if err := manager.Send(ctx, alert); err != nil {
+		fmt.Fprintf(os.Stderr, "[WARN] Failed to send alert: %v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Alert sent: %s\n", alert.Title)
+}
+
+// scanSummaryAlert summarises a CLI scan, using the ComplianceFailure event type
+// when any check failed so notifier event filters match operator alerts
+func scanSummaryAlert(result *scanner.ScanResult) alerts.Alert {
+	clusterName := result.Metadata.Cluster.Name
+	specName := result.Metadata.Spec.Name
+
+	failedChecks := []string{}
+	criticalFailed := 0
+	for _, r := range result.Results {
+		if r.Status == scanner.StatusFail {
+			failedChecks = append(failedChecks, r.Name)
+			if r.Severity == scanner.SeverityCritical {
+				criticalFailed++
+			}
+		}
+	}
+
+	alert := alerts.Alert{
+		Level:     alerts.AlertLevelInfo,
+		Title:     "Compliance scan passed",
+		EventType: "ScanCompleted",
+		Source:    fmt.Sprintf("kspec-cli/%s", specName),
+		Description: fmt.Sprintf("Cluster %s: %d/%d checks passed, %d failed, %d errors",
+			clusterName, result.Summary.Passed, result.Summary.TotalChecks, result.Summary.Failed, result.Summary.Errors),
+		Labels: map[string]string{
+			"cluster":     clusterName,
+			"cluster_uid": result.Metadata.Cluster.UID,
+			"spec":        specName,
+		},
+		Metadata: map[string]interface{}{
+			"total_checks":    result.Summary.TotalChecks,
+			"passed":          result.Summary.Passed,
+			"failed":          result.Summary.Failed,
+			"errors":          result.Summary.Errors,
+			"weighted_score":  result.Summary.WeightedScore,
+			"critical_failed": criticalFailed,
+			"failed_checks":   failedChecks,
+			"cluster":         clusterName,
+		},
+	}
+
+	switch {
+	case result.Summary.Failed > 0:
+		alert.Level = alerts.AlertLevelWarning
+		alert.Title = "Compliance scan failed"
+		alert.EventType = "ComplianceFailure"
+	case result.Summary.Errors > 0:
+		alert.Level = alerts.AlertLevelWarning
+		alert.Title = "Compliance scan incomplete"
+	}
+
+	return alert
+}
+
+// driftSummaryAlert summarises a CLI drift detection run, using the DriftDetected
+// event type when drift was found
+func driftSummaryAlert(report *drift.DriftReport) alerts.Alert {
+	specName := report.Spec.Name
+	target := fmt.Sprintf("spec %s", specName)
+	if report.Baseline != nil {
+		specName = baselineHistoryName
+		target = fmt.Sprintf("baseline captured %s", report.Baseline.CapturedAt.Format(time.RFC3339))
+	}
+	eventCount := len(report.Events)
+
+	alert := alerts.Alert{
+		Level:       alerts.AlertLevelInfo,
+		Title:       "No configuration drift detected",
+		Description: fmt.Sprintf("Cluster matches %s", target),
+		EventType:   "DriftCheckCompleted",
+		Source:      fmt.Sprintf("kspec-cli/%s", specName),
+		Timestamp:   report.Timestamp,
+		Labels: map[string]string{
+			"spec": specName,
+		},
+		Metadata: map[string]interface{}{
+			"event_count": eventCount,
+		},
+	}
+
+	if report.Drift.Detected {
+		alert.Level = alerts.AlertLevelCritical
+		alert.Title = "Configuration drift detected"
+		alert.EventType = "DriftDetected"
+		alert.Description = fmt.Sprintf("Detected %d drift event(s) against %s", eventCount, target)
+		for i, event := range report.Events {
+			if i >= 5 {
+				alert.Description += fmt.Sprintf("\n... and %d more", eventCount-5)
+				break
+			}
+			alert.Description += fmt.Sprintf("\n- %s: %s/%s", event.DriftKind, event.Resource.Kind, event.Resource.Name)
+		}
+	}
+
+	return alert
+}
@@ -17,14 +17,23 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+	clientpkg "github.com/cloudcwfranck/kspec/pkg/client"
 	"github.com/cloudcwfranck/kspec/pkg/discovery"
 )
 
@@ -49,15 +58,28 @@ var clusterAddCmd = &cobra.Command{
 	RunE:  runClusterAdd,
 }
 
+var clusterTestCmd = &cobra.Command{
+	Use:   "test <target-name>",
+	Short: "Test connectivity to a ClusterTarget",
+	Long: `Verify that kspec can connect to a ClusterTarget with its configured credentials.
+
+Loads the ClusterTarget and its Secret from the management cluster, connects the same
+way the operator does, and reports reachability, platform, version, and node count.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runClusterTest,
+}
+
 var (
 	kubeconfigPath  string
 	outputFormat    string
 	targetNamespace string
+	testOutput      string
 )
 
 func init() {
 	clusterCmd.AddCommand(clusterDiscoverCmd)
 	clusterCmd.AddCommand(clusterAddCmd)
+	clusterCmd.AddCommand(clusterTestCmd)
 
 	// Flags for discover command
 	clusterDiscoverCmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to kubeconfig file (default: $HOME/.kube/config)")
@@ -66,6 +88,11 @@ func init() {
 	clusterAddCmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to kubeconfig file (default: $HOME/.kube/config)")
 	clusterAddCmd.Flags().StringVarP(&outputFormat, "output", "o", "yaml", "Output format: yaml or json")
 	clusterAddCmd.Flags().StringVarP(&targetNamespace, "namespace", "n", "kspec-system", "Namespace for ClusterTarget and Secret")
+
+	// Flags for test command
+	clusterTestCmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to management cluster kubeconfig file (default: $HOME/.kube/config)")
+	clusterTestCmd.Flags().StringVarP(&testOutput, "output", "o", "text", "Output format: text or json")
+	clusterTestCmd.Flags().StringVarP(&targetNamespace, "namespace", "n", "kspec-system", "Namespace of the ClusterTarget")
 }
 
 func runClusterDiscover(cmd *cobra.Command, args []string) error {
@@ -203,3 +230,150 @@ func runClusterAdd(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// clusterTestResult is the outcome of a ClusterTarget connectivity test
+type clusterTestResult struct {
+	Name             string `json:"name"`
+	Namespace        string `json:"namespace"`
+	APIServerURL     string `json:"apiServerURL"`
+	AuthMode         string `json:"authMode"`
+	Reachable        bool   `json:"reachable"`
+	CredentialsValid *bool  `json:"credentialsValid"`
+	Platform         string `json:"platform,omitempty"`
+	Version          string `json:"version,omitempty"`
+	NodeCount        int32  `json:"nodeCount,omitempty"`
+	Error            string `json:"error,omitempty"`
+}
+
+func runClusterTest(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	targetName := args[0]
+
+	// Connect to the management cluster holding the ClusterTarget
+	config, err := buildRestConfig(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+
+	scheme, err := createScheme()
+	if err != nil {
+		return fmt.Errorf("failed to create scheme: %w", err)
+	}
+
+	k8sClient, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	// Load the ClusterTarget
+	target := &kspecv1alpha1.ClusterTarget{}
+	key := types.NamespacedName{Name: targetName, Namespace: targetNamespace}
+	if err := k8sClient.Get(ctx, key, target); err != nil {
+		return fmt.Errorf("failed to get ClusterTarget %s/%s: %w", targetNamespace, targetName, err)
+	}
+
+	result := clusterTestResult{
+		Name:         target.Name,
+		Namespace:    target.Namespace,
+		APIServerURL: target.Spec.APIServerURL,
+		AuthMode:     target.Spec.AuthMode,
+	}
+
+	// Run the same health check the operator performs
+	factory := clientpkg.NewClusterClientFactory(config, k8sClient)
+	health, checkErr := factory.CheckClusterTarget(ctx, target)
+	// Credentials are left unknown (null) unless the API server answered or they
+	// could not be loaded
+	switch clientpkg.CredentialStatus(checkErr) {
+	case metav1.ConditionTrue:
+		valid := true
+		result.CredentialsValid = &valid
+	case metav1.ConditionFalse:
+		valid := false
+		result.CredentialsValid = &valid
+	}
+	if checkErr != nil {
+		result.Error = checkErr.Error()
+	} else {
+		result.Reachable = true
+		result.Platform = health.Platform
+		result.Version = health.Version
+		result.NodeCount = health.NodeCount
+	}
+
+	if testOutput == "json" {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal result: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printClusterTestResult(result)
+	}
+
+	if checkErr != nil {
+		return fmt.Errorf("cluster %s is not reachable", targetName)
+	}
+	return nil
+}
+
+// createClusterTargetClients creates clients for the remote cluster of a
+// ClusterTarget, reading the target and its credentials Secret from the
+// management cluster of managementKubeconfig the way the operator does.
+func createClusterTargetClients(ctx context.Context, managementKubeconfig, namespace, name string) (kubernetes.Interface, dynamic.Interface, error) {
+	config, err := buildRestConfig(managementKubeconfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scheme, err := createScheme()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create scheme: %w", err)
+	}
+
+	k8sClient, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	target := &kspecv1alpha1.ClusterTarget{}
+	key := types.NamespacedName{Name: name, Namespace: namespace}
+	if err := k8sClient.Get(ctx, key, target); err != nil {
+		return nil, nil, fmt.Errorf("failed to get ClusterTarget %s/%s: %w", namespace, name, err)
+	}
+
+	factory := clientpkg.NewClusterClientFactory(config, k8sClient)
+	kubeClient, dynamicClient, _, err := factory.CreateClientsForClusterTarget(ctx, target)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to ClusterTarget %s (%s): %w", name, target.Spec.APIServerURL, err)
+	}
+
+	// Client creation doesn't contact the API server, so probe it explicitly
+	if _, err := kubeClient.Discovery().ServerVersion(); err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to ClusterTarget %s (%s): %w", name, target.Spec.APIServerURL, err)
+	}
+
+	return kubeClient, dynamicClient, nil
+}
+
+func printClusterTestResult(result clusterTestResult) {
+	fmt.Printf("ClusterTarget: %s/%s\n", result.Namespace, result.Name)
+	fmt.Printf("API Server:    %s\n", result.APIServerURL)
+	fmt.Printf("Auth Mode:     %s\n", result.AuthMode)
+	fmt.Println()
+
+	if !result.Reachable {
+		if result.CredentialsValid != nil && !*result.CredentialsValid {
+			fmt.Printf("[FAIL] InvalidCredentials: %s\n", result.Error)
+			return
+		}
+		fmt.Printf("[FAIL] Unreachable: %s\n", result.Error)
+		fmt.Printf("Credentials were not verified\n")
+		return
+	}
+
+	fmt.Printf("[OK] Cluster reachable, credentials valid\n")
+	fmt.Printf("Platform:      %s\n", result.Platform)
+	fmt.Printf("Version:       %s\n", result.Version)
+	fmt.Printf("Nodes:         %d\n", result.NodeCount)
+}
@@ -26,11 +26,13 @@ import (
 
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
 	"github.com/cloudcwfranck/kspec/pkg/aggregation"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
 )
 
 var (
@@ -38,6 +40,8 @@ var (
 	dashboardWatch       bool
 	dashboardInterval    int
 	dashboardClusterSpec string
+	dashboardOwner       string
+	dashboardTeam        string
 )
 
 var dashboardCmd = &cobra.Command{
@@ -52,7 +56,10 @@ and cluster health across all managed clusters.`,
   kspec dashboard --watch --interval 30
 
   # Dashboard for specific ClusterSpec
-  kspec dashboard --cluster-spec prod-baseline`,
+  kspec dashboard --cluster-spec prod-baseline
+
+  # Dashboard for one team's ClusterSpecs in a shared cluster
+  kspec dashboard --team payments`,
 	RunE: runDashboard,
 }
 
@@ -61,6 +68,8 @@ func init() {
 	dashboardCmd.Flags().BoolVarP(&dashboardWatch, "watch", "w", false, "Watch mode - continuously update dashboard")
 	dashboardCmd.Flags().IntVar(&dashboardInterval, "interval", 10, "Refresh interval in seconds (when using --watch)")
 	dashboardCmd.Flags().StringVar(&dashboardClusterSpec, "cluster-spec", "", "Filter by specific ClusterSpec name")
+	dashboardCmd.Flags().StringVar(&dashboardOwner, "owner", "", "Filter by owner (kspec.io/owner label)")
+	dashboardCmd.Flags().StringVar(&dashboardTeam, "team", "", "Filter by team (kspec.io/team label)")
 }
 
 func runDashboard(cmd *cobra.Command, args []string) error {
@@ -74,6 +83,8 @@ func runDashboard(cmd *cobra.Command, args []string) error {
 
 	// Create aggregator
 	aggregator := aggregation.NewReportAggregator(k8sClient)
+	aggregator.Owner = dashboardOwner
+	aggregator.Team = dashboardTeam
 
 	if dashboardWatch {
 		// Watch mode - clear screen and refresh
@@ -114,6 +125,10 @@ func displayDashboard(ctx context.Context, aggregator *aggregation.ReportAggrega
 	if dashboardClusterSpec != "" {
 		listOpts = append(listOpts, client.MatchingFields{"metadata.name": dashboardClusterSpec})
 	}
+	ownership := spec.Metadata{Owner: dashboardOwner, Team: dashboardTeam}.OwnershipLabels()
+	if len(ownership) > 0 {
+		listOpts = append(listOpts, client.MatchingLabels(ownership))
+	}
 
 	if err := aggregator.List(ctx, &clusterSpecs, listOpts...); err != nil {
 		return fmt.Errorf("failed to list ClusterSpecs: %w", err)
@@ -356,6 +371,7 @@ func createDashboardClient() (client.Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to build config: %w", err)
 	}
+	applyClientRateLimits(config)
 
 	// Create scheme
 	scheme, err := createScheme()
@@ -374,6 +390,9 @@ func createDashboardClient() (client.Client, error) {
 
 func createScheme() (*runtime.Scheme, error) {
 	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
 	if err := kspecv1alpha1.AddToScheme(scheme); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudcwfranck/kspec/pkg/discovery"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+)
+
+// specDiff is the JSON output of kspec diff
+type specDiff struct {
+	From    string        `json:"from"`
+	To      string        `json:"to"`
+	Changes []spec.Change `json:"changes"`
+}
+
+func newDiffCmd() *cobra.Command {
+	var (
+		fromFile       string
+		toFile         string
+		againstCluster bool
+		kubeconfigPath string
+		outputFormat   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show the field-level differences between two specs",
+		Long: `Diff compares the spec fields of two cluster specifications and lists each
+added, removed or changed field, so spec changes can be reviewed before they are
+rolled out. List items are matched by their key or name.
+
+With --against-cluster, the --from spec is compared with the effective spec of the
+current cluster, derived from its Kubernetes version, namespace Pod Security labels,
+network policies, service types, and workload images and probes. Only those
+derived fields are compared.`,
+		Example: `  # Review a baseline upgrade
+  kspec diff --from baseline-v1.yaml --to baseline-v2.yaml
+
+  # Compare a spec with what the cluster currently runs
+  kspec diff --from cluster-spec.yaml --against-cluster
+
+  # Machine-readable diff
+  kspec diff --from baseline-v1.yaml --to baseline-v2.yaml --output json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if outputFormat != "text" && outputFormat != "json" {
+				return fmt.Errorf("invalid output format %q: must be text or json", outputFormat)
+			}
+			if againstCluster == (toFile != "") {
+				return fmt.Errorf("specify exactly one of --to or --against-cluster")
+			}
+
+			fromSpec, err := spec.LoadFromFile(fromFile)
+			if err != nil {
+				return fmt.Errorf("failed to load spec %s: %w", fromFile, err)
+			}
+
+			result := specDiff{From: fromFile, To: toFile}
+			if againstCluster {
+				client, err := createKubernetesClient(kubeconfigPath)
+				if err != nil {
+					return fmt.Errorf("failed to create Kubernetes client: %w", err)
+				}
+
+				clusterSpec, err := discovery.DeriveSpec(context.Background(), client)
+				if err != nil {
+					return fmt.Errorf("failed to derive spec from cluster: %w", err)
+				}
+
+				result.To = "cluster"
+				result.Changes = spec.FilterChanges(spec.Diff(fromSpec, clusterSpec), discovery.DerivedSpecPaths)
+			} else {
+				toSpec, err := spec.LoadFromFile(toFile)
+				if err != nil {
+					return fmt.Errorf("failed to load spec %s: %w", toFile, err)
+				}
+				result.Changes = spec.Diff(fromSpec, toSpec)
+			}
+
+			if outputFormat == "json" {
+				if result.Changes == nil {
+					result.Changes = []spec.Change{}
+				}
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(result)
+			}
+
+			printSpecDiff(result)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&fromFile, "from", "", "Path to the original cluster spec file (required)")
+	cmd.Flags().StringVar(&toFile, "to", "", "Path to the updated cluster spec file")
+	cmd.Flags().BoolVar(&againstCluster, "against-cluster", false, "Compare --from with the spec derived from the current cluster")
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to kubeconfig file (default: $KUBECONFIG or ~/.kube/config)")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text|json")
+	cmd.MarkFlagRequired("from")
+
+	return cmd
+}
+
+func printSpecDiff(result specDiff) {
+	fmt.Printf("--- %s\n", result.From)
+	fmt.Printf("+++ %s\n", result.To)
+	fmt.Printf("\n")
+
+	if len(result.Changes) == 0 {
+		fmt.Printf("No differences\n")
+		return
+	}
+
+	counts := map[spec.ChangeType]int{}
+	for _, change := range result.Changes {
+		fmt.Println(change.String())
+		counts[change.Type]++
+	}
+
+	fmt.Printf("\n%d added, %d removed, %d changed\n",
+		counts[spec.ChangeAdded], counts[spec.ChangeRemoved], counts[spec.ChangeChanged])
+}
@@ -5,14 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/cloudcwfranck/kspec/pkg/alerts"
 	"github.com/cloudcwfranck/kspec/pkg/drift"
 	"github.com/cloudcwfranck/kspec/pkg/spec"
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/clientcmd"
 )
 
 func driftCommand() *cobra.Command {
@@ -25,6 +27,7 @@ Drift detection compares the current cluster state against your specification to
 - Missing policies (policies that should exist but don't)
 - Modified policies (policies that have been changed)
 - Compliance violations (new failures in compliance checks)
+- RBAC drift (roles and bindings granting what the spec forbids)
 
 Automatic remediation can restore drift to the expected state.`,
 		Example: `  # Detect drift once
@@ -37,7 +40,10 @@ Automatic remediation can restore drift to the expected state.`,
   kspec drift remediate --spec cluster-spec.yaml
 
   # View drift history
-  kspec drift history --spec cluster-spec.yaml`,
+  kspec drift history --spec cluster-spec.yaml
+
+  # Detect what changed since a snapshot captured with 'kspec snapshot'
+  kspec drift detect --baseline baseline.json`,
 	}
 
 	cmd.AddCommand(driftDetectCommand())
@@ -49,12 +55,16 @@ Automatic remediation can restore drift to the expected state.`,
 
 func driftDetectCommand() *cobra.Command {
 	var (
-		specFile       string
+		specFiles      []string
 		kubeconfigPath string
 		watch          bool
 		watchInterval  time.Duration
 		outputFormat   string
 		outputFile     string
+		resourceTypes  []string
+		alertConfig    string
+		baselineFile   string
+		history        historyOptions
 	)
 
 	cmd := &cobra.Command{
@@ -65,6 +75,15 @@ func driftDetectCommand() *cobra.Command {
 This command compares:
 1. Expected policies (from spec) vs deployed policies (in cluster)
 2. Expected compliance (from spec) vs actual compliance (from checks)
+3. RBAC requirements (from spec) vs roles and bindings (in cluster)
+
+With --baseline, the cluster is compared against a snapshot captured with
+'kspec snapshot' instead of a spec: policies, RBAC, network policies and
+namespace Pod Security labels deleted, modified or created since the snapshot
+are reported. --resource-types then accepts policy, rbac and configuration
+(network policies and namespaces), with netpol and psa as aliases for
+configuration. Without --baseline, netpol and psa are rejected as not yet
+supported.
 
 Outputs a drift report showing what has changed.`,
 		Example: `  # Detect drift once
@@ -74,14 +93,46 @@ Outputs a drift report showing what has changed.`,
   kspec drift detect --spec cluster-spec.yaml --watch --watch-interval=5m
 
   # Output drift report to file
-  kspec drift detect --spec cluster-spec.yaml --output drift-report.json`,
+  kspec drift detect --spec cluster-spec.yaml --output drift-report.json
+
+  # Only check policy drift (skip compliance recompute)
+  kspec drift detect --spec cluster-spec.yaml --resource-types=policy
+
+  # Send a summary to the Slack and webhook notifiers of an AlertConfig file
+  kspec drift detect --spec cluster-spec.yaml --alert-config alerts.yaml
+
+  # Report what changed since a known-good snapshot
+  kspec snapshot --output baseline.json
+  kspec drift detect --baseline baseline.json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
-			// Load spec
-			clusterSpec, err := spec.LoadFromFile(specFile)
+			if baselineFile != "" {
+				if len(specFiles) > 0 {
+					return fmt.Errorf("--baseline and --spec are mutually exclusive")
+				}
+				if watch {
+					return fmt.Errorf("--watch is not supported with --baseline")
+				}
+			} else if len(specFiles) == 0 {
+				return fmt.Errorf("--spec or --baseline is required")
+			}
+
+			parseTypes := drift.ParseDriftTypes
+			if baselineFile != "" {
+				parseTypes = drift.ParseBaselineDriftTypes
+			}
+			enabledTypes, err := parseTypes(resourceTypes)
 			if err != nil {
-				return fmt.Errorf("failed to load spec: %w", err)
+				return err
+			}
+
+			if alertConfig != "" && watch {
+				return fmt.Errorf("--alert-config is not supported with --watch")
+			}
+			alertManager, err := loadAlertManager(alertConfig)
+			if err != nil {
+				return fmt.Errorf("invalid --alert-config: %w", err)
 			}
 
 			// Create Kubernetes clients
@@ -90,14 +141,41 @@ Outputs a drift report showing what has changed.`,
 				return fmt.Errorf("failed to create clients: %w", err)
 			}
 
-			// Watch mode - continuous monitoring
+			// Baseline mode - report changes since the snapshot
+			if baselineFile != "" {
+				baseline, err := drift.LoadSnapshotFile(baselineFile)
+				if err != nil {
+					return fmt.Errorf("invalid --baseline: %w", err)
+				}
+				report, err := drift.NewDetector(client, dynamicClient).DetectBaselineDrift(ctx, baseline, drift.DetectOptions{
+					EnabledTypes: enabledTypes,
+					OutputFormat: outputFormat,
+					OutputFile:   outputFile,
+				})
+				if err != nil {
+					return fmt.Errorf("drift detection failed: %w", err)
+				}
+				return finishDriftDetection(ctx, client, report, outputFormat, outputFile, history, baselineHistoryName, alertManager)
+			}
+
+			// Watch mode - continuous monitoring, reloading the spec file on edits
 			if watch {
-				return runContinuousMonitoring(ctx, client, dynamicClient, clusterSpec, watchInterval)
+				if len(specFiles) > 1 {
+					return fmt.Errorf("--watch supports a single --spec file")
+				}
+				return runContinuousMonitoring(ctx, client, dynamicClient, specFiles[0], watchInterval, enabledTypes)
+			}
+
+			// Load spec
+			clusterSpec, err := spec.LoadFromFiles(specFiles)
+			if err != nil {
+				return fmt.Errorf("failed to load spec: %w", err)
 			}
 
 			// One-time drift detection
 			detector := drift.NewDetector(client, dynamicClient)
 			report, err := detector.Detect(ctx, clusterSpec, drift.DetectOptions{
+				EnabledTypes: enabledTypes,
 				OutputFormat: outputFormat,
 				OutputFile:   outputFile,
 			})
@@ -105,36 +183,56 @@ Outputs a drift report showing what has changed.`,
 				return fmt.Errorf("drift detection failed: %w", err)
 			}
 
-			// Print report
-			printDriftReport(report, outputFormat, outputFile)
-
-			// Exit with code 1 if drift detected
-			if report.Drift.Detected {
-				return fmt.Errorf("drift detected")
-			}
-
-			return nil
+			return finishDriftDetection(ctx, client, report, outputFormat, outputFile, history, clusterSpec.Metadata.Name, alertManager)
 		},
 	}
 
-	cmd.Flags().StringVarP(&specFile, "spec", "s", "", "Path to cluster spec file (required)")
+	cmd.Flags().StringArrayVarP(&specFiles, "spec", "s", nil, "Path to cluster spec file; repeat to layer specs, later files adding to earlier ones (required without --baseline)")
 	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to kubeconfig file")
 	cmd.Flags().BoolVar(&watch, "watch", false, "Continuous monitoring mode")
 	cmd.Flags().DurationVar(&watchInterval, "watch-interval", 5*time.Minute, "Polling interval for watch mode")
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text|json")
 	cmd.Flags().StringVar(&outputFile, "output-file", "", "Write report to file")
-	cmd.MarkFlagRequired("spec")
+	cmd.Flags().StringSliceVar(&resourceTypes, "resource-types", nil, "Drift types to detect: policy,compliance,rbac, or with --baseline policy,rbac,configuration (netpol and psa are aliases for configuration) (default: all)")
+	cmd.Flags().StringVar(&alertConfig, "alert-config", "", "AlertConfig file whose Slack and webhook notifiers receive a summary after detection")
+	cmd.Flags().StringVar(&baselineFile, "baseline", "", "Snapshot file from 'kspec snapshot' to detect changes against, instead of a spec")
+	history.addFlags(cmd)
 
 	return cmd
 }
 
+// baselineHistoryName is the name drift detected against a baseline snapshot is
+// recorded under in the drift history, in place of a spec name.
+const baselineHistoryName = "baseline"
+
+// finishDriftDetection prints a drift report, records it in the drift history
+// and sends its summary alert, returning an error if drift was detected.
+func finishDriftDetection(ctx context.Context, client kubernetes.Interface, report *drift.DriftReport, format, outputFile string, history historyOptions, historyName string, alertManager *alerts.Manager) error {
+	printDriftReport(report, format, outputFile)
+
+	recordDriftHistory(ctx, client, history, historyName, report)
+
+	sendSummaryAlert(ctx, alertManager, driftSummaryAlert(report))
+
+	// Exit with code 1 if drift detected
+	if report.Drift.Detected {
+		return fmt.Errorf("drift detected")
+	}
+
+	return nil
+}
+
 func driftRemediateCommand() *cobra.Command {
 	var (
-		specFile       string
+		specFiles      []string
 		kubeconfigPath string
 		dryRun         bool
 		force          bool
+		applyPatches   bool
+		enforce        bool
 		types          []string
+		outputFormat   string
+		history        historyOptions
 	)
 
 	cmd := &cobra.Command{
@@ -146,20 +244,37 @@ Remediation actions:
 - Missing policies: Create them
 - Modified policies: Update them to match spec
 - Extra policies: Report (delete with --force)
-- Compliance drift: Report (manual remediation required)`,
-		Example: `  # Dry-run (show what would be fixed)
+- Compliance drift: Report (apply the Kyverno policies that prevent
+  recurrence with --enforce-compliance, where a policy applies)
+- RBAC drift: Report (fix roles, delete bindings and create missing
+  rules with --force)
+- Workload security drift: Report a patch per violating workload
+  (apply with --apply-patches)`,
+		Example: `  # Dry-run (show the manifests and diffs of what would be fixed)
   kspec drift remediate --spec cluster-spec.yaml --dry-run
 
+  # Dry-run with machine-readable output for review tooling
+  kspec drift remediate --spec cluster-spec.yaml --dry-run --output=json
+
   # Remediate all policy drift
   kspec drift remediate --spec cluster-spec.yaml
 
   # Remediate specific types only
-  kspec drift remediate --spec cluster-spec.yaml --types=policy`,
+  kspec drift remediate --spec cluster-spec.yaml --types=policy
+
+  # Strip forbidden rules from roles and delete the bindings granting them
+  kspec drift remediate --spec cluster-spec.yaml --types=rbac --force
+
+  # Generate and apply securityContext patches for violating workloads
+  kspec drift remediate --spec cluster-spec.yaml --types=compliance --apply-patches
+
+  # Enforce the policies preventing failed compliance checks from recurring
+  kspec drift remediate --spec cluster-spec.yaml --types=compliance --enforce-compliance`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
 			// Load spec
-			clusterSpec, err := spec.LoadFromFile(specFile)
+			clusterSpec, err := spec.LoadFromFiles(specFiles)
 			if err != nil {
 				return fmt.Errorf("failed to load spec: %w", err)
 			}
@@ -171,33 +286,54 @@ Remediation actions:
 			}
 
 			// Convert type strings to DriftType
-			var driftTypes []drift.DriftType
-			for _, t := range types {
-				driftTypes = append(driftTypes, drift.DriftType(t))
+			driftTypes, err := drift.ParseDriftTypes(types)
+			if err != nil {
+				return err
+			}
+			if enforce && !containsDriftType(driftTypes, drift.DriftTypeCompliance) {
+				return fmt.Errorf("--enforce-compliance requires --types to include compliance")
 			}
 
 			// Detect and remediate
 			report, err := drift.RemediateAll(ctx, client, dynamicClient, clusterSpec, drift.RemediateOptions{
-				DryRun: dryRun,
-				Types:  driftTypes,
-				Force:  force,
+				DryRun:            dryRun,
+				Types:             driftTypes,
+				Force:             force,
+				ApplyPatches:      applyPatches,
+				EnforceCompliance: enforce,
 			})
 			if err != nil {
 				return fmt.Errorf("remediation failed: %w", err)
 			}
 
 			// Print remediation report
-			printRemediationReport(report, dryRun)
+			if outputFormat == "json" {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(report); err != nil {
+					return err
+				}
+			} else {
+				printRemediationReport(report, dryRun)
+			}
+
+			if !dryRun {
+				recordDriftHistory(ctx, client, history, clusterSpec.Metadata.Name, report)
+			}
 
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVarP(&specFile, "spec", "s", "", "Path to cluster spec file (required)")
+	cmd.Flags().StringArrayVarP(&specFiles, "spec", "s", nil, "Path to cluster spec file; repeat to layer specs, later files adding to earlier ones (required)")
 	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to kubeconfig file")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be fixed without applying changes")
-	cmd.Flags().BoolVar(&force, "force", false, "Delete extra policies (use with caution)")
-	cmd.Flags().StringSliceVar(&types, "types", []string{"policy"}, "Drift types to remediate: policy,compliance")
+	cmd.Flags().BoolVar(&force, "force", false, "Delete extra policies and remediate RBAC drift (use with caution)")
+	cmd.Flags().BoolVar(&applyPatches, "apply-patches", false, "Apply the workload patches generated for compliance drift")
+	cmd.Flags().BoolVar(&enforce, "enforce-compliance", false, "Apply Kyverno policies preventing failed compliance checks from recurring (affects all new workloads)")
+	cmd.Flags().StringSliceVar(&types, "types", []string{"policy"}, "Drift types to remediate: policy,compliance,rbac")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text|json")
+	history.addFlags(cmd)
 	cmd.MarkFlagRequired("spec")
 
 	return cmd
@@ -205,41 +341,55 @@ Remediation actions:
 
 func driftHistoryCommand() *cobra.Command {
 	var (
-		specFile       string
-		kubeconfigPath string
-		since          string
-		outputFormat   string
+		specFile         string
+		kubeconfigPath   string
+		since            string
+		outputFormat     string
+		historyNamespace string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "history",
 		Short: "Show drift detection history",
-		Long:  `Display historical drift events and statistics.`,
+		Long: `Display historical drift events and statistics.
+
+Drift detect and remediate runs append their events to the kspec-drift-history
+ConfigMap, under a key named after the spec. History reads them back.`,
 		Example: `  # Show all drift history
   kspec drift history --spec cluster-spec.yaml
 
   # Show drift from last 24 hours
   kspec drift history --spec cluster-spec.yaml --since=24h
 
+  # Show drift from last week
+  kspec drift history --spec cluster-spec.yaml --since=7d
+
   # Output as JSON
   kspec drift history --spec cluster-spec.yaml --output=json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Parse since duration (currently unused, will be used when storage is connected)
+			var sinceTime time.Time
 			if since != "" {
-				_, err := time.ParseDuration(since)
+				duration, err := parseSince(since)
 				if err != nil {
 					return fmt.Errorf("invalid duration '%s': %w", since, err)
 				}
-				// TODO: use parsed duration to filter history when storage is connected
+				sinceTime = time.Now().Add(-duration)
 			}
 
-			// For now, just return empty history
-			// In a full implementation, this would read from storage
-			history := &drift.DriftHistory{
-				Events: []drift.DriftEvent{},
-				Stats: drift.DriftStats{
-					TotalEvents: 0,
-				},
+			clusterSpec, err := spec.LoadFromFile(specFile)
+			if err != nil {
+				return fmt.Errorf("failed to load spec: %w", err)
+			}
+
+			client, err := createKubernetesClient(kubeconfigPath)
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %w", err)
+			}
+
+			storage := drift.NewConfigMapStorage(client, historyNamespace, clusterSpec.Metadata.Name, 0)
+			history, err := storage.GetHistory(sinceTime)
+			if err != nil {
+				return fmt.Errorf("failed to read drift history: %w", err)
 			}
 
 			printDriftHistory(history, outputFormat)
@@ -251,28 +401,69 @@ func driftHistoryCommand() *cobra.Command {
 	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to kubeconfig file")
 	cmd.Flags().StringVar(&since, "since", "", "Show history since duration (e.g., 24h, 7d)")
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text|json")
+	cmd.Flags().StringVar(&historyNamespace, "history-namespace", drift.DefaultHistoryNamespace, "Namespace of the drift history ConfigMap")
 	cmd.MarkFlagRequired("spec")
 
 	return cmd
 }
 
-// Helper functions
+// historyOptions configures where drift detect and remediate record history
+type historyOptions struct {
+	namespace  string
+	maxEntries int
+	disabled   bool
+}
 
-func createClients(kubeconfigPath string) (kubernetes.Interface, dynamic.Interface, error) {
-	// Use default kubeconfig path if not specified
-	if kubeconfigPath == "" {
-		kubeconfigPath = os.Getenv("KUBECONFIG")
-		if kubeconfigPath == "" {
-			kubeconfigPath = clientcmd.NewDefaultClientConfigLoadingRules().GetDefaultFilename()
+func (o *historyOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.namespace, "history-namespace", drift.DefaultHistoryNamespace, "Namespace of the drift history ConfigMap")
+	cmd.Flags().IntVar(&o.maxEntries, "history-max-entries", drift.DefaultHistoryMaxEntries, "Drift events to retain per spec in the history ConfigMap")
+	cmd.Flags().BoolVar(&o.disabled, "no-history", false, "Do not record drift events in the history ConfigMap")
+}
+
+// recordDriftHistory appends a report's events to the spec's drift history.
+// Failures are reported on stderr and do not change the command's outcome.
+func recordDriftHistory(ctx context.Context, client kubernetes.Interface, options historyOptions, specName string, report *drift.DriftReport) {
+	if options.disabled || len(report.Events) == 0 {
+		return
+	}
+
+	storage := drift.NewConfigMapStorage(client, options.namespace, specName, options.maxEntries)
+	if err := storage.StoreReport(ctx, report); err != nil {
+		fmt.Fprintf(os.Stderr, "[WARN] Failed to record drift history: %v\n", err)
+	}
+}
+
+// parseSince parses a --since duration, accepting a "d" suffix for days
+func parseSince(value string) (time.Duration, error) {
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid number of days")
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// containsDriftType reports whether types includes driftType
+func containsDriftType(types []drift.DriftType, driftType drift.DriftType) bool {
+	for _, t := range types {
+		if t == driftType {
+			return true
 		}
 	}
+	return false
+}
+
+// Helper functions
 
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+func createClients(kubeconfigPath string) (kubernetes.Interface, dynamic.Interface, error) {
+	config, err := buildRestConfig(kubeconfigPath)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	client, err := createKubernetesClient(kubeconfigPath)
+	client, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -285,20 +476,31 @@ func createClients(kubeconfigPath string) (kubernetes.Interface, dynamic.Interfa
 	return client, dynamicClient, nil
 }
 
-func runContinuousMonitoring(ctx context.Context, client kubernetes.Interface, dynamicClient dynamic.Interface, clusterSpec *spec.ClusterSpecification, interval time.Duration) error {
+func runContinuousMonitoring(ctx context.Context, client kubernetes.Interface, dynamicClient dynamic.Interface, specFile string, interval time.Duration, enabledTypes []drift.DriftType) error {
+	watcher, err := spec.NewFileWatcher(specFile)
+	if err != nil {
+		return fmt.Errorf("failed to load spec: %w", err)
+	}
+	go func() {
+		if err := watcher.Run(ctx); err != nil && ctx.Err() == nil {
+			fmt.Printf("[WARN] Spec file watching stopped, edits will not be picked up: %v\n", err)
+		}
+	}()
+
 	fmt.Printf("Starting continuous drift monitoring (interval: %s)\n", interval)
+	fmt.Printf("Watching %s for changes\n", specFile)
 	fmt.Printf("Press Ctrl+C to stop\n\n")
 
 	monitor, err := drift.NewMonitor(client, dynamicClient, &drift.MonitorConfig{
 		Interval:      interval,
-		EnabledTypes:  []drift.DriftType{drift.DriftTypePolicy, drift.DriftTypeCompliance},
+		EnabledTypes:  enabledTypes,
 		AutoRemediate: false,
 	})
 	if err != nil {
 		return err
 	}
 
-	return monitor.Start(ctx, clusterSpec)
+	return monitor.StartWithSource(ctx, watcher)
 }
 
 func printDriftReport(report *drift.DriftReport, format, outputFile string) {
@@ -320,6 +522,21 @@ func printDriftReport(report *drift.DriftReport, format, outputFile string) {
 	fmt.Printf("└─────────────────────────────────────────┘\n")
 	fmt.Printf("\n")
 
+	if report.Baseline != nil {
+		fmt.Printf("Baseline: captured %s", report.Baseline.CapturedAt.Format(time.RFC3339))
+		if report.Baseline.Cluster.Name != "" {
+			fmt.Printf(" from cluster %s", report.Baseline.Cluster.Name)
+		}
+		fmt.Printf("\n\n")
+	}
+
+	for _, skipped := range report.Skipped {
+		fmt.Printf("[SKIP] %s\n", skipped.Reason)
+	}
+	if len(report.Skipped) > 0 {
+		fmt.Printf("\n")
+	}
+
 	if !report.Drift.Detected {
 		fmt.Printf("[OK] No drift detected\n")
 		fmt.Printf("\n")
@@ -336,6 +553,12 @@ func printDriftReport(report *drift.DriftReport, format, outputFile string) {
 	if report.Drift.Counts.Compliance > 0 {
 		fmt.Printf("Compliance Drift: %d\n", report.Drift.Counts.Compliance)
 	}
+	if report.Drift.Counts.RBAC > 0 {
+		fmt.Printf("RBAC Drift: %d\n", report.Drift.Counts.RBAC)
+	}
+	if report.Drift.Counts.Configuration > 0 {
+		fmt.Printf("Configuration Drift: %d\n", report.Drift.Counts.Configuration)
+	}
 	fmt.Printf("\n")
 
 	fmt.Printf("Drift Events:\n")
@@ -382,6 +605,14 @@ func printRemediationReport(report *drift.DriftReport, dryRun bool) {
 	fmt.Printf("Manual required: %d\n", manualCount)
 	fmt.Printf("\n")
 
+	if len(report.RemediationOrder) > 0 {
+		fmt.Printf("Applied order:\n")
+		for i, path := range report.RemediationOrder {
+			fmt.Printf("  %d. %s\n", i+1, path)
+		}
+		fmt.Printf("\n")
+	}
+
 	if remediatedCount > 0 {
 		fmt.Printf("Remediated:\n")
 		for _, event := range report.Events {
@@ -401,6 +632,52 @@ func printRemediationReport(report *drift.DriftReport, dryRun bool) {
 		}
 		fmt.Printf("\n")
 	}
+
+	if manualCount > 0 {
+		fmt.Printf("Manual remediation required:\n")
+		for _, event := range report.Events {
+			if event.Remediation != nil && event.Remediation.Status == drift.DriftStatusManualRequired {
+				fmt.Printf("  [MANUAL] %s: %s\n", event.Resource.Path, event.Remediation.Details)
+			}
+		}
+		fmt.Printf("\n")
+	}
+
+	for _, event := range report.Events {
+		if event.Remediation == nil {
+			continue
+		}
+		if event.Remediation.Manifest != "" {
+			fmt.Printf("Would create %s:\n", event.Resource.Path)
+			for _, line := range strings.Split(strings.TrimRight(event.Remediation.Manifest, "\n"), "\n") {
+				fmt.Printf("  %s\n", line)
+			}
+			fmt.Printf("\n")
+		}
+		if event.Remediation.Diff != "" {
+			fmt.Printf("Would update %s:\n", event.Resource.Path)
+			fmt.Print(event.Remediation.Diff)
+			fmt.Printf("\n")
+		}
+	}
+
+	for _, event := range report.Events {
+		if event.Remediation == nil || len(event.Remediation.Patches) == 0 {
+			continue
+		}
+		fmt.Printf("Workload patches (%s):\n", event.Resource.Path)
+		for _, patch := range event.Remediation.Patches {
+			switch {
+			case patch.Applied:
+				fmt.Printf("  [OK] %s %s/%s patched\n", patch.Kind, patch.Namespace, patch.Name)
+			case patch.Error != "":
+				fmt.Printf("  [FAIL] %s %s/%s: %s\n", patch.Kind, patch.Namespace, patch.Name, patch.Error)
+			default:
+				fmt.Printf("  %s\n", patch.Command())
+			}
+		}
+		fmt.Printf("\n")
+	}
 }
 
 func printDriftHistory(history *drift.DriftHistory, format string) {
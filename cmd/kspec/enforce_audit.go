@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cloudcwfranck/kspec/pkg/drift"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/spf13/cobra"
+)
+
+func enforceAuditCommand() *cobra.Command {
+	var (
+		specFiles      []string
+		kubeconfigPath string
+		outputFormat   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Report orphaned and drifted kspec-generated policies",
+		Long: `Audit lists the Kyverno ClusterPolicies generated by kspec that are deployed in the
+cluster and cross-references them against what the current spec would generate.
+
+Each generated policy is reported as:
+- in-sync:  deployed and matching the spec
+- drifted:  deployed but its content differs from what the spec generates
+- orphaned: deployed but no longer generated by the spec
+- missing:  generated by the spec but not deployed
+
+Audit is read-only; it never creates, updates or deletes policies.`,
+		Example: `  # Audit generated policies against the current spec
+  kspec enforce audit --spec cluster-spec.yaml
+
+  # Machine-readable audit report
+  kspec enforce audit --spec cluster-spec.yaml --output json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if outputFormat != "text" && outputFormat != "json" {
+				return fmt.Errorf("invalid output format %q: must be text or json", outputFormat)
+			}
+
+			clusterSpec, err := spec.LoadFromFiles(specFiles)
+			if err != nil {
+				return fmt.Errorf("failed to load spec: %w", err)
+			}
+
+			if err := spec.Validate(clusterSpec); err != nil {
+				return fmt.Errorf("spec validation failed: %w", err)
+			}
+
+			client, dynamicClient, err := createClients(kubeconfigPath)
+			if err != nil {
+				return fmt.Errorf("failed to create clients: %w", err)
+			}
+
+			detector := drift.NewDetector(client, dynamicClient)
+			audit, err := detector.AuditPolicies(context.Background(), clusterSpec)
+			if errors.Is(err, drift.ErrKyvernoNotInstalled) {
+				return fmt.Errorf("Kyverno is not installed, no generated policies to audit")
+			}
+			if err != nil {
+				return fmt.Errorf("policy audit failed: %w", err)
+			}
+
+			return printPolicyAudit(audit, outputFormat)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&specFiles, "spec", "s", nil, "Path to cluster spec file; repeat to layer specs, later files adding to earlier ones (required)")
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to kubeconfig file (default: $KUBECONFIG or ~/.kube/config)")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text|json")
+	cmd.MarkFlagRequired("spec")
+
+	return cmd
+}
+
+func printPolicyAudit(audit *drift.PolicyAudit, format string) error {
+	if format == "json" {
+		data, err := json.MarshalIndent(audit, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit report: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("\n")
+	fmt.Printf("┌─────────────────────────────────────────┐\n")
+	fmt.Printf("│ kspec v%s — Policy Audit           │\n", version)
+	fmt.Printf("└─────────────────────────────────────────┘\n")
+	fmt.Printf("\n")
+
+	fmt.Printf("Generated Policies Deployed: %d\n", audit.Summary.Deployed)
+	fmt.Printf("In sync: %d\n", audit.Summary.InSync)
+	fmt.Printf("Drifted: %d\n", audit.Summary.Drifted)
+	fmt.Printf("Orphaned: %d\n", audit.Summary.Orphaned)
+	fmt.Printf("Missing: %d\n", audit.Summary.Missing)
+	fmt.Printf("\n")
+
+	if len(audit.Policies) > 0 {
+		fmt.Printf("Policies:\n")
+		fmt.Printf("─────────\n")
+		for _, entry := range audit.Policies {
+			fmt.Printf("  [%s] %s\n", auditStatusTag(entry.Status), entry.Name)
+		}
+		fmt.Printf("\n")
+	}
+
+	if !audit.HasFindings() {
+		fmt.Printf("[OK] All generated policies match the spec\n\n")
+		return nil
+	}
+
+	if audit.Summary.Orphaned > 0 {
+		fmt.Printf("Orphaned policies are no longer generated by the spec; remove them with:\n")
+		fmt.Printf("  kubectl delete clusterpolicy <policy-name>\n")
+	}
+	if audit.Summary.Drifted > 0 || audit.Summary.Missing > 0 {
+		fmt.Printf("Restore drifted and missing policies with:\n")
+		fmt.Printf("  kspec enforce --spec <file>\n")
+	}
+	fmt.Printf("\n")
+
+	return nil
+}
+
+// auditStatusTag returns the bracketed tag printed for an audit status.
+func auditStatusTag(status drift.PolicyAuditStatus) string {
+	switch status {
+	case drift.PolicyAuditInSync:
+		return "OK"
+	case drift.PolicyAuditDrifted:
+		return "DRIFT"
+	case drift.PolicyAuditOrphaned:
+		return "ORPHAN"
+	case drift.PolicyAuditMissing:
+		return "MISSING"
+	default:
+		return string(status)
+	}
+}
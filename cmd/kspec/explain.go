@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/scanner/checks"
+	"github.com/spf13/cobra"
+)
+
+func newExplainCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain [check]",
+		Short: "Describe what a compliance check verifies",
+		Long: `Explain describes a compliance check: what it verifies and why, the spec
+fields it reads, the severity it assigns to failures and an example fix.
+
+Without a check name, explain lists all checks with a one-line summary.`,
+		Example: `  # List all checks
+  kspec explain
+
+  # Describe a check named in a scan failure
+  kspec explain workload.security`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Checks are only described, so none needs a cluster connection
+			checkList := checks.All(nil, "")
+
+			if len(args) == 0 {
+				printCheckList(checkList)
+				return nil
+			}
+
+			for _, check := range checkList {
+				if check.Name() == args[0] {
+					printCheckExplanation(check)
+					return nil
+				}
+			}
+			return fmt.Errorf("unknown check '%s' (run kspec explain to list checks)", args[0])
+		},
+	}
+
+	return cmd
+}
+
+// printCheckList prints every check with its one-line summary.
+func printCheckList(checkList []scanner.Check) {
+	sorted := append([]scanner.Check{}, checkList...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name() < sorted[j].Name() })
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tSEVERITY\tSUMMARY")
+	for _, check := range sorted {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", check.Name(), check.Severity(), scanner.Summary(check))
+	}
+	w.Flush()
+
+	fmt.Printf("\nRun 'kspec explain <check>' for details.\n")
+}
+
+// printCheckExplanation prints the description, spec fields, severity and
+// remediation example of a check.
+func printCheckExplanation(check scanner.Check) {
+	fmt.Printf("%s\n", check.Name())
+	fmt.Printf("%s\n\n", strings.Repeat("─", len(check.Name())))
+	fmt.Printf("%s\n\n", check.Description())
+
+	fmt.Printf("Spec fields:\n")
+	for _, field := range check.SpecFields() {
+		fmt.Printf("  spec.%s\n", field)
+	}
+	fmt.Printf("\n")
+
+	fmt.Printf("Severity: %s (override with spec.severityOverrides)\n\n", check.Severity())
+
+	fmt.Printf("Remediation:\n")
+	for _, line := range strings.Split(check.Remediation(), "\n") {
+		if line == "" {
+			fmt.Printf("\n")
+			continue
+		}
+		fmt.Printf("  %s\n", line)
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudcwfranck/kspec/config"
+	"github.com/cloudcwfranck/kspec/pkg/alerts"
+	"github.com/cloudcwfranck/kspec/pkg/enforcer"
+	"github.com/cloudcwfranck/kspec/pkg/reporter"
+	"github.com/cloudcwfranck/kspec/pkg/scanner/checks"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+)
+
+func newExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Print kspec install manifests",
+		Long: `Export writes the install manifests embedded in this kspec binary to stdout,
+so the operator can be installed without a checkout of the repository.
+
+Apply them in order: CRDs, then RBAC, then the manager.
+
+Export bundle packages a spec for GitOps instead of applying it with enforce.
+
+Export oscal-component describes the controls kspec implements as an OSCAL
+component-definition, for registering kspec in an OSCAL-based GRC pipeline.`,
+		Example: `  # Install the operator
+  kspec export crds | kubectl apply -f -
+  kspec export rbac | kubectl apply -f -
+  kspec export manager | kubectl apply -f -
+
+  # Write a kustomize bundle for a GitOps repository
+  kspec export bundle --spec cluster-spec.yaml --output-dir clusters/prod/kspec
+
+  # Describe the controls kspec implements for a spec's compliance frameworks
+  kspec export oscal-component --spec cluster-spec.yaml > component-definition.json`,
+	}
+
+	cmd.AddCommand(newExportManifestCmd("crds", "Print the kspec CustomResourceDefinitions", config.CRDs))
+	cmd.AddCommand(newExportManifestCmd("rbac", "Print the operator namespace, ServiceAccount, ClusterRole and ClusterRoleBinding", config.RBAC))
+	cmd.AddCommand(newExportManifestCmd("manager", "Print the operator namespace, Deployment and PodDisruptionBudget", config.Manager))
+	cmd.AddCommand(newExportBundleCmd())
+	cmd.AddCommand(newExportOSCALComponentCmd())
+
+	return cmd
+}
+
+// newExportManifestCmd creates a subcommand that prints one set of embedded manifests.
+func newExportManifestCmd(name, short string, manifests func() ([]byte, error)) *cobra.Command {
+	return &cobra.Command{
+		Use:   name,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := manifests()
+			if err != nil {
+				return fmt.Errorf("failed to load %s manifests: %w", name, err)
+			}
+			_, err = cmd.OutOrStdout().Write(data)
+			return err
+		},
+	}
+}
+
+func newExportBundleCmd() *cobra.Command {
+	var (
+		specFiles   []string
+		outputDir   string
+		namespace   string
+		labels      map[string]string
+		alertConfig string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Write a GitOps-ready manifest bundle for a specification",
+		Long: `Bundle writes the Kyverno policies generated from a kspec specification, a
+ClusterSpecification resource and, with --alert-config, an AlertConfig to a
+directory, one manifest per resource, together with a kustomization.yaml listing
+them. Commit the directory and let Argo CD or Flux apply it instead of running
+kspec enforce.
+
+--namespace is set on namespaced resources (the AlertConfig); --labels are added
+to every resource.`,
+		Example: `  # Write a bundle
+  kspec export bundle --spec cluster-spec.yaml --output-dir clusters/prod/kspec
+
+  # Stamp common metadata and include alerting
+  kspec export bundle --spec cluster-spec.yaml --output-dir clusters/prod/kspec \
+    --namespace kspec-system --labels app.kubernetes.io/part-of=platform \
+    --alert-config alerts.yaml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusterSpec, err := spec.LoadFromFiles(specFiles)
+			if err != nil {
+				return fmt.Errorf("failed to load spec: %w", err)
+			}
+			if err := spec.Validate(clusterSpec); err != nil {
+				return fmt.Errorf("spec validation failed: %w", err)
+			}
+
+			opts := enforcer.BundleOptions{Namespace: namespace, Labels: labels}
+			if alertConfig != "" {
+				opts.AlertConfig, err = alerts.LoadConfigFile(alertConfig)
+				if err != nil {
+					return fmt.Errorf("invalid --alert-config: %w", err)
+				}
+			}
+
+			files, err := enforcer.GenerateBundle(clusterSpec, opts)
+			if err != nil {
+				return fmt.Errorf("failed to generate bundle: %w", err)
+			}
+			if err := enforcer.WriteBundle(outputDir, files); err != nil {
+				return fmt.Errorf("failed to write bundle: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "[OK] Wrote %d manifests to %s\n", len(files)-1, outputDir)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&specFiles, "spec", "s", nil, "Path to cluster spec file; repeat to layer specs, later files adding to earlier ones (required)")
+	cmd.Flags().StringVarP(&outputDir, "output-dir", "o", "", "Directory to write the bundle to (required)")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace for namespaced resources in the bundle")
+	cmd.Flags().StringToStringVar(&labels, "labels", nil, "Labels to add to every resource, e.g. team=platform,env=prod")
+	cmd.Flags().StringVar(&alertConfig, "alert-config", "", "AlertConfig file to include in the bundle")
+	cmd.MarkFlagRequired("spec")
+	cmd.MarkFlagRequired("output-dir")
+
+	return cmd
+}
+
+func newExportOSCALComponentCmd() *cobra.Command {
+	var specFiles []string
+
+	cmd := &cobra.Command{
+		Use:   "oscal-component",
+		Short: "Print an OSCAL component-definition of the controls kspec implements",
+		Long: `OSCAL-component writes an OSCAL component-definition describing kspec as a
+software component. Each compliance framework in the spec's compliance section
+becomes a control implementation, with an implementation statement per control
+derived from the descriptions and spec fields of its mapped checks. Checks that
+no control maps are listed as controls named after the check.
+
+Without --spec, every check is listed as a control of its own.`,
+		Example: `  # Component definition for a spec's compliance frameworks
+  kspec export oscal-component --spec cluster-spec.yaml > component-definition.json
+
+  # Component definition of every kspec check
+  kspec export oscal-component`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var clusterSpec *spec.ClusterSpecification
+			if len(specFiles) > 0 {
+				var err error
+				clusterSpec, err = spec.LoadFromFiles(specFiles)
+				if err != nil {
+					return fmt.Errorf("failed to load spec: %w", err)
+				}
+				if err := spec.Validate(clusterSpec); err != nil {
+					return fmt.Errorf("spec validation failed: %w", err)
+				}
+			}
+
+			// Checks are only described, so none needs a cluster connection
+			r := reporter.NewOSCALComponentReporter(cmd.OutOrStdout(), checks.All(nil, ""))
+			return r.Report(clusterSpec)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&specFiles, "spec", "s", nil, "Path to cluster spec file whose compliance frameworks map controls to checks; repeat to layer specs")
+
+	return cmd
+}
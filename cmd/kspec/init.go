@@ -101,7 +101,7 @@ func runInitWizard(kubeconfigPath, outputFile string, autoYes bool, template str
 
 	// Step 4: Scan current cluster
 	fmt.Println("🔍 Step 4: Scanning cluster for compliance...")
-	scanResults, err := scanCluster(ctx, client, clusterSpec)
+	scanResults, err := scanCluster(ctx, client, dynamicClient, clusterSpec)
 	if err != nil {
 		fmt.Printf("   ⚠ Scan failed: %v\n", err)
 	} else {
@@ -159,6 +159,7 @@ func detectCluster(kubeconfigPath string) (kubernetes.Interface, dynamic.Interfa
 	if err != nil {
 		return nil, nil, "", err
 	}
+	applyClientRateLimits(config)
 
 	client, err := kubernetes.NewForConfig(config)
 	if err != nil {
@@ -241,7 +242,8 @@ func generateProductionSpec(clusterVersion string) *spec.ClusterSpecification {
 				Warn:    "restricted",
 			},
 			Network: &spec.NetworkSpec{
-				DefaultDeny: true,
+				DefaultDeny:      true,
+				RequireDNSEgress: true,
 			},
 			Workloads: &spec.WorkloadsSpec{
 				Containers: &spec.ContainerSpec{
@@ -343,18 +345,8 @@ func saveSpec(clusterSpec *spec.ClusterSpecification, filename string) error {
 	return os.WriteFile(filename, data, 0644)
 }
 
-func scanCluster(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.ScanResult, error) {
-	checkList := []scanner.Check{
-		&checks.KubernetesVersionCheck{},
-		&checks.PodSecurityStandardsCheck{},
-		&checks.NetworkPolicyCheck{},
-		&checks.WorkloadSecurityCheck{},
-		&checks.RBACCheck{},
-		&checks.AdmissionCheck{},
-		&checks.ObservabilityCheck{},
-	}
-
-	s := scanner.NewScanner(client, checkList)
+func scanCluster(ctx context.Context, client kubernetes.Interface, dynamicClient dynamic.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.ScanResult, error) {
+	s := scanner.NewScanner(client, checks.All(dynamicClient, ""))
 	return s.Scan(ctx, clusterSpec)
 }
 
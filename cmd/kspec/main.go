@@ -5,8 +5,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/cloudcwfranck/kspec/pkg/enforcer"
+	"github.com/cloudcwfranck/kspec/pkg/metrics"
 	"github.com/cloudcwfranck/kspec/pkg/reporter"
 	"github.com/cloudcwfranck/kspec/pkg/scanner"
 	"github.com/cloudcwfranck/kspec/pkg/scanner/checks"
@@ -15,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/yaml"
 )
@@ -27,6 +31,13 @@ var (
 	builtBy = "manual"
 )
 
+var (
+	// Client-side rate limits applied to every Kubernetes client the CLI builds.
+	// Defaults match controller-runtime.
+	clientQPS   float32
+	clientBurst int
+)
+
 func main() {
 	if err := newRootCmd().Execute(); err != nil {
 		os.Exit(1)
@@ -41,14 +52,27 @@ func newRootCmd() *cobra.Command {
 enforces security policies, and generates compliance evidence for audits.`,
 	}
 
+	rootCmd.PersistentFlags().Float32Var(&clientQPS, "qps", 20, "Maximum queries per second to the Kubernetes API server")
+	rootCmd.PersistentFlags().IntVar(&clientBurst, "burst", 30, "Maximum burst of queries to the Kubernetes API server")
+
 	rootCmd.AddCommand(newVersionCmd())
 	rootCmd.AddCommand(newValidateCmd())
+	rootCmd.AddCommand(newDiffCmd())
 	rootCmd.AddCommand(newScanCmd())
+	rootCmd.AddCommand(newExplainCmd())
+	rootCmd.AddCommand(newScheduleCmd())
 	rootCmd.AddCommand(newEnforceCmd())
 	rootCmd.AddCommand(driftCommand())
+	rootCmd.AddCommand(snapshotCommand())
 	rootCmd.AddCommand(initCommand())
 	rootCmd.AddCommand(clusterCmd)
 	rootCmd.AddCommand(dashboardCmd)
+	rootCmd.AddCommand(newStatusCmd())
+	rootCmd.AddCommand(newReportCmd())
+	rootCmd.AddCommand(newPolicyCmd())
+	rootCmd.AddCommand(newWebhookCmd())
+	rootCmd.AddCommand(newExportCmd())
+	rootCmd.AddCommand(uninstallCommand())
 
 	return rootCmd
 }
@@ -78,21 +102,33 @@ func newVersionCmd() *cobra.Command {
 
 func newValidateCmd() *cobra.Command {
 	var specFile string
+	var strict bool
 
 	cmd := &cobra.Command{
 		Use:   "validate",
-		Short: "Validate spec file syntax",
-		Long:  `Validate checks that a cluster specification file is syntactically correct.`,
+		Short: "Validate spec file syntax and consistency",
+		Long: `Validate checks that a cluster specification file is syntactically correct
+and internally consistent, e.g. that minVersion does not exceed maxVersion and no
+registry is both allowed and blocked. All problems are reported at once, each
+naming the offending field.
+
+With --strict, keys that are not part of the spec schema are rejected with their
+line number instead of being ignored, so a misspelled field cannot silently
+leave a requirement unenforced. Strict validation is recommended in CI.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Load spec
-			clusterSpec, err := spec.LoadFromFile(specFile)
+			load := spec.LoadFromFile
+			if strict {
+				load = spec.LoadFromFileStrict
+			}
+			clusterSpec, err := load(specFile)
 			if err != nil {
 				return fmt.Errorf("failed to load spec: %w", err)
 			}
 
-			// Validate spec
+			// Validate spec, listing every problem on its own line
 			if err := spec.Validate(clusterSpec); err != nil {
-				return fmt.Errorf("spec validation failed: %w", err)
+				return fmt.Errorf("spec validation failed:\n  %s", strings.ReplaceAll(err.Error(), "\n", "\n  "))
 			}
 
 			fmt.Printf("✓ Spec file is valid\n")
@@ -103,6 +139,7 @@ func newValidateCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&specFile, "spec", "s", "", "Path to cluster spec file (required)")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Reject keys that are not part of the spec schema")
 	cmd.MarkFlagRequired("spec")
 
 	return cmd
@@ -110,16 +147,47 @@ func newValidateCmd() *cobra.Command {
 
 func newScanCmd() *cobra.Command {
 	var (
-		specFile       string
+		specFiles      []string
 		kubeconfigPath string
+		clusterTarget  string
+		targetNS       string
 		outputFormat   string
+		sarifLevels    string
+		reportSink     string
+		assumeVersion  string
+		explain        bool
+		onlyFailures   bool
+		watch          bool
+		watchInterval  time.Duration
+		checkTimeout   time.Duration
+		scanTimeout    time.Duration
+		timeoutFlags   map[string]string
+		concurrency    int
+		alertConfig    string
+		failOn         string
+		failOnSkip     []string
+		checkNames     []string
+		skipChecks     []string
+		framework      string
+		mappingFiles   []string
+		pushGateway    string
+		metricsJob     string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "scan",
 		Short: "Scan cluster against specification",
 		Long: `Scan validates a Kubernetes cluster against a kspec specification file.
-This operation is read-only and safe to run in production.`,
+This operation is read-only and safe to run in production.
+
+Exit codes:
+  0  No failure at or above the --fail-on severity, and every check evaluated
+  1  A check failed at or above the --fail-on severity, or a check was skipped
+     for a --fail-on-skip reason
+  2  One or more checks could not be evaluated (e.g. permission errors)
+
+--fail-on defaults to low, so any failure exits 1. Failures without a severity
+count as low. With --fail-on none, scan only reports and always exits 0.`,
 		Example: `  # Scan with JSON output
   kspec scan --spec cluster-spec.yaml --output json
 
@@ -129,80 +197,353 @@ This operation is read-only and safe to run in production.`,
   # Scan with SARIF security report
   kspec scan --spec cluster-spec.yaml --output sarif > results.sarif
 
+  # Report medium severity failures as SARIF errors
+  kspec scan --spec cluster-spec.yaml --output sarif --sarif-level medium=error > results.sarif
+
+  # Archive a timestamped JSON report in addition to the chosen output
+  kspec scan --spec cluster-spec.yaml --report-sink file:///var/lib/kspec/reports
+
+  # Check for APIs removed by an upgrade target before upgrading
+  kspec scan --spec cluster-spec.yaml --assume-version 1.29
+
+  # Emit only failing results (summary counts still cover every check)
+  kspec scan --spec cluster-spec.yaml --output json --only-failures > failures.json
+
+  # Give the deprecated API check longer than the default per-check timeout
+  kspec scan --spec cluster-spec.yaml --check-timeout-override kubernetes.deprecated-apis=5m
+
+  # Bound the whole scan; checks still running after 5 minutes are reported as errors
+  kspec scan --spec cluster-spec.yaml --timeout 5m
+
+  # Expand remediation for failing checks into step-by-step playbooks
+  kspec scan --spec cluster-spec.yaml --explain-failures
+
   # Scan with Markdown documentation
   kspec scan --spec cluster-spec.yaml --output markdown > COMPLIANCE.md
 
+  # Flat CSV table of every check result for spreadsheet audits
+  kspec scan --spec cluster-spec.yaml --output csv > results.csv
+
+  # Self-contained HTML report to share without a cluster or dashboard
+  kspec scan --spec cluster-spec.yaml --output html > report.html
+
   # Scan with custom kubeconfig
-  kspec scan --spec cluster-spec.yaml --kubeconfig ~/.kube/prod-config`,
+  kspec scan --spec cluster-spec.yaml --kubeconfig ~/.kube/prod-config
+
+  # Scan a fleet member through its ClusterTarget on the management cluster
+  kspec scan --spec cluster-spec.yaml --cluster-target prod-east --namespace kspec-system
+
+  # Re-scan every minute and whenever the spec file is edited
+  kspec scan --spec cluster-spec.yaml --watch --watch-interval=1m
+
+  # Send a summary to the Slack and webhook notifiers of an AlertConfig file
+  kspec scan --spec cluster-spec.yaml --alert-config alerts.yaml
+
+  # Record compliance metrics from a CI run in a Prometheus Pushgateway
+  kspec scan --spec cluster-spec.yaml --push-gateway http://pushgateway:9091
+
+  # Fast partial scan for pre-merge CI
+  kspec scan --spec cluster-spec.yaml --checks kubernetes.version,workload.security
+
+  # Run everything except the RBAC checks
+  kspec scan --spec cluster-spec.yaml --skip-checks 'rbac.*'
+
+  # Run only the checks mapped to CIS Kubernetes Benchmark controls
+  kspec scan --spec cluster-spec.yaml --framework cis
+
+  # Extend the built-in check-to-control mappings with your own
+  kspec scan --spec cluster-spec.yaml --control-mappings controls.yaml
+
+  # Fail when a check was skipped because an optional input was not readable
+  kspec scan --spec cluster-spec.yaml --fail-on-skip PermissionDenied
+
+  # Fail the pipeline only on high and critical failures
+  kspec scan --spec cluster-spec.yaml --fail-on high
+
+  # Report-only run that never fails the pipeline
+  kspec scan --spec cluster-spec.yaml --fail-on none`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
-			// Load spec
-			clusterSpec, err := spec.LoadFromFile(specFile)
+			// Parse SARIF level mapping before scanning so mistakes fail fast
+			levels, err := reporter.ParseSARIFLevelMapping(sarifLevels)
 			if err != nil {
-				return fmt.Errorf("failed to load spec: %w", err)
+				return fmt.Errorf("invalid --sarif-level: %w", err)
 			}
 
-			// Validate spec
-			if err := spec.Validate(clusterSpec); err != nil {
-				return fmt.Errorf("spec validation failed: %w", err)
+			failOnSkipReasons, err := scanner.ParseSkipReasons(failOnSkip)
+			if err != nil {
+				return fmt.Errorf("invalid --fail-on-skip: %w", err)
 			}
 
-			// Create Kubernetes client
-			client, err := createKubernetesClient(kubeconfigPath)
+			// An empty threshold means --fail-on none
+			var failThreshold scanner.Severity
+			if !strings.EqualFold(failOn, "none") {
+				failThreshold, err = scanner.ParseSeverity(failOn)
+				if err != nil {
+					return fmt.Errorf("invalid --fail-on: %w", err)
+				}
+			}
+
+			// Load extra control mappings before scanning so bad files fail fast
+			var controlMappings []*spec.ComplianceSpec
+			for _, path := range mappingFiles {
+				mappings, err := scanner.LoadControlMappingsFile(path)
+				if err != nil {
+					return fmt.Errorf("invalid --control-mappings: %w", err)
+				}
+				controlMappings = append(controlMappings, mappings)
+			}
+
+			// Resolve report sink before scanning so configuration errors fail fast
+			reporter.RegisterSinkScheme("configmap", reporter.ConfigMapSinkFactory(func() (kubernetes.Interface, error) {
+				return createKubernetesClient(kubeconfigPath)
+			}))
+			var sink reporter.Sink
+			if reportSink != "" {
+				sink, err = reporter.NewSink(reportSink)
+				if err != nil {
+					return fmt.Errorf("invalid --report-sink: %w", err)
+				}
+			}
+
+			// Build alert notifiers before scanning so configuration errors fail fast
+			if alertConfig != "" && watch {
+				return fmt.Errorf("--alert-config is not supported with --watch")
+			}
+			alertManager, err := loadAlertManager(alertConfig)
 			if err != nil {
-				return fmt.Errorf("failed to create Kubernetes client: %w", err)
+				return fmt.Errorf("invalid --alert-config: %w", err)
+			}
+
+			// Load spec, reloading it on edits in watch mode
+			var source spec.Source
+			if watch {
+				if len(specFiles) > 1 {
+					return fmt.Errorf("--watch supports a single --spec file")
+				}
+				watcher, err := spec.NewFileWatcher(specFiles[0])
+				if err != nil {
+					return fmt.Errorf("failed to load spec: %w", err)
+				}
+				// Keep reload messages out of machine-readable report output
+				watcher.Logf = func(format string, args ...interface{}) {
+					fmt.Fprintf(os.Stderr, format, args...)
+				}
+				go func() {
+					if err := watcher.Run(ctx); err != nil && ctx.Err() == nil {
+						fmt.Fprintf(os.Stderr, "[WARN] Spec file watching stopped, edits will not be picked up: %v\n", err)
+					}
+				}()
+				source = watcher
+			} else {
+				clusterSpec, err := spec.LoadFromFiles(specFiles)
+				if err != nil {
+					return fmt.Errorf("failed to load spec: %w", err)
+				}
+
+				// Validate spec
+				if err := spec.Validate(clusterSpec); err != nil {
+					return fmt.Errorf("spec validation failed: %w", err)
+				}
+				source = spec.StaticSource(clusterSpec)
+			}
+
+			if cmd.Flags().Changed("namespace") && clusterTarget == "" {
+				return fmt.Errorf("--namespace requires --cluster-target")
+			}
+
+			// Create Kubernetes clients, for a ClusterTarget's remote cluster if requested
+			var client kubernetes.Interface
+			var dynamicClient dynamic.Interface
+			if clusterTarget != "" {
+				client, dynamicClient, err = createClusterTargetClients(ctx, kubeconfigPath, targetNS, clusterTarget)
+				if err != nil {
+					return err
+				}
+			} else {
+				client, err = createKubernetesClient(kubeconfigPath)
+				if err != nil {
+					return fmt.Errorf("failed to create Kubernetes client: %w", err)
+				}
+
+				// Create dynamic client for deprecated API detection
+				config, err := buildRestConfig(kubeconfigPath)
+				if err != nil {
+					return fmt.Errorf("failed to build config: %w", err)
+				}
+				dynamicClient, err = dynamic.NewForConfig(config)
+				if err != nil {
+					return fmt.Errorf("failed to create dynamic client: %w", err)
+				}
 			}
 
 			// Create scanner with checks
-			checkList := []scanner.Check{
-				&checks.KubernetesVersionCheck{},
-				&checks.PodSecurityStandardsCheck{},
-				&checks.NetworkPolicyCheck{},
-				&checks.WorkloadSecurityCheck{},
-				&checks.RBACCheck{},
-				&checks.AdmissionCheck{},
-				&checks.ObservabilityCheck{},
-			}
-			s := scanner.NewScanner(client, checkList)
-
-			// Run scan
-			fmt.Fprintf(os.Stderr, "Scanning cluster...\n")
-			result, err := s.Scan(ctx, clusterSpec)
+			checkList := checks.All(dynamicClient, assumeVersion)
+
+			timeouts, err := parseCheckTimeouts(checkTimeout, timeoutFlags, checkList)
+			if err != nil {
+				return err
+			}
+			if concurrency < 1 {
+				return fmt.Errorf("invalid --concurrency %d: must be at least 1", concurrency)
+			}
+			if scanTimeout < 0 {
+				return fmt.Errorf("invalid --timeout %s: must not be negative", scanTimeout)
+			}
+
+			// Spec references are validated against every check, so a partial scan
+			// accepts the same specs as a full one
+			selectedChecks, err := scanner.SelectChecks(checkList, checkNames, skipChecks)
 			if err != nil {
-				return fmt.Errorf("scan failed: %w", err)
+				return fmt.Errorf("invalid check selection: %w", err)
 			}
 
-			// Output results
-			switch outputFormat {
-			case "json":
-				r := reporter.NewJSONReporter(os.Stdout)
-				if err := r.Report(result); err != nil {
-					return fmt.Errorf("failed to output results: %w", err)
+			// scanOnce scans the cluster against a spec and writes the report
+			scanOnce := func(clusterSpec *spec.ClusterSpecification) (*scanner.ScanResult, error) {
+				if err := scanner.ValidateSeverityOverrides(clusterSpec.Spec.SeverityOverrides, checkList); err != nil {
+					return nil, fmt.Errorf("spec validation failed: %w", err)
+				}
+				if err := scanner.ValidateScoringWeights(clusterSpec.Spec.Scoring, checkList); err != nil {
+					return nil, fmt.Errorf("spec validation failed: %w", err)
+				}
+
+				// The spec's compliance section may map further checks to the framework
+				checksToRun := selectedChecks
+				if framework != "" {
+					index := scanner.NewControlIndexForSpec(clusterSpec, controlMappings...)
+					mapped, err := index.ChecksForFramework(selectedChecks, framework)
+					if err != nil {
+						return nil, fmt.Errorf("invalid --framework: %w", err)
+					}
+					checksToRun = mapped
+				}
+
+				s := scanner.NewScannerWithOptions(client, checksToRun, scanner.ScannerOptions{Concurrency: concurrency}).
+					WithCheckTimeouts(timeouts).
+					WithScanTimeout(scanTimeout).
+					WithControlMappings(controlMappings...)
+
+				// Run scan
+				fmt.Fprintf(os.Stderr, "Scanning cluster...\n")
+				result, err := s.Scan(ctx, clusterSpec)
+				if err != nil {
+					return nil, fmt.Errorf("scan failed: %w", err)
+				}
+				if clusterTarget != "" {
+					result.Metadata.Cluster.Name = clusterTarget
+				}
+
+				if explain {
+					explainFailures(result, checkList)
+				}
+
+				// Output results, dropping passed and skipped checks if requested.
+				// Exit codes and the archived report still use the full result.
+				output := result
+				if onlyFailures {
+					output = reporter.OnlyFailures(result)
 				}
-			case "oscal":
-				r := reporter.NewOSCALReporter(os.Stdout)
-				if err := r.Report(result); err != nil {
-					return fmt.Errorf("failed to output results: %w", err)
+
+				switch outputFormat {
+				case "json":
+					r := reporter.NewJSONReporter(os.Stdout)
+					if err := r.Report(output); err != nil {
+						return nil, fmt.Errorf("failed to output results: %w", err)
+					}
+				case "oscal":
+					r := reporter.NewOSCALReporter(os.Stdout)
+					if err := r.Report(output); err != nil {
+						return nil, fmt.Errorf("failed to output results: %w", err)
+					}
+				case "sarif":
+					r := reporter.NewSARIFReporterWithLevels(os.Stdout, levels)
+					if err := r.Report(output); err != nil {
+						return nil, fmt.Errorf("failed to output results: %w", err)
+					}
+				case "markdown":
+					r := reporter.NewMarkdownReporter(os.Stdout)
+					if err := r.Report(output); err != nil {
+						return nil, fmt.Errorf("failed to output results: %w", err)
+					}
+				case "csv":
+					r := reporter.NewCSVReporter(os.Stdout)
+					if err := r.Report(output); err != nil {
+						return nil, fmt.Errorf("failed to output results: %w", err)
+					}
+				case "html":
+					r := reporter.NewHTMLReporter(os.Stdout)
+					if err := r.Report(output); err != nil {
+						return nil, fmt.Errorf("failed to output results: %w", err)
+					}
+				case "text":
+					printTextReport(output)
+				default:
+					return nil, fmt.Errorf("unsupported output format: %s (supported: text, json, oscal, sarif, markdown, csv, html)", outputFormat)
 				}
-			case "sarif":
-				r := reporter.NewSARIFReporter(os.Stdout)
-				if err := r.Report(result); err != nil {
-					return fmt.Errorf("failed to output results: %w", err)
+
+				// Archive report
+				if sink != nil {
+					name, err := reporter.WriteReport(ctx, sink, result)
+					if err != nil {
+						return nil, fmt.Errorf("failed to archive report: %w", err)
+					}
+					fmt.Fprintf(os.Stderr, "Report archived to %s\n", name)
 				}
-			case "markdown":
-				r := reporter.NewMarkdownReporter(os.Stdout)
-				if err := r.Report(result); err != nil {
-					return fmt.Errorf("failed to output results: %w", err)
+
+				// Push metrics; a failed push does not change the scan outcome
+				if pushGateway != "" {
+					job := metricsJob
+					if job == "" {
+						job = result.Metadata.Spec.Name
+					}
+					err := metrics.PushComplianceMetrics(ctx, pushGateway, job,
+						result.Metadata.Cluster.Name, result.Metadata.Cluster.UID, result.Metadata.Spec.Name,
+						result.Summary.TotalChecks, result.Summary.Passed, result.Summary.Failed, result.Summary.WeightedScore)
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "[WARN] %v\n", err)
+					} else {
+						fmt.Fprintf(os.Stderr, "Metrics pushed to %s (job %s)\n", pushGateway, job)
+					}
 				}
-			case "text":
-				printTextReport(result)
-			default:
-				return fmt.Errorf("unsupported output format: %s (supported: text, json, oscal, sarif, markdown)", outputFormat)
+
+				return result, nil
+			}
+
+			// Watch mode - re-scan periodically and on spec edits
+			if watch {
+				return watchScans(ctx, source, watchInterval, scanOnce)
+			}
+
+			result, err := scanOnce(source.Current())
+			if err != nil {
+				return err
+			}
+
+			sendSummaryAlert(ctx, alertManager, scanSummaryAlert(result))
+
+			// Report-only: never fail the caller
+			if failThreshold == "" {
+				return nil
 			}
 
-			// Exit with code 1 if there are failures
-			if result.Summary.Failed > 0 {
+			// Exit with code 1 if there are failures at or above the threshold
+			if len(scanner.FailedAtOrAbove(result.Results, failThreshold)) > 0 {
+				os.Exit(1)
+			}
+
+			// Exit with code 2 if checks could not be evaluated, so a permissions
+			// gap is not mistaken for compliance
+			if result.Summary.Errors > 0 {
+				os.Exit(2)
+			}
+
+			// Exit with code 1 if checks were skipped for a reason the caller
+			// treats as non-compliant
+			if skipped := scanner.SkippedFor(result.Results, failOnSkipReasons); len(skipped) > 0 {
+				for _, r := range skipped {
+					fmt.Fprintf(os.Stderr, "Check %s skipped (%s): %s\n", r.Name, r.SkipReason, r.Message)
+				}
 				os.Exit(1)
 			}
 
@@ -210,21 +551,156 @@ This operation is read-only and safe to run in production.`,
 		},
 	}
 
-	cmd.Flags().StringVarP(&specFile, "spec", "s", "", "Path to cluster spec file (required)")
-	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to kubeconfig file (default: $KUBECONFIG or ~/.kube/config)")
-	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text|json|oscal|sarif|markdown")
+	cmd.Flags().StringArrayVarP(&specFiles, "spec", "s", nil, "Path to cluster spec file; repeat to layer specs, later files adding to earlier ones (required)")
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "",
+		"Path to kubeconfig file, of the management cluster with --cluster-target (default: $KUBECONFIG or ~/.kube/config)")
+	cmd.Flags().StringVar(&clusterTarget, "cluster-target", "",
+		"Scan the remote cluster of this ClusterTarget, connecting with its credentials Secret on the management cluster")
+	cmd.Flags().StringVarP(&targetNS, "namespace", "n", "kspec-system", "Namespace of the --cluster-target ClusterTarget")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text|json|oscal|sarif|markdown|csv|html")
+	cmd.Flags().StringVar(&sarifLevels, "sarif-level", reporter.DefaultSARIFLevels,
+		"Severity to SARIF level mapping as severity=level pairs (levels: error|warning|note); unlisted severities keep their default")
+	cmd.Flags().StringVar(&reportSink, "report-sink", "",
+		"Also write a timestamped JSON report to this sink (e.g. file:///path, configmap://namespace/name)")
+	cmd.Flags().StringVar(&assumeVersion, "assume-version", "",
+		"Kubernetes version to check for deprecated and removed API usage (default: the spec's kubernetes.maxVersion)")
+	cmd.Flags().BoolVar(&explain, "explain-failures", false,
+		"Expand the remediation of each failing check into a step-by-step playbook with example patches")
+	cmd.Flags().BoolVar(&onlyFailures, "only-failures", false,
+		"Report only failed, warning and error results; summary counts still cover every check")
+	cmd.Flags().BoolVar(&watch, "watch", false, "Re-scan continuously, reloading the spec file when it is edited")
+	cmd.Flags().DurationVar(&watchInterval, "watch-interval", 5*time.Minute, "Re-scan interval for watch mode")
+	cmd.Flags().DurationVar(&checkTimeout, "check-timeout", scanner.DefaultCheckTimeout,
+		"Maximum time a single check may run before it is reported as an error (0 disables)")
+	cmd.Flags().DurationVar(&scanTimeout, "timeout", 0,
+		"Maximum time the whole scan may run; checks unfinished at the deadline are reported as errors (0 disables)")
+	cmd.Flags().StringToStringVar(&timeoutFlags, "check-timeout-override", nil,
+		"Per-check timeouts as check=duration pairs (e.g. kubernetes.deprecated-apis=5m)")
+	cmd.Flags().IntVar(&concurrency, "concurrency", scanner.DefaultConcurrency,
+		"Maximum number of checks run at once (1 runs checks one after another)")
+	cmd.Flags().StringVar(&alertConfig, "alert-config", "",
+		"AlertConfig file whose Slack and webhook notifiers receive a summary after the scan")
+	cmd.Flags().StringVar(&pushGateway, "push-gateway", "",
+		"Prometheus Pushgateway URL to push the scan's compliance metrics to (e.g. http://pushgateway:9091)")
+	cmd.Flags().StringVar(&metricsJob, "metrics-job", "",
+		"Pushgateway job label for --push-gateway (default: the spec name)")
+	cmd.Flags().StringSliceVar(&checkNames, "checks", nil,
+		"Run only these checks; names or glob patterns (e.g. kubernetes.version,rbac.*)")
+	cmd.Flags().StringSliceVar(&skipChecks, "skip-checks", nil,
+		"Do not run these checks; names or glob patterns (e.g. rbac.*)")
+	cmd.Flags().StringVar(&framework, "framework", "",
+		"Run only checks mapped to controls of this compliance framework (e.g. cis, nist-800-53, pci-dss)")
+	cmd.Flags().StringSliceVar(&mappingFiles, "control-mappings", nil,
+		"Files mapping checks to framework controls, in the format of a spec's compliance section; extends the built-in mappings")
+	cmd.Flags().StringVar(&failOn, "fail-on", string(scanner.SeverityLow),
+		"Exit with code 1 only for failures at or above this severity: critical|high|medium|low|none")
+	cmd.Flags().StringSliceVar(&failOnSkip, "fail-on-skip", nil,
+		"Exit with code 1 when a check is skipped for one of these reasons: all|SpecSectionAbsent|NoApplicableResources|PermissionDenied|FeatureUnavailable")
 	cmd.MarkFlagRequired("spec")
 
 	return cmd
 }
 
+// parseCheckTimeouts builds the scanner's check timeouts from the --check-timeout
+// and --check-timeout-override flags, rejecting unknown checks and bad durations.
+func parseCheckTimeouts(defaultTimeout time.Duration, overrides map[string]string, checkList []scanner.Check) (scanner.CheckTimeouts, error) {
+	timeouts := scanner.CheckTimeouts{Default: defaultTimeout}
+	if len(overrides) == 0 {
+		return timeouts, nil
+	}
+
+	known := make(map[string]bool, len(checkList))
+	for _, check := range checkList {
+		known[check.Name()] = true
+	}
+
+	timeouts.Overrides = make(map[string]time.Duration, len(overrides))
+	for name, value := range overrides {
+		if !known[name] {
+			return timeouts, fmt.Errorf("invalid --check-timeout-override: unknown check %q", name)
+		}
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return timeouts, fmt.Errorf("invalid --check-timeout-override for %s: %w", name, err)
+		}
+		timeouts.Overrides[name] = timeout
+	}
+
+	return timeouts, nil
+}
+
+// watchScans runs scanOnce against the source's current spec on every interval
+// and whenever the spec changes, until the context is cancelled.
+func watchScans(ctx context.Context, source spec.Source, interval time.Duration, scanOnce func(*spec.ClusterSpecification) (*scanner.ScanResult, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := scanOnce(source.Current()); err != nil {
+			fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-source.Changes():
+		}
+	}
+}
+
+// explainFailures replaces the remediation of each failing result with the
+// check's step-by-step playbook.
+func explainFailures(result *scanner.ScanResult, checkList []scanner.Check) {
+	byName := make(map[string]scanner.Check, len(checkList))
+	for _, check := range checkList {
+		byName[check.Name()] = check
+	}
+
+	for i := range result.Results {
+		r := &result.Results[i]
+		// Checks that failed to execute have no remediation to expand
+		if r.Status != scanner.StatusFail || r.Remediation == "" {
+			continue
+		}
+		if check, ok := byName[r.Name]; ok {
+			r.Remediation = scanner.Playbook(check, r)
+		}
+	}
+}
+
 // createKubernetesClient creates a Kubernetes client from kubeconfig.
 func createKubernetesClient(kubeconfigPath string) (kubernetes.Interface, error) {
+	config, err := buildRestConfig(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create clientset
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clientset: %w", err)
+	}
+
+	return clientset, nil
+}
+
+// buildRestConfig builds a REST config from kubeconfig with the CLI rate limits applied.
+// Without a kubeconfig, e.g. in a scheduled scan's pod, the in-cluster config is used.
+func buildRestConfig(kubeconfigPath string) (*rest.Config, error) {
 	// Use default kubeconfig path if not specified
 	if kubeconfigPath == "" {
 		kubeconfigPath = os.Getenv("KUBECONFIG")
 		if kubeconfigPath == "" {
 			kubeconfigPath = clientcmd.NewDefaultClientConfigLoadingRules().GetDefaultFilename()
+			if _, err := os.Stat(kubeconfigPath); os.IsNotExist(err) && os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+				config, err := rest.InClusterConfig()
+				if err != nil {
+					return nil, fmt.Errorf("failed to build in-cluster config: %w", err)
+				}
+				applyClientRateLimits(config)
+				return config, nil
+			}
 		}
 	}
 
@@ -234,13 +710,39 @@ func createKubernetesClient(kubeconfigPath string) (kubernetes.Interface, error)
 		return nil, fmt.Errorf("failed to build config from kubeconfig: %w", err)
 	}
 
-	// Create clientset
-	clientset, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create clientset: %w", err)
-	}
+	applyClientRateLimits(config)
+	return config, nil
+}
 
-	return clientset, nil
+// applyClientRateLimits sets the --qps and --burst limits on a REST config.
+func applyClientRateLimits(config *rest.Config) {
+	config.QPS = clientQPS
+	config.Burst = clientBurst
+}
+
+// printRemediationSteps prints a result's remediation steps as a numbered list.
+func printRemediationSteps(steps []scanner.RemediationStep) {
+	if len(steps) == 0 {
+		return
+	}
+	fmt.Printf("  Steps:\n")
+	for i, step := range steps {
+		fmt.Printf("    %d. %s\n", i+1, step.Description)
+		var detail string
+		switch step.Type {
+		case scanner.RemediationStepKubectl:
+			detail = step.Command
+		case scanner.RemediationStepPatch:
+			detail = step.Patch
+		case scanner.RemediationStepDocLink:
+			detail = step.URL
+		}
+		for _, line := range strings.Split(detail, "\n") {
+			if line != "" {
+				fmt.Printf("       %s\n", line)
+			}
+		}
+	}
 }
 
 // printTextReport prints a human-readable text report.
@@ -261,6 +763,9 @@ func printTextReport(result *scanner.ScanResult) {
 		passRate = (result.Summary.Passed * 100) / result.Summary.TotalChecks
 	}
 	fmt.Printf("COMPLIANCE: %d/%d checks passed (%d%%)\n", result.Summary.Passed, result.Summary.TotalChecks, passRate)
+	if result.Summary.TotalChecks > 0 && result.Summary.WeightedScore != float64(result.Summary.Passed)/float64(result.Summary.TotalChecks)*100 {
+		fmt.Printf("WEIGHTED SCORE: %.1f%%\n", result.Summary.WeightedScore)
+	}
 	fmt.Printf("\n")
 
 	// Critical failures
@@ -269,10 +774,11 @@ func printTextReport(result *scanner.ScanResult) {
 		fmt.Printf("[CRITICAL] FAILURES (%d)\n", len(criticalFailures))
 		fmt.Printf("─────────────────────────\n")
 		for _, r := range criticalFailures {
-			fmt.Printf("[%s] %s\n", r.Name, r.Message)
+			fmt.Printf("%s %s\n", resultTag(r), r.Message)
 			if r.Remediation != "" {
 				fmt.Printf("  Fix: %s\n", r.Remediation)
 			}
+			printRemediationSteps(r.RemediationSteps)
 			fmt.Printf("\n")
 		}
 	}
@@ -284,6 +790,21 @@ func printTextReport(result *scanner.ScanResult) {
 		fmt.Printf("[FAIL] FAILURES (%d)\n", len(otherFailures))
 		fmt.Printf("─────────────────────────\n")
 		for _, r := range otherFailures {
+			fmt.Printf("%s %s\n", resultTag(r), r.Message)
+			if r.Remediation != "" {
+				fmt.Printf("  Fix: %s\n", r.Remediation)
+			}
+			printRemediationSteps(r.RemediationSteps)
+			fmt.Printf("\n")
+		}
+	}
+
+	// Checks that could not be evaluated (e.g. insufficient permissions)
+	errored := filterResults(result.Results, scanner.StatusError, "")
+	if len(errored) > 0 {
+		fmt.Printf("[ERROR] NOT EVALUATED (%d)\n", len(errored))
+		fmt.Printf("─────────────────────────\n")
+		for _, r := range errored {
 			fmt.Printf("[%s] %s\n", r.Name, r.Message)
 			if r.Remediation != "" {
 				fmt.Printf("  Fix: %s\n", r.Remediation)
@@ -298,7 +819,7 @@ func printTextReport(result *scanner.ScanResult) {
 		fmt.Printf("[WARN] WARNINGS (%d)\n", len(warnings))
 		fmt.Printf("─────────────────\n")
 		for _, r := range warnings {
-			fmt.Printf("[%s] %s\n", r.Name, r.Message)
+			fmt.Printf("%s %s\n", resultTag(r), r.Message)
 			fmt.Printf("\n")
 		}
 	}
@@ -313,6 +834,30 @@ func printTextReport(result *scanner.ScanResult) {
 		}
 		fmt.Printf("\n")
 	}
+
+	// Skipped checks
+	skipped := filterResults(result.Results, scanner.StatusSkip, "")
+	if len(skipped) > 0 {
+		fmt.Printf("[SKIP] SKIPPED CHECKS (%d)\n", len(skipped))
+		fmt.Printf("──────────────────────\n")
+		for _, r := range skipped {
+			if r.SkipReason != "" {
+				fmt.Printf("  [%s] %s (%s)\n", r.Name, r.Message, r.SkipReason)
+			} else {
+				fmt.Printf("  [%s] %s\n", r.Name, r.Message)
+			}
+		}
+		fmt.Printf("\n")
+	}
+}
+
+// resultTag returns the bracketed check name, followed by the organisation-specific
+// severity label when one is configured.
+func resultTag(r scanner.CheckResult) string {
+	if r.SeverityLabel != "" {
+		return fmt.Sprintf("[%s] [%s]", r.Name, r.SeverityLabel)
+	}
+	return fmt.Sprintf("[%s]", r.Name)
 }
 
 // filterResults filters results by status and optionally by severity.
@@ -341,19 +886,23 @@ func excludeBySeverity(results []scanner.CheckResult, severity scanner.Severity)
 
 func newEnforceCmd() *cobra.Command {
 	var (
-		specFile       string
+		specFiles      []string
 		kubeconfigPath string
 		dryRun         bool
 		skipInstall    bool
 		outputFile     string
+		engineName     string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "enforce",
-		Short: "Generate and deploy Kyverno policies from specification",
+		Short: "Generate and deploy admission policies from specification",
 		Long: `Enforce generates Kyverno ClusterPolicy resources from a kspec specification
 and optionally deploys them to the cluster. This enables proactive policy enforcement
-to prevent non-compliant workloads from being deployed.`,
+to prevent non-compliant workloads from being deployed.
+
+With --engine gatekeeper, OPA Gatekeeper ConstraintTemplate and Constraint pairs are
+generated instead.`,
 		Example: `  # Generate policies (dry-run, see what would be created)
   kspec enforce --spec cluster-spec.yaml --dry-run
 
@@ -364,12 +913,23 @@ to prevent non-compliant workloads from being deployed.`,
   kspec enforce --spec cluster-spec.yaml --dry-run --output policies.yaml
 
   # Skip Kyverno installation check
-  kspec enforce --spec cluster-spec.yaml --skip-install`,
+  kspec enforce --spec cluster-spec.yaml --skip-install
+
+  # Generate OPA Gatekeeper constraints instead of Kyverno policies
+  kspec enforce --spec cluster-spec.yaml --engine gatekeeper
+
+  # Find orphaned or drifted generated policies
+  kspec enforce audit --spec cluster-spec.yaml`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
+			engine, err := enforcer.ParseEngine(engineName)
+			if err != nil {
+				return fmt.Errorf("invalid --engine: %w", err)
+			}
+
 			// Load spec
-			clusterSpec, err := spec.LoadFromFile(specFile)
+			clusterSpec, err := spec.LoadFromFiles(specFiles)
 			if err != nil {
 				return fmt.Errorf("failed to load spec: %w", err)
 			}
@@ -386,16 +946,7 @@ to prevent non-compliant workloads from being deployed.`,
 			}
 
 			// Create dynamic client for applying policies
-			// Use default kubeconfig path if not specified
-			kubeconfigToUse := kubeconfigPath
-			if kubeconfigToUse == "" {
-				kubeconfigToUse = os.Getenv("KUBECONFIG")
-				if kubeconfigToUse == "" {
-					kubeconfigToUse = clientcmd.NewDefaultClientConfigLoadingRules().GetDefaultFilename()
-				}
-			}
-
-			config, err := clientcmd.BuildConfigFromFlags("", kubeconfigToUse)
+			config, err := buildRestConfig(kubeconfigPath)
 			if err != nil {
 				return fmt.Errorf("failed to build config: %w", err)
 			}
@@ -412,6 +963,7 @@ to prevent non-compliant workloads from being deployed.`,
 			result, err := enf.Enforce(ctx, clusterSpec, enforcer.EnforceOptions{
 				DryRun:      dryRun,
 				SkipInstall: skipInstall,
+				Engine:      engine,
 			})
 			if err != nil {
 				return fmt.Errorf("enforcement failed: %w", err)
@@ -424,13 +976,16 @@ to prevent non-compliant workloads from being deployed.`,
 		},
 	}
 
-	cmd.Flags().StringVarP(&specFile, "spec", "s", "", "Path to cluster spec file (required)")
+	cmd.Flags().StringArrayVarP(&specFiles, "spec", "s", nil, "Path to cluster spec file; repeat to layer specs, later files adding to earlier ones (required)")
 	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to kubeconfig file (default: $KUBECONFIG or ~/.kube/config)")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Generate policies without deploying them")
 	cmd.Flags().BoolVar(&skipInstall, "skip-install", false, "Skip Kyverno installation check")
 	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Save generated policies to file (YAML)")
+	cmd.Flags().StringVar(&engineName, "engine", string(enforcer.EngineKyverno), "Policy engine to generate policies for: kyverno|gatekeeper")
 	cmd.MarkFlagRequired("spec")
 
+	cmd.AddCommand(enforceAuditCommand())
+
 	return cmd
 }
 
@@ -442,14 +997,18 @@ func printEnforceResult(result *enforcer.EnforceResult, dryRun bool, outputFile
 	fmt.Printf("└─────────────────────────────────────────┘\n")
 	fmt.Printf("\n")
 
-	// Kyverno status
-	if result.KyvernoInstalled {
-		fmt.Printf("[OK] Kyverno Status: Installed\n")
-		if result.KyvernoVersion != "" {
-			fmt.Printf("     Version: %s\n", result.KyvernoVersion)
+	// Policy engine status
+	engineName, installed, engineVersion := "Kyverno", result.KyvernoInstalled, result.KyvernoVersion
+	if result.Engine == enforcer.EngineGatekeeper {
+		engineName, installed, engineVersion = "Gatekeeper", result.GatekeeperInstalled, result.GatekeeperVersion
+	}
+	if installed {
+		fmt.Printf("[OK] %s Status: Installed\n", engineName)
+		if engineVersion != "" {
+			fmt.Printf("     Version: %s\n", engineVersion)
 		}
 	} else {
-		fmt.Printf("[ERROR] Kyverno Status: Not Installed\n")
+		fmt.Printf("[ERROR] %s Status: Not Installed\n", engineName)
 	}
 	fmt.Printf("\n")
 
@@ -500,8 +1059,8 @@ func printEnforceResult(result *enforcer.EnforceResult, dryRun bool, outputFile
 	if dryRun {
 		fmt.Printf("Next Steps:\n")
 		fmt.Printf("───────────\n")
-		if !result.KyvernoInstalled {
-			fmt.Printf("1. Install Kyverno in your cluster\n")
+		if !installed {
+			fmt.Printf("1. Install %s in your cluster\n", engineName)
 			fmt.Printf("2. Run: kspec enforce --spec <file> (without --dry-run)\n")
 		} else {
 			fmt.Printf("1. Review the generated policies above\n")
@@ -512,8 +1071,13 @@ func printEnforceResult(result *enforcer.EnforceResult, dryRun bool, outputFile
 		fmt.Printf("[OK] Policies successfully deployed\n")
 		fmt.Printf("\n")
 		fmt.Printf("Verify policies:\n")
-		fmt.Printf("  kubectl get clusterpolicies\n")
-		fmt.Printf("  kubectl describe clusterpolicy <policy-name>\n")
+		if result.Engine == enforcer.EngineGatekeeper {
+			fmt.Printf("  kubectl get constrainttemplates\n")
+			fmt.Printf("  kubectl get constraints\n")
+		} else {
+			fmt.Printf("  kubectl get clusterpolicies\n")
+			fmt.Printf("  kubectl describe clusterpolicy <policy-name>\n")
+		}
 		fmt.Printf("\n")
 	}
 }
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/cloudcwfranck/kspec/pkg/policy"
+)
+
+func newPolicyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Work with policy templates",
+		Long:  `Policy commands render the policy templates the kspec operator applies through policyTemplate.`,
+	}
+
+	cmd.AddCommand(newPolicyApplyTemplateCmd())
+
+	return cmd
+}
+
+func newPolicyApplyTemplateCmd() *cobra.Command {
+	var (
+		params       []string
+		outputFormat string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "apply-template <name>",
+		Short: "Render a policy template with parameters",
+		Long: `Apply-template renders a built-in policy template with the given parameters and
+prints the resulting policy definition. Parameters are validated, merged with the
+template defaults and substituted exactly as the operator does for a
+ClusterSpecification's policyTemplate.
+
+Parameter values are parsed according to the template's parameter types: int,
+bool, array (comma-separated) or string.`,
+		Example: `  # Render the security baseline with its defaults
+  kspec policy apply-template security-baseline
+
+  # Allow privilege escalation
+  kspec policy apply-template security-baseline --param allowPrivilegeEscalation=true
+
+  # Machine-readable output
+  kspec policy apply-template compliance-strict --param requireDigests=false -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			templateName := args[0]
+
+			if outputFormat != "yaml" && outputFormat != "json" {
+				return fmt.Errorf("unsupported output format: %s (supported: yaml, json)", outputFormat)
+			}
+
+			manager := policy.NewAdvancedPolicyManager(nil)
+			if _, exists := manager.Templates[templateName]; !exists {
+				return fmt.Errorf("template %s not found (available: %s)", templateName, templateNames(manager))
+			}
+
+			parameters, err := manager.ParseTemplateParameters(templateName, params)
+			if err != nil {
+				return err
+			}
+
+			definition, err := manager.ApplyTemplate(context.Background(), templateName, parameters)
+			if err != nil {
+				return fmt.Errorf("failed to apply template %s: %w", templateName, err)
+			}
+
+			if outputFormat == "json" {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(definition)
+			}
+
+			data, err := yaml.Marshal(definition)
+			if err != nil {
+				return fmt.Errorf("failed to marshal policy definition: %w", err)
+			}
+			fmt.Print(string(data))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&params, "param", "p", nil, "Template parameter as key=value; repeat for multiple parameters")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "yaml", "Output format: yaml|json")
+
+	return cmd
+}
+
+// templateNames lists the registered template names for error messages
+func templateNames(manager *policy.AdvancedPolicyManager) string {
+	names := make([]string, 0, len(manager.Templates))
+	for name := range manager.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
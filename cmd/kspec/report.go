@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudcwfranck/kspec/pkg/aggregation"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+)
+
+func newReportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Work with stored compliance reports",
+		Long:  `Report commands operate on the ComplianceReports stored by the kspec operator.`,
+	}
+
+	cmd.AddCommand(reportReplayCommand())
+	cmd.AddCommand(reportExportCSVCommand())
+
+	return cmd
+}
+
+func reportReplayCommand() *cobra.Command {
+	var (
+		specFiles       []string
+		kubeconfigPath  string
+		clusterSpecName string
+		outputFormat    string
+		showChecks      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Project the fleet impact of a new spec from stored reports",
+		Long: `Replay re-scores the latest stored ComplianceReport of each cluster against a
+new specification, without contacting the clusters, to project how many would fail
+under it before it is rolled out.
+
+Only checks whose stored evidence is rich enough are re-scored:
+- kubernetes.version:   the recorded cluster version against the new version range
+- capacity.pod-density: the recorded per-node pod counts against the new cap
+
+Other checks keep their stored status. Reports written before evidence was stored
+carry over every check.`,
+		Example: `  # Project the impact of a stricter spec on the clusters scanned against prod-baseline
+  kspec report replay --spec stricter-spec.yaml --cluster-spec prod-baseline
+
+  # Machine-readable projection
+  kspec report replay --spec stricter-spec.yaml --cluster-spec prod-baseline --output json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if outputFormat != "text" && outputFormat != "json" {
+				return fmt.Errorf("invalid output format %q: must be text or json", outputFormat)
+			}
+
+			newSpec, err := spec.LoadFromFiles(specFiles)
+			if err != nil {
+				return fmt.Errorf("failed to load spec: %w", err)
+			}
+
+			if err := spec.Validate(newSpec); err != nil {
+				return fmt.Errorf("spec validation failed: %w", err)
+			}
+
+			if clusterSpecName == "" {
+				clusterSpecName = newSpec.Metadata.Name
+			}
+
+			k8sClient, err := createRuntimeClient(kubeconfigPath)
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %w", err)
+			}
+
+			aggregator := aggregation.NewReportAggregator(k8sClient)
+			fleet, err := aggregator.ReplayFleet(context.Background(), clusterSpecName, newSpec)
+			if err != nil {
+				return fmt.Errorf("replay failed: %w", err)
+			}
+
+			if outputFormat == "json" {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(fleet)
+			}
+
+			printFleetReplay(fleet, clusterSpecName, showChecks)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&specFiles, "spec", "s", nil, "Path to the new cluster spec file; repeat to layer specs (required)")
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to kubeconfig file (default: $KUBECONFIG or ~/.kube/config)")
+	cmd.Flags().StringVar(&clusterSpecName, "cluster-spec", "", "ClusterSpecification whose stored reports to replay (default: the new spec's metadata.name)")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text|json")
+	cmd.Flags().BoolVar(&showChecks, "show-checks", false, "List the re-scored checks of each cluster")
+	cmd.MarkFlagRequired("spec")
+
+	return cmd
+}
+
+func printFleetReplay(fleet *aggregation.FleetReplay, clusterSpecName string, showChecks bool) {
+	fmt.Printf("\n")
+	fmt.Printf("┌─────────────────────────────────────────┐\n")
+	fmt.Printf("│ kspec v%s — Report Replay          │\n", version)
+	fmt.Printf("└─────────────────────────────────────────┘\n")
+	fmt.Printf("\n")
+
+	fmt.Printf("Stored reports: %s\n", clusterSpecName)
+	fmt.Printf("New spec: %s (version %s)\n", fleet.SpecName, fleet.SpecVersion)
+	fmt.Printf("\n")
+
+	if fleet.TotalClusters == 0 {
+		fmt.Printf("No stored compliance reports found\n\n")
+		return
+	}
+
+	fmt.Printf("Projected Fleet Impact:\n")
+	fmt.Printf("───────────────────────\n")
+	fmt.Printf("Clusters: %d\n", fleet.TotalClusters)
+	fmt.Printf("Failing now: %d\n", fleet.CurrentlyFailing)
+	fmt.Printf("Failing under new spec: %d\n", fleet.ProjectedFailing)
+	fmt.Printf("Newly failing: %d\n", fleet.NewlyFailing)
+	fmt.Printf("Newly passing: %d\n", fleet.NewlyPassing)
+	fmt.Printf("Checks re-scored: %d (%d carried over without replayable evidence)\n",
+		fleet.ReevaluatedChecks, fleet.CarriedOverChecks)
+	fmt.Printf("\n")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CLUSTER\tFAILED NOW\tPROJECTED FAILED\tSCANNED")
+	for _, cluster := range fleet.Clusters {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\n", cluster.ClusterName, cluster.StoredFailed, cluster.ProjectedFailed,
+			cluster.ScanTime.Format("2006-01-02 15:04"))
+	}
+	w.Flush()
+	fmt.Printf("\n")
+
+	if !showChecks {
+		return
+	}
+
+	for _, cluster := range fleet.Clusters {
+		fmt.Printf("%s:\n", cluster.ClusterName)
+		for _, check := range cluster.Checks {
+			if !check.Reevaluated {
+				continue
+			}
+			fmt.Printf("  [%s -> %s] %s: %s\n", check.StoredStatus, check.ProjectedStatus, check.Name, check.Message)
+		}
+		fmt.Printf("\n")
+	}
+}
+
+func reportExportCSVCommand() *cobra.Command {
+	var (
+		kubeconfigPath  string
+		clusterSpecName string
+		outputFile      string
+		owner           string
+		team            string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export-csv",
+		Short: "Export the compliance of every cluster as CSV",
+		Long: `Export-csv writes one CSV row per cluster from the latest stored ComplianceReport
+against a ClusterSpecification: cluster, platform, compliance score, passed and
+failed checks, drift events and last scan time.`,
+		Example: `  # Monthly fleet compliance export
+  kspec report export-csv --cluster-spec prod-baseline -o fleet-$(date +%Y-%m).csv
+
+  # Export a team's clusters to stdout
+  kspec report export-csv --cluster-spec prod-baseline --team payments`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k8sClient, err := createRuntimeClient(kubeconfigPath)
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %w", err)
+			}
+
+			aggregator := aggregation.NewReportAggregator(k8sClient)
+			aggregator.Owner = owner
+			aggregator.Team = team
+
+			out := os.Stdout
+			if outputFile != "" {
+				file, err := os.Create(outputFile)
+				if err != nil {
+					return fmt.Errorf("failed to create output file: %w", err)
+				}
+				defer file.Close()
+				out = file
+			}
+
+			if err := aggregator.ExportFleetCSV(context.Background(), clusterSpecName, out); err != nil {
+				return fmt.Errorf("export failed: %w", err)
+			}
+
+			if outputFile != "" {
+				fmt.Fprintf(os.Stderr, "Fleet compliance exported to %s\n", outputFile)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to kubeconfig file (default: $KUBECONFIG or ~/.kube/config)")
+	cmd.Flags().StringVar(&clusterSpecName, "cluster-spec", "", "ClusterSpecification whose stored reports to export (required)")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write the CSV to this file instead of stdout")
+	cmd.Flags().StringVar(&owner, "owner", "", "Filter by owner (kspec.io/owner label)")
+	cmd.Flags().StringVar(&team, "team", "", "Filter by team (kspec.io/team label)")
+	cmd.MarkFlagRequired("cluster-spec")
+
+	return cmd
+}
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+)
+
+const (
+	// scheduledSpecKey is the key of the spec file in the spec ConfigMap
+	scheduledSpecKey = "cluster-spec.yaml"
+
+	// scheduledSpecMountPath is where the spec ConfigMap is mounted in the scan pod
+	scheduledSpecMountPath = "/etc/kspec"
+
+	// defaultScheduleImage is the kspec CLI image the CronJob runs
+	defaultScheduleImage = "ghcr.io/cloudcwfranck/kspec:latest"
+)
+
+// cronMacros are the predefined schedules accepted by CronJobs
+var cronMacros = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true,
+	"@daily": true, "@midnight": true, "@hourly": true,
+}
+
+// scheduleOptions describes a scheduled scan CronJob
+type scheduleOptions struct {
+	name             string
+	namespace        string
+	cron             string
+	image            string
+	serviceAccount   string
+	resultsConfigMap string
+	createRBAC       bool
+}
+
+func newScheduleCmd() *cobra.Command {
+	var (
+		specFile       string
+		kubeconfigPath string
+		dryRun         bool
+		opts           scheduleOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Schedule periodic compliance scans with a CronJob",
+		Long: `Schedule generates a Kubernetes CronJob that runs kspec scan on a cron schedule,
+for periodic compliance snapshots without the kspec operator.
+
+The spec file is stored in a ConfigMap mounted into the scan pod. Each run writes
+its JSON report to a results ConfigMap (default: <name>-results), replacing the
+previous report. Unless --create-rbac=false, a ServiceAccount with read-only
+access to the cluster and write access to ConfigMaps in the namespace is created.
+
+The namespace must already exist.`,
+		Example: `  # Print the manifests for a nightly scan
+  kspec schedule --spec cluster-spec.yaml --cron "0 2 * * *" --dry-run
+
+  # Apply them, scanning every 6 hours
+  kspec schedule --spec cluster-spec.yaml --cron "0 */6 * * *"
+
+  # Use an existing service account and a mirrored image
+  kspec schedule --spec cluster-spec.yaml --cron @daily \
+    --service-account compliance-scanner --create-rbac=false \
+    --image registry.example.com/kspec:v0.3.1
+
+  # Read the latest report
+  kubectl get configmap kspec-scheduled-scan-results -n kspec-system \
+    -o jsonpath='{.data.report\.json}'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateCronSchedule(opts.cron); err != nil {
+				return fmt.Errorf("invalid --cron: %w", err)
+			}
+			if errs := validation.IsDNS1123Label(opts.name); len(errs) > 0 {
+				return fmt.Errorf("invalid --name %q: %s", opts.name, strings.Join(errs, ", "))
+			}
+			if opts.serviceAccount == "" {
+				opts.serviceAccount = opts.name
+			}
+			if opts.resultsConfigMap == "" {
+				opts.resultsConfigMap = opts.name + "-results"
+			}
+
+			specData, err := os.ReadFile(specFile)
+			if err != nil {
+				return fmt.Errorf("failed to read spec: %w", err)
+			}
+
+			// Validate the spec now rather than in the first scheduled run
+			clusterSpec, err := spec.LoadFromFile(specFile)
+			if err != nil {
+				return fmt.Errorf("failed to load spec: %w", err)
+			}
+			if err := spec.Validate(clusterSpec); err != nil {
+				return fmt.Errorf("spec validation failed: %w", err)
+			}
+
+			objects := buildScheduleObjects(opts, specData)
+
+			if dryRun {
+				for i, obj := range objects {
+					if i > 0 {
+						fmt.Println("---")
+					}
+					data, err := yaml.Marshal(obj)
+					if err != nil {
+						return fmt.Errorf("failed to marshal %s: %w", obj.GetName(), err)
+					}
+					fmt.Print(string(data))
+				}
+				return nil
+			}
+
+			k8sClient, err := createRuntimeClient(kubeconfigPath)
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %w", err)
+			}
+
+			ctx := context.Background()
+			for _, obj := range objects {
+				// The decoded apply response may drop TypeMeta
+				kind := obj.GetObjectKind().GroupVersionKind().Kind
+				if err := k8sClient.Patch(ctx, obj, client.Apply, client.FieldOwner("kspec-cli"), client.ForceOwnership); err != nil {
+					return fmt.Errorf("failed to apply %s %s: %w", kind, obj.GetName(), err)
+				}
+				fmt.Printf("[OK] %s/%s configured\n", kind, obj.GetName())
+			}
+
+			fmt.Printf("\nScheduled scan %s/%s runs on %q\n", opts.namespace, opts.name, opts.cron)
+			fmt.Printf("Latest report: kubectl get configmap %s -n %s -o jsonpath='{.data.report\\.json}'\n",
+				opts.resultsConfigMap, opts.namespace)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&specFile, "spec", "s", "", "Path to cluster spec file (required)")
+	cmd.Flags().StringVar(&opts.cron, "cron", "", "Cron schedule for the scan, e.g. \"0 2 * * *\" or @daily (required)")
+	cmd.Flags().StringVar(&opts.name, "name", "kspec-scheduled-scan", "Name of the CronJob and its related resources")
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "kspec-system", "Namespace to run the scan in")
+	cmd.Flags().StringVar(&opts.image, "image", defaultScheduleImage, "kspec CLI image to run")
+	cmd.Flags().StringVar(&opts.serviceAccount, "service-account", "", "Service account the scan runs as (default: the --name)")
+	cmd.Flags().BoolVar(&opts.createRBAC, "create-rbac", true, "Create the service account and its read-only ClusterRole")
+	cmd.Flags().StringVar(&opts.resultsConfigMap, "results-configmap", "", "ConfigMap the latest report is written to (default: <name>-results)")
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to kubeconfig file (default: $KUBECONFIG or ~/.kube/config)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the manifests without applying them")
+	cmd.MarkFlagRequired("spec")
+	cmd.MarkFlagRequired("cron")
+
+	return cmd
+}
+
+// validateCronSchedule checks that a schedule is a predefined macro or has the
+// five fields of a standard cron expression. The API server validates the fields.
+func validateCronSchedule(schedule string) error {
+	schedule = strings.TrimSpace(schedule)
+	if strings.HasPrefix(schedule, "@") {
+		if !cronMacros[schedule] {
+			return fmt.Errorf("unknown schedule %q", schedule)
+		}
+		return nil
+	}
+	if fields := strings.Fields(schedule); len(fields) != 5 {
+		return fmt.Errorf("expected 5 fields (minute hour day-of-month month day-of-week), got %d", len(fields))
+	}
+	return nil
+}
+
+// buildScheduleObjects returns the resources of a scheduled scan in apply order
+func buildScheduleObjects(opts scheduleOptions, specData []byte) []client.Object {
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "kspec",
+		"app.kubernetes.io/component":  "scheduled-scan",
+		"app.kubernetes.io/instance":   opts.name,
+		"app.kubernetes.io/managed-by": "kspec-cli",
+	}
+	specConfigMap := opts.name + "-spec"
+
+	objects := []client.Object{
+		&corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Name: specConfigMap, Namespace: opts.namespace, Labels: labels},
+			Data:       map[string]string{scheduledSpecKey: string(specData)},
+		},
+	}
+
+	if opts.createRBAC {
+		subjects := []rbacv1.Subject{{Kind: "ServiceAccount", Name: opts.serviceAccount, Namespace: opts.namespace}}
+		objects = append(objects,
+			&corev1.ServiceAccount{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+				ObjectMeta: metav1.ObjectMeta{Name: opts.serviceAccount, Namespace: opts.namespace, Labels: labels},
+			},
+			// Checks read workloads, RBAC, admission configuration and, for the
+			// deprecated API check, every served resource
+			&rbacv1.ClusterRole{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"},
+				ObjectMeta: metav1.ObjectMeta{Name: opts.name, Labels: labels},
+				Rules: []rbacv1.PolicyRule{
+					{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"get", "list"}},
+				},
+			},
+			&rbacv1.ClusterRoleBinding{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRoleBinding"},
+				ObjectMeta: metav1.ObjectMeta{Name: opts.name, Labels: labels},
+				RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: opts.name},
+				Subjects:   subjects,
+			},
+			&rbacv1.Role{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "Role"},
+				ObjectMeta: metav1.ObjectMeta{Name: opts.name + "-results", Namespace: opts.namespace, Labels: labels},
+				Rules: []rbacv1.PolicyRule{
+					{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get", "create", "update"}},
+				},
+			},
+			&rbacv1.RoleBinding{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "RoleBinding"},
+				ObjectMeta: metav1.ObjectMeta{Name: opts.name + "-results", Namespace: opts.namespace, Labels: labels},
+				RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: opts.name + "-results"},
+				Subjects:   subjects,
+			},
+		)
+	}
+
+	return append(objects, buildScanCronJob(opts, specConfigMap, labels))
+}
+
+// buildScanCronJob returns the CronJob running kspec scan against the mounted spec
+func buildScanCronJob(opts scheduleOptions, specConfigMap string, labels map[string]string) *batchv1.CronJob {
+	// A scan with failing checks exits non-zero; retrying it would only
+	// overwrite the report with the same result
+	var backoffLimit int32
+	successfulJobs := int32(3)
+	failedJobs := int32(3)
+	ttl := int32(86400)
+	runAsNonRoot := true
+	runAsUser := int64(65534)
+	allowPrivilegeEscalation := false
+	readOnlyRootFilesystem := true
+
+	return &batchv1.CronJob{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "batch/v1", Kind: "CronJob"},
+		ObjectMeta: metav1.ObjectMeta{Name: opts.name, Namespace: opts.namespace, Labels: labels},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   opts.cron,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: &successfulJobs,
+			FailedJobsHistoryLimit:     &failedJobs,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: batchv1.JobSpec{
+					BackoffLimit:            &backoffLimit,
+					TTLSecondsAfterFinished: &ttl,
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: labels},
+						Spec: corev1.PodSpec{
+							ServiceAccountName: opts.serviceAccount,
+							RestartPolicy:      corev1.RestartPolicyNever,
+							Containers: []corev1.Container{
+								{
+									Name:    "scan",
+									Image:   opts.image,
+									Command: []string{"kspec"},
+									Args: []string{
+										"scan",
+										"--spec=" + path.Join(scheduledSpecMountPath, scheduledSpecKey),
+										"--output=json",
+										fmt.Sprintf("--report-sink=configmap://%s/%s", opts.namespace, opts.resultsConfigMap),
+									},
+									VolumeMounts: []corev1.VolumeMount{
+										{Name: "cluster-spec", MountPath: scheduledSpecMountPath, ReadOnly: true},
+									},
+									Resources: corev1.ResourceRequirements{
+										Requests: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("100m"),
+											corev1.ResourceMemory: resource.MustParse("128Mi"),
+										},
+										Limits: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("500m"),
+											corev1.ResourceMemory: resource.MustParse("256Mi"),
+										},
+									},
+									SecurityContext: &corev1.SecurityContext{
+										RunAsNonRoot:             &runAsNonRoot,
+										RunAsUser:                &runAsUser,
+										AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+										ReadOnlyRootFilesystem:   &readOnlyRootFilesystem,
+										Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+									},
+								},
+							},
+							Volumes: []corev1.Volume{
+								{
+									Name: "cluster-spec",
+									VolumeSource: corev1.VolumeSource{
+										ConfigMap: &corev1.ConfigMapVolumeSource{
+											LocalObjectReference: corev1.LocalObjectReference{Name: specConfigMap},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/cloudcwfranck/kspec/pkg/drift"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func snapshotCommand() *cobra.Command {
+	var (
+		specFiles      []string
+		kubeconfigPath string
+		outputFile     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Capture the cluster's compliance-relevant state as a baseline",
+		Long: `Snapshot captures the state of the cluster kspec cares about as a versioned,
+timestamped JSON document:
+- Kyverno ClusterPolicies generated by kspec (annotated kspec.dev/generated)
+- NetworkPolicies
+- RBAC roles and bindings, except system ones (named "system:...")
+- the Pod Security labels of namespaces, with a summary of enforced levels
+
+The snapshot records the cluster's name, UID and version, and with --spec the
+spec it was captured for. Pass it to 'kspec drift detect --baseline' to see what
+changed since it was captured; snapshots of another cluster are rejected.`,
+		Example: `  # Capture a known-good baseline
+  kspec snapshot --spec cluster-spec.yaml --output snapshot.json
+
+  # A week later: what changed since?
+  kspec drift detect --baseline snapshot.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			var specInfo *drift.SpecInfo
+			if len(specFiles) > 0 {
+				clusterSpec, err := spec.LoadFromFiles(specFiles)
+				if err != nil {
+					return fmt.Errorf("failed to load spec: %w", err)
+				}
+				specInfo = &drift.SpecInfo{Name: clusterSpec.Metadata.Name, Version: clusterSpec.Metadata.Version}
+			}
+
+			client, dynamicClient, err := createClients(kubeconfigPath)
+			if err != nil {
+				return fmt.Errorf("failed to create clients: %w", err)
+			}
+
+			snapshot, err := drift.NewDetector(client, dynamicClient).CaptureSnapshot(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to capture snapshot: %w", err)
+			}
+			snapshot.Cluster.Name = kubeconfigClusterName(kubeconfigPath)
+			snapshot.Spec = specInfo
+
+			data, err := json.MarshalIndent(snapshot, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode snapshot: %w", err)
+			}
+			if outputFile == "" {
+				fmt.Fprintln(cmd.OutOrStdout(), string(data))
+				return nil
+			}
+			if err := os.WriteFile(outputFile, append(data, '\n'), 0644); err != nil {
+				return fmt.Errorf("failed to write snapshot: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "[OK] Captured %d resources to %s\n", len(snapshot.Resources), outputFile)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&specFiles, "spec", "s", nil, "Path to the cluster spec the snapshot is captured for; repeat to layer specs")
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to kubeconfig file")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write the snapshot to this file instead of stdout")
+
+	return cmd
+}
+
+// kubeconfigClusterName returns the cluster name of the kubeconfig's current
+// context, or "" when it cannot be determined (e.g. in-cluster).
+func kubeconfigClusterName(kubeconfigPath string) string {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfigPath != "" {
+		rules.ExplicitPath = kubeconfigPath
+	}
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{}).RawConfig()
+	if err != nil {
+		return ""
+	}
+	if kubeContext, ok := config.Contexts[config.CurrentContext]; ok {
+		return kubeContext.Cluster
+	}
+	return ""
+}
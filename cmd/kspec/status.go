@@ -0,0 +1,334 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+)
+
+// operatorStatus is the consolidated operational view printed by kspec status.
+type operatorStatus struct {
+	GeneratedAt    time.Time             `json:"generatedAt"`
+	ClusterSpecs   []clusterSpecStatus   `json:"clusterSpecs"`
+	AlertConfigs   []alertConfigStatus   `json:"alertConfigs"`
+	ClusterTargets []clusterTargetStatus `json:"clusterTargets"`
+}
+
+// clusterSpecStatus summarizes the reconcile state of a ClusterSpecification.
+type clusterSpecStatus struct {
+	Name            string       `json:"name"`
+	Phase           string       `json:"phase"`
+	ComplianceScore int          `json:"complianceScore"`
+	Synced          bool         `json:"synced"`
+	LastScanTime    *metav1.Time `json:"lastScanTime,omitempty"`
+	LastReconcileAt string       `json:"lastReconcileAt,omitempty"`
+	Enforcement     string       `json:"enforcement"`
+	Webhooks        string       `json:"webhooks"`
+}
+
+// alertConfigStatus summarizes the health of an AlertConfig's notifiers.
+type alertConfigStatus struct {
+	Name          string           `json:"name"`
+	Namespace     string           `json:"namespace"`
+	Configured    bool             `json:"configured"`
+	AlertsSent    int64            `json:"alertsSent"`
+	AlertsFailed  int64            `json:"alertsFailed"`
+	LastAlertTime *metav1.Time     `json:"lastAlertTime,omitempty"`
+	Notifiers     []notifierHealth `json:"notifiers,omitempty"`
+}
+
+// notifierHealth summarizes a single notifier of an AlertConfig.
+type notifierHealth struct {
+	Name         string `json:"name"`
+	AlertsSent   int64  `json:"alertsSent"`
+	AlertsFailed int64  `json:"alertsFailed"`
+	LastError    string `json:"lastError,omitempty"`
+}
+
+// clusterTargetStatus summarizes the reachability of a ClusterTarget.
+type clusterTargetStatus struct {
+	Name      string       `json:"name"`
+	Namespace string       `json:"namespace"`
+	Reachable bool         `json:"reachable"`
+	Version   string       `json:"version,omitempty"`
+	LastCheck *metav1.Time `json:"lastCheck,omitempty"`
+}
+
+func newStatusCmd() *cobra.Command {
+	var (
+		kubeconfigPath string
+		outputFormat   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show kspec operator status",
+		Long: `Status shows the operational health of the kspec operator in one view:
+ClusterSpecification phases, scores and reconcile times, webhook and enforcement
+state, alert notifier health and ClusterTarget reachability.
+
+For fleet-wide compliance results, use kspec dashboard.`,
+		Example: `  # Show operator status
+  kspec status
+
+  # Machine-readable status
+  kspec status --output json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			if outputFormat != "text" && outputFormat != "json" {
+				return fmt.Errorf("unsupported output format: %s (supported: text, json)", outputFormat)
+			}
+
+			k8sClient, err := createRuntimeClient(kubeconfigPath)
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %w", err)
+			}
+
+			status, err := collectOperatorStatus(ctx, k8sClient)
+			if err != nil {
+				return err
+			}
+
+			if outputFormat == "json" {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(status)
+			}
+
+			printOperatorStatus(status)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to kubeconfig file (default: $KUBECONFIG or ~/.kube/config)")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text|json")
+
+	return cmd
+}
+
+// createRuntimeClient creates a controller-runtime client for the kspec CRDs.
+func createRuntimeClient(kubeconfigPath string) (client.Client, error) {
+	config, err := buildRestConfig(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, err := createScheme()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scheme: %w", err)
+	}
+
+	return client.New(config, client.Options{Scheme: scheme})
+}
+
+// collectOperatorStatus aggregates the status of the kspec custom resources.
+func collectOperatorStatus(ctx context.Context, k8sClient client.Client) (*operatorStatus, error) {
+	status := &operatorStatus{
+		GeneratedAt:    time.Now().UTC(),
+		ClusterSpecs:   []clusterSpecStatus{},
+		AlertConfigs:   []alertConfigStatus{},
+		ClusterTargets: []clusterTargetStatus{},
+	}
+
+	var clusterSpecs kspecv1alpha1.ClusterSpecificationList
+	if err := k8sClient.List(ctx, &clusterSpecs); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterSpecifications: %w", err)
+	}
+	for _, cs := range clusterSpecs.Items {
+		status.ClusterSpecs = append(status.ClusterSpecs, summarizeClusterSpec(&cs))
+	}
+
+	var alertConfigs kspecv1alpha1.AlertConfigList
+	if err := k8sClient.List(ctx, &alertConfigs); err != nil {
+		return nil, fmt.Errorf("failed to list AlertConfigs: %w", err)
+	}
+	for _, ac := range alertConfigs.Items {
+		status.AlertConfigs = append(status.AlertConfigs, summarizeAlertConfig(&ac))
+	}
+
+	var targets kspecv1alpha1.ClusterTargetList
+	if err := k8sClient.List(ctx, &targets); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterTargets: %w", err)
+	}
+	for _, target := range targets.Items {
+		status.ClusterTargets = append(status.ClusterTargets, clusterTargetStatus{
+			Name:      target.Name,
+			Namespace: target.Namespace,
+			Reachable: target.Status.Reachable,
+			Version:   target.Status.Version,
+			LastCheck: target.Status.LastChecked,
+		})
+	}
+
+	return status, nil
+}
+
+// summarizeClusterSpec extracts the operational state of a ClusterSpecification.
+func summarizeClusterSpec(cs *kspecv1alpha1.ClusterSpecification) clusterSpecStatus {
+	summary := clusterSpecStatus{
+		Name:            cs.Name,
+		Phase:           cs.Status.Phase,
+		ComplianceScore: cs.Status.ComplianceScore,
+		Synced:          cs.Status.ObservedGeneration == cs.Generation,
+		LastScanTime:    cs.Status.LastScanTime,
+		LastReconcileAt: cs.Status.LastHandledReconcileAt,
+		Enforcement:     "disabled",
+		Webhooks:        "disabled",
+	}
+	if summary.Phase == "" {
+		summary.Phase = "Pending"
+	}
+
+	if e := cs.Status.Enforcement; e != nil && e.Active {
+		summary.Enforcement = fmt.Sprintf("%s (%d policies)", e.Mode, e.PoliciesGenerated)
+	}
+
+	if w := cs.Status.Webhooks; w != nil && w.Active {
+		switch {
+		case w.CircuitBreakerTripped:
+			summary.Webhooks = "circuit-breaker-tripped"
+		case !w.CertificateReady:
+			summary.Webhooks = "certificate-pending"
+		default:
+			summary.Webhooks = fmt.Sprintf("active (%.1f%% errors)", w.ErrorRate*100)
+		}
+	}
+
+	return summary
+}
+
+// summarizeAlertConfig extracts notifier health from an AlertConfig.
+func summarizeAlertConfig(ac *kspecv1alpha1.AlertConfig) alertConfigStatus {
+	summary := alertConfigStatus{
+		Name:          ac.Name,
+		Namespace:     ac.Namespace,
+		Configured:    meta.IsStatusConditionTrue(ac.Status.Conditions, "Configured"),
+		AlertsSent:    ac.Status.AlertsSent,
+		AlertsFailed:  ac.Status.AlertsFailed,
+		LastAlertTime: ac.Status.LastAlertTime,
+	}
+
+	names := make([]string, 0, len(ac.Status.NotifierStatus))
+	for name := range ac.Status.NotifierStatus {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		notifier := ac.Status.NotifierStatus[name]
+		summary.Notifiers = append(summary.Notifiers, notifierHealth{
+			Name:         name,
+			AlertsSent:   notifier.AlertsSent,
+			AlertsFailed: notifier.AlertsFailed,
+			LastError:    notifier.LastError,
+		})
+	}
+
+	return summary
+}
+
+// printOperatorStatus prints the operator status as text tables.
+func printOperatorStatus(status *operatorStatus) {
+	fmt.Println("┌────────────────────────────────────────────────────────────────────────────┐")
+	fmt.Printf("│ %-74s │\n", "kspec Operator Status")
+	fmt.Printf("│ %-74s │\n", fmt.Sprintf("Updated: %s", status.GeneratedAt.Local().Format("2006-01-02 15:04:05")))
+	fmt.Println("└────────────────────────────────────────────────────────────────────────────┘")
+	fmt.Println()
+
+	fmt.Println("ClusterSpecifications")
+	fmt.Println("─────────────────────────────────────────────────────────────────────────────")
+	if len(status.ClusterSpecs) == 0 {
+		fmt.Println("  No ClusterSpecifications found.")
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tPHASE\tSCORE\tSYNCED\tLAST SCAN\tENFORCEMENT\tWEBHOOKS")
+		for _, cs := range status.ClusterSpecs {
+			lastScan := "Never"
+			if cs.LastScanTime != nil {
+				lastScan = formatAge(cs.LastScanTime.Time)
+			}
+			synced := "yes"
+			if !cs.Synced {
+				synced = "no"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d%%\t%s\t%s\t%s\t%s\n",
+				cs.Name, cs.Phase, cs.ComplianceScore, synced, lastScan, cs.Enforcement, cs.Webhooks)
+		}
+		w.Flush()
+	}
+	fmt.Println()
+
+	fmt.Println("Alert Notifiers")
+	fmt.Println("─────────────────────────────────────────────────────────────────────────────")
+	if len(status.AlertConfigs) == 0 {
+		fmt.Println("  No AlertConfigs found.")
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ALERTCONFIG\tNOTIFIER\tSENT\tFAILED\tLAST ERROR")
+		for _, ac := range status.AlertConfigs {
+			name := fmt.Sprintf("%s/%s", ac.Namespace, ac.Name)
+			if !ac.Configured {
+				name += " (not configured)"
+			}
+			if len(ac.Notifiers) == 0 {
+				fmt.Fprintf(w, "%s\t-\t%d\t%d\t-\n", name, ac.AlertsSent, ac.AlertsFailed)
+				continue
+			}
+			for _, n := range ac.Notifiers {
+				lastError := "-"
+				if n.LastError != "" {
+					lastError = truncate(n.LastError, 50)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\n", name, n.Name, n.AlertsSent, n.AlertsFailed, lastError)
+			}
+		}
+		w.Flush()
+	}
+	fmt.Println()
+
+	if len(status.ClusterTargets) > 0 {
+		fmt.Println("Cluster Targets")
+		fmt.Println("─────────────────────────────────────────────────────────────────────────────")
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tREACHABLE\tVERSION\tLAST CHECK")
+		for _, target := range status.ClusterTargets {
+			reachable := "✓"
+			if !target.Reachable {
+				reachable = "✗"
+			}
+			lastCheck := "Never"
+			if target.LastCheck != nil {
+				lastCheck = formatAge(target.LastCheck.Time)
+			}
+			fmt.Fprintf(w, "%s/%s\t%s\t%s\t%s\n", target.Namespace, target.Name, reachable, target.Version, lastCheck)
+		}
+		w.Flush()
+		fmt.Println()
+	}
+}
+
+// formatAge formats how long ago t was, e.g. "5m ago".
+func formatAge(t time.Time) string {
+	duration := time.Since(t)
+	switch {
+	case duration < time.Minute:
+		return "Just now"
+	case duration < time.Hour:
+		return fmt.Sprintf("%dm ago", int(duration.Minutes()))
+	case duration < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(duration.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(duration.Hours()/24))
+	}
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudcwfranck/kspec/api/v1alpha1"
+	"github.com/cloudcwfranck/kspec/controllers"
+	"github.com/cloudcwfranck/kspec/pkg/enforcer/kyverno"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// clusterSpecLabel links generated resources to the ClusterSpecification that owns them
+	clusterSpecLabel = "kspec.io/cluster-spec"
+
+	// webhookComponentLabel marks the webhook configurations managed by kspec
+	webhookComponentLabel = "kspec.io/component"
+)
+
+// uninstallItem is a single resource scheduled for removal
+type uninstallItem struct {
+	Kind      string
+	Namespace string
+	Name      string
+
+	// gvr is set for resources deleted through the dynamic client
+	gvr schema.GroupVersionResource
+}
+
+func (i uninstallItem) String() string {
+	if i.Namespace != "" {
+		return fmt.Sprintf("%s %s/%s", i.Kind, i.Namespace, i.Name)
+	}
+	return fmt.Sprintf("%s %s", i.Kind, i.Name)
+}
+
+func uninstallCommand() *cobra.Command {
+	var (
+		kubeconfigPath string
+		clusterSpec    string
+		includeReports bool
+		dryRun         bool
+		yes            bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove resources generated by kspec",
+		Long: `Remove the resources kspec generated in the cluster for a clean teardown.
+
+Resources removed:
+- Kyverno ClusterPolicies generated by kspec
+- The Validating- and MutatingWebhookConfigurations managed by kspec
+- ComplianceReports and DriftReports (with --include-reports)
+
+Use --cluster-spec to only remove resources owned by a single ClusterSpecification.
+The shared webhook configurations are kept when scoping to a single spec.`,
+		Example: `  # List what would be removed
+  kspec uninstall --dry-run
+
+  # Remove everything kspec generated, including reports
+  kspec uninstall --include-reports
+
+  # Remove only resources owned by one ClusterSpecification
+  kspec uninstall --cluster-spec production --yes`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			client, dynamicClient, err := createClients(kubeconfigPath)
+			if err != nil {
+				return fmt.Errorf("failed to create clients: %w", err)
+			}
+
+			items, err := collectUninstallItems(ctx, client, dynamicClient, clusterSpec, includeReports)
+			if err != nil {
+				return err
+			}
+
+			if len(items) == 0 {
+				fmt.Println("No kspec resources found")
+				return nil
+			}
+
+			if dryRun {
+				fmt.Printf("[DRY-RUN] Would remove %d resources:\n", len(items))
+			} else {
+				fmt.Printf("The following %d resources will be removed:\n", len(items))
+			}
+			for _, item := range items {
+				fmt.Printf("  - %s\n", item)
+			}
+
+			if dryRun {
+				return nil
+			}
+
+			if !yes {
+				fmt.Println()
+				if !askYesNo("Proceed with uninstall?", false) {
+					fmt.Println("Uninstall cancelled")
+					return nil
+				}
+			}
+
+			return deleteUninstallItems(ctx, client, dynamicClient, items)
+		},
+	}
+
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to kubeconfig file")
+	cmd.Flags().StringVar(&clusterSpec, "cluster-spec", "", "Only remove resources owned by this ClusterSpecification")
+	cmd.Flags().BoolVar(&includeReports, "include-reports", false, "Also remove ComplianceReports and DriftReports")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List resources that would be removed without deleting them")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+
+	return cmd
+}
+
+// collectUninstallItems enumerates the kspec resources to remove. When clusterSpec is
+// set, only resources labeled as owned by that ClusterSpecification are returned.
+func collectUninstallItems(ctx context.Context, client kubernetes.Interface, dynamicClient dynamic.Interface, clusterSpec string, includeReports bool) ([]uninstallItem, error) {
+	items := []uninstallItem{}
+
+	// Generated ClusterPolicies
+	policies, err := dynamicClient.Resource(kyverno.ClusterPolicyGVR()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to list ClusterPolicies: %w", err)
+		}
+		// Kyverno is not installed, so there are no policies to remove
+	} else {
+		for _, policy := range policies.Items {
+			if !isKspecGeneratedPolicy(policy.GetLabels(), policy.GetAnnotations(), clusterSpec) {
+				continue
+			}
+			items = append(items, uninstallItem{
+				Kind: "ClusterPolicy",
+				Name: policy.GetName(),
+				gvr:  kyverno.ClusterPolicyGVR(),
+			})
+		}
+	}
+
+	// Webhook configurations are shared by all ClusterSpecifications
+	if clusterSpec == "" {
+		webhook, err := client.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(ctx, controllers.ValidatingWebhookConfigName, metav1.GetOptions{})
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get ValidatingWebhookConfiguration: %w", err)
+			}
+		} else if webhook.Labels[webhookComponentLabel] == "webhook" {
+			items = append(items, uninstallItem{
+				Kind: "ValidatingWebhookConfiguration",
+				Name: webhook.Name,
+			})
+		}
+
+		mutatingWebhook, err := client.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, controllers.MutatingWebhookConfigName, metav1.GetOptions{})
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get MutatingWebhookConfiguration: %w", err)
+			}
+		} else if mutatingWebhook.Labels[webhookComponentLabel] == "webhook" {
+			items = append(items, uninstallItem{
+				Kind: "MutatingWebhookConfiguration",
+				Name: mutatingWebhook.Name,
+			})
+		}
+	}
+
+	if includeReports {
+		// Only reports carrying an owning-spec label are removed
+		selector := clusterSpecLabel
+		if clusterSpec != "" {
+			selector = fmt.Sprintf("%s=%s", clusterSpecLabel, clusterSpec)
+		}
+
+		for _, report := range []struct {
+			kind string
+			gvr  schema.GroupVersionResource
+		}{
+			{kind: "ComplianceReport", gvr: v1alpha1.GroupVersion.WithResource("compliancereports")},
+			{kind: "DriftReport", gvr: v1alpha1.GroupVersion.WithResource("driftreports")},
+		} {
+			list, err := dynamicClient.Resource(report.gvr).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+				LabelSelector: selector,
+			})
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, fmt.Errorf("failed to list %ss: %w", report.kind, err)
+			}
+			for _, obj := range list.Items {
+				items = append(items, uninstallItem{
+					Kind:      report.kind,
+					Namespace: obj.GetNamespace(),
+					Name:      obj.GetName(),
+					gvr:       report.gvr,
+				})
+			}
+		}
+	}
+
+	return items, nil
+}
+
+// isKspecGeneratedPolicy reports whether a ClusterPolicy was generated by kspec.
+// Policies generated by the CLI carry the kspec.dev/generated annotation, while
+// policies generated by the operator carry the kspec.io/generated and owning-spec labels.
+func isKspecGeneratedPolicy(labels, annotations map[string]string, clusterSpec string) bool {
+	if clusterSpec != "" {
+		return labels[clusterSpecLabel] == clusterSpec
+	}
+
+	return annotations["kspec.dev/generated"] == "true" ||
+		labels["kspec.dev/generated"] == "true" ||
+		labels["kspec.io/generated"] == "true"
+}
+
+// deleteUninstallItems deletes the collected resources, ignoring resources that are already gone
+func deleteUninstallItems(ctx context.Context, client kubernetes.Interface, dynamicClient dynamic.Interface, items []uninstallItem) error {
+	var failures []string
+	removed := 0
+
+	for _, item := range items {
+		var err error
+		switch {
+		case item.Kind == "ValidatingWebhookConfiguration":
+			err = client.AdmissionregistrationV1().ValidatingWebhookConfigurations().Delete(ctx, item.Name, metav1.DeleteOptions{})
+		case item.Kind == "MutatingWebhookConfiguration":
+			err = client.AdmissionregistrationV1().MutatingWebhookConfigurations().Delete(ctx, item.Name, metav1.DeleteOptions{})
+		case item.Namespace != "":
+			err = dynamicClient.Resource(item.gvr).Namespace(item.Namespace).Delete(ctx, item.Name, metav1.DeleteOptions{})
+		default:
+			err = dynamicClient.Resource(item.gvr).Delete(ctx, item.Name, metav1.DeleteOptions{})
+		}
+
+		if err != nil && !apierrors.IsNotFound(err) {
+			fmt.Printf("  [FAIL] %s: %v\n", item, err)
+			failures = append(failures, item.String())
+			continue
+		}
+
+		fmt.Printf("  [OK] Removed %s\n", item)
+		removed++
+	}
+
+	fmt.Printf("\nRemoved %d/%d resources\n", removed, len(items))
+
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to remove %d resources: %s", len(failures), strings.Join(failures, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/cloudcwfranck/kspec/pkg/webhooks"
+)
+
+// admissionSimulation is the outcome of replaying live pods through the webhook.
+type admissionSimulation struct {
+	Spec      string               `json:"spec"`
+	Evaluated int                  `json:"evaluated"`
+	Denied    []simulatedPodDenial `json:"denied"`
+}
+
+// simulatedPodDenial is a live pod the webhook would deny if it were created now.
+type simulatedPodDenial struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason"`
+}
+
+func newWebhookCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "webhook",
+		Short: "Admission webhook utilities",
+		Long:  `Tools for working with the kspec admission webhook.`,
+	}
+
+	cmd.AddCommand(newWebhookSimulateCmd())
+
+	return cmd
+}
+
+func newWebhookSimulateCmd() *cobra.Command {
+	var (
+		specFile       string
+		kubeconfigPath string
+		namespace      string
+		outputFormat   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Simulate admission decisions for existing pods",
+		Long: `Simulate evaluates every live pod with the same field evaluation the admission
+webhook uses, and lists the pods it would deny if they were created now, and why.
+
+Use it as a pre-flight impact analysis before switching enforcement to enforce
+mode. Unlike a compliance scan, it mirrors admission semantics exactly: it stops
+at the first violation per pod and reports the message the webhook would return.`,
+		Example: `  # Simulate enforcement for all pods
+  kspec webhook simulate --spec cluster-spec.yaml
+
+  # Only pods in one namespace, as JSON
+  kspec webhook simulate --spec cluster-spec.yaml -n payments -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			if outputFormat != "text" && outputFormat != "json" {
+				return fmt.Errorf("unsupported output format: %s (supported: text, json)", outputFormat)
+			}
+
+			// Load spec
+			clusterSpec, err := spec.LoadFromFile(specFile)
+			if err != nil {
+				return fmt.Errorf("failed to load spec: %w", err)
+			}
+
+			// Validate spec
+			if err := spec.Validate(clusterSpec); err != nil {
+				return fmt.Errorf("spec validation failed: %w", err)
+			}
+
+			client, err := createKubernetesClient(kubeconfigPath)
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %w", err)
+			}
+
+			simulation, err := simulateAdmission(ctx, client, clusterSpec, namespace)
+			if err != nil {
+				return err
+			}
+
+			if outputFormat == "json" {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(simulation)
+			}
+
+			printAdmissionSimulation(simulation)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&specFile, "spec", "s", "", "Path to cluster spec file (required)")
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to kubeconfig file (default: $KUBECONFIG or ~/.kube/config)")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Only simulate pods in this namespace (default: all namespaces)")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text|json")
+	cmd.MarkFlagRequired("spec")
+
+	return cmd
+}
+
+// simulateAdmission evaluates the live pods in namespace (all if empty) against
+// the spec's workload requirements, as the webhook would on pod creation.
+func simulateAdmission(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification, namespace string) (*admissionSimulation, error) {
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	simulation := &admissionSimulation{
+		Spec:   clusterSpec.Metadata.Name,
+		Denied: []simulatedPodDenial{},
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		simulation.Evaluated++
+
+		if allowed, reason := webhooks.EvaluatePod(pod, clusterSpec.Spec.Workloads); !allowed {
+			simulation.Denied = append(simulation.Denied, simulatedPodDenial{
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+				Reason:    reason,
+			})
+		}
+	}
+
+	return simulation, nil
+}
+
+// printAdmissionSimulation prints the simulation as a table of denied pods.
+func printAdmissionSimulation(simulation *admissionSimulation) {
+	fmt.Printf("Admission simulation for %s: %d pods evaluated, %d would be denied\n",
+		simulation.Spec, simulation.Evaluated, len(simulation.Denied))
+
+	if len(simulation.Denied) == 0 {
+		fmt.Println("\n✓ All pods would be admitted")
+		return
+	}
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tPOD\tREASON")
+	for _, denial := range simulation.Denied {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", denial.Namespace, denial.Name, denial.Reason)
+	}
+	w.Flush()
+}
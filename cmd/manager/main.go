@@ -18,6 +18,7 @@ package main
 
 import (
 	"flag"
+	"net/http"
 	"os"
 	"time"
 
@@ -36,7 +37,11 @@ import (
 	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
 	"github.com/cloudcwfranck/kspec/controllers"
 	"github.com/cloudcwfranck/kspec/pkg/alerts"
+	"github.com/cloudcwfranck/kspec/pkg/audit"
 	clientpkg "github.com/cloudcwfranck/kspec/pkg/client"
+	"github.com/cloudcwfranck/kspec/pkg/metrics"
+	"github.com/cloudcwfranck/kspec/pkg/reporter"
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
 	"github.com/cloudcwfranck/kspec/pkg/webhooks"
 	// +kubebuilder:scaffold:imports
 )
@@ -61,6 +66,15 @@ func main() {
 	var leaseDuration time.Duration
 	var renewDeadline time.Duration
 	var retryPeriod time.Duration
+	var kubeAPIQPS float64
+	var kubeAPIBurst int
+	var enableOpenMetrics bool
+	var decisionCacheSize int
+	var decisionCacheTTL time.Duration
+	var reportSinkURL string
+	var scanTimeout time.Duration
+	var clusterScanConcurrency int
+	var auditLogFile string
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -77,6 +91,24 @@ func main() {
 		"Duration that the acting leader will retry refreshing leadership before giving up")
 	flag.DurationVar(&retryPeriod, "leader-election-retry-period", 2*time.Second,
 		"Duration the LeaderElector clients should wait between tries of actions")
+	flag.Float64Var(&kubeAPIQPS, "kube-api-qps", 20,
+		"Maximum queries per second to each Kubernetes API server, including remote ClusterTargets")
+	flag.IntVar(&kubeAPIBurst, "kube-api-burst", 30,
+		"Maximum burst of queries to each Kubernetes API server, including remote ClusterTargets")
+	flag.BoolVar(&enableOpenMetrics, "metrics-enable-openmetrics", false,
+		"Serve metrics in the OpenMetrics format, including trace exemplars, on "+metrics.OpenMetricsPath)
+	flag.IntVar(&decisionCacheSize, "webhook-decision-cache-size", webhooks.DefaultDecisionCacheSize,
+		"Maximum number of cached admission decisions for identical pods (0 disables the cache)")
+	flag.DurationVar(&decisionCacheTTL, "webhook-decision-cache-ttl", webhooks.DefaultDecisionCacheTTL,
+		"Duration a cached admission decision is reused")
+	flag.DurationVar(&scanTimeout, "scan-timeout", scanner.DefaultScanTimeout,
+		"Maximum time a compliance scan may run; checks unfinished at the deadline are reported as errors (0 disables).")
+	flag.IntVar(&clusterScanConcurrency, "cluster-scan-concurrency", controllers.DefaultClusterScanConcurrency,
+		"Maximum number of clusters of one ClusterSpecification scanned concurrently")
+	flag.StringVar(&reportSinkURL, "report-sink", "",
+		"Also archive each scan report as timestamped JSON to this sink (e.g. file:///path)")
+	flag.StringVar(&auditLogFile, "audit-log-file", "",
+		"Also append every audit event as a JSON line to this file, independent of the log level")
 
 	opts := zap.Options{
 		Development: true,
@@ -86,11 +118,24 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme: scheme,
-		Metrics: metricsserver.Options{
-			BindAddress: metricsAddr,
-		},
+	// Get config shared by the manager and multi-cluster client factory
+	config := ctrl.GetConfigOrDie()
+	config.QPS = float32(kubeAPIQPS)
+	config.Burst = kubeAPIBurst
+
+	metricsOptions := metricsserver.Options{
+		BindAddress: metricsAddr,
+	}
+	if enableOpenMetrics {
+		metricsOptions.ExtraHandlers = map[string]http.Handler{
+			metrics.OpenMetricsPath: metrics.OpenMetricsHandler(),
+		}
+		setupLog.Info("OpenMetrics endpoint enabled", "path", metrics.OpenMetricsPath)
+	}
+
+	mgr, err := ctrl.NewManager(config, ctrl.Options{
+		Scheme:                        scheme,
+		Metrics:                       metricsOptions,
 		HealthProbeBindAddress:        probeAddr,
 		LeaderElection:                enableLeaderElection,
 		LeaderElectionID:              "kspec-operator-lock",
@@ -105,19 +150,28 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Get config for multi-cluster support
-	config := ctrl.GetConfigOrDie()
-
 	// Create Client Factory for multi-cluster support
 	clientFactory := clientpkg.NewClusterClientFactory(config, mgr.GetClient())
 
+	// Open the audit trail shared by all controllers
+	var auditSink *audit.Sink
+	if auditLogFile != "" {
+		auditSink, err = audit.OpenFileSink(auditLogFile)
+		if err != nil {
+			setupLog.Error(err, "unable to open audit log file", "path", auditLogFile)
+			os.Exit(1)
+		}
+	}
+
 	// Setup ClusterTarget controller
-	if err = controllers.NewClusterTargetReconciler(
+	clusterTargetReconciler := controllers.NewClusterTargetReconciler(
 		mgr.GetClient(),
 		mgr.GetScheme(),
 		config,
 		clientFactory,
-	).SetupWithManager(mgr); err != nil {
+	)
+	clusterTargetReconciler.AuditSink = auditSink
+	if err = clusterTargetReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterTarget")
 		os.Exit(1)
 	}
@@ -126,13 +180,25 @@ func main() {
 	alertManager := alerts.NewManager(ctrl.Log.WithName("alerts"))
 
 	// Setup ClusterSpecification controller (multi-cluster enabled)
-	if err = controllers.NewClusterSpecReconciler(
+	clusterSpecReconciler := controllers.NewClusterSpecReconciler(
 		mgr.GetClient(),
 		mgr.GetScheme(),
 		config,
 		clientFactory,
 		alertManager,
-	).SetupWithManager(mgr); err != nil {
+	)
+	if reportSinkURL != "" {
+		reportSink, err := reporter.NewSink(reportSinkURL)
+		if err != nil {
+			setupLog.Error(err, "unable to create report sink", "sink", reportSinkURL)
+			os.Exit(1)
+		}
+		clusterSpecReconciler.ReportSink = reportSink
+	}
+	clusterSpecReconciler.AuditSink = auditSink
+	clusterSpecReconciler.ScanTimeout = scanTimeout
+	clusterSpecReconciler.ClusterScanConcurrency = clusterScanConcurrency
+	if err = clusterSpecReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterSpecification")
 		os.Exit(1)
 	}
@@ -150,7 +216,12 @@ func main() {
 	// Start webhook server (v0.3.0 Phase 3)
 	if enableWebhooks {
 		setupLog.Info("Starting admission webhook server")
-		webhookServer := webhooks.NewServer(mgr.GetClient(), 9443, alertManager)
+		webhookServer := webhooks.NewServer(mgr.GetClient(), 9443, alertManager, webhooks.DefaultCircuitBreakerConfig())
+		if decisionCacheSize > 0 {
+			webhookServer.DecisionCache = webhooks.NewDecisionCache(decisionCacheSize, decisionCacheTTL)
+		} else {
+			webhookServer.DecisionCache = nil
+		}
 		if err := mgr.Add(webhookServer); err != nil {
 			setupLog.Error(err, "unable to start webhook server")
 			// Don't exit - allow operator to run without webhooks
@@ -174,11 +245,22 @@ func main() {
 	}
 
 	setupLog.Info("starting manager", "leaderElection", enableLeaderElection,
+		"kubeAPIQPS", kubeAPIQPS,
+		"kubeAPIBurst", kubeAPIBurst,
 		"leaseDuration", leaseDuration,
 		"renewDeadline", renewDeadline,
 		"retryPeriod", retryPeriod)
 
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+
+	// Release cached remote cluster clients
+	clientFactory.Close()
+
+	if auditSink != nil {
+		auditSink.Close()
+	}
+
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
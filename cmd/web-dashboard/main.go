@@ -24,6 +24,8 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
@@ -32,6 +34,7 @@ import (
 
 	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
 	"github.com/cloudcwfranck/kspec/pkg/aggregation"
+	"github.com/cloudcwfranck/kspec/pkg/dashboard"
 )
 
 var (
@@ -50,11 +53,26 @@ func main() {
 		log.Fatalf("Failed to initialize Kubernetes client: %v", err)
 	}
 
+	// Authentication of /api/* (disabled unless configured)
+	auth, err := dashboard.NewAuthenticator(dashboard.AuthConfigFromEnv(os.Getenv))
+	if err != nil {
+		log.Fatalf("Invalid authentication configuration: %v", err)
+	}
+	if auth == nil {
+		log.Printf("WARNING: the dashboard API is unauthenticated; set %s or %s before exposing it beyond localhost",
+			dashboard.TokenEnv, dashboard.OIDCIssuerEnv)
+	}
+
 	// Setup HTTP handlers
+	api := http.NewServeMux()
+	api.HandleFunc("/api/summary", handleAPISummary)
+	api.HandleFunc("/api/clusters", handleAPIClusters)
+	api.HandleFunc("/api/failures", handleAPIFailures)
+	api.HandleFunc("/api/trend", handleAPITrend)
+	api.HandleFunc("/api/drift", handleAPIDrift)
+
 	http.HandleFunc("/", handleDashboard)
-	http.HandleFunc("/api/summary", handleAPISummary)
-	http.HandleFunc("/api/clusters", handleAPIClusters)
-	http.HandleFunc("/api/failures", handleAPIFailures)
+	http.Handle("/api/", dashboard.RequireAuth(auth, api))
 	http.HandleFunc("/health", handleHealth)
 
 	// Start server
@@ -213,6 +231,80 @@ func handleAPIFailures(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(failures)
 }
 
+func handleAPIDrift(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Background()
+	clusterSpec := r.URL.Query().Get("cluster_spec")
+
+	if clusterSpec == "" {
+		var clusterSpecs kspecv1alpha1.ClusterSpecificationList
+		if err := k8sClient.List(ctx, &clusterSpecs); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(clusterSpecs.Items) > 0 {
+			clusterSpec = clusterSpecs.Items[0].Name
+		}
+	}
+
+	events, err := aggregator.GetDriftEventsByCluster(ctx, clusterSpec)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(events)
+}
+
+// defaultTrendPoints is the number of data points /api/trend returns by default
+const defaultTrendPoints = 24
+
+func handleAPITrend(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Background()
+	clusterSpec := r.URL.Query().Get("cluster_spec")
+
+	if clusterSpec == "" {
+		var clusterSpecs kspecv1alpha1.ClusterSpecificationList
+		if err := k8sClient.List(ctx, &clusterSpecs); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(clusterSpecs.Items) > 0 {
+			clusterSpec = clusterSpecs.Items[0].Name
+		}
+	}
+
+	limit := defaultTrendPoints
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	// The window applies to this request only
+	trendAggregator := *aggregator
+	if value := r.URL.Query().Get("window"); value != "" {
+		window, err := time.ParseDuration(value)
+		if err != nil || window <= 0 {
+			http.Error(w, "window must be a positive duration, e.g. 1h", http.StatusBadRequest)
+			return
+		}
+		trendAggregator.TrendWindow = window
+	}
+
+	history, err := trendAggregator.GetFleetComplianceHistory(ctx, clusterSpec, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
+
 func handleHealth(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("OK"))
@@ -297,6 +389,11 @@ const dashboardHTML = `<!DOCTYPE html>
         }
         tr:last-child td { border-bottom: none; }
         tr:hover { background: #f8f9fa; }
+        tr.expandable { cursor: pointer; }
+        tr.drift-detail > td { background: #f8f9fa; padding: 10px 15px 20px 40px; }
+        tr.drift-detail table { box-shadow: none; }
+        tr.drift-detail th { background: #7f8c8d; padding: 8px 15px; }
+        tr.drift-detail td { padding: 8px 15px; }
         .status-badge {
             padding: 4px 12px;
             border-radius: 12px;
@@ -352,6 +449,11 @@ const dashboardHTML = `<!DOCTYPE html>
             <div class="loading">Loading fleet summary...</div>
         </div>
 
+        <div class="card" style="margin-bottom: 30px;">
+            <h3>Compliance Trend</h3>
+            <div id="trend"><div class="loading">Loading trend...</div></div>
+        </div>
+
         <div class="card" style="margin-bottom: 30px;">
             <h3>Cluster Status</h3>
             <table id="clusters">
@@ -374,10 +476,35 @@ const dashboardHTML = `<!DOCTYPE html>
     </div>
 
     <script>
+        // Bearer token of authenticated dashboards, kept in local storage
+        let tokenPrompted = false;
+
+        function apiFetch(path) {
+            const token = localStorage.getItem('kspecDashboardToken');
+            const headers = token ? { 'Authorization': 'Bearer ' + token } : {};
+            return fetch(path, { headers: headers }).then(r => {
+                if (r.status === 401) {
+                    promptForToken();
+                    throw new Error('authentication required');
+                }
+                return r.json();
+            });
+        }
+
+        function promptForToken() {
+            if (tokenPrompted) return;
+            tokenPrompted = true;
+            const token = window.prompt('This dashboard requires a bearer token:');
+            if (token) {
+                localStorage.setItem('kspecDashboardToken', token.trim());
+                tokenPrompted = false;
+                fetchData();
+            }
+        }
+
         function fetchData() {
             // Fetch summary
-            fetch('/api/summary')
-                .then(r => r.json())
+            apiFetch('/api/summary')
                 .then(data => {
                     if (data.error) {
                         document.getElementById('summary').innerHTML =
@@ -391,15 +518,28 @@ const dashboardHTML = `<!DOCTYPE html>
                         '<div class="error">Failed to load summary: ' + err + '</div>';
                 });
 
-            // Fetch clusters
-            fetch('/api/clusters')
-                .then(r => r.json())
-                .then(data => updateClusters(data))
+            // Fetch clusters, then the drift events shown when a cluster is expanded
+            Promise.all([
+                apiFetch('/api/clusters'),
+                apiFetch('/api/drift').catch(() => ({}))
+            ])
+                .then(([clusters, drift]) => {
+                    driftEvents = drift || {};
+                    updateClusters(clusters);
+                })
                 .catch(err => {
                     document.getElementById('clusters').querySelector('tbody').innerHTML =
                         '<tr><td colspan="7" class="error">Failed to load clusters: ' + err + '</td></tr>';
                 });
 
+            // Fetch fleet compliance trend
+            apiFetch('/api/trend')
+                .then(data => updateTrend(data))
+                .catch(err => {
+                    document.getElementById('trend').innerHTML =
+                        '<div class="error">Failed to load trend: ' + err + '</div>';
+                });
+
             // Update timestamp
             document.getElementById('last-update').textContent =
                 'Last updated: ' + new Date().toLocaleString();
@@ -414,8 +554,7 @@ const dashboardHTML = `<!DOCTYPE html>
             if (compliancePercent >= 95) complianceClass = 'compliance-high';
             else if (compliancePercent >= 80) complianceClass = 'compliance-medium';
 
-            const html = ` +
-	`<div class="card">
+            const html = ` + "`" + `<div class="card">
                     <h3>Overall Compliance</h3>
                     <div class="value ${complianceClass}">${compliancePercent}%</div>
                     <div class="subvalue">${data.PassedChecks}/${data.TotalChecks} checks passed</div>
@@ -453,7 +592,77 @@ const dashboardHTML = `<!DOCTYPE html>
             document.getElementById('summary').innerHTML = html;
         }
 
+        function updateTrend(data) {
+            const points = data.DataPoints || [];
+            if (points.length === 0) {
+                document.getElementById('trend').innerHTML =
+                    '<div class="subvalue">No compliance history yet</div>';
+                return;
+            }
+
+            // Plot the pass rate (0-100%) left to right, oldest first
+            const width = 1000, height = 120;
+            const step = points.length > 1 ? width / (points.length - 1) : 0;
+            const coords = points.map((p, i) =>
+                (i * step).toFixed(1) + ',' + (height - p.ComplianceScore / 100 * height).toFixed(1)).join(' ');
+            const last = points[points.length - 1];
+
+            document.getElementById('trend').innerHTML =
+                '<svg viewBox="0 0 ' + width + ' ' + height + '" preserveAspectRatio="none" style="width: 100%; height: 120px;">' +
+                '<polyline fill="none" stroke="#667eea" stroke-width="3" points="' + coords + '"/></svg>' +
+                '<div class="subvalue">' + last.ComplianceScore.toFixed(1) + '% across ' + last.Clusters +
+                ' clusters at ' + new Date(last.Timestamp).toLocaleString() + '</div>';
+        }
+
+        // The last clusters rendered, their drift events, and the clusters
+        // whose events are shown
+        let lastClusters = [];
+        let driftEvents = {};
+        const expandedClusters = new Set();
+
+        function escapeHTML(value) {
+            return String(value == null ? '' : value).replace(/[&<>"']/g, c => ({
+                '&': '&amp;', '<': '&lt;', '>': '&gt;', '"': '&quot;', "'": '&#39;'
+            })[c]);
+        }
+
+        function toggleCluster(name) {
+            if (expandedClusters.has(name)) {
+                expandedClusters.delete(name);
+            } else {
+                expandedClusters.add(name);
+            }
+            updateClusters(lastClusters);
+        }
+
+        function driftDetail(name) {
+            const events = driftEvents[name] || [];
+            if (events.length === 0) {
+                return '<tr class="drift-detail"><td colspan="7">No drift events reported</td></tr>';
+            }
+
+            const rows = events.map(e => {
+                const resource = e.resource
+                    ? e.resource.kind + '/' + (e.resource.namespace ? e.resource.namespace + '/' : '') + e.resource.name
+                    : (e.check || '-');
+                let severityClass = 'status-warning';
+                if (e.severity === 'critical' || e.severity === 'high') severityClass = 'status-error';
+                else if (e.severity === 'low') severityClass = 'status-healthy';
+
+                return '<tr><td>' + escapeHTML(e.type) + '</td>' +
+                    '<td><span class="status-badge ' + severityClass + '">' + escapeHTML(e.severity) + '</span></td>' +
+                    '<td>' + escapeHTML(resource) + '</td>' +
+                    '<td>' + escapeHTML(e.message) + '</td></tr>';
+            }).join('');
+
+            return '<tr class="drift-detail"><td colspan="7"><table>' +
+                '<thead><tr><th>Type</th><th>Severity</th><th>Resource</th><th>Message</th></tr></thead>' +
+                '<tbody>' + rows + '</tbody></table></td></tr>';
+        }
+
         function updateClusters(data) {
+            lastClusters = data;
+
             if (!data || data.length === 0) {
                 document.getElementById('clusters').querySelector('tbody').innerHTML =
                     '<tr><td colspan="7" style="text-align: center; padding: 40px; color: #95a5a6;">No clusters found</td></tr>';
@@ -469,8 +678,9 @@ const dashboardHTML = `<!DOCTYPE html>
                 const statusClass = c.Reachable ? 'status-healthy' : 'status-error';
                 const statusText = c.Reachable ? '✓ Healthy' : '✗ Unreachable';
 
-                return ` + "`" + `<tr>
-                    <td><strong>${c.ClusterName}</strong></td>
+                const expanded = expandedClusters.has(c.ClusterName);
+                const row = ` + "`" + `<tr class="${c.HasDrift ? 'expandable' : ''}" data-cluster="${escapeHTML(c.ClusterName)}">
+                    <td>${c.HasDrift ? (expanded ? '▾ ' : '▸ ') : ''}<strong>${c.ClusterName}</strong></td>
                     <td><span class="status-badge ${complianceClass}">${compliancePercent}%</span></td>
                     <td>${c.PassedChecks}/${c.TotalChecks}</td>
                     <td>${c.HasDrift ? '⚡ ' + c.DriftEventCount + ' events' : '✓ None'}</td>
@@ -478,9 +688,14 @@ const dashboardHTML = `<!DOCTYPE html>
                     <td>${c.Nodes || '-'}</td>
                     <td><span class="status-badge ${statusClass}">${statusText}</span></td>
                 </tr>` + "`" + `;
+                return expanded ? row + driftDetail(c.ClusterName) : row;
             }).join('');
 
-            document.getElementById('clusters').querySelector('tbody').innerHTML = rows;
+            const tbody = document.getElementById('clusters').querySelector('tbody');
+            tbody.innerHTML = rows;
+            tbody.querySelectorAll('tr.expandable').forEach(tr => {
+                tr.addEventListener('click', () => toggleCluster(tr.dataset.cluster));
+            });
         }
 
         // Initial load
@@ -0,0 +1,63 @@
+// Package config embeds the kspec install manifests so the CLI can print them
+// without a checkout of the repository.
+package config
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+)
+
+//go:embed crd/bases/*.yaml default/namespace.yaml rbac/*.yaml manager/*.yaml
+var manifests embed.FS
+
+// namespaceManifest creates the namespace the operator's namespaced resources live in.
+const namespaceManifest = "default/namespace.yaml"
+
+// CRDs returns the CustomResourceDefinitions for all kspec resources.
+func CRDs() ([]byte, error) {
+	files, err := fs.Glob(manifests, "crd/bases/*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	return concatManifests(files...)
+}
+
+// RBAC returns the operator's namespace, ServiceAccount, ClusterRole and ClusterRoleBinding.
+func RBAC() ([]byte, error) {
+	return concatManifests(
+		namespaceManifest,
+		"rbac/service_account.yaml",
+		"rbac/role.yaml",
+		"rbac/role_binding.yaml",
+	)
+}
+
+// Manager returns the operator's namespace, Deployment and PodDisruptionBudget.
+func Manager() ([]byte, error) {
+	return concatManifests(
+		namespaceManifest,
+		"manager/manager.yaml",
+		"manager/poddisruptionbudget.yaml",
+	)
+}
+
+// concatManifests joins the embedded files into a single multi-document YAML stream.
+func concatManifests(files ...string) ([]byte, error) {
+	var out bytes.Buffer
+	for i, file := range files {
+		data, err := manifests.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read embedded manifest %s: %w", file, err)
+		}
+		if i > 0 {
+			out.WriteString("---\n")
+		}
+		// Some files start with their own document separator
+		data = bytes.TrimPrefix(data, []byte("---\n"))
+		out.Write(bytes.TrimRight(data, "\n"))
+		out.WriteString("\n")
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+// manifestKinds returns the kind of every document in a multi-document YAML stream.
+func manifestKinds(t *testing.T, data []byte) []string {
+	t.Helper()
+	var kinds []string
+	for _, doc := range bytes.Split(data, []byte("\n---\n")) {
+		var obj struct {
+			Kind string `json:"kind"`
+		}
+		if err := yaml.Unmarshal(doc, &obj); err != nil {
+			t.Fatalf("Failed to parse manifest: %v", err)
+		}
+		if obj.Kind == "" {
+			t.Fatalf("Manifest document without kind:\n%s", doc)
+		}
+		kinds = append(kinds, obj.Kind)
+	}
+	return kinds
+}
+
+func TestManifests(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest func() ([]byte, error)
+		want     []string
+	}{
+		{"crds", CRDs, []string{"CustomResourceDefinition", "CustomResourceDefinition", "CustomResourceDefinition", "CustomResourceDefinition", "CustomResourceDefinition", "CustomResourceDefinition"}},
+		{"rbac", RBAC, []string{"Namespace", "ServiceAccount", "ClusterRole", "ClusterRoleBinding"}},
+		{"manager", Manager, []string{"Namespace", "Deployment", "PodDisruptionBudget"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.manifest()
+			if err != nil {
+				t.Fatalf("Failed to load manifests: %v", err)
+			}
+			kinds := manifestKinds(t, data)
+			if len(kinds) != len(tt.want) {
+				t.Fatalf("Expected kinds %v, got %v", tt.want, kinds)
+			}
+			for i := range kinds {
+				if kinds[i] != tt.want[i] {
+					t.Errorf("Document %d: expected kind %s, got %s", i, tt.want[i], kinds[i])
+				}
+			}
+		})
+	}
+}
@@ -36,6 +36,9 @@ import (
 const (
 	// ConditionTypeConfigured indicates the AlertConfig is configured
 	ConditionTypeConfigured = "Configured"
+
+	// ConditionTypeTemplatesValid indicates all notifier payload templates are valid
+	ConditionTypeTemplatesValid = "TemplatesValid"
 )
 
 // AlertConfigReconciler reconciles an AlertConfig object
@@ -79,10 +82,15 @@ func (r *AlertConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	r.AlertManager.Clear()
 
 	var errors []string
+	var templateErrors []string
 
 	// Configure Slack notifier if present
 	if alertConfig.Spec.Slack != nil && alertConfig.Spec.Slack.Enabled {
-		if err := r.configureSlackNotifier(ctx, &alertConfig); err != nil {
+		if err := alerts.ValidateTemplate(alertConfig.Spec.Slack.Template); err != nil {
+			log.Error(err, "Invalid Slack template")
+			errors = append(errors, fmt.Sprintf("slack: invalid template: %v", err))
+			templateErrors = append(templateErrors, fmt.Sprintf("slack: %v", err))
+		} else if err := r.configureSlackNotifier(ctx, &alertConfig); err != nil {
 			log.Error(err, "Failed to configure Slack notifier")
 			errors = append(errors, fmt.Sprintf("slack: %v", err))
 		} else {
@@ -90,9 +98,33 @@ func (r *AlertConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}
 
+	// Configure PagerDuty notifier if present
+	if alertConfig.Spec.PagerDuty != nil && alertConfig.Spec.PagerDuty.Enabled {
+		if err := r.configurePagerDutyNotifier(ctx, &alertConfig); err != nil {
+			log.Error(err, "Failed to configure PagerDuty notifier")
+			errors = append(errors, fmt.Sprintf("pagerduty: %v", err))
+		} else {
+			log.Info("PagerDuty notifier configured successfully")
+		}
+	}
+
+	// Configure Teams notifier if present
+	if alertConfig.Spec.Teams != nil && alertConfig.Spec.Teams.Enabled {
+		if err := r.configureTeamsNotifier(ctx, &alertConfig); err != nil {
+			log.Error(err, "Failed to configure Teams notifier")
+			errors = append(errors, fmt.Sprintf("teams: %v", err))
+		} else {
+			log.Info("Teams notifier configured successfully")
+		}
+	}
+
 	// Configure webhook notifiers
 	for i, webhookConfig := range alertConfig.Spec.Webhooks {
-		if err := r.configureWebhookNotifier(ctx, &alertConfig, &webhookConfig); err != nil {
+		if err := alerts.ValidateTemplate(webhookConfig.Template); err != nil {
+			log.Error(err, "Invalid webhook template", "webhook", webhookConfig.Name)
+			errors = append(errors, fmt.Sprintf("webhook[%d] %s: invalid template: %v", i, webhookConfig.Name, err))
+			templateErrors = append(templateErrors, fmt.Sprintf("webhook[%d] %s: %v", i, webhookConfig.Name, err))
+		} else if err := r.configureWebhookNotifier(ctx, &alertConfig, &webhookConfig); err != nil {
 			log.Error(err, "Failed to configure webhook notifier", "webhook", webhookConfig.Name)
 			errors = append(errors, fmt.Sprintf("webhook[%d] %s: %v", i, webhookConfig.Name, err))
 		} else {
@@ -106,6 +138,11 @@ func (r *AlertConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	} else {
 		r.setCondition(&alertConfig, ConditionTypeConfigured, metav1.ConditionTrue, "Configured", "All notifiers configured successfully")
 	}
+	if len(templateErrors) > 0 {
+		r.setCondition(&alertConfig, ConditionTypeTemplatesValid, metav1.ConditionFalse, "InvalidTemplate", fmt.Sprintf("Errors: %v", templateErrors))
+	} else {
+		r.setCondition(&alertConfig, ConditionTypeTemplatesValid, metav1.ConditionTrue, "Valid", "All notifier templates are valid")
+	}
 
 	// Update notifier status from stats
 	r.updateNotifierStatus(&alertConfig)
@@ -117,6 +154,8 @@ func (r *AlertConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	log.Info("AlertConfig reconciled successfully",
 		"slack_enabled", alertConfig.Spec.Slack != nil && alertConfig.Spec.Slack.Enabled,
+		"pagerduty_enabled", alertConfig.Spec.PagerDuty != nil && alertConfig.Spec.PagerDuty.Enabled,
+		"teams_enabled", alertConfig.Spec.Teams != nil && alertConfig.Spec.Teams.Enabled,
 		"webhooks_count", len(alertConfig.Spec.Webhooks),
 		"notifiers_count", len(r.AlertManager.ListNotifiers()))
 
@@ -155,6 +194,61 @@ func (r *AlertConfigReconciler) configureSlackNotifier(ctx context.Context, aler
 	// Create Slack notifier
 	notifier := alerts.NewSlackNotifier(webhookURL, slackConfig.Channel, username, iconEmoji)
 	notifier.EventFilter = slackConfig.Events
+	notifier.Template = slackConfig.Template
+
+	return r.AlertManager.AddNotifier(notifier)
+}
+
+// configurePagerDutyNotifier configures the PagerDuty notifier from AlertConfig
+func (r *AlertConfigReconciler) configurePagerDutyNotifier(ctx context.Context, alertConfig *kspecv1alpha1.AlertConfig) error {
+	pagerDutyConfig := alertConfig.Spec.PagerDuty
+
+	// Get routing key from secret or direct config
+	routingKey := pagerDutyConfig.RoutingKey
+	if pagerDutyConfig.RoutingKeySecretRef != nil {
+		secretRef := *pagerDutyConfig.RoutingKeySecretRef
+		if secretRef.Key == "" {
+			secretRef.Key = "routingKey"
+		}
+		var err error
+		routingKey, err = r.getSecretValue(ctx, alertConfig.Namespace, &secretRef)
+		if err != nil {
+			return fmt.Errorf("failed to get routing key from secret: %w", err)
+		}
+	}
+
+	if routingKey == "" {
+		return fmt.Errorf("routing key is required but not provided")
+	}
+
+	// Create PagerDuty notifier
+	notifier := alerts.NewPagerDutyNotifier(routingKey, pagerDutyConfig.EventsURL)
+	notifier.EventFilter = pagerDutyConfig.Events
+
+	return r.AlertManager.AddNotifier(notifier)
+}
+
+// configureTeamsNotifier configures the Microsoft Teams notifier from AlertConfig
+func (r *AlertConfigReconciler) configureTeamsNotifier(ctx context.Context, alertConfig *kspecv1alpha1.AlertConfig) error {
+	teamsConfig := alertConfig.Spec.Teams
+
+	// Get webhook URL from secret or direct config
+	webhookURL := teamsConfig.WebhookURL
+	if teamsConfig.WebhookURLSecretRef != nil {
+		var err error
+		webhookURL, err = r.getSecretValue(ctx, alertConfig.Namespace, teamsConfig.WebhookURLSecretRef)
+		if err != nil {
+			return fmt.Errorf("failed to get webhook URL from secret: %w", err)
+		}
+	}
+
+	if webhookURL == "" {
+		return fmt.Errorf("webhook URL is required but not provided")
+	}
+
+	// Create Teams notifier
+	notifier := alerts.NewTeamsNotifier(webhookURL)
+	notifier.EventFilter = teamsConfig.Events
 
 	return r.AlertManager.AddNotifier(notifier)
 }
@@ -18,10 +18,12 @@ package controllers
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -164,6 +166,77 @@ func TestAlertConfigReconciler_Reconcile_WebhookNotifier(t *testing.T) {
 	}
 }
 
+func TestAlertConfigReconciler_Reconcile_InvalidTemplate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = kspecv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	enabled := true
+	alertConfig := &kspecv1alpha1.AlertConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-config",
+			Namespace: "default",
+		},
+		Spec: kspecv1alpha1.AlertConfigSpec{
+			Enabled: &enabled,
+			Webhooks: []kspecv1alpha1.WebhookConfig{
+				{
+					Name:     "jira",
+					URL:      "https://example.com/jira",
+					Template: `{"summary": {{ .Title | toJson }}, "cluster": {{ .Cluster | toJson }}}`,
+				},
+				{
+					Name:     "servicenow",
+					URL:      "https://example.com/servicenow",
+					Template: `{"short_description": "{{ .ShortDescription }}"}`,
+				},
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(alertConfig).
+		WithStatusSubresource(alertConfig).
+		Build()
+
+	alertManager := alerts.NewManager(logr.Discard())
+	reconciler := NewAlertConfigReconciler(fakeClient, scheme, alertManager)
+
+	req := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "test-config",
+			Namespace: "default",
+		},
+	}
+
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	// Only the webhook with a valid template is configured
+	notifiers := alertManager.ListNotifiers()
+	if len(notifiers) != 1 || notifiers[0] != "jira" {
+		t.Errorf("Expected only the 'jira' notifier, got %v", notifiers)
+	}
+
+	var updatedConfig kspecv1alpha1.AlertConfig
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, &updatedConfig); err != nil {
+		t.Fatalf("Failed to get updated AlertConfig: %v", err)
+	}
+
+	cond := meta.FindStatusCondition(updatedConfig.Status.Conditions, ConditionTypeTemplatesValid)
+	if cond == nil {
+		t.Fatal("Expected TemplatesValid condition to be set")
+	}
+	if cond.Status != metav1.ConditionFalse || cond.Reason != "InvalidTemplate" {
+		t.Errorf("Expected TemplatesValid False/InvalidTemplate, got %s/%s", cond.Status, cond.Reason)
+	}
+	if !strings.Contains(cond.Message, "servicenow") || !strings.Contains(cond.Message, "ShortDescription") {
+		t.Errorf("Expected condition message to name the webhook and field, got: %s", cond.Message)
+	}
+}
+
 func TestAlertConfigReconciler_Reconcile_WithSecretRef(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = kspecv1alpha1.AddToScheme(scheme)
@@ -0,0 +1,277 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+	"github.com/cloudcwfranck/kspec/pkg/audit"
+	clientpkg "github.com/cloudcwfranck/kspec/pkg/client"
+	"github.com/cloudcwfranck/kspec/pkg/metrics"
+	"github.com/cloudcwfranck/kspec/pkg/reporter"
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+)
+
+// DefaultClusterScanConcurrency is the default number of clusters of one
+// ClusterSpecification scanned at once
+const DefaultClusterScanConcurrency = 4
+
+// complianceThreshold is the pass rate below which a compliance alert is sent
+const complianceThreshold = 80
+
+// clusterClientsFunc creates the clients for a cluster a ClusterSpecification
+// references. A nil reference selects the local cluster.
+type clusterClientsFunc func(
+	ctx context.Context,
+	clusterSpec *kspecv1alpha1.ClusterSpecification,
+	ref *kspecv1alpha1.ClusterReference,
+) (kubernetes.Interface, dynamic.Interface, *clientpkg.ClusterInfo, error)
+
+// clusterScan is the outcome of scanning one cluster of a ClusterSpecification
+type clusterScan struct {
+	// ref is the reference the cluster was selected by; nil for the local cluster
+	ref *kspecv1alpha1.ClusterReference
+	// primary marks the cluster drift remediation and enforcement apply to
+	primary bool
+
+	kubeClient    kubernetes.Interface
+	dynamicClient dynamic.Interface
+	clusterInfo   *clientpkg.ClusterInfo
+	result        *scanner.ScanResult
+
+	// err is set when the cluster could not be reached or scanned
+	err error
+	// failures records the non-fatal failures of reporting an additional cluster
+	failures reconcileErrors
+}
+
+// name returns the name of the scanned cluster, falling back to the reference
+// when no clients could be created
+func (s *clusterScan) name() string {
+	switch {
+	case s.clusterInfo != nil:
+		return s.clusterInfo.Name
+	case s.ref != nil:
+		return s.ref.Name
+	default:
+		return "local"
+	}
+}
+
+// clusterRefs returns the clusters a ClusterSpecification is scanned against: the
+// primary cluster (ClusterRef, or the local cluster when unset) followed by
+// ClusterRefs. References resolving to the same ClusterTarget are scanned once.
+func clusterRefs(clusterSpec *kspecv1alpha1.ClusterSpecification) []*kspecv1alpha1.ClusterReference {
+	refs := []*kspecv1alpha1.ClusterReference{clusterSpec.Spec.ClusterRef}
+	seen := map[string]bool{}
+	key := func(ref *kspecv1alpha1.ClusterReference) string {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = clusterSpec.Namespace
+		}
+		return namespace + "/" + ref.Name
+	}
+	if clusterSpec.Spec.ClusterRef != nil {
+		seen[key(clusterSpec.Spec.ClusterRef)] = true
+	}
+	for i := range clusterSpec.Spec.ClusterRefs {
+		ref := &clusterSpec.Spec.ClusterRefs[i]
+		if seen[key(ref)] {
+			continue
+		}
+		seen[key(ref)] = true
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// scanClusters scans the clusters of a ClusterSpecification concurrently, at most
+// ClusterScanConcurrency at a time. The primary cluster is returned first; its
+// report is left to the caller. Every additional cluster that was scanned gets its
+// own ComplianceReport. A failure against one cluster does not stop the others.
+func (r *ClusterSpecReconciler) scanClusters(
+	ctx context.Context,
+	clusterSpec *kspecv1alpha1.ClusterSpecification,
+	specChanges string,
+	auditLog *audit.Logger,
+) []*clusterScan {
+	refs := clusterRefs(clusterSpec)
+	scans := make([]*clusterScan, len(refs))
+
+	concurrency := r.ClusterScanConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	slots := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, ref := range refs {
+		scans[i] = &clusterScan{ref: ref, primary: i == 0}
+		wg.Add(1)
+		go func(scan *clusterScan) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			r.scanCluster(ctx, clusterSpec, scan, auditLog)
+			if !scan.primary && scan.err == nil {
+				r.reportClusterScan(ctx, clusterSpec, scan, specChanges, auditLog)
+			}
+		}(scans[i])
+	}
+	wg.Wait()
+
+	return scans
+}
+
+// scanCluster creates the clients for a cluster and runs the compliance scan,
+// recording the scan metrics and audit event
+func (r *ClusterSpecReconciler) scanCluster(
+	ctx context.Context,
+	clusterSpec *kspecv1alpha1.ClusterSpecification,
+	scan *clusterScan,
+	auditLog *audit.Logger,
+) {
+	log := log.FromContext(ctx)
+
+	createClients := r.clusterClients
+	if createClients == nil {
+		createClients = r.createClusterClients
+	}
+	kubeClient, dynamicClient, clusterInfo, err := createClients(ctx, clusterSpec, scan.ref)
+	if err != nil {
+		log.Error(err, "Failed to create cluster clients", "clusterRef", scan.ref)
+		scan.err = fmt.Errorf("cluster unreachable: %w", err)
+		return
+	}
+	scan.kubeClient, scan.dynamicClient, scan.clusterInfo = kubeClient, dynamicClient, clusterInfo
+
+	log.Info("Running compliance scan",
+		"cluster", clusterInfo.Name,
+		"isLocal", clusterInfo.IsLocal,
+		"allowEnforcement", clusterInfo.AllowEnforcement)
+	scanStartTime := time.Now()
+	scanResult, err := r.runComplianceScan(ctx, clusterSpec, kubeClient, dynamicClient)
+	scanDuration := time.Since(scanStartTime).Seconds()
+	if err != nil {
+		log.Error(err, "Failed to run compliance scan", "cluster", clusterInfo.Name)
+		auditLog.LogComplianceScan(clusterInfo.Name, clusterInfo.UID, clusterSpec.Name, 0, 0, 0, err)
+		metrics.RecordReconcileError("clusterspec", clusterSpec.Name, "scan_failed")
+		scan.err = err
+		return
+	}
+	scan.result = scanResult
+
+	metrics.RecordScanDuration(ctx, clusterInfo.Name, clusterSpec.Name, scanDuration)
+	metrics.RecordComplianceMetrics(
+		clusterInfo.Name,
+		clusterInfo.UID,
+		clusterSpec.Name,
+		scanResult.Summary.TotalChecks,
+		scanResult.Summary.Passed,
+		scanResult.Summary.Failed,
+	)
+	metrics.RecordWeightedComplianceScore(clusterInfo.Name, clusterInfo.UID, clusterSpec.Name, scanResult.Summary.WeightedScore)
+	auditLog.LogComplianceScan(
+		clusterInfo.Name,
+		clusterInfo.UID,
+		clusterSpec.Name,
+		scanResult.Summary.TotalChecks,
+		scanResult.Summary.Passed,
+		scanResult.Summary.Failed,
+		nil,
+	)
+}
+
+// createClusterClients creates the clients for a cluster through the ClientFactory
+func (r *ClusterSpecReconciler) createClusterClients(
+	ctx context.Context,
+	clusterSpec *kspecv1alpha1.ClusterSpecification,
+	ref *kspecv1alpha1.ClusterReference,
+) (kubernetes.Interface, dynamic.Interface, *clientpkg.ClusterInfo, error) {
+	if ref == nil {
+		return r.ClientFactory.CreateClientsForClusterSpec(ctx, clusterSpec)
+	}
+	return r.ClientFactory.CreateClientsForClusterRef(ctx, ref, clusterSpec.Namespace)
+}
+
+// reportClusterScan publishes the scan of an additional cluster: it creates the
+// ComplianceReport, archives the report, alerts on a low score and prunes the
+// cluster's old reports. Failures are recorded on the scan, prefixed with the
+// cluster name.
+func (r *ClusterSpecReconciler) reportClusterScan(
+	ctx context.Context,
+	clusterSpec *kspecv1alpha1.ClusterSpecification,
+	scan *clusterScan,
+	specChanges string,
+	auditLog *audit.Logger,
+) {
+	log := log.FromContext(ctx).WithValues("cluster", scan.clusterInfo.Name)
+	step := func(name string) string {
+		return scan.clusterInfo.Name + "/" + name
+	}
+
+	if err := r.createComplianceReport(ctx, clusterSpec, scan.result, scan.clusterInfo, specChanges); err != nil {
+		log.Error(err, "Failed to create ComplianceReport")
+		auditLog.LogReportGeneration("ComplianceReport", "", scan.clusterInfo.Name, err)
+		scan.failures.add(step("compliance-report"), err)
+	}
+
+	if r.ReportSink != nil {
+		name, err := reporter.WriteReport(ctx, r.ReportSink, scan.result)
+		if err != nil {
+			log.Error(err, "Failed to archive report to sink")
+			auditLog.LogReportGeneration("ArchivedReport", "", scan.clusterInfo.Name, err)
+			scan.failures.add(step("report-archive"), err)
+		} else {
+			auditLog.LogReportGeneration("ArchivedReport", name, scan.clusterInfo.Name, nil)
+		}
+	}
+
+	if score := calculatePassRate(scan.result.Summary); score < complianceThreshold {
+		r.sendComplianceAlert(ctx, clusterSpec, scan.clusterInfo, scan.result, score)
+	}
+
+	if err := r.cleanupOldReports(ctx, clusterSpec, scan.clusterInfo); err != nil {
+		log.Error(err, "Failed to cleanup old reports")
+		scan.failures.add(step("report-cleanup"), err)
+	}
+}
+
+// mergeClusterScanFailures records the failures of the additional clusters,
+// including clusters that could not be scanned at all
+func mergeClusterScanFailures(failures *reconcileErrors, scans []*clusterScan) {
+	for _, scan := range scans {
+		if scan.primary {
+			continue
+		}
+		if scan.err != nil {
+			failures.add(scan.name()+"/scan", scan.err)
+			continue
+		}
+		failures.steps = append(failures.steps, scan.failures.steps...)
+		failures.errs = append(failures.errs, scan.failures.errs...)
+	}
+}
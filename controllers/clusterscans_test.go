@@ -0,0 +1,182 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+	"github.com/cloudcwfranck/kspec/pkg/audit"
+	clientpkg "github.com/cloudcwfranck/kspec/pkg/client"
+	"github.com/cloudcwfranck/kspec/pkg/metrics"
+)
+
+// TestClusterRefs ensures the primary cluster comes first and duplicate references are scanned once
+func TestClusterRefs(t *testing.T) {
+	clusterSpec := &kspecv1alpha1.ClusterSpecification{
+		Spec: kspecv1alpha1.ClusterSpecificationSpec{
+			ClusterRef: &kspecv1alpha1.ClusterReference{Name: "prod", Namespace: "kspec-system"},
+			ClusterRefs: []kspecv1alpha1.ClusterReference{
+				{Name: "staging", Namespace: "kspec-system"},
+				{Name: "prod", Namespace: "kspec-system"},
+				{Name: "staging", Namespace: "kspec-system"},
+				{Name: "staging", Namespace: "other"},
+			},
+		},
+	}
+
+	refs := clusterRefs(clusterSpec)
+	got := []string{}
+	for _, ref := range refs {
+		got = append(got, ref.Namespace+"/"+ref.Name)
+	}
+	expected := "kspec-system/prod,kspec-system/staging,other/staging"
+	if strings.Join(got, ",") != expected {
+		t.Errorf("clusterRefs() = %v, expected %s", got, expected)
+	}
+
+	local := clusterRefs(&kspecv1alpha1.ClusterSpecification{})
+	if len(local) != 1 || local[0] != nil {
+		t.Errorf("Expected only the local cluster without references, got %v", local)
+	}
+}
+
+// TestScanClustersConcurrently ensures every referenced cluster is scanned within the
+// concurrency bound, gets its own ComplianceReport and scan duration, and that an
+// unreachable cluster does not block the others
+func TestScanClustersConcurrently(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = kspecv1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	clusterSpec := &kspecv1alpha1.ClusterSpecification{
+		ObjectMeta: metav1.ObjectMeta{Name: "fleet-spec"},
+		Spec: kspecv1alpha1.ClusterSpecificationSpec{
+			ClusterRefs: []kspecv1alpha1.ClusterReference{
+				{Name: "east"},
+				{Name: "unreachable"},
+				{Name: "west"},
+				{Name: "north"},
+			},
+		},
+	}
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	reconciler := &ClusterSpecReconciler{
+		Client:                 fakeClient,
+		Scheme:                 scheme,
+		ClusterScanConcurrency: 2,
+		clusterClients: func(ctx context.Context, _ *kspecv1alpha1.ClusterSpecification, ref *kspecv1alpha1.ClusterReference) (kubernetes.Interface, dynamic.Interface, *clientpkg.ClusterInfo, error) {
+			mu.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+			}()
+			time.Sleep(10 * time.Millisecond)
+
+			if ref == nil {
+				return kubefake.NewSimpleClientset(), nil, &clientpkg.ClusterInfo{Name: "local", IsLocal: true}, nil
+			}
+			if ref.Name == "unreachable" {
+				return nil, nil, nil, fmt.Errorf("connection refused")
+			}
+			return kubefake.NewSimpleClientset(), nil, &clientpkg.ClusterInfo{Name: ref.Name, UID: ref.Name + "-uid"}, nil
+		},
+	}
+
+	ctx := context.Background()
+	scans := reconciler.scanClusters(ctx, clusterSpec, "", audit.NewLogger(ctx))
+
+	if len(scans) != 5 {
+		t.Fatalf("Expected 5 cluster scans, got %d", len(scans))
+	}
+	if !scans[0].primary || scans[0].name() != "local" || scans[0].result == nil {
+		t.Errorf("Expected the local cluster to be scanned first as the primary cluster, got %+v", scans[0])
+	}
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 clusters scanned at once, got %d", maxInFlight)
+	}
+
+	for _, scan := range scans[1:] {
+		if scan.name() == "unreachable" {
+			if scan.err == nil || !strings.Contains(scan.err.Error(), "cluster unreachable") {
+				t.Errorf("Expected unreachable cluster error, got %v", scan.err)
+			}
+			continue
+		}
+		if scan.err != nil || scan.result == nil {
+			t.Errorf("Expected cluster %s to be scanned, got error %v", scan.name(), scan.err)
+		}
+	}
+
+	// Additional clusters are reported; the primary cluster is left to the caller
+	var reports kspecv1alpha1.ComplianceReportList
+	if err := fakeClient.List(ctx, &reports, client.MatchingLabels{"kspec.io/cluster-spec": "fleet-spec"}); err != nil {
+		t.Fatalf("Failed to list compliance reports: %v", err)
+	}
+	reported := map[string]int{}
+	for _, report := range reports.Items {
+		reported[report.Spec.ClusterName]++
+	}
+	for _, cluster := range []string{"east", "west", "north"} {
+		if reported[cluster] != 1 {
+			t.Errorf("Expected 1 ComplianceReport for cluster %s, got %d", cluster, reported[cluster])
+		}
+	}
+	if reported["local"] != 0 || reported["unreachable"] != 0 {
+		t.Errorf("Expected no reports for the primary or unreachable cluster, got %v", reported)
+	}
+
+	for _, cluster := range []string{"local", "east", "west", "north"} {
+		m := &dto.Metric{}
+		observer := metrics.ScanDuration.With(prometheus.Labels{"cluster_name": cluster, "cluster_spec": "fleet-spec"})
+		if err := observer.(prometheus.Metric).Write(m); err != nil {
+			t.Fatalf("failed to read metric: %v", err)
+		}
+		if m.GetHistogram().GetSampleCount() != 1 {
+			t.Errorf("Expected 1 scan duration sample for cluster %s, got %d", cluster, m.GetHistogram().GetSampleCount())
+		}
+	}
+
+	var failures reconcileErrors
+	mergeClusterScanFailures(&failures, scans)
+	if len(failures.steps) != 1 || failures.steps[0] != "unreachable/scan" {
+		t.Errorf("Expected only the unreachable cluster to fail, got steps %v", failures.steps)
+	}
+}
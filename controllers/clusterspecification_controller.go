@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/dynamic"
@@ -38,6 +39,7 @@ import (
 	"github.com/cloudcwfranck/kspec/pkg/drift"
 	"github.com/cloudcwfranck/kspec/pkg/enforcer/kyverno"
 	"github.com/cloudcwfranck/kspec/pkg/metrics"
+	"github.com/cloudcwfranck/kspec/pkg/reporter"
 	"github.com/cloudcwfranck/kspec/pkg/scanner"
 	"github.com/cloudcwfranck/kspec/pkg/scanner/checks"
 	"github.com/cloudcwfranck/kspec/pkg/spec"
@@ -64,6 +66,23 @@ type ClusterSpecReconciler struct {
 	LocalConfig   *rest.Config
 	ClientFactory *clientpkg.ClusterClientFactory
 	AlertManager  *alerts.Manager
+
+	// ReportSink optionally archives each scan report outside the cluster
+	ReportSink reporter.Sink
+
+	// AuditSink optionally records audit events as JSON lines
+	AuditSink *audit.Sink
+
+	// ScanTimeout bounds each compliance scan so an unresponsive cluster cannot
+	// stall reconciles. Zero disables the deadline.
+	ScanTimeout time.Duration
+
+	// ClusterScanConcurrency bounds how many clusters of a ClusterSpecification are
+	// scanned at once. Values below one scan the clusters one at a time.
+	ClusterScanConcurrency int
+
+	// clusterClients creates the clients for a scanned cluster; nil uses ClientFactory
+	clusterClients clusterClientsFunc
 }
 
 // +kubebuilder:rbac:groups=kspec.io,resources=clusterspecifications,verbs=get;list;watch;create;update;patch;delete
@@ -71,10 +90,11 @@ type ClusterSpecReconciler struct {
 // +kubebuilder:rbac:groups=kspec.io,resources=clusterspecifications/finalizers,verbs=update
 // +kubebuilder:rbac:groups=kspec.io,resources=compliancereports,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kspec.io,resources=driftreports,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kspec.io,resources=policylibraries,verbs=get;list;watch
 // +kubebuilder:rbac:groups=kyverno.io,resources=clusterpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cert-manager.io,resources=certificates/status,verbs=get
-// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations;mutatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=namespaces;pods;serviceaccounts,verbs=get;list;watch
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings;roles;rolebindings,verbs=get;list;watch
 
@@ -82,13 +102,18 @@ type ClusterSpecReconciler struct {
 // move the current state of the cluster closer to the desired state.
 func (r *ClusterSpecReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx).WithValues("clusterspec", req.NamespacedName)
-	auditLog := audit.NewLogger(ctx)
+	auditLog := audit.NewLogger(ctx).WithSink(r.AuditSink)
+
+	// Run the reconcile in a span so its duration metrics carry the trace ID as an
+	// exemplar once a tracer provider is installed
+	ctx, span := otel.Tracer(metrics.TracerName).Start(ctx, "ClusterSpecification.Reconcile")
+	defer span.End()
 
 	// Track reconciliation duration
 	startTime := time.Now()
 	defer func() {
 		duration := time.Since(startTime).Seconds()
-		metrics.RecordReconcileDuration("clusterspec", req.Name, duration)
+		metrics.RecordReconcileDuration(ctx, "clusterspec", req.Name, duration)
 	}()
 
 	// Fetch the ClusterSpecification instance
@@ -131,65 +156,63 @@ func (r *ClusterSpecReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}
 
-	// NEW: Create clients for target cluster (local or remote)
-	kubeClient, dynamicClient, clusterInfo, err := r.ClientFactory.CreateClientsForClusterSpec(ctx, &clusterSpec)
-	if err != nil {
-		log.Error(err, "Failed to create cluster clients", "clusterRef", clusterSpec.Spec.ClusterRef)
-		r.updateStatusFailed(ctx, &clusterSpec, fmt.Errorf("cluster unreachable: %w", err))
-		return ctrl.Result{RequeueAfter: DefaultRequeueAfter}, err
+	// Record on-demand reconciliation requests made via annotation
+	requestedAt, onDemand := pendingReconcileRequest(&clusterSpec)
+	if onDemand {
+		requester := reconcileRequester(&clusterSpec)
+		log.Info("On-demand reconciliation requested", "requestedAt", requestedAt, "requester", requester)
+		auditLog.LogReconcileRequest(clusterSpec.Name, requestedAt, reconcileRequestSource, requester)
 	}
 
-	log.Info("Reconciling cluster",
-		"cluster", clusterInfo.Name,
-		"isLocal", clusterInfo.IsLocal,
-		"allowEnforcement", clusterInfo.AllowEnforcement)
-
-	// Step 1: Run compliance scan using existing pkg/scanner
-	log.Info("Running compliance scan")
-	scanStartTime := time.Now()
-	scanResult, err := r.runComplianceScan(ctx, &clusterSpec, kubeClient)
-	scanDuration := time.Since(scanStartTime).Seconds()
+	// Non-fatal failures from here on are surfaced in the Degraded condition
+	var failures reconcileErrors
 
-	// Record scan metrics and audit log
+	// Note any spec edits since the last report, for the ComplianceReports
+	specChanges, err := detectSpecChanges(&clusterSpec)
 	if err != nil {
-		log.Error(err, "Failed to run compliance scan")
-		auditLog.LogComplianceScan(clusterInfo.Name, clusterInfo.UID, clusterSpec.Name, 0, 0, 0, err)
-		metrics.RecordReconcileError("clusterspec", clusterSpec.Name, "scan_failed")
-		r.updateStatusFailed(ctx, &clusterSpec, err)
-		return ctrl.Result{RequeueAfter: DefaultRequeueAfter}, err
+		log.Error(err, "Failed to detect spec changes")
+		failures.add("spec-changes", err)
 	}
 
-	// Record successful scan metrics
-	metrics.RecordScanDuration(clusterInfo.Name, clusterSpec.Name, scanDuration)
-	metrics.RecordComplianceMetrics(
-		clusterInfo.Name,
-		clusterInfo.UID,
-		clusterSpec.Name,
-		scanResult.Summary.TotalChecks,
-		scanResult.Summary.Passed,
-		scanResult.Summary.Failed,
-	)
-	auditLog.LogComplianceScan(
-		clusterInfo.Name,
-		clusterInfo.UID,
-		clusterSpec.Name,
-		scanResult.Summary.TotalChecks,
-		scanResult.Summary.Passed,
-		scanResult.Summary.Failed,
-		nil,
-	)
+	// Step 1: Run compliance scans using existing pkg/scanner. The primary cluster
+	// (local or ClusterRef) and any ClusterRefs are scanned concurrently; each
+	// additional cluster gets its ComplianceReport as soon as its scan completes.
+	scans := r.scanClusters(ctx, &clusterSpec, specChanges, auditLog)
+	mergeClusterScanFailures(&failures, scans)
+	primary := scans[0]
+	if primary.err != nil {
+		r.updateStatusFailed(ctx, &clusterSpec, primary.err)
+		return ctrl.Result{RequeueAfter: DefaultRequeueAfter}, primary.err
+	}
+	kubeClient, dynamicClient, clusterInfo, scanResult := primary.kubeClient, primary.dynamicClient, primary.clusterInfo, primary.result
+	recordExemptionMetrics(&clusterSpec, time.Now())
 
-	// Step 2: Create ComplianceReport CR
+	// Step 2: Create ComplianceReport CR for the primary cluster
 	log.Info("Creating ComplianceReport", "passRate", calculatePassRate(scanResult.Summary))
-	if err := r.createComplianceReport(ctx, &clusterSpec, scanResult, clusterInfo); err != nil {
+	if err := r.createComplianceReport(ctx, &clusterSpec, scanResult, clusterInfo, specChanges); err != nil {
 		log.Error(err, "Failed to create ComplianceReport")
 		auditLog.LogReportGeneration("ComplianceReport", "", clusterInfo.Name, err)
 		// Don't fail reconciliation if report creation fails
+		failures.add("compliance-report", err)
+	} else if err := r.recordObservedSpec(ctx, &clusterSpec, specChanges); err != nil {
+		log.Error(err, "Failed to record observed spec")
+		failures.add("observed-spec", err)
+	}
+
+	// Archive report to the configured sink, if any
+	if r.ReportSink != nil {
+		name, err := reporter.WriteReport(ctx, r.ReportSink, scanResult)
+		if err != nil {
+			log.Error(err, "Failed to archive report to sink")
+			auditLog.LogReportGeneration("ArchivedReport", "", clusterInfo.Name, err)
+			failures.add("report-archive", err)
+		} else {
+			auditLog.LogReportGeneration("ArchivedReport", name, clusterInfo.Name, nil)
+		}
 	}
 
 	// Send compliance alert if score is below threshold (default: 80%)
 	complianceScore := calculatePassRate(scanResult.Summary)
-	complianceThreshold := 80
 	if complianceScore < complianceThreshold {
 		r.sendComplianceAlert(ctx, &clusterSpec, clusterInfo, scanResult, complianceScore)
 	}
@@ -201,6 +224,7 @@ func (r *ClusterSpecReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		log.Error(err, "Failed to detect drift")
 		auditLog.LogDriftDetection(clusterInfo.Name, clusterInfo.UID, clusterSpec.Name, false, 0, err)
 		// Continue even if drift detection fails
+		failures.add("drift-detection", err)
 	} else if driftReport != nil {
 		// Record drift metrics
 		eventCount := len(driftReport.Events)
@@ -231,6 +255,7 @@ func (r *ClusterSpecReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			if err := r.createDriftReport(ctx, &clusterSpec, driftReport, clusterInfo); err != nil {
 				log.Error(err, "Failed to create DriftReport")
 				auditLog.LogReportGeneration("DriftReport", "", clusterInfo.Name, err)
+				failures.add("drift-report", err)
 			}
 
 			// Send drift detection alert
@@ -242,6 +267,7 @@ func (r *ClusterSpecReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				if err := r.remediateDrift(ctx, &clusterSpec, driftReport, kubeClient, dynamicClient, clusterInfo, auditLog); err != nil {
 					log.Error(err, "Failed to remediate drift")
 					// Continue even if remediation fails
+					failures.add("drift-remediation", err)
 				} else {
 					// Send remediation success alert
 					r.sendRemediationAlert(ctx, &clusterSpec, clusterInfo, driftReport)
@@ -259,12 +285,13 @@ func (r *ClusterSpecReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if err := r.managePolicyEnforcement(ctx, &clusterSpec, dynamicClient); err != nil {
 			log.Error(err, "Failed to manage policy enforcement")
 			// Continue even if policy enforcement fails (non-fatal)
+			failures.add("policy-enforcement", err)
 		} else {
 			// Count generated policies for status
 			if clusterSpec.Spec.Enforcement != nil && clusterSpec.Spec.Enforcement.Enabled {
-				generator := kyverno.NewGenerator()
+				generator := kyverno.NewGenerator().WithNamespaceScope(policyNamespaceScope(&clusterSpec))
 				specForCounting := &spec.ClusterSpecification{
-					Metadata: spec.Metadata{Name: clusterSpec.Name},
+					Metadata: specMetadata(&clusterSpec),
 					Spec:     clusterSpec.Spec.SpecFields,
 				}
 				policies, _ := generator.GeneratePolicies(specForCounting)
@@ -286,6 +313,7 @@ func (r *ClusterSpecReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if err != nil {
 			log.Error(err, "Failed to manage certificate")
 			// Continue even if certificate management fails (non-fatal)
+			failures.add("webhook-certificate", err)
 		}
 		certificateReady = certReady
 	} else {
@@ -301,27 +329,38 @@ func (r *ClusterSpecReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if err := r.manageValidatingWebhook(ctx, &clusterSpec); err != nil {
 			log.Error(err, "Failed to manage ValidatingWebhookConfiguration")
 			// Continue even if webhook config management fails (non-fatal)
+			failures.add("webhook-configuration", err)
+		}
+		if err := r.manageMutatingWebhook(ctx, &clusterSpec); err != nil {
+			log.Error(err, "Failed to manage MutatingWebhookConfiguration")
+			failures.add("mutating-webhook-configuration", err)
 		}
 	} else {
 		log.Info("Skipping webhook configuration (enforcement not allowed on this cluster)")
 	}
 
-	// Step 6: Update ClusterSpecification status
-	if err := r.updateStatus(ctx, &clusterSpec, scanResult, driftReport); err != nil {
-		log.Error(err, "Failed to update status")
-		return ctrl.Result{}, err
-	}
-
-	// Step 7: Clean up old reports
+	// Step 6: Clean up old reports
 	if err := r.cleanupOldReports(ctx, &clusterSpec, clusterInfo); err != nil {
 		log.Error(err, "Failed to cleanup old reports")
 		// Don't fail reconciliation if cleanup fails
+		failures.add("report-cleanup", err)
+	}
+
+	// Step 7: Update ClusterSpecification status
+	if onDemand {
+		clusterSpec.Status.LastHandledReconcileAt = requestedAt
+	}
+	if err := r.updateStatus(ctx, &clusterSpec, scanResult, driftReport, &failures); err != nil {
+		log.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
 	}
 
 	log.Info("Reconciliation complete",
 		"cluster", clusterInfo.Name,
+		"clusters", len(scans),
 		"phase", clusterSpec.Status.Phase,
-		"score", clusterSpec.Status.ComplianceScore)
+		"score", clusterSpec.Status.ComplianceScore,
+		"failedSteps", failures.steps)
 
 	// Requeue after configured interval for continuous monitoring
 	return ctrl.Result{RequeueAfter: DefaultRequeueAfter}, nil
@@ -400,6 +439,10 @@ func (r *ClusterSpecReconciler) handleDeletion(ctx context.Context, clusterSpec
 		log.Error(err, "Failed to cleanup ValidatingWebhookConfiguration")
 		// Continue even if cleanup fails
 	}
+	if err := r.cleanupMutatingWebhook(ctx); err != nil {
+		log.Error(err, "Failed to cleanup MutatingWebhookConfiguration")
+		// Continue even if cleanup fails
+	}
 
 	// Remove finalizer
 	controllerutil.RemoveFinalizer(clusterSpec, FinalizerName)
@@ -412,29 +455,35 @@ func (r *ClusterSpecReconciler) handleDeletion(ctx context.Context, clusterSpec
 	return ctrl.Result{}, nil
 }
 
+// specMetadata returns the spec metadata for a ClusterSpecification. Ownership is
+// read from the kspec.io/owner and kspec.io/team labels.
+func specMetadata(clusterSpec *kspecv1alpha1.ClusterSpecification) spec.Metadata {
+	return spec.Metadata{
+		Name:    clusterSpec.Name,
+		Version: clusterSpec.ResourceVersion,
+		Owner:   clusterSpec.Labels[spec.OwnerLabel],
+		Team:    clusterSpec.Labels[spec.TeamLabel],
+	}
+}
+
 // runComplianceScan runs a compliance scan using the existing scanner
-func (r *ClusterSpecReconciler) runComplianceScan(ctx context.Context, clusterSpec *kspecv1alpha1.ClusterSpecification, kubeClient kubernetes.Interface) (*scanner.ScanResult, error) {
+func (r *ClusterSpecReconciler) runComplianceScan(ctx context.Context, clusterSpec *kspecv1alpha1.ClusterSpecification, kubeClient kubernetes.Interface, dynamicClient dynamic.Interface) (*scanner.ScanResult, error) {
 	// Convert ClusterSpecification to spec.ClusterSpecification
 	specToScan := &spec.ClusterSpecification{
-		Metadata: spec.Metadata{
-			Name:    clusterSpec.Name,
-			Version: clusterSpec.ResourceVersion,
-		},
-		Spec: clusterSpec.Spec.SpecFields,
+		Metadata: specMetadata(clusterSpec),
+		Spec:     clusterSpec.Spec.SpecFields,
 	}
 
-	// Create scanner with all checks
-	checkList := []scanner.Check{
-		&checks.KubernetesVersionCheck{},
-		&checks.PodSecurityStandardsCheck{},
-		&checks.NetworkPolicyCheck{},
-		&checks.WorkloadSecurityCheck{},
-		&checks.RBACCheck{},
-		&checks.AdmissionCheck{},
-		&checks.ObservabilityCheck{},
+	checkList := checks.All(dynamicClient, "")
+
+	if err := scanner.ValidateSeverityOverrides(specToScan.Spec.SeverityOverrides, checkList); err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+	if err := scanner.ValidateScoringWeights(specToScan.Spec.Scoring, checkList); err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
 	}
 
-	scannerInstance := scanner.NewScanner(kubeClient, checkList)
+	scannerInstance := scanner.NewScanner(kubeClient, checkList).WithScanTimeout(r.ScanTimeout)
 
 	// Run scan using scanner
 	result, err := scannerInstance.Scan(ctx, specToScan)
@@ -449,11 +498,8 @@ func (r *ClusterSpecReconciler) runComplianceScan(ctx context.Context, clusterSp
 func (r *ClusterSpecReconciler) detectDrift(ctx context.Context, clusterSpec *kspecv1alpha1.ClusterSpecification, kubeClient kubernetes.Interface, dynamicClient dynamic.Interface) (*drift.DriftReport, error) {
 	// Convert ClusterSpecification to spec.ClusterSpecification
 	specToCheck := &spec.ClusterSpecification{
-		Metadata: spec.Metadata{
-			Name:    clusterSpec.Name,
-			Version: clusterSpec.ResourceVersion,
-		},
-		Spec: clusterSpec.Spec.SpecFields,
+		Metadata: specMetadata(clusterSpec),
+		Spec:     clusterSpec.Spec.SpecFields,
 	}
 
 	// Create drift detector
@@ -464,6 +510,7 @@ func (r *ClusterSpecReconciler) detectDrift(ctx context.Context, clusterSpec *ks
 		EnabledTypes: []drift.DriftType{
 			drift.DriftTypePolicy,
 			drift.DriftTypeCompliance,
+			drift.DriftTypeRBAC,
 		},
 	}
 
@@ -479,11 +526,8 @@ func (r *ClusterSpecReconciler) detectDrift(ctx context.Context, clusterSpec *ks
 func (r *ClusterSpecReconciler) remediateDrift(ctx context.Context, clusterSpec *kspecv1alpha1.ClusterSpecification, driftReport *drift.DriftReport, kubeClient kubernetes.Interface, dynamicClient dynamic.Interface, clusterInfo *clientpkg.ClusterInfo, auditLog *audit.Logger) error {
 	// Convert to spec.ClusterSpecification
 	specToRemediate := &spec.ClusterSpecification{
-		Metadata: spec.Metadata{
-			Name:    clusterSpec.Name,
-			Version: clusterSpec.ResourceVersion,
-		},
-		Spec: clusterSpec.Spec.SpecFields,
+		Metadata: specMetadata(clusterSpec),
+		Spec:     clusterSpec.Spec.SpecFields,
 	}
 
 	// Remediate using existing drift.RemediateAll
@@ -536,6 +580,18 @@ func (r *ClusterSpecReconciler) sendComplianceAlert(ctx context.Context, cluster
 	}
 
 	log := log.FromContext(ctx)
+
+	failedChecks := []string{}
+	criticalFailed := 0
+	for _, result := range scanResult.Results {
+		if result.Status == scanner.StatusFail {
+			failedChecks = append(failedChecks, result.Name)
+			if result.Severity == scanner.SeverityCritical {
+				criticalFailed++
+			}
+		}
+	}
+
 	alert := alerts.Alert{
 		Level:       alerts.AlertLevelWarning,
 		Title:       "Compliance score below threshold",
@@ -549,11 +605,13 @@ func (r *ClusterSpecReconciler) sendComplianceAlert(ctx context.Context, cluster
 			"platform":    clusterInfo.Platform,
 		},
 		Metadata: map[string]interface{}{
-			"score":        score,
-			"total_checks": scanResult.Summary.TotalChecks,
-			"passed":       scanResult.Summary.Passed,
-			"failed":       scanResult.Summary.Failed,
-			"cluster":      clusterInfo.Name,
+			"score":           score,
+			"total_checks":    scanResult.Summary.TotalChecks,
+			"passed":          scanResult.Summary.Passed,
+			"failed":          scanResult.Summary.Failed,
+			"critical_failed": criticalFailed,
+			"failed_checks":   failedChecks,
+			"cluster":         clusterInfo.Name,
 		},
 	}
 
@@ -662,5 +720,8 @@ func NewClusterSpecReconciler(
 		LocalConfig:   localConfig,
 		ClientFactory: clientFactory,
 		AlertManager:  alertManager,
+		ScanTimeout:   scanner.DefaultScanTimeout,
+
+		ClusterScanConcurrency: DefaultClusterScanConcurrency,
 	}
 }
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
@@ -51,6 +52,9 @@ type ClusterTargetReconciler struct {
 	Scheme        *runtime.Scheme
 	LocalConfig   *rest.Config
 	ClientFactory *clientpkg.ClusterClientFactory
+
+	// AuditSink optionally records audit events as JSON lines
+	AuditSink *audit.Sink
 }
 
 // +kubebuilder:rbac:groups=kspec.io,resources=clustertargets,verbs=get;list;watch;create;update;patch;delete
@@ -65,7 +69,11 @@ func (r *ClusterTargetReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	// Fetch the ClusterTarget instance
 	var clusterTarget kspecv1alpha1.ClusterTarget
 	if err := r.Get(ctx, req.NamespacedName, &clusterTarget); err != nil {
-		log.Info("ClusterTarget resource not found, ignoring since object must be deleted")
+		if apierrors.IsNotFound(err) {
+			// Release the cached clients of the deleted target
+			log.Info("ClusterTarget resource not found, releasing cached clients")
+			r.ClientFactory.Evict(req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
@@ -88,36 +96,25 @@ func (r *ClusterTargetReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 // healthCheck performs a health check on the cluster
 func (r *ClusterTargetReconciler) healthCheck(ctx context.Context, clusterTarget *kspecv1alpha1.ClusterTarget) error {
 	log := log.FromContext(ctx)
-	auditLog := audit.NewLogger(ctx)
+	auditLog := audit.NewLogger(ctx).WithSink(r.AuditSink)
 
 	now := metav1.Now()
 	clusterTarget.Status.LastChecked = &now
 
-	// Try to create clients for this cluster
-	kubeClient, _, clusterInfo, err := r.ClientFactory.CreateClientsForClusterSpec(
-		ctx,
-		&kspecv1alpha1.ClusterSpecification{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "health-check",
-				Namespace: clusterTarget.Namespace,
-			},
-			Spec: kspecv1alpha1.ClusterSpecificationSpec{
-				ClusterRef: &kspecv1alpha1.ClusterReference{
-					Name:      clusterTarget.Name,
-					Namespace: clusterTarget.Namespace,
-				},
-			},
-		},
-	)
-
+	// Connect to the cluster and gather its details
+	health, err := r.ClientFactory.CheckClusterTarget(ctx, clusterTarget)
 	if err != nil {
 		// Cluster unreachable or credentials invalid
 		clusterTarget.Status.Reachable = false
 		r.setCondition(clusterTarget, ConditionTypeReady, metav1.ConditionFalse, "Unreachable", err.Error())
 
-		// Check if it's a credential issue
-		if isCredentialError(err) {
+		// Credentials are only known to be invalid when they could not be loaded or
+		// the API server rejected them; otherwise they could not be verified
+		switch clientpkg.CredentialStatus(err) {
+		case metav1.ConditionFalse:
 			r.setCondition(clusterTarget, ConditionTypeCredentialsValid, metav1.ConditionFalse, "InvalidCredentials", err.Error())
+		default:
+			r.setCondition(clusterTarget, ConditionTypeCredentialsValid, metav1.ConditionUnknown, "CredentialsNotVerified", err.Error())
 		}
 
 		// Record failed health check metrics
@@ -129,19 +126,13 @@ func (r *ClusterTargetReconciler) healthCheck(ctx context.Context, clusterTarget
 
 	// Successfully connected - update status with cluster info
 	clusterTarget.Status.Reachable = true
-	clusterTarget.Status.UID = clusterInfo.UID
-	clusterTarget.Status.Version = clusterInfo.Version
+	clusterTarget.Status.UID = health.UID
+	clusterTarget.Status.Version = health.Version
+	clusterTarget.Status.NodeCount = health.NodeCount
 
 	// Detect platform if not already set
 	if clusterTarget.Status.Platform == "" || clusterTarget.Status.Platform == "unknown" {
-		platform := clientpkg.DetectPlatform(ctx, kubeClient)
-		clusterTarget.Status.Platform = platform
-	}
-
-	// Count nodes
-	nodes, err := kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
-	if err == nil {
-		clusterTarget.Status.NodeCount = int32(len(nodes.Items))
+		clusterTarget.Status.Platform = health.Platform
 	}
 
 	// Set success conditions
@@ -208,50 +199,6 @@ func (r *ClusterTargetReconciler) setCondition(
 	})
 }
 
-// isCredentialError checks if an error is related to invalid credentials
-func isCredentialError(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	errMsg := err.Error()
-	// Check for common credential error patterns
-	credErrorPatterns := []string{
-		"failed to get secret",
-		"secret does not contain key",
-		"secret is empty",
-		"failed to get kubeconfig",
-		"failed to get token",
-		"unauthorized",
-		"forbidden",
-		"authentication",
-	}
-
-	for _, pattern := range credErrorPatterns {
-		if contains(errMsg, pattern) {
-			return true
-		}
-	}
-
-	return false
-}
-
-// contains checks if a string contains a substring (case-insensitive)
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) &&
-		(s[:len(substr)] == substr || s[len(s)-len(substr):] == substr ||
-			containsMiddle(s, substr)))
-}
-
-func containsMiddle(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
-}
-
 // SetupWithManager sets up the controller with the Manager
 func (r *ClusterTargetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -16,6 +16,18 @@ import (
 	"github.com/cloudcwfranck/kspec/pkg/spec"
 )
 
+// policyNamespaceScope converts the ClusterSpec's namespace scope into the
+// scope applied to generated policies.
+func policyNamespaceScope(clusterSpec *kspecv1alpha1.ClusterSpecification) kyverno.NamespaceScope {
+	if clusterSpec.Spec.NamespaceScope == nil {
+		return kyverno.NamespaceScope{}
+	}
+	return kyverno.NamespaceScope{
+		IncludeNamespaces: clusterSpec.Spec.NamespaceScope.IncludeNamespaces,
+		ExcludeNamespaces: clusterSpec.Spec.NamespaceScope.ExcludeNamespaces,
+	}
+}
+
 // managePolicyEnforcement handles policy generation and application
 func (r *ClusterSpecReconciler) managePolicyEnforcement(
 	ctx context.Context,
@@ -39,13 +51,10 @@ func (r *ClusterSpecReconciler) managePolicyEnforcement(
 	log.Info("Managing policy enforcement", "mode", mode)
 
 	// Generate policies from ClusterSpec
-	generator := kyverno.NewGenerator()
+	generator := kyverno.NewGenerator().WithNamespaceScope(policyNamespaceScope(clusterSpec))
 	specForGeneration := &spec.ClusterSpecification{
-		Metadata: spec.Metadata{
-			Name:    clusterSpec.Name,
-			Version: clusterSpec.ResourceVersion,
-		},
-		Spec: clusterSpec.Spec.SpecFields,
+		Metadata: specMetadata(clusterSpec),
+		Spec:     clusterSpec.Spec.SpecFields,
 	}
 
 	policies, err := generator.GeneratePolicies(specForGeneration)
@@ -72,7 +81,8 @@ func (r *ClusterSpecReconciler) managePolicyEnforcement(
 			continue
 		case "audit":
 			policy.Spec.ValidationFailureAction = kyverno.Audit
-		case "enforce":
+		case "enforce", "mutate":
+			// In mutate mode the mutating webhook fixes what it can before validation
 			policy.Spec.ValidationFailureAction = kyverno.Enforce
 		default:
 			log.Info("Unknown enforcement mode, defaulting to audit", "mode", mode)
@@ -0,0 +1,61 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strings"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+)
+
+const (
+	// ReconcileNowAnnotation requests an immediate reconciliation when set to a new value
+	// (e.g. the current timestamp). Any update to the ClusterSpecification triggers a
+	// reconcile, so no extra endpoint is needed; the handled value is recorded in
+	// status.lastHandledReconcileAt.
+	ReconcileNowAnnotation = "kspec.io/reconcile-now"
+
+	// reconcileRequestSource identifies annotation-triggered requests in audit events
+	reconcileRequestSource = "annotation"
+
+	// unknownRequester is reported when the field manager that set the annotation can't be determined
+	unknownRequester = "unknown"
+)
+
+// pendingReconcileRequest returns the requested-at value of an on-demand reconciliation
+// that has not been handled yet
+func pendingReconcileRequest(clusterSpec *kspecv1alpha1.ClusterSpecification) (string, bool) {
+	requestedAt, ok := clusterSpec.Annotations[ReconcileNowAnnotation]
+	if !ok || requestedAt == "" || requestedAt == clusterSpec.Status.LastHandledReconcileAt {
+		return "", false
+	}
+	return requestedAt, true
+}
+
+// reconcileRequester returns the field manager (e.g. "kubectl-annotate") that last
+// set the reconcile-now annotation, based on the object's managed fields
+func reconcileRequester(clusterSpec *kspecv1alpha1.ClusterSpecification) string {
+	fieldKey := `"f:` + ReconcileNowAnnotation + `"`
+
+	for _, entry := range clusterSpec.ManagedFields {
+		if entry.FieldsV1 != nil && strings.Contains(string(entry.FieldsV1.Raw), fieldKey) {
+			return entry.Manager
+		}
+	}
+
+	return unknownRequester
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+)
+
+// TestPendingReconcileRequest ensures each annotation value is only handled once
+func TestPendingReconcileRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		lastHandled   string
+		expectPending bool
+	}{
+		{
+			name:          "no annotation",
+			expectPending: false,
+		},
+		{
+			name:          "new request",
+			annotations:   map[string]string{ReconcileNowAnnotation: "1700000000"},
+			expectPending: true,
+		},
+		{
+			name:          "already handled",
+			annotations:   map[string]string{ReconcileNowAnnotation: "1700000000"},
+			lastHandled:   "1700000000",
+			expectPending: false,
+		},
+		{
+			name:          "newer request",
+			annotations:   map[string]string{ReconcileNowAnnotation: "1700000100"},
+			lastHandled:   "1700000000",
+			expectPending: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterSpec := &kspecv1alpha1.ClusterSpecification{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-spec", Annotations: tt.annotations},
+				Status:     kspecv1alpha1.ClusterSpecificationStatus{LastHandledReconcileAt: tt.lastHandled},
+			}
+
+			requestedAt, pending := pendingReconcileRequest(clusterSpec)
+			if pending != tt.expectPending {
+				t.Errorf("pendingReconcileRequest() pending = %v, expected %v", pending, tt.expectPending)
+			}
+			if pending && requestedAt != tt.annotations[ReconcileNowAnnotation] {
+				t.Errorf("pendingReconcileRequest() requestedAt = %q, expected %q", requestedAt, tt.annotations[ReconcileNowAnnotation])
+			}
+		})
+	}
+}
+
+// TestReconcileRequester ensures the field manager that set the annotation is reported
+func TestReconcileRequester(t *testing.T) {
+	clusterSpec := &kspecv1alpha1.ClusterSpecification{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-spec",
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:  "manager",
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:finalizers":{}}}`)},
+				},
+				{
+					Manager:  "kubectl-annotate",
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:annotations":{"f:kspec.io/reconcile-now":{}}}}`)},
+				},
+			},
+		},
+	}
+
+	if requester := reconcileRequester(clusterSpec); requester != "kubectl-annotate" {
+		t.Errorf("reconcileRequester() = %q, expected %q", requester, "kubectl-annotate")
+	}
+
+	clusterSpec.ManagedFields = nil
+	if requester := reconcileRequester(clusterSpec); requester != unknownRequester {
+		t.Errorf("reconcileRequester() = %q, expected %q", requester, unknownRequester)
+	}
+}
@@ -18,17 +18,23 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
 	clientpkg "github.com/cloudcwfranck/kspec/pkg/client"
 	"github.com/cloudcwfranck/kspec/pkg/drift"
+	"github.com/cloudcwfranck/kspec/pkg/metrics"
+	"github.com/cloudcwfranck/kspec/pkg/policy"
 	"github.com/cloudcwfranck/kspec/pkg/scanner"
 )
 
@@ -38,12 +44,11 @@ func (r *ClusterSpecReconciler) createComplianceReport(
 	clusterSpec *kspecv1alpha1.ClusterSpecification,
 	scanResult *scanner.ScanResult,
 	clusterInfo *clientpkg.ClusterInfo,
+	specChanges string,
 ) error {
 	log := log.FromContext(ctx)
 
-	// Generate report name with timestamp (including milliseconds to avoid collisions)
-	timestamp := time.Now().UTC().Format("20060102-150405.000000")
-	reportName := fmt.Sprintf("%s-%s-%s", clusterInfo.Name, clusterSpec.Name, timestamp)
+	namePrefix := fmt.Sprintf("%s-%s", clusterInfo.Name, clusterSpec.Name)
 
 	// Convert scanner.CheckResult to kspecv1alpha1.CheckResult
 	results := make([]kspecv1alpha1.CheckResult, len(scanResult.Results))
@@ -54,20 +59,31 @@ func (r *ClusterSpecReconciler) createComplianceReport(
 			Status:   normalizeStatus(string(result.Status)),
 			Severity: normalizeSeverity(string(result.Severity)),
 			Message:  result.Message,
-			Details:  nil, // TODO: Convert evidence to runtime.RawExtension
+			Details:  evidenceDetails(result.Evidence),
 		}
 	}
 
+	scanTime := time.Now().UTC()
+	exemptions, warnings := exemptionRecords(clusterSpec, scanTime)
+	for _, warning := range warnings {
+		log.Info("Policy exemption warning", "warning", warning)
+	}
+
+	labels := map[string]string{
+		"kspec.io/cluster-spec": clusterSpec.Name,
+		"kspec.io/cluster-name": clusterInfo.Name,
+		"kspec.io/report-type":  "compliance",
+	}
+	for key, value := range specMetadata(clusterSpec).OwnershipLabels() {
+		labels[key] = value
+	}
+
 	// Create ComplianceReport
 	report := &kspecv1alpha1.ComplianceReport{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      reportName,
-			Namespace: ReportNamespace,
-			Labels: map[string]string{
-				"kspec.io/cluster-spec": clusterSpec.Name,
-				"kspec.io/cluster-name": clusterInfo.Name,
-				"kspec.io/report-type":  "compliance",
-			},
+			Namespace:   ReportNamespace,
+			Labels:      labels,
+			Annotations: specChangeAnnotations(specChanges, clusterSpec.Generation),
 		},
 		Spec: kspecv1alpha1.ComplianceReportSpec{
 			ClusterSpecRef: kspecv1alpha1.ObjectReference{
@@ -76,14 +92,16 @@ func (r *ClusterSpecReconciler) createComplianceReport(
 			},
 			ClusterName: clusterInfo.Name,
 			ClusterUID:  clusterInfo.UID,
-			ScanTime:    metav1.Time{Time: time.Now().UTC()},
+			ScanTime:    metav1.Time{Time: scanTime},
 			Summary: kspecv1alpha1.ReportSummary{
 				Total:    scanResult.Summary.TotalChecks,
 				Passed:   scanResult.Summary.Passed,
 				Failed:   scanResult.Summary.Failed,
 				PassRate: calculatePassRate(scanResult.Summary),
 			},
-			Results: results,
+			Results:    results,
+			Exemptions: exemptions,
+			Warnings:   warnings,
 		},
 		Status: kspecv1alpha1.ComplianceReportStatus{
 			Phase: "Completed",
@@ -94,11 +112,11 @@ func (r *ClusterSpecReconciler) createComplianceReport(
 	// while reports are namespaced. Cleanup is handled via finalizers instead.
 
 	// Create the report
-	if err := r.Create(ctx, report); err != nil {
+	if err := r.createReport(ctx, report, namePrefix); err != nil {
 		return fmt.Errorf("failed to create ComplianceReport: %w", err)
 	}
 
-	log.Info("ComplianceReport created", "name", reportName, "passRate", report.Spec.Summary.PassRate)
+	log.Info("ComplianceReport created", "name", report.Name, "passRate", report.Spec.Summary.PassRate)
 	return nil
 }
 
@@ -111,9 +129,7 @@ func (r *ClusterSpecReconciler) createDriftReport(
 ) error {
 	log := log.FromContext(ctx)
 
-	// Generate report name with timestamp (including milliseconds to avoid collisions)
-	timestamp := time.Now().UTC().Format("20060102-150405.000000")
-	reportName := fmt.Sprintf("%s-%s-drift-%s", clusterInfo.Name, clusterSpec.Name, timestamp)
+	namePrefix := fmt.Sprintf("%s-%s-drift", clusterInfo.Name, clusterSpec.Name)
 
 	// Convert drift.DriftEvent to kspecv1alpha1.DriftEvent
 	events := make([]kspecv1alpha1.DriftEvent, len(driftReport.Events))
@@ -166,16 +182,20 @@ func (r *ClusterSpecReconciler) createDriftReport(
 		}
 	}
 
+	labels := map[string]string{
+		"kspec.io/cluster-spec": clusterSpec.Name,
+		"kspec.io/cluster-name": clusterInfo.Name,
+		"kspec.io/severity":     severity,
+	}
+	for key, value := range specMetadata(clusterSpec).OwnershipLabels() {
+		labels[key] = value
+	}
+
 	// Create DriftReport
 	report := &kspecv1alpha1.DriftReport{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      reportName,
 			Namespace: ReportNamespace,
-			Labels: map[string]string{
-				"kspec.io/cluster-spec": clusterSpec.Name,
-				"kspec.io/cluster-name": clusterInfo.Name,
-				"kspec.io/severity":     severity,
-			},
+			Labels:    labels,
 		},
 		Spec: kspecv1alpha1.DriftReportSpec{
 			ClusterSpecRef: kspecv1alpha1.ObjectReference{
@@ -201,14 +221,38 @@ func (r *ClusterSpecReconciler) createDriftReport(
 	// while reports are namespaced. Cleanup is handled via finalizers instead.
 
 	// Create the report
-	if err := r.Create(ctx, report); err != nil {
+	if err := r.createReport(ctx, report, namePrefix); err != nil {
 		return fmt.Errorf("failed to create DriftReport: %w", err)
 	}
 
-	log.Info("DriftReport created", "name", reportName, "events", len(events))
+	log.Info("DriftReport created", "name", report.Name, "events", len(events))
 	return nil
 }
 
+// reportNameAttempts bounds how often a report name is regenerated after a collision
+const reportNameAttempts = 3
+
+// generateReportName returns a report name made of the prefix, the current time and
+// a short random suffix, so reports created within the same second do not collide
+func generateReportName(prefix string) string {
+	timestamp := time.Now().UTC().Format("20060102-150405")
+	return fmt.Sprintf("%s-%s-%s", prefix, timestamp, utilrand.String(5))
+}
+
+// createReport creates a report under a generated name. If the name is already
+// taken, the name is regenerated and the create retried.
+func (r *ClusterSpecReconciler) createReport(ctx context.Context, report client.Object, namePrefix string) error {
+	var err error
+	for attempt := 0; attempt < reportNameAttempts; attempt++ {
+		report.SetName(generateReportName(namePrefix))
+		if err = r.Create(ctx, report); !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		log.FromContext(ctx).V(1).Info("Report name already taken, retrying", "name", report.GetName())
+	}
+	return err
+}
+
 // cleanupOldReports deletes old reports to maintain retention policy
 func (r *ClusterSpecReconciler) cleanupOldReports(ctx context.Context, clusterSpec *kspecv1alpha1.ClusterSpecification, clusterInfo *clientpkg.ClusterInfo) error {
 	log := log.FromContext(ctx)
@@ -308,6 +352,76 @@ func countPendingEvents(events []kspecv1alpha1.DriftEvent) int {
 	return count
 }
 
+// evidenceDetails stores a check's evidence as report details, so stored reports
+// can be re-evaluated later (e.g. by kspec report replay). Evidence that cannot
+// be encoded is dropped rather than failing the report.
+func evidenceDetails(evidence map[string]interface{}) *runtime.RawExtension {
+	if len(evidence) == 0 {
+		return nil
+	}
+	raw, err := json.Marshal(evidence)
+	if err != nil {
+		return nil
+	}
+	return &runtime.RawExtension{Raw: raw}
+}
+
+// exemptionRecords records the policy exemptions of a ClusterSpecification for
+// a report, with warnings for exemptions that had expired at scanTime or whose
+// ticket reference does not match the spec's exemption ticket pattern
+func exemptionRecords(clusterSpec *kspecv1alpha1.ClusterSpecification, scanTime time.Time) ([]kspecv1alpha1.ExemptionRecord, []string) {
+	var records []kspecv1alpha1.ExemptionRecord
+	var warnings []string
+
+	for _, exemption := range clusterSpec.Spec.PolicyExemptions {
+		record := kspecv1alpha1.ExemptionRecord{
+			Name:      exemption.Name,
+			TicketRef: exemption.TicketRef,
+			Approver:  exemption.Approver,
+			Reason:    exemption.Reason,
+			ExpiresAt: exemption.ExpiresAt,
+			Expired:   exemption.ExpiresAt != nil && scanTime.After(exemption.ExpiresAt.Time),
+		}
+		records = append(records, record)
+
+		if record.Expired {
+			warnings = append(warnings, fmt.Sprintf("Exemption %s%s expired at %s and is still configured",
+				exemption.Name, ticketSuffix(exemption.TicketRef), exemption.ExpiresAt.UTC().Format(time.RFC3339)))
+		}
+		if err := policy.ValidateTicketRef(clusterSpec.Spec.ExemptionTicketPattern, exemption.TicketRef); err != nil {
+			warnings = append(warnings, fmt.Sprintf("Exemption %s is not honored: %v", exemption.Name, err))
+		}
+	}
+
+	return records, warnings
+}
+
+// recordExemptionMetrics records the unexpired policy exemptions of a
+// ClusterSpecification and when they expire
+func recordExemptionMetrics(clusterSpec *kspecv1alpha1.ClusterSpecification, now time.Time) {
+	active := 0
+	expiries := map[string]time.Time{}
+	for _, exemption := range clusterSpec.Spec.PolicyExemptions {
+		if exemption.ExpiresAt != nil && now.After(exemption.ExpiresAt.Time) {
+			continue
+		}
+		active++
+		if exemption.ExpiresAt != nil {
+			expiries[exemption.Name] = exemption.ExpiresAt.Time
+		}
+	}
+
+	metrics.RecordExemptionMetrics(clusterSpec.Name, active, expiries)
+}
+
+// ticketSuffix formats a ticket reference for warning messages
+func ticketSuffix(ticketRef string) string {
+	if ticketRef == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (ticket %s)", ticketRef)
+}
+
 // inferCategory infers the check category from the check name
 func inferCategory(checkName string) string {
 	// Check names follow the pattern "category.subcategory" (e.g., "kubernetes.version")
@@ -370,7 +484,7 @@ func normalizeSeverity(severity string) string {
 }
 
 // normalizeType converts drift type values to CRD-compliant capitalized values
-// DriftReport CRD requires: Policy, Compliance, Configuration (capitalized)
+// DriftReport CRD requires: Policy, Compliance, Configuration, RBAC (capitalized)
 func normalizeType(driftType string) string {
 	switch driftType {
 	case "policy":
@@ -379,7 +493,9 @@ func normalizeType(driftType string) string {
 		return "Compliance"
 	case "configuration":
 		return "Configuration"
-	case "Policy", "Compliance", "Configuration":
+	case "rbac":
+		return "RBAC"
+	case "Policy", "Compliance", "Configuration", "RBAC":
 		// Already in correct format
 		return driftType
 	default:
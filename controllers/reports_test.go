@@ -17,9 +17,26 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
+	"strings"
 	"testing"
-
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+	clientpkg "github.com/cloudcwfranck/kspec/pkg/client"
+	"github.com/cloudcwfranck/kspec/pkg/drift"
+	"github.com/cloudcwfranck/kspec/pkg/metrics"
 	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
 )
 
 // TestNormalizeStatus ensures all scanner status values are correctly mapped to CRD enums
@@ -121,11 +138,13 @@ func TestNormalizeType(t *testing.T) {
 		{"policy lowercase", "policy", "Policy"},
 		{"compliance lowercase", "compliance", "Compliance"},
 		{"configuration lowercase", "configuration", "Configuration"},
+		{"rbac lowercase", "rbac", "RBAC"},
 
 		// Capitalized values (already correct)
 		{"Policy capitalized", "Policy", "Policy"},
 		{"Compliance capitalized", "Compliance", "Compliance"},
 		{"Configuration capitalized", "Configuration", "Configuration"},
+		{"RBAC capitalized", "RBAC", "RBAC"},
 
 		// Unknown values should default to Policy
 		{"empty string", "", "Policy"},
@@ -141,7 +160,7 @@ func TestNormalizeType(t *testing.T) {
 			}
 
 			// Verify result is a valid CRD enum value
-			if result != "Policy" && result != "Compliance" && result != "Configuration" {
+			if result != "Policy" && result != "Compliance" && result != "Configuration" && result != "RBAC" {
 				t.Errorf("normalizeType(%q) returned invalid CRD value: %q", tt.input, result)
 			}
 		})
@@ -245,3 +264,183 @@ func TestCalculatePassRate(t *testing.T) {
 		})
 	}
 }
+
+// TestEvidenceDetails ensures check evidence is stored as report details
+func TestEvidenceDetails(t *testing.T) {
+	if details := evidenceDetails(nil); details != nil {
+		t.Errorf("evidenceDetails(nil) = %s, expected nil", details.Raw)
+	}
+
+	details := evidenceDetails(map[string]interface{}{"current": "1.28.3"})
+	if details == nil {
+		t.Fatal("evidenceDetails returned nil for non-empty evidence")
+	}
+	if string(details.Raw) != `{"current":"1.28.3"}` {
+		t.Errorf("evidenceDetails raw = %s, expected {\"current\":\"1.28.3\"}", details.Raw)
+	}
+}
+
+func TestExemptionRecords(t *testing.T) {
+	scanTime := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	expired := metav1.NewTime(scanTime.Add(-time.Hour))
+	active := metav1.NewTime(scanTime.Add(time.Hour))
+
+	clusterSpec := &kspecv1alpha1.ClusterSpecification{
+		Spec: kspecv1alpha1.ClusterSpecificationSpec{
+			ExemptionTicketPattern: `^CHG-\d+$`,
+			PolicyExemptions: []kspecv1alpha1.PolicyExemptionSpec{
+				{Name: "active", TicketRef: "CHG-1", Approver: "security-team", ExpiresAt: &active},
+				{Name: "stale", TicketRef: "CHG-2", ExpiresAt: &expired},
+				{Name: "untracked"},
+			},
+		},
+	}
+
+	records, warnings := exemptionRecords(clusterSpec, scanTime)
+	if len(records) != 3 {
+		t.Fatalf("expected 3 exemption records, got %d", len(records))
+	}
+	if records[0].Expired || records[0].TicketRef != "CHG-1" || records[0].Approver != "security-team" {
+		t.Errorf("unexpected record for active exemption: %+v", records[0])
+	}
+	if !records[1].Expired {
+		t.Errorf("expected stale exemption to be marked expired")
+	}
+
+	if len(warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0], "stale (ticket CHG-2) expired") {
+		t.Errorf("expected an expired exemption warning, got %q", warnings[0])
+	}
+	if !strings.Contains(warnings[1], "untracked is not honored") {
+		t.Errorf("expected a ticket reference warning, got %q", warnings[1])
+	}
+}
+
+func TestRecordExemptionMetrics(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	expired := metav1.NewTime(now.Add(-time.Hour))
+	expiring := metav1.NewTime(now.Add(48 * time.Hour))
+
+	clusterSpec := &kspecv1alpha1.ClusterSpecification{
+		ObjectMeta: metav1.ObjectMeta{Name: "exemptions-spec"},
+		Spec: kspecv1alpha1.ClusterSpecificationSpec{
+			PolicyExemptions: []kspecv1alpha1.PolicyExemptionSpec{
+				{Name: "expiring", ExpiresAt: &expiring},
+				{Name: "permanent"},
+				{Name: "stale", ExpiresAt: &expired},
+			},
+		},
+	}
+
+	recordExemptionMetrics(clusterSpec, now)
+
+	gaugeValue := func(metric interface{ Write(*dto.Metric) error }) float64 {
+		m := &dto.Metric{}
+		if err := metric.Write(m); err != nil {
+			t.Fatalf("failed to read metric: %v", err)
+		}
+		return m.GetGauge().GetValue()
+	}
+
+	if active := gaugeValue(metrics.ActiveExemptions.WithLabelValues("exemptions-spec")); active != 2 {
+		t.Errorf("kspec_active_exemptions = %v, expected 2 (expired exemption excluded)", active)
+	}
+	if expiry := gaugeValue(metrics.ExemptionExpiryTimestamp.WithLabelValues("exemptions-spec", "expiring")); expiry != float64(expiring.Unix()) {
+		t.Errorf("kspec_exemption_expiry_timestamp = %v, expected %d", expiry, expiring.Unix())
+	}
+}
+
+// TestReportOwnershipLabels ensures ownership labels on a ClusterSpec are propagated to reports
+func TestReportOwnershipLabels(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = kspecv1alpha1.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := &ClusterSpecReconciler{Client: fakeClient, Scheme: scheme}
+
+	clusterSpec := &kspecv1alpha1.ClusterSpecification{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "shared-spec",
+			Labels: map[string]string{
+				spec.OwnerLabel: "jane.doe",
+				spec.TeamLabel:  "payments",
+			},
+		},
+	}
+	clusterInfo := &clientpkg.ClusterInfo{Name: "local", IsLocal: true}
+	ctx := context.Background()
+
+	if err := reconciler.createComplianceReport(ctx, clusterSpec, &scanner.ScanResult{}, clusterInfo, ""); err != nil {
+		t.Fatalf("createComplianceReport failed: %v", err)
+	}
+	if err := reconciler.createDriftReport(ctx, clusterSpec, &drift.DriftReport{}, clusterInfo); err != nil {
+		t.Fatalf("createDriftReport failed: %v", err)
+	}
+
+	var complianceReports kspecv1alpha1.ComplianceReportList
+	if err := fakeClient.List(ctx, &complianceReports); err != nil {
+		t.Fatalf("Failed to list compliance reports: %v", err)
+	}
+	var driftReports kspecv1alpha1.DriftReportList
+	if err := fakeClient.List(ctx, &driftReports); err != nil {
+		t.Fatalf("Failed to list drift reports: %v", err)
+	}
+	if len(complianceReports.Items) != 1 || len(driftReports.Items) != 1 {
+		t.Fatalf("Expected 1 compliance and 1 drift report, got %d and %d", len(complianceReports.Items), len(driftReports.Items))
+	}
+
+	for _, labels := range []map[string]string{complianceReports.Items[0].Labels, driftReports.Items[0].Labels} {
+		if labels[spec.OwnerLabel] != "jane.doe" || labels[spec.TeamLabel] != "payments" {
+			t.Errorf("Expected ownership labels on report, got %v", labels)
+		}
+	}
+}
+
+// TestCreateReportsSameSecond ensures reports created in quick succession get distinct names,
+// and that a name collision is retried instead of failing the reconcile
+func TestCreateReportsSameSecond(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = kspecv1alpha1.AddToScheme(scheme)
+
+	// Reject the first create as if a report with that name already existed
+	collisions := 0
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if collisions == 0 {
+					collisions++
+					return apierrors.NewAlreadyExists(schema.GroupResource{Group: "kspec.io", Resource: "compliancereports"}, obj.GetName())
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	reconciler := &ClusterSpecReconciler{Client: fakeClient, Scheme: scheme}
+
+	clusterSpec := &kspecv1alpha1.ClusterSpecification{ObjectMeta: metav1.ObjectMeta{Name: "test-spec"}}
+	clusterInfo := &clientpkg.ClusterInfo{Name: "local", IsLocal: true}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := reconciler.createComplianceReport(ctx, clusterSpec, &scanner.ScanResult{}, clusterInfo, ""); err != nil {
+			t.Fatalf("createComplianceReport #%d failed: %v", i+1, err)
+		}
+	}
+
+	var reports kspecv1alpha1.ComplianceReportList
+	if err := fakeClient.List(ctx, &reports); err != nil {
+		t.Fatalf("Failed to list compliance reports: %v", err)
+	}
+	if len(reports.Items) != 2 {
+		t.Fatalf("Expected 2 compliance reports, got %d", len(reports.Items))
+	}
+	if reports.Items[0].Name == reports.Items[1].Name {
+		t.Errorf("Expected distinct report names, both are %q", reports.Items[0].Name)
+	}
+	if collisions != 1 {
+		t.Errorf("Expected the simulated collision to be hit once, got %d", collisions)
+	}
+}
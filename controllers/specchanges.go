@@ -0,0 +1,191 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+)
+
+const (
+	// SpecChangesAnnotation records on a ComplianceReport what changed in the spec since the previous report
+	SpecChangesAnnotation = "kspec.io/spec-changes"
+
+	// SpecGenerationAnnotation records on a ComplianceReport the spec generation that introduced the changes
+	SpecGenerationAnnotation = "kspec.io/spec-generation"
+
+	// maxSpecChangesListed caps the number of changed fields listed in the summary
+	maxSpecChangesListed = 10
+)
+
+// detectSpecChanges compares the current spec against the last observed spec and
+// returns a concise change summary. It returns an empty string when the generation
+// is unchanged or when no previous spec has been recorded yet.
+func detectSpecChanges(clusterSpec *kspecv1alpha1.ClusterSpecification) (string, error) {
+	observed := clusterSpec.Status.ObservedSpec
+	if observed == nil || observed.Generation == clusterSpec.Generation {
+		return "", nil
+	}
+
+	current, err := currentSpecFieldHashes(clusterSpec)
+	if err != nil {
+		return "", err
+	}
+
+	return summarizeSpecChanges(diffFieldHashes(observed.FieldHashes, current)), nil
+}
+
+// recordObservedSpec records the current spec generation and field hashes in status
+// so the next spec edit can be summarized against them, along with the changes the
+// current generation made. The status is patched rather than updated. It is a no-op
+// if the generation is already recorded.
+func (r *ClusterSpecReconciler) recordObservedSpec(ctx context.Context, clusterSpec *kspecv1alpha1.ClusterSpecification, changes string) error {
+	observed := clusterSpec.Status.ObservedSpec
+	if observed != nil && observed.Generation == clusterSpec.Generation {
+		return nil
+	}
+
+	hashes, err := currentSpecFieldHashes(clusterSpec)
+	if err != nil {
+		return err
+	}
+	if changes == "" && observed != nil {
+		changes = observed.LastChanges
+	}
+
+	base := clusterSpec.DeepCopy()
+	clusterSpec.Status.ObservedSpec = &kspecv1alpha1.ObservedSpecStatus{
+		Generation:  clusterSpec.Generation,
+		FieldHashes: hashes,
+		LastChanges: changes,
+	}
+	if err := r.Status().Patch(ctx, clusterSpec, client.MergeFrom(base)); err != nil {
+		return fmt.Errorf("failed to record observed spec: %w", err)
+	}
+	return nil
+}
+
+// currentSpecFieldHashes returns the field hashes of a ClusterSpecification's spec
+func currentSpecFieldHashes(clusterSpec *kspecv1alpha1.ClusterSpecification) (map[string]string, error) {
+	current, err := json.Marshal(clusterSpec.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal spec: %w", err)
+	}
+	return specFieldHashes(current)
+}
+
+// specFieldHashes flattens a JSON-encoded spec into dotted field paths, each mapped
+// to a short hash of its value. Lists are hashed as a whole.
+func specFieldHashes(specJSON []byte) (map[string]string, error) {
+	var obj map[string]interface{}
+	if err := json.Unmarshal(specJSON, &obj); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]interface{})
+	flattenFields("", obj, fields)
+
+	hashes := make(map[string]string, len(fields))
+	for path, value := range fields {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", path, err)
+		}
+		h := fnv.New64a()
+		h.Write(encoded)
+		hashes[path] = strconv.FormatUint(h.Sum64(), 16)
+	}
+	return hashes, nil
+}
+
+// diffFieldHashes compares two sets of field hashes and returns the changed field
+// paths, each prefixed with "added", "removed" or "modified"
+func diffFieldHashes(previous, current map[string]string) []string {
+	changes := []string{}
+	for path, hash := range current {
+		previousHash, exists := previous[path]
+		if !exists {
+			changes = append(changes, "added "+path)
+		} else if previousHash != hash {
+			changes = append(changes, "modified "+path)
+		}
+	}
+	for path := range previous {
+		if _, exists := current[path]; !exists {
+			changes = append(changes, "removed "+path)
+		}
+	}
+
+	// Sort by field path so the summary is stable
+	sort.Slice(changes, func(i, j int) bool {
+		return changePath(changes[i]) < changePath(changes[j])
+	})
+
+	return changes
+}
+
+// specChangeAnnotations builds the ComplianceReport annotations for a change summary
+func specChangeAnnotations(changes string, generation int64) map[string]string {
+	if changes == "" {
+		return nil
+	}
+	return map[string]string{
+		SpecChangesAnnotation:    changes,
+		SpecGenerationAnnotation: strconv.FormatInt(generation, 10),
+	}
+}
+
+// flattenFields flattens nested objects into dotted field paths. Lists are treated
+// as leaf values.
+func flattenFields(prefix string, obj map[string]interface{}, out map[string]interface{}) {
+	for key, value := range obj {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			flattenFields(path, nested, out)
+			continue
+		}
+		out[path] = value
+	}
+}
+
+// changePath strips the change kind from a change entry
+func changePath(change string) string {
+	if idx := strings.Index(change, " "); idx >= 0 {
+		return change[idx+1:]
+	}
+	return change
+}
+
+// summarizeSpecChanges joins change entries into a single line, truncating long lists
+func summarizeSpecChanges(changes []string) string {
+	if len(changes) <= maxSpecChangesListed {
+		return strings.Join(changes, "; ")
+	}
+	return fmt.Sprintf("%s; and %d more", strings.Join(changes[:maxSpecChangesListed], "; "), len(changes)-maxSpecChangesListed)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+)
+
+// TestDiffFieldHashes ensures added, removed and modified fields are reported by path
+func TestDiffFieldHashes(t *testing.T) {
+	previous, err := specFieldHashes([]byte(`{"kubernetes":{"minVersion":"1.26.0","maxVersion":"1.30.0"},"network":{"defaultDeny":false}}`))
+	if err != nil {
+		t.Fatalf("specFieldHashes returned error: %v", err)
+	}
+	current, err := specFieldHashes([]byte(`{"kubernetes":{"minVersion":"1.27.0","maxVersion":"1.30.0"},"rbac":{"forbidWorkloadClusterAdmin":true}}`))
+	if err != nil {
+		t.Fatalf("specFieldHashes returned error: %v", err)
+	}
+
+	changes := diffFieldHashes(previous, current)
+	expected := []string{
+		"modified kubernetes.minVersion",
+		"removed network.defaultDeny",
+		"added rbac.forbidWorkloadClusterAdmin",
+	}
+	if strings.Join(changes, ",") != strings.Join(expected, ",") {
+		t.Errorf("diffFieldHashes() = %v, expected %v", changes, expected)
+	}
+}
+
+func newSpecChangesReconciler(t *testing.T, objs ...client.Object) (*ClusterSpecReconciler, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = kspecv1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&kspecv1alpha1.ClusterSpecification{}).
+		Build()
+	return &ClusterSpecReconciler{Client: fakeClient, Scheme: scheme}, fakeClient
+}
+
+// TestDetectSpecChanges ensures changes are only reported once a previous spec is
+// recorded, and only for a new generation
+func TestDetectSpecChanges(t *testing.T) {
+	clusterSpec := &kspecv1alpha1.ClusterSpecification{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-spec", Generation: 1},
+		Spec: kspecv1alpha1.ClusterSpecificationSpec{
+			SpecFields: spec.SpecFields{
+				Kubernetes: spec.KubernetesSpec{MinVersion: "1.26.0", MaxVersion: "1.30.0"},
+			},
+		},
+	}
+	reconciler, _ := newSpecChangesReconciler(t, clusterSpec)
+	ctx := context.Background()
+
+	// No previous spec recorded
+	changes, err := detectSpecChanges(clusterSpec)
+	if err != nil {
+		t.Fatalf("detectSpecChanges returned error: %v", err)
+	}
+	if changes != "" {
+		t.Errorf("expected no changes without a recorded spec, got %q", changes)
+	}
+
+	// Record the current spec, then edit it
+	if err := reconciler.recordObservedSpec(ctx, clusterSpec, ""); err != nil {
+		t.Fatalf("recordObservedSpec returned error: %v", err)
+	}
+	changes, err = detectSpecChanges(clusterSpec)
+	if err != nil {
+		t.Fatalf("detectSpecChanges returned error: %v", err)
+	}
+	if changes != "" {
+		t.Errorf("expected no changes for an unchanged generation, got %q", changes)
+	}
+
+	clusterSpec.Spec.Kubernetes.MinVersion = "1.27.0"
+	clusterSpec.Generation = 2
+	changes, err = detectSpecChanges(clusterSpec)
+	if err != nil {
+		t.Fatalf("detectSpecChanges returned error: %v", err)
+	}
+	if changes != "modified kubernetes.minVersion" {
+		t.Errorf("detectSpecChanges() = %q, expected %q", changes, "modified kubernetes.minVersion")
+	}
+}
+
+// TestRecordObservedSpec ensures the observed spec is patched into status along
+// with the change summary
+func TestRecordObservedSpec(t *testing.T) {
+	clusterSpec := &kspecv1alpha1.ClusterSpecification{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-spec", Generation: 3},
+		Spec: kspecv1alpha1.ClusterSpecificationSpec{
+			SpecFields: spec.SpecFields{
+				Kubernetes: spec.KubernetesSpec{MinVersion: "1.26.0", MaxVersion: "1.31.0"},
+			},
+		},
+	}
+	reconciler, fakeClient := newSpecChangesReconciler(t, clusterSpec)
+	ctx := context.Background()
+
+	if err := reconciler.recordObservedSpec(ctx, clusterSpec, "modified kubernetes.maxVersion"); err != nil {
+		t.Fatalf("recordObservedSpec returned error: %v", err)
+	}
+
+	var stored kspecv1alpha1.ClusterSpecification
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(clusterSpec), &stored); err != nil {
+		t.Fatalf("Failed to get ClusterSpecification: %v", err)
+	}
+	observed := stored.Status.ObservedSpec
+	if observed == nil {
+		t.Fatal("Expected status.observedSpec to be recorded")
+	}
+	if observed.Generation != 3 || observed.LastChanges != "modified kubernetes.maxVersion" {
+		t.Errorf("Unexpected observed spec: generation %d, lastChanges %q", observed.Generation, observed.LastChanges)
+	}
+	if observed.FieldHashes["kubernetes.maxVersion"] == "" {
+		t.Errorf("Expected a hash for kubernetes.maxVersion, got %v", observed.FieldHashes)
+	}
+}
+
+// TestSummarizeSpecChanges ensures long change lists are truncated
+func TestSummarizeSpecChanges(t *testing.T) {
+	changes := make([]string, maxSpecChangesListed+3)
+	for i := range changes {
+		changes[i] = "modified field"
+	}
+
+	summary := summarizeSpecChanges(changes)
+	if !strings.HasSuffix(summary, "and 3 more") {
+		t.Errorf("summarizeSpecChanges() = %q, expected truncation suffix", summary)
+	}
+}
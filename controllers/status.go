@@ -18,20 +18,69 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
 	"github.com/cloudcwfranck/kspec/pkg/drift"
 	"github.com/cloudcwfranck/kspec/pkg/scanner"
 )
 
+// maxRecentScans bounds status.recentScans so the status stays small
+const maxRecentScans = 10
+
+// ConditionTypeDegraded indicates a reconcile completed but some of its sub-steps failed
+const ConditionTypeDegraded = "Degraded"
+
+// reconcileErrors accumulates the errors of reconcile sub-steps that do not abort
+// the reconcile, so partial failures are visible in status rather than only in logs.
+type reconcileErrors struct {
+	steps []string
+	errs  []error
+}
+
+// add records a failed sub-step; nil errors are ignored.
+func (e *reconcileErrors) add(step string, err error) {
+	if err == nil {
+		return
+	}
+	e.steps = append(e.steps, step)
+	e.errs = append(e.errs, fmt.Errorf("%s: %w", step, err))
+}
+
+// aggregate returns the recorded errors as a single error, or nil if there are none.
+func (e *reconcileErrors) aggregate() error {
+	return utilerrors.NewAggregate(e.errs)
+}
+
+// degradedCondition builds the Degraded condition listing the failed sub-steps.
+func (e *reconcileErrors) degradedCondition(generation int64) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               ConditionTypeDegraded,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "AllStepsSucceeded",
+		Message:            "All reconcile steps succeeded",
+		ObservedGeneration: generation,
+	}
+	if len(e.errs) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "StepsFailed"
+		condition.Message = fmt.Sprintf("Failed steps: %s. Errors: %v", strings.Join(e.steps, ", "), e.aggregate())
+	}
+	return condition
+}
+
 // updateStatus updates the ClusterSpecification status based on scan and drift results
 func (r *ClusterSpecReconciler) updateStatus(
 	ctx context.Context,
 	clusterSpec *kspecv1alpha1.ClusterSpecification,
 	scanResult *scanner.ScanResult,
 	driftReport *drift.DriftReport,
+	failures *reconcileErrors,
 ) error {
 	now := metav1.Now()
 
@@ -47,6 +96,7 @@ func (r *ClusterSpecReconciler) updateStatus(
 
 	// Update compliance score
 	clusterSpec.Status.ComplianceScore = calculatePassRate(scanResult.Summary)
+	clusterSpec.Status.WeightedComplianceScore = int(scanResult.Summary.WeightedScore)
 
 	// Update summary
 	driftEvents := 0
@@ -62,8 +112,17 @@ func (r *ClusterSpecReconciler) updateStatus(
 		DriftEvents:      driftEvents,
 	}
 
+	// Record scan in history
+	clusterSpec.Status.RecentScans = recordRecentScan(clusterSpec.Status.RecentScans, kspecv1alpha1.ScanSummary{
+		Timestamp: now,
+		Score:     clusterSpec.Status.ComplianceScore,
+		Passed:    scanResult.Summary.Passed,
+		Failed:    scanResult.Summary.Failed,
+	})
+
 	// Update conditions
 	clusterSpec.Status.Conditions = r.buildConditions(scanResult, driftReport)
+	clusterSpec.Status.Conditions = append(clusterSpec.Status.Conditions, failures.degradedCondition(clusterSpec.Generation))
 
 	// Update status
 	if err := r.Status().Update(ctx, clusterSpec); err != nil {
@@ -73,6 +132,16 @@ func (r *ClusterSpecReconciler) updateStatus(
 	return nil
 }
 
+// recordRecentScan prepends a scan summary to the history, dropping the oldest
+// entries beyond maxRecentScans
+func recordRecentScan(history []kspecv1alpha1.ScanSummary, scan kspecv1alpha1.ScanSummary) []kspecv1alpha1.ScanSummary {
+	recent := append([]kspecv1alpha1.ScanSummary{scan}, history...)
+	if len(recent) > maxRecentScans {
+		recent = recent[:maxRecentScans]
+	}
+	return recent
+}
+
 // updateStatusFailed updates status when reconciliation fails
 func (r *ClusterSpecReconciler) updateStatusFailed(
 	ctx context.Context,
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+	clientpkg "github.com/cloudcwfranck/kspec/pkg/client"
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+)
+
+// TestRecordRecentScan ensures the scan history is ordered newest first and capped
+func TestRecordRecentScan(t *testing.T) {
+	var history []kspecv1alpha1.ScanSummary
+	for score := 1; score <= maxRecentScans+5; score++ {
+		history = recordRecentScan(history, kspecv1alpha1.ScanSummary{Score: score})
+	}
+
+	if len(history) != maxRecentScans {
+		t.Fatalf("recordRecentScan() kept %d scans, expected %d", len(history), maxRecentScans)
+	}
+
+	newest := maxRecentScans + 5
+	for i, scan := range history {
+		if expected := newest - i; scan.Score != expected {
+			t.Errorf("history[%d].Score = %d, expected %d", i, scan.Score, expected)
+		}
+	}
+}
+
+// TestUpdateStatusDegraded ensures a failed report creation is surfaced in the
+// Degraded condition while the rest of the status is still updated
+func TestUpdateStatusDegraded(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = kspecv1alpha1.AddToScheme(scheme)
+
+	clusterSpec := &kspecv1alpha1.ClusterSpecification{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-spec", Generation: 3},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(clusterSpec).
+		WithStatusSubresource(clusterSpec).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*kspecv1alpha1.ComplianceReport); ok {
+					return fmt.Errorf("admission webhook denied the request")
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	reconciler := &ClusterSpecReconciler{Client: fakeClient, Scheme: scheme}
+
+	ctx := context.Background()
+	scanResult := &scanner.ScanResult{Summary: scanner.ScanSummary{TotalChecks: 2, Passed: 2, WeightedScore: 100}}
+	clusterInfo := &clientpkg.ClusterInfo{Name: "local", IsLocal: true}
+
+	var failures reconcileErrors
+	failures.add("compliance-report", reconciler.createComplianceReport(ctx, clusterSpec, scanResult, clusterInfo, ""))
+	if failures.aggregate() == nil {
+		t.Fatal("Expected the compliance report creation to fail")
+	}
+
+	if err := reconciler.updateStatus(ctx, clusterSpec, scanResult, nil, &failures); err != nil {
+		t.Fatalf("updateStatus failed: %v", err)
+	}
+
+	var updated kspecv1alpha1.ClusterSpecification
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(clusterSpec), &updated); err != nil {
+		t.Fatalf("Failed to get ClusterSpecification: %v", err)
+	}
+	if updated.Status.ComplianceScore != 100 {
+		t.Errorf("Expected compliance score 100, got %d", updated.Status.ComplianceScore)
+	}
+
+	degraded := meta.FindStatusCondition(updated.Status.Conditions, ConditionTypeDegraded)
+	if degraded == nil {
+		t.Fatal("Expected Degraded condition to be set")
+	}
+	if degraded.Status != metav1.ConditionTrue || degraded.Reason != "StepsFailed" {
+		t.Errorf("Expected Degraded True/StepsFailed, got %s/%s", degraded.Status, degraded.Reason)
+	}
+	if !strings.Contains(degraded.Message, "compliance-report") || !strings.Contains(degraded.Message, "admission webhook denied") {
+		t.Errorf("Expected message to list the failed step and error, got: %s", degraded.Message)
+	}
+	if degraded.ObservedGeneration != 3 {
+		t.Errorf("Expected observed generation 3, got %d", degraded.ObservedGeneration)
+	}
+
+	// A clean reconcile clears the condition
+	if err := reconciler.updateStatus(ctx, &updated, scanResult, nil, &reconcileErrors{}); err != nil {
+		t.Fatalf("updateStatus failed: %v", err)
+	}
+	degraded = meta.FindStatusCondition(updated.Status.Conditions, ConditionTypeDegraded)
+	if degraded == nil || degraded.Status != metav1.ConditionFalse {
+		t.Errorf("Expected Degraded False after a clean reconcile, got %+v", degraded)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	admissionv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -22,6 +23,12 @@ const (
 
 	// WebhookPath is the webhook endpoint path
 	WebhookPath = "/validate"
+
+	// MutatingWebhookConfigName is the name of the mutating webhook configuration
+	MutatingWebhookConfigName = "kspec-mutating-webhook"
+
+	// MutatingWebhookPath is the mutating webhook endpoint path
+	MutatingWebhookPath = "/mutate"
 )
 
 // manageValidatingWebhook creates or updates the ValidatingWebhookConfiguration
@@ -89,6 +96,30 @@ func (r *ClusterSpecReconciler) manageValidatingWebhook(
 							Resources:   []string{"pods"},
 						},
 					},
+					// Controllers are validated by their pod template, so a bad
+					// template is rejected before any pod is created
+					{
+						Operations: []admissionv1.OperationType{
+							admissionv1.Create,
+							admissionv1.Update,
+						},
+						Rule: admissionv1.Rule{
+							APIGroups:   []string{"apps"},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"deployments", "statefulsets", "daemonsets"},
+						},
+					},
+					{
+						Operations: []admissionv1.OperationType{
+							admissionv1.Create,
+							admissionv1.Update,
+						},
+						Rule: admissionv1.Rule{
+							APIGroups:   []string{"batch"},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"jobs", "cronjobs"},
+						},
+					},
 				},
 				FailurePolicy:           &failurePolicy,
 				SideEffects:             &sideEffects,
@@ -151,3 +182,161 @@ func (r *ClusterSpecReconciler) cleanupValidatingWebhook(ctx context.Context) er
 	log.Info("Cleaned up ValidatingWebhookConfiguration")
 	return nil
 }
+
+// manageMutatingWebhook creates or updates the MutatingWebhookConfiguration for
+// ClusterSpecs in mutate mode, and removes it once no ClusterSpec uses mutate mode
+func (r *ClusterSpecReconciler) manageMutatingWebhook(
+	ctx context.Context,
+	clusterSpec *kspecv1alpha1.ClusterSpecification,
+) error {
+	log := log.FromContext(ctx)
+
+	if !isMutateMode(clusterSpec) {
+		// The configuration is shared, so keep it while another ClusterSpec mutates
+		inUse, err := r.mutateModeInUse(ctx, clusterSpec)
+		if err != nil {
+			return fmt.Errorf("failed to list ClusterSpecifications: %w", err)
+		}
+		if inUse {
+			return nil
+		}
+		return r.cleanupMutatingWebhook(ctx)
+	}
+
+	if clusterSpec.Spec.Webhooks == nil || !clusterSpec.Spec.Webhooks.Enabled {
+		log.V(1).Info("Webhooks disabled, skipping mutating webhook configuration")
+		return nil
+	}
+
+	if clusterSpec.Status.Webhooks == nil || !clusterSpec.Status.Webhooks.CertificateReady {
+		log.Info("Certificate not ready, skipping mutating webhook configuration")
+		return nil
+	}
+
+	failurePolicy := admissionv1.Ignore // Default to fail-open
+	if clusterSpec.Spec.Webhooks.FailurePolicy == "Fail" {
+		failurePolicy = admissionv1.Fail
+	}
+
+	timeoutSeconds := int32(10) // Default timeout
+	if clusterSpec.Spec.Webhooks.TimeoutSeconds > 0 {
+		timeoutSeconds = clusterSpec.Spec.Webhooks.TimeoutSeconds
+	}
+
+	sideEffects := admissionv1.SideEffectClassNone
+	reinvocationPolicy := admissionv1.IfNeededReinvocationPolicy
+	port := int32(9443)
+	path := MutatingWebhookPath
+
+	webhook := &admissionv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: MutatingWebhookConfigName,
+			Labels: map[string]string{
+				"kspec.io/component": "webhook",
+			},
+			Annotations: map[string]string{
+				"cert-manager.io/inject-ca-from": fmt.Sprintf("%s/%s", ReportNamespace, WebhookCertificateName),
+			},
+		},
+		Webhooks: []admissionv1.MutatingWebhook{
+			{
+				Name: "pod-mutation.kspec.io",
+				ClientConfig: admissionv1.WebhookClientConfig{
+					Service: &admissionv1.ServiceReference{
+						Name:      WebhookServiceName,
+						Namespace: ReportNamespace,
+						Path:      &path,
+						Port:      &port,
+					},
+				},
+				Rules: []admissionv1.RuleWithOperations{
+					{
+						Operations: []admissionv1.OperationType{
+							admissionv1.Create,
+						},
+						Rule: admissionv1.Rule{
+							APIGroups:   []string{""},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"pods"},
+						},
+					},
+				},
+				FailurePolicy:           &failurePolicy,
+				SideEffects:             &sideEffects,
+				ReinvocationPolicy:      &reinvocationPolicy,
+				AdmissionReviewVersions: []string{"v1", "v1beta1"},
+				TimeoutSeconds:          &timeoutSeconds,
+			},
+		},
+	}
+
+	existing := &admissionv1.MutatingWebhookConfiguration{}
+	err := r.Get(ctx, types.NamespacedName{Name: MutatingWebhookConfigName}, existing)
+	if err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to get mutating webhook configuration: %w", err)
+		}
+
+		if err := r.Create(ctx, webhook); err != nil {
+			return fmt.Errorf("failed to create mutating webhook configuration: %w", err)
+		}
+		log.Info("Created MutatingWebhookConfiguration")
+		return nil
+	}
+
+	existing.Webhooks = webhook.Webhooks
+	existing.Annotations = webhook.Annotations
+	existing.Labels = webhook.Labels
+	if err := r.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update mutating webhook configuration: %w", err)
+	}
+	log.Info("Updated MutatingWebhookConfiguration")
+	return nil
+}
+
+// isMutateMode reports whether a ClusterSpec enforces in mutate mode
+func isMutateMode(clusterSpec *kspecv1alpha1.ClusterSpecification) bool {
+	return clusterSpec.Spec.Enforcement != nil && clusterSpec.Spec.Enforcement.Mode == "mutate"
+}
+
+// mutateModeInUse reports whether a ClusterSpec other than the given one, and not
+// being deleted, enforces in mutate mode
+func (r *ClusterSpecReconciler) mutateModeInUse(ctx context.Context, except *kspecv1alpha1.ClusterSpecification) (bool, error) {
+	var clusterSpecs kspecv1alpha1.ClusterSpecificationList
+	if err := r.List(ctx, &clusterSpecs); err != nil {
+		return false, err
+	}
+	for i := range clusterSpecs.Items {
+		other := &clusterSpecs.Items[i]
+		if other.Namespace == except.Namespace && other.Name == except.Name {
+			continue
+		}
+		if other.DeletionTimestamp.IsZero() && isMutateMode(other) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// cleanupMutatingWebhook removes the MutatingWebhookConfiguration
+func (r *ClusterSpecReconciler) cleanupMutatingWebhook(ctx context.Context) error {
+	log := log.FromContext(ctx)
+
+	webhook := &admissionv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: MutatingWebhookConfigName,
+		},
+	}
+
+	err := r.Delete(ctx, webhook)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		log.Error(err, "Failed to delete MutatingWebhookConfiguration")
+		return err
+	}
+
+	log.Info("Cleaned up MutatingWebhookConfiguration")
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+)
+
+func newEnforcementSpec(name, mode string) *kspecv1alpha1.ClusterSpecification {
+	return &kspecv1alpha1.ClusterSpecification{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: kspecv1alpha1.ClusterSpecificationSpec{
+			Enforcement: &kspecv1alpha1.EnforcementSpec{Enabled: true, Mode: mode},
+		},
+	}
+}
+
+// TestManageMutatingWebhookCleanup ensures the MutatingWebhookConfiguration is
+// removed once a ClusterSpec leaves mutate mode, unless another one still uses it
+func TestManageMutatingWebhookCleanup(t *testing.T) {
+	tests := []struct {
+		name        string
+		others      []client.Object
+		wantRemoved bool
+	}{
+		{
+			name:        "no other spec mutates",
+			others:      []client.Object{newEnforcementSpec("audit-spec", "audit")},
+			wantRemoved: true,
+		},
+		{
+			name:        "another spec mutates",
+			others:      []client.Object{newEnforcementSpec("mutating-spec", "mutate")},
+			wantRemoved: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = kspecv1alpha1.AddToScheme(scheme)
+			_ = admissionv1.AddToScheme(scheme)
+
+			clusterSpec := newEnforcementSpec("test-spec", "enforce")
+			webhook := &admissionv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: MutatingWebhookConfigName},
+			}
+			objs := append([]client.Object{clusterSpec, webhook}, tt.others...)
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+			reconciler := &ClusterSpecReconciler{Client: fakeClient, Scheme: scheme}
+
+			ctx := context.Background()
+			if err := reconciler.manageMutatingWebhook(ctx, clusterSpec); err != nil {
+				t.Fatalf("manageMutatingWebhook returned error: %v", err)
+			}
+
+			err := fakeClient.Get(ctx, types.NamespacedName{Name: MutatingWebhookConfigName}, &admissionv1.MutatingWebhookConfiguration{})
+			if removed := errors.IsNotFound(err); removed != tt.wantRemoved {
+				t.Errorf("Expected MutatingWebhookConfiguration removed=%v, got error %v", tt.wantRemoved, err)
+			}
+
+			// Cleaning up an already removed configuration is not an error
+			if err := reconciler.manageMutatingWebhook(ctx, clusterSpec); err != nil {
+				t.Errorf("manageMutatingWebhook returned error on second run: %v", err)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
 )
 
 // FleetSummary represents aggregated compliance across all clusters
@@ -63,6 +64,15 @@ type ClusterCompliance struct {
 // ReportAggregator aggregates compliance and drift reports across clusters
 type ReportAggregator struct {
 	client.Client
+
+	// Owner and Team, when set, restrict aggregation to reports labelled with
+	// that owner or team
+	Owner string
+	Team  string
+
+	// TrendWindow is the width of the time windows fleet compliance history is
+	// bucketed into (default DefaultTrendWindow)
+	TrendWindow time.Duration
 }
 
 // NewReportAggregator creates a new ReportAggregator
@@ -72,14 +82,25 @@ func NewReportAggregator(k8sClient client.Client) *ReportAggregator {
 	}
 }
 
+// reportLabels returns the label selector for reports, adding the ownership filter
+func (a *ReportAggregator) reportLabels(labels map[string]string) client.MatchingLabels {
+	if a.Owner != "" {
+		labels[spec.OwnerLabel] = a.Owner
+	}
+	if a.Team != "" {
+		labels[spec.TeamLabel] = a.Team
+	}
+	return client.MatchingLabels(labels)
+}
+
 // GetFleetSummary returns an aggregated view of compliance across all clusters
 func (a *ReportAggregator) GetFleetSummary(ctx context.Context, clusterSpecName string) (*FleetSummary, error) {
 	// Get all compliance reports for this ClusterSpec across all clusters
 	var reports kspecv1alpha1.ComplianceReportList
 	listOpts := []client.ListOption{
-		client.MatchingLabels{
+		a.reportLabels(map[string]string{
 			"kspec.io/cluster-spec": clusterSpecName,
-		},
+		}),
 	}
 
 	if err := a.List(ctx, &reports, listOpts...); err != nil {
@@ -130,9 +151,9 @@ func (a *ReportAggregator) GetClusterCompliance(ctx context.Context, clusterSpec
 	// Get all compliance reports
 	var reports kspecv1alpha1.ComplianceReportList
 	listOpts := []client.ListOption{
-		client.MatchingLabels{
+		a.reportLabels(map[string]string{
 			"kspec.io/cluster-spec": clusterSpecName,
-		},
+		}),
 	}
 
 	if err := a.List(ctx, &reports, listOpts...); err != nil {
@@ -188,9 +209,9 @@ func (a *ReportAggregator) GetClusterCompliance(ctx context.Context, clusterSpec
 func (a *ReportAggregator) GetFailedChecksByCluster(ctx context.Context, clusterSpecName string) (map[string][]kspecv1alpha1.CheckResult, error) {
 	var reports kspecv1alpha1.ComplianceReportList
 	listOpts := []client.ListOption{
-		client.MatchingLabels{
+		a.reportLabels(map[string]string{
 			"kspec.io/cluster-spec": clusterSpecName,
-		},
+		}),
 	}
 
 	if err := a.List(ctx, &reports, listOpts...); err != nil {
@@ -219,9 +240,9 @@ func (a *ReportAggregator) GetFailedChecksByCluster(ctx context.Context, cluster
 func (a *ReportAggregator) GetDriftEventsByCluster(ctx context.Context, clusterSpecName string) (map[string][]kspecv1alpha1.DriftEvent, error) {
 	var driftReports kspecv1alpha1.DriftReportList
 	listOpts := []client.ListOption{
-		client.MatchingLabels{
+		a.reportLabels(map[string]string{
 			"kspec.io/cluster-spec": clusterSpecName,
-		},
+		}),
 	}
 
 	if err := a.List(ctx, &driftReports, listOpts...); err != nil {
@@ -344,10 +365,10 @@ type ComplianceDataPoint struct {
 func (a *ReportAggregator) GetComplianceHistory(ctx context.Context, clusterSpecName, clusterName string, limit int) (*ComplianceHistory, error) {
 	var reports kspecv1alpha1.ComplianceReportList
 	listOpts := []client.ListOption{
-		client.MatchingLabels{
+		a.reportLabels(map[string]string{
 			"kspec.io/cluster-spec": clusterSpecName,
 			"kspec.io/cluster-name": clusterName,
-		},
+		}),
 	}
 
 	if err := a.List(ctx, &reports, listOpts...); err != nil {
@@ -396,9 +417,9 @@ func (a *ReportAggregator) GetComplianceHistory(ctx context.Context, clusterSpec
 func (a *ReportAggregator) GetRecentActivity(ctx context.Context, clusterSpecName string, limit int) ([]ActivityEvent, error) {
 	var reports kspecv1alpha1.ComplianceReportList
 	listOpts := []client.ListOption{
-		client.MatchingLabels{
+		a.reportLabels(map[string]string{
 			"kspec.io/cluster-spec": clusterSpecName,
-		},
+		}),
 	}
 
 	if err := a.List(ctx, &reports, listOpts...); err != nil {
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aggregation
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+)
+
+// fleetCSVHeader is the header row of ExportFleetCSV
+var fleetCSVHeader = []string{
+	"cluster", "platform", "compliance_score", "passed_checks", "failed_checks", "drift_events", "last_scan",
+}
+
+// ExportFleetCSV writes one CSV row per cluster with the compliance of its latest
+// report against a ClusterSpec, enriched with the platform of its ClusterTarget
+func (a *ReportAggregator) ExportFleetCSV(ctx context.Context, clusterSpecName string, w io.Writer) error {
+	clusters, err := a.GetClusterCompliance(ctx, clusterSpecName)
+	if err != nil {
+		return err
+	}
+
+	// Non-fatal: clusters without a readable target are exported as unknown
+	targets, _ := a.GetClusterTargets(ctx, "")
+
+	return writeFleetCSV(w, clusters, targets)
+}
+
+// writeFleetCSV writes the cluster rows of ExportFleetCSV
+func writeFleetCSV(w io.Writer, clusters []ClusterCompliance, targets []kspecv1alpha1.ClusterTarget) error {
+	targetMap := make(map[string]*kspecv1alpha1.ClusterTarget)
+	for i := range targets {
+		targetMap[targets[i].Name] = &targets[i]
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(fleetCSVHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, cluster := range clusters {
+		platform := "Unknown"
+		if target, ok := targetMap[cluster.ClusterName]; ok && target.Status.Platform != "" {
+			platform = target.Status.Platform
+		} else if cluster.IsLocal {
+			platform = "Local"
+		}
+
+		lastScan := ""
+		if !cluster.LastScanTime.IsZero() {
+			lastScan = cluster.LastScanTime.UTC().Format(time.RFC3339)
+		}
+
+		row := []string{
+			cluster.ClusterName,
+			platform,
+			strconv.FormatFloat(cluster.ComplianceScore, 'f', 1, 64),
+			strconv.Itoa(cluster.PassedChecks),
+			strconv.Itoa(cluster.FailedChecks),
+			strconv.Itoa(cluster.DriftEventCount),
+			lastScan,
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row for cluster %s: %w", cluster.ClusterName, err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aggregation
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+)
+
+func TestWriteFleetCSV(t *testing.T) {
+	clusters := []ClusterCompliance{
+		{
+			ClusterName:     "local",
+			IsLocal:         true,
+			LastScanTime:    time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC),
+			TotalChecks:     12,
+			PassedChecks:    11,
+			FailedChecks:    1,
+			ComplianceScore: 91.66,
+		},
+		{
+			ClusterName:     "prod-eks",
+			PassedChecks:    10,
+			TotalChecks:     10,
+			DriftEventCount: 3,
+			ComplianceScore: 100,
+		},
+		{
+			ClusterName: "staging",
+		},
+	}
+	targets := []kspecv1alpha1.ClusterTarget{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod-eks"},
+			Status:     kspecv1alpha1.ClusterTargetStatus{Platform: "eks, us-east-1"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeFleetCSV(&buf, clusters, targets); err != nil {
+		t.Fatalf("writeFleetCSV() error = %v", err)
+	}
+
+	want := "cluster,platform,compliance_score,passed_checks,failed_checks,drift_events,last_scan\n" +
+		"local,Local,91.7,11,1,0,2025-01-15T10:30:00Z\n" +
+		"prod-eks,\"eks, us-east-1\",100.0,10,0,3,\n" +
+		"staging,Unknown,0.0,0,0,0,\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeFleetCSV() =\n%s\nwant:\n%s", got, want)
+	}
+}
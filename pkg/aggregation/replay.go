@@ -0,0 +1,256 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aggregation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+)
+
+// ReplayedCheck is a stored check result re-scored against a new specification
+type ReplayedCheck struct {
+	Name            string `json:"name"`
+	StoredStatus    string `json:"storedStatus"`
+	ProjectedStatus string `json:"projectedStatus"`
+
+	// Reevaluated is false when the stored evidence could not be re-scored and
+	// the stored status was carried over
+	Reevaluated bool   `json:"reevaluated"`
+	Message     string `json:"message,omitempty"`
+}
+
+// ClusterReplay is the projected compliance of one cluster under a new specification
+type ClusterReplay struct {
+	ClusterName     string          `json:"clusterName"`
+	ScanTime        time.Time       `json:"scanTime"`
+	StoredFailed    int             `json:"storedFailed"`
+	ProjectedFailed int             `json:"projectedFailed"`
+	Checks          []ReplayedCheck `json:"checks"`
+}
+
+// FleetReplay is the projected fleet impact of a new specification, computed from
+// the latest stored ComplianceReport of each cluster
+type FleetReplay struct {
+	SpecName    string `json:"specName"`
+	SpecVersion string `json:"specVersion"`
+
+	TotalClusters    int `json:"totalClusters"`
+	CurrentlyFailing int `json:"currentlyFailing"`
+	ProjectedFailing int `json:"projectedFailing"`
+	NewlyFailing     int `json:"newlyFailing"`
+	NewlyPassing     int `json:"newlyPassing"`
+
+	// ReevaluatedChecks and CarriedOverChecks count check results that were
+	// re-scored and that kept their stored status
+	ReevaluatedChecks int `json:"reevaluatedChecks"`
+	CarriedOverChecks int `json:"carriedOverChecks"`
+
+	Clusters []ClusterReplay `json:"clusters"`
+}
+
+// replayFunc re-scores a check from its stored evidence. It returns false when
+// the evidence is insufficient to re-score the check.
+type replayFunc func(evidence map[string]interface{}, clusterSpec *spec.ClusterSpecification) (status, message string, ok bool)
+
+// replayFuncs are the checks whose stored evidence is rich enough to re-score
+var replayFuncs = map[string]replayFunc{
+	"kubernetes.version":   replayKubernetesVersion,
+	"capacity.pod-density": replayPodDensity,
+}
+
+// ReplayFleet re-scores the latest ComplianceReport of each cluster scanned
+// against clusterSpecName using the requirements of newSpec. The cluster is not
+// contacted; only stored report evidence is used.
+func (a *ReportAggregator) ReplayFleet(ctx context.Context, clusterSpecName string, newSpec *spec.ClusterSpecification) (*FleetReplay, error) {
+	var reports kspecv1alpha1.ComplianceReportList
+	listOpts := []client.ListOption{
+		a.reportLabels(map[string]string{
+			"kspec.io/cluster-spec": clusterSpecName,
+		}),
+	}
+
+	if err := a.List(ctx, &reports, listOpts...); err != nil {
+		return nil, fmt.Errorf("failed to list compliance reports: %w", err)
+	}
+
+	latestReports := a.getLatestReportPerCluster(reports.Items)
+	latest := make([]kspecv1alpha1.ComplianceReport, 0, len(latestReports))
+	for _, report := range latestReports {
+		latest = append(latest, *report)
+	}
+
+	return ReplayReports(latest, newSpec), nil
+}
+
+// ReplayReports re-scores stored reports against newSpec. Checks whose evidence
+// cannot be re-scored keep their stored status.
+func ReplayReports(reports []kspecv1alpha1.ComplianceReport, newSpec *spec.ClusterSpecification) *FleetReplay {
+	fleet := &FleetReplay{
+		SpecName:      newSpec.Metadata.Name,
+		SpecVersion:   newSpec.Metadata.Version,
+		TotalClusters: len(reports),
+		Clusters:      make([]ClusterReplay, 0, len(reports)),
+	}
+
+	for _, report := range reports {
+		cluster := ClusterReplay{
+			ClusterName: report.Spec.ClusterName,
+			ScanTime:    report.Spec.ScanTime.Time,
+			Checks:      make([]ReplayedCheck, 0, len(report.Spec.Results)),
+		}
+
+		for _, result := range report.Spec.Results {
+			check := replayCheck(result, newSpec)
+			if check.Reevaluated {
+				fleet.ReevaluatedChecks++
+			} else {
+				fleet.CarriedOverChecks++
+			}
+			if check.StoredStatus == "Fail" {
+				cluster.StoredFailed++
+			}
+			if check.ProjectedStatus == "Fail" {
+				cluster.ProjectedFailed++
+			}
+			cluster.Checks = append(cluster.Checks, check)
+		}
+
+		storedFailing := cluster.StoredFailed > 0
+		projectedFailing := cluster.ProjectedFailed > 0
+		if storedFailing {
+			fleet.CurrentlyFailing++
+		}
+		if projectedFailing {
+			fleet.ProjectedFailing++
+		}
+		if projectedFailing && !storedFailing {
+			fleet.NewlyFailing++
+		}
+		if storedFailing && !projectedFailing {
+			fleet.NewlyPassing++
+		}
+
+		fleet.Clusters = append(fleet.Clusters, cluster)
+	}
+
+	sort.Slice(fleet.Clusters, func(i, j int) bool {
+		return fleet.Clusters[i].ClusterName < fleet.Clusters[j].ClusterName
+	})
+
+	return fleet
+}
+
+// replayCheck re-scores a single stored check result
+func replayCheck(result kspecv1alpha1.CheckResult, newSpec *spec.ClusterSpecification) ReplayedCheck {
+	check := ReplayedCheck{
+		Name:            result.Name,
+		StoredStatus:    result.Status,
+		ProjectedStatus: result.Status,
+		Message:         "No replayable evidence; stored status carried over",
+	}
+
+	replay, ok := replayFuncs[result.Name]
+	if !ok || result.Status == "Error" {
+		return check
+	}
+
+	evidence := map[string]interface{}{}
+	if result.Details == nil || len(result.Details.Raw) == 0 || json.Unmarshal(result.Details.Raw, &evidence) != nil {
+		return check
+	}
+
+	status, message, ok := replay(evidence, newSpec)
+	if !ok {
+		return check
+	}
+
+	check.ProjectedStatus = status
+	check.Message = message
+	check.Reevaluated = true
+	return check
+}
+
+// replayKubernetesVersion re-checks the stored cluster version against the new
+// version range and excluded versions
+func replayKubernetesVersion(evidence map[string]interface{}, clusterSpec *spec.ClusterSpecification) (string, string, bool) {
+	currentVersion, _ := evidence["current"].(string)
+	current, err := semver.NewVersion(strings.TrimPrefix(currentVersion, "v"))
+	if err != nil {
+		return "", "", false
+	}
+
+	min, err := semver.NewVersion(clusterSpec.Spec.Kubernetes.MinVersion)
+	if err != nil {
+		return "", "", false
+	}
+	max, err := semver.NewVersion(clusterSpec.Spec.Kubernetes.MaxVersion)
+	if err != nil {
+		return "", "", false
+	}
+
+	for _, excludedVersion := range clusterSpec.Spec.Kubernetes.ExcludedVersions {
+		excluded, err := semver.NewVersion(excludedVersion)
+		if err == nil && current.Equal(excluded) {
+			return "Fail", fmt.Sprintf("Cluster version %s is explicitly excluded", current), true
+		}
+	}
+
+	if current.LessThan(min) || current.GreaterThan(max) {
+		return "Fail", fmt.Sprintf("Cluster version %s is outside allowed range %s - %s", current, min, max), true
+	}
+	return "Pass", fmt.Sprintf("Cluster version %s is within spec range %s - %s", current, min, max), true
+}
+
+// replayPodDensity re-checks the stored per-node pod counts against the new cap
+func replayPodDensity(evidence map[string]interface{}, clusterSpec *spec.ClusterSpecification) (string, string, bool) {
+	if clusterSpec.Spec.Capacity == nil || clusterSpec.Spec.Capacity.MaxPodsPerNode <= 0 {
+		// Skipped checks are stored as passing
+		return "Pass", "Pod density requirements not specified in cluster spec", true
+	}
+
+	counts, ok := evidence["node_pod_counts"].(map[string]interface{})
+	if !ok {
+		return "", "", false
+	}
+
+	maxPods := clusterSpec.Spec.Capacity.MaxPodsPerNode
+	violating := 0
+	for _, value := range counts {
+		count, ok := value.(float64)
+		if !ok {
+			return "", "", false
+		}
+		if int(count) > maxPods {
+			violating++
+		}
+	}
+
+	if violating > 0 {
+		return "Fail", fmt.Sprintf("Found %d nodes running more than %d pods", violating, maxPods), true
+	}
+	return "Pass", fmt.Sprintf("All %d nodes run at most %d pods", len(counts), maxPods), true
+}
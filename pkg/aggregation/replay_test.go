@@ -0,0 +1,111 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aggregation
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+)
+
+func storedReport(clusterName string, results ...kspecv1alpha1.CheckResult) kspecv1alpha1.ComplianceReport {
+	return kspecv1alpha1.ComplianceReport{
+		Spec: kspecv1alpha1.ComplianceReportSpec{
+			ClusterName: clusterName,
+			Results:     results,
+		},
+	}
+}
+
+func storedResult(name, status, evidence string) kspecv1alpha1.CheckResult {
+	result := kspecv1alpha1.CheckResult{Name: name, Status: status}
+	if evidence != "" {
+		result.Details = &runtime.RawExtension{Raw: []byte(evidence)}
+	}
+	return result
+}
+
+func TestReplayReports(t *testing.T) {
+	reports := []kspecv1alpha1.ComplianceReport{
+		storedReport("old-cluster",
+			storedResult("kubernetes.version", "Pass", `{"current":"1.26.5","required_min":"1.26.0","required_max":"1.30.0"}`),
+			storedResult("capacity.pod-density", "Pass", `{"node_pod_counts":{"node-a":80,"node-b":20},"max_pods":110}`),
+		),
+		storedReport("new-cluster",
+			storedResult("kubernetes.version", "Pass", `{"current":"1.29.1","required_min":"1.26.0","required_max":"1.30.0"}`),
+			storedResult("capacity.pod-density", "Pass", `{"node_pod_counts":{"node-c":30},"max_pods":110}`),
+			storedResult("workload.security", "Fail", `{"violation_count":2}`),
+		),
+	}
+
+	newSpec := &spec.ClusterSpecification{
+		Metadata: spec.Metadata{Name: "stricter", Version: "2.0.0"},
+		Spec: spec.SpecFields{
+			Kubernetes: spec.KubernetesSpec{MinVersion: "1.28.0", MaxVersion: "1.30.0"},
+			Capacity:   &spec.CapacitySpec{MaxPodsPerNode: 50},
+		},
+	}
+
+	fleet := ReplayReports(reports, newSpec)
+
+	if fleet.TotalClusters != 2 {
+		t.Errorf("TotalClusters = %d, expected 2", fleet.TotalClusters)
+	}
+	if fleet.CurrentlyFailing != 1 || fleet.ProjectedFailing != 2 || fleet.NewlyFailing != 1 || fleet.NewlyPassing != 0 {
+		t.Errorf("unexpected fleet impact: %+v", fleet)
+	}
+	if fleet.ReevaluatedChecks != 4 || fleet.CarriedOverChecks != 1 {
+		t.Errorf("ReevaluatedChecks = %d, CarriedOverChecks = %d, expected 4 and 1", fleet.ReevaluatedChecks, fleet.CarriedOverChecks)
+	}
+
+	// Clusters are sorted by name
+	newCluster, oldCluster := fleet.Clusters[0], fleet.Clusters[1]
+	if oldCluster.ClusterName != "old-cluster" {
+		t.Fatalf("expected clusters sorted by name, got %s first", newCluster.ClusterName)
+	}
+
+	// old-cluster fails the raised minimum version and the lower pod cap
+	if oldCluster.ProjectedFailed != 2 {
+		t.Errorf("old-cluster ProjectedFailed = %d, expected 2: %+v", oldCluster.ProjectedFailed, oldCluster.Checks)
+	}
+
+	// Checks without replayable evidence keep their stored status
+	workloads := newCluster.Checks[2]
+	if workloads.Reevaluated || workloads.ProjectedStatus != "Fail" {
+		t.Errorf("expected workload.security carried over as Fail, got %+v", workloads)
+	}
+}
+
+func TestReplayReports_MissingEvidence(t *testing.T) {
+	reports := []kspecv1alpha1.ComplianceReport{
+		storedReport("legacy", storedResult("kubernetes.version", "Pass", "")),
+	}
+
+	newSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Kubernetes: spec.KubernetesSpec{MinVersion: "1.28.0", MaxVersion: "1.30.0"},
+		},
+	}
+
+	check := ReplayReports(reports, newSpec).Clusters[0].Checks[0]
+	if check.Reevaluated || check.ProjectedStatus != "Pass" {
+		t.Errorf("expected stored status carried over without evidence, got %+v", check)
+	}
+}
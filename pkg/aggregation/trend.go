@@ -0,0 +1,129 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aggregation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+)
+
+// DefaultTrendWindow is the width of the time windows fleet compliance history
+// is bucketed into when ReportAggregator.TrendWindow is not set
+const DefaultTrendWindow = time.Hour
+
+// FleetComplianceHistory represents historical compliance data across all clusters
+type FleetComplianceHistory struct {
+	ClusterSpecName string
+	Window          time.Duration
+	DataPoints      []FleetComplianceDataPoint
+}
+
+// FleetComplianceDataPoint aggregates the compliance of the clusters scanned
+// within one time window
+type FleetComplianceDataPoint struct {
+	// Timestamp is the start of the window
+	Timestamp time.Time
+
+	// Clusters is the number of clusters scanned within the window; each
+	// contributes its latest scan in the window
+	Clusters int
+
+	TotalChecks     int
+	PassedChecks    int
+	FailedChecks    int
+	ComplianceScore float64 // Percentage of passed checks
+}
+
+// GetFleetComplianceHistory returns the fleet's compliance over time, bucketing
+// every cluster's compliance reports into windows of TrendWindow. Each data
+// point only counts the clusters scanned within its window, so clusters joining
+// or leaving the fleet do not skew the windows they are absent from. At most
+// limit data points are returned, the most recent ones, in chronological order.
+func (a *ReportAggregator) GetFleetComplianceHistory(ctx context.Context, clusterSpecName string, limit int) (*FleetComplianceHistory, error) {
+	var reports kspecv1alpha1.ComplianceReportList
+	listOpts := []client.ListOption{
+		a.reportLabels(map[string]string{
+			"kspec.io/cluster-spec": clusterSpecName,
+		}),
+	}
+
+	if err := a.List(ctx, &reports, listOpts...); err != nil {
+		return nil, fmt.Errorf("failed to list compliance reports: %w", err)
+	}
+
+	window := a.TrendWindow
+	if window <= 0 {
+		window = DefaultTrendWindow
+	}
+
+	return &FleetComplianceHistory{
+		ClusterSpecName: clusterSpecName,
+		Window:          window,
+		DataPoints:      a.fleetTrend(reports.Items, window, limit),
+	}, nil
+}
+
+// fleetTrend buckets compliance reports into windows and aggregates the latest
+// report of each cluster per window.
+func (a *ReportAggregator) fleetTrend(reports []kspecv1alpha1.ComplianceReport, window time.Duration, limit int) []FleetComplianceDataPoint {
+	// Group reports by the window they were scanned in
+	buckets := make(map[time.Time][]kspecv1alpha1.ComplianceReport)
+	for _, report := range reports {
+		start := report.Spec.ScanTime.Time.UTC().Truncate(window)
+		buckets[start] = append(buckets[start], report)
+	}
+
+	starts := make([]time.Time, 0, len(buckets))
+	for start := range buckets {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool {
+		return starts[i].Before(starts[j])
+	})
+
+	// Keep the most recent windows
+	if limit > 0 && len(starts) > limit {
+		starts = starts[len(starts)-limit:]
+	}
+
+	dataPoints := make([]FleetComplianceDataPoint, len(starts))
+	for i, start := range starts {
+		latestReports := a.getLatestReportPerCluster(buckets[start])
+
+		point := FleetComplianceDataPoint{
+			Timestamp: start,
+			Clusters:  len(latestReports),
+		}
+		for _, report := range latestReports {
+			point.TotalChecks += report.Spec.Summary.Total
+			point.PassedChecks += report.Spec.Summary.Passed
+			point.FailedChecks += report.Spec.Summary.Failed
+		}
+		if point.TotalChecks > 0 {
+			point.ComplianceScore = float64(point.PassedChecks) / float64(point.TotalChecks) * 100
+		}
+		dataPoints[i] = point
+	}
+
+	return dataPoints
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aggregation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+)
+
+var trendStart = time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+func trendReport(name, clusterName string, scanTime time.Time, passed, failed int) *kspecv1alpha1.ComplianceReport {
+	return &kspecv1alpha1.ComplianceReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "kspec-system",
+			Labels:    map[string]string{"kspec.io/cluster-spec": "prod"},
+		},
+		Spec: kspecv1alpha1.ComplianceReportSpec{
+			ClusterName: clusterName,
+			ScanTime:    metav1.NewTime(scanTime),
+			Summary: kspecv1alpha1.ReportSummary{
+				Total:  passed + failed,
+				Passed: passed,
+				Failed: failed,
+			},
+		},
+	}
+}
+
+func TestGetFleetComplianceHistory(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kspecv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to build scheme: %v", err)
+	}
+
+	reports := []*kspecv1alpha1.ComplianceReport{
+		// 10:00 window: both clusters, staging scanned twice
+		trendReport("prod-1", "prod", trendStart.Add(5*time.Minute), 10, 0),
+		trendReport("staging-1", "staging", trendStart.Add(10*time.Minute), 5, 5),
+		trendReport("staging-2", "staging", trendStart.Add(40*time.Minute), 8, 2),
+		// 11:00 window: staging has left the fleet
+		trendReport("prod-2", "prod", trendStart.Add(70*time.Minute), 9, 1),
+		// 12:00 window: a new cluster has joined
+		trendReport("prod-3", "prod", trendStart.Add(130*time.Minute), 10, 0),
+		trendReport("dev-1", "dev", trendStart.Add(135*time.Minute), 0, 10),
+	}
+	objects := make([]runtime.Object, len(reports))
+	for i := range reports {
+		objects[i] = reports[i]
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+	aggregator := NewReportAggregator(k8sClient)
+
+	history, err := aggregator.GetFleetComplianceHistory(context.Background(), "prod", 0)
+	if err != nil {
+		t.Fatalf("GetFleetComplianceHistory() error = %v", err)
+	}
+	if history.Window != DefaultTrendWindow {
+		t.Errorf("Window = %v, want %v", history.Window, DefaultTrendWindow)
+	}
+
+	want := []FleetComplianceDataPoint{
+		{Timestamp: trendStart, Clusters: 2, TotalChecks: 20, PassedChecks: 18, FailedChecks: 2, ComplianceScore: 90},
+		{Timestamp: trendStart.Add(time.Hour), Clusters: 1, TotalChecks: 10, PassedChecks: 9, FailedChecks: 1, ComplianceScore: 90},
+		{Timestamp: trendStart.Add(2 * time.Hour), Clusters: 2, TotalChecks: 20, PassedChecks: 10, FailedChecks: 10, ComplianceScore: 50},
+	}
+	if len(history.DataPoints) != len(want) {
+		t.Fatalf("got %d data points, want %d: %+v", len(history.DataPoints), len(want), history.DataPoints)
+	}
+	for i := range want {
+		got := history.DataPoints[i]
+		if !got.Timestamp.Equal(want[i].Timestamp) || got.Clusters != want[i].Clusters ||
+			got.TotalChecks != want[i].TotalChecks || got.PassedChecks != want[i].PassedChecks ||
+			got.FailedChecks != want[i].FailedChecks || got.ComplianceScore != want[i].ComplianceScore {
+			t.Errorf("data point %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+
+	limited, err := aggregator.GetFleetComplianceHistory(context.Background(), "prod", 2)
+	if err != nil {
+		t.Fatalf("GetFleetComplianceHistory() error = %v", err)
+	}
+	if len(limited.DataPoints) != 2 || !limited.DataPoints[0].Timestamp.Equal(trendStart.Add(time.Hour)) {
+		t.Errorf("Expected the 2 most recent windows, got %+v", limited.DataPoints)
+	}
+}
+
+func TestGetFleetComplianceHistory_Window(t *testing.T) {
+	reports := []kspecv1alpha1.ComplianceReport{
+		*trendReport("prod-1", "prod", trendStart.Add(5*time.Minute), 10, 0),
+		*trendReport("prod-2", "prod", trendStart.Add(70*time.Minute), 9, 1),
+	}
+
+	dataPoints := NewReportAggregator(nil).fleetTrend(reports, 24*time.Hour, 0)
+	if len(dataPoints) != 1 {
+		t.Fatalf("got %d data points, want 1: %+v", len(dataPoints), dataPoints)
+	}
+	if dataPoints[0].Clusters != 1 || dataPoints[0].PassedChecks != 9 {
+		t.Errorf("Expected the latest scan of the day to count, got %+v", dataPoints[0])
+	}
+}
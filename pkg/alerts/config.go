@@ -0,0 +1,124 @@
+package alerts
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/yaml"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+)
+
+// LoadConfigFile reads alert configuration for use outside the operator. The
+// file may hold a full AlertConfig manifest or just its spec.
+func LoadConfigFile(path string) (*kspecv1alpha1.AlertConfigSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alert config: %w", err)
+	}
+
+	var alertConfig kspecv1alpha1.AlertConfig
+	if err := yaml.Unmarshal(data, &alertConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse alert config: %w", err)
+	}
+	if alertConfig.Kind != "" {
+		if alertConfig.Kind != "AlertConfig" {
+			return nil, fmt.Errorf("unexpected kind %s in alert config (expected AlertConfig)", alertConfig.Kind)
+		}
+		return &alertConfig.Spec, nil
+	}
+
+	var configSpec kspecv1alpha1.AlertConfigSpec
+	if err := yaml.Unmarshal(data, &configSpec); err != nil {
+		return nil, fmt.Errorf("failed to parse alert config: %w", err)
+	}
+	return &configSpec, nil
+}
+
+// NewManagerFromConfig builds a Manager with the Slack, PagerDuty, Teams and
+// webhook notifiers of an AlertConfig spec, applying the same defaults as the operator. Secret
+// references cannot be resolved without the operator, so URLs and routing keys
+// must be inline.
+func NewManagerFromConfig(configSpec *kspecv1alpha1.AlertConfigSpec, logger logr.Logger) (*Manager, error) {
+	manager := NewManager(logger)
+	if configSpec.Enabled != nil && !*configSpec.Enabled {
+		return manager, nil
+	}
+
+	if slackConfig := configSpec.Slack; slackConfig != nil && slackConfig.Enabled {
+		if slackConfig.WebhookURLSecretRef != nil {
+			return nil, fmt.Errorf("slack: webhookURLSecretRef is only supported by the operator, set webhookURL instead")
+		}
+		if slackConfig.WebhookURL == "" {
+			return nil, fmt.Errorf("slack: webhook URL is required but not provided")
+		}
+		if err := ValidateTemplate(slackConfig.Template); err != nil {
+			return nil, fmt.Errorf("slack: invalid template: %w", err)
+		}
+
+		notifier := NewSlackNotifier(slackConfig.WebhookURL, slackConfig.Channel, slackConfig.Username, slackConfig.IconEmoji)
+		notifier.EventFilter = slackConfig.Events
+		notifier.Template = slackConfig.Template
+		if err := manager.AddNotifier(notifier); err != nil {
+			return nil, fmt.Errorf("slack: %w", err)
+		}
+	}
+
+	if pagerDutyConfig := configSpec.PagerDuty; pagerDutyConfig != nil && pagerDutyConfig.Enabled {
+		if pagerDutyConfig.RoutingKeySecretRef != nil {
+			return nil, fmt.Errorf("pagerduty: routingKeySecretRef is only supported by the operator, set routingKey instead")
+		}
+		if pagerDutyConfig.RoutingKey == "" {
+			return nil, fmt.Errorf("pagerduty: routing key is required but not provided")
+		}
+
+		notifier := NewPagerDutyNotifier(pagerDutyConfig.RoutingKey, pagerDutyConfig.EventsURL)
+		notifier.EventFilter = pagerDutyConfig.Events
+		if err := manager.AddNotifier(notifier); err != nil {
+			return nil, fmt.Errorf("pagerduty: %w", err)
+		}
+	}
+
+	if teamsConfig := configSpec.Teams; teamsConfig != nil && teamsConfig.Enabled {
+		if teamsConfig.WebhookURLSecretRef != nil {
+			return nil, fmt.Errorf("teams: webhookURLSecretRef is only supported by the operator, set webhookURL instead")
+		}
+		if teamsConfig.WebhookURL == "" {
+			return nil, fmt.Errorf("teams: webhook URL is required but not provided")
+		}
+
+		notifier := NewTeamsNotifier(teamsConfig.WebhookURL)
+		notifier.EventFilter = teamsConfig.Events
+		if err := manager.AddNotifier(notifier); err != nil {
+			return nil, fmt.Errorf("teams: %w", err)
+		}
+	}
+
+	for i, webhookConfig := range configSpec.Webhooks {
+		if webhookConfig.URLSecretRef != nil || webhookConfig.HeadersSecretRef != nil {
+			return nil, fmt.Errorf("webhook[%d] %s: secret references are only supported by the operator, set url and headers instead", i, webhookConfig.Name)
+		}
+		if webhookConfig.URL == "" {
+			return nil, fmt.Errorf("webhook[%d] %s: webhook URL is required but not provided", i, webhookConfig.Name)
+		}
+		if err := ValidateTemplate(webhookConfig.Template); err != nil {
+			return nil, fmt.Errorf("webhook[%d] %s: invalid template: %w", i, webhookConfig.Name, err)
+		}
+
+		notifier := NewWebhookNotifier(webhookConfig.Name, webhookConfig.URL, webhookConfig.Method, webhookConfig.Headers, webhookConfig.Template)
+		notifier.EventFilter = webhookConfig.Events
+		if webhookConfig.RetryAttempts > 0 {
+			notifier.RetryAttempts = webhookConfig.RetryAttempts
+		}
+		if webhookConfig.TimeoutSeconds > 0 {
+			notifier.Timeout = time.Duration(webhookConfig.TimeoutSeconds) * time.Second
+		}
+		if err := manager.AddNotifier(notifier); err != nil {
+			return nil, fmt.Errorf("webhook[%d] %s: %w", i, webhookConfig.Name, err)
+		}
+	}
+
+	return manager, nil
+}
@@ -0,0 +1,212 @@
+package alerts
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+)
+
+func writeAlertConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "alerts.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write alert config: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "full manifest",
+			content: `apiVersion: kspec.io/v1alpha1
+kind: AlertConfig
+metadata:
+  name: cli
+spec:
+  slack:
+    enabled: true
+    webhookURL: https://hooks.slack.com/services/T/B/X
+  webhooks:
+  - name: ops
+    url: https://ops.example.com/hook
+`,
+		},
+		{
+			name: "bare spec",
+			content: `slack:
+  enabled: true
+  webhookURL: https://hooks.slack.com/services/T/B/X
+webhooks:
+- name: ops
+  url: https://ops.example.com/hook
+`,
+		},
+		{
+			name:    "wrong kind",
+			content: "kind: ClusterSpecification\nspec: {}\n",
+			wantErr: "unexpected kind ClusterSpecification",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configSpec, err := LoadConfigFile(writeAlertConfig(t, tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if configSpec.Slack == nil || configSpec.Slack.WebhookURL != "https://hooks.slack.com/services/T/B/X" {
+				t.Errorf("expected slack webhook URL to be loaded, got %+v", configSpec.Slack)
+			}
+			if len(configSpec.Webhooks) != 1 || configSpec.Webhooks[0].URL != "https://ops.example.com/hook" {
+				t.Errorf("expected one webhook to be loaded, got %+v", configSpec.Webhooks)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFileMissing(t *testing.T) {
+	if _, err := LoadConfigFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected error for missing alert config file")
+	}
+}
+
+func TestNewManagerFromConfig(t *testing.T) {
+	disabled := false
+
+	tests := []struct {
+		name          string
+		configSpec    kspecv1alpha1.AlertConfigSpec
+		wantNotifiers []string
+		wantErr       string
+	}{
+		{
+			name: "slack and webhook",
+			configSpec: kspecv1alpha1.AlertConfigSpec{
+				Slack:    &kspecv1alpha1.SlackConfig{Enabled: true, WebhookURL: "https://hooks.slack.com/services/T/B/X"},
+				Webhooks: []kspecv1alpha1.WebhookConfig{{Name: "ops", URL: "https://ops.example.com/hook"}},
+			},
+			wantNotifiers: []string{"ops", "slack"},
+		},
+		{
+			name: "pagerduty",
+			configSpec: kspecv1alpha1.AlertConfigSpec{
+				PagerDuty: &kspecv1alpha1.PagerDutyConfig{Enabled: true, RoutingKey: "R0UT1NGK3Y"},
+			},
+			wantNotifiers: []string{"pagerduty"},
+		},
+		{
+			name: "pagerduty secret reference",
+			configSpec: kspecv1alpha1.AlertConfigSpec{
+				PagerDuty: &kspecv1alpha1.PagerDutyConfig{
+					Enabled:             true,
+					RoutingKeySecretRef: &kspecv1alpha1.SecretReference{Name: "pagerduty"},
+				},
+			},
+			wantErr: "only supported by the operator",
+		},
+		{
+			name: "teams",
+			configSpec: kspecv1alpha1.AlertConfigSpec{
+				Teams: &kspecv1alpha1.TeamsConfig{Enabled: true, WebhookURL: "https://example.webhook.office.com/webhookb2/x"},
+			},
+			wantNotifiers: []string{"teams"},
+		},
+		{
+			name: "globally disabled",
+			configSpec: kspecv1alpha1.AlertConfigSpec{
+				Enabled:  &disabled,
+				Webhooks: []kspecv1alpha1.WebhookConfig{{Name: "ops", URL: "https://ops.example.com/hook"}},
+			},
+		},
+		{
+			name: "slack disabled",
+			configSpec: kspecv1alpha1.AlertConfigSpec{
+				Slack: &kspecv1alpha1.SlackConfig{Enabled: false},
+			},
+		},
+		{
+			name: "secret reference",
+			configSpec: kspecv1alpha1.AlertConfigSpec{
+				Webhooks: []kspecv1alpha1.WebhookConfig{{
+					Name:         "ops",
+					URLSecretRef: &kspecv1alpha1.SecretReference{Name: "ops-webhook"},
+				}},
+			},
+			wantErr: "only supported by the operator",
+		},
+		{
+			name: "missing URL",
+			configSpec: kspecv1alpha1.AlertConfigSpec{
+				Slack: &kspecv1alpha1.SlackConfig{Enabled: true},
+			},
+			wantErr: "webhook URL is required",
+		},
+		{
+			name: "invalid template",
+			configSpec: kspecv1alpha1.AlertConfigSpec{
+				Webhooks: []kspecv1alpha1.WebhookConfig{{Name: "ops", URL: "https://ops.example.com/hook", Template: "{{ .Title"}},
+			},
+			wantErr: "invalid template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, err := NewManagerFromConfig(&tt.configSpec, logr.Discard())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			notifiers := manager.ListNotifiers()
+			sort.Strings(notifiers)
+			if strings.Join(notifiers, ",") != strings.Join(tt.wantNotifiers, ",") {
+				t.Errorf("expected notifiers %v, got %v", tt.wantNotifiers, notifiers)
+			}
+		})
+	}
+}
+
+func TestNewManagerFromConfigDefaults(t *testing.T) {
+	manager, err := NewManagerFromConfig(&kspecv1alpha1.AlertConfigSpec{
+		Slack:    &kspecv1alpha1.SlackConfig{Enabled: true, WebhookURL: "https://hooks.slack.com/services/T/B/X"},
+		Webhooks: []kspecv1alpha1.WebhookConfig{{Name: "ops", URL: "https://ops.example.com/hook"}},
+	}, logr.Discard())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	slack, _ := manager.GetNotifier("slack")
+	if s := slack.(*SlackNotifier); s.Username != "kspec-bot" || s.IconEmoji != ":shield:" {
+		t.Errorf("expected slack defaults, got username %q icon %q", s.Username, s.IconEmoji)
+	}
+
+	webhook, _ := manager.GetNotifier("ops")
+	w := webhook.(*WebhookNotifier)
+	if w.Method != "POST" || w.RetryAttempts != 3 || w.Timeout != 10*time.Second {
+		t.Errorf("expected webhook defaults, got method %s retries %d timeout %s", w.Method, w.RetryAttempts, w.Timeout)
+	}
+}
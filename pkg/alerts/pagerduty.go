@@ -0,0 +1,176 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultPagerDutyEventsURL is the PagerDuty Events API v2 endpoint
+const DefaultPagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// pagerDutySummaryLimit is the maximum length of an event summary
+const pagerDutySummaryLimit = 1024
+
+// PagerDutyNotifier triggers PagerDuty incidents via the Events API v2
+type PagerDutyNotifier struct {
+	RoutingKey  string
+	EventsURL   string
+	Enabled_    bool
+	EventFilter []string // List of event types to send (empty = all)
+	Timeout     time.Duration
+}
+
+// NewPagerDutyNotifier creates a new PagerDuty notifier for the integration
+// with the given routing key. eventsURL defaults to the public Events API.
+func NewPagerDutyNotifier(routingKey, eventsURL string) *PagerDutyNotifier {
+	if eventsURL == "" {
+		eventsURL = DefaultPagerDutyEventsURL
+	}
+
+	return &PagerDutyNotifier{
+		RoutingKey: routingKey,
+		EventsURL:  eventsURL,
+		Enabled_:   true,
+		Timeout:    10 * time.Second,
+	}
+}
+
+// Send triggers a PagerDuty event for the alert
+func (p *PagerDutyNotifier) Send(ctx context.Context, alert Alert) error {
+	if p.RoutingKey == "" {
+		return fmt.Errorf("pagerduty routing key is not configured")
+	}
+
+	data, err := json.Marshal(p.buildPayload(alert))
+	if err != nil {
+		return fmt.Errorf("failed to marshal PagerDuty payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.EventsURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{
+		Timeout: p.Timeout,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The Events API answers 202 Accepted for enqueued events
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("pagerduty API returned non-2xx status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Name returns the name of this notifier
+func (p *PagerDutyNotifier) Name() string {
+	return "pagerduty"
+}
+
+// Enabled returns whether this notifier is enabled
+func (p *PagerDutyNotifier) Enabled() bool {
+	return p.Enabled_
+}
+
+// ShouldSend determines if this alert should be sent based on event filters
+func (p *PagerDutyNotifier) ShouldSend(alert Alert) bool {
+	// If no filters configured, send all
+	if len(p.EventFilter) == 0 {
+		return true
+	}
+
+	// Check if alert's event type is in the filter list
+	for _, eventType := range p.EventFilter {
+		if eventType == alert.EventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// buildPayload constructs the Events API v2 trigger event
+func (p *PagerDutyNotifier) buildPayload(alert Alert) map[string]interface{} {
+	event := NewTemplateContext(alert, nil)
+
+	summary := alert.Title
+	if event.Cluster != "" {
+		summary = fmt.Sprintf("%s (cluster %s)", alert.Title, event.Cluster)
+	}
+	if len(summary) > pagerDutySummaryLimit {
+		summary = summary[:pagerDutySummaryLimit]
+	}
+
+	source := event.Cluster
+	if source == "" {
+		source = alert.Source
+	}
+
+	timestamp := alert.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	details := map[string]interface{}{
+		"description": alert.Description,
+		"source":      alert.Source,
+	}
+	for key, value := range alert.Metadata {
+		details[key] = value
+	}
+
+	payload := map[string]interface{}{
+		"summary":        summary,
+		"source":         source,
+		"severity":       p.severity(alert, event),
+		"timestamp":      timestamp.Format(time.RFC3339),
+		"class":          alert.EventType,
+		"custom_details": details,
+	}
+	if event.Spec != "" {
+		payload["component"] = event.Spec
+	}
+
+	return map[string]interface{}{
+		"routing_key":  p.RoutingKey,
+		"event_action": "trigger",
+		"dedup_key":    p.dedupKey(alert, event),
+		"payload":      payload,
+	}
+}
+
+// severity maps an alert to a PagerDuty severity (critical, warning or info).
+// Compliance failures with a failing critical-severity check page as critical.
+func (p *PagerDutyNotifier) severity(alert Alert, event TemplateContext) string {
+	if alert.EventType == "ComplianceFailure" && event.Counts["critical_failed"] > 0 {
+		return "critical"
+	}
+
+	switch alert.Level {
+	case AlertLevelCritical:
+		return "critical"
+	case AlertLevelWarning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// dedupKey groups repeated events for the same cluster, spec and event type
+// into one PagerDuty incident
+func (p *PagerDutyNotifier) dedupKey(alert Alert, event TemplateContext) string {
+	return fmt.Sprintf("kspec/%s/%s/%s", event.Cluster, event.Spec, alert.EventType)
+}
@@ -0,0 +1,147 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPagerDutyNotifier_Send(t *testing.T) {
+	var receivedPayload map[string]interface{}
+
+	// Mock Events API
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&receivedPayload); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	notifier := NewPagerDutyNotifier("R0UT1NGK3Y", server.URL)
+
+	alert := Alert{
+		Level:       AlertLevelCritical,
+		Title:       "Configuration drift detected",
+		Description: "Detected 3 drift event(s) in cluster prod-cluster",
+		Source:      "ClusterSpec/prod-spec",
+		Timestamp:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EventType:   "DriftDetected",
+		Labels: map[string]string{
+			"cluster": "prod-cluster",
+			"spec":    "prod-spec",
+		},
+		Metadata: map[string]interface{}{
+			"event_count": 3,
+		},
+	}
+
+	if err := notifier.Send(context.Background(), alert); err != nil {
+		t.Fatalf("Send() failed: %v", err)
+	}
+
+	if receivedPayload["routing_key"] != "R0UT1NGK3Y" {
+		t.Errorf("Expected routing key, got %v", receivedPayload["routing_key"])
+	}
+	if receivedPayload["event_action"] != "trigger" {
+		t.Errorf("Expected trigger event, got %v", receivedPayload["event_action"])
+	}
+	if receivedPayload["dedup_key"] != "kspec/prod-cluster/prod-spec/DriftDetected" {
+		t.Errorf("Unexpected dedup key %v", receivedPayload["dedup_key"])
+	}
+
+	payload, ok := receivedPayload["payload"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected payload object")
+	}
+	if payload["summary"] != "Configuration drift detected (cluster prod-cluster)" {
+		t.Errorf("Unexpected summary %v", payload["summary"])
+	}
+	if payload["severity"] != "critical" {
+		t.Errorf("Expected severity critical, got %v", payload["severity"])
+	}
+	if payload["source"] != "prod-cluster" || payload["component"] != "prod-spec" {
+		t.Errorf("Unexpected source %v and component %v", payload["source"], payload["component"])
+	}
+	if payload["timestamp"] != "2024-01-01T00:00:00Z" {
+		t.Errorf("Unexpected timestamp %v", payload["timestamp"])
+	}
+	details := payload["custom_details"].(map[string]interface{})
+	if details["event_count"] != float64(3) {
+		t.Errorf("Expected metadata in custom details, got %v", details)
+	}
+}
+
+func TestPagerDutyNotifier_Severity(t *testing.T) {
+	notifier := NewPagerDutyNotifier("R0UT1NGK3Y", "")
+
+	tests := []struct {
+		name     string
+		alert    Alert
+		expected string
+	}{
+		{
+			name:     "critical",
+			alert:    Alert{Level: AlertLevelCritical, EventType: "DriftDetected"},
+			expected: "critical",
+		},
+		{
+			name:     "warning",
+			alert:    Alert{Level: AlertLevelWarning, EventType: "ComplianceFailure"},
+			expected: "warning",
+		},
+		{
+			name:     "info",
+			alert:    Alert{Level: AlertLevelInfo, EventType: "RemediationPerformed"},
+			expected: "info",
+		},
+		{
+			name: "critical compliance failure",
+			alert: Alert{
+				Level:     AlertLevelWarning,
+				EventType: "ComplianceFailure",
+				Metadata:  map[string]interface{}{"critical_failed": 2},
+			},
+			expected: "critical",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := notifier.buildPayload(tt.alert)["payload"].(map[string]interface{})
+			if payload["severity"] != tt.expected {
+				t.Errorf("Expected severity %s, got %v", tt.expected, payload["severity"])
+			}
+		})
+	}
+}
+
+func TestPagerDutyNotifier_Errors(t *testing.T) {
+	if err := NewPagerDutyNotifier("", "").Send(context.Background(), Alert{}); err == nil {
+		t.Error("Expected error for missing routing key")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	if err := NewPagerDutyNotifier("R0UT1NGK3Y", server.URL).Send(context.Background(), Alert{}); err == nil {
+		t.Error("Expected error for non-2xx response")
+	}
+}
+
+func TestPagerDutyNotifier_Defaults(t *testing.T) {
+	notifier := NewPagerDutyNotifier("R0UT1NGK3Y", "")
+	if notifier.EventsURL != DefaultPagerDutyEventsURL {
+		t.Errorf("Expected default events URL, got %s", notifier.EventsURL)
+	}
+	if notifier.Name() != "pagerduty" || !notifier.Enabled() {
+		t.Errorf("Unexpected name %s or enabled %v", notifier.Name(), notifier.Enabled())
+	}
+}
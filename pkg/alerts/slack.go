@@ -17,6 +17,7 @@ type SlackNotifier struct {
 	IconEmoji   string
 	Enabled_    bool
 	EventFilter []string // List of event types to send (empty = all)
+	Template    string   // Optional Go template replacing the default message payload
 }
 
 // NewSlackNotifier creates a new Slack notifier
@@ -43,11 +44,9 @@ func (s *SlackNotifier) Send(ctx context.Context, alert Alert) error {
 		return fmt.Errorf("slack webhook URL is not configured")
 	}
 
-	payload := s.buildPayload(alert)
-
-	data, err := json.Marshal(payload)
+	data, err := s.renderPayload(alert)
 	if err != nil {
-		return fmt.Errorf("failed to marshal Slack payload: %w", err)
+		return err
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", s.WebhookURL, bytes.NewReader(data))
@@ -101,6 +100,23 @@ func (s *SlackNotifier) ShouldSend(alert Alert) bool {
 	return false
 }
 
+// renderPayload renders the message using the template or the default attachment payload
+func (s *SlackNotifier) renderPayload(alert Alert) ([]byte, error) {
+	if s.Template != "" {
+		data, err := RenderTemplate(s.Template, alert, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render Slack payload: %w", err)
+		}
+		return data, nil
+	}
+
+	data, err := json.Marshal(s.buildPayload(alert))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Slack payload: %w", err)
+	}
+	return data, nil
+}
+
 // buildPayload constructs the Slack message payload
 func (s *SlackNotifier) buildPayload(alert Alert) map[string]interface{} {
 	attachment := map[string]interface{}{
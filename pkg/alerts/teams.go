@@ -0,0 +1,141 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// TeamsNotifier sends alerts to Microsoft Teams incoming webhooks as Adaptive
+// Cards. Delivery, retries and timeouts are those of the embedded webhook
+// notifier.
+type TeamsNotifier struct {
+	*WebhookNotifier
+}
+
+// NewTeamsNotifier creates a new Microsoft Teams notifier
+func NewTeamsNotifier(webhookURL string) *TeamsNotifier {
+	return &TeamsNotifier{
+		WebhookNotifier: NewWebhookNotifier("teams", webhookURL, "POST", nil, ""),
+	}
+}
+
+// Send sends an alert to Teams
+func (t *TeamsNotifier) Send(ctx context.Context, alert Alert) error {
+	if t.URL == "" {
+		return fmt.Errorf("teams webhook URL is not configured")
+	}
+
+	data, err := json.Marshal(t.buildPayload(alert))
+	if err != nil {
+		return fmt.Errorf("failed to marshal Teams payload: %w", err)
+	}
+
+	return t.deliver(ctx, data)
+}
+
+// buildPayload constructs a Teams message carrying an Adaptive Card with a
+// color-coded header and a facts table of the event context
+func (t *TeamsNotifier) buildPayload(alert Alert) map[string]interface{} {
+	event := NewTemplateContext(alert, nil)
+
+	body := []interface{}{
+		map[string]interface{}{
+			"type":  "Container",
+			"style": t.cardStyle(alert),
+			"bleed": true,
+			"items": []interface{}{
+				map[string]interface{}{
+					"type":   "TextBlock",
+					"text":   alert.Title,
+					"weight": "Bolder",
+					"size":   "Medium",
+					"wrap":   true,
+				},
+			},
+		},
+	}
+
+	if alert.Description != "" {
+		body = append(body, map[string]interface{}{
+			"type": "TextBlock",
+			"text": alert.Description,
+			"wrap": true,
+		})
+	}
+
+	body = append(body, map[string]interface{}{
+		"type":  "FactSet",
+		"facts": t.buildFacts(alert, event),
+	})
+
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []interface{}{
+			map[string]interface{}{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body":    body,
+					"msteams": map[string]interface{}{"width": "Full"},
+				},
+			},
+		},
+	}
+}
+
+// cardStyle returns the Adaptive Card container style for an alert: red for
+// failures, yellow for drift, and otherwise by alert level
+func (t *TeamsNotifier) cardStyle(alert Alert) string {
+	switch alert.EventType {
+	case "ComplianceFailure", "PolicyViolation":
+		return "attention" // Red
+	case "DriftDetected":
+		return "warning" // Yellow
+	}
+
+	switch alert.Level {
+	case AlertLevelCritical:
+		return "attention" // Red
+	case AlertLevelWarning:
+		return "warning" // Yellow
+	case AlertLevelInfo:
+		return "good" // Green
+	default:
+		return "default"
+	}
+}
+
+// buildFacts creates the facts table of cluster, spec and check counts
+func (t *TeamsNotifier) buildFacts(alert Alert, event TemplateContext) []map[string]string {
+	facts := []map[string]string{
+		{"title": "Severity", "value": event.Severity},
+	}
+
+	if alert.EventType != "" {
+		facts = append(facts, map[string]string{"title": "Event Type", "value": alert.EventType})
+	}
+	if event.Cluster != "" {
+		facts = append(facts, map[string]string{"title": "Cluster", "value": event.Cluster})
+	}
+	if event.Spec != "" {
+		facts = append(facts, map[string]string{"title": "Spec", "value": event.Spec})
+	}
+	if passed, ok := event.Counts["passed"]; ok {
+		facts = append(facts, map[string]string{"title": "Passed", "value": fmt.Sprintf("%d", passed)})
+	}
+	if failed, ok := event.Counts["failed"]; ok {
+		facts = append(facts, map[string]string{"title": "Failed", "value": fmt.Sprintf("%d", failed)})
+	}
+	if len(event.FailedChecks) > 0 {
+		facts = append(facts, map[string]string{"title": "Failed Checks", "value": strings.Join(event.FailedChecks, ", ")})
+	}
+	if alert.Source != "" {
+		facts = append(facts, map[string]string{"title": "Source", "value": alert.Source})
+	}
+
+	return facts
+}
@@ -0,0 +1,109 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTeamsNotifier_Send(t *testing.T) {
+	var receivedPayload map[string]interface{}
+
+	// Mock Teams incoming webhook
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected Content-Type application/json, got %s", r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&receivedPayload); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewTeamsNotifier(server.URL)
+	if err := notifier.Send(context.Background(), templateTestAlert()); err != nil {
+		t.Fatalf("Send() failed: %v", err)
+	}
+
+	if receivedPayload["type"] != "message" {
+		t.Errorf("Expected message payload, got %v", receivedPayload["type"])
+	}
+	attachments, ok := receivedPayload["attachments"].([]interface{})
+	if !ok || len(attachments) != 1 {
+		t.Fatalf("Expected one attachment, got %v", receivedPayload["attachments"])
+	}
+	attachment := attachments[0].(map[string]interface{})
+	if attachment["contentType"] != "application/vnd.microsoft.card.adaptive" {
+		t.Errorf("Expected Adaptive Card attachment, got %v", attachment["contentType"])
+	}
+
+	card := attachment["content"].(map[string]interface{})
+	body := card["body"].([]interface{})
+	header := body[0].(map[string]interface{})
+	if header["style"] != "attention" {
+		t.Errorf("Expected compliance failure to be styled attention, got %v", header["style"])
+	}
+
+	facts := map[string]string{}
+	for _, fact := range body[len(body)-1].(map[string]interface{})["facts"].([]interface{}) {
+		f := fact.(map[string]interface{})
+		facts[f["title"].(string)] = f["value"].(string)
+	}
+	expected := map[string]string{
+		"Cluster":       "prod",
+		"Spec":          "prod-spec",
+		"Passed":        "3",
+		"Failed":        "2",
+		"Failed Checks": "network.policies, workload.security",
+	}
+	for title, value := range expected {
+		if facts[title] != value {
+			t.Errorf("Expected fact %s=%s, got %q", title, value, facts[title])
+		}
+	}
+}
+
+func TestTeamsNotifier_CardStyle(t *testing.T) {
+	notifier := NewTeamsNotifier("https://example.webhook.office.com/test")
+
+	tests := []struct {
+		name     string
+		alert    Alert
+		expected string
+	}{
+		{"compliance failure", Alert{Level: AlertLevelWarning, EventType: "ComplianceFailure"}, "attention"},
+		{"drift", Alert{Level: AlertLevelCritical, EventType: "DriftDetected"}, "warning"},
+		{"critical", Alert{Level: AlertLevelCritical, EventType: "CircuitBreakerTripped"}, "attention"},
+		{"info", Alert{Level: AlertLevelInfo, EventType: "RemediationPerformed"}, "good"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if style := notifier.cardStyle(tt.alert); style != tt.expected {
+				t.Errorf("Expected style %s, got %s", tt.expected, style)
+			}
+		})
+	}
+}
+
+func TestTeamsNotifier_Errors(t *testing.T) {
+	if err := NewTeamsNotifier("").Send(context.Background(), Alert{}); err == nil {
+		t.Error("Expected error for missing webhook URL")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := NewTeamsNotifier(server.URL)
+	notifier.RetryAttempts = 0
+	if err := notifier.Send(context.Background(), Alert{}); err == nil {
+		t.Error("Expected error for non-2xx response")
+	}
+}
@@ -0,0 +1,144 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateContext is the data available to notifier payload templates.
+// It embeds the Alert, so .Title, .Level, .Labels, .Metadata etc. keep working,
+// and adds the event context extracted from the alert's labels and metadata.
+type TemplateContext struct {
+	Alert
+
+	// Cluster is the name of the cluster the event concerns
+	Cluster string
+
+	// Spec is the name of the ClusterSpecification the event concerns
+	Spec string
+
+	// Severity is the alert level as a string (critical, warning, info)
+	Severity string
+
+	// Counts holds every integer metadata value, e.g. failed, passed, event_count
+	Counts map[string]int64
+
+	// FailedChecks lists the names of failed checks, for compliance events
+	FailedChecks []string
+
+	// Vars are notifier-specific values, e.g. a PagerDuty integration key
+	Vars map[string]string
+}
+
+// templateFuncs are the functions available to notifier payload templates.
+var templateFuncs = template.FuncMap{
+	"toJson": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	},
+	"join":  func(sep string, elems []string) string { return strings.Join(elems, sep) },
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"rfc3339": func(t time.Time) string {
+		return t.Format(time.RFC3339)
+	},
+}
+
+// NewTemplateContext builds the template data for an alert.
+func NewTemplateContext(alert Alert, vars map[string]string) TemplateContext {
+	ctx := TemplateContext{
+		Alert:        alert,
+		Cluster:      alert.Labels["cluster"],
+		Spec:         alert.Labels["spec"],
+		Severity:     string(alert.Level),
+		Counts:       make(map[string]int64),
+		FailedChecks: []string{},
+		Vars:         vars,
+	}
+
+	if ctx.Cluster == "" {
+		if cluster, ok := alert.Metadata["cluster"].(string); ok {
+			ctx.Cluster = cluster
+		}
+	}
+
+	for key, value := range alert.Metadata {
+		switch v := value.(type) {
+		case int:
+			ctx.Counts[key] = int64(v)
+		case int32:
+			ctx.Counts[key] = int64(v)
+		case int64:
+			ctx.Counts[key] = v
+		}
+	}
+
+	if failed, ok := alert.Metadata["failed_checks"].([]string); ok {
+		ctx.FailedChecks = append(ctx.FailedChecks, failed...)
+		sort.Strings(ctx.FailedChecks)
+	}
+
+	return ctx
+}
+
+// ParseTemplate parses a notifier payload template with the template functions.
+func ParseTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// ValidateTemplate parses a template and executes it against a sample alert, so
+// references to unknown fields are reported at configuration time rather than
+// when the first alert is sent.
+func ValidateTemplate(text string) error {
+	tmpl, err := ParseTemplate("validate", text)
+	if err != nil {
+		return err
+	}
+
+	sample := Alert{
+		Level:       AlertLevelWarning,
+		Title:       "Compliance score below threshold",
+		Description: "Cluster sample compliance score is 50% (threshold: 80%)",
+		Source:      "ClusterSpec/sample",
+		Timestamp:   time.Now(),
+		EventType:   "ComplianceFailure",
+		Labels:      map[string]string{"cluster": "sample", "spec": "sample"},
+		Metadata: map[string]interface{}{
+			"failed":        1,
+			"failed_checks": []string{"sample.check"},
+		},
+	}
+
+	if err := tmpl.Execute(io.Discard, NewTemplateContext(sample, nil)); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return nil
+}
+
+// RenderTemplate renders a notifier payload template for an alert.
+func RenderTemplate(text string, alert Alert, vars map[string]string) ([]byte, error) {
+	tmpl, err := ParseTemplate("payload", text)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, NewTemplateContext(alert, vars)); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,160 @@
+package alerts
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func templateTestAlert() Alert {
+	return Alert{
+		Level:       AlertLevelWarning,
+		Title:       "Compliance score below threshold",
+		Description: "Cluster prod compliance score is 60% (threshold: 80%)",
+		Source:      "ClusterSpec/prod-spec",
+		Timestamp:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EventType:   "ComplianceFailure",
+		Labels: map[string]string{
+			"cluster": "prod",
+			"spec":    "prod-spec",
+		},
+		Metadata: map[string]interface{}{
+			"score":         60,
+			"failed":        2,
+			"passed":        3,
+			"failed_checks": []string{"workload.security", "network.policies"},
+			"cluster":       "prod",
+		},
+	}
+}
+
+func TestRenderTemplate_EventContext(t *testing.T) {
+	// A Jira issue payload
+	tmpl := `{
+  "fields": {
+    "project": {"key": "OPS"},
+    "summary": {{ printf "[%s] %s: %s" (upper .Severity) .Cluster .Title | toJson }},
+    "description": {{ printf "Spec %s failed %d checks: %s" .Spec .Counts.failed (join ", " .FailedChecks) | toJson }},
+    "labels": [{{ .EventType | toJson }}],
+    "created": {{ rfc3339 .Timestamp | toJson }}
+  }
+}`
+
+	payload, err := RenderTemplate(tmpl, templateTestAlert(), nil)
+	if err != nil {
+		t.Fatalf("RenderTemplate() failed: %v", err)
+	}
+
+	var result struct {
+		Fields struct {
+			Summary     string   `json:"summary"`
+			Description string   `json:"description"`
+			Labels      []string `json:"labels"`
+			Created     string   `json:"created"`
+		} `json:"fields"`
+	}
+	if err := json.Unmarshal(payload, &result); err != nil {
+		t.Fatalf("Rendered payload is not valid JSON: %v\n%s", err, payload)
+	}
+
+	if want := "[WARNING] prod: Compliance score below threshold"; result.Fields.Summary != want {
+		t.Errorf("Expected summary '%s', got '%s'", want, result.Fields.Summary)
+	}
+	if want := "Spec prod-spec failed 2 checks: network.policies, workload.security"; result.Fields.Description != want {
+		t.Errorf("Expected description '%s', got '%s'", want, result.Fields.Description)
+	}
+	if len(result.Fields.Labels) != 1 || result.Fields.Labels[0] != "ComplianceFailure" {
+		t.Errorf("Expected labels [ComplianceFailure], got %v", result.Fields.Labels)
+	}
+	if result.Fields.Created != "2024-01-01T00:00:00Z" {
+		t.Errorf("Expected created '2024-01-01T00:00:00Z', got '%s'", result.Fields.Created)
+	}
+}
+
+func TestRenderTemplate_ClusterFromMetadata(t *testing.T) {
+	alert := Alert{
+		Level:    AlertLevelCritical,
+		Metadata: map[string]interface{}{"cluster": "edge-1", "event_count": int64(4)},
+	}
+
+	payload, err := RenderTemplate(`{{ .Cluster }}/{{ .Counts.event_count }}/{{ len .FailedChecks }}`, alert, nil)
+	if err != nil {
+		t.Fatalf("RenderTemplate() failed: %v", err)
+	}
+	if string(payload) != "edge-1/4/0" {
+		t.Errorf("Expected 'edge-1/4/0', got '%s'", payload)
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tmpl    string
+		wantErr string
+	}{
+		{name: "empty", tmpl: ""},
+		{name: "valid", tmpl: `{"text": {{ .Title | toJson }}, "failed": {{ .Counts.failed }}}`},
+		{name: "syntax error", tmpl: `{{ .Title `, wantErr: "failed to parse template"},
+		{name: "unknown function", tmpl: `{{ .Title | shout }}`, wantErr: "failed to parse template"},
+		{name: "unknown field", tmpl: `{{ .IntegrationKey }}`, wantErr: "failed to execute template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTemplate(tt.tmpl)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPagerDutyNotifier_RenderPayload(t *testing.T) {
+	notifier := NewPagerDutyNotifier("key-123", "")
+
+	payload, err := json.Marshal(notifier.buildPayload(Alert{
+		Level:     AlertLevelCritical,
+		Title:     `Drift in "prod"`,
+		Source:    "ClusterSpec/prod",
+		Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Metadata:  map[string]interface{}{"event_count": 2},
+	}))
+	if err != nil {
+		t.Fatalf("buildPayload() failed: %v", err)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(payload, &result); err != nil {
+		t.Fatalf("Rendered payload is not valid JSON: %v\n%s", err, payload)
+	}
+	if result["routing_key"] != "key-123" {
+		t.Errorf("Expected routing_key 'key-123', got '%v'", result["routing_key"])
+	}
+	if result["event_action"] != "trigger" {
+		t.Errorf("Expected event_action 'trigger', got '%v'", result["event_action"])
+	}
+	details := result["payload"].(map[string]interface{})
+	if details["summary"] != `Drift in "prod"` {
+		t.Errorf("Expected escaped summary, got '%v'", details["summary"])
+	}
+}
+
+func TestSlackNotifier_Template(t *testing.T) {
+	notifier := NewSlackNotifier("https://hooks.slack.com/test", "#alerts", "", "")
+	notifier.Template = `{"text": {{ printf "%s on %s" .Title .Cluster | toJson }}}`
+
+	payload, err := notifier.renderPayload(templateTestAlert())
+	if err != nil {
+		t.Fatalf("renderPayload() failed: %v", err)
+	}
+	if string(payload) != `{"text": "Compliance score below threshold on prod"}` {
+		t.Errorf("Unexpected payload: %s", payload)
+	}
+}
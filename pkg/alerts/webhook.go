@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"text/template"
 	"time"
 )
 
@@ -17,7 +16,8 @@ type WebhookNotifier struct {
 	Method        string
 	Headers       map[string]string
 	Template      string
-	EventFilter   []string // List of event types to send (empty = all)
+	TemplateVars  map[string]string // Exposed to the template as .Vars
+	EventFilter   []string          // List of event types to send (empty = all)
 	Enabled_      bool
 	RetryAttempts int
 	Timeout       time.Duration
@@ -52,6 +52,11 @@ func (w *WebhookNotifier) Send(ctx context.Context, alert Alert) error {
 		return fmt.Errorf("failed to render payload: %w", err)
 	}
 
+	return w.deliver(ctx, payload)
+}
+
+// deliver sends a rendered payload, retrying with exponential backoff
+func (w *WebhookNotifier) deliver(ctx context.Context, payload []byte) error {
 	var lastErr error
 	for attempt := 0; attempt <= w.RetryAttempts; attempt++ {
 		if attempt > 0 {
@@ -145,19 +150,9 @@ func (w *WebhookNotifier) renderPayload(alert Alert) ([]byte, error) {
 	return w.defaultPayload(alert)
 }
 
-// renderTemplate renders the payload using a Go template
+// renderTemplate renders the payload using a Go template with the full event context
 func (w *WebhookNotifier) renderTemplate(alert Alert) ([]byte, error) {
-	tmpl, err := template.New("webhook").Parse(w.Template)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse template: %w", err)
-	}
-
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, alert); err != nil {
-		return nil, fmt.Errorf("failed to execute template: %w", err)
-	}
-
-	return buf.Bytes(), nil
+	return RenderTemplate(w.Template, alert, w.TemplateVars)
 }
 
 // defaultPayload creates the default JSON payload
@@ -186,40 +181,3 @@ func (w *WebhookNotifier) defaultPayload(alert Alert) ([]byte, error) {
 
 	return data, nil
 }
-
-// PagerDutyNotifier is a specialized webhook notifier for PagerDuty
-type PagerDutyNotifier struct {
-	*WebhookNotifier
-	IntegrationKey string
-}
-
-// NewPagerDutyNotifier creates a PagerDuty notifier
-func NewPagerDutyNotifier(integrationKey string) *PagerDutyNotifier {
-	// PagerDuty Events API v2 endpoint
-	template := `{
-  "routing_key": "{{ .IntegrationKey }}",
-  "event_action": "{{ if eq .Level "critical" }}trigger{{ else }}acknowledge{{ end }}",
-  "payload": {
-    "summary": "{{ .Title }}",
-    "severity": "{{ if eq .Level "critical" }}critical{{ else if eq .Level "warning" }}warning{{ else }}info{{ end }}",
-    "source": "{{ .Source }}",
-    "timestamp": "{{ .Timestamp.Format "2006-01-02T15:04:05Z07:00" }}",
-    "custom_details": {{ .Metadata | toJson }}
-  }
-}`
-
-	webhook := NewWebhookNotifier(
-		"pagerduty",
-		"https://events.pagerduty.com/v2/enqueue",
-		"POST",
-		map[string]string{
-			"Content-Type": "application/json",
-		},
-		template,
-	)
-
-	return &PagerDutyNotifier{
-		WebhookNotifier: webhook,
-		IntegrationKey:  integrationKey,
-	}
-}
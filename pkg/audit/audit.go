@@ -52,6 +52,9 @@ const (
 
 	// EventTypeHealthCheck represents health check event
 	EventTypeHealthCheck EventType = "health_check"
+
+	// EventTypeReconcileRequest represents an on-demand reconciliation request
+	EventTypeReconcileRequest EventType = "reconcile_request"
 )
 
 // Severity represents the severity of an audit event
@@ -92,6 +95,7 @@ type ResourceInfo struct {
 // Logger provides structured audit logging
 type Logger struct {
 	logger logr.Logger
+	sink   *Sink
 }
 
 // NewLogger creates a new audit logger
@@ -101,6 +105,13 @@ func NewLogger(ctx context.Context) *Logger {
 	}
 }
 
+// WithSink also writes every event to sink, regardless of the log level.
+// A nil sink leaves the logger unchanged.
+func (l *Logger) WithSink(sink *Sink) *Logger {
+	l.sink = sink
+	return l
+}
+
 // LogEvent logs an audit event
 func (l *Logger) LogEvent(event AuditEvent) {
 	// Set timestamp if not already set
@@ -108,6 +119,13 @@ func (l *Logger) LogEvent(event AuditEvent) {
 		event.Timestamp = time.Now()
 	}
 
+	// The audit trail records every event, independent of the log level
+	if l.sink != nil {
+		if err := l.sink.Write(event); err != nil {
+			l.logger.Error(err, "Failed to write audit event to sink")
+		}
+	}
+
 	// Convert event to JSON for structured logging
 	eventJSON, err := json.Marshal(event)
 	if err != nil {
@@ -429,3 +447,26 @@ func (l *Logger) LogHealthCheck(clusterName, namespace string, healthy bool, err
 
 	l.LogEvent(event)
 }
+
+// LogReconcileRequest logs an on-demand reconciliation request, recording where it came from
+func (l *Logger) LogReconcileRequest(clusterSpec, requestedAt, source, requester string) {
+	event := AuditEvent{
+		EventType: EventTypeReconcileRequest,
+		Severity:  SeverityInfo,
+		Actor:     requester,
+		Action:    "reconcile_now",
+		Resource: ResourceInfo{
+			Kind:        "ClusterSpecification",
+			Name:        clusterSpec,
+			ClusterSpec: clusterSpec,
+		},
+		Result:  "accepted",
+		Message: "On-demand reconciliation requested",
+		Metadata: map[string]interface{}{
+			"source":       source,
+			"requested_at": requestedAt,
+		},
+	}
+
+	l.LogEvent(event)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Sink writes audit events as JSON lines, one object per event, keeping an
+// audit trail separate from operational logging. A Sink is safe for concurrent
+// use by many loggers.
+type Sink struct {
+	mu   sync.Mutex
+	w    io.Writer
+	file *os.File
+}
+
+// NewSink creates a sink that writes events to w.
+func NewSink(w io.Writer) *Sink {
+	return &Sink{w: w}
+}
+
+// OpenFileSink opens path for appending, creating it if needed, and returns a
+// sink that syncs the file after every event.
+func OpenFileSink(path string) (*Sink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	return &Sink{w: file, file: file}, nil
+}
+
+// Write appends an event to the sink as a single JSON line.
+func (s *Sink) Write(event AuditEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit event: %w", err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.w.Write(line); err != nil {
+		return fmt.Errorf("failed to write audit event: %w", err)
+	}
+	if s.file != nil {
+		if err := s.file.Sync(); err != nil {
+			return fmt.Errorf("failed to sync audit log file: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close closes the file of a sink opened with OpenFileSink.
+func (s *Sink) Close() error {
+	if s.file == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
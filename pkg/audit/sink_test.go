@@ -0,0 +1,105 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// readEvents parses a JSON-lines audit file.
+func readEvents(t *testing.T, path string) []AuditEvent {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open audit log: %v", err)
+	}
+	defer file.Close()
+
+	var events []AuditEvent
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event AuditEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("Audit log line is not a JSON event: %q: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestFileSink_ConcurrentLoggers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := OpenFileSink(path)
+	if err != nil {
+		t.Fatalf("OpenFileSink failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			logger := NewLogger(context.Background()).WithSink(sink)
+			for j := 0; j < 10; j++ {
+				logger.LogComplianceScan(fmt.Sprintf("cluster-%d", i), "uid", "spec", 10, 10-j, j, nil)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	events := readEvents(t, path)
+	if len(events) != 200 {
+		t.Fatalf("Expected 200 audit events, got %d", len(events))
+	}
+	for _, event := range events {
+		if event.EventType != EventTypeComplianceScan || event.Timestamp.IsZero() {
+			t.Errorf("Unexpected audit event: %+v", event)
+		}
+	}
+}
+
+func TestFileSink_Appends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	for _, clusterSpec := range []string{"first", "second"} {
+		sink, err := OpenFileSink(path)
+		if err != nil {
+			t.Fatalf("OpenFileSink failed: %v", err)
+		}
+		NewLogger(context.Background()).WithSink(sink).LogReconcileRequest(clusterSpec, "now", "annotation", "admin")
+		sink.Close()
+	}
+
+	events := readEvents(t, path)
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 audit events, got %d", len(events))
+	}
+	if events[0].Resource.Name != "first" || events[1].Resource.Name != "second" {
+		t.Errorf("Expected events in write order, got %s, %s", events[0].Resource.Name, events[1].Resource.Name)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+	"github.com/cloudcwfranck/kspec/pkg/metrics"
+)
+
+// cachedClients holds the clients built for a ClusterTarget, along with the
+// object versions they were built from
+type cachedClients struct {
+	target          types.NamespacedName
+	resourceVersion string
+	secretVersion   string
+
+	// httpClient is shared by the kube and dynamic clients, so they reuse
+	// one connection pool per cluster
+	httpClient    *http.Client
+	kubeClient    kubernetes.Interface
+	dynamicClient dynamic.Interface
+	info          ClusterInfo
+}
+
+// close releases the idle connections held by the cached clients
+func (c *cachedClients) close() {
+	if c.httpClient != nil {
+		c.httpClient.CloseIdleConnections()
+	}
+}
+
+// cachedClientsFor returns the cached clients of a ClusterTarget, or nil when
+// none are cached or the target or its credentials Secret changed since they
+// were built
+func (f *ClusterClientFactory) cachedClientsFor(target *kspecv1alpha1.ClusterTarget, secretVersion string) *cachedClients {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entry, ok := f.clients[target.UID]
+	if !ok {
+		return nil
+	}
+	if entry.resourceVersion != target.ResourceVersion || entry.secretVersion != secretVersion {
+		return nil
+	}
+	return entry
+}
+
+// storeClients caches the clients of a ClusterTarget, replacing any stale entry
+func (f *ClusterClientFactory) storeClients(uid types.UID, entry *cachedClients) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if previous, ok := f.clients[uid]; ok {
+		previous.close()
+	}
+	f.clients[uid] = entry
+	metrics.RecordClusterClientsCached(len(f.clients))
+}
+
+// Evict releases the cached clients of a ClusterTarget. Call it when the
+// target is deleted.
+func (f *ClusterClientFactory) Evict(target types.NamespacedName) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for uid, entry := range f.clients {
+		if entry.target == target {
+			entry.close()
+			delete(f.clients, uid)
+		}
+	}
+	metrics.RecordClusterClientsCached(len(f.clients))
+}
+
+// Close releases all cached clients
+func (f *ClusterClientFactory) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for uid, entry := range f.clients {
+		entry.close()
+		delete(f.clients, uid)
+	}
+	metrics.RecordClusterClientsCached(0)
+}
+
+// CachedClients returns the number of ClusterTargets with cached clients
+func (f *ClusterClientFactory) CachedClients() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.clients)
+}
+
+// credentialsSecretVersion returns the resourceVersion of the Secret holding a
+// ClusterTarget's credentials, so cached clients are rebuilt when it rotates.
+// An empty version is returned when the Secret can't be read; building the
+// clients then reports the error.
+func (f *ClusterClientFactory) credentialsSecretVersion(ctx context.Context, target *kspecv1alpha1.ClusterTarget) string {
+	var secretRef *kspecv1alpha1.SecretReference
+	switch target.Spec.AuthMode {
+	case "kubeconfig":
+		secretRef = target.Spec.KubeconfigSecretRef
+	case "serviceAccount":
+		secretRef = target.Spec.ServiceAccountSecretRef
+	case "token":
+		secretRef = target.Spec.TokenSecretRef
+	}
+	if secretRef == nil {
+		return ""
+	}
+
+	namespace := secretRef.Namespace
+	if namespace == "" {
+		namespace = target.Namespace
+	}
+
+	secret := &corev1.Secret{}
+	if err := f.k8sClient.Get(ctx, types.NamespacedName{Name: secretRef.Name, Namespace: namespace}, secret); err != nil {
+		return ""
+	}
+	return secret.ResourceVersion
+}
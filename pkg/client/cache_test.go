@@ -0,0 +1,137 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+)
+
+func newTestTarget() (*kspecv1alpha1.ClusterTarget, *corev1.Secret) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-token", Namespace: "kspec-system"},
+		Data:       map[string][]byte{"token": []byte("secret-token")},
+	}
+	target := &kspecv1alpha1.ClusterTarget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "prod",
+			Namespace:       "kspec-system",
+			UID:             types.UID("target-uid"),
+			ResourceVersion: "1",
+		},
+		Spec: kspecv1alpha1.ClusterTargetSpec{
+			// Nothing listens here, so cluster lookups fail fast
+			APIServerURL:   "https://127.0.0.1:1",
+			AuthMode:       "token",
+			TokenSecretRef: &kspecv1alpha1.SecretReference{Name: "prod-token"},
+		},
+	}
+	return target, secret
+}
+
+func TestCreateClientsForClusterTarget_Cache(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to build scheme: %v", err)
+	}
+
+	target, secret := newTestTarget()
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	factory := NewClusterClientFactory(&rest.Config{}, k8sClient)
+
+	first, _, _, err := factory.CreateClientsForClusterTarget(ctx, target)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	second, _, _, err := factory.CreateClientsForClusterTarget(ctx, target)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if first != second {
+		t.Error("Expected cached clients to be reused for an unchanged target")
+	}
+	if factory.CachedClients() != 1 {
+		t.Errorf("Expected 1 cached client, got %d", factory.CachedClients())
+	}
+
+	// A new target resourceVersion rebuilds the clients
+	target.ResourceVersion = "2"
+	third, _, _, err := factory.CreateClientsForClusterTarget(ctx, target)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if third == second {
+		t.Error("Expected clients to be rebuilt after the target changed")
+	}
+
+	// Rotating the credentials Secret rebuilds the clients
+	secret.Data["token"] = []byte("rotated-token")
+	if err := k8sClient.Update(ctx, secret); err != nil {
+		t.Fatalf("Failed to update secret: %v", err)
+	}
+	fourth, _, _, err := factory.CreateClientsForClusterTarget(ctx, target)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fourth == third {
+		t.Error("Expected clients to be rebuilt after the secret changed")
+	}
+	if factory.CachedClients() != 1 {
+		t.Errorf("Expected rebuilt clients to replace the stale entry, got %d cached", factory.CachedClients())
+	}
+
+	factory.Evict(types.NamespacedName{Name: "other", Namespace: "kspec-system"})
+	if factory.CachedClients() != 1 {
+		t.Errorf("Expected evicting another target to keep the entry, got %d cached", factory.CachedClients())
+	}
+
+	factory.Evict(types.NamespacedName{Name: "prod", Namespace: "kspec-system"})
+	if factory.CachedClients() != 0 {
+		t.Errorf("Expected no cached clients after eviction, got %d", factory.CachedClients())
+	}
+}
+
+func TestClose(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to build scheme: %v", err)
+	}
+
+	target, secret := newTestTarget()
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	factory := NewClusterClientFactory(&rest.Config{}, k8sClient)
+
+	if _, _, _, err := factory.CreateClientsForClusterTarget(ctx, target); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	factory.Close()
+	if factory.CachedClients() != 0 {
+		t.Errorf("Expected no cached clients after Close, got %d", factory.CachedClients())
+	}
+}
@@ -19,6 +19,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -31,10 +32,15 @@ import (
 	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
 )
 
-// ClusterClientFactory creates Kubernetes clients for local and remote clusters
+// ClusterClientFactory creates Kubernetes clients for local and remote clusters.
+// Remote clients are cached by ClusterTarget UID and rebuilt when the target or
+// its credentials Secret changes.
 type ClusterClientFactory struct {
 	localConfig *rest.Config
 	k8sClient   client.Client
+
+	mu      sync.Mutex
+	clients map[types.UID]*cachedClients
 }
 
 // NewClusterClientFactory creates a new ClusterClientFactory
@@ -42,6 +48,7 @@ func NewClusterClientFactory(localConfig *rest.Config, k8sClient client.Client)
 	return &ClusterClientFactory{
 		localConfig: localConfig,
 		k8sClient:   k8sClient,
+		clients:     make(map[types.UID]*cachedClients),
 	}
 }
 
@@ -57,8 +64,18 @@ func (f *ClusterClientFactory) CreateClientsForClusterSpec(
 		return f.createLocalClients(ctx)
 	}
 
+	return f.CreateClientsForClusterRef(ctx, clusterSpec.Spec.ClusterRef, clusterSpec.Namespace)
+}
+
+// CreateClientsForClusterRef creates Kubernetes clients for the ClusterTarget a
+// reference points to. A reference without a namespace resolves to defaultNamespace.
+func (f *ClusterClientFactory) CreateClientsForClusterRef(
+	ctx context.Context,
+	ref *kspecv1alpha1.ClusterReference,
+	defaultNamespace string,
+) (kubernetes.Interface, dynamic.Interface, *ClusterInfo, error) {
 	// Fetch ClusterTarget
-	clusterTarget, err := f.getClusterTarget(ctx, clusterSpec.Spec.ClusterRef, clusterSpec.Namespace)
+	clusterTarget, err := f.getClusterTarget(ctx, ref, defaultNamespace)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to get ClusterTarget: %w", err)
 	}
@@ -113,24 +130,39 @@ func (f *ClusterClientFactory) createLocalClients(ctx context.Context) (kubernet
 	return kubeClient, dynamicClient, info, nil
 }
 
-// createRemoteClients creates clients for a remote cluster defined by ClusterTarget
+// createRemoteClients returns clients for a remote cluster defined by ClusterTarget,
+// reusing the cached clients while the target and its credentials are unchanged
 func (f *ClusterClientFactory) createRemoteClients(
 	ctx context.Context,
 	target *kspecv1alpha1.ClusterTarget,
 ) (kubernetes.Interface, dynamic.Interface, *ClusterInfo, error) {
+	secretVersion := f.credentialsSecretVersion(ctx, target)
+	if target.UID != "" {
+		if entry := f.cachedClientsFor(target, secretVersion); entry != nil {
+			info := entry.info
+			return entry.kubeClient, entry.dynamicClient, &info, nil
+		}
+	}
+
 	// Build REST config from ClusterTarget
 	config, err := f.buildRestConfigFromTarget(ctx, target)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to build REST config: %w", err)
 	}
 
+	// Share one connection pool between the kube and dynamic clients
+	httpClient, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
 	// Create clients
-	kubeClient, err := kubernetes.NewForConfig(config)
+	kubeClient, err := kubernetes.NewForConfigAndClient(config, httpClient)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to create kube client: %w", err)
 	}
 
-	dynamicClient, err := dynamic.NewForConfig(config)
+	dynamicClient, err := dynamic.NewForConfigAndClient(config, httpClient)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to create dynamic client: %w", err)
 	}
@@ -159,6 +191,18 @@ func (f *ClusterClientFactory) createRemoteClients(
 		AllowEnforcement: target.Spec.AllowEnforcement,
 	}
 
+	if target.UID != "" {
+		f.storeClients(target.UID, &cachedClients{
+			target:          types.NamespacedName{Name: target.Name, Namespace: target.Namespace},
+			resourceVersion: target.ResourceVersion,
+			secretVersion:   secretVersion,
+			httpClient:      httpClient,
+			kubeClient:      kubeClient,
+			dynamicClient:   dynamicClient,
+			info:            *info,
+		})
+	}
+
 	return kubeClient, dynamicClient, info, nil
 }
 
@@ -167,16 +211,36 @@ func (f *ClusterClientFactory) buildRestConfigFromTarget(
 	ctx context.Context,
 	target *kspecv1alpha1.ClusterTarget,
 ) (*rest.Config, error) {
+	var config *rest.Config
+	var err error
+
 	switch target.Spec.AuthMode {
 	case "kubeconfig":
-		return f.buildConfigFromKubeconfig(ctx, target)
+		config, err = f.buildConfigFromKubeconfig(ctx, target)
 	case "serviceAccount":
-		return f.buildConfigFromServiceAccount(ctx, target)
+		config, err = f.buildConfigFromServiceAccount(ctx, target)
 	case "token":
-		return f.buildConfigFromToken(ctx, target)
+		config, err = f.buildConfigFromToken(ctx, target)
 	default:
 		return nil, fmt.Errorf("unsupported auth mode: %s", target.Spec.AuthMode)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Remote clusters share the local client rate limits
+	f.applyRateLimits(config)
+
+	return config, nil
+}
+
+// applyRateLimits copies the local config's QPS and burst to a remote REST config
+func (f *ClusterClientFactory) applyRateLimits(config *rest.Config) {
+	if f.localConfig == nil {
+		return
+	}
+	config.QPS = f.localConfig.QPS
+	config.Burst = f.localConfig.Burst
 }
 
 // buildConfigFromKubeconfig builds REST config from kubeconfig in Secret
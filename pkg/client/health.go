@@ -0,0 +1,126 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+)
+
+// ClusterHealth contains the result of a ClusterTarget health check
+type ClusterHealth struct {
+	// UID is the kube-system namespace UID
+	UID string
+
+	// Version is the Kubernetes version
+	Version string
+
+	// Platform describes the cluster platform (e.g., "eks", "gke", "aks", "vanilla")
+	Platform string
+
+	// NodeCount is the number of nodes in the cluster
+	NodeCount int32
+}
+
+// CheckClusterTarget connects to the cluster defined by a ClusterTarget and gathers
+// the information reported in its status. An error means the cluster is unreachable
+// or the credentials are invalid; use IsCredentialError to tell them apart.
+func (f *ClusterClientFactory) CheckClusterTarget(
+	ctx context.Context,
+	target *kspecv1alpha1.ClusterTarget,
+) (*ClusterHealth, error) {
+	kubeClient, _, clusterInfo, err := f.CreateClientsForClusterTarget(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	// Client creation doesn't contact the API server, so probe it explicitly
+	versionInfo, err := kubeClient.Discovery().ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach API server: %w", err)
+	}
+
+	health := &ClusterHealth{
+		UID:      clusterInfo.UID,
+		Version:  versionInfo.GitVersion,
+		Platform: DetectPlatform(ctx, kubeClient),
+	}
+
+	// Count nodes
+	nodes, err := kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err == nil {
+		health.NodeCount = int32(len(nodes.Items))
+	}
+
+	return health, nil
+}
+
+// IsCredentialError checks if an error is related to invalid credentials: the
+// credentials could not be loaded from their Secret, or the API server answered
+// and rejected them. Errors where the API server never answered (DNS, TLS,
+// timeouts) are not credential errors.
+func IsCredentialError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// The API server answered and rejected the request
+	if apierrors.IsUnauthorized(err) || apierrors.IsForbidden(err) {
+		return true
+	}
+
+	errMsg := strings.ToLower(err.Error())
+	// Check for the errors of loading credentials from their Secret
+	credErrorPatterns := []string{
+		"secret reference is nil",
+		"failed to get secret",
+		"does not contain key",
+		"is empty",
+		"failed to get kubeconfig",
+		"failed to build config from kubeconfig",
+		"failed to get token",
+	}
+
+	for _, pattern := range credErrorPatterns {
+		if strings.Contains(errMsg, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CredentialStatus reports what the result of CheckClusterTarget says about the
+// ClusterTarget credentials. They are only known to be valid when the API server
+// answered, and only known to be invalid for a credential error; any other failure
+// leaves them unknown.
+func CredentialStatus(err error) metav1.ConditionStatus {
+	switch {
+	case err == nil:
+		return metav1.ConditionTrue
+	case IsCredentialError(err):
+		return metav1.ConditionFalse
+	default:
+		return metav1.ConditionUnknown
+	}
+}
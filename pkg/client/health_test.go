@@ -0,0 +1,176 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newTestAPIServer serves the endpoints CheckClusterTarget probes, accepting only
+// the given bearer token
+func newTestAPIServer(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Unauthorized","code":401}`)
+			return
+		}
+		switch r.URL.Path {
+		case "/version":
+			fmt.Fprint(w, `{"major":"1","minor":"29","gitVersion":"v1.29.0"}`)
+		case "/api/v1/namespaces/kube-system":
+			fmt.Fprint(w, `{"kind":"Namespace","apiVersion":"v1","metadata":{"name":"kube-system","uid":"cluster-uid"}}`)
+		case "/api/v1/nodes":
+			fmt.Fprint(w, `{"kind":"NodeList","apiVersion":"v1","items":[{"metadata":{"name":"node-1"}},{"metadata":{"name":"node-2"}}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheckClusterTarget(t *testing.T) {
+	server := newTestAPIServer(t, "secret-token")
+
+	tests := []struct {
+		name           string
+		apiServerURL   string
+		secretToken    string
+		withoutSecret  bool
+		wantErr        bool
+		wantCredential metav1.ConditionStatus
+	}{
+		{
+			name:           "API server answers",
+			apiServerURL:   server.URL,
+			secretToken:    "secret-token",
+			wantCredential: metav1.ConditionTrue,
+		},
+		{
+			name:           "API server rejects the token",
+			apiServerURL:   server.URL,
+			secretToken:    "stale-token",
+			wantErr:        true,
+			wantCredential: metav1.ConditionFalse,
+		},
+		{
+			name:           "credentials secret missing",
+			apiServerURL:   server.URL,
+			withoutSecret:  true,
+			wantErr:        true,
+			wantCredential: metav1.ConditionFalse,
+		},
+		{
+			name:           "API server unreachable",
+			apiServerURL:   "https://127.0.0.1:1",
+			secretToken:    "secret-token",
+			wantErr:        true,
+			wantCredential: metav1.ConditionUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := corev1.AddToScheme(scheme); err != nil {
+				t.Fatalf("Failed to build scheme: %v", err)
+			}
+
+			target, secret := newTestTarget()
+			target.UID = ""
+			target.Spec.APIServerURL = tt.apiServerURL
+			secret.Data["token"] = []byte(tt.secretToken)
+
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if !tt.withoutSecret {
+				builder = builder.WithObjects(secret)
+			}
+			factory := NewClusterClientFactory(&rest.Config{}, builder.Build())
+
+			health, err := factory.CheckClusterTarget(context.Background(), target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckClusterTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := CredentialStatus(err); got != tt.wantCredential {
+				t.Errorf("CredentialStatus(%v) = %s, expected %s", err, got, tt.wantCredential)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if health.UID != "cluster-uid" {
+				t.Errorf("Expected UID cluster-uid, got %q", health.UID)
+			}
+			if health.Version != "v1.29.0" {
+				t.Errorf("Expected version v1.29.0, got %q", health.Version)
+			}
+			if health.NodeCount != 2 {
+				t.Errorf("Expected 2 nodes, got %d", health.NodeCount)
+			}
+			if health.Platform != "vanilla" {
+				t.Errorf("Expected platform vanilla, got %q", health.Platform)
+			}
+		})
+	}
+}
+
+func TestIsCredentialError(t *testing.T) {
+	resource := schema.GroupResource{Resource: "namespaces"}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil", err: nil, expected: false},
+		{name: "unauthorized", err: fmt.Errorf("failed to reach API server: %w", apierrors.NewUnauthorized("invalid token")), expected: true},
+		{name: "forbidden", err: fmt.Errorf("failed to reach API server: %w", apierrors.NewForbidden(resource, "kube-system", errors.New("denied"))), expected: true},
+		{name: "secret not found", err: errors.New("failed to build REST config: failed to get token: failed to get secret kspec-system/prod-token: not found"), expected: true},
+		{name: "secret missing key", err: errors.New("failed to get token: secret kspec-system/prod-token does not contain key token"), expected: true},
+		{name: "secret key empty", err: errors.New("failed to get token: secret kspec-system/prod-token key token is empty"), expected: true},
+		{name: "invalid kubeconfig", err: errors.New("failed to build config from kubeconfig: yaml: line 1: did not find expected key"), expected: true},
+		{name: "DNS failure", err: errors.New("failed to reach API server: Get \"https://prod.example.com/version\": dial tcp: lookup prod.example.com: no such host"), expected: false},
+		{name: "TLS failure", err: errors.New("failed to reach API server: Get \"https://prod.example.com/version\": tls: failed to verify certificate: x509: certificate signed by unknown authority"), expected: false},
+		{name: "timeout", err: errors.New("failed to reach API server: Get \"https://prod.example.com/version\": context deadline exceeded"), expected: false},
+		{name: "server error", err: fmt.Errorf("failed to reach API server: %w", apierrors.NewInternalError(errors.New("etcd unavailable"))), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsCredentialError(tt.err); got != tt.expected {
+				t.Errorf("IsCredentialError(%v) = %v, expected %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dashboard provides the HTTP authentication of the web dashboard.
+package dashboard
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Environment variables configuring dashboard authentication
+const (
+	// TokenEnv holds the static bearer token clients must present
+	TokenEnv = "DASHBOARD_TOKEN"
+
+	// OIDCIssuerEnv holds the issuer URL OIDC ID tokens must be issued by
+	OIDCIssuerEnv = "DASHBOARD_OIDC_ISSUER"
+
+	// OIDCAudienceEnv holds the client ID OIDC ID tokens must be issued for
+	OIDCAudienceEnv = "DASHBOARD_OIDC_AUDIENCE"
+)
+
+// ErrUnauthenticated is returned when a request carries no bearer token.
+var ErrUnauthenticated = errors.New("missing bearer token")
+
+// AuthConfig configures dashboard authentication. At most one of Token and
+// OIDCIssuer may be set; with neither, authentication is disabled.
+type AuthConfig struct {
+	// Token enables bearer-token mode: requests must present this token
+	Token string
+
+	// OIDCIssuer enables OIDC mode: requests must present a JWT signed by
+	// this issuer
+	OIDCIssuer string
+
+	// OIDCAudience is the audience (client ID) OIDC tokens must be issued for
+	OIDCAudience string
+}
+
+// AuthConfigFromEnv reads the authentication configuration from the environment.
+func AuthConfigFromEnv(getenv func(string) string) AuthConfig {
+	return AuthConfig{
+		Token:        getenv(TokenEnv),
+		OIDCIssuer:   getenv(OIDCIssuerEnv),
+		OIDCAudience: getenv(OIDCAudienceEnv),
+	}
+}
+
+// Authenticator verifies the credentials of a request.
+type Authenticator interface {
+	// Authenticate returns an error if the request is not authenticated
+	Authenticate(r *http.Request) error
+}
+
+// NewAuthenticator returns the authenticator for config, or nil when
+// authentication is disabled.
+func NewAuthenticator(config AuthConfig) (Authenticator, error) {
+	switch {
+	case config.Token != "" && config.OIDCIssuer != "":
+		return nil, fmt.Errorf("%s and %s are mutually exclusive", TokenEnv, OIDCIssuerEnv)
+	case config.Token != "":
+		return &TokenAuthenticator{token: config.Token}, nil
+	case config.OIDCIssuer != "":
+		if config.OIDCAudience == "" {
+			return nil, fmt.Errorf("%s is required with %s", OIDCAudienceEnv, OIDCIssuerEnv)
+		}
+		return NewOIDCAuthenticator(config.OIDCIssuer, config.OIDCAudience), nil
+	case config.OIDCAudience != "":
+		return nil, fmt.Errorf("%s requires %s", OIDCAudienceEnv, OIDCIssuerEnv)
+	default:
+		return nil, nil
+	}
+}
+
+// RequireAuth wraps next so that unauthenticated requests get 401
+// Unauthorized. A nil authenticator lets every request through.
+func RequireAuth(auth Authenticator, next http.Handler) http.Handler {
+	if auth == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := auth.Authenticate(r); err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="kspec-dashboard"`)
+			http.Error(w, "Unauthorized: "+err.Error(), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// TokenAuthenticator accepts requests presenting a static bearer token.
+type TokenAuthenticator struct {
+	token string
+}
+
+// Authenticate checks the request's bearer token against the configured token.
+func (a *TokenAuthenticator) Authenticate(r *http.Request) error {
+	token, err := bearerToken(r)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) != 1 {
+		return errors.New("invalid bearer token")
+	}
+	return nil
+}
+
+// bearerToken returns the bearer token of a request's Authorization header.
+func bearerToken(r *http.Request) (string, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return "", ErrUnauthenticated
+	}
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", errors.New("authorization header must be a bearer token")
+	}
+	return strings.TrimSpace(token), nil
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dashboard
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewAuthenticator(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  AuthConfig
+		wantNil bool
+		wantErr bool
+	}{
+		{name: "disabled", wantNil: true},
+		{name: "token", config: AuthConfig{Token: "secret"}},
+		{name: "oidc", config: AuthConfig{OIDCIssuer: "https://issuer.example.com", OIDCAudience: "kspec"}},
+		{name: "token and oidc", config: AuthConfig{Token: "secret", OIDCIssuer: "https://issuer.example.com", OIDCAudience: "kspec"}, wantErr: true},
+		{name: "oidc without audience", config: AuthConfig{OIDCIssuer: "https://issuer.example.com"}, wantErr: true},
+		{name: "audience without issuer", config: AuthConfig{OIDCAudience: "kspec"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth, err := NewAuthenticator(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewAuthenticator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (auth == nil) != tt.wantNil {
+				t.Errorf("NewAuthenticator() = %v, want nil %v", auth, tt.wantNil)
+			}
+		})
+	}
+}
+
+func TestAuthConfigFromEnv(t *testing.T) {
+	env := map[string]string{TokenEnv: "secret", OIDCAudienceEnv: "kspec"}
+	config := AuthConfigFromEnv(func(key string) string { return env[key] })
+	if config.Token != "secret" || config.OIDCAudience != "kspec" || config.OIDCIssuer != "" {
+		t.Errorf("AuthConfigFromEnv() = %+v", config)
+	}
+}
+
+// serveAuthenticated returns the status code of a request through RequireAuth.
+func serveAuthenticated(auth Authenticator, authorization string) int {
+	handler := RequireAuth(auth, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/api/summary", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	return recorder.Code
+}
+
+func TestRequireAuth_Token(t *testing.T) {
+	auth, err := NewAuthenticator(AuthConfig{Token: "secret"})
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error = %v", err)
+	}
+
+	tests := []struct {
+		authorization string
+		want          int
+	}{
+		{authorization: "Bearer secret", want: http.StatusOK},
+		{authorization: "bearer secret", want: http.StatusOK},
+		{authorization: "", want: http.StatusUnauthorized},
+		{authorization: "Bearer wrong", want: http.StatusUnauthorized},
+		{authorization: "Basic c2VjcmV0", want: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		if got := serveAuthenticated(auth, tt.authorization); got != tt.want {
+			t.Errorf("Authorization %q: status = %d, want %d", tt.authorization, got, tt.want)
+		}
+	}
+}
+
+func TestRequireAuth_Disabled(t *testing.T) {
+	if got := serveAuthenticated(nil, ""); got != http.StatusOK {
+		t.Errorf("status = %d, want %d", got, http.StatusOK)
+	}
+}
+
+// testIssuer is an OIDC issuer serving a discovery document and a single signing key.
+type testIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	return newGatedTestIssuer(t, nil)
+}
+
+// newGatedTestIssuer returns a test issuer whose discovery document is served
+// only once gate is closed. A nil gate serves it immediately.
+func newGatedTestIssuer(t *testing.T, gate <-chan struct{}) *testIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	issuer := &testIssuer{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		if gate != nil {
+			<-gate
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   issuer.server.URL,
+			"jwks_uri": issuer.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "key-1",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+// sign returns an RS256 JWT with the given claims.
+func (i *testIssuer) sign(t *testing.T, kid string, claims map[string]interface{}) string {
+	t.Helper()
+
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Failed to marshal: %v", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := encode(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid}) + "." + encode(claims)
+
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestRequireAuth_OIDC(t *testing.T) {
+	issuer := newTestIssuer(t)
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	auth := NewOIDCAuthenticator(issuer.server.URL, "kspec-dashboard")
+	auth.now = func() time.Time { return now }
+
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"iss": issuer.server.URL,
+			"aud": "kspec-dashboard",
+			"sub": "alice",
+			"exp": now.Add(time.Hour).Unix(),
+			"iat": now.Unix(),
+		}
+		for k, v := range overrides {
+			c[k] = v
+		}
+		return c
+	}
+
+	valid := issuer.sign(t, "key-1", claims(nil))
+	tampered := valid[:len(valid)-4] + "AAAA"
+
+	tests := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{name: "valid", token: valid, want: http.StatusOK},
+		{name: "audience list", token: issuer.sign(t, "key-1", claims(map[string]interface{}{"aud": []string{"other", "kspec-dashboard"}})), want: http.StatusOK},
+		{name: "expired", token: issuer.sign(t, "key-1", claims(map[string]interface{}{"exp": now.Add(-time.Hour).Unix()})), want: http.StatusUnauthorized},
+		{name: "not yet valid", token: issuer.sign(t, "key-1", claims(map[string]interface{}{"nbf": now.Add(time.Hour).Unix()})), want: http.StatusUnauthorized},
+		{name: "wrong audience", token: issuer.sign(t, "key-1", claims(map[string]interface{}{"aud": "other"})), want: http.StatusUnauthorized},
+		{name: "wrong issuer", token: issuer.sign(t, "key-1", claims(map[string]interface{}{"iss": "https://evil.example.com"})), want: http.StatusUnauthorized},
+		{name: "unknown key", token: issuer.sign(t, "key-2", claims(nil)), want: http.StatusUnauthorized},
+		{name: "tampered signature", token: tampered, want: http.StatusUnauthorized},
+		{name: "malformed", token: "not-a-jwt", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serveAuthenticated(auth, "Bearer "+tt.token); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOIDCAuthenticator_DiscoveryOutlivesRequest(t *testing.T) {
+	gate := make(chan struct{})
+	issuer := newGatedTestIssuer(t, gate)
+	auth := NewOIDCAuthenticator(issuer.server.URL, "kspec-dashboard")
+	token := issuer.sign(t, "key-1", map[string]interface{}{
+		"iss": issuer.server.URL,
+		"aud": "kspec-dashboard",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	// A request that gives up while the issuer is slow fails on its own context
+	// without cancelling the discovery it started
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/api/clusters", nil).WithContext(ctx)
+	req.Header.Set("Authorization", "Bearer "+token)
+	if err := auth.Authenticate(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the request's deadline to be exceeded, got %v", err)
+	}
+
+	close(gate)
+	if got := serveAuthenticated(auth, "Bearer "+token); got != http.StatusOK {
+		t.Errorf("status = %d, want %d", got, http.StatusOK)
+	}
+}
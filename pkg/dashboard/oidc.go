@@ -0,0 +1,136 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/sync/singleflight"
+)
+
+// clockSkew is the leeway allowed when checking token expiry
+const clockSkew = time.Minute
+
+// discoveryTimeout bounds issuer discovery. Discovery runs detached from the
+// request that triggered it, so a cancelled request does not fail it for the
+// requests waiting on the same discovery.
+const discoveryTimeout = 10 * time.Second
+
+// signingAlgorithms are the JWT algorithms accepted for ID tokens
+var signingAlgorithms = []string{oidc.RS256, oidc.RS384, oidc.RS512}
+
+// OIDCAuthenticator accepts requests presenting an OIDC ID token, a JWT
+// signed by the issuer's keys and issued for the configured audience. The
+// issuer is discovered on first use; its signing keys are fetched by go-oidc
+// in the background and refetched when a token is signed with an unknown key.
+type OIDCAuthenticator struct {
+	issuer   string
+	audience string
+	client   *http.Client
+
+	// now returns the current time; tests override it
+	now func() time.Time
+
+	// discovery deduplicates concurrent discoveries of the issuer
+	discovery singleflight.Group
+
+	mu       sync.Mutex
+	verifier *oidc.IDTokenVerifier
+}
+
+// NewOIDCAuthenticator creates an authenticator for ID tokens of issuer.
+func NewOIDCAuthenticator(issuer, audience string) *OIDCAuthenticator {
+	return &OIDCAuthenticator{
+		issuer:   issuer,
+		audience: audience,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// currentTime returns the authenticator's notion of now.
+func (a *OIDCAuthenticator) currentTime() time.Time {
+	if a.now != nil {
+		return a.now()
+	}
+	return time.Now()
+}
+
+// Authenticate verifies the request's bearer token as an ID token.
+func (a *OIDCAuthenticator) Authenticate(r *http.Request) error {
+	token, err := bearerToken(r)
+	if err != nil {
+		return err
+	}
+
+	verifier, err := a.idTokenVerifier(r.Context())
+	if err != nil {
+		return err
+	}
+	if _, err := verifier.Verify(r.Context(), token); err != nil {
+		return fmt.Errorf("invalid token: %w", err)
+	}
+	return nil
+}
+
+// idTokenVerifier returns the verifier for the issuer's ID tokens, discovering
+// the issuer if that has not succeeded yet. No lock is held while discovering;
+// concurrent callers share one discovery and stop waiting when ctx is done.
+func (a *OIDCAuthenticator) idTokenVerifier(ctx context.Context) (*oidc.IDTokenVerifier, error) {
+	a.mu.Lock()
+	verifier := a.verifier
+	a.mu.Unlock()
+	if verifier != nil {
+		return verifier, nil
+	}
+
+	result := a.discovery.DoChan(a.issuer, func() (interface{}, error) {
+		discoveryCtx, cancel := context.WithTimeout(context.Background(), discoveryTimeout)
+		defer cancel()
+
+		provider, err := oidc.NewProvider(oidc.ClientContext(discoveryCtx, a.client), a.issuer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover issuer %s: %w", a.issuer, err)
+		}
+		verifier := provider.Verifier(&oidc.Config{
+			ClientID:             a.audience,
+			SupportedSigningAlgs: signingAlgorithms,
+			// go-oidc checks expiry without leeway; checking against a slightly
+			// earlier time allows for clock skew
+			Now: func() time.Time { return a.currentTime().Add(-clockSkew) },
+		})
+
+		a.mu.Lock()
+		a.verifier = verifier
+		a.mu.Unlock()
+		return verifier, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*oidc.IDTokenVerifier), nil
+	}
+}
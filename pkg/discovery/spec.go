@@ -0,0 +1,258 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+)
+
+// DerivedSpecPaths are the spec fields DeriveSpec fills in from cluster state.
+// Compare a specification with a derived one only on these paths.
+var DerivedSpecPaths = []string{
+	"spec.kubernetes.minVersion",
+	"spec.kubernetes.maxVersion",
+	"spec.podSecurity.enforce",
+	"spec.podSecurity.audit",
+	"spec.podSecurity.warn",
+	"spec.network.defaultDeny",
+	"spec.network.allowedServiceTypes",
+	"spec.workloads.images.allowedRegistries",
+	"spec.workloads.images.requireDigests",
+	"spec.workloads.requireLiveness",
+	"spec.workloads.requireReadiness",
+}
+
+// podSecurityLevels orders the Pod Security Standards from least to most restrictive
+var podSecurityLevels = []string{"privileged", "baseline", "restricted"}
+
+// derivedSystemNamespaces are excluded when deriving a spec, matching the scanner
+var derivedSystemNamespaces = map[string]bool{
+	"kube-system":     true,
+	"kube-public":     true,
+	"kube-node-lease": true,
+}
+
+// DeriveSpec builds the effective specification a cluster currently meets: its
+// Kubernetes version, the weakest Pod Security level of its namespaces, whether
+// every namespace denies ingress by default, and the service types, image
+// registries and probes its workloads use. System namespaces are ignored.
+func DeriveSpec(ctx context.Context, client kubernetes.Interface) (*spec.ClusterSpecification, error) {
+	derived := &spec.ClusterSpecification{
+		APIVersion: "kspec.dev/v1",
+		Kind:       "ClusterSpecification",
+		Metadata: spec.Metadata{
+			Name:        "live-cluster",
+			Description: "Derived from the current cluster state",
+		},
+	}
+
+	versionInfo, err := client.Discovery().ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server version: %w", err)
+	}
+	version := normalizeVersion(versionInfo.GitVersion)
+	derived.Spec.Kubernetes = spec.KubernetesSpec{MinVersion: version, MaxVersion: version}
+
+	namespaceList, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	var namespaces []corev1.Namespace
+	for _, ns := range namespaceList.Items {
+		if !derivedSystemNamespaces[ns.Name] {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	if len(namespaces) > 0 {
+		derived.Spec.PodSecurity = &spec.PodSecuritySpec{
+			Enforce: weakestPodSecurityLevel(namespaces, "enforce"),
+			Audit:   weakestPodSecurityLevel(namespaces, "audit"),
+			Warn:    weakestPodSecurityLevel(namespaces, "warn"),
+		}
+	}
+
+	network, err := deriveNetwork(ctx, client, namespaces)
+	if err != nil {
+		return nil, err
+	}
+	derived.Spec.Network = network
+
+	workloads, err := deriveWorkloads(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	derived.Spec.Workloads = workloads
+
+	return derived, nil
+}
+
+// deriveNetwork derives the network requirements met by every namespace.
+func deriveNetwork(ctx context.Context, client kubernetes.Interface, namespaces []corev1.Namespace) (*spec.NetworkSpec, error) {
+	policies, err := client.NetworkingV1().NetworkPolicies("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network policies: %w", err)
+	}
+	denyAll := map[string]bool{}
+	for _, policy := range policies.Items {
+		if isDefaultDenyIngress(policy) {
+			denyAll[policy.Namespace] = true
+		}
+	}
+
+	network := &spec.NetworkSpec{DefaultDeny: len(namespaces) > 0}
+	for _, ns := range namespaces {
+		if !denyAll[ns.Name] {
+			network.DefaultDeny = false
+			break
+		}
+	}
+
+	services, err := client.CoreV1().Services("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	serviceTypes := map[string]bool{}
+	for _, svc := range services.Items {
+		if derivedSystemNamespaces[svc.Namespace] {
+			continue
+		}
+		serviceType := string(svc.Spec.Type)
+		if serviceType == "" {
+			serviceType = string(corev1.ServiceTypeClusterIP)
+		}
+		serviceTypes[serviceType] = true
+	}
+	network.AllowedServiceTypes = sortedKeys(serviceTypes)
+
+	return network, nil
+}
+
+// deriveWorkloads derives the image and probe requirements met by every container.
+func deriveWorkloads(ctx context.Context, client kubernetes.Interface) (*spec.WorkloadsSpec, error) {
+	pods, err := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	registries := map[string]bool{}
+	containers := 0
+	allDigests, allLiveness, allReadiness := true, true, true
+	for _, pod := range pods.Items {
+		if derivedSystemNamespaces[pod.Namespace] || pod.Status.Phase == corev1.PodSucceeded {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			containers++
+			registries[imageRegistry(container.Image)] = true
+			allDigests = allDigests && strings.Contains(container.Image, "@sha256:")
+			allLiveness = allLiveness && container.LivenessProbe != nil
+			allReadiness = allReadiness && container.ReadinessProbe != nil
+		}
+	}
+
+	if containers == 0 {
+		return nil, nil
+	}
+	return &spec.WorkloadsSpec{
+		Images: &spec.ImageSpec{
+			AllowedRegistries: sortedKeys(registries),
+			RequireDigests:    allDigests,
+		},
+		RequireLiveness:  allLiveness,
+		RequireReadiness: allReadiness,
+	}, nil
+}
+
+// isDefaultDenyIngress reports whether a network policy selects every pod in
+// its namespace and allows no ingress.
+func isDefaultDenyIngress(policy networkingv1.NetworkPolicy) bool {
+	if len(policy.Spec.PodSelector.MatchLabels) > 0 || len(policy.Spec.PodSelector.MatchExpressions) > 0 {
+		return false
+	}
+	if len(policy.Spec.Ingress) > 0 {
+		return false
+	}
+	if len(policy.Spec.PolicyTypes) == 0 {
+		// Without policyTypes, a policy always applies to ingress
+		return true
+	}
+	for _, policyType := range policy.Spec.PolicyTypes {
+		if policyType == networkingv1.PolicyTypeIngress {
+			return true
+		}
+	}
+	return false
+}
+
+// weakestPodSecurityLevel returns the least restrictive Pod Security level of
+// a mode across namespaces. Unlabelled namespaces are privileged.
+func weakestPodSecurityLevel(namespaces []corev1.Namespace, mode string) string {
+	weakest := len(podSecurityLevels) - 1
+	for _, ns := range namespaces {
+		level := ns.Labels["pod-security.kubernetes.io/"+mode]
+		rank := 0
+		for i, known := range podSecurityLevels {
+			if level == known {
+				rank = i
+			}
+		}
+		if rank < weakest {
+			weakest = rank
+		}
+	}
+	return podSecurityLevels[weakest]
+}
+
+// imageRegistry returns the registry an image is pulled from, defaulting to
+// Docker Hub for images without one.
+func imageRegistry(image string) string {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		return parts[0]
+	}
+	return "docker.io"
+}
+
+// normalizeVersion strips the "v" prefix and build metadata from a Kubernetes
+// version, e.g. v1.29.2-eks-1234 becomes 1.29.2.
+func normalizeVersion(gitVersion string) string {
+	version := strings.TrimPrefix(gitVersion, "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	return version
+}
+
+// sortedKeys returns the keys of a set in order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDeriveSpec(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "payments",
+			Labels: map[string]string{"pod-security.kubernetes.io/enforce": "restricted"},
+		}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "web",
+			Labels: map[string]string{"pod-security.kubernetes.io/enforce": "baseline"},
+		}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		&networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-deny", Namespace: "payments"},
+			Spec:       networkingv1.NetworkPolicySpec{PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "web"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "payments"},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "payments"},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:           "api",
+				Image:          "registry.example.com/payments/api@sha256:abc",
+				LivenessProbe:  &corev1.Probe{},
+				ReadinessProbe: &corev1.Probe{},
+			}}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "web"},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:           "web",
+				Image:          "nginx:1.25",
+				ReadinessProbe: &corev1.Probe{},
+			}}},
+		},
+		// System namespaces are ignored
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "coredns", Image: "k8s.gcr.io/coredns"}}},
+		},
+	)
+	client.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.29.2-eks-1234"}
+
+	derived, err := DeriveSpec(context.Background(), client)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if derived.Spec.Kubernetes.MinVersion != "1.29.2" || derived.Spec.Kubernetes.MaxVersion != "1.29.2" {
+		t.Errorf("Expected version 1.29.2, got %+v", derived.Spec.Kubernetes)
+	}
+
+	if derived.Spec.PodSecurity == nil {
+		t.Fatal("Expected pod security to be derived")
+	}
+	if derived.Spec.PodSecurity.Enforce != "baseline" {
+		t.Errorf("Expected weakest enforce level baseline, got %s", derived.Spec.PodSecurity.Enforce)
+	}
+	if derived.Spec.PodSecurity.Audit != "privileged" {
+		t.Errorf("Expected unlabelled audit level privileged, got %s", derived.Spec.PodSecurity.Audit)
+	}
+
+	if derived.Spec.Network.DefaultDeny {
+		t.Error("Expected defaultDeny false when a namespace has no default deny policy")
+	}
+	if !reflect.DeepEqual(derived.Spec.Network.AllowedServiceTypes, []string{"ClusterIP", "LoadBalancer"}) {
+		t.Errorf("Unexpected service types: %v", derived.Spec.Network.AllowedServiceTypes)
+	}
+
+	workloads := derived.Spec.Workloads
+	if workloads == nil || workloads.Images == nil {
+		t.Fatal("Expected workloads to be derived")
+	}
+	if !reflect.DeepEqual(workloads.Images.AllowedRegistries, []string{"docker.io", "registry.example.com"}) {
+		t.Errorf("Unexpected registries: %v", workloads.Images.AllowedRegistries)
+	}
+	if workloads.Images.RequireDigests {
+		t.Error("Expected requireDigests false when an image uses a tag")
+	}
+	if workloads.RequireLiveness {
+		t.Error("Expected requireLiveness false when a container has no liveness probe")
+	}
+	if !workloads.RequireReadiness {
+		t.Error("Expected requireReadiness true when every container has a readiness probe")
+	}
+}
+
+func TestImageRegistry(t *testing.T) {
+	tests := map[string]string{
+		"nginx":                             "docker.io",
+		"library/nginx:1.25":                "docker.io",
+		"gcr.io/project/app:v1":             "gcr.io",
+		"localhost/app":                     "localhost",
+		"registry.example.com:5000/app@sha": "registry.example.com:5000",
+	}
+
+	for image, expected := range tests {
+		if got := imageRegistry(image); got != expected {
+			t.Errorf("imageRegistry(%q) = %q, want %q", image, got, expected)
+		}
+	}
+}
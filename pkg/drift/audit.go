@@ -0,0 +1,155 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cloudcwfranck/kspec/pkg/enforcer"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// PolicyAuditStatus classifies a generated policy against the current spec.
+type PolicyAuditStatus string
+
+const (
+	// PolicyAuditInSync means the deployed policy matches what the spec generates.
+	PolicyAuditInSync PolicyAuditStatus = "in-sync"
+
+	// PolicyAuditDrifted means the deployed policy differs from what the spec generates.
+	PolicyAuditDrifted PolicyAuditStatus = "drifted"
+
+	// PolicyAuditOrphaned means the policy is deployed but the spec no longer generates it.
+	PolicyAuditOrphaned PolicyAuditStatus = "orphaned"
+
+	// PolicyAuditMissing means the spec generates the policy but it is not deployed.
+	PolicyAuditMissing PolicyAuditStatus = "missing"
+)
+
+// PolicyAudit is a read-only report on the hygiene of kspec-generated policies.
+type PolicyAudit struct {
+	// Timestamp when the audit ran
+	Timestamp time.Time `json:"timestamp"`
+
+	// Spec identifies the specification audited against
+	Spec SpecInfo `json:"spec"`
+
+	// Policies lists every generated policy that is deployed or desired, sorted by name
+	Policies []PolicyAuditEntry `json:"policies"`
+
+	// Summary counts policies by status
+	Summary PolicyAuditSummary `json:"summary"`
+}
+
+// PolicyAuditEntry is the audit result for a single ClusterPolicy.
+type PolicyAuditEntry struct {
+	// Name of the ClusterPolicy
+	Name string `json:"name"`
+
+	// Status of the policy relative to the spec
+	Status PolicyAuditStatus `json:"status"`
+
+	// Diff between generated and deployed content, set for drifted policies
+	Diff *DriftDiff `json:"diff,omitempty"`
+}
+
+// PolicyAuditSummary counts audited policies by status.
+type PolicyAuditSummary struct {
+	Deployed int `json:"deployed"`
+	InSync   int `json:"inSync"`
+	Drifted  int `json:"drifted"`
+	Orphaned int `json:"orphaned"`
+	Missing  int `json:"missing"`
+}
+
+// HasFindings reports whether any policy is drifted, orphaned or missing.
+func (a *PolicyAudit) HasFindings() bool {
+	return a.Summary.Drifted > 0 || a.Summary.Orphaned > 0 || a.Summary.Missing > 0
+}
+
+// AuditPolicies lists the kspec-generated ClusterPolicies deployed in the cluster
+// and cross-references them against what the spec currently generates. It never
+// modifies the cluster; deployed policies kspec did not generate are ignored.
+func (d *Detector) AuditPolicies(ctx context.Context, clusterSpec *spec.ClusterSpecification) (*PolicyAudit, error) {
+	installed, err := d.isKyvernoCRDInstalled()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover Kyverno CRDs: %w", err)
+	}
+	if !installed {
+		return nil, ErrKyvernoNotInstalled
+	}
+
+	result, err := d.enforcer.Enforce(ctx, clusterSpec, enforcer.EnforceOptions{
+		DryRun:      true,
+		SkipInstall: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate expected policies: %w", err)
+	}
+
+	actualPolicies, err := d.getClusterPolicies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster policies: %w", err)
+	}
+
+	expectedMap := d.buildPolicyMap(result.Policies)
+	actualMap := d.buildPolicyMap(actualPolicies)
+
+	audit := &PolicyAudit{
+		Timestamp: time.Now(),
+		Spec: SpecInfo{
+			Name:    clusterSpec.Metadata.Name,
+			Version: clusterSpec.Metadata.Version,
+		},
+		Policies: []PolicyAuditEntry{},
+	}
+
+	for name, actualPolicy := range actualMap {
+		if !d.isGeneratedPolicy(actualPolicy) {
+			continue
+		}
+		audit.Summary.Deployed++
+
+		expectedPolicy, desired := expectedMap[name]
+		if !desired {
+			audit.Policies = append(audit.Policies, PolicyAuditEntry{Name: name, Status: PolicyAuditOrphaned})
+			audit.Summary.Orphaned++
+			continue
+		}
+
+		if diff := d.comparePolicies(expectedPolicy, actualPolicy); diff != nil {
+			audit.Policies = append(audit.Policies, PolicyAuditEntry{Name: name, Status: PolicyAuditDrifted, Diff: diff})
+			audit.Summary.Drifted++
+			continue
+		}
+
+		audit.Policies = append(audit.Policies, PolicyAuditEntry{Name: name, Status: PolicyAuditInSync})
+		audit.Summary.InSync++
+	}
+
+	for name := range expectedMap {
+		if _, deployed := actualMap[name]; !deployed {
+			audit.Policies = append(audit.Policies, PolicyAuditEntry{Name: name, Status: PolicyAuditMissing})
+			audit.Summary.Missing++
+		}
+	}
+
+	sort.Slice(audit.Policies, func(i, j int) bool {
+		return audit.Policies[i].Name < audit.Policies[j].Name
+	})
+
+	return audit, nil
+}
+
+// isGeneratedPolicy reports whether a deployed policy carries the kspec.dev/generated
+// marker, either as the annotation the generator sets or as a label.
+func (d *Detector) isGeneratedPolicy(policy runtime.Object) bool {
+	u, ok := policy.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+	return u.GetLabels()["kspec.dev/generated"] == "true" || d.isKspecGenerated(u)
+}
@@ -0,0 +1,145 @@
+package drift
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// newAuditPolicy builds a deployed ClusterPolicy with the given metadata.
+func newAuditPolicy(name string, labels, annotations map[string]interface{}) *unstructured.Unstructured {
+	metadata := map[string]interface{}{"name": name}
+	if labels != nil {
+		metadata["labels"] = labels
+	}
+	if annotations != nil {
+		metadata["annotations"] = annotations
+	}
+
+	policy := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "kyverno.io/v1",
+			"kind":       "ClusterPolicy",
+			"metadata":   metadata,
+			"spec": map[string]interface{}{
+				"rules": []interface{}{
+					map[string]interface{}{"name": "some-rule"},
+				},
+			},
+		},
+	}
+	policy.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kyverno.io",
+		Version: "v1",
+		Kind:    "ClusterPolicy",
+	})
+	return policy
+}
+
+func TestAuditPolicies(t *testing.T) {
+	generated := map[string]interface{}{"kspec.dev/generated": "true"}
+
+	client, dynamicClient := createTestClients(
+		// Still desired but edited in the cluster
+		newAuditPolicy("require-run-as-non-root", nil, generated),
+		// No longer desired by the spec, marked by annotation and by label
+		newAuditPolicy("old-annotated-policy", nil, generated),
+		newAuditPolicy("old-labeled-policy", generated, nil),
+		// Not generated by kspec, must be ignored
+		newAuditPolicy("team-policy", nil, nil),
+	)
+
+	detector := NewDetector(client, dynamicClient)
+
+	clusterSpec := &spec.ClusterSpecification{
+		Metadata: spec.Metadata{Name: "test-spec", Version: "1.0.0"},
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{
+				Containers: &spec.ContainerSpec{
+					Required: []spec.FieldRequirement{
+						{Key: "securityContext.runAsNonRoot", Value: "true"},
+					},
+				},
+			},
+		},
+	}
+
+	audit, err := detector.AuditPolicies(context.Background(), clusterSpec)
+	if err != nil {
+		t.Fatalf("AuditPolicies failed: %v", err)
+	}
+
+	statuses := make(map[string]PolicyAuditStatus)
+	for _, entry := range audit.Policies {
+		statuses[entry.Name] = entry.Status
+		if entry.Status == PolicyAuditDrifted && entry.Diff == nil {
+			t.Errorf("Expected diff for drifted policy %s", entry.Name)
+		}
+	}
+
+	expected := map[string]PolicyAuditStatus{
+		"require-run-as-non-root": PolicyAuditDrifted,
+		"old-annotated-policy":    PolicyAuditOrphaned,
+		"old-labeled-policy":      PolicyAuditOrphaned,
+	}
+	for name, status := range expected {
+		if statuses[name] != status {
+			t.Errorf("Expected %s to be %s, got %q", name, status, statuses[name])
+		}
+	}
+	if _, found := statuses["team-policy"]; found {
+		t.Error("Expected policies not generated by kspec to be ignored")
+	}
+
+	if audit.Summary.Deployed != 3 || audit.Summary.Orphaned != 2 || audit.Summary.Drifted != 1 {
+		t.Errorf("Unexpected summary: %+v", audit.Summary)
+	}
+	if !audit.HasFindings() {
+		t.Error("Expected audit to report findings")
+	}
+}
+
+func TestAuditPolicies_MissingPolicy(t *testing.T) {
+	client, dynamicClient := createTestClients()
+	detector := NewDetector(client, dynamicClient)
+
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{
+				Containers: &spec.ContainerSpec{
+					Required: []spec.FieldRequirement{
+						{Key: "securityContext.runAsNonRoot", Value: "true"},
+					},
+				},
+			},
+		},
+	}
+
+	audit, err := detector.AuditPolicies(context.Background(), clusterSpec)
+	if err != nil {
+		t.Fatalf("AuditPolicies failed: %v", err)
+	}
+
+	if audit.Summary.Deployed != 0 || audit.Summary.Missing == 0 {
+		t.Errorf("Expected only missing policies, got %+v", audit.Summary)
+	}
+	for _, entry := range audit.Policies {
+		if entry.Status != PolicyAuditMissing {
+			t.Errorf("Expected %s to be missing, got %s", entry.Name, entry.Status)
+		}
+	}
+}
+
+func TestAuditPolicies_KyvernoNotInstalled(t *testing.T) {
+	client, dynamicClient := createTestClientsWithoutKyverno()
+	detector := NewDetector(client, dynamicClient)
+
+	_, err := detector.AuditPolicies(context.Background(), &spec.ClusterSpecification{})
+	if !errors.Is(err, ErrKyvernoNotInstalled) {
+		t.Errorf("Expected ErrKyvernoNotInstalled, got %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudcwfranck/kspec/pkg/enforcer"
+	"github.com/cloudcwfranck/kspec/pkg/enforcer/kyverno"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+)
+
+// complianceEnforcementPolicies maps the compliance checks whose failures a
+// generated Kyverno policy prevents from recurring to the names of those
+// policies. Checks not listed here (e.g. RBAC, network or Kubernetes version
+// checks) have no safe automatic fix.
+var complianceEnforcementPolicies = map[string][]string{
+	workloadSecurityCheck: {
+		"require-run-as-non-root",
+		"disallow-privilege-escalation",
+		"disallow-privileged-containers",
+		"disallow-host-namespaces",
+		"require-resource-limits",
+		"require-image-digests",
+		"restrict-image-registries",
+		"block-image-registries",
+	},
+	"workload.probes":              {"require-pod-probes"},
+	"availability.topology-spread": {"require-topology-spread"},
+	"scheduling.priority-class":    {"require-priority-class"},
+}
+
+// remediateComplianceDrift remediates a failed compliance check. Workload
+// security drift gets workload patches (see remediateWorkloadDrift); with
+// opts.EnforceCompliance, checks that map to Kyverno policies additionally get
+// those policies applied so the violation cannot recur. Anything else stays
+// manual-required.
+func (r *Remediator) remediateComplianceDrift(ctx context.Context, clusterSpec *spec.ClusterSpecification, event *DriftEvent, opts RemediateOptions) error {
+	if event.Resource.Name == workloadSecurityCheck {
+		if err := r.remediateWorkloadDrift(ctx, clusterSpec, event, opts); err != nil {
+			return err
+		}
+	}
+
+	if !opts.EnforceCompliance {
+		if event.Resource.Name != workloadSecurityCheck {
+			event.Remediation = &RemediationResult{
+				Action:    "manual-required",
+				Status:    DriftStatusManualRequired,
+				Timestamp: time.Now(),
+				Details:   manualComplianceDetails(event.Resource.Name),
+			}
+		}
+		return nil
+	}
+
+	return r.enforceCompliance(ctx, clusterSpec, event, opts)
+}
+
+// manualComplianceDetails explains why a failed check is left for manual remediation.
+func manualComplianceDetails(check string) string {
+	if _, ok := complianceEnforcementPolicies[check]; ok {
+		return "Compliance drift requires manual intervention (enforce a policy preventing it with --enforce-compliance)"
+	}
+	return fmt.Sprintf("Compliance drift requires manual intervention (no policy can prevent %s failures)", check)
+}
+
+// compliancePolicies returns the generated Kyverno policies that prevent
+// failures of the given check, in generation order.
+func (r *Remediator) compliancePolicies(ctx context.Context, clusterSpec *spec.ClusterSpecification, check string) ([]*kyverno.ClusterPolicy, error) {
+	names, ok := complianceEnforcementPolicies[check]
+	if !ok {
+		return nil, nil
+	}
+
+	result, err := r.enforcer.Enforce(ctx, clusterSpec, enforcer.EnforceOptions{
+		DryRun:      true,
+		SkipInstall: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate policies: %w", err)
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var policies []*kyverno.ClusterPolicy
+	for _, obj := range result.Policies {
+		if policy, ok := obj.(*kyverno.ClusterPolicy); ok && wanted[policy.Name] {
+			policies = append(policies, policy)
+		}
+	}
+	return policies, nil
+}
+
+// enforceCompliance applies the Kyverno policies that prevent a failed check
+// from recurring and marks the event remediated. Workload patches recorded on
+// the event by an earlier step are kept.
+func (r *Remediator) enforceCompliance(ctx context.Context, clusterSpec *spec.ClusterSpecification, event *DriftEvent, opts RemediateOptions) error {
+	var patches []WorkloadPatch
+	if event.Remediation != nil {
+		patches = event.Remediation.Patches
+	}
+
+	policies, err := r.compliancePolicies(ctx, clusterSpec, event.Resource.Name)
+	if err != nil {
+		event.Remediation = &RemediationResult{
+			Action:    "enforce",
+			Status:    DriftStatusFailed,
+			Timestamp: time.Now(),
+			Error:     err.Error(),
+			Patches:   patches,
+		}
+		return err
+	}
+
+	if len(policies) == 0 {
+		// Keep the outcome of workload patching, if any
+		if event.Remediation != nil && event.Resource.Name == workloadSecurityCheck {
+			return nil
+		}
+		details := manualComplianceDetails(event.Resource.Name)
+		if _, ok := complianceEnforcementPolicies[event.Resource.Name]; ok {
+			details = fmt.Sprintf("Compliance drift requires manual intervention (the spec defines no policy preventing %s failures)", event.Resource.Name)
+		}
+		event.Remediation = &RemediationResult{
+			Action:    "manual-required",
+			Status:    DriftStatusManualRequired,
+			Timestamp: time.Now(),
+			Details:   details,
+			Patches:   patches,
+		}
+		return nil
+	}
+
+	names := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		names = append(names, policy.Name)
+	}
+
+	if opts.DryRun {
+		event.Remediation = &RemediationResult{
+			Action:    "enforce",
+			Status:    DriftStatusDetected,
+			Timestamp: time.Now(),
+			Details:   fmt.Sprintf("Would apply policies %s (dry-run)", strings.Join(names, ", ")),
+			Patches:   patches,
+		}
+		return nil
+	}
+
+	for _, policy := range policies {
+		if err := r.enforcer.ApplyPolicy(ctx, policy); err != nil {
+			event.Remediation = &RemediationResult{
+				Action:    "enforce",
+				Status:    DriftStatusFailed,
+				Timestamp: time.Now(),
+				Error:     err.Error(),
+				Patches:   patches,
+			}
+			return fmt.Errorf("failed to apply policy: %w", err)
+		}
+	}
+
+	details := fmt.Sprintf("Applied policies %s to prevent recurrence", strings.Join(names, ", "))
+	if event.Remediation != nil && event.Remediation.Status == DriftStatusRemediated {
+		details = event.Remediation.Details + "; " + details
+	}
+	event.Remediation = &RemediationResult{
+		Action:    "enforce",
+		Status:    DriftStatusRemediated,
+		Timestamp: time.Now(),
+		Details:   details,
+		Patches:   patches,
+	}
+	return nil
+}
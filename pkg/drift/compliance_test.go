@@ -0,0 +1,123 @@
+package drift
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/enforcer/kyverno"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func complianceEvent(check string) DriftEvent {
+	return DriftEvent{
+		Type:      DriftTypeCompliance,
+		DriftKind: "violation",
+		Resource: DriftResource{
+			Kind: "ComplianceCheck",
+			Name: check,
+			Path: "Check/" + check,
+		},
+	}
+}
+
+func probesSpec() *spec.ClusterSpecification {
+	return &spec.ClusterSpecification{
+		Metadata: spec.Metadata{Name: "test-spec", Version: "1.0.0"},
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{RequireLiveness: true},
+		},
+	}
+}
+
+func TestRemediate_ComplianceEnforcement(t *testing.T) {
+	ctx := context.Background()
+	client, dynamicClient := createTestClients()
+	remediator := NewRemediator(client, dynamicClient)
+
+	report := &DriftReport{Events: []DriftEvent{complianceEvent("workload.probes")}}
+	err := remediator.Remediate(ctx, probesSpec(), report, RemediateOptions{
+		Types:             []DriftType{DriftTypeCompliance},
+		EnforceCompliance: true,
+	})
+	if err != nil {
+		t.Fatalf("Remediate failed: %v", err)
+	}
+
+	event := report.Events[0]
+	if event.Remediation == nil || event.Remediation.Status != DriftStatusRemediated {
+		t.Fatalf("Expected compliance drift to be remediated, got %+v", event.Remediation)
+	}
+	if !strings.Contains(event.Remediation.Details, "require-pod-probes") {
+		t.Errorf("Expected details to name the applied policy, got %q", event.Remediation.Details)
+	}
+
+	if _, err := dynamicClient.Resource(kyverno.ClusterPolicyGVR()).Get(ctx, "require-pod-probes", metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected require-pod-probes to be applied: %v", err)
+	}
+	if len(report.RemediationOrder) != 1 || report.RemediationOrder[0] != "Check/workload.probes" {
+		t.Errorf("Expected the check in the remediation order, got %v", report.RemediationOrder)
+	}
+}
+
+func TestRemediate_ComplianceEnforcementDryRun(t *testing.T) {
+	ctx := context.Background()
+	client, dynamicClient := createTestClients()
+	remediator := NewRemediator(client, dynamicClient)
+
+	report := &DriftReport{Events: []DriftEvent{complianceEvent("workload.probes")}}
+	err := remediator.Remediate(ctx, probesSpec(), report, RemediateOptions{
+		DryRun:            true,
+		Types:             []DriftType{DriftTypeCompliance},
+		EnforceCompliance: true,
+	})
+	if err != nil {
+		t.Fatalf("Remediate failed: %v", err)
+	}
+
+	if status := report.Events[0].Remediation.Status; status != DriftStatusDetected {
+		t.Errorf("Expected status %s for dry-run, got %s", DriftStatusDetected, status)
+	}
+	if _, err := dynamicClient.Resource(kyverno.ClusterPolicyGVR()).Get(ctx, "require-pod-probes", metav1.GetOptions{}); err == nil {
+		t.Error("Expected no policy to be applied in dry-run mode")
+	}
+}
+
+func TestRemediate_ComplianceManualRequired(t *testing.T) {
+	tests := []struct {
+		name    string
+		check   string
+		enforce bool
+		want    string
+	}{
+		{name: "enforcement not enabled", check: "workload.probes", want: "--enforce-compliance"},
+		{name: "no policy for the check", check: "rbac.validation", enforce: true, want: "no policy can prevent rbac.validation failures"},
+		{name: "spec defines no policy", check: "scheduling.priority-class", enforce: true, want: "the spec defines no policy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client, dynamicClient := createTestClients()
+			remediator := NewRemediator(client, dynamicClient)
+
+			report := &DriftReport{Events: []DriftEvent{complianceEvent(tt.check)}}
+			err := remediator.Remediate(ctx, probesSpec(), report, RemediateOptions{
+				Types:             []DriftType{DriftTypeCompliance},
+				EnforceCompliance: tt.enforce,
+			})
+			if err != nil {
+				t.Fatalf("Remediate failed: %v", err)
+			}
+
+			event := report.Events[0]
+			if event.Remediation == nil || event.Remediation.Status != DriftStatusManualRequired {
+				t.Fatalf("Expected manual-required, got %+v", event.Remediation)
+			}
+			if !strings.Contains(event.Remediation.Details, tt.want) {
+				t.Errorf("Expected details to contain %q, got %q", tt.want, event.Remediation.Details)
+			}
+		})
+	}
+}
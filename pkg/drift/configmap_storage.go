@@ -0,0 +1,216 @@
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// HistoryConfigMapName is the ConfigMap that holds drift history
+	HistoryConfigMapName = "kspec-drift-history"
+
+	// DefaultHistoryNamespace is the namespace of the drift history ConfigMap
+	DefaultHistoryNamespace = "kspec-system"
+
+	// DefaultHistoryMaxEntries is the number of events kept per spec
+	DefaultHistoryMaxEntries = 500
+)
+
+// ConfigMapStorage stores the drift history of one spec in the
+// kspec-drift-history ConfigMap, under a key named after the spec. Only the
+// newest maxEntries events are kept, so the ConfigMap stays well below the
+// Kubernetes object size limit.
+type ConfigMapStorage struct {
+	client     kubernetes.Interface
+	namespace  string
+	specName   string
+	maxEntries int
+	mu         sync.Mutex
+}
+
+// NewConfigMapStorage creates a ConfigMap-backed storage for a spec's drift
+// history. A non-positive maxEntries uses DefaultHistoryMaxEntries.
+func NewConfigMapStorage(client kubernetes.Interface, namespace, specName string, maxEntries int) *ConfigMapStorage {
+	if namespace == "" {
+		namespace = DefaultHistoryNamespace
+	}
+	if maxEntries <= 0 {
+		maxEntries = DefaultHistoryMaxEntries
+	}
+
+	return &ConfigMapStorage{
+		client:     client,
+		namespace:  namespace,
+		specName:   specName,
+		maxEntries: maxEntries,
+	}
+}
+
+// Store stores a drift event.
+func (s *ConfigMapStorage) Store(event DriftEvent) error {
+	return s.StoreEvents(context.Background(), []DriftEvent{event})
+}
+
+// StoreReport appends the events of a drift report to the history.
+func (s *ConfigMapStorage) StoreReport(ctx context.Context, report *DriftReport) error {
+	return s.StoreEvents(ctx, report.Events)
+}
+
+// StoreEvents appends events to the history in a single ConfigMap update,
+// dropping the oldest events beyond the retention cap. Expected and actual
+// state, diffs and patches are not persisted.
+func (s *ConfigMapStorage) StoreEvents(ctx context.Context, events []DriftEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, HistoryConfigMapName, metav1.GetOptions{})
+		create := apierrors.IsNotFound(err)
+		if create {
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      HistoryConfigMapName,
+					Namespace: s.namespace,
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "kspec",
+					},
+				},
+			}
+		} else if err != nil {
+			return fmt.Errorf("failed to get drift history: %w", err)
+		}
+
+		stored, err := s.decode(configMap)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			stored = append(stored, summarizeEvent(event))
+		}
+		sort.SliceStable(stored, func(i, j int) bool {
+			return stored[i].Timestamp.Before(stored[j].Timestamp)
+		})
+		if len(stored) > s.maxEntries {
+			stored = stored[len(stored)-s.maxEntries:]
+		}
+
+		data, err := json.Marshal(stored)
+		if err != nil {
+			return fmt.Errorf("failed to encode drift history: %w", err)
+		}
+		if configMap.Data == nil {
+			configMap.Data = make(map[string]string)
+		}
+		configMap.Data[s.key()] = string(data)
+
+		if create {
+			_, err = s.client.CoreV1().ConfigMaps(s.namespace).Create(ctx, configMap, metav1.CreateOptions{})
+		} else {
+			_, err = s.client.CoreV1().ConfigMaps(s.namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+		}
+		return err
+	})
+}
+
+// GetHistory returns drift history since a given time.
+func (s *ConfigMapStorage) GetHistory(since time.Time) (*DriftHistory, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	configMap, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(context.Background(), HistoryConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return &DriftHistory{Events: []DriftEvent{}}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get drift history: %w", err)
+	}
+
+	stored, err := s.decode(configMap)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []DriftEvent{}
+	for _, event := range stored {
+		if event.Timestamp.After(since) || event.Timestamp.Equal(since) {
+			filtered = append(filtered, event)
+		}
+	}
+
+	memStorage := &MemoryStorage{events: filtered}
+	return &DriftHistory{
+		Events: filtered,
+		Stats:  memStorage.calculateStats(filtered),
+	}, nil
+}
+
+// Clear clears the history of the spec, leaving other specs untouched.
+func (s *ConfigMapStorage) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := context.Background()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, HistoryConfigMapName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to get drift history: %w", err)
+		}
+
+		if _, exists := configMap.Data[s.key()]; !exists {
+			return nil
+		}
+		delete(configMap.Data, s.key())
+		_, err = s.client.CoreV1().ConfigMaps(s.namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// key is the ConfigMap data key holding the spec's history
+func (s *ConfigMapStorage) key() string {
+	return s.specName + ".json"
+}
+
+// decode reads the spec's events from the ConfigMap
+func (s *ConfigMapStorage) decode(configMap *corev1.ConfigMap) ([]DriftEvent, error) {
+	data, exists := configMap.Data[s.key()]
+	if !exists || data == "" {
+		return []DriftEvent{}, nil
+	}
+
+	var events []DriftEvent
+	if err := json.Unmarshal([]byte(data), &events); err != nil {
+		return nil, fmt.Errorf("failed to decode drift history for %s: %w", s.specName, err)
+	}
+	return events, nil
+}
+
+// summarizeEvent drops the bulky parts of an event before it is persisted
+func summarizeEvent(event DriftEvent) DriftEvent {
+	event.Expected = nil
+	event.Actual = nil
+	event.Diff = nil
+	if event.Remediation != nil {
+		remediation := *event.Remediation
+		remediation.Patches = nil
+		remediation.Manifest = ""
+		remediation.Diff = ""
+		event.Remediation = &remediation
+	}
+	return event
+}
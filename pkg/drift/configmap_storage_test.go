@@ -0,0 +1,143 @@
+package drift
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestConfigMapStorage_StoreAndGetHistory(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	storage := NewConfigMapStorage(client, "kspec-system", "prod", 0)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	report := &DriftReport{
+		Events: []DriftEvent{
+			{
+				Timestamp: now.Add(-2 * time.Hour),
+				Type:      DriftTypePolicy,
+				Severity:  SeverityHigh,
+				Resource:  DriftResource{Kind: "ClusterPolicy", Name: "require-run-as-non-root"},
+				DriftKind: "deleted",
+				Expected:  map[string]interface{}{"spec": "large"},
+				Remediation: &RemediationResult{
+					Status:  DriftStatusRemediated,
+					Patches: []WorkloadPatch{{}},
+				},
+			},
+			{
+				Timestamp: now.Add(-30 * time.Minute),
+				Type:      DriftTypeCompliance,
+				Severity:  SeverityMedium,
+				Remediation: &RemediationResult{
+					Status: DriftStatusFailed,
+				},
+			},
+		},
+	}
+
+	if err := storage.StoreReport(context.Background(), report); err != nil {
+		t.Fatalf("StoreReport failed: %v", err)
+	}
+	if err := storage.Store(DriftEvent{Timestamp: now, Type: DriftTypePolicy, Severity: SeverityLow}); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	history, err := storage.GetHistory(time.Time{})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if history.Stats.TotalEvents != 3 {
+		t.Fatalf("Expected 3 events, got %d", history.Stats.TotalEvents)
+	}
+	if !history.Stats.FirstEvent.Equal(now.Add(-2*time.Hour)) || !history.Stats.LastEvent.Equal(now) {
+		t.Errorf("Unexpected event range %s - %s", history.Stats.FirstEvent, history.Stats.LastEvent)
+	}
+	if rate := history.Stats.RemediationSuccessRate; rate < 0.33 || rate > 0.34 {
+		t.Errorf("Expected remediation success rate of 1/3, got %f", rate)
+	}
+
+	first := history.Events[0]
+	if first.Expected != nil || first.Remediation.Patches != nil {
+		t.Errorf("Expected bulky event fields to be dropped, got %+v", first)
+	}
+	if first.Resource.Name != "require-run-as-non-root" {
+		t.Errorf("Expected resource to be kept, got %+v", first.Resource)
+	}
+
+	recent, err := storage.GetHistory(now.Add(-1 * time.Hour))
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(recent.Events) != 2 {
+		t.Errorf("Expected 2 events in the last hour, got %d", len(recent.Events))
+	}
+}
+
+func TestConfigMapStorage_Retention(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	storage := NewConfigMapStorage(client, "kspec-system", "prod", 3)
+
+	start := time.Now().UTC().Truncate(time.Second)
+	for i := 0; i < 5; i++ {
+		event := DriftEvent{Timestamp: start.Add(time.Duration(i) * time.Minute), Type: DriftTypePolicy}
+		if err := storage.Store(event); err != nil {
+			t.Fatalf("Store failed: %v", err)
+		}
+	}
+
+	history, err := storage.GetHistory(time.Time{})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(history.Events) != 3 {
+		t.Fatalf("Expected retention to keep 3 events, got %d", len(history.Events))
+	}
+	if !history.Stats.FirstEvent.Equal(start.Add(2 * time.Minute)) {
+		t.Errorf("Expected the oldest events to be dropped, first event is %s", history.Stats.FirstEvent)
+	}
+}
+
+func TestConfigMapStorage_SpecsAreIsolated(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	prod := NewConfigMapStorage(client, "kspec-system", "prod", 0)
+	staging := NewConfigMapStorage(client, "kspec-system", "staging", 0)
+
+	if err := prod.Store(DriftEvent{Timestamp: time.Now(), Type: DriftTypePolicy}); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if err := staging.Store(DriftEvent{Timestamp: time.Now(), Type: DriftTypeCompliance}); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	configMap, err := client.CoreV1().ConfigMaps("kspec-system").Get(context.Background(), HistoryConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected drift history ConfigMap: %v", err)
+	}
+	if len(configMap.Data) != 2 {
+		t.Errorf("Expected one key per spec, got %v", configMap.Data)
+	}
+
+	if err := staging.Clear(); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+
+	history, err := prod.GetHistory(time.Time{})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(history.Events) != 1 {
+		t.Errorf("Expected clearing staging to keep prod history, got %d events", len(history.Events))
+	}
+
+	history, err = staging.GetHistory(time.Time{})
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(history.Events) != 0 {
+		t.Errorf("Expected staging history to be cleared, got %d events", len(history.Events))
+	}
+}
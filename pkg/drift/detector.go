@@ -2,6 +2,7 @@ package drift
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"time"
@@ -10,14 +11,23 @@ import (
 	"github.com/cloudcwfranck/kspec/pkg/scanner"
 	"github.com/cloudcwfranck/kspec/pkg/scanner/checks"
 	"github.com/cloudcwfranck/kspec/pkg/spec"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
+// kyvernoGroupVersion is the API group version serving Kyverno ClusterPolicies.
+const kyvernoGroupVersion = "kyverno.io/v1"
+
+// ErrKyvernoNotInstalled is returned by DetectPolicyDrift when the Kyverno
+// ClusterPolicy CRD is not installed in the cluster.
+var ErrKyvernoNotInstalled = errors.New("Kyverno not installed, policy drift not evaluated")
+
 // Detector detects drift between desired state and actual state.
 type Detector struct {
 	client        kubernetes.Interface
@@ -26,25 +36,45 @@ type Detector struct {
 	scanner       *scanner.Scanner
 }
 
-// NewDetector creates a new drift detector.
+// NewDetector creates a new drift detector. Compliance drift runs the same
+// checks as kspec scan.
 func NewDetector(client kubernetes.Interface, dynamicClient dynamic.Interface) *Detector {
-	// Create scanner with all checks
-	checkList := []scanner.Check{
-		&checks.KubernetesVersionCheck{},
-		&checks.PodSecurityStandardsCheck{},
-		&checks.NetworkPolicyCheck{},
-		&checks.WorkloadSecurityCheck{},
-		&checks.RBACCheck{},
-		&checks.AdmissionCheck{},
-		&checks.ObservabilityCheck{},
-	}
-
 	return &Detector{
 		client:        client,
 		dynamicClient: dynamicClient,
 		enforcer:      enforcer.NewEnforcer(client, dynamicClient),
-		scanner:       scanner.NewScanner(client, checkList),
+		scanner:       scanner.NewScanner(client, checks.All(dynamicClient, "")),
+	}
+}
+
+// NewDetectorFromConfig creates a new drift detector whose typed and dynamic
+// clients are built from the given REST config.
+func NewDetectorFromConfig(config *rest.Config) (*Detector, error) {
+	client, dynamicClient, err := clientsForConfig(config)
+	if err != nil {
+		return nil, err
 	}
+	return NewDetector(client, dynamicClient), nil
+}
+
+// clientsForConfig builds the typed and dynamic clients used by the drift
+// components from a REST config.
+func clientsForConfig(config *rest.Config) (kubernetes.Interface, dynamic.Interface, error) {
+	if config == nil {
+		return nil, nil, fmt.Errorf("rest config cannot be nil")
+	}
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	return client, dynamicClient, nil
 }
 
 // Detect detects all configured drift types.
@@ -66,10 +96,17 @@ func (d *Detector) Detect(ctx context.Context, clusterSpec *spec.ClusterSpecific
 	// Detect policy drift if enabled
 	if d.isTypeEnabled(DriftTypePolicy, opts.EnabledTypes) {
 		policyEvents, err := d.DetectPolicyDrift(ctx, clusterSpec)
-		if err != nil {
+		if errors.Is(err, ErrKyvernoNotInstalled) {
+			// Clusters using other enforcement backends have no policies to compare
+			report.Skipped = append(report.Skipped, SkippedDrift{
+				Type:   DriftTypePolicy,
+				Reason: err.Error(),
+			})
+		} else if err != nil {
 			return nil, fmt.Errorf("failed to detect policy drift: %w", err)
+		} else {
+			report.Events = append(report.Events, policyEvents...)
 		}
-		report.Events = append(report.Events, policyEvents...)
 	}
 
 	// Detect compliance drift if enabled
@@ -81,16 +118,34 @@ func (d *Detector) Detect(ctx context.Context, clusterSpec *spec.ClusterSpecific
 		report.Events = append(report.Events, complianceEvents...)
 	}
 
+	// Detect RBAC drift if enabled
+	if d.isTypeEnabled(DriftTypeRBAC, opts.EnabledTypes) {
+		rbacEvents, err := d.DetectRBACDrift(ctx, clusterSpec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect RBAC drift: %w", err)
+		}
+		report.Events = append(report.Events, rbacEvents...)
+	}
+
 	// Update summary
 	d.updateSummary(report)
 
 	return report, nil
 }
 
-// DetectPolicyDrift detects drift in Kyverno policies.
+// DetectPolicyDrift detects drift in Kyverno policies. It returns ErrKyvernoNotInstalled
+// if the cluster does not serve the Kyverno ClusterPolicy CRD.
 func (d *Detector) DetectPolicyDrift(ctx context.Context, clusterSpec *spec.ClusterSpecification) ([]DriftEvent, error) {
 	events := []DriftEvent{}
 
+	installed, err := d.isKyvernoCRDInstalled()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover Kyverno CRDs: %w", err)
+	}
+	if !installed {
+		return nil, ErrKyvernoNotInstalled
+	}
+
 	// Generate expected policies from spec
 	result, err := d.enforcer.Enforce(ctx, clusterSpec, enforcer.EnforceOptions{
 		DryRun:      true,
@@ -220,6 +275,24 @@ func (d *Detector) DetectComplianceDrift(ctx context.Context, clusterSpec *spec.
 	return events, nil
 }
 
+// isKyvernoCRDInstalled uses discovery to check whether the cluster serves Kyverno ClusterPolicies.
+func (d *Detector) isKyvernoCRDInstalled() (bool, error) {
+	resources, err := d.client.Discovery().ServerResourcesForGroupVersion(kyvernoGroupVersion)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Name == "clusterpolicies" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // getClusterPolicies retrieves all ClusterPolicies from the cluster.
 func (d *Detector) getClusterPolicies(ctx context.Context) ([]runtime.Object, error) {
 	gvr := schema.GroupVersionResource{
@@ -267,8 +340,8 @@ func (d *Detector) comparePolicies(expected, actual runtime.Object) *DriftDiff {
 	}
 
 	// Remove metadata fields that change (resourceVersion, generation, etc.)
-	d.removeVolatileFields(expectedUnstructured)
-	d.removeVolatileFields(actualUnstructured)
+	removeVolatileFields(expectedUnstructured)
+	removeVolatileFields(actualUnstructured)
 
 	// Get spec from both
 	expectedSpec, expectedHasSpec := expectedUnstructured["spec"]
@@ -301,7 +374,7 @@ func (d *Detector) comparePolicies(expected, actual runtime.Object) *DriftDiff {
 }
 
 // removeVolatileFields removes fields that change frequently and aren't drift.
-func (d *Detector) removeVolatileFields(obj map[string]interface{}) {
+func removeVolatileFields(obj map[string]interface{}) {
 	// Remove volatile metadata fields
 	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
 		delete(metadata, "resourceVersion")
@@ -372,6 +445,8 @@ func (d *Detector) updateSummary(report *DriftReport) {
 			report.Drift.Counts.Compliance++
 		case DriftTypeConfiguration:
 			report.Drift.Counts.Configuration++
+		case DriftTypeRBAC:
+			report.Drift.Counts.RBAC++
 		}
 
 		// Track unique types
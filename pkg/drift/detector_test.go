@@ -2,12 +2,14 @@ package drift
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/cloudcwfranck/kspec/pkg/spec"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
 )
 
 func TestDetectPolicyDrift_MissingPolicy(t *testing.T) {
@@ -249,6 +251,56 @@ func TestDetectPolicyDrift_NoDrift(t *testing.T) {
 	}
 }
 
+func TestDetect_KyvernoNotInstalled(t *testing.T) {
+	ctx := context.Background()
+
+	// Create fake clients without the Kyverno CRD in discovery
+	client, dynamicClient := createTestClientsWithoutKyverno()
+
+	detector := NewDetector(client, dynamicClient)
+
+	clusterSpec := &spec.ClusterSpecification{
+		Metadata: spec.Metadata{
+			Name:    "test-spec",
+			Version: "1.0.0",
+		},
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{
+				Containers: &spec.ContainerSpec{
+					Required: []spec.FieldRequirement{
+						{Key: "securityContext.runAsNonRoot", Value: "true"},
+					},
+				},
+			},
+		},
+	}
+
+	if _, err := detector.DetectPolicyDrift(ctx, clusterSpec); !errors.Is(err, ErrKyvernoNotInstalled) {
+		t.Fatalf("Expected ErrKyvernoNotInstalled, got %v", err)
+	}
+
+	// The full detect run must still succeed and record the skipped drift type
+	report, err := detector.Detect(ctx, clusterSpec, DetectOptions{
+		EnabledTypes: []DriftType{DriftTypePolicy, DriftTypeCompliance},
+	})
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+
+	if len(report.Skipped) != 1 {
+		t.Fatalf("Expected 1 skipped drift type, got %d", len(report.Skipped))
+	}
+	if report.Skipped[0].Type != DriftTypePolicy {
+		t.Errorf("Expected skipped type 'policy', got '%s'", report.Skipped[0].Type)
+	}
+	if report.Skipped[0].Reason != "Kyverno not installed, policy drift not evaluated" {
+		t.Errorf("Unexpected skip reason: %s", report.Skipped[0].Reason)
+	}
+	if report.Drift.Counts.Policies != 0 {
+		t.Errorf("Expected no policy drift events, got %d", report.Drift.Counts.Policies)
+	}
+}
+
 func TestDetect_IntegrationTest(t *testing.T) {
 	ctx := context.Background()
 
@@ -401,3 +453,150 @@ func TestUpdateSummary(t *testing.T) {
 		t.Errorf("Expected 2 unique drift types, got %d", len(report.Drift.Types))
 	}
 }
+
+func TestParseDriftTypes(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []string
+		expected  []DriftType
+		expectErr bool
+	}{
+		{
+			name:     "empty enables all types",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:     "single type",
+			input:    []string{"policy"},
+			expected: []DriftType{DriftTypePolicy},
+		},
+		{
+			name:     "multiple types with whitespace",
+			input:    []string{"policy", " compliance"},
+			expected: []DriftType{DriftTypePolicy, DriftTypeCompliance},
+		},
+		{
+			name:     "rbac type",
+			input:    []string{"rbac"},
+			expected: []DriftType{DriftTypeRBAC},
+		},
+		{
+			name:      "unknown type",
+			input:     []string{"policy", "bogus"},
+			expectErr: true,
+		},
+		{
+			name:      "netpol needs a baseline",
+			input:     []string{"policy", "netpol"},
+			expectErr: true,
+		},
+		{
+			name:      "psa needs a baseline",
+			input:     []string{"psa"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseDriftTypes(tt.input)
+			if tt.expectErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(result) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, result)
+			}
+			for i := range tt.expected {
+				if result[i] != tt.expected[i] {
+					t.Errorf("Expected %v, got %v", tt.expected, result)
+				}
+			}
+		})
+	}
+}
+
+func TestParseBaselineDriftTypes(t *testing.T) {
+	types, err := ParseBaselineDriftTypes([]string{"rbac", "configuration"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(types) != 2 || types[0] != DriftTypeRBAC || types[1] != DriftTypeConfiguration {
+		t.Errorf("Expected [rbac configuration], got %v", types)
+	}
+
+	if _, err := ParseBaselineDriftTypes([]string{"compliance"}); err == nil {
+		t.Error("Expected compliance to be rejected for baseline drift")
+	}
+
+	types, err = ParseBaselineDriftTypes([]string{"policy", "netpol", "psa"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(types) != 2 || types[0] != DriftTypePolicy || types[1] != DriftTypeConfiguration {
+		t.Errorf("Expected netpol and psa to map to configuration, got %v", types)
+	}
+}
+
+func TestDetect_EnabledTypes(t *testing.T) {
+	ctx := context.Background()
+	client, dynamicClient := createTestClients()
+	detector := NewDetector(client, dynamicClient)
+
+	clusterSpec := &spec.ClusterSpecification{
+		Metadata: spec.Metadata{
+			Name:    "test-spec",
+			Version: "1.0.0",
+		},
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{
+				Containers: &spec.ContainerSpec{
+					Required: []spec.FieldRequirement{
+						{
+							Key:   "securityContext.runAsNonRoot",
+							Value: "true",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	report, err := detector.Detect(ctx, clusterSpec, DetectOptions{
+		EnabledTypes: []DriftType{DriftTypeCompliance},
+	})
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+
+	for _, event := range report.Events {
+		if event.Type == DriftTypePolicy {
+			t.Errorf("Expected no policy drift events when only compliance is enabled, got %s", event.Resource.Path)
+		}
+	}
+}
+
+func TestNewDetectorFromConfig(t *testing.T) {
+	detector, err := NewDetectorFromConfig(&rest.Config{Host: "https://127.0.0.1:6443"})
+	if err != nil {
+		t.Fatalf("NewDetectorFromConfig failed: %v", err)
+	}
+	if detector.client == nil || detector.dynamicClient == nil || detector.scanner == nil || detector.enforcer == nil {
+		t.Error("Expected detector to be fully wired from the REST config")
+	}
+
+	if _, err := NewDetectorFromConfig(nil); err == nil {
+		t.Error("Expected error for nil REST config")
+	}
+
+	// A QPS without a burst is rejected when the clients are built
+	if _, err := NewDetectorFromConfig(&rest.Config{Host: "https://127.0.0.1:6443", QPS: 5}); err == nil {
+		t.Error("Expected error for invalid REST config")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/cloudcwfranck/kspec/pkg/spec"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 // Monitor continuously monitors for drift.
@@ -38,13 +39,30 @@ func NewMonitor(client kubernetes.Interface, dynamicClient dynamic.Interface, co
 	}, nil
 }
 
+// NewMonitorFromConfig creates a new drift monitor whose typed and dynamic
+// clients are built from the given REST config.
+func NewMonitorFromConfig(restConfig *rest.Config, config *MonitorConfig) (*Monitor, error) {
+	client, dynamicClient, err := clientsForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return NewMonitor(client, dynamicClient, config)
+}
+
 // Start starts continuous monitoring.
 func (m *Monitor) Start(ctx context.Context, clusterSpec *spec.ClusterSpecification) error {
+	return m.StartWithSource(ctx, spec.StaticSource(clusterSpec))
+}
+
+// StartWithSource starts continuous monitoring of the specification provided by
+// source. Each check uses the source's current spec, and a spec change triggers
+// an immediate check.
+func (m *Monitor) StartWithSource(ctx context.Context, source spec.Source) error {
 	ticker := time.NewTicker(m.config.Interval)
 	defer ticker.Stop()
 
 	// Run initial check immediately
-	if err := m.checkOnce(ctx, clusterSpec); err != nil {
+	if err := m.checkOnce(ctx, source.Current()); err != nil {
 		fmt.Printf("[WARN] Initial drift check failed: %v\n", err)
 	}
 
@@ -54,7 +72,11 @@ func (m *Monitor) Start(ctx context.Context, clusterSpec *spec.ClusterSpecificat
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-ticker.C:
-			if err := m.checkOnce(ctx, clusterSpec); err != nil {
+			if err := m.checkOnce(ctx, source.Current()); err != nil {
+				fmt.Printf("[ERROR] Drift check failed: %v\n", err)
+			}
+		case <-source.Changes():
+			if err := m.checkOnce(ctx, source.Current()); err != nil {
 				fmt.Printf("[ERROR] Drift check failed: %v\n", err)
 			}
 		}
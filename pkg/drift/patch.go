@@ -0,0 +1,240 @@
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// workloadSecurityCheck is the compliance check whose drift can be fixed by
+// patching workload pod templates.
+const workloadSecurityCheck = "workload.security"
+
+// PatchTypeStrategicMerge identifies a strategic merge patch.
+const PatchTypeStrategicMerge = "strategic"
+
+// patchSkippedNamespaces are never patched, matching the namespaces the
+// workload security check ignores.
+var patchSkippedNamespaces = map[string]bool{
+	"kube-system":     true,
+	"kube-public":     true,
+	"kube-node-lease": true,
+}
+
+// WorkloadPatch is a ready-to-apply patch bringing a workload into compliance.
+type WorkloadPatch struct {
+	// Kind of workload (Deployment, StatefulSet or DaemonSet)
+	Kind string `json:"kind"`
+
+	// Namespace of the workload
+	Namespace string `json:"namespace"`
+
+	// Name of the workload
+	Name string `json:"name"`
+
+	// Type of patch, e.g. "strategic"
+	Type string `json:"type"`
+
+	// Patch is the patch document
+	Patch string `json:"patch"`
+
+	// Applied is true when the patch was applied to the cluster
+	Applied bool `json:"applied,omitempty"`
+
+	// Error message if applying the patch failed
+	Error string `json:"error,omitempty"`
+}
+
+// Command returns the kubectl command that applies the patch.
+func (p WorkloadPatch) Command() string {
+	return fmt.Sprintf("kubectl patch %s %s -n %s --type %s -p '%s'",
+		strings.ToLower(p.Kind), p.Name, p.Namespace, p.Type, p.Patch)
+}
+
+// BuildWorkloadPatches lists Deployments, StatefulSets and DaemonSets and returns
+// a strategic merge patch for each one whose pod template violates the spec's
+// container securityContext requirements. Compliant workloads get no patch.
+func BuildWorkloadPatches(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) ([]WorkloadPatch, error) {
+	workloads := clusterSpec.Spec.Workloads
+	if workloads == nil || workloads.Containers == nil {
+		return nil, nil
+	}
+
+	deployments, err := client.AppsV1().Deployments("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	statefulSets, err := client.AppsV1().StatefulSets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+
+	daemonSets, err := client.AppsV1().DaemonSets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+
+	patches := []WorkloadPatch{}
+	addPatch := func(kind, namespace, name string, template *corev1.PodTemplateSpec) error {
+		if patchSkippedNamespaces[namespace] {
+			return nil
+		}
+		patch, err := SecurityContextPatch(template, workloads)
+		if err != nil {
+			return fmt.Errorf("failed to build patch for %s %s/%s: %w", kind, namespace, name, err)
+		}
+		if patch == nil {
+			return nil
+		}
+		patches = append(patches, WorkloadPatch{
+			Kind:      kind,
+			Namespace: namespace,
+			Name:      name,
+			Type:      PatchTypeStrategicMerge,
+			Patch:     string(patch),
+		})
+		return nil
+	}
+
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if err := addPatch("Deployment", deployment.Namespace, deployment.Name, &deployment.Spec.Template); err != nil {
+			return nil, err
+		}
+	}
+	for i := range statefulSets.Items {
+		statefulSet := &statefulSets.Items[i]
+		if err := addPatch("StatefulSet", statefulSet.Namespace, statefulSet.Name, &statefulSet.Spec.Template); err != nil {
+			return nil, err
+		}
+	}
+	for i := range daemonSets.Items {
+		daemonSet := &daemonSets.Items[i]
+		if err := addPatch("DaemonSet", daemonSet.Namespace, daemonSet.Name, &daemonSet.Spec.Template); err != nil {
+			return nil, err
+		}
+	}
+
+	sortWorkloadPatches(patches)
+	return patches, nil
+}
+
+// SecurityContextPatch returns a strategic merge patch for a pod template that
+// sets the container securityContext fields the spec requires, or nil if the
+// template already complies. Containers are matched by name, so the patch only
+// touches the fields it sets; excluded containers are left alone.
+func SecurityContextPatch(template *corev1.PodTemplateSpec, workloads *spec.WorkloadsSpec) ([]byte, error) {
+	if workloads == nil || workloads.Containers == nil {
+		return nil, nil
+	}
+
+	podSpec := map[string]interface{}{}
+	if containers := containerPatches(template.Spec.Containers, template.Spec.SecurityContext, workloads); len(containers) > 0 {
+		podSpec["containers"] = containers
+	}
+	if initContainers := containerPatches(template.Spec.InitContainers, template.Spec.SecurityContext, workloads); len(initContainers) > 0 {
+		podSpec["initContainers"] = initContainers
+	}
+	if len(podSpec) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": podSpec,
+			},
+		},
+	})
+}
+
+// containerPatches returns the per-container patch entries for containers
+// missing required securityContext settings.
+func containerPatches(containers []corev1.Container, podSecurityContext *corev1.PodSecurityContext, workloads *spec.WorkloadsSpec) []interface{} {
+	patches := []interface{}{}
+	for i := range containers {
+		container := &containers[i]
+		if workloads.IsContainerExcluded(container.Name) {
+			continue
+		}
+
+		securityContext := containerSecurityContextFixes(container, podSecurityContext, workloads.Containers)
+		if len(securityContext) == 0 {
+			continue
+		}
+		patches = append(patches, map[string]interface{}{
+			"name":            container.Name,
+			"securityContext": securityContext,
+		})
+	}
+	return patches
+}
+
+// containerSecurityContextFixes returns the securityContext fields a container
+// must set to satisfy the spec, using the same rules as the workload security check.
+func containerSecurityContextFixes(container *corev1.Container, podSecurityContext *corev1.PodSecurityContext, containers *spec.ContainerSpec) map[string]interface{} {
+	sc := container.SecurityContext
+	fixes := map[string]interface{}{}
+
+	for _, req := range containers.Required {
+		switch req.Key {
+		case "securityContext.runAsNonRoot":
+			containerOK := sc != nil && sc.RunAsNonRoot != nil && *sc.RunAsNonRoot
+			podOK := podSecurityContext != nil && podSecurityContext.RunAsNonRoot != nil && *podSecurityContext.RunAsNonRoot
+			if !containerOK && !podOK {
+				fixes["runAsNonRoot"] = true
+			}
+		case "securityContext.allowPrivilegeEscalation":
+			if req.Value == "false" && (sc == nil || sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation) {
+				fixes["allowPrivilegeEscalation"] = false
+			}
+		}
+	}
+
+	for _, forbidden := range containers.Forbidden {
+		if forbidden.Key == "securityContext.privileged" && sc != nil && sc.Privileged != nil && *sc.Privileged {
+			fixes["privileged"] = false
+		}
+	}
+
+	return fixes
+}
+
+// applyWorkloadPatch applies a strategic merge patch to its workload.
+func applyWorkloadPatch(ctx context.Context, client kubernetes.Interface, patch WorkloadPatch) error {
+	data := []byte(patch.Patch)
+	var err error
+	switch patch.Kind {
+	case "Deployment":
+		_, err = client.AppsV1().Deployments(patch.Namespace).Patch(ctx, patch.Name, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = client.AppsV1().StatefulSets(patch.Namespace).Patch(ctx, patch.Name, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+	case "DaemonSet":
+		_, err = client.AppsV1().DaemonSets(patch.Namespace).Patch(ctx, patch.Name, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+	default:
+		return fmt.Errorf("patching not supported for kind %s", patch.Kind)
+	}
+	return err
+}
+
+// sortWorkloadPatches orders patches by kind, namespace and name.
+func sortWorkloadPatches(patches []WorkloadPatch) {
+	sort.Slice(patches, func(i, j int) bool {
+		if patches[i].Kind != patches[j].Kind {
+			return patches[i].Kind < patches[j].Kind
+		}
+		if patches[i].Namespace != patches[j].Namespace {
+			return patches[i].Namespace < patches[j].Namespace
+		}
+		return patches[i].Name < patches[j].Name
+	})
+}
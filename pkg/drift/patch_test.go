@@ -0,0 +1,141 @@
+package drift
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func runAsNonRootWorkloads() *spec.WorkloadsSpec {
+	return &spec.WorkloadsSpec{
+		Containers: &spec.ContainerSpec{
+			Required: []spec.FieldRequirement{
+				{Key: "securityContext.runAsNonRoot", Value: "true"},
+			},
+		},
+	}
+}
+
+func testDeployment(namespace, name string, podSecurityContext *corev1.PodSecurityContext) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					SecurityContext: podSecurityContext,
+					Containers: []corev1.Container{
+						{Name: "app", Image: "nginx"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSecurityContextPatch_MissingRunAsNonRoot(t *testing.T) {
+	deployment := testDeployment("apps", "web", nil)
+
+	patch, err := SecurityContextPatch(&deployment.Spec.Template, runAsNonRootWorkloads())
+	if err != nil {
+		t.Fatalf("SecurityContextPatch failed: %v", err)
+	}
+
+	expected := `{"spec":{"template":{"spec":{"containers":[{"name":"app","securityContext":{"runAsNonRoot":true}}]}}}}`
+	if string(patch) != expected {
+		t.Errorf("Expected patch %s, got: %s", expected, patch)
+	}
+}
+
+func TestSecurityContextPatch_Compliant(t *testing.T) {
+	runAsNonRoot := true
+	deployment := testDeployment("apps", "web", &corev1.PodSecurityContext{RunAsNonRoot: &runAsNonRoot})
+
+	patch, err := SecurityContextPatch(&deployment.Spec.Template, runAsNonRootWorkloads())
+	if err != nil {
+		t.Fatalf("SecurityContextPatch failed: %v", err)
+	}
+	if patch != nil {
+		t.Errorf("Expected no patch for a compliant pod template, got: %s", patch)
+	}
+}
+
+func TestRemediate_WorkloadPatches(t *testing.T) {
+	ctx := context.Background()
+
+	_, dynamicClient := createTestClients()
+	client := fake.NewSimpleClientset(
+		testDeployment("apps", "web", nil),
+		testDeployment("kube-system", "coredns", nil),
+	)
+
+	clusterSpec := &spec.ClusterSpecification{
+		Metadata: spec.Metadata{Name: "test-spec", Version: "1.0.0"},
+		Spec:     spec.SpecFields{Workloads: runAsNonRootWorkloads()},
+	}
+
+	newReport := func() *DriftReport {
+		return &DriftReport{
+			Events: []DriftEvent{
+				{
+					Type:      DriftTypeCompliance,
+					DriftKind: "violation",
+					Resource: DriftResource{
+						Kind: "ComplianceCheck",
+						Name: workloadSecurityCheck,
+					},
+				},
+			},
+		}
+	}
+
+	remediator := NewRemediator(client, dynamicClient)
+
+	// Without --apply-patches the patch is only reported
+	report := newReport()
+	if err := remediator.Remediate(ctx, clusterSpec, report, RemediateOptions{
+		Types: []DriftType{DriftTypeCompliance},
+	}); err != nil {
+		t.Fatalf("Remediate failed: %v", err)
+	}
+
+	result := report.Events[0].Remediation
+	if result == nil || result.Status != DriftStatusManualRequired {
+		t.Fatalf("Expected manual-required remediation, got: %+v", result)
+	}
+	if len(result.Patches) != 1 {
+		t.Fatalf("Expected 1 patch (system namespaces skipped), got: %d", len(result.Patches))
+	}
+	if patch := result.Patches[0]; patch.Kind != "Deployment" || patch.Namespace != "apps" || patch.Name != "web" || patch.Applied {
+		t.Errorf("Unexpected patch: %+v", patch)
+	}
+
+	// With --apply-patches the workload is patched
+	report = newReport()
+	if err := remediator.Remediate(ctx, clusterSpec, report, RemediateOptions{
+		Types:        []DriftType{DriftTypeCompliance},
+		ApplyPatches: true,
+	}); err != nil {
+		t.Fatalf("Remediate with ApplyPatches failed: %v", err)
+	}
+
+	if status := report.Events[0].Remediation.Status; status != DriftStatusRemediated {
+		t.Errorf("Expected status remediated, got: %s", status)
+	}
+
+	deployment, err := client.AppsV1().Deployments("apps").Get(ctx, "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get deployment: %v", err)
+	}
+	sc := deployment.Spec.Template.Spec.Containers[0].SecurityContext
+	if sc == nil || sc.RunAsNonRoot == nil || !*sc.RunAsNonRoot {
+		t.Errorf("Expected runAsNonRoot=true after patching, got: %+v", sc)
+	}
+	if image := deployment.Spec.Template.Spec.Containers[0].Image; image != "nginx" {
+		t.Errorf("Expected patch to preserve the container image, got: %s", image)
+	}
+}
@@ -0,0 +1,52 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// previewManifest renders a resource as YAML without server-populated fields
+// and Kyverno defaults, so live and desired resources compare cleanly.
+func previewManifest(obj map[string]interface{}) (string, error) {
+	// Round-trip through JSON to copy the object with JSON-typed values
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	preview := map[string]interface{}{}
+	if err := json.Unmarshal(data, &preview); err != nil {
+		return "", fmt.Errorf("failed to copy manifest: %w", err)
+	}
+	removeVolatileFields(preview)
+
+	manifest, err := yaml.Marshal(preview)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	return string(manifest), nil
+}
+
+// previewDiff returns the unified diff from the live to the desired manifest
+// of the resource at path.
+func previewDiff(path string, live, desired *unstructured.Unstructured) (string, error) {
+	liveManifest, err := previewManifest(live.Object)
+	if err != nil {
+		return "", err
+	}
+	desiredManifest, err := previewManifest(desired.Object)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(liveManifest),
+		B:        difflib.SplitLines(desiredManifest),
+		FromFile: "live/" + path,
+		ToFile:   "desired/" + path,
+		Context:  3,
+	})
+}
@@ -0,0 +1,108 @@
+package drift
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/enforcer/kyverno"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func previewPolicy(rule string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "kyverno.io/v1",
+			"kind":       "ClusterPolicy",
+			"metadata": map[string]interface{}{
+				"name": "test-policy",
+			},
+			"spec": map[string]interface{}{
+				"rules": []interface{}{
+					map[string]interface{}{"name": rule},
+				},
+			},
+		},
+	}
+}
+
+func TestRemediate_DryRunDiffsModifiedPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	live := previewPolicy("old-rule")
+	live.SetResourceVersion("123")
+	client, dynamicClient := createTestClients(live)
+	remediator := NewRemediator(client, dynamicClient)
+
+	report := &DriftReport{
+		Events: []DriftEvent{
+			{
+				Type:      DriftTypePolicy,
+				DriftKind: "modified",
+				Resource:  DriftResource{Kind: "ClusterPolicy", Name: "test-policy"},
+				Expected:  previewPolicy("new-rule"),
+			},
+		},
+	}
+
+	if err := remediator.Remediate(ctx, &spec.ClusterSpecification{}, report, RemediateOptions{DryRun: true}); err != nil {
+		t.Fatalf("Remediate in dry-run mode failed: %v", err)
+	}
+
+	remediation := report.Events[0].Remediation
+	if remediation == nil || remediation.Status != DriftStatusDetected {
+		t.Fatalf("Expected a planned update, got %+v", remediation)
+	}
+	for _, want := range []string{
+		"--- live/ClusterPolicy/test-policy",
+		"+++ desired/ClusterPolicy/test-policy",
+		"-  - name: old-rule",
+		"+  - name: new-rule",
+	} {
+		if !strings.Contains(remediation.Diff, want) {
+			t.Errorf("Expected diff to contain %q, got:\n%s", want, remediation.Diff)
+		}
+	}
+	if strings.Contains(remediation.Diff, "resourceVersion") {
+		t.Errorf("Expected server-populated fields to be left out of the diff, got:\n%s", remediation.Diff)
+	}
+
+	existing, err := dynamicClient.Resource(kyverno.ClusterPolicyGVR()).Get(ctx, "test-policy", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get policy: %v", err)
+	}
+	rules, _, _ := unstructured.NestedSlice(existing.Object, "spec", "rules")
+	if len(rules) != 1 || rules[0].(map[string]interface{})["name"] != "old-rule" {
+		t.Errorf("Expected dry-run to leave the live policy unchanged, got %v", rules)
+	}
+}
+
+func TestRemediate_DryRunShowsMissingPolicyManifest(t *testing.T) {
+	ctx := context.Background()
+	client, dynamicClient := createTestClients()
+	remediator := NewRemediator(client, dynamicClient)
+
+	report := &DriftReport{
+		Events: []DriftEvent{
+			{
+				Type:      DriftTypePolicy,
+				DriftKind: "missing",
+				Resource:  DriftResource{Kind: "ClusterPolicy", Name: "test-policy"},
+				Expected:  previewPolicy("new-rule"),
+			},
+		},
+	}
+
+	if err := remediator.Remediate(ctx, &spec.ClusterSpecification{}, report, RemediateOptions{DryRun: true}); err != nil {
+		t.Fatalf("Remediate in dry-run mode failed: %v", err)
+	}
+
+	manifest := report.Events[0].Remediation.Manifest
+	for _, want := range []string{"kind: ClusterPolicy", "name: test-policy", "- name: new-rule"} {
+		if !strings.Contains(manifest, want) {
+			t.Errorf("Expected manifest to contain %q, got:\n%s", want, manifest)
+		}
+	}
+}
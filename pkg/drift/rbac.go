@@ -0,0 +1,373 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// minimumRulesRole is the ClusterRole kspec creates to hold the spec's minimum
+// RBAC rules that no role in the cluster grants.
+const minimumRulesRole = "kspec-minimum-rules"
+
+// rbacBootstrappingLabel marks the default roles and bindings the API server
+// creates, and reconciles back to their defaults, at startup.
+const rbacBootstrappingLabel = "kubernetes.io/bootstrapping"
+
+// rbacSystemNamespaces are skipped when looking for workload ServiceAccounts
+// bound to cluster-admin-equivalent roles.
+var rbacSystemNamespaces = map[string]bool{
+	"kube-system":     true,
+	"kube-public":     true,
+	"kube-node-lease": true,
+}
+
+// DetectRBACDrift detects RBAC objects that have drifted from the spec's RBAC
+// requirements:
+//   - roles granting a forbidden rule are reported as modified, expecting the
+//     role without that rule
+//   - bindings to such roles, and with forbidWorkloadClusterAdmin bindings
+//     granting cluster-admin-equivalent roles to workload ServiceAccounts, are
+//     reported as extra
+//   - minimum rules no role grants are reported as missing from the
+//     kspec-minimum-rules ClusterRole
+//
+// Roles and bindings managed by Kubernetes are never reported; see
+// isManagedRBACObject.
+func (d *Detector) DetectRBACDrift(ctx context.Context, clusterSpec *spec.ClusterSpecification) ([]DriftEvent, error) {
+	events := []DriftEvent{}
+
+	rbacSpec := clusterSpec.Spec.RBAC
+	if rbacSpec == nil {
+		return events, nil
+	}
+
+	clusterRoles, err := d.client.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster roles: %w", err)
+	}
+	roles, err := d.client.RbacV1().Roles("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	clusterRoleBindings, err := d.client.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster role bindings: %w", err)
+	}
+	roleBindings, err := d.client.RbacV1().RoleBindings("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role bindings: %w", err)
+	}
+
+	// Roles granting forbidden rules, and roles equivalent to cluster-admin
+	forbiddenClusterRoles := map[string]bool{}
+	forbiddenRoles := map[string]bool{}
+	adminClusterRoles := map[string]bool{"cluster-admin": true}
+	adminRoles := map[string]bool{}
+
+	for i := range clusterRoles.Items {
+		role := &clusterRoles.Items[i]
+		if isWildcardRole(role.Rules) {
+			adminClusterRoles[role.Name] = true
+		}
+		if isManagedRBACObject(role.Name, role.Labels, role.AggregationRule != nil) {
+			continue
+		}
+
+		allowed, removed := filterForbiddenRules(role.Rules, rbacSpec.ForbiddenRules)
+		if len(removed) == 0 {
+			continue
+		}
+		forbiddenClusterRoles[role.Name] = true
+
+		expected := role.DeepCopy()
+		expected.Rules = allowed
+		events = append(events, modifiedRoleEvent("ClusterRole", "", role.Name, expected, role, removed))
+	}
+
+	for i := range roles.Items {
+		role := &roles.Items[i]
+		key := role.Namespace + "/" + role.Name
+		if isWildcardRole(role.Rules) {
+			adminRoles[key] = true
+		}
+		if isManagedRBACObject(role.Name, role.Labels, false) {
+			continue
+		}
+
+		allowed, removed := filterForbiddenRules(role.Rules, rbacSpec.ForbiddenRules)
+		if len(removed) == 0 {
+			continue
+		}
+		forbiddenRoles[key] = true
+
+		expected := role.DeepCopy()
+		expected.Rules = allowed
+		events = append(events, modifiedRoleEvent("Role", role.Namespace, role.Name, expected, role, removed))
+	}
+
+	for i := range clusterRoleBindings.Items {
+		binding := &clusterRoleBindings.Items[i]
+		if isManagedRBACObject(binding.Name, binding.Labels, false) || binding.RoleRef.Kind != "ClusterRole" {
+			continue
+		}
+
+		admin := rbacSpec.ForbidWorkloadClusterAdmin && adminClusterRoles[binding.RoleRef.Name] &&
+			bindsWorkloadServiceAccount(binding.Subjects, "")
+		if admin || forbiddenClusterRoles[binding.RoleRef.Name] {
+			events = append(events, extraBindingEvent("ClusterRoleBinding", "", binding.Name, binding, binding.RoleRef, admin))
+		}
+	}
+
+	for i := range roleBindings.Items {
+		binding := &roleBindings.Items[i]
+		if isManagedRBACObject(binding.Name, binding.Labels, false) {
+			continue
+		}
+
+		var admin, forbidden bool
+		switch binding.RoleRef.Kind {
+		case "ClusterRole":
+			admin = adminClusterRoles[binding.RoleRef.Name]
+			forbidden = forbiddenClusterRoles[binding.RoleRef.Name]
+		case "Role":
+			admin = adminRoles[binding.Namespace+"/"+binding.RoleRef.Name]
+			forbidden = forbiddenRoles[binding.Namespace+"/"+binding.RoleRef.Name]
+		}
+		admin = admin && rbacSpec.ForbidWorkloadClusterAdmin && bindsWorkloadServiceAccount(binding.Subjects, binding.Namespace)
+		if admin || forbidden {
+			events = append(events, extraBindingEvent("RoleBinding", binding.Namespace, binding.Name, binding, binding.RoleRef, admin))
+		}
+	}
+
+	if event := missingRulesEvent(clusterRoles.Items, roles.Items, rbacSpec.MinimumRules); event != nil {
+		events = append(events, *event)
+	}
+
+	return events, nil
+}
+
+// isManagedRBACObject checks if an RBAC object is managed by Kubernetes rather
+// than by cluster users: system objects (named "system:..."), the bootstrapped
+// defaults such as cluster-admin and its binding, and aggregated ClusterRoles,
+// whose rules the controller manager rewrites from their aggregation rule.
+// Changes to them would be reverted, or would break the cluster.
+func isManagedRBACObject(name string, labels map[string]string, aggregated bool) bool {
+	return strings.HasPrefix(name, "system:") || labels[rbacBootstrappingLabel] == "rbac-defaults" || aggregated
+}
+
+// modifiedRoleEvent reports a role that grants forbidden rules.
+func modifiedRoleEvent(kind, namespace, name string, expected, actual interface{}, removed []rbacv1.PolicyRule) DriftEvent {
+	return DriftEvent{
+		Timestamp: time.Now(),
+		Type:      DriftTypeRBAC,
+		Severity:  SeverityHigh,
+		Resource:  rbacResource(kind, namespace, name),
+		DriftKind: "modified",
+		Expected:  expected,
+		Actual:    actual,
+		Diff: &DriftDiff{
+			Removed: map[string]interface{}{"rules": removed},
+		},
+		Message: fmt.Sprintf("%s '%s' grants %d forbidden rules", kind, qualifiedName(namespace, name), len(removed)),
+	}
+}
+
+// extraBindingEvent reports a binding that grants a forbidden role, or with
+// admin a cluster-admin-equivalent role to workload ServiceAccounts.
+func extraBindingEvent(kind, namespace, name string, actual interface{}, roleRef rbacv1.RoleRef, admin bool) DriftEvent {
+	event := DriftEvent{
+		Timestamp: time.Now(),
+		Type:      DriftTypeRBAC,
+		Severity:  SeverityHigh,
+		Resource:  rbacResource(kind, namespace, name),
+		DriftKind: "extra",
+		Actual:    actual,
+		Message:   fmt.Sprintf("%s '%s' binds %s '%s', which grants forbidden rules", kind, qualifiedName(namespace, name), roleRef.Kind, roleRef.Name),
+	}
+	if admin {
+		event.Severity = SeverityCritical
+		event.Message = fmt.Sprintf("%s '%s' grants cluster-admin-equivalent %s '%s' to workload ServiceAccounts", kind, qualifiedName(namespace, name), roleRef.Kind, roleRef.Name)
+	}
+	return event
+}
+
+// missingRulesEvent reports the minimum rules no role grants. They are
+// expected in the kspec-minimum-rules ClusterRole, which is reported missing,
+// or modified if it exists without them.
+func missingRulesEvent(clusterRoles []rbacv1.ClusterRole, roles []rbacv1.Role, minimumRules []spec.RBACRule) *DriftEvent {
+	var existing *rbacv1.ClusterRole
+	var granted []rbacv1.PolicyRule
+	for i := range clusterRoles {
+		if clusterRoles[i].Name == minimumRulesRole {
+			existing = &clusterRoles[i]
+		}
+		granted = append(granted, clusterRoles[i].Rules...)
+	}
+	for i := range roles {
+		granted = append(granted, roles[i].Rules...)
+	}
+
+	var missing []rbacv1.PolicyRule
+	for _, required := range minimumRules {
+		if !rulesCover(granted, required) {
+			missing = append(missing, rbacv1.PolicyRule{
+				APIGroups: []string{required.APIGroup},
+				Resources: []string{required.Resource},
+				Verbs:     required.Verbs,
+			})
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	expected := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        minimumRulesRole,
+			Annotations: map[string]string{"kspec.dev/generated": "true"},
+		},
+		Rules: missing,
+	}
+
+	event := &DriftEvent{
+		Timestamp: time.Now(),
+		Type:      DriftTypeRBAC,
+		Severity:  SeverityMedium,
+		Resource:  rbacResource("ClusterRole", "", minimumRulesRole),
+		DriftKind: "missing",
+		Expected:  expected,
+		Diff: &DriftDiff{
+			Added: map[string]interface{}{"rules": missing},
+		},
+		Message: fmt.Sprintf("%d required RBAC rules are not granted by any role", len(missing)),
+	}
+	if existing != nil {
+		expected = existing.DeepCopy()
+		expected.Rules = append(expected.Rules, missing...)
+		event.DriftKind = "modified"
+		event.Expected = expected
+		event.Actual = existing
+	}
+	return event
+}
+
+// rbacResource identifies an RBAC object in a drift event.
+func rbacResource(kind, namespace, name string) DriftResource {
+	return DriftResource{
+		Kind:      kind,
+		Name:      name,
+		Namespace: namespace,
+		Path:      kind + "/" + qualifiedName(namespace, name),
+	}
+}
+
+// qualifiedName returns namespace/name, or name for cluster-scoped objects.
+func qualifiedName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+// filterForbiddenRules splits rules into those allowed by the spec and those
+// matching a forbidden rule.
+func filterForbiddenRules(rules []rbacv1.PolicyRule, forbiddenRules []spec.RBACRule) (allowed, removed []rbacv1.PolicyRule) {
+	for _, rule := range rules {
+		forbidden := false
+		for _, f := range forbiddenRules {
+			if ruleMatchesForbidden(rule, f) {
+				forbidden = true
+				break
+			}
+		}
+		if forbidden {
+			removed = append(removed, rule)
+		} else {
+			allowed = append(allowed, rule)
+		}
+	}
+	return allowed, removed
+}
+
+// ruleMatchesForbidden checks if a rule names a forbidden API group, resource
+// and verb, matching the rbac.validation check.
+func ruleMatchesForbidden(rule rbacv1.PolicyRule, forbidden spec.RBACRule) bool {
+	if !containsValue(rule.APIGroups, forbidden.APIGroup) || !containsValue(rule.Resources, forbidden.Resource) {
+		return false
+	}
+	for _, verb := range forbidden.Verbs {
+		if containsValue(rule.Verbs, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+// rulesCover checks if any rule grants all verbs of a required rule.
+func rulesCover(rules []rbacv1.PolicyRule, required spec.RBACRule) bool {
+	for _, rule := range rules {
+		if !grants(rule.APIGroups, required.APIGroup) || !grants(rule.Resources, required.Resource) {
+			continue
+		}
+		covered := true
+		for _, verb := range required.Verbs {
+			if !grants(rule.Verbs, verb) {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			return true
+		}
+	}
+	return false
+}
+
+// isWildcardRole checks if a role grants all verbs on all resources.
+func isWildcardRole(rules []rbacv1.PolicyRule) bool {
+	for _, rule := range rules {
+		if containsValue(rule.APIGroups, "*") && containsValue(rule.Resources, "*") && containsValue(rule.Verbs, "*") {
+			return true
+		}
+	}
+	return false
+}
+
+// bindsWorkloadServiceAccount checks if a binding's subjects include a
+// ServiceAccount outside the system namespaces.
+func bindsWorkloadServiceAccount(subjects []rbacv1.Subject, bindingNamespace string) bool {
+	for _, subject := range subjects {
+		if subject.Kind != rbacv1.ServiceAccountKind {
+			continue
+		}
+		namespace := subject.Namespace
+		if namespace == "" {
+			namespace = bindingNamespace
+		}
+		if !rbacSystemNamespaces[namespace] {
+			return true
+		}
+	}
+	return false
+}
+
+// grants checks if values contain value or the "*" wildcard.
+func grants(values []string, value string) bool {
+	return containsValue(values, "*") || containsValue(values, value)
+}
+
+// containsValue checks if a slice contains the given string.
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,323 @@
+package drift
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func rbacDriftSpec() *spec.ClusterSpecification {
+	return &spec.ClusterSpecification{
+		Metadata: spec.Metadata{Name: "test-spec", Version: "1.0.0"},
+		Spec: spec.SpecFields{
+			RBAC: &spec.RBACSpec{
+				ForbiddenRules: []spec.RBACRule{
+					{APIGroup: "", Resource: "secrets", Verbs: []string{"delete"}},
+				},
+				MinimumRules: []spec.RBACRule{
+					{APIGroup: "", Resource: "pods", Verbs: []string{"get", "list"}},
+				},
+				ForbidWorkloadClusterAdmin: true,
+			},
+		},
+	}
+}
+
+func TestDetectRBACDrift(t *testing.T) {
+	ctx := context.Background()
+
+	client := fake.NewSimpleClientset(
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "secret-cleaner"},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get", "delete"}},
+				{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}},
+			},
+		},
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "system:controller:secret-cleaner"},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"delete"}},
+			},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "secret-cleaner"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "secret-cleaner"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "ops"}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-admin", Namespace: "apps"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "app"}},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-system-admin"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "addon", Namespace: "kube-system"}},
+		},
+	)
+	detector := NewDetector(client, dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()))
+
+	events, err := detector.DetectRBACDrift(ctx, rbacDriftSpec())
+	if err != nil {
+		t.Fatalf("DetectRBACDrift failed: %v", err)
+	}
+
+	byPath := map[string]DriftEvent{}
+	for _, event := range events {
+		if event.Type != DriftTypeRBAC {
+			t.Errorf("Expected RBAC drift type, got %s", event.Type)
+		}
+		byPath[event.Resource.Path] = event
+	}
+	if len(byPath) != 4 {
+		t.Fatalf("Expected 4 drift events, got %d: %v", len(events), byPath)
+	}
+
+	role, ok := byPath["ClusterRole/secret-cleaner"]
+	if !ok {
+		t.Fatal("Expected modified event for ClusterRole secret-cleaner")
+	}
+	if role.DriftKind != "modified" || role.Resource.Kind != "ClusterRole" || role.Resource.Name != "secret-cleaner" {
+		t.Errorf("Unexpected role event: %+v", role.Resource)
+	}
+	expected, ok := role.Expected.(*rbacv1.ClusterRole)
+	if !ok || len(expected.Rules) != 1 || expected.Rules[0].Resources[0] != "configmaps" {
+		t.Errorf("Expected role without the forbidden rule, got %+v", role.Expected)
+	}
+
+	if binding := byPath["ClusterRoleBinding/secret-cleaner"]; binding.DriftKind != "extra" || binding.Severity != SeverityHigh {
+		t.Errorf("Expected extra high-severity binding event, got %s/%s", binding.DriftKind, binding.Severity)
+	}
+
+	admin := byPath["RoleBinding/apps/app-admin"]
+	if admin.DriftKind != "extra" || admin.Severity != SeverityCritical || admin.Resource.Namespace != "apps" {
+		t.Errorf("Expected critical extra event for workload cluster-admin binding, got %+v", admin)
+	}
+
+	minimum := byPath["ClusterRole/"+minimumRulesRole]
+	if minimum.DriftKind != "missing" {
+		t.Errorf("Expected missing event for minimum rules, got %q", minimum.DriftKind)
+	}
+	if _, ok := byPath["ClusterRoleBinding/kube-system-admin"]; ok {
+		t.Error("Expected bindings of system ServiceAccounts to be ignored")
+	}
+}
+
+// defaultClusterAdmin returns the cluster-admin ClusterRole and binding the API
+// server bootstraps
+func defaultClusterAdmin() (*rbacv1.ClusterRole, *rbacv1.ClusterRoleBinding) {
+	labels := map[string]string{rbacBootstrappingLabel: "rbac-defaults"}
+	role := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin", Labels: labels},
+		Rules: []rbacv1.PolicyRule{
+			{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+			{NonResourceURLs: []string{"*"}, Verbs: []string{"*"}},
+		},
+	}
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin", Labels: labels},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:masters"}},
+	}
+	return role, binding
+}
+
+// TestDetectRBACDrift_StrictSpecSkipsDefaultRoles ensures the strict example spec,
+// which forbids */*/*, reports user roles but not the bootstrapped defaults or
+// aggregated roles
+func TestDetectRBACDrift_StrictSpecSkipsDefaultRoles(t *testing.T) {
+	strict, err := spec.LoadFromFile("../../specs/examples/strict.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load strict spec: %v", err)
+	}
+	if strict.Spec.RBAC == nil || len(strict.Spec.RBAC.ForbiddenRules) == 0 {
+		t.Fatal("Expected the strict spec to forbid RBAC rules")
+	}
+
+	wildcard := []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}}
+	clusterAdmin, clusterAdminBinding := defaultClusterAdmin()
+	client := fake.NewSimpleClientset(
+		clusterAdmin,
+		clusterAdminBinding,
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "platform-admin"},
+			AggregationRule: &rbacv1.AggregationRule{
+				ClusterRoleSelectors: []metav1.LabelSelector{{MatchLabels: map[string]string{"example.com/aggregate-to-platform-admin": "true"}}},
+			},
+			Rules: wildcard,
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "platform-admins"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "platform-admin"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "platform"}},
+		},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "app-superuser"}, Rules: wildcard},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-superuser"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "app-superuser"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "dev"}},
+		},
+	)
+	detector := NewDetector(client, dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()))
+
+	events, err := detector.DetectRBACDrift(context.Background(), strict)
+	if err != nil {
+		t.Fatalf("DetectRBACDrift failed: %v", err)
+	}
+
+	paths := map[string]string{}
+	for _, event := range events {
+		paths[event.Resource.Path] = event.DriftKind
+	}
+	expected := map[string]string{
+		"ClusterRole/app-superuser":        "modified",
+		"ClusterRoleBinding/app-superuser": "extra",
+	}
+	if len(paths) != len(expected) {
+		t.Errorf("Expected drift events %v, got %v", expected, paths)
+	}
+	for path, kind := range expected {
+		if paths[path] != kind {
+			t.Errorf("Expected %s drift for %s, got %q", kind, path, paths[path])
+		}
+	}
+}
+
+func TestDetectRBACDrift_MinimumRulesRoleModified(t *testing.T) {
+	client := fake.NewSimpleClientset(&rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: minimumRulesRole},
+		Rules: []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}},
+		},
+	})
+	detector := NewDetector(client, dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()))
+
+	events, err := detector.DetectRBACDrift(context.Background(), rbacDriftSpec())
+	if err != nil {
+		t.Fatalf("DetectRBACDrift failed: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 drift event, got %d", len(events))
+	}
+	if events[0].DriftKind != "modified" {
+		t.Errorf("Expected modified drift kind, got %s", events[0].DriftKind)
+	}
+	if expected := events[0].Expected.(*rbacv1.ClusterRole); len(expected.Rules) != 2 {
+		t.Errorf("Expected existing rule plus missing rule, got %v", expected.Rules)
+	}
+}
+
+func TestDetectRBACDrift_NoRBACSpec(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	detector := NewDetector(client, dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()))
+
+	events, err := detector.DetectRBACDrift(context.Background(), &spec.ClusterSpecification{})
+	if err != nil {
+		t.Fatalf("DetectRBACDrift failed: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected no events without an RBAC spec, got %d", len(events))
+	}
+}
+
+func TestRemediate_RBACDriftRequiresForce(t *testing.T) {
+	ctx := context.Background()
+
+	binding := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "rbac.authorization.k8s.io/v1",
+		"kind":       "ClusterRoleBinding",
+		"metadata":   map[string]interface{}{"name": "secret-cleaner"},
+	}}
+	client, dynamicClient := createTestClients(binding)
+	remediator := NewRemediator(client, dynamicClient)
+
+	newReport := func() *DriftReport {
+		return &DriftReport{Events: []DriftEvent{{
+			Type:      DriftTypeRBAC,
+			DriftKind: "extra",
+			Resource:  rbacResource("ClusterRoleBinding", "", "secret-cleaner"),
+		}}}
+	}
+	gvr := schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterrolebindings"}
+
+	report := newReport()
+	if err := remediator.Remediate(ctx, rbacDriftSpec(), report, RemediateOptions{Types: []DriftType{DriftTypeRBAC}}); err != nil {
+		t.Fatalf("Remediate failed: %v", err)
+	}
+	if remediation := report.Events[0].Remediation; remediation == nil || remediation.Status != DriftStatusManualRequired {
+		t.Errorf("Expected RBAC drift to require --force, got %+v", remediation)
+	}
+	if _, err := dynamicClient.Resource(gvr).Get(ctx, "secret-cleaner", metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected binding to remain without --force: %v", err)
+	}
+
+	report = newReport()
+	if err := remediator.Remediate(ctx, rbacDriftSpec(), report, RemediateOptions{Types: []DriftType{DriftTypeRBAC}, Force: true}); err != nil {
+		t.Fatalf("Remediate failed: %v", err)
+	}
+	if remediation := report.Events[0].Remediation; remediation == nil || remediation.Status != DriftStatusRemediated {
+		t.Errorf("Expected RBAC drift to be remediated with --force, got %+v", remediation)
+	}
+	if _, err := dynamicClient.Resource(gvr).Get(ctx, "secret-cleaner", metav1.GetOptions{}); err == nil {
+		t.Error("Expected binding to be deleted with --force")
+	}
+}
+
+// TestRemediate_RBACSkipsManagedObjects ensures forced remediation leaves the
+// bootstrapped defaults and aggregated roles alone
+func TestRemediate_RBACSkipsManagedObjects(t *testing.T) {
+	ctx := context.Background()
+
+	defaults := map[string]interface{}{rbacBootstrappingLabel: "rbac-defaults"}
+	client, dynamicClient := createTestClients(
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "rbac.authorization.k8s.io/v1",
+			"kind":       "ClusterRoleBinding",
+			"metadata":   map[string]interface{}{"name": "cluster-admin", "labels": defaults},
+		}},
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion":      "rbac.authorization.k8s.io/v1",
+			"kind":            "ClusterRole",
+			"metadata":        map[string]interface{}{"name": "platform-admin"},
+			"aggregationRule": map[string]interface{}{"clusterRoleSelectors": []interface{}{}},
+		}},
+	)
+	remediator := NewRemediator(client, dynamicClient)
+
+	expectedRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "platform-admin"}}
+	report := &DriftReport{Events: []DriftEvent{
+		{Type: DriftTypeRBAC, DriftKind: "extra", Resource: rbacResource("ClusterRoleBinding", "", "cluster-admin")},
+		{Type: DriftTypeRBAC, DriftKind: "modified", Resource: rbacResource("ClusterRole", "", "platform-admin"), Expected: expectedRole},
+	}}
+	if err := remediator.Remediate(ctx, rbacDriftSpec(), report, RemediateOptions{Types: []DriftType{DriftTypeRBAC}, Force: true}); err != nil {
+		t.Fatalf("Remediate failed: %v", err)
+	}
+
+	for _, event := range report.Events {
+		if remediation := event.Remediation; remediation == nil || remediation.Status != DriftStatusManualRequired {
+			t.Errorf("Expected %s to be skipped, got %+v", event.Resource.Path, remediation)
+		}
+	}
+
+	bindings := schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterrolebindings"}
+	if _, err := dynamicClient.Resource(bindings).Get(ctx, "cluster-admin", metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected the cluster-admin binding to remain: %v", err)
+	}
+	roles := schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterroles"}
+	role, err := dynamicClient.Resource(roles).Get(ctx, "platform-admin", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected the aggregated role to remain: %v", err)
+	}
+	if _, ok := role.Object["aggregationRule"]; !ok {
+		t.Error("Expected the aggregated role to be left unchanged")
+	}
+}
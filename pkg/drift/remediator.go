@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cloudcwfranck/kspec/pkg/enforcer"
 	"github.com/cloudcwfranck/kspec/pkg/spec"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -32,11 +34,48 @@ func NewRemediator(client kubernetes.Interface, dynamicClient dynamic.Interface)
 	}
 }
 
-// Remediate remediates drift detected in a drift report.
-func (r *Remediator) Remediate(ctx context.Context, clusterSpec *spec.ClusterSpecification, report *DriftReport, opts RemediateOptions) error {
-	remediatedCount := 0
-	failedCount := 0
+// defaultRemediationConcurrency bounds concurrent remediation actions when
+// RemediateOptions.Concurrency is not set.
+const defaultRemediationConcurrency = 4
+
+// remediationResources maps the resource kinds the remediator can apply to
+// their API resources.
+var remediationResources = map[string]schema.GroupVersionResource{
+	"Namespace":                {Version: "v1", Resource: "namespaces"},
+	"CustomResourceDefinition": {Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"},
+	"NetworkPolicy":            {Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies"},
+	"ClusterPolicy":            {Group: "kyverno.io", Version: "v1", Resource: "clusterpolicies"},
+	"ClusterRole":              {Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterroles"},
+	"ClusterRoleBinding":       {Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterrolebindings"},
+	"Role":                     {Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "roles"},
+	"RoleBinding":              {Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "rolebindings"},
+}
+
+// remediationStage orders remediation so that resources other resources depend
+// on exist first: namespaces and CRDs are created or updated before anything
+// else, and deletions run last, removing dependents before what they depend on.
+func remediationStage(event *DriftEvent) int {
+	foundational := event.Resource.Kind == "Namespace" || event.Resource.Kind == "CustomResourceDefinition"
+	switch {
+	case event.DriftKind == "extra" && foundational:
+		return 3
+	case event.DriftKind == "extra":
+		return 2
+	case foundational:
+		return 0
+	default:
+		return 1
+	}
+}
 
+// Remediate remediates drift detected in a drift report. Events are applied in
+// dependency order (see remediationStage); events within a stage are independent
+// and run concurrently, bounded by opts.Concurrency. A failed action does not
+// stop the others. The order actions were applied in is recorded in
+// report.RemediationOrder.
+func (r *Remediator) Remediate(ctx context.Context, clusterSpec *spec.ClusterSpecification, report *DriftReport, opts RemediateOptions) error {
+	// Group eligible events into stages, preserving report order within a stage
+	var stages [4][]*DriftEvent
 	for i := range report.Events {
 		event := &report.Events[i]
 
@@ -50,98 +89,235 @@ func (r *Remediator) Remediate(ctx context.Context, clusterSpec *spec.ClusterSpe
 			continue
 		}
 
-		// Perform remediation based on drift type
-		var err error
-		switch event.Type {
-		case DriftTypePolicy:
-			err = r.remediatePolicyDrift(ctx, clusterSpec, event, opts)
-		case DriftTypeCompliance:
-			// Compliance drift requires manual remediation
+		stage := remediationStage(event)
+		stages[stage] = append(stages[stage], event)
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultRemediationConcurrency
+	}
+
+	var (
+		mu              sync.Mutex
+		remediatedCount int
+		failedCount     int
+	)
+	report.RemediationOrder = nil
+
+	for _, events := range stages {
+		var wg sync.WaitGroup
+		slots := make(chan struct{}, concurrency)
+
+		for _, event := range events {
+			wg.Add(1)
+			slots <- struct{}{}
+			go func(event *DriftEvent) {
+				defer wg.Done()
+				defer func() { <-slots }()
+
+				applied, err := r.remediateEvent(ctx, clusterSpec, event, opts)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if applied {
+					report.RemediationOrder = append(report.RemediationOrder, resourcePath(event.Resource))
+				}
+				if err != nil {
+					failedCount++
+					if event.Remediation != nil {
+						event.Remediation.Status = DriftStatusFailed
+						event.Remediation.Error = err.Error()
+					}
+				} else if event.Remediation != nil && event.Remediation.Status == DriftStatusRemediated {
+					remediatedCount++
+				}
+			}(event)
+		}
+
+		// Wait for the stage to finish before starting resources that may depend on it
+		wg.Wait()
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("remediation completed with %d failures (%d succeeded)", failedCount, remediatedCount)
+	}
+
+	return nil
+}
+
+// remediateEvent remediates a single drift event. It reports whether a change
+// was applied to (or, in dry-run mode, planned against) the cluster. Panics are
+// recovered so a single action cannot abort the remaining remediations.
+func (r *Remediator) remediateEvent(ctx context.Context, clusterSpec *spec.ClusterSpecification, event *DriftEvent, opts RemediateOptions) (applied bool, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("remediation of %s panicked: %v", resourcePath(event.Resource), recovered)
+		}
+	}()
+
+	// Perform remediation based on drift type
+	switch event.Type {
+	case DriftTypePolicy, DriftTypeConfiguration:
+		err = r.remediateResourceDrift(ctx, clusterSpec, event, opts)
+		applied = event.Remediation != nil && event.Remediation.Action != "skip"
+	case DriftTypeRBAC:
+		// Changing roles and bindings can lock users and workloads out of the cluster
+		if !opts.Force {
 			event.Remediation = &RemediationResult{
-				Action:    "manual-required",
+				Action:    "skip",
 				Status:    DriftStatusManualRequired,
 				Timestamp: time.Now(),
-				Details:   "Compliance drift requires manual intervention",
+				Details:   fmt.Sprintf("RBAC drift of %s not remediated (use --force to remediate)", resourcePath(event.Resource)),
 			}
-		default:
+			break
+		}
+		// Events may predate the object becoming managed, e.g. when read from history
+		managed, managedErr := r.managedRBACTarget(ctx, event)
+		if managedErr != nil {
+			err = managedErr
+			break
+		}
+		if managed {
 			event.Remediation = &RemediationResult{
-				Action:    "skipped",
+				Action:    "skip",
 				Status:    DriftStatusManualRequired,
 				Timestamp: time.Now(),
-				Details:   fmt.Sprintf("Remediation not supported for type %s", event.Type),
+				Details:   fmt.Sprintf("%s is managed by Kubernetes and is not remediated", resourcePath(event.Resource)),
 			}
+			break
 		}
-
-		// Update counters
-		if err != nil {
-			failedCount++
-			if event.Remediation != nil {
-				event.Remediation.Status = DriftStatusFailed
-				event.Remediation.Error = err.Error()
-			}
-		} else if event.Remediation != nil && event.Remediation.Status == DriftStatusRemediated {
-			remediatedCount++
+		err = r.remediateResourceDrift(ctx, clusterSpec, event, opts)
+		applied = event.Remediation != nil && event.Remediation.Action != "skip"
+	case DriftTypeCompliance:
+		err = r.remediateComplianceDrift(ctx, clusterSpec, event, opts)
+		applied = event.Remediation != nil && event.Remediation.Status == DriftStatusRemediated
+	default:
+		event.Remediation = &RemediationResult{
+			Action:    "skipped",
+			Status:    DriftStatusManualRequired,
+			Timestamp: time.Now(),
+			Details:   fmt.Sprintf("Remediation not supported for type %s", event.Type),
 		}
 	}
 
-	if failedCount > 0 {
-		return fmt.Errorf("remediation completed with %d failures (%d succeeded)", failedCount, remediatedCount)
+	return applied, err
+}
+
+// managedRBACTarget checks if the live object of an RBAC drift event is managed
+// by Kubernetes (see isManagedRBACObject). Objects that do not exist are not.
+func (r *Remediator) managedRBACTarget(ctx context.Context, event *DriftEvent) (bool, error) {
+	if isManagedRBACObject(event.Resource.Name, nil, false) {
+		return true, nil
+	}
+	if event.DriftKind == "missing" {
+		return false, nil
 	}
 
-	return nil
+	resources, _, err := r.resourceClient(event)
+	if err != nil {
+		return false, err
+	}
+	live, err := resources.Get(ctx, event.Resource.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get %s: %w", resourcePath(event.Resource), err)
+	}
+	return isManagedRBACObject(live.GetName(), live.GetLabels(), live.Object["aggregationRule"] != nil), nil
+}
+
+// resourcePath returns the path of a drifted resource, e.g. "NetworkPolicy/apps/default-deny".
+func resourcePath(resource DriftResource) string {
+	if resource.Path != "" {
+		return resource.Path
+	}
+	if resource.Namespace != "" {
+		return fmt.Sprintf("%s/%s/%s", resource.Kind, resource.Namespace, resource.Name)
+	}
+	return fmt.Sprintf("%s/%s", resource.Kind, resource.Name)
+}
+
+// resourceClient returns the dynamic client for the resource a drift event refers to.
+func (r *Remediator) resourceClient(event *DriftEvent) (dynamic.ResourceInterface, schema.GroupVersionResource, error) {
+	gvr, ok := remediationResources[event.Resource.Kind]
+	if !ok {
+		return nil, gvr, fmt.Errorf("remediation not supported for kind %s", event.Resource.Kind)
+	}
+	if event.Resource.Namespace != "" {
+		return r.dynamicClient.Resource(gvr).Namespace(event.Resource.Namespace), gvr, nil
+	}
+	return r.dynamicClient.Resource(gvr), gvr, nil
+}
+
+// expectedObject converts the expected state of a drift event to unstructured form.
+func expectedObject(event *DriftEvent, gvr schema.GroupVersionResource) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(event.Expected)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s: %w", event.Resource.Kind, err)
+	}
+
+	u := &unstructured.Unstructured{Object: content}
+	if u.GetAPIVersion() == "" {
+		u.SetAPIVersion(gvr.GroupVersion().String())
+	}
+	if u.GetKind() == "" {
+		u.SetKind(event.Resource.Kind)
+	}
+	return u, nil
 }
 
-// remediatePolicyDrift remediates policy drift.
-func (r *Remediator) remediatePolicyDrift(ctx context.Context, clusterSpec *spec.ClusterSpecification, event *DriftEvent, opts RemediateOptions) error {
+// remediateResourceDrift remediates drift of a single resource.
+func (r *Remediator) remediateResourceDrift(ctx context.Context, clusterSpec *spec.ClusterSpecification, event *DriftEvent, opts RemediateOptions) error {
 	switch event.DriftKind {
 	case "missing":
-		return r.remediateMissingPolicy(ctx, event, opts)
+		return r.remediateMissingResource(ctx, event, opts)
 	case "modified":
-		return r.remediateModifiedPolicy(ctx, event, opts)
+		return r.remediateModifiedResource(ctx, event, opts)
 	case "extra":
-		return r.remediateExtraPolicy(ctx, event, opts)
+		return r.remediateExtraResource(ctx, event, opts)
 	default:
 		return fmt.Errorf("unknown drift kind: %s", event.DriftKind)
 	}
 }
 
-// remediateMissingPolicy creates a missing policy.
-func (r *Remediator) remediateMissingPolicy(ctx context.Context, event *DriftEvent, opts RemediateOptions) error {
+// remediateMissingResource creates a missing resource.
+func (r *Remediator) remediateMissingResource(ctx context.Context, event *DriftEvent, opts RemediateOptions) error {
 	if event.Expected == nil {
-		return fmt.Errorf("no expected policy to create")
+		return fmt.Errorf("no expected %s to create", event.Resource.Kind)
 	}
 
-	// Convert to unstructured for dynamic client
-	unstructuredPolicy, err := runtime.DefaultUnstructuredConverter.ToUnstructured(event.Expected)
+	resources, gvr, err := r.resourceClient(event)
 	if err != nil {
-		return fmt.Errorf("failed to convert policy: %w", err)
+		return err
 	}
 
-	u := &unstructured.Unstructured{Object: unstructuredPolicy}
-	u.SetAPIVersion("kyverno.io/v1")
-	u.SetKind("ClusterPolicy")
+	u, err := expectedObject(event, gvr)
+	if err != nil {
+		return err
+	}
 
-	policyName := u.GetName()
+	kind, name := u.GetKind(), u.GetName()
 
 	// Dry-run mode
 	if opts.DryRun {
+		manifest, err := previewManifest(u.Object)
+		if err != nil {
+			return err
+		}
 		event.Remediation = &RemediationResult{
 			Action:    "create",
 			Status:    DriftStatusDetected,
 			Timestamp: time.Now(),
-			Details:   fmt.Sprintf("Would create ClusterPolicy '%s' (dry-run)", policyName),
+			Details:   fmt.Sprintf("Would create %s '%s' (dry-run)", kind, name),
+			Manifest:  manifest,
 		}
 		return nil
 	}
 
-	// Create the policy
-	gvr := schema.GroupVersionResource{
-		Group:    "kyverno.io",
-		Version:  "v1",
-		Resource: "clusterpolicies",
-	}
-
-	_, err = r.dynamicClient.Resource(gvr).Create(ctx, u, metav1.CreateOptions{})
+	// Create the resource
+	_, err = resources.Create(ctx, u, metav1.CreateOptions{})
 	if err != nil {
 		event.Remediation = &RemediationResult{
 			Action:    "create",
@@ -149,71 +325,70 @@ func (r *Remediator) remediateMissingPolicy(ctx context.Context, event *DriftEve
 			Timestamp: time.Now(),
 			Error:     err.Error(),
 		}
-		return fmt.Errorf("failed to create policy: %w", err)
+		return fmt.Errorf("failed to create %s: %w", kind, err)
 	}
 
 	event.Remediation = &RemediationResult{
 		Action:    "create",
 		Status:    DriftStatusRemediated,
 		Timestamp: time.Now(),
-		Details:   fmt.Sprintf("Created ClusterPolicy '%s'", policyName),
+		Details:   fmt.Sprintf("Created %s '%s'", kind, name),
 	}
 
 	return nil
 }
 
-// remediateModifiedPolicy updates a modified policy.
-func (r *Remediator) remediateModifiedPolicy(ctx context.Context, event *DriftEvent, opts RemediateOptions) error {
+// remediateModifiedResource updates a modified resource.
+func (r *Remediator) remediateModifiedResource(ctx context.Context, event *DriftEvent, opts RemediateOptions) error {
 	if event.Expected == nil {
-		return fmt.Errorf("no expected policy to update to")
+		return fmt.Errorf("no expected %s to update to", event.Resource.Kind)
 	}
 
-	// Convert to unstructured for dynamic client
-	unstructuredPolicy, err := runtime.DefaultUnstructuredConverter.ToUnstructured(event.Expected)
+	resources, gvr, err := r.resourceClient(event)
 	if err != nil {
-		return fmt.Errorf("failed to convert policy: %w", err)
+		return err
 	}
 
-	u := &unstructured.Unstructured{Object: unstructuredPolicy}
-	u.SetAPIVersion("kyverno.io/v1")
-	u.SetKind("ClusterPolicy")
+	u, err := expectedObject(event, gvr)
+	if err != nil {
+		return err
+	}
 
-	policyName := u.GetName()
+	kind, name := u.GetKind(), u.GetName()
 
-	// Dry-run mode
-	if opts.DryRun {
+	// Get current resource to diff against and to retrieve its resourceVersion
+	existing, err := resources.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
 		event.Remediation = &RemediationResult{
 			Action:    "update",
-			Status:    DriftStatusDetected,
+			Status:    DriftStatusFailed,
 			Timestamp: time.Now(),
-			Details:   fmt.Sprintf("Would update ClusterPolicy '%s' (dry-run)", policyName),
+			Error:     err.Error(),
 		}
-		return nil
-	}
-
-	// Get current policy to retrieve resourceVersion
-	gvr := schema.GroupVersionResource{
-		Group:    "kyverno.io",
-		Version:  "v1",
-		Resource: "clusterpolicies",
+		return fmt.Errorf("failed to get existing %s: %w", kind, err)
 	}
 
-	existing, err := r.dynamicClient.Resource(gvr).Get(ctx, policyName, metav1.GetOptions{})
-	if err != nil {
+	// Dry-run mode
+	if opts.DryRun {
+		diff, err := previewDiff(resourcePath(event.Resource), existing, u)
+		if err != nil {
+			return err
+		}
 		event.Remediation = &RemediationResult{
 			Action:    "update",
-			Status:    DriftStatusFailed,
+			Status:    DriftStatusDetected,
 			Timestamp: time.Now(),
-			Error:     err.Error(),
+			Details:   fmt.Sprintf("Would update %s '%s' (dry-run)", kind, name),
+			Diff:      diff,
 		}
-		return fmt.Errorf("failed to get existing policy: %w", err)
+		return nil
 	}
 
 	// Set resourceVersion for update
 	u.SetResourceVersion(existing.GetResourceVersion())
 
-	// Update the policy
-	_, err = r.dynamicClient.Resource(gvr).Update(ctx, u, metav1.UpdateOptions{})
+	// Update the resource
+	_, err = resources.Update(ctx, u, metav1.UpdateOptions{})
 	if err != nil {
 		event.Remediation = &RemediationResult{
 			Action:    "update",
@@ -221,54 +396,53 @@ func (r *Remediator) remediateModifiedPolicy(ctx context.Context, event *DriftEv
 			Timestamp: time.Now(),
 			Error:     err.Error(),
 		}
-		return fmt.Errorf("failed to update policy: %w", err)
+		return fmt.Errorf("failed to update %s: %w", kind, err)
 	}
 
 	event.Remediation = &RemediationResult{
 		Action:    "update",
 		Status:    DriftStatusRemediated,
 		Timestamp: time.Now(),
-		Details:   fmt.Sprintf("Updated ClusterPolicy '%s'", policyName),
+		Details:   fmt.Sprintf("Updated %s '%s'", kind, name),
 	}
 
 	return nil
 }
 
-// remediateExtraPolicy handles extra policies.
-func (r *Remediator) remediateExtraPolicy(ctx context.Context, event *DriftEvent, opts RemediateOptions) error {
-	policyName := event.Resource.Name
+// remediateExtraResource handles extra resources.
+func (r *Remediator) remediateExtraResource(ctx context.Context, event *DriftEvent, opts RemediateOptions) error {
+	kind, name := event.Resource.Kind, event.Resource.Name
 
-	// By default, we don't delete extra policies (conservative approach)
+	// By default, we don't delete extra resources (conservative approach)
 	// Only delete if Force flag is set
 	if !opts.Force {
 		event.Remediation = &RemediationResult{
 			Action:    "skip",
 			Status:    DriftStatusManualRequired,
 			Timestamp: time.Now(),
-			Details:   fmt.Sprintf("Extra policy '%s' not deleted (use --force to delete)", policyName),
+			Details:   fmt.Sprintf("Extra %s '%s' not deleted (use --force to delete)", kind, name),
 		}
 		return nil
 	}
 
+	resources, _, err := r.resourceClient(event)
+	if err != nil {
+		return err
+	}
+
 	// Dry-run mode
 	if opts.DryRun {
 		event.Remediation = &RemediationResult{
 			Action:    "delete",
 			Status:    DriftStatusDetected,
 			Timestamp: time.Now(),
-			Details:   fmt.Sprintf("Would delete ClusterPolicy '%s' (dry-run)", policyName),
+			Details:   fmt.Sprintf("Would delete %s '%s' (dry-run)", kind, name),
 		}
 		return nil
 	}
 
-	// Delete the policy
-	gvr := schema.GroupVersionResource{
-		Group:    "kyverno.io",
-		Version:  "v1",
-		Resource: "clusterpolicies",
-	}
-
-	err := r.dynamicClient.Resource(gvr).Delete(ctx, policyName, metav1.DeleteOptions{})
+	// Delete the resource
+	err = resources.Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil && !strings.Contains(err.Error(), "not found") {
 		event.Remediation = &RemediationResult{
 			Action:    "delete",
@@ -276,16 +450,83 @@ func (r *Remediator) remediateExtraPolicy(ctx context.Context, event *DriftEvent
 			Timestamp: time.Now(),
 			Error:     err.Error(),
 		}
-		return fmt.Errorf("failed to delete policy: %w", err)
+		return fmt.Errorf("failed to delete %s: %w", kind, err)
 	}
 
 	event.Remediation = &RemediationResult{
 		Action:    "delete",
 		Status:    DriftStatusRemediated,
 		Timestamp: time.Now(),
-		Details:   fmt.Sprintf("Deleted ClusterPolicy '%s'", policyName),
+		Details:   fmt.Sprintf("Deleted %s '%s'", kind, name),
+	}
+
+	return nil
+}
+
+// remediateWorkloadDrift generates patches for workloads violating the spec's
+// securityContext requirements. The patches are recorded on the event and, with
+// opts.ApplyPatches, applied; otherwise the drift stays manual-required.
+func (r *Remediator) remediateWorkloadDrift(ctx context.Context, clusterSpec *spec.ClusterSpecification, event *DriftEvent, opts RemediateOptions) error {
+	patches, err := BuildWorkloadPatches(ctx, r.client, clusterSpec)
+	if err != nil {
+		event.Remediation = &RemediationResult{
+			Action:    "patch",
+			Status:    DriftStatusFailed,
+			Timestamp: time.Now(),
+			Error:     err.Error(),
+		}
+		return err
+	}
+
+	if len(patches) == 0 {
+		event.Remediation = &RemediationResult{
+			Action:    "manual-required",
+			Status:    DriftStatusManualRequired,
+			Timestamp: time.Now(),
+			Details:   "Compliance drift requires manual intervention (no workload patch can fix it)",
+		}
+		return nil
+	}
+
+	if !opts.ApplyPatches || opts.DryRun {
+		event.Remediation = &RemediationResult{
+			Action:    "manual-required",
+			Status:    DriftStatusManualRequired,
+			Timestamp: time.Now(),
+			Details:   fmt.Sprintf("Generated %d workload patches (apply with --apply-patches)", len(patches)),
+			Patches:   patches,
+		}
+		return nil
+	}
+
+	failed := 0
+	for i := range patches {
+		if err := applyWorkloadPatch(ctx, r.client, patches[i]); err != nil {
+			patches[i].Error = err.Error()
+			failed++
+			continue
+		}
+		patches[i].Applied = true
+	}
+
+	if failed > 0 {
+		event.Remediation = &RemediationResult{
+			Action:    "patch",
+			Status:    DriftStatusFailed,
+			Timestamp: time.Now(),
+			Error:     fmt.Sprintf("%d of %d workload patches failed", failed, len(patches)),
+			Patches:   patches,
+		}
+		return fmt.Errorf("failed to apply %d of %d workload patches", failed, len(patches))
 	}
 
+	event.Remediation = &RemediationResult{
+		Action:    "patch",
+		Status:    DriftStatusRemediated,
+		Timestamp: time.Now(),
+		Details:   fmt.Sprintf("Patched %d workloads", len(patches)),
+		Patches:   patches,
+	}
 	return nil
 }
 
@@ -2,11 +2,14 @@ package drift
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/cloudcwfranck/kspec/pkg/spec"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestRemediate_DryRun(t *testing.T) {
@@ -420,3 +423,143 @@ func TestRemediateAll(t *testing.T) {
 		t.Error("Expected non-nil events slice")
 	}
 }
+
+func TestRemediate_CreatesNamespaceBeforeNetworkPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	client, dynamicClient := createTestClients()
+
+	// Record the order resources are created in
+	var created []string
+	dynamicClient.PrependReactor("create", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		created = append(created, action.GetResource().Resource)
+		return false, nil, nil
+	})
+
+	namespace := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata": map[string]interface{}{
+				"name": "apps",
+			},
+		},
+	}
+	networkPolicy := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "networking.k8s.io/v1",
+			"kind":       "NetworkPolicy",
+			"metadata": map[string]interface{}{
+				"name":      "default-deny",
+				"namespace": "apps",
+			},
+			"spec": map[string]interface{}{
+				"podSelector": map[string]interface{}{},
+			},
+		},
+	}
+
+	// The NetworkPolicy is listed first, but depends on the namespace
+	report := &DriftReport{
+		Events: []DriftEvent{
+			{
+				Type:      DriftTypeConfiguration,
+				DriftKind: "missing",
+				Resource: DriftResource{
+					Kind:      "NetworkPolicy",
+					Name:      "default-deny",
+					Namespace: "apps",
+				},
+				Expected: networkPolicy,
+			},
+			{
+				Type:      DriftTypeConfiguration,
+				DriftKind: "missing",
+				Resource: DriftResource{
+					Kind: "Namespace",
+					Name: "apps",
+				},
+				Expected: namespace,
+			},
+		},
+	}
+
+	remediator := NewRemediator(client, dynamicClient)
+	err := remediator.Remediate(ctx, &spec.ClusterSpecification{}, report, RemediateOptions{
+		Types: []DriftType{DriftTypeConfiguration},
+	})
+	if err != nil {
+		t.Fatalf("Remediate failed: %v", err)
+	}
+
+	if len(created) != 2 || created[0] != "namespaces" || created[1] != "networkpolicies" {
+		t.Errorf("Expected namespace to be created before the NetworkPolicy, got create order %v", created)
+	}
+
+	wantOrder := []string{"Namespace/apps", "NetworkPolicy/apps/default-deny"}
+	if len(report.RemediationOrder) != len(wantOrder) {
+		t.Fatalf("Expected remediation order %v, got %v", wantOrder, report.RemediationOrder)
+	}
+	for i := range wantOrder {
+		if report.RemediationOrder[i] != wantOrder[i] {
+			t.Errorf("Remediation order[%d]: expected %s, got %s", i, wantOrder[i], report.RemediationOrder[i])
+		}
+	}
+
+	for _, event := range report.Events {
+		if event.Remediation == nil || event.Remediation.Status != DriftStatusRemediated {
+			t.Errorf("Expected %s to be remediated, got %+v", event.Resource.Kind, event.Remediation)
+		}
+	}
+}
+
+func TestRemediate_IsolatesFailures(t *testing.T) {
+	ctx := context.Background()
+
+	client, dynamicClient := createTestClients()
+
+	// Fail creation of one policy only
+	dynamicClient.PrependReactor("create", "clusterpolicies", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := action.(k8stesting.CreateAction).GetObject().(*unstructured.Unstructured)
+		if obj.GetName() == "broken-policy" {
+			return true, nil, fmt.Errorf("admission denied")
+		}
+		return false, nil, nil
+	})
+
+	var events []DriftEvent
+	for _, name := range []string{"policy-a", "broken-policy", "policy-b", "policy-c"} {
+		events = append(events, DriftEvent{
+			Type:      DriftTypePolicy,
+			DriftKind: "missing",
+			Resource:  DriftResource{Kind: "ClusterPolicy", Name: name},
+			Expected: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "kyverno.io/v1",
+					"kind":       "ClusterPolicy",
+					"metadata":   map[string]interface{}{"name": name},
+				},
+			},
+		})
+	}
+	report := &DriftReport{Events: events}
+
+	remediator := NewRemediator(client, dynamicClient)
+	err := remediator.Remediate(ctx, &spec.ClusterSpecification{}, report, RemediateOptions{
+		Types:       []DriftType{DriftTypePolicy},
+		Concurrency: 2,
+	})
+	if err == nil {
+		t.Fatal("Expected an error reporting the failed remediation")
+	}
+
+	for _, event := range report.Events {
+		wantStatus := DriftStatusRemediated
+		if event.Resource.Name == "broken-policy" {
+			wantStatus = DriftStatusFailed
+		}
+		if event.Remediation == nil || event.Remediation.Status != wantStatus {
+			t.Errorf("Expected %s to be %s, got %+v", event.Resource.Name, wantStatus, event.Remediation)
+		}
+	}
+}
@@ -0,0 +1,479 @@
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// SnapshotAPIVersion is the apiVersion of baseline snapshot files
+	SnapshotAPIVersion = "kspec.dev/v1"
+
+	// SnapshotKind is the kind of baseline snapshot files
+	SnapshotKind = "ClusterSnapshot"
+
+	// podSecurityLabelPrefix prefixes the Pod Security Admission namespace labels
+	podSecurityLabelPrefix = "pod-security.kubernetes.io/"
+
+	// podSecurityUnset counts namespaces without a Pod Security enforce label
+	podSecurityUnset = "unset"
+)
+
+// snapshotKindOrder orders snapshot resources by kind, and maps each kind to
+// the drift type its changes are reported as.
+var snapshotKindOrder = []struct {
+	kind      string
+	driftType DriftType
+}{
+	{"ClusterPolicy", DriftTypePolicy},
+	{"ClusterRole", DriftTypeRBAC},
+	{"ClusterRoleBinding", DriftTypeRBAC},
+	{"Role", DriftTypeRBAC},
+	{"RoleBinding", DriftTypeRBAC},
+	{"NetworkPolicy", DriftTypeConfiguration},
+	{"Namespace", DriftTypeConfiguration},
+}
+
+// Snapshot is a captured state of the cluster resources kspec manages: Kyverno
+// ClusterPolicies generated by kspec, RBAC roles and bindings, NetworkPolicies
+// and the Pod Security labels of namespaces. Drift detected against a snapshot
+// shows what changed since it was captured, rather than what deviates from a
+// spec.
+type Snapshot struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+
+	// KspecVersion is the version of kspec that captured the snapshot
+	KspecVersion string `json:"kspecVersion"`
+
+	// CapturedAt is when the snapshot was captured
+	CapturedAt time.Time `json:"capturedAt"`
+
+	// Cluster identifies the captured cluster
+	Cluster SnapshotCluster `json:"cluster"`
+
+	// Spec identifies the spec the cluster was captured for, if any
+	Spec *SpecInfo `json:"spec,omitempty"`
+
+	// PodSecurity summarises the Pod Security Standards namespaces enforce
+	PodSecurity PodSecuritySummary `json:"podSecurity"`
+
+	// Resources are the captured resources, ordered by kind, namespace and name
+	Resources []SnapshotResource `json:"resources"`
+}
+
+// SnapshotCluster identifies a captured cluster.
+type SnapshotCluster struct {
+	// Name is the kubeconfig cluster name, when known
+	Name string `json:"name,omitempty"`
+
+	// UID is the kube-system namespace UID, which distinguishes clusters
+	UID string `json:"uid,omitempty"`
+
+	// Version is the Kubernetes server version
+	Version string `json:"version,omitempty"`
+}
+
+// PodSecuritySummary summarises the cluster's Pod Security posture.
+type PodSecuritySummary struct {
+	// EnforceLevels counts namespaces by their enforced Pod Security Standard
+	// (privileged, baseline or restricted; "unset" without an enforce label)
+	EnforceLevels map[string]int `json:"enforceLevels"`
+}
+
+// SnapshotResource is a captured resource.
+type SnapshotResource struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+
+	// Spec holds the compared fields: the spec of policies and NetworkPolicies,
+	// the rules of roles, the roleRef and subjects of bindings and the Pod
+	// Security labels of namespaces
+	Spec map[string]interface{} `json:"spec"`
+}
+
+// path identifies the resource in drift events.
+func (r SnapshotResource) path() string {
+	return r.Kind + "/" + qualifiedName(r.Namespace, r.Name)
+}
+
+// LoadSnapshotFile loads a baseline snapshot from a JSON or YAML file.
+func LoadSnapshotFile(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot file %s: %w", path, err)
+	}
+
+	var snapshot Snapshot
+	if err := yaml.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot file %s: %w", path, err)
+	}
+	if snapshot.APIVersion != SnapshotAPIVersion || snapshot.Kind != SnapshotKind {
+		return nil, fmt.Errorf("snapshot file %s is not a %s %s", path, SnapshotAPIVersion, SnapshotKind)
+	}
+	return &snapshot, nil
+}
+
+// CaptureSnapshot captures the cluster's kspec-generated Kyverno
+// ClusterPolicies, RBAC roles and bindings, NetworkPolicies and namespace Pod
+// Security labels. Policies are left out when Kyverno is not installed, and
+// system roles and bindings (named "system:...") always are. The cluster name
+// and spec are left for the caller to fill in.
+func (d *Detector) CaptureSnapshot(ctx context.Context) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		APIVersion:   SnapshotAPIVersion,
+		Kind:         SnapshotKind,
+		KspecVersion: scanner.Version,
+		CapturedAt:   time.Now().UTC(),
+		PodSecurity:  PodSecuritySummary{EnforceLevels: map[string]int{}},
+		Resources:    []SnapshotResource{},
+	}
+	if version, err := d.client.Discovery().ServerVersion(); err == nil {
+		snapshot.Cluster.Version = version.GitVersion
+	}
+
+	add := func(kind, namespace, name string, fields interface{}) error {
+		spec, err := snapshotSpec(fields)
+		if err != nil {
+			return fmt.Errorf("failed to capture %s %s: %w", kind, qualifiedName(namespace, name), err)
+		}
+		snapshot.Resources = append(snapshot.Resources, SnapshotResource{
+			Kind:      kind,
+			Name:      name,
+			Namespace: namespace,
+			Spec:      spec,
+		})
+		return nil
+	}
+
+	installed, err := d.isKyvernoCRDInstalled()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover Kyverno CRDs: %w", err)
+	}
+	if installed {
+		policies, err := d.getClusterPolicies(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cluster policies: %w", err)
+		}
+		for _, policy := range policies {
+			if !d.isKspecGenerated(policy) {
+				continue
+			}
+			u := policy.(*unstructured.Unstructured).DeepCopy()
+			removeVolatileFields(u.Object)
+			if err := add("ClusterPolicy", "", u.GetName(), u.Object["spec"]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	clusterRoles, err := d.client.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster roles: %w", err)
+	}
+	for _, role := range clusterRoles.Items {
+		if isSystemRBAC(role.Name) {
+			continue
+		}
+		fields := struct {
+			Rules           []rbacv1.PolicyRule     `json:"rules,omitempty"`
+			AggregationRule *rbacv1.AggregationRule `json:"aggregationRule,omitempty"`
+		}{role.Rules, role.AggregationRule}
+		if err := add("ClusterRole", "", role.Name, fields); err != nil {
+			return nil, err
+		}
+	}
+
+	clusterRoleBindings, err := d.client.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster role bindings: %w", err)
+	}
+	for _, binding := range clusterRoleBindings.Items {
+		if isSystemRBAC(binding.Name) {
+			continue
+		}
+		if err := add("ClusterRoleBinding", "", binding.Name, bindingFields(binding.RoleRef, binding.Subjects)); err != nil {
+			return nil, err
+		}
+	}
+
+	roles, err := d.client.RbacV1().Roles("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	for _, role := range roles.Items {
+		if isSystemRBAC(role.Name) {
+			continue
+		}
+		fields := struct {
+			Rules []rbacv1.PolicyRule `json:"rules,omitempty"`
+		}{role.Rules}
+		if err := add("Role", role.Namespace, role.Name, fields); err != nil {
+			return nil, err
+		}
+	}
+
+	roleBindings, err := d.client.RbacV1().RoleBindings("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role bindings: %w", err)
+	}
+	for _, binding := range roleBindings.Items {
+		if isSystemRBAC(binding.Name) {
+			continue
+		}
+		if err := add("RoleBinding", binding.Namespace, binding.Name, bindingFields(binding.RoleRef, binding.Subjects)); err != nil {
+			return nil, err
+		}
+	}
+
+	networkPolicies, err := d.client.NetworkingV1().NetworkPolicies("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network policies: %w", err)
+	}
+	for _, policy := range networkPolicies.Items {
+		if err := add("NetworkPolicy", policy.Namespace, policy.Name, policy.Spec); err != nil {
+			return nil, err
+		}
+	}
+
+	namespaces, err := d.client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	for _, ns := range namespaces.Items {
+		if ns.Name == "kube-system" {
+			snapshot.Cluster.UID = string(ns.UID)
+		}
+
+		podSecurity := map[string]string{}
+		for key, value := range ns.Labels {
+			if strings.HasPrefix(key, podSecurityLabelPrefix) {
+				podSecurity[strings.TrimPrefix(key, podSecurityLabelPrefix)] = value
+			}
+		}
+		level := podSecurity["enforce"]
+		if level == "" {
+			level = podSecurityUnset
+		}
+		snapshot.PodSecurity.EnforceLevels[level]++
+
+		fields := struct {
+			PodSecurity map[string]string `json:"podSecurity,omitempty"`
+		}{podSecurity}
+		if err := add("Namespace", "", ns.Name, fields); err != nil {
+			return nil, err
+		}
+	}
+
+	sortSnapshotResources(snapshot.Resources)
+	return snapshot, nil
+}
+
+// DetectBaselineDrift detects the changes to the resources of a baseline
+// snapshot since it was captured: resources deleted since are reported as
+// missing, changed ones as modified and new ones as extra. Policy and RBAC
+// changes are policy and RBAC drift; NetworkPolicy changes are configuration
+// drift. A baseline captured from another cluster is an error.
+func (d *Detector) DetectBaselineDrift(ctx context.Context, baseline *Snapshot, opts DetectOptions) (*DriftReport, error) {
+	current, err := d.CaptureSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if baseline.Cluster.UID != "" && current.Cluster.UID != "" && baseline.Cluster.UID != current.Cluster.UID {
+		return nil, fmt.Errorf("baseline was captured from cluster %s, not this cluster (%s)",
+			baselineClusterName(baseline), current.Cluster.UID)
+	}
+
+	report := &DriftReport{
+		Timestamp: time.Now(),
+		Baseline: &BaselineInfo{
+			Cluster:    baseline.Cluster,
+			CapturedAt: baseline.CapturedAt,
+		},
+		Events: []DriftEvent{},
+		Drift: DriftSummary{
+			Detected: false,
+			Types:    []DriftType{},
+			Counts:   DriftCounts{},
+		},
+	}
+
+	since := baseline.CapturedAt.Format(time.RFC3339)
+	currentByPath := make(map[string]SnapshotResource, len(current.Resources))
+	for _, resource := range current.Resources {
+		currentByPath[resource.path()] = resource
+	}
+	baselineByPath := make(map[string]SnapshotResource, len(baseline.Resources))
+	for _, resource := range baseline.Resources {
+		baselineByPath[resource.path()] = resource
+	}
+
+	for _, expected := range baseline.Resources {
+		driftType := snapshotDriftType(expected.Kind)
+		if !d.isTypeEnabled(driftType, opts.EnabledTypes) {
+			continue
+		}
+		actual, exists := currentByPath[expected.path()]
+		switch {
+		case !exists:
+			report.Events = append(report.Events, baselineEvent(driftType, "missing", expected,
+				fmt.Sprintf("%s was deleted since the baseline (%s)", expected.path(), since)))
+		case !reflect.DeepEqual(expected.Spec, actual.Spec):
+			event := baselineEvent(driftType, "modified", expected,
+				fmt.Sprintf("%s was modified since the baseline (%s)", expected.path(), since))
+			event.Expected = expected.Spec
+			event.Actual = actual.Spec
+			event.Diff = diffFields(expected.Spec, actual.Spec)
+			report.Events = append(report.Events, event)
+		}
+	}
+	for _, actual := range current.Resources {
+		driftType := snapshotDriftType(actual.Kind)
+		if !d.isTypeEnabled(driftType, opts.EnabledTypes) {
+			continue
+		}
+		if _, exists := baselineByPath[actual.path()]; !exists {
+			event := baselineEvent(driftType, "extra", actual,
+				fmt.Sprintf("%s was created since the baseline (%s)", actual.path(), since))
+			event.Actual = actual.Spec
+			report.Events = append(report.Events, event)
+		}
+	}
+
+	d.updateSummary(report)
+	return report, nil
+}
+
+// baselineClusterName names the cluster a baseline was captured from.
+func baselineClusterName(baseline *Snapshot) string {
+	if baseline.Cluster.Name != "" {
+		return fmt.Sprintf("%s (%s)", baseline.Cluster.Name, baseline.Cluster.UID)
+	}
+	return baseline.Cluster.UID
+}
+
+// baselineEvent creates a drift event for a change to a snapshot resource.
+// Deleted policies and NetworkPolicies remove protection and new RBAC grants
+// access, so those rank above other changes.
+func baselineEvent(driftType DriftType, driftKind string, resource SnapshotResource, message string) DriftEvent {
+	severity := SeverityMedium
+	switch {
+	case driftKind == "missing" && (resource.Kind == "ClusterPolicy" || resource.Kind == "NetworkPolicy"):
+		severity = SeverityHigh
+	case driftKind == "extra" && driftType != DriftTypeRBAC:
+		severity = SeverityLow
+	}
+
+	event := DriftEvent{
+		Timestamp: time.Now(),
+		Type:      driftType,
+		Severity:  severity,
+		Resource: DriftResource{
+			Kind:      resource.Kind,
+			Name:      resource.Name,
+			Namespace: resource.Namespace,
+			Path:      resource.path(),
+		},
+		DriftKind: driftKind,
+		Message:   message,
+	}
+	if driftKind == "missing" {
+		event.Expected = resource.Spec
+	}
+	return event
+}
+
+// diffFields describes how the top-level fields of a resource's spec changed.
+func diffFields(before, after map[string]interface{}) *DriftDiff {
+	diff := &DriftDiff{
+		Added:    make(map[string]interface{}),
+		Removed:  make(map[string]interface{}),
+		Modified: make(map[string]DriftModification),
+	}
+	for key, oldValue := range before {
+		newValue, exists := after[key]
+		if !exists {
+			diff.Removed[key] = oldValue
+		} else if !reflect.DeepEqual(oldValue, newValue) {
+			diff.Modified[key] = DriftModification{OldValue: oldValue, NewValue: newValue}
+		}
+	}
+	for key, newValue := range after {
+		if _, exists := before[key]; !exists {
+			diff.Added[key] = newValue
+		}
+	}
+	return diff
+}
+
+// snapshotSpec converts the compared fields of a resource to their JSON form,
+// so captured resources compare equal to the same resources loaded from a file.
+func snapshotSpec(fields interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	spec := map[string]interface{}{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// bindingFields are the compared fields of a role binding.
+func bindingFields(roleRef rbacv1.RoleRef, subjects []rbacv1.Subject) interface{} {
+	return struct {
+		RoleRef  rbacv1.RoleRef   `json:"roleRef"`
+		Subjects []rbacv1.Subject `json:"subjects,omitempty"`
+	}{roleRef, subjects}
+}
+
+// isSystemRBAC reports whether a role or binding is managed by Kubernetes.
+func isSystemRBAC(name string) bool {
+	return strings.HasPrefix(name, "system:")
+}
+
+// snapshotDriftType returns the drift type changes to a kind are reported as.
+func snapshotDriftType(kind string) DriftType {
+	for _, entry := range snapshotKindOrder {
+		if entry.kind == kind {
+			return entry.driftType
+		}
+	}
+	return DriftTypeConfiguration
+}
+
+// sortSnapshotResources orders resources by kind, namespace and name.
+func sortSnapshotResources(resources []SnapshotResource) {
+	rank := func(kind string) int {
+		for i, entry := range snapshotKindOrder {
+			if entry.kind == kind {
+				return i
+			}
+		}
+		return len(snapshotKindOrder)
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		a, b := resources[i], resources[j]
+		if rank(a.Kind) != rank(b.Kind) {
+			return rank(a.Kind) < rank(b.Kind)
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
@@ -0,0 +1,225 @@
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func snapshotTestPolicy() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "kyverno.io/v1",
+			"kind":       "ClusterPolicy",
+			"metadata": map[string]interface{}{
+				"name":            "require-run-as-non-root",
+				"resourceVersion": "42",
+				"annotations": map[string]interface{}{
+					"kspec.dev/generated": "true",
+				},
+			},
+			"spec": map[string]interface{}{
+				"validationFailureAction": "Enforce",
+				"rules": []interface{}{
+					map[string]interface{}{"name": "check-run-as-non-root"},
+				},
+			},
+		},
+	}
+}
+
+func TestCaptureSnapshot(t *testing.T) {
+	ctx := context.Background()
+	unmanaged := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "kyverno.io/v1",
+		"kind":       "ClusterPolicy",
+		"metadata":   map[string]interface{}{"name": "team-policy"},
+	}}
+	client, dynamicClient := createTestClients(snapshotTestPolicy(), unmanaged)
+	client.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "kube-system", UID: types.UID("cluster-1")},
+	}, metav1.CreateOptions{})
+	client.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Labels: map[string]string{
+			"pod-security.kubernetes.io/enforce": "restricted",
+			"team":                               "payments",
+		}},
+	}, metav1.CreateOptions{})
+	client.RbacV1().ClusterRoles().Create(ctx, &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-reader"},
+		Rules:      []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}},
+	}, metav1.CreateOptions{})
+	client.RbacV1().ClusterRoles().Create(ctx, &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "system:node"},
+	}, metav1.CreateOptions{})
+	client.RbacV1().RoleBindings("apps").Create(ctx, &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "readers", Namespace: "apps"},
+		RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "pod-reader"},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "devs"}},
+	}, metav1.CreateOptions{})
+	client.NetworkingV1().NetworkPolicies("apps").Create(ctx, &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-deny", Namespace: "apps"},
+		Spec:       networkingv1.NetworkPolicySpec{PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}},
+	}, metav1.CreateOptions{})
+
+	snapshot, err := NewDetector(client, dynamicClient).CaptureSnapshot(ctx)
+	if err != nil {
+		t.Fatalf("CaptureSnapshot failed: %v", err)
+	}
+
+	if snapshot.Cluster.UID != "cluster-1" {
+		t.Errorf("Expected cluster UID cluster-1, got %q", snapshot.Cluster.UID)
+	}
+	if snapshot.KspecVersion == "" {
+		t.Error("Expected the snapshot to record the kspec version")
+	}
+	if levels := snapshot.PodSecurity.EnforceLevels; levels["restricted"] != 1 || levels["unset"] != 1 {
+		t.Errorf("Expected one restricted and one unset namespace, got %v", levels)
+	}
+	paths := []string{}
+	for _, resource := range snapshot.Resources {
+		paths = append(paths, resource.path())
+	}
+	expected := []string{
+		"ClusterPolicy/require-run-as-non-root",
+		"ClusterRole/pod-reader",
+		"RoleBinding/apps/readers",
+		"NetworkPolicy/apps/default-deny",
+		"Namespace/apps",
+		"Namespace/kube-system",
+	}
+	if len(paths) != len(expected) {
+		t.Fatalf("Expected resources %v, got %v", expected, paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("Expected resources %v, got %v", expected, paths)
+			break
+		}
+	}
+
+	binding := snapshot.Resources[2].Spec
+	if roleRef, ok := binding["roleRef"].(map[string]interface{}); !ok || roleRef["name"] != "pod-reader" {
+		t.Errorf("Expected binding roleRef to be captured, got %v", binding)
+	}
+	namespace := snapshot.Resources[4].Spec
+	if podSecurity, ok := namespace["podSecurity"].(map[string]interface{}); !ok || len(podSecurity) != 1 || podSecurity["enforce"] != "restricted" {
+		t.Errorf("Expected only the Pod Security labels of the namespace, got %v", namespace)
+	}
+}
+
+func TestDetectBaselineDrift(t *testing.T) {
+	ctx := context.Background()
+	client, dynamicClient := createTestClients(snapshotTestPolicy())
+	client.RbacV1().ClusterRoles().Create(ctx, &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-reader"},
+		Rules:      []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}},
+	}, metav1.CreateOptions{})
+	client.NetworkingV1().NetworkPolicies("apps").Create(ctx, &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-deny", Namespace: "apps"},
+	}, metav1.CreateOptions{})
+	detector := NewDetector(client, dynamicClient)
+
+	// Round-trip the baseline through a file, as kspec snapshot and drift
+	// detect --baseline do
+	captured, err := detector.CaptureSnapshot(ctx)
+	if err != nil {
+		t.Fatalf("CaptureSnapshot failed: %v", err)
+	}
+	data, _ := json.Marshal(captured)
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	baseline, err := LoadSnapshotFile(path)
+	if err != nil {
+		t.Fatalf("LoadSnapshotFile failed: %v", err)
+	}
+
+	report, err := detector.DetectBaselineDrift(ctx, baseline, DetectOptions{})
+	if err != nil {
+		t.Fatalf("DetectBaselineDrift failed: %v", err)
+	}
+	if report.Drift.Detected {
+		t.Fatalf("Expected no drift against a fresh baseline, got %+v", report.Events)
+	}
+
+	client.RbacV1().ClusterRoles().Update(ctx, &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-reader"},
+		Rules:      []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods", "secrets"}, Verbs: []string{"get"}}},
+	}, metav1.UpdateOptions{})
+	client.RbacV1().ClusterRoleBindings().Create(ctx, &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "readers"},
+		RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "pod-reader"},
+	}, metav1.CreateOptions{})
+	client.NetworkingV1().NetworkPolicies("apps").Delete(ctx, "default-deny", metav1.DeleteOptions{})
+
+	report, err = detector.DetectBaselineDrift(ctx, baseline, DetectOptions{})
+	if err != nil {
+		t.Fatalf("DetectBaselineDrift failed: %v", err)
+	}
+
+	byPath := map[string]DriftEvent{}
+	for _, event := range report.Events {
+		byPath[event.Resource.Path] = event
+	}
+	if len(byPath) != 3 {
+		t.Fatalf("Expected 3 drift events, got %d: %+v", len(report.Events), byPath)
+	}
+	if role := byPath["ClusterRole/pod-reader"]; role.DriftKind != "modified" || role.Type != DriftTypeRBAC || role.Diff.Modified["rules"].NewValue == nil {
+		t.Errorf("Expected modified RBAC event with a rules diff, got %+v", role)
+	}
+	if binding := byPath["ClusterRoleBinding/readers"]; binding.DriftKind != "extra" || binding.Severity != SeverityMedium {
+		t.Errorf("Expected medium extra event for the new binding, got %s/%s", binding.DriftKind, binding.Severity)
+	}
+	if netpol := byPath["NetworkPolicy/apps/default-deny"]; netpol.DriftKind != "missing" || netpol.Type != DriftTypeConfiguration || netpol.Severity != SeverityHigh {
+		t.Errorf("Expected high missing configuration event for the deleted NetworkPolicy, got %+v", netpol)
+	}
+	if report.Drift.Counts.RBAC != 2 || report.Drift.Counts.Configuration != 1 {
+		t.Errorf("Unexpected counts: %+v", report.Drift.Counts)
+	}
+	if report.Baseline == nil || !report.Baseline.CapturedAt.Equal(baseline.CapturedAt) {
+		t.Errorf("Expected the report to identify the baseline, got %+v", report.Baseline)
+	}
+
+	report, err = detector.DetectBaselineDrift(ctx, baseline, DetectOptions{EnabledTypes: []DriftType{DriftTypeConfiguration}})
+	if err != nil {
+		t.Fatalf("DetectBaselineDrift failed: %v", err)
+	}
+	if len(report.Events) != 1 || report.Events[0].Resource.Kind != "NetworkPolicy" {
+		t.Errorf("Expected only the NetworkPolicy event, got %+v", report.Events)
+	}
+}
+
+func TestDetectBaselineDrift_OtherCluster(t *testing.T) {
+	ctx := context.Background()
+	client, dynamicClient := createTestClientsWithoutKyverno()
+	client.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "kube-system", UID: types.UID("cluster-2")},
+	}, metav1.CreateOptions{})
+
+	baseline := &Snapshot{APIVersion: SnapshotAPIVersion, Kind: SnapshotKind, Cluster: SnapshotCluster{Name: "prod", UID: "cluster-1"}}
+	_, err := NewDetector(client, dynamicClient).DetectBaselineDrift(ctx, baseline, DetectOptions{})
+	if err == nil {
+		t.Fatal("Expected an error for a baseline of another cluster")
+	}
+}
+
+func TestLoadSnapshotFile_WrongKind(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(path, []byte("apiVersion: kspec.dev/v1\nkind: ClusterSpecification\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSnapshotFile(path); err == nil {
+		t.Error("Expected an error for a file that is not a snapshot")
+	}
+}
@@ -1,15 +1,35 @@
 package drift
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
 )
 
 // createTestClients creates properly configured fake clients for testing.
-// This sets up the dynamic client with Kyverno GVR support.
+// This sets up the dynamic client with Kyverno GVR support and registers the
+// Kyverno ClusterPolicy CRD in discovery.
 func createTestClients(initialObjects ...runtime.Object) (*fake.Clientset, *dynamicfake.FakeDynamicClient) {
+	client, dynamicClient := createTestClientsWithoutKyverno(initialObjects...)
+
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: kyvernoGroupVersion,
+			APIResources: []metav1.APIResource{
+				{Name: "clusterpolicies", Kind: "ClusterPolicy"},
+			},
+		},
+	}
+
+	return client, dynamicClient
+}
+
+// createTestClientsWithoutKyverno creates fake clients for a cluster where the
+// Kyverno CRDs are not installed.
+func createTestClientsWithoutKyverno(initialObjects ...runtime.Object) (*fake.Clientset, *dynamicfake.FakeDynamicClient) {
 	client := fake.NewSimpleClientset()
 	scheme := runtime.NewScheme()
 
@@ -1,6 +1,8 @@
 package drift
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -18,8 +20,82 @@ const (
 
 	// DriftTypeConfiguration indicates configuration drift (cluster config).
 	DriftTypeConfiguration DriftType = "configuration"
+
+	// DriftTypeRBAC indicates RBAC drift (roles and bindings).
+	DriftTypeRBAC DriftType = "rbac"
 )
 
+// DetectableTypes lists the drift types the detector knows how to check.
+var DetectableTypes = []DriftType{DriftTypePolicy, DriftTypeCompliance, DriftTypeRBAC}
+
+// BaselineTypes lists the drift types detected against a baseline snapshot.
+// NetworkPolicy and namespace changes are configuration drift.
+var BaselineTypes = []DriftType{DriftTypePolicy, DriftTypeRBAC, DriftTypeConfiguration}
+
+// driftTypeAliases maps the resource names accepted by ParseDriftTypes to the
+// drift type that covers them. NetworkPolicy and namespace Pod Security label
+// changes are configuration drift, which is only detected against a baseline.
+var driftTypeAliases = map[string]DriftType{
+	"netpol": DriftTypeConfiguration,
+	"psa":    DriftTypeConfiguration,
+}
+
+// ParseDriftTypes converts drift type names (e.g. from a CLI flag) to DriftTypes.
+// Unknown names are rejected. An empty list returns nil, which enables all types.
+func ParseDriftTypes(names []string) ([]DriftType, error) {
+	return parseDriftTypes(names, DetectableTypes)
+}
+
+// ParseBaselineDriftTypes is ParseDriftTypes for drift detected against a
+// baseline snapshot, accepting the names of BaselineTypes.
+func ParseBaselineDriftTypes(names []string) ([]DriftType, error) {
+	return parseDriftTypes(names, BaselineTypes)
+}
+
+// parseDriftTypes converts drift type names to DriftTypes, rejecting names not
+// in detectable. The aliases netpol and psa resolve to configuration drift and
+// are rejected as not yet supported where configuration drift is not detectable.
+func parseDriftTypes(names []string, detectable []DriftType) ([]DriftType, error) {
+	var types []DriftType
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		driftType, isAlias := driftTypeAliases[name]
+		if !isAlias {
+			driftType = DriftType(name)
+		}
+
+		if !containsType(detectable, driftType) {
+			if isAlias {
+				return nil, fmt.Errorf("drift type '%s' is not yet supported here: it maps to %s drift, which is only detected against a baseline snapshot", name, driftType)
+			}
+			valid := make([]string, len(detectable))
+			for i, known := range detectable {
+				valid[i] = string(known)
+			}
+			return nil, fmt.Errorf("unknown drift type '%s' (valid types: %s)", name, strings.Join(valid, ", "))
+		}
+
+		if !containsType(types, driftType) {
+			types = append(types, driftType)
+		}
+	}
+	return types, nil
+}
+
+// containsType reports whether types includes driftType.
+func containsType(types []DriftType, driftType DriftType) bool {
+	for _, t := range types {
+		if t == driftType {
+			return true
+		}
+	}
+	return false
+}
+
 // DriftStatus represents the drift status after remediation.
 type DriftStatus string
 
@@ -136,6 +212,16 @@ type RemediationResult struct {
 
 	// Details about what was done
 	Details string `json:"details,omitempty"`
+
+	// Patches are ready-to-apply workload patches for compliance drift
+	Patches []WorkloadPatch `json:"patches,omitempty"`
+
+	// Manifest is the resource a dry run would create, as YAML
+	Manifest string `json:"manifest,omitempty"`
+
+	// Diff is the unified diff from the live resource to the one a dry run
+	// would apply
+	Diff string `json:"diff,omitempty"`
 }
 
 // DriftReport represents a complete drift detection report.
@@ -146,11 +232,30 @@ type DriftReport struct {
 	// Spec information
 	Spec SpecInfo `json:"spec"`
 
+	// Baseline identifies the snapshot drift was detected against, if any
+	Baseline *BaselineInfo `json:"baseline,omitempty"`
+
 	// Drift summary
 	Drift DriftSummary `json:"drift"`
 
 	// Individual drift events
 	Events []DriftEvent `json:"events"`
+
+	// Skipped lists drift types that could not be evaluated
+	Skipped []SkippedDrift `json:"skipped,omitempty"`
+
+	// RemediationOrder lists the resources remediation was applied to, in order
+	RemediationOrder []string `json:"remediation_order,omitempty"`
+}
+
+// SkippedDrift records a drift type that was not evaluated, e.g. because the
+// backend it inspects is not installed.
+type SkippedDrift struct {
+	// Type of drift that was skipped
+	Type DriftType `json:"type"`
+
+	// Reason the drift type was not evaluated
+	Reason string `json:"reason"`
 }
 
 // SpecInfo contains information about the specification.
@@ -159,6 +264,12 @@ type SpecInfo struct {
 	Version string `json:"version"`
 }
 
+// BaselineInfo identifies a baseline snapshot.
+type BaselineInfo struct {
+	Cluster    SnapshotCluster `json:"cluster"`
+	CapturedAt time.Time       `json:"captured_at"`
+}
+
 // DriftSummary provides a summary of all drift.
 type DriftSummary struct {
 	// Whether any drift was detected
@@ -180,6 +291,7 @@ type DriftCounts struct {
 	Policies      int `json:"policies"`
 	Compliance    int `json:"compliance"`
 	Configuration int `json:"configuration"`
+	RBAC          int `json:"rbac"`
 }
 
 // DetectOptions contains options for drift detection.
@@ -211,8 +323,21 @@ type RemediateOptions struct {
 	// AutoRemediate enables automatic remediation
 	AutoRemediate bool
 
-	// Force enables remediation even for risky operations
+	// Force enables remediation even for risky operations: deleting extra
+	// resources and changing RBAC
 	Force bool
+
+	// Concurrency bounds how many independent remediations run at once
+	// (default 4)
+	Concurrency int
+
+	// ApplyPatches applies the workload patches generated for compliance
+	// drift instead of only reporting them
+	ApplyPatches bool
+
+	// EnforceCompliance applies the Kyverno policies that prevent failed
+	// compliance checks from recurring, for the checks that map to one
+	EnforceCompliance bool
 }
 
 // PolicyDrift represents drift in Kyverno policies.
@@ -0,0 +1,199 @@
+package enforcer
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+	"github.com/cloudcwfranck/kspec/pkg/enforcer/kyverno"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+)
+
+// KustomizationFile is the name of the kustomization listing a bundle's manifests.
+const KustomizationFile = "kustomization.yaml"
+
+// BundleOptions configures a GitOps policy bundle.
+type BundleOptions struct {
+	// Namespace is set on namespaced resources, such as the AlertConfig
+	Namespace string
+
+	// Labels are added to every resource in the bundle
+	Labels map[string]string
+
+	// AlertConfig, if set, is included as an AlertConfig named after the spec
+	AlertConfig *kspecv1alpha1.AlertConfigSpec
+}
+
+// BundleFile is one file of a policy bundle.
+type BundleFile struct {
+	// Path is the slash-separated path of the file within the bundle
+	Path string
+
+	// Data is the file content
+	Data []byte
+}
+
+// kustomization is the subset of a kustomize Kustomization a bundle uses.
+type kustomization struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Resources  []string `json:"resources"`
+}
+
+// GenerateBundle packages a cluster specification for GitOps: a ClusterSpecification
+// resource, the optional AlertConfig and the generated Kyverno policies, one manifest
+// per resource, followed by a kustomization.yaml listing them.
+func GenerateBundle(clusterSpec *spec.ClusterSpecification, opts BundleOptions) ([]BundleFile, error) {
+	if opts.Namespace != "" {
+		if problems := validation.IsDNS1123Label(opts.Namespace); len(problems) > 0 {
+			return nil, fmt.Errorf("invalid namespace %q: %s", opts.Namespace, strings.Join(problems, "; "))
+		}
+	}
+	if err := validateLabels(opts.Labels); err != nil {
+		return nil, err
+	}
+
+	policies, err := kyverno.NewGenerator().GeneratePolicies(clusterSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate policies: %w", err)
+	}
+	if err := validateKyvernoPolicies(kyverno.NewValidator(), policies); err != nil {
+		return nil, fmt.Errorf("policy validation failed: %w", err)
+	}
+
+	// The ClusterSpecification carries the spec's own labels as well as its ownership labels
+	specLabels := map[string]string{}
+	for key, value := range clusterSpec.Metadata.Labels {
+		specLabels[key] = value
+	}
+	for key, value := range clusterSpec.Metadata.OwnershipLabels() {
+		specLabels[key] = value
+	}
+
+	clusterSpecification := &kspecv1alpha1.ClusterSpecification{}
+	clusterSpecification.APIVersion = kspecv1alpha1.GroupVersion.String()
+	clusterSpecification.Kind = "ClusterSpecification"
+	clusterSpecification.Name = clusterSpec.Metadata.Name
+	clusterSpecification.Labels = specLabels
+	clusterSpecification.Spec.SpecFields = clusterSpec.Spec
+
+	var files []BundleFile
+	file, err := bundleManifest("clusterspecification.yaml", clusterSpecification, "", opts.Labels)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, file)
+
+	if opts.AlertConfig != nil {
+		alertConfig := &kspecv1alpha1.AlertConfig{Spec: *opts.AlertConfig}
+		alertConfig.APIVersion = kspecv1alpha1.GroupVersion.String()
+		alertConfig.Kind = "AlertConfig"
+		alertConfig.Name = clusterSpec.Metadata.Name
+
+		file, err := bundleManifest("alertconfig.yaml", alertConfig, opts.Namespace, opts.Labels)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	for _, policyObj := range policies {
+		policy := policyObj.(*kyverno.ClusterPolicy)
+		file, err := bundleManifest(path.Join("policies", policy.Name+".yaml"), policy, "", opts.Labels)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	resources := make([]string, 0, len(files))
+	for _, file := range files {
+		resources = append(resources, file.Path)
+	}
+	data, err := yaml.Marshal(kustomization{
+		APIVersion: "kustomize.config.k8s.io/v1beta1",
+		Kind:       "Kustomization",
+		Resources:  resources,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal kustomization: %w", err)
+	}
+
+	return append(files, BundleFile{Path: KustomizationFile, Data: data}), nil
+}
+
+// WriteBundle writes the files of a bundle under dir, creating directories as needed.
+// Files from an earlier bundle that are no longer generated are left in place but
+// are not listed in the new kustomization.
+func WriteBundle(dir string, files []BundleFile) error {
+	for _, file := range files {
+		filename := filepath.Join(dir, filepath.FromSlash(file.Path))
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", file.Path, err)
+		}
+		if err := os.WriteFile(filename, file.Data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.Path, err)
+		}
+	}
+	return nil
+}
+
+// bundleManifest renders obj as a manifest without server-populated fields,
+// stamping the namespace, if any, and the common labels.
+func bundleManifest(filePath string, obj runtime.Object, namespace string, labels map[string]string) (BundleFile, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return BundleFile{}, fmt.Errorf("failed to convert %s: %w", filePath, err)
+	}
+	manifest := &unstructured.Unstructured{Object: content}
+	unstructured.RemoveNestedField(manifest.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(manifest.Object, "status")
+
+	if namespace != "" {
+		manifest.SetNamespace(namespace)
+	}
+	if len(labels) > 0 {
+		merged := manifest.GetLabels()
+		if merged == nil {
+			merged = map[string]string{}
+		}
+		for key, value := range labels {
+			merged[key] = value
+		}
+		manifest.SetLabels(merged)
+	}
+
+	data, err := yaml.Marshal(manifest.Object)
+	if err != nil {
+		return BundleFile{}, fmt.Errorf("failed to marshal %s: %w", filePath, err)
+	}
+	return BundleFile{Path: filePath, Data: data}, nil
+}
+
+// validateLabels checks that labels are valid Kubernetes label keys and values.
+func validateLabels(labels map[string]string) error {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if problems := validation.IsQualifiedName(key); len(problems) > 0 {
+			return fmt.Errorf("invalid label key %q: %s", key, strings.Join(problems, "; "))
+		}
+		if problems := validation.IsValidLabelValue(labels[key]); len(problems) > 0 {
+			return fmt.Errorf("invalid value for label %s: %s", key, strings.Join(problems, "; "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package enforcer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+)
+
+func bundleTestSpec() *spec.ClusterSpecification {
+	return &spec.ClusterSpecification{
+		APIVersion: "kspec.dev/v1",
+		Kind:       "ClusterSpecification",
+		Metadata:   spec.Metadata{Name: "prod", Version: "1.0.0", Team: "platform"},
+		Spec: spec.SpecFields{
+			Kubernetes: spec.KubernetesSpec{MinVersion: "1.26.0", MaxVersion: "1.30.0"},
+			Workloads: &spec.WorkloadsSpec{
+				Images: &spec.ImageSpec{BlockedRegistries: []string{"docker.io/"}, RequireDigests: true},
+			},
+		},
+	}
+}
+
+// decodeBundle parses the manifests of a bundle by path.
+func decodeBundle(t *testing.T, files []BundleFile) map[string]*unstructured.Unstructured {
+	t.Helper()
+
+	manifests := map[string]*unstructured.Unstructured{}
+	for _, file := range files {
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(file.Data, &obj.Object); err != nil {
+			t.Fatalf("Failed to parse %s: %v", file.Path, err)
+		}
+		manifests[file.Path] = obj
+	}
+	return manifests
+}
+
+func TestGenerateBundle(t *testing.T) {
+	files, err := GenerateBundle(bundleTestSpec(), BundleOptions{
+		Namespace:   "kspec-system",
+		Labels:      map[string]string{"env": "prod"},
+		AlertConfig: &kspecv1alpha1.AlertConfigSpec{DefaultSeverity: "critical"},
+	})
+	if err != nil {
+		t.Fatalf("GenerateBundle failed: %v", err)
+	}
+	manifests := decodeBundle(t, files)
+
+	kustomization := manifests[KustomizationFile]
+	if kustomization == nil {
+		t.Fatal("Expected a kustomization.yaml")
+	}
+	resources, _, _ := unstructured.NestedStringSlice(kustomization.Object, "resources")
+	want := []string{
+		"clusterspecification.yaml",
+		"alertconfig.yaml",
+		"policies/require-image-digests.yaml",
+		"policies/block-image-registries.yaml",
+	}
+	if len(resources) != len(want) {
+		t.Fatalf("Expected resources %v, got %v", want, resources)
+	}
+	for i := range want {
+		if resources[i] != want[i] {
+			t.Errorf("Expected resources %v, got %v", want, resources)
+			break
+		}
+	}
+
+	for _, path := range resources {
+		manifest := manifests[path]
+		if manifest == nil {
+			t.Fatalf("kustomization lists %s, which is not in the bundle", path)
+		}
+		if manifest.GetLabels()["env"] != "prod" {
+			t.Errorf("Expected %s to have label env=prod, got %v", path, manifest.GetLabels())
+		}
+		if _, found := manifest.Object["status"]; found {
+			t.Errorf("Expected %s to have no status", path)
+		}
+	}
+
+	clusterSpec := manifests["clusterspecification.yaml"]
+	if clusterSpec.GetKind() != "ClusterSpecification" || clusterSpec.GetAPIVersion() != "kspec.io/v1alpha1" {
+		t.Errorf("Unexpected ClusterSpecification type %s %s", clusterSpec.GetAPIVersion(), clusterSpec.GetKind())
+	}
+	if clusterSpec.GetName() != "prod" || clusterSpec.GetNamespace() != "" {
+		t.Errorf("Expected cluster-scoped ClusterSpecification prod, got %s/%s", clusterSpec.GetNamespace(), clusterSpec.GetName())
+	}
+	if clusterSpec.GetLabels()[spec.TeamLabel] != "platform" {
+		t.Errorf("Expected ClusterSpecification to carry the team label, got %v", clusterSpec.GetLabels())
+	}
+	if registries, _, _ := unstructured.NestedStringSlice(clusterSpec.Object, "spec", "workloads", "images", "blockedRegistries"); len(registries) != 1 {
+		t.Errorf("Expected ClusterSpecification to carry the spec fields, got %v", clusterSpec.Object["spec"])
+	}
+
+	alertConfig := manifests["alertconfig.yaml"]
+	if alertConfig.GetKind() != "AlertConfig" || alertConfig.GetNamespace() != "kspec-system" {
+		t.Errorf("Expected AlertConfig in kspec-system, got %s in %q", alertConfig.GetKind(), alertConfig.GetNamespace())
+	}
+
+	policy := manifests["policies/block-image-registries.yaml"]
+	if policy.GetKind() != "ClusterPolicy" || policy.GetNamespace() != "" {
+		t.Errorf("Expected cluster-scoped ClusterPolicy, got %s in %q", policy.GetKind(), policy.GetNamespace())
+	}
+}
+
+func TestGenerateBundle_WithoutAlertConfig(t *testing.T) {
+	files, err := GenerateBundle(bundleTestSpec(), BundleOptions{})
+	if err != nil {
+		t.Fatalf("GenerateBundle failed: %v", err)
+	}
+
+	for _, file := range files {
+		if file.Path == "alertconfig.yaml" {
+			t.Error("Expected no AlertConfig without --alert-config")
+		}
+	}
+}
+
+func TestGenerateBundle_InvalidMetadata(t *testing.T) {
+	tests := []struct {
+		name string
+		opts BundleOptions
+	}{
+		{name: "namespace", opts: BundleOptions{Namespace: "Not_A_Namespace"}},
+		{name: "label key", opts: BundleOptions{Labels: map[string]string{"bad key": "x"}}},
+		{name: "label value", opts: BundleOptions{Labels: map[string]string{"env": "not a value"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GenerateBundle(bundleTestSpec(), tt.opts); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestWriteBundle(t *testing.T) {
+	files, err := GenerateBundle(bundleTestSpec(), BundleOptions{})
+	if err != nil {
+		t.Fatalf("GenerateBundle failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := WriteBundle(dir, files); err != nil {
+		t.Fatalf("WriteBundle failed: %v", err)
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file.Path)))
+		if err != nil {
+			t.Fatalf("Expected %s to be written: %v", file.Path, err)
+		}
+		if string(data) != string(file.Data) {
+			t.Errorf("Unexpected content of %s", file.Path)
+		}
+	}
+}
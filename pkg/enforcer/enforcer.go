@@ -4,56 +4,122 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/cloudcwfranck/kspec/pkg/enforcer/gatekeeper"
 	"github.com/cloudcwfranck/kspec/pkg/enforcer/kyverno"
 	"github.com/cloudcwfranck/kspec/pkg/spec"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
+// constraintCRDTimeout bounds how long Enforce waits for Gatekeeper to create the
+// CRD of a freshly applied ConstraintTemplate before applying its Constraint.
+const constraintCRDTimeout = 30 * time.Second
+
+// Engine selects the admission controller policies are generated for.
+type Engine string
+
+const (
+	// EngineKyverno generates Kyverno ClusterPolicies
+	EngineKyverno Engine = "kyverno"
+	// EngineGatekeeper generates OPA Gatekeeper ConstraintTemplates and Constraints
+	EngineGatekeeper Engine = "gatekeeper"
+)
+
+// ParseEngine parses a policy engine name. An empty name selects Kyverno.
+func ParseEngine(name string) (Engine, error) {
+	switch Engine(name) {
+	case "", EngineKyverno:
+		return EngineKyverno, nil
+	case EngineGatekeeper:
+		return EngineGatekeeper, nil
+	default:
+		return "", fmt.Errorf("unknown policy engine %q (supported: kyverno, gatekeeper)", name)
+	}
+}
+
 // Enforcer orchestrates policy enforcement.
 type Enforcer struct {
-	client           kubernetes.Interface
-	dynamicClient    dynamic.Interface
-	kyvernoGen       *kyverno.Generator
-	kyvernoInstaller *kyverno.Installer
-	kyvernoValidator *kyverno.Validator
+	client              kubernetes.Interface
+	dynamicClient       dynamic.Interface
+	kyvernoGen          *kyverno.Generator
+	kyvernoInstaller    *kyverno.Installer
+	kyvernoValidator    *kyverno.Validator
+	gatekeeperGen       *gatekeeper.Generator
+	gatekeeperInstaller *gatekeeper.Installer
 }
 
 // NewEnforcer creates a new policy enforcer.
 func NewEnforcer(client kubernetes.Interface, dynamicClient dynamic.Interface) *Enforcer {
 	return &Enforcer{
-		client:           client,
-		dynamicClient:    dynamicClient,
-		kyvernoGen:       kyverno.NewGenerator(),
-		kyvernoInstaller: kyverno.NewInstaller(),
-		kyvernoValidator: kyverno.NewValidator(),
+		client:              client,
+		dynamicClient:       dynamicClient,
+		kyvernoGen:          kyverno.NewGenerator(),
+		kyvernoInstaller:    kyverno.NewInstaller(),
+		kyvernoValidator:    kyverno.NewValidator(),
+		gatekeeperGen:       gatekeeper.NewGenerator(),
+		gatekeeperInstaller: gatekeeper.NewInstaller(),
 	}
 }
 
+// NewEnforcerFromConfig creates a new policy enforcer whose typed and dynamic
+// clients are built from the given REST config.
+func NewEnforcerFromConfig(config *rest.Config) (*Enforcer, error) {
+	if config == nil {
+		return nil, fmt.Errorf("rest config cannot be nil")
+	}
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	return NewEnforcer(client, dynamicClient), nil
+}
+
 // EnforceOptions contains options for policy enforcement.
 type EnforceOptions struct {
 	DryRun      bool
 	SkipInstall bool
+
+	// Engine selects the policy backend (default: Kyverno)
+	Engine Engine
 }
 
 // EnforceResult contains the results of policy enforcement.
 type EnforceResult struct {
-	KyvernoInstalled  bool
-	KyvernoVersion    string
-	PoliciesGenerated int
-	PoliciesApplied   int
-	Policies          []runtime.Object
-	Errors            []string
+	Engine              Engine
+	KyvernoInstalled    bool
+	KyvernoVersion      string
+	GatekeeperInstalled bool
+	GatekeeperVersion   string
+	PoliciesGenerated   int
+	PoliciesApplied     int
+	Policies            []runtime.Object
+	Errors              []string
 }
 
 // Enforce generates and optionally deploys policies from a cluster specification.
 func (e *Enforcer) Enforce(ctx context.Context, clusterSpec *spec.ClusterSpecification, opts EnforceOptions) (*EnforceResult, error) {
+	if opts.Engine == EngineGatekeeper {
+		return e.enforceGatekeeper(ctx, clusterSpec, opts)
+	}
+
 	result := &EnforceResult{
+		Engine:   EngineKyverno,
 		Policies: []runtime.Object{},
 		Errors:   []string{},
 	}
@@ -112,71 +178,177 @@ func (e *Enforcer) Enforce(ctx context.Context, clusterSpec *spec.ClusterSpecifi
 	return result, nil
 }
 
-// applyPolicies applies Kyverno policies to the cluster.
-func (e *Enforcer) applyPolicies(ctx context.Context, policies []runtime.Object) (int, []string) {
-	applied := 0
-	errors := []string{}
+// enforceGatekeeper generates and optionally deploys Gatekeeper ConstraintTemplates
+// and Constraints from a cluster specification.
+func (e *Enforcer) enforceGatekeeper(ctx context.Context, clusterSpec *spec.ClusterSpecification, opts EnforceOptions) (*EnforceResult, error) {
+	result := &EnforceResult{
+		Engine:   EngineGatekeeper,
+		Policies: []runtime.Object{},
+		Errors:   []string{},
+	}
 
-	// Define Kyverno ClusterPolicy GVR
-	gvr := schema.GroupVersionResource{
-		Group:    "kyverno.io",
-		Version:  "v1",
-		Resource: "clusterpolicies",
+	installed, err := e.gatekeeperInstaller.IsInstalled(ctx, e.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check Gatekeeper installation: %w", err)
 	}
 
-	for i, policyObj := range policies {
-		// Convert typed ClusterPolicy to unstructured for dynamic client
-		unstructuredPolicy, err := runtime.DefaultUnstructuredConverter.ToUnstructured(policyObj)
-		if err != nil {
-			errors = append(errors, fmt.Sprintf("policy[%d]: failed to convert: %v", i, err))
-			continue
+	result.GatekeeperInstalled = installed
+
+	if installed {
+		version, err := e.gatekeeperInstaller.GetVersion(ctx, e.client)
+		if err == nil {
+			result.GatekeeperVersion = version
 		}
+	}
+
+	policies, err := e.gatekeeperGen.GeneratePolicies(clusterSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate policies: %w", err)
+	}
 
-		u := &unstructured.Unstructured{Object: unstructuredPolicy}
+	result.Policies = policies
+	result.PoliciesGenerated = len(policies)
 
-		// Ensure APIVersion and Kind are set (required by dynamic client)
-		u.SetAPIVersion("kyverno.io/v1")
-		u.SetKind("ClusterPolicy")
+	if opts.DryRun {
+		return result, nil
+	}
 
-		policyName := u.GetName()
-		if policyName == "" {
-			errors = append(errors, fmt.Sprintf("policy[%d]: missing name", i))
-			continue
-		}
+	if !installed && !opts.SkipInstall {
+		return result, fmt.Errorf("Gatekeeper is not installed. Install it first or use --skip-install flag.\n\n%s",
+			e.gatekeeperInstaller.GetInstallInstructions())
+	}
 
-		// Try to create the policy, or update if it already exists
-		_, createErr := e.dynamicClient.Resource(gvr).Create(ctx, u, metav1.CreateOptions{})
-		if createErr != nil {
-			if strings.Contains(createErr.Error(), "already exists") {
-				// Get existing policy to retrieve its resourceVersion
-				existing, getErr := e.dynamicClient.Resource(gvr).Get(ctx, policyName, metav1.GetOptions{})
-				if getErr != nil {
-					errors = append(errors, fmt.Sprintf("%s: failed to get existing policy: %v", policyName, getErr))
-					continue
-				}
-
-				// Set resourceVersion from existing policy (required for updates)
-				u.SetResourceVersion(existing.GetResourceVersion())
-
-				_, updateErr := e.dynamicClient.Resource(gvr).Update(ctx, u, metav1.UpdateOptions{})
-				if updateErr != nil {
-					errors = append(errors, fmt.Sprintf("%s: update failed: %v", policyName, updateErr))
-					continue
-				}
+	if installed {
+		// Templates go first: Gatekeeper serves a constraint kind only once the
+		// template defining it has been created
+		var templates, constraints []runtime.Object
+		for _, policy := range policies {
+			if _, ok := policy.(*gatekeeper.Constraint); ok {
+				constraints = append(constraints, policy)
 			} else {
-				errors = append(errors, fmt.Sprintf("%s: creation failed: %v", policyName, createErr))
+				templates = append(templates, policy)
+			}
+		}
+
+		applied, applyErrors := e.applyPolicies(ctx, templates)
+		for _, constraint := range constraints {
+			if err := e.applyConstraint(ctx, constraint); err != nil {
+				applyErrors = append(applyErrors, err.Error())
 				continue
 			}
+			applied++
+		}
+		result.PoliciesApplied = applied
+		result.Errors = applyErrors
+
+		if len(applyErrors) > 0 {
+			return nil, fmt.Errorf("failed to apply %d policies: %v", len(applyErrors), applyErrors)
 		}
+	}
+
+	return result, nil
+}
 
+// applyPolicies applies generated policies to the cluster.
+func (e *Enforcer) applyPolicies(ctx context.Context, policies []runtime.Object) (int, []string) {
+	applied := 0
+	errors := []string{}
+
+	for i, policyObj := range policies {
+		if err := e.ApplyPolicy(ctx, policyObj); err != nil {
+			errors = append(errors, fmt.Sprintf("policy[%d]: %v", i, err))
+			continue
+		}
 		applied++
 	}
 
 	return applied, errors
 }
 
+// applyConstraint applies a Gatekeeper Constraint, retrying while Gatekeeper is
+// still creating the CRD for its kind.
+func (e *Enforcer) applyConstraint(ctx context.Context, constraint runtime.Object) error {
+	var applyErr error
+	err := wait.PollUntilContextTimeout(ctx, 2*time.Second, constraintCRDTimeout, true, func(ctx context.Context) (bool, error) {
+		applyErr = e.ApplyPolicy(ctx, constraint)
+		return !apierrors.IsNotFound(applyErr), nil
+	})
+	if applyErr != nil {
+		return applyErr
+	}
+	return err
+}
+
+// ApplyPolicy creates a generated policy, or updates it if it already exists.
+func (e *Enforcer) ApplyPolicy(ctx context.Context, policyObj runtime.Object) error {
+	gvr, apiVersion, kind, err := policyResource(policyObj)
+	if err != nil {
+		return err
+	}
+
+	// Convert typed policy to unstructured for dynamic client
+	unstructuredPolicy, err := runtime.DefaultUnstructuredConverter.ToUnstructured(policyObj)
+	if err != nil {
+		return fmt.Errorf("failed to convert: %w", err)
+	}
+
+	u := &unstructured.Unstructured{Object: unstructuredPolicy}
+
+	// Ensure APIVersion and Kind are set (required by dynamic client)
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+
+	policyName := u.GetName()
+	if policyName == "" {
+		return fmt.Errorf("missing name")
+	}
+
+	// Try to create the policy, or update if it already exists
+	_, createErr := e.dynamicClient.Resource(gvr).Create(ctx, u, metav1.CreateOptions{})
+	if createErr == nil {
+		return nil
+	}
+	if !strings.Contains(createErr.Error(), "already exists") {
+		return fmt.Errorf("%s: creation failed: %w", policyName, createErr)
+	}
+
+	// Get existing policy to retrieve its resourceVersion
+	existing, getErr := e.dynamicClient.Resource(gvr).Get(ctx, policyName, metav1.GetOptions{})
+	if getErr != nil {
+		return fmt.Errorf("%s: failed to get existing policy: %w", policyName, getErr)
+	}
+
+	// Set resourceVersion from existing policy (required for updates)
+	u.SetResourceVersion(existing.GetResourceVersion())
+
+	if _, updateErr := e.dynamicClient.Resource(gvr).Update(ctx, u, metav1.UpdateOptions{}); updateErr != nil {
+		return fmt.Errorf("%s: update failed: %w", policyName, updateErr)
+	}
+
+	return nil
+}
+
+// policyResource returns the resource, API version and kind a generated policy is served as.
+func policyResource(policyObj runtime.Object) (schema.GroupVersionResource, string, string, error) {
+	switch policy := policyObj.(type) {
+	case *kyverno.ClusterPolicy:
+		return kyverno.ClusterPolicyGVR(), "kyverno.io/v1", "ClusterPolicy", nil
+	case *gatekeeper.ConstraintTemplate:
+		return gatekeeper.ConstraintTemplateGVR(), gatekeeper.TemplateAPIVersion, "ConstraintTemplate", nil
+	case *gatekeeper.Constraint:
+		return policy.GVR(), gatekeeper.ConstraintAPIVersion, policy.Kind, nil
+	default:
+		return schema.GroupVersionResource{}, "", "", fmt.Errorf("unsupported policy type %T", policyObj)
+	}
+}
+
 // validatePolicies validates all generated policies before deployment.
 func (e *Enforcer) validatePolicies(policies []runtime.Object) error {
+	return validateKyvernoPolicies(e.kyvernoValidator, policies)
+}
+
+// validateKyvernoPolicies validates generated Kyverno policies.
+func validateKyvernoPolicies(validator *kyverno.Validator, policies []runtime.Object) error {
 	var clusterPolicies []*kyverno.ClusterPolicy
 
 	// Convert runtime.Object to ClusterPolicy for validation
@@ -189,7 +361,7 @@ func (e *Enforcer) validatePolicies(policies []runtime.Object) error {
 	}
 
 	// Validate all policies
-	validationErrors := e.kyvernoValidator.ValidateBatch(clusterPolicies)
+	validationErrors := validator.ValidateBatch(clusterPolicies)
 	if len(validationErrors) > 0 {
 		return kyverno.FormatValidationErrors(validationErrors)
 	}
@@ -0,0 +1,345 @@
+// Package gatekeeper generates OPA Gatekeeper ConstraintTemplates and
+// Constraints from cluster specifications, as an alternative to the Kyverno
+// policy backend.
+package gatekeeper
+
+import (
+	"fmt"
+
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DefaultExcludedNamespaces are excluded from every generated constraint so that
+// enforcement never blocks Kubernetes system components. They match the system
+// namespaces the scanner checks skip.
+var DefaultExcludedNamespaces = []string{"kube-system", "kube-public", "kube-node-lease"}
+
+// containersRego collects the containers and init containers of the reviewed Pod.
+const containersRego = `
+input_containers[c] {
+	c := input.review.object.spec.containers[_]
+}
+
+input_containers[c] {
+	c := input.review.object.spec.initContainers[_]
+}
+`
+
+// Generator generates Gatekeeper ConstraintTemplate and Constraint pairs from
+// cluster specifications.
+type Generator struct{}
+
+// NewGenerator creates a new Gatekeeper policy generator.
+func NewGenerator() *Generator {
+	return &Generator{}
+}
+
+// GeneratePolicies generates a ConstraintTemplate followed by its Constraint for
+// each requirement in the specification. It consumes the same workload and image
+// requirements as the Kyverno generator.
+func (g *Generator) GeneratePolicies(clusterSpec *spec.ClusterSpecification) ([]runtime.Object, error) {
+	policies := []runtime.Object{}
+
+	workloads := clusterSpec.Spec.Workloads
+	if workloads != nil && workloads.Containers != nil {
+		policies = append(policies, g.generateWorkloadPolicies(workloads.Containers)...)
+	}
+
+	if workloads != nil && (workloads.RequireLiveness || workloads.RequireReadiness) {
+		policies = append(policies, g.createRequireProbesPolicy(workloads.RequireLiveness, workloads.RequireReadiness, workloads.IncludeInitContainers)...)
+	}
+
+	if workloads != nil && workloads.Images != nil {
+		policies = append(policies, g.generateImagePolicies(workloads.Images)...)
+	}
+
+	ownershipLabels := clusterSpec.Metadata.OwnershipLabels()
+	for _, obj := range policies {
+		switch policy := obj.(type) {
+		case *ConstraintTemplate:
+			applyLabels(&policy.ObjectMeta.Labels, ownershipLabels)
+		case *Constraint:
+			policy.Spec.Match.ExcludedNamespaces = append([]string(nil), DefaultExcludedNamespaces...)
+			applyLabels(&policy.ObjectMeta.Labels, ownershipLabels)
+		default:
+			return nil, fmt.Errorf("unexpected generated object %T", obj)
+		}
+	}
+
+	return policies, nil
+}
+
+// applyLabels adds labels to an object's label map.
+func applyLabels(target *map[string]string, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+	if *target == nil {
+		*target = make(map[string]string, len(labels))
+	}
+	for key, value := range labels {
+		(*target)[key] = value
+	}
+}
+
+// newPolicy builds a template and a matching constraint named like the
+// equivalent Kyverno policy.
+func newPolicy(name, kind, description, rego string) (*ConstraintTemplate, *Constraint) {
+	template := NewConstraintTemplate(kind, rego)
+	template.Annotations["description"] = description
+
+	constraint := NewConstraint(kind, name)
+	constraint.Annotations["description"] = description
+
+	return template, constraint
+}
+
+// generateWorkloadPolicies creates policies for container security requirements.
+func (g *Generator) generateWorkloadPolicies(containers *spec.ContainerSpec) []runtime.Object {
+	policies := []runtime.Object{}
+
+	for _, req := range containers.Required {
+		if req.Key == "securityContext.runAsNonRoot" && req.Value == "true" {
+			template, constraint := g.createRunAsNonRootPolicy()
+			policies = append(policies, template, constraint)
+		}
+		if req.Key == "securityContext.allowPrivilegeEscalation" && req.Value == "false" {
+			template, constraint := g.createDisallowPrivilegeEscalationPolicy()
+			policies = append(policies, template, constraint)
+		}
+		if req.Key == "resources.limits.memory" && req.Exists != nil && *req.Exists {
+			template, constraint := g.createRequireResourceLimitsPolicy()
+			policies = append(policies, template, constraint)
+		}
+	}
+
+	for _, forbidden := range containers.Forbidden {
+		if forbidden.Key == "securityContext.privileged" && forbidden.Value == "true" {
+			template, constraint := g.createDisallowPrivilegedPolicy()
+			policies = append(policies, template, constraint)
+		}
+		if forbidden.Key == "hostNetwork" && forbidden.Value == "true" {
+			template, constraint := g.createDisallowHostNamespacesPolicy()
+			policies = append(policies, template, constraint)
+		}
+	}
+
+	return policies
+}
+
+// createRunAsNonRootPolicy requires every container to run as non-root, either
+// directly or by inheriting the Pod security context.
+func (g *Generator) createRunAsNonRootPolicy() (*ConstraintTemplate, *Constraint) {
+	rego := `package kspecrequirerunasnonroot
+
+violation[{"msg": msg}] {
+	c := input_containers[_]
+	not runs_as_non_root(c)
+	msg := sprintf("Container %v must run as non-root (securityContext.runAsNonRoot must be true)", [c.name])
+}
+
+runs_as_non_root(c) {
+	c.securityContext.runAsNonRoot == true
+}
+
+runs_as_non_root(c) {
+	input.review.object.spec.securityContext.runAsNonRoot == true
+	not c.securityContext.runAsNonRoot == false
+}
+` + containersRego
+
+	return newPolicy("require-run-as-non-root", "KspecRequireRunAsNonRoot",
+		"Containers must run as non-root users", rego)
+}
+
+// createDisallowPrivilegeEscalationPolicy requires allowPrivilegeEscalation: false.
+func (g *Generator) createDisallowPrivilegeEscalationPolicy() (*ConstraintTemplate, *Constraint) {
+	rego := `package kspecdisallowprivilegeescalation
+
+violation[{"msg": msg}] {
+	c := input_containers[_]
+	not c.securityContext.allowPrivilegeEscalation == false
+	msg := sprintf("Privilege escalation is disallowed for container %v (securityContext.allowPrivilegeEscalation must be false)", [c.name])
+}
+` + containersRego
+
+	return newPolicy("disallow-privilege-escalation", "KspecDisallowPrivilegeEscalation",
+		"Privilege escalation must be disabled", rego)
+}
+
+// createDisallowPrivilegedPolicy rejects privileged containers.
+func (g *Generator) createDisallowPrivilegedPolicy() (*ConstraintTemplate, *Constraint) {
+	rego := `package kspecdisallowprivileged
+
+violation[{"msg": msg}] {
+	c := input_containers[_]
+	c.securityContext.privileged == true
+	msg := sprintf("Privileged containers are not allowed (container %v)", [c.name])
+}
+` + containersRego
+
+	return newPolicy("disallow-privileged-containers", "KspecDisallowPrivileged",
+		"Privileged containers are not allowed", rego)
+}
+
+// createDisallowHostNamespacesPolicy rejects Pods sharing host namespaces.
+func (g *Generator) createDisallowHostNamespacesPolicy() (*ConstraintTemplate, *Constraint) {
+	rego := `package kspecdisallowhostnamespaces
+
+violation[{"msg": msg}] {
+	field := ["hostNetwork", "hostPID", "hostIPC"][_]
+	input.review.object.spec[field] == true
+	msg := sprintf("Host namespaces are not allowed (%v must not be true)", [field])
+}
+`
+
+	return newPolicy("disallow-host-namespaces", "KspecDisallowHostNamespaces",
+		"Host namespaces (hostNetwork, hostPID, hostIPC) are not allowed", rego)
+}
+
+// createRequireResourceLimitsPolicy requires CPU and memory limits on every container.
+func (g *Generator) createRequireResourceLimitsPolicy() (*ConstraintTemplate, *Constraint) {
+	rego := `package kspecrequireresourcelimits
+
+violation[{"msg": msg}] {
+	c := input_containers[_]
+	resource := ["cpu", "memory"][_]
+	not c.resources.limits[resource]
+	msg := sprintf("Container %v must define a %v limit", [c.name, resource])
+}
+` + containersRego
+
+	return newPolicy("require-resource-limits", "KspecRequireResourceLimits",
+		"All containers must have memory and CPU limits defined", rego)
+}
+
+// createRequireProbesPolicy requires the configured probes on every container,
+// and on sidecar init containers when includeInitContainers is set.
+func (g *Generator) createRequireProbesPolicy(requireLiveness, requireReadiness, includeInitContainers bool) []runtime.Object {
+	rego := `package kspecrequireprobes
+
+violation[{"msg": msg}] {
+	c := probed_containers[_]
+	probe := input.parameters.probes[_]
+	not c[probe]
+	msg := sprintf("Container %v must define %v", [c.name, probe])
+}
+
+probed_containers[c] {
+	c := input.review.object.spec.containers[_]
+}
+
+probed_containers[c] {
+	input.parameters.includeInitContainers
+	c := input.review.object.spec.initContainers[_]
+	c.restartPolicy == "Always"
+}
+`
+
+	template, constraint := newPolicy("require-pod-probes", "KspecRequireProbes",
+		"All containers must define the required liveness and readiness probes", rego)
+	template.Spec.CRD.Spec.Validation = stringListSchema("probes")
+	properties := template.Spec.CRD.Spec.Validation.OpenAPIV3Schema["properties"].(map[string]interface{})
+	properties["includeInitContainers"] = map[string]interface{}{"type": "boolean"}
+
+	probes := []string{}
+	if requireLiveness {
+		probes = append(probes, "livenessProbe")
+	}
+	if requireReadiness {
+		probes = append(probes, "readinessProbe")
+	}
+	constraint.Spec.Parameters = map[string]interface{}{
+		"probes":                probes,
+		"includeInitContainers": includeInitContainers,
+	}
+
+	return []runtime.Object{template, constraint}
+}
+
+// generateImagePolicies creates policies for image registry requirements.
+func (g *Generator) generateImagePolicies(imageSpec *spec.ImageSpec) []runtime.Object {
+	policies := []runtime.Object{}
+
+	if imageSpec.RequireDigests {
+		template, constraint := g.createRequireDigestsPolicy()
+		policies = append(policies, template, constraint)
+	}
+
+	if len(imageSpec.BlockedRegistries) > 0 {
+		template, constraint := g.createBlockedRegistriesPolicy(imageSpec.BlockedRegistries)
+		policies = append(policies, template, constraint)
+	}
+
+	return policies
+}
+
+// createRequireDigestsPolicy requires images to be pinned by digest.
+func (g *Generator) createRequireDigestsPolicy() (*ConstraintTemplate, *Constraint) {
+	rego := `package kspecrequireimagedigests
+
+violation[{"msg": msg}] {
+	c := input_containers[_]
+	not contains(c.image, "@sha256:")
+	msg := sprintf("Image %v must use a digest (e.g., image@sha256:...) not a tag", [c.image])
+}
+` + containersRego
+
+	return newPolicy("require-image-digests", "KspecRequireImageDigests",
+		"Images must use digests (not tags) for immutability", rego)
+}
+
+// createBlockedRegistriesPolicy rejects images from the blocked registries.
+// Registries may use * wildcards (e.g. "*.example.com"), and images without a
+// registry host are treated as docker.io images, matching the scanner.
+func (g *Generator) createBlockedRegistriesPolicy(blockedRegistries []string) (*ConstraintTemplate, *Constraint) {
+	rego := `package kspecblockimageregistries
+
+violation[{"msg": msg}] {
+	c := input_containers[_]
+	registry := input.parameters.registries[_]
+	glob.match(registry, [], image_registry(c.image))
+	msg := sprintf("Image %v is from blocked registry %v", [c.image, registry])
+}
+
+image_registry(image) = registry {
+	has_registry(image)
+	registry := split(image, "/")[0]
+}
+
+image_registry(image) = "docker.io" {
+	not has_registry(image)
+}
+
+has_registry(image) {
+	parts := split(image, "/")
+	count(parts) > 1
+	regex.match("[.:]|^localhost$", parts[0])
+}
+` + containersRego
+
+	template, constraint := newPolicy("block-image-registries", "KspecBlockImageRegistries",
+		fmt.Sprintf("Block images from: %v", blockedRegistries), rego)
+	template.Spec.CRD.Spec.Validation = stringListSchema("registries")
+	constraint.Spec.Parameters = map[string]interface{}{
+		"registries": append([]string(nil), blockedRegistries...),
+	}
+
+	return template, constraint
+}
+
+// stringListSchema returns a parameters schema with a single string list property.
+func stringListSchema(property string) *Validation {
+	return &Validation{
+		OpenAPIV3Schema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				property: map[string]interface{}{
+					"type":  "array",
+					"items": map[string]interface{}{"type": "string"},
+				},
+			},
+		},
+	}
+}
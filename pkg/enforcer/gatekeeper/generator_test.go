@@ -0,0 +1,143 @@
+package gatekeeper
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func gatekeeperTestSpec() *spec.ClusterSpecification {
+	exists := true
+	return &spec.ClusterSpecification{
+		Metadata: spec.Metadata{Name: "test-cluster", Team: "payments"},
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{
+				Containers: &spec.ContainerSpec{
+					Required: []spec.FieldRequirement{
+						{Key: "securityContext.runAsNonRoot", Value: "true"},
+						{Key: "securityContext.allowPrivilegeEscalation", Value: "false"},
+						{Key: "resources.limits.memory", Exists: &exists},
+					},
+					Forbidden: []spec.FieldRequirement{
+						{Key: "securityContext.privileged", Value: "true"},
+						{Key: "hostNetwork", Value: "true"},
+					},
+				},
+				Images: &spec.ImageSpec{
+					RequireDigests:    true,
+					BlockedRegistries: []string{"docker.io", "*.untrusted.example"},
+				},
+			},
+		},
+	}
+}
+
+func TestGeneratePolicies_TemplateConstraintPairs(t *testing.T) {
+	policies, err := NewGenerator().GeneratePolicies(gatekeeperTestSpec())
+	if err != nil {
+		t.Fatalf("GeneratePolicies failed: %v", err)
+	}
+
+	wantConstraints := []string{
+		"require-run-as-non-root",
+		"disallow-privilege-escalation",
+		"require-resource-limits",
+		"disallow-privileged-containers",
+		"disallow-host-namespaces",
+		"require-image-digests",
+		"block-image-registries",
+	}
+	if len(policies) != 2*len(wantConstraints) {
+		t.Fatalf("Expected %d objects, got %d", 2*len(wantConstraints), len(policies))
+	}
+
+	for i, name := range wantConstraints {
+		template, ok := policies[2*i].(*ConstraintTemplate)
+		if !ok {
+			t.Fatalf("Object %d: expected ConstraintTemplate, got %T", 2*i, policies[2*i])
+		}
+		constraint, ok := policies[2*i+1].(*Constraint)
+		if !ok {
+			t.Fatalf("Object %d: expected Constraint, got %T", 2*i+1, policies[2*i+1])
+		}
+
+		if constraint.Name != name {
+			t.Errorf("Expected constraint %s, got %s", name, constraint.Name)
+		}
+		kind := template.Spec.CRD.Spec.Names.Kind
+		if constraint.Kind != kind || template.Name != strings.ToLower(kind) {
+			t.Errorf("Constraint %s: kind %s does not match template %s (%s)", name, constraint.Kind, template.Name, kind)
+		}
+		if len(template.Spec.Targets) != 1 || !strings.Contains(template.Spec.Targets[0].Rego, "violation[") {
+			t.Errorf("Template %s: expected one target defining violations", template.Name)
+		}
+
+		for _, annotations := range []map[string]string{template.Annotations, constraint.Annotations} {
+			if annotations["kspec.dev/generated"] != "true" {
+				t.Errorf("Policy %s: missing kspec.dev/generated annotation", name)
+			}
+		}
+		if constraint.Labels[spec.TeamLabel] != "payments" || template.Labels[spec.TeamLabel] != "payments" {
+			t.Errorf("Policy %s: expected ownership labels on template and constraint", name)
+		}
+		if !reflect.DeepEqual(constraint.Spec.Match.ExcludedNamespaces, DefaultExcludedNamespaces) {
+			t.Errorf("Constraint %s: expected excluded namespaces %v, got %v", name, DefaultExcludedNamespaces, constraint.Spec.Match.ExcludedNamespaces)
+		}
+		if constraint.Spec.EnforcementAction != Deny {
+			t.Errorf("Constraint %s: expected enforcement action %s, got %s", name, Deny, constraint.Spec.EnforcementAction)
+		}
+
+		// Objects must convert cleanly for the dynamic client
+		if _, err := runtime.DefaultUnstructuredConverter.ToUnstructured(constraint); err != nil {
+			t.Errorf("Constraint %s: failed to convert to unstructured: %v", name, err)
+		}
+		if _, err := runtime.DefaultUnstructuredConverter.ToUnstructured(template); err != nil {
+			t.Errorf("Template %s: failed to convert to unstructured: %v", template.Name, err)
+		}
+	}
+
+	registries := policies[len(policies)-1].(*Constraint).Spec.Parameters["registries"]
+	if !reflect.DeepEqual(registries, []string{"docker.io", "*.untrusted.example"}) {
+		t.Errorf("Expected blocked registries as parameters, got %v", registries)
+	}
+}
+
+func TestGeneratePolicies_Probes(t *testing.T) {
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{RequireReadiness: true},
+		},
+	}
+
+	policies, err := NewGenerator().GeneratePolicies(clusterSpec)
+	if err != nil {
+		t.Fatalf("GeneratePolicies failed: %v", err)
+	}
+	if len(policies) != 2 {
+		t.Fatalf("Expected a template and a constraint, got %d objects", len(policies))
+	}
+
+	constraint := policies[1].(*Constraint)
+	if got := constraint.Spec.Parameters["probes"]; !reflect.DeepEqual(got, []string{"readinessProbe"}) {
+		t.Errorf("Expected only the readiness probe to be required, got %v", got)
+	}
+	if got := constraint.Spec.Parameters["includeInitContainers"]; got != false {
+		t.Errorf("Expected init containers to be left out by default, got %v", got)
+	}
+	if !reflect.DeepEqual(constraint.Spec.Match.ExcludedNamespaces, DefaultExcludedNamespaces) {
+		t.Errorf("Expected the probes constraint to exclude %v, got %v", DefaultExcludedNamespaces, constraint.Spec.Match.ExcludedNamespaces)
+	}
+}
+
+func TestGeneratePolicies_Empty(t *testing.T) {
+	policies, err := NewGenerator().GeneratePolicies(&spec.ClusterSpecification{})
+	if err != nil {
+		t.Fatalf("GeneratePolicies failed: %v", err)
+	}
+	if len(policies) != 0 {
+		t.Errorf("Expected no policies for an empty spec, got %d", len(policies))
+	}
+}
@@ -0,0 +1,66 @@
+package gatekeeper
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Installer handles Gatekeeper installation checks.
+type Installer struct{}
+
+// NewInstaller creates a new Gatekeeper installer.
+func NewInstaller() *Installer {
+	return &Installer{}
+}
+
+// IsInstalled checks if Gatekeeper is installed in the cluster.
+func (i *Installer) IsInstalled(ctx context.Context, client kubernetes.Interface) (bool, error) {
+	// The Helm chart and the release manifests both deploy the controller as
+	// gatekeeper-controller-manager in gatekeeper-system
+	deployment, err := client.AppsV1().Deployments("gatekeeper-system").Get(ctx, "gatekeeper-controller-manager", metav1.GetOptions{})
+	if err != nil {
+		// Namespace or deployment doesn't exist
+		return false, nil
+	}
+
+	return deployment.Status.ReadyReplicas > 0, nil
+}
+
+// GetInstallInstructions returns installation instructions for Gatekeeper.
+func (i *Installer) GetInstallInstructions() string {
+	return `Gatekeeper is not installed. To install Gatekeeper, run:
+
+# Add Gatekeeper Helm repository
+helm repo add gatekeeper https://open-policy-agent.github.io/gatekeeper/charts
+helm repo update
+
+# Install Gatekeeper
+helm install gatekeeper gatekeeper/gatekeeper \
+  --namespace gatekeeper-system \
+  --create-namespace \
+  --wait
+
+# Verify installation
+kubectl get deployments -n gatekeeper-system
+kubectl get pods -n gatekeeper-system
+
+For more information, visit: https://open-policy-agent.github.io/gatekeeper/website/docs/install`
+}
+
+// GetVersion attempts to get the installed Gatekeeper version.
+func (i *Installer) GetVersion(ctx context.Context, client kubernetes.Interface) (string, error) {
+	deployment, err := client.AppsV1().Deployments("gatekeeper-system").Get(ctx, "gatekeeper-controller-manager", metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get Gatekeeper deployment: %w", err)
+	}
+
+	// Extract version from image tag
+	if len(deployment.Spec.Template.Spec.Containers) > 0 {
+		return deployment.Spec.Template.Spec.Containers[0].Image, nil
+	}
+
+	return "unknown", nil
+}
@@ -0,0 +1,231 @@
+package gatekeeper
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// TemplateAPIVersion is the API version serving ConstraintTemplates
+	TemplateAPIVersion = "templates.gatekeeper.sh/v1"
+
+	// ConstraintAPIVersion is the API version serving constraints
+	ConstraintAPIVersion = "constraints.gatekeeper.sh/v1beta1"
+
+	// AdmissionTarget is the Gatekeeper target for admission review requests
+	AdmissionTarget = "admission.k8s.gatekeeper.sh"
+)
+
+// ConstraintTemplate defines a Gatekeeper constraint template.
+// This is a vendored subset of github.com/open-policy-agent/frameworks/constraint
+// to avoid heavyweight dependencies while maintaining API compatibility.
+type ConstraintTemplate struct {
+	metav1.TypeMeta   `json:",inline" yaml:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Spec              ConstraintTemplateSpec `json:"spec" yaml:"spec"`
+}
+
+// ConstraintTemplateSpec defines the constraint kind and the Rego that enforces it.
+type ConstraintTemplateSpec struct {
+	// CRD describes the constraint kind Gatekeeper creates for this template
+	CRD CRD `json:"crd"`
+
+	// Targets holds the Rego evaluated for each target
+	Targets []Target `json:"targets"`
+}
+
+// CRD describes the constraint CRD generated from a template.
+type CRD struct {
+	Spec CRDSpec `json:"spec"`
+}
+
+// CRDSpec defines the constraint kind and its parameters schema.
+type CRDSpec struct {
+	// Names holds the constraint kind
+	Names Names `json:"names"`
+
+	// Validation holds the schema of the constraint parameters
+	Validation *Validation `json:"validation,omitempty"`
+}
+
+// Names defines the constraint kind.
+type Names struct {
+	Kind string `json:"kind"`
+}
+
+// Validation defines the schema of the constraint parameters.
+type Validation struct {
+	OpenAPIV3Schema map[string]interface{} `json:"openAPIV3Schema,omitempty"`
+}
+
+// Target holds the Rego for a single Gatekeeper target.
+type Target struct {
+	// Target is the Gatekeeper target name
+	Target string `json:"target"`
+
+	// Rego is the policy source; it must define violation[{"msg": msg}]
+	Rego string `json:"rego"`
+}
+
+// Constraint is an instance of the constraint kind defined by a ConstraintTemplate.
+type Constraint struct {
+	metav1.TypeMeta   `json:",inline" yaml:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Spec              ConstraintSpec `json:"spec" yaml:"spec"`
+}
+
+// ConstraintSpec defines what a constraint matches and how it is enforced.
+type ConstraintSpec struct {
+	// EnforcementAction controls the action on a violation
+	EnforcementAction EnforcementAction `json:"enforcementAction,omitempty"`
+
+	// Match defines the resources the constraint applies to
+	Match Match `json:"match,omitempty"`
+
+	// Parameters are passed to the template's Rego as input.parameters
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// EnforcementAction defines the action on a constraint violation.
+type EnforcementAction string
+
+const (
+	// Deny blocks the operation
+	Deny EnforcementAction = "deny"
+	// Warn allows the operation but returns a warning
+	Warn EnforcementAction = "warn"
+	// DryRun allows the operation and only records an audit violation
+	DryRun EnforcementAction = "dryrun"
+)
+
+// Match defines the resources a constraint applies to.
+type Match struct {
+	// Kinds is a list of resource kinds
+	Kinds []Kinds `json:"kinds,omitempty"`
+
+	// Namespaces limits the constraint to these namespaces (empty means all)
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// ExcludedNamespaces are exempt from the constraint
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+}
+
+// Kinds selects resource kinds within API groups.
+type Kinds struct {
+	APIGroups []string `json:"apiGroups"`
+	Kinds     []string `json:"kinds"`
+}
+
+// NewConstraintTemplate creates a ConstraintTemplate for the given constraint kind.
+// The template name is the lowercased kind, as Gatekeeper requires.
+func NewConstraintTemplate(kind, rego string) *ConstraintTemplate {
+	return &ConstraintTemplate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: TemplateAPIVersion,
+			Kind:       "ConstraintTemplate",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(kind),
+			Annotations: map[string]string{
+				"kspec.dev/generated": "true",
+			},
+		},
+		Spec: ConstraintTemplateSpec{
+			CRD: CRD{
+				Spec: CRDSpec{
+					Names: Names{Kind: kind},
+				},
+			},
+			Targets: []Target{
+				{Target: AdmissionTarget, Rego: rego},
+			},
+		},
+	}
+}
+
+// NewConstraint creates a deny constraint of the given kind matching Pods.
+func NewConstraint(kind, name string) *Constraint {
+	return &Constraint{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: ConstraintAPIVersion,
+			Kind:       kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				"kspec.dev/generated": "true",
+			},
+		},
+		Spec: ConstraintSpec{
+			EnforcementAction: Deny,
+			Match: Match{
+				Kinds: []Kinds{
+					{APIGroups: []string{""}, Kinds: []string{"Pod"}},
+				},
+			},
+		},
+	}
+}
+
+// DeepCopyObject implements runtime.Object interface for ConstraintTemplate.
+func (t *ConstraintTemplate) DeepCopyObject() runtime.Object {
+	if t == nil {
+		return nil
+	}
+	out := new(ConstraintTemplate)
+	*out = *t
+	t.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if t.Spec.CRD.Spec.Validation != nil {
+		validation := *t.Spec.CRD.Spec.Validation
+		out.Spec.CRD.Spec.Validation = &validation
+	}
+	if t.Spec.Targets != nil {
+		out.Spec.Targets = make([]Target, len(t.Spec.Targets))
+		copy(out.Spec.Targets, t.Spec.Targets)
+	}
+	return out
+}
+
+// DeepCopyObject implements runtime.Object interface for Constraint.
+func (c *Constraint) DeepCopyObject() runtime.Object {
+	if c == nil {
+		return nil
+	}
+	out := new(Constraint)
+	*out = *c
+	c.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if c.Spec.Match.Kinds != nil {
+		out.Spec.Match.Kinds = make([]Kinds, len(c.Spec.Match.Kinds))
+		copy(out.Spec.Match.Kinds, c.Spec.Match.Kinds)
+	}
+	out.Spec.Match.Namespaces = append([]string(nil), c.Spec.Match.Namespaces...)
+	out.Spec.Match.ExcludedNamespaces = append([]string(nil), c.Spec.Match.ExcludedNamespaces...)
+	if c.Spec.Parameters != nil {
+		out.Spec.Parameters = make(map[string]interface{}, len(c.Spec.Parameters))
+		for key, value := range c.Spec.Parameters {
+			out.Spec.Parameters[key] = value
+		}
+	}
+	return out
+}
+
+// ConstraintTemplateGVR returns the GroupVersionResource for ConstraintTemplate.
+func ConstraintTemplateGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    "templates.gatekeeper.sh",
+		Version:  "v1",
+		Resource: "constrainttemplates",
+	}
+}
+
+// GVR returns the GroupVersionResource serving this constraint's kind.
+func (c *Constraint) GVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    "constraints.gatekeeper.sh",
+		Version:  "v1beta1",
+		Resource: strings.ToLower(c.Kind),
+	}
+}
@@ -2,19 +2,43 @@ package kyverno
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cloudcwfranck/kspec/pkg/spec"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DefaultExcludedNamespaces are excluded from every generated policy so that
+// enforcement never blocks Kubernetes system components. They match the system
+// namespaces the scanner checks skip.
+var DefaultExcludedNamespaces = []string{"kube-system", "kube-public", "kube-node-lease"}
+
+// NamespaceScope restricts generated policies to a set of namespaces.
+type NamespaceScope struct {
+	// IncludeNamespaces limits policies to these namespaces (empty means all)
+	IncludeNamespaces []string
+
+	// ExcludeNamespaces are exempt from policies, in addition to DefaultExcludedNamespaces
+	ExcludeNamespaces []string
+}
+
 // Generator generates Kyverno policies from cluster specifications.
-type Generator struct{}
+type Generator struct {
+	scope NamespaceScope
+}
 
 // NewGenerator creates a new Kyverno policy generator.
 func NewGenerator() *Generator {
 	return &Generator{}
 }
 
+// WithNamespaceScope sets the namespaces generated policies apply to.
+func (g *Generator) WithNamespaceScope(scope NamespaceScope) *Generator {
+	g.scope = scope
+	return g
+}
+
 // GeneratePolicies generates Kyverno ClusterPolicy resources from a cluster specification.
 func (g *Generator) GeneratePolicies(clusterSpec *spec.ClusterSpecification) ([]runtime.Object, error) {
 	policies := []runtime.Object{}
@@ -28,6 +52,23 @@ func (g *Generator) GeneratePolicies(clusterSpec *spec.ClusterSpecification) ([]
 		policies = append(policies, workloadPolicies...)
 	}
 
+	// Generate probe policies
+	if clusterSpec.Spec.Workloads != nil && (clusterSpec.Spec.Workloads.RequireLiveness || clusterSpec.Spec.Workloads.RequireReadiness) {
+		workloads := clusterSpec.Spec.Workloads
+		policy := g.createRequireProbesPolicy(workloads.RequireLiveness, workloads.RequireReadiness, workloads.IncludeInitContainers)
+		policies = append(policies, policy)
+	}
+
+	// Generate availability policies
+	if clusterSpec.Spec.Availability != nil && clusterSpec.Spec.Availability.RequireTopologySpread {
+		policies = append(policies, g.createRequireTopologySpreadPolicy())
+	}
+
+	// Generate scheduling policies
+	if clusterSpec.Spec.Scheduling != nil && clusterSpec.Spec.Scheduling.RequiredPriorityClass != "" {
+		policies = append(policies, g.createRequirePriorityClassPolicy(clusterSpec.Spec.Scheduling))
+	}
+
 	// Generate image registry policies
 	if clusterSpec.Spec.Workloads != nil && clusterSpec.Spec.Workloads.Images != nil {
 		imagePolicies, err := g.generateImagePolicies(clusterSpec.Spec.Workloads.Images)
@@ -37,9 +78,73 @@ func (g *Generator) GeneratePolicies(clusterSpec *spec.ClusterSpecification) ([]
 		policies = append(policies, imagePolicies...)
 	}
 
+	ownershipLabels := clusterSpec.Metadata.OwnershipLabels()
+	for _, obj := range policies {
+		if policy, ok := obj.(*ClusterPolicy); ok {
+			g.applyNamespaceScope(policy)
+			applyLabels(policy, ownershipLabels)
+		}
+	}
+
 	return policies, nil
 }
 
+// applyNamespaceScope adds the generator's namespace selectors to every rule:
+// included namespaces narrow the match block, and excluded namespaces (always
+// including DefaultExcludedNamespaces) are added to the exclude block. Match
+// filters that already name their own namespaces keep them.
+func (g *Generator) applyNamespaceScope(policy *ClusterPolicy) {
+	excluded := g.excludedNamespaces()
+
+	for i := range policy.Spec.Rules {
+		rule := &policy.Spec.Rules[i]
+
+		if len(g.scope.IncludeNamespaces) > 0 {
+			for _, filters := range [][]ResourceFilter{rule.Match.Any, rule.Match.All} {
+				for j := range filters {
+					if filters[j].Resources != nil && len(filters[j].Resources.Namespaces) == 0 {
+						filters[j].Resources.Namespaces = append([]string(nil), g.scope.IncludeNamespaces...)
+					}
+				}
+			}
+		}
+
+		rule.Exclude.Any = append(rule.Exclude.Any, ResourceFilter{
+			Resources: &ResourceDescription{
+				Namespaces: excluded,
+			},
+		})
+	}
+}
+
+// applyLabels adds labels to a policy.
+func applyLabels(policy *ClusterPolicy, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+	if policy.Labels == nil {
+		policy.Labels = make(map[string]string, len(labels))
+	}
+	for key, value := range labels {
+		policy.Labels[key] = value
+	}
+}
+
+// excludedNamespaces returns the default exclusions followed by the configured
+// ones, without duplicates.
+func (g *Generator) excludedNamespaces() []string {
+	seen := make(map[string]bool)
+	excluded := []string{}
+	for _, ns := range append(append([]string{}, DefaultExcludedNamespaces...), g.scope.ExcludeNamespaces...) {
+		if ns == "" || seen[ns] {
+			continue
+		}
+		seen[ns] = true
+		excluded = append(excluded, ns)
+	}
+	return excluded
+}
+
 // generateWorkloadPolicies creates policies for workload security requirements.
 func (g *Generator) generateWorkloadPolicies(workloadsSpec *spec.WorkloadsSpec) ([]runtime.Object, error) {
 	policies := []runtime.Object{}
@@ -274,6 +379,175 @@ func (g *Generator) createRequireResourceLimitsPolicy() *ClusterPolicy {
 	return policy
 }
 
+// createRequireProbesPolicy creates a policy requiring liveness and/or readiness probes.
+// With includeInitContainers, sidecar init containers (restartPolicy: Always) must
+// define them too.
+func (g *Generator) createRequireProbesPolicy(requireLiveness, requireReadiness, includeInitContainers bool) *ClusterPolicy {
+	policy := NewClusterPolicy("require-pod-probes")
+	policy.Annotations["policies.kyverno.io/title"] = "Require Pod Probes"
+	policy.Annotations["policies.kyverno.io/category"] = "Best Practices"
+	policy.Annotations["policies.kyverno.io/severity"] = "medium"
+	policy.Annotations["policies.kyverno.io/description"] = "All containers must define the required liveness and readiness probes"
+
+	container := map[string]interface{}{}
+	probes := []string{}
+	if requireLiveness {
+		container["livenessProbe"] = map[string]interface{}{
+			"periodSeconds": ">0",
+		}
+		probes = append(probes, "livenessProbe")
+	}
+	if requireReadiness {
+		container["readinessProbe"] = map[string]interface{}{
+			"periodSeconds": ">0",
+		}
+		probes = append(probes, "readinessProbe")
+	}
+
+	podSpec := map[string]interface{}{
+		"containers": []interface{}{
+			container,
+		},
+	}
+	if includeInitContainers {
+		sidecar := map[string]interface{}{
+			"(restartPolicy)": "Always",
+		}
+		for probe, pattern := range container {
+			sidecar[probe] = pattern
+		}
+		podSpec["=(initContainers)"] = []interface{}{
+			sidecar,
+		}
+	}
+
+	policy.Spec.Rules = []Rule{
+		{
+			Name: "check-probes",
+			Match: MatchResources{
+				Any: []ResourceFilter{
+					{
+						Resources: &ResourceDescription{
+							Kinds: []string{"Pod"},
+						},
+					},
+				},
+			},
+			Validation: &Validation{
+				Message: fmt.Sprintf("All containers must define %v", probes),
+				Pattern: map[string]interface{}{
+					"spec": podSpec,
+				},
+			},
+		},
+	}
+
+	return policy
+}
+
+// createRequireTopologySpreadPolicy creates a policy requiring multi-replica workloads
+// to spread across zones via topology spread constraints or pod anti-affinity.
+func (g *Generator) createRequireTopologySpreadPolicy() *ClusterPolicy {
+	policy := NewClusterPolicy("require-topology-spread")
+	policy.Annotations["policies.kyverno.io/title"] = "Require Topology Spread"
+	policy.Annotations["policies.kyverno.io/category"] = "Availability"
+	policy.Annotations["policies.kyverno.io/severity"] = "medium"
+	policy.Annotations["policies.kyverno.io/description"] = "Deployments and StatefulSets with more than one replica must spread across zones"
+
+	zoneKey := "topology.kubernetes.io/zone"
+
+	policy.Spec.Rules = []Rule{
+		{
+			Name: "check-zone-spread",
+			Match: MatchResources{
+				Any: []ResourceFilter{
+					{
+						Resources: &ResourceDescription{
+							Kinds: []string{"Deployment", "StatefulSet"},
+						},
+					},
+				},
+			},
+			Validation: &Validation{
+				Message: fmt.Sprintf("Workloads with more than one replica must define topologySpreadConstraints or podAntiAffinity with topologyKey %s", zoneKey),
+				Deny: &Deny{
+					Conditions: map[string]interface{}{
+						"all": []interface{}{
+							map[string]interface{}{
+								"key":      "{{ request.object.spec.replicas || `1` }}",
+								"operator": "GreaterThan",
+								"value":    1,
+							},
+							map[string]interface{}{
+								"key":      zoneKey,
+								"operator": "AnyNotIn",
+								"value":    "{{ request.object.spec.template.spec.topologySpreadConstraints[].topologyKey || `[]` }}",
+							},
+							map[string]interface{}{
+								"key":      zoneKey,
+								"operator": "AnyNotIn",
+								"value":    "{{ request.object.spec.template.spec.affinity.podAntiAffinity.requiredDuringSchedulingIgnoredDuringExecution[].topologyKey || `[]` }}",
+							},
+							map[string]interface{}{
+								"key":      zoneKey,
+								"operator": "AnyNotIn",
+								"value":    "{{ request.object.spec.template.spec.affinity.podAntiAffinity.preferredDuringSchedulingIgnoredDuringExecution[].podAffinityTerm.topologyKey || `[]` }}",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return policy
+}
+
+// createRequirePriorityClassPolicy creates a policy requiring critical workloads,
+// optionally narrowed by namespace and pod labels, to use the required PriorityClass.
+func (g *Generator) createRequirePriorityClassPolicy(scheduling *spec.SchedulingSpec) *ClusterPolicy {
+	policy := NewClusterPolicy("require-priority-class")
+	policy.Annotations["policies.kyverno.io/title"] = "Require PriorityClass"
+	policy.Annotations["policies.kyverno.io/category"] = "Availability"
+	policy.Annotations["policies.kyverno.io/severity"] = "medium"
+	policy.Annotations["policies.kyverno.io/description"] = fmt.Sprintf("Critical workloads must use PriorityClass %s", scheduling.RequiredPriorityClass)
+
+	resources := &ResourceDescription{
+		Kinds:      []string{"Pod"},
+		Namespaces: append([]string(nil), scheduling.Namespaces...),
+	}
+	if len(scheduling.Selector) > 0 {
+		matchLabels := make(map[string]string, len(scheduling.Selector))
+		for key, value := range scheduling.Selector {
+			matchLabels[key] = value
+		}
+		resources.Selector = &metav1.LabelSelector{MatchLabels: matchLabels}
+	}
+
+	policy.Spec.Rules = []Rule{
+		{
+			Name: "check-priority-class",
+			Match: MatchResources{
+				Any: []ResourceFilter{
+					{
+						Resources: resources,
+					},
+				},
+			},
+			Validation: &Validation{
+				Message: fmt.Sprintf("Critical workloads must set priorityClassName to %s", scheduling.RequiredPriorityClass),
+				Pattern: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"priorityClassName": scheduling.RequiredPriorityClass,
+					},
+				},
+			},
+		},
+	}
+
+	return policy
+}
+
 // generateImagePolicies creates policies for image registry requirements.
 func (g *Generator) generateImagePolicies(imageSpec *spec.ImageSpec) ([]runtime.Object, error) {
 	policies := []runtime.Object{}
@@ -284,6 +558,12 @@ func (g *Generator) generateImagePolicies(imageSpec *spec.ImageSpec) ([]runtime.
 		policies = append(policies, policy)
 	}
 
+	// Create policy for allowed registries
+	if len(imageSpec.AllowedRegistries) > 0 {
+		policy := g.createAllowedRegistriesPolicy(imageSpec.AllowedRegistries)
+		policies = append(policies, policy)
+	}
+
 	// Create policy for blocked registries
 	if len(imageSpec.BlockedRegistries) > 0 {
 		policy := g.createBlockedRegistriesPolicy(imageSpec.BlockedRegistries)
@@ -331,16 +611,67 @@ func (g *Generator) createRequireDigestsPolicy() *ClusterPolicy {
 	return policy
 }
 
+// createAllowedRegistriesPolicy creates a policy admitting only images from the
+// allowed registries. Registries may use * wildcards (e.g. "*.azurecr.io"), as in
+// the scanner.
+func (g *Generator) createAllowedRegistriesPolicy(allowedRegistries []string) *ClusterPolicy {
+	policy := NewClusterPolicy("restrict-image-registries")
+	policy.Annotations["policies.kyverno.io/title"] = "Restrict Image Registries"
+	policy.Annotations["policies.kyverno.io/category"] = "Supply Chain Security"
+	policy.Annotations["policies.kyverno.io/severity"] = "high"
+	policy.Annotations["policies.kyverno.io/description"] = fmt.Sprintf("Allow images only from: %s", strings.Join(allowedRegistries, ", "))
+
+	// Alternatives are OR'ed with "|"
+	patterns := make([]string, 0, len(allowedRegistries))
+	for _, registry := range allowedRegistries {
+		patterns = append(patterns, registryImagePattern(registry))
+	}
+
+	policy.Spec.Rules = []Rule{
+		{
+			Name: "allow-registries",
+			Match: MatchResources{
+				Any: []ResourceFilter{
+					{
+						Resources: &ResourceDescription{
+							Kinds: []string{"Pod"},
+						},
+					},
+				},
+			},
+			Validation: &Validation{
+				Message: fmt.Sprintf("Images must come from an allowed registry: %s", strings.Join(allowedRegistries, ", ")),
+				Pattern: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{
+								"image": strings.Join(patterns, " | "),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return policy
+}
+
 // createBlockedRegistriesPolicy creates a policy blocking specific registries.
+// Registries may use * wildcards (e.g. "*.example.com"), as in the scanner.
 func (g *Generator) createBlockedRegistriesPolicy(blockedRegistries []string) *ClusterPolicy {
 	policy := NewClusterPolicy("block-image-registries")
 	policy.Annotations["policies.kyverno.io/title"] = "Block Specific Image Registries"
 	policy.Annotations["policies.kyverno.io/category"] = "Supply Chain Security"
 	policy.Annotations["policies.kyverno.io/severity"] = "high"
-	policy.Annotations["policies.kyverno.io/description"] = fmt.Sprintf("Block images from: %v", blockedRegistries)
+	policy.Annotations["policies.kyverno.io/description"] = fmt.Sprintf("Block images from: %s", strings.Join(blockedRegistries, ", "))
+
+	// Negated patterns are AND'ed with "&", so an image must avoid every registry
+	patterns := make([]string, 0, len(blockedRegistries))
+	for _, registry := range blockedRegistries {
+		patterns = append(patterns, "!"+registryImagePattern(registry))
+	}
 
-	// Build deny pattern for blocked registries
-	// For Kyverno, we need to create a deny condition that blocks specific registries
 	policy.Spec.Rules = []Rule{
 		{
 			Name: "block-registries",
@@ -354,14 +685,12 @@ func (g *Generator) createBlockedRegistriesPolicy(blockedRegistries []string) *C
 				},
 			},
 			Validation: &Validation{
-				Message: fmt.Sprintf("Images from blocked registries are not allowed: %v", blockedRegistries),
+				Message: fmt.Sprintf("Images from blocked registries are not allowed: %s", strings.Join(blockedRegistries, ", ")),
 				Pattern: map[string]interface{}{
 					"spec": map[string]interface{}{
 						"containers": []interface{}{
 							map[string]interface{}{
-								// Use negation pattern to block specific registry
-								// This is a simplified pattern - in production, you'd iterate over blockedRegistries
-								"image": "!docker.io/*",
+								"image": strings.Join(patterns, " & "),
 							},
 						},
 					},
@@ -372,3 +701,10 @@ func (g *Generator) createBlockedRegistriesPolicy(blockedRegistries []string) *C
 
 	return policy
 }
+
+// registryImagePattern returns the Kyverno wildcard pattern matching images from
+// a registry. Kyverno matches the image string as written, so images without a
+// registry host (e.g. "nginx") are not matched by a docker.io pattern.
+func registryImagePattern(registry string) string {
+	return strings.TrimSuffix(registry, "/") + "/*"
+}
@@ -0,0 +1,250 @@
+package kyverno
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+)
+
+func namespaceScopeTestSpec() *spec.ClusterSpecification {
+	return &spec.ClusterSpecification{
+		Metadata: spec.Metadata{Name: "test-cluster"},
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{
+				RequireLiveness: true,
+				Containers: &spec.ContainerSpec{
+					Forbidden: []spec.FieldRequirement{
+						{Key: "securityContext.privileged", Value: "true"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGeneratePolicies_DefaultNamespaceExclusions(t *testing.T) {
+	policies, err := NewGenerator().GeneratePolicies(namespaceScopeTestSpec())
+	if err != nil {
+		t.Fatalf("GeneratePolicies failed: %v", err)
+	}
+	if len(policies) == 0 {
+		t.Fatal("Expected generated policies, got none")
+	}
+
+	names := []string{}
+	for _, obj := range policies {
+		policy := obj.(*ClusterPolicy)
+		names = append(names, policy.Name)
+		for _, rule := range policy.Spec.Rules {
+			if len(rule.Exclude.Any) != 1 || rule.Exclude.Any[0].Resources == nil {
+				t.Fatalf("Policy %s rule %s: expected one namespace exclusion, got %+v", policy.Name, rule.Name, rule.Exclude)
+			}
+			if got := rule.Exclude.Any[0].Resources.Namespaces; !reflect.DeepEqual(got, DefaultExcludedNamespaces) {
+				t.Errorf("Policy %s rule %s: expected excluded namespaces %v, got %v", policy.Name, rule.Name, DefaultExcludedNamespaces, got)
+			}
+			for _, filter := range rule.Match.Any {
+				if len(filter.Resources.Namespaces) != 0 {
+					t.Errorf("Policy %s rule %s: expected no match namespaces, got %v", policy.Name, rule.Name, filter.Resources.Namespaces)
+				}
+			}
+		}
+	}
+
+	// The probes policy gets the same system-namespace exclusions as the others
+	if !strings.Contains(strings.Join(names, ","), "require-pod-probes") {
+		t.Errorf("Expected the require-pod-probes policy, got %v", names)
+	}
+}
+
+func TestGeneratePolicies_NamespaceScope(t *testing.T) {
+	generator := NewGenerator().WithNamespaceScope(NamespaceScope{
+		IncludeNamespaces: []string{"apps", "payments"},
+		ExcludeNamespaces: []string{"payments-sandbox", "kube-system"},
+	})
+
+	policies, err := generator.GeneratePolicies(namespaceScopeTestSpec())
+	if err != nil {
+		t.Fatalf("GeneratePolicies failed: %v", err)
+	}
+
+	wantExcluded := []string{"kube-system", "kube-public", "kube-node-lease", "payments-sandbox"}
+	wantIncluded := []string{"apps", "payments"}
+	validator := NewValidator()
+
+	for _, obj := range policies {
+		policy := obj.(*ClusterPolicy)
+		if err := validator.Validate(policy); err != nil {
+			t.Errorf("Policy %s failed validation: %v", policy.Name, err)
+		}
+		for _, rule := range policy.Spec.Rules {
+			if len(rule.Exclude.Any) != 1 {
+				t.Fatalf("Policy %s rule %s: expected one namespace exclusion, got %+v", policy.Name, rule.Name, rule.Exclude)
+			}
+			if got := rule.Exclude.Any[0].Resources.Namespaces; !reflect.DeepEqual(got, wantExcluded) {
+				t.Errorf("Policy %s rule %s: expected excluded namespaces %v, got %v", policy.Name, rule.Name, wantExcluded, got)
+			}
+			for _, filter := range rule.Match.Any {
+				if got := filter.Resources.Namespaces; !reflect.DeepEqual(got, wantIncluded) {
+					t.Errorf("Policy %s rule %s: expected match namespaces %v, got %v", policy.Name, rule.Name, wantIncluded, got)
+				}
+			}
+		}
+	}
+}
+
+func TestGeneratePolicies_OwnershipLabels(t *testing.T) {
+	clusterSpec := namespaceScopeTestSpec()
+	clusterSpec.Metadata.Owner = "jane.doe"
+	clusterSpec.Metadata.Team = "payments"
+
+	policies, err := NewGenerator().GeneratePolicies(clusterSpec)
+	if err != nil {
+		t.Fatalf("GeneratePolicies failed: %v", err)
+	}
+
+	for _, obj := range policies {
+		policy := obj.(*ClusterPolicy)
+		if got := policy.Labels[spec.OwnerLabel]; got != "jane.doe" {
+			t.Errorf("Policy %s: expected owner label 'jane.doe', got '%s'", policy.Name, got)
+		}
+		if got := policy.Labels[spec.TeamLabel]; got != "payments" {
+			t.Errorf("Policy %s: expected team label 'payments', got '%s'", policy.Name, got)
+		}
+	}
+}
+
+func TestGeneratePolicies_RequirePriorityClass(t *testing.T) {
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Scheduling: &spec.SchedulingSpec{
+				RequiredPriorityClass: "business-critical",
+				Namespaces:            []string{"payments"},
+				Selector:              map[string]string{"tier": "critical"},
+			},
+		},
+	}
+
+	// The requirement's own namespaces take precedence over the generator scope
+	generator := NewGenerator().WithNamespaceScope(NamespaceScope{IncludeNamespaces: []string{"apps"}})
+	policies, err := generator.GeneratePolicies(clusterSpec)
+	if err != nil {
+		t.Fatalf("GeneratePolicies failed: %v", err)
+	}
+	if len(policies) != 1 {
+		t.Fatalf("Expected one policy, got %d", len(policies))
+	}
+
+	policy := policies[0].(*ClusterPolicy)
+	if policy.Name != "require-priority-class" {
+		t.Errorf("Expected policy require-priority-class, got %s", policy.Name)
+	}
+	if err := NewValidator().Validate(policy); err != nil {
+		t.Errorf("Policy failed validation: %v", err)
+	}
+
+	rule := policy.Spec.Rules[0]
+	resources := rule.Match.Any[0].Resources
+	if !reflect.DeepEqual(resources.Namespaces, []string{"payments"}) {
+		t.Errorf("Expected match namespaces [payments], got %v", resources.Namespaces)
+	}
+	if resources.Selector == nil || resources.Selector.MatchLabels["tier"] != "critical" {
+		t.Errorf("Expected label selector tier=critical, got %+v", resources.Selector)
+	}
+	pattern := rule.Validation.Pattern.(map[string]interface{})["spec"].(map[string]interface{})
+	if pattern["priorityClassName"] != "business-critical" {
+		t.Errorf("Expected priorityClassName pattern business-critical, got %v", pattern["priorityClassName"])
+	}
+}
+
+func TestGeneratePolicies_ProbesIncludeInitContainers(t *testing.T) {
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{RequireLiveness: true},
+		},
+	}
+
+	policies, err := NewGenerator().GeneratePolicies(clusterSpec)
+	if err != nil {
+		t.Fatalf("GeneratePolicies failed: %v", err)
+	}
+	pattern := policies[0].(*ClusterPolicy).Spec.Rules[0].Validation.Pattern.(map[string]interface{})["spec"].(map[string]interface{})
+	if _, ok := pattern["=(initContainers)"]; ok {
+		t.Errorf("Expected init containers to be left out by default, got %v", pattern)
+	}
+
+	clusterSpec.Spec.Workloads.IncludeInitContainers = true
+	policies, err = NewGenerator().GeneratePolicies(clusterSpec)
+	if err != nil {
+		t.Fatalf("GeneratePolicies failed: %v", err)
+	}
+	policy := policies[0].(*ClusterPolicy)
+	if err := NewValidator().Validate(policy); err != nil {
+		t.Errorf("Policy failed validation: %v", err)
+	}
+	pattern = policy.Spec.Rules[0].Validation.Pattern.(map[string]interface{})["spec"].(map[string]interface{})
+	initContainers, ok := pattern["=(initContainers)"].([]interface{})
+	if !ok || len(initContainers) != 1 {
+		t.Fatalf("Expected an init container pattern, got %v", pattern["=(initContainers)"])
+	}
+	sidecar := initContainers[0].(map[string]interface{})
+	if sidecar["(restartPolicy)"] != "Always" || sidecar["livenessProbe"] == nil {
+		t.Errorf("Expected sidecar init containers to require a livenessProbe, got %v", sidecar)
+	}
+}
+
+func TestGeneratePolicies_ImageRegistries(t *testing.T) {
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{
+				Images: &spec.ImageSpec{
+					AllowedRegistries: []string{"*.azurecr.io", "ghcr.io/acme"},
+					BlockedRegistries: []string{"docker.io", "quay.io/"},
+				},
+			},
+		},
+	}
+
+	policies, err := NewGenerator().GeneratePolicies(clusterSpec)
+	if err != nil {
+		t.Fatalf("GeneratePolicies failed: %v", err)
+	}
+
+	tests := []struct {
+		policy         string
+		wantPattern    string
+		wantRegistries []string
+	}{
+		{"restrict-image-registries", "*.azurecr.io/* | ghcr.io/acme/*", []string{"*.azurecr.io", "ghcr.io/acme"}},
+		{"block-image-registries", "!docker.io/* & !quay.io/*", []string{"docker.io", "quay.io/"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			var policy *ClusterPolicy
+			for _, obj := range policies {
+				if p := obj.(*ClusterPolicy); p.Name == tt.policy {
+					policy = p
+				}
+			}
+			if policy == nil {
+				t.Fatalf("Expected policy %s to be generated", tt.policy)
+			}
+			if err := NewValidator().Validate(policy); err != nil {
+				t.Errorf("Policy failed validation: %v", err)
+			}
+
+			validation := policy.Spec.Rules[0].Validation
+			containers := validation.Pattern.(map[string]interface{})["spec"].(map[string]interface{})["containers"].([]interface{})
+			if got := containers[0].(map[string]interface{})["image"]; got != tt.wantPattern {
+				t.Errorf("Expected image pattern %q, got %q", tt.wantPattern, got)
+			}
+			for _, registry := range tt.wantRegistries {
+				if !strings.Contains(validation.Message, registry) {
+					t.Errorf("Expected message to name registry %s, got %q", registry, validation.Message)
+				}
+			}
+		})
+	}
+}
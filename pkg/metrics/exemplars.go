@@ -0,0 +1,70 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// TraceIDExemplarLabel is the exemplar label linking an observation to its trace
+	TraceIDExemplarLabel = "trace_id"
+
+	// OpenMetricsPath is the metrics server path serving the OpenMetrics format
+	OpenMetricsPath = "/metrics/openmetrics"
+)
+
+// TracerName is the OpenTelemetry tracer reconciles start their spans with
+const TracerName = "github.com/cloudcwfranck/kspec"
+
+// TraceIDFromContext returns the trace ID of the sampled OpenTelemetry span in a
+// context, or "" when there is none. Spans only carry a trace ID once a tracer
+// provider is installed; with the default no-op provider no exemplars are added.
+func TraceIDFromContext(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() || !spanContext.IsSampled() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// observeWithExemplar records a histogram observation, attaching the current
+// trace ID as an exemplar when one is available
+func observeWithExemplar(ctx context.Context, observer prometheus.Observer, value float64) {
+	if traceID := TraceIDFromContext(ctx); traceID != "" {
+		if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok {
+			exemplarObserver.ObserveWithExemplar(value, prometheus.Labels{TraceIDExemplarLabel: traceID})
+			return
+		}
+	}
+	observer.Observe(value)
+}
+
+// OpenMetricsHandler serves the controller-runtime registry in the OpenMetrics
+// format, which is required for exemplars to be exposed to scrapers
+func OpenMetricsHandler() http.Handler {
+	return promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{
+		ErrorHandling:     promhttp.HTTPErrorOnError,
+		EnableOpenMetrics: true,
+	})
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package metrics
 
 import (
+	"context"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
@@ -58,6 +61,15 @@ var (
 		[]string{"cluster_name", "cluster_uid", "cluster_spec"},
 	)
 
+	// ComplianceScoreWeighted tracks the compliance score weighted by spec.scoring.weights
+	ComplianceScoreWeighted = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kspec_compliance_score_weighted",
+			Help: "Compliance score percentage (0-100) per cluster, weighted by per-check scoring weights",
+		},
+		[]string{"cluster_name", "cluster_uid", "cluster_spec"},
+	)
+
 	// DriftDetected tracks whether drift is currently detected
 	DriftDetected = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -130,6 +142,14 @@ var (
 		[]string{"cluster_name", "namespace"},
 	)
 
+	// ClusterClientsCached tracks the cluster clients held by the client factory cache
+	ClusterClientsCached = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "kspec_cluster_clients_cached",
+			Help: "Number of remote cluster clients currently cached",
+		},
+	)
+
 	// ScanDuration tracks scan duration in seconds
 	ScanDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -212,6 +232,24 @@ var (
 		},
 	)
 
+	// ActiveExemptions tracks the unexpired policy exemptions per ClusterSpecification
+	ActiveExemptions = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kspec_active_exemptions",
+			Help: "Number of unexpired policy exemptions per ClusterSpecification",
+		},
+		[]string{"cluster_spec"},
+	)
+
+	// ExemptionExpiryTimestamp tracks when each active policy exemption expires
+	ExemptionExpiryTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kspec_exemption_expiry_timestamp",
+			Help: "Unix timestamp at which an active policy exemption expires",
+		},
+		[]string{"cluster_spec", "exemption"},
+	)
+
 	// LeaderElectionStatus indicates if this instance is the leader (Phase 8)
 	LeaderElectionStatus = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -244,6 +282,7 @@ func init() {
 		ComplianceChecksPassed,
 		ComplianceChecksFailed,
 		ComplianceScore,
+		ComplianceScoreWeighted,
 		DriftDetected,
 		DriftEventsTotal,
 		DriftEventsByType,
@@ -252,6 +291,7 @@ func init() {
 		ClusterTargetHealthy,
 		ClusterTargetInfo,
 		ClusterTargetNodeCount,
+		ClusterClientsCached,
 		ScanDuration,
 		ReconcileTotal,
 		ReconcileErrors,
@@ -259,6 +299,8 @@ func init() {
 		FleetSummaryTotal,
 		ReportsGenerated,
 		KyvernoPolicyCreated,
+		ActiveExemptions,
+		ExemptionExpiryTimestamp,
 		CertificateProvisioningDuration,
 		CertificateRenewalTotal,
 		LeaderElectionStatus,
@@ -287,6 +329,15 @@ func RecordComplianceMetrics(clusterName, clusterUID, clusterSpec string, total,
 	ComplianceScore.With(labels).Set(score)
 }
 
+// RecordWeightedComplianceScore records the weighted compliance score for a cluster
+func RecordWeightedComplianceScore(clusterName, clusterUID, clusterSpec string, score float64) {
+	ComplianceScoreWeighted.With(prometheus.Labels{
+		"cluster_name": clusterName,
+		"cluster_uid":  clusterUID,
+		"cluster_spec": clusterSpec,
+	}).Set(score)
+}
+
 // RecordDriftMetrics records drift metrics for a cluster
 func RecordDriftMetrics(clusterName, clusterUID, clusterSpec string, detected bool, eventCount int, eventsByType map[string]int) {
 	labels := prometheus.Labels{
@@ -315,6 +366,17 @@ func RecordDriftMetrics(clusterName, clusterUID, clusterSpec string, detected bo
 	}
 }
 
+// RecordExemptionMetrics records the active policy exemptions of a ClusterSpecification
+// and their expiry times. Expiry series of exemptions no longer active are removed.
+func RecordExemptionMetrics(clusterSpec string, active int, expiries map[string]time.Time) {
+	ActiveExemptions.WithLabelValues(clusterSpec).Set(float64(active))
+
+	ExemptionExpiryTimestamp.DeletePartialMatch(prometheus.Labels{"cluster_spec": clusterSpec})
+	for exemption, expiresAt := range expiries {
+		ExemptionExpiryTimestamp.WithLabelValues(clusterSpec, exemption).Set(float64(expiresAt.Unix()))
+	}
+}
+
 // RecordRemediationAction records a remediation action
 func RecordRemediationAction(clusterName, clusterUID, clusterSpec, action string) {
 	labels := prometheus.Labels{
@@ -370,13 +432,18 @@ func RecordClusterTargetInfo(clusterName, namespace, platform, version, apiServe
 	ClusterTargetNodeCount.With(nodeLabels).Set(float64(nodeCount))
 }
 
-// RecordScanDuration records the duration of a scan
-func RecordScanDuration(clusterName, clusterSpec string, durationSeconds float64) {
+// RecordClusterClientsCached records the number of cached cluster clients
+func RecordClusterClientsCached(count int) {
+	ClusterClientsCached.Set(float64(count))
+}
+
+// RecordScanDuration records the duration of a scan, with a trace exemplar when tracing is enabled
+func RecordScanDuration(ctx context.Context, clusterName, clusterSpec string, durationSeconds float64) {
 	labels := prometheus.Labels{
 		"cluster_name": clusterName,
 		"cluster_spec": clusterSpec,
 	}
-	ScanDuration.With(labels).Observe(durationSeconds)
+	observeWithExemplar(ctx, ScanDuration.With(labels), durationSeconds)
 }
 
 // RecordReconcile records a reconciliation attempt
@@ -398,13 +465,13 @@ func RecordReconcileError(controller, clusterSpec, errorType string) {
 	ReconcileErrors.With(labels).Inc()
 }
 
-// RecordReconcileDuration records reconciliation duration
-func RecordReconcileDuration(controller, clusterSpec string, durationSeconds float64) {
+// RecordReconcileDuration records reconciliation duration, with a trace exemplar when tracing is enabled
+func RecordReconcileDuration(ctx context.Context, controller, clusterSpec string, durationSeconds float64) {
 	labels := prometheus.Labels{
 		"controller":   controller,
 		"cluster_spec": clusterSpec,
 	}
-	ReconcileDuration.With(labels).Observe(durationSeconds)
+	observeWithExemplar(ctx, ReconcileDuration.With(labels), durationSeconds)
 }
 
 // RecordReportGenerated records a report generation
@@ -1,10 +1,16 @@
 package metrics
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Helper function to get gauge value
@@ -179,6 +185,22 @@ func TestRecordComplianceMetrics(t *testing.T) {
 	}
 }
 
+func TestRecordWeightedComplianceScore(t *testing.T) {
+	labels := prometheus.Labels{
+		"cluster_name": "test-cluster",
+		"cluster_uid":  "test-uid-123",
+		"cluster_spec": "test-spec-v1",
+	}
+
+	RecordWeightedComplianceScore("test-cluster", "test-uid-123", "test-spec-v1", 62.5)
+
+	score := &dto.Metric{}
+	ComplianceScoreWeighted.With(labels).(prometheus.Gauge).Write(score)
+	if score.GetGauge().GetValue() != 62.5 {
+		t.Errorf("Expected weighted score to be 62.5, got %f", score.GetGauge().GetValue())
+	}
+}
+
 // Test Drift Metrics
 
 func TestRecordDriftMetrics(t *testing.T) {
@@ -238,6 +260,37 @@ func TestRecordDriftMetrics(t *testing.T) {
 	}
 }
 
+// Test Exemption Metrics
+
+func TestRecordExemptionMetrics(t *testing.T) {
+	expiresAt := time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC)
+
+	RecordExemptionMetrics("exemption-spec", 2, map[string]time.Time{
+		"legacy-app": expiresAt,
+		"batch-jobs": expiresAt.Add(24 * time.Hour),
+	})
+
+	if value := getGaugeValue(ActiveExemptions.WithLabelValues("exemption-spec")); value != 2 {
+		t.Errorf("Expected 2 active exemptions, got %f", value)
+	}
+	if value := getGaugeValue(ExemptionExpiryTimestamp.WithLabelValues("exemption-spec", "legacy-app")); value != float64(expiresAt.Unix()) {
+		t.Errorf("Expected expiry timestamp %d, got %f", expiresAt.Unix(), value)
+	}
+
+	// A removed exemption's expiry series is dropped on the next recording
+	RecordExemptionMetrics("exemption-spec", 1, map[string]time.Time{"legacy-app": expiresAt})
+
+	if value := getGaugeValue(ActiveExemptions.WithLabelValues("exemption-spec")); value != 1 {
+		t.Errorf("Expected 1 active exemption, got %f", value)
+	}
+	ch := make(chan prometheus.Metric, 10)
+	ExemptionExpiryTimestamp.Collect(ch)
+	close(ch)
+	if series := len(ch); series != 1 {
+		t.Errorf("Expected 1 expiry series after removing an exemption, got %d", series)
+	}
+}
+
 // Test Remediation Metrics
 
 func TestRecordRemediationAction(t *testing.T) {
@@ -364,9 +417,9 @@ func TestRecordReconcileDuration(t *testing.T) {
 	clusterSpec := "test-spec"
 
 	// Record some durations
-	RecordReconcileDuration(controller, clusterSpec, 0.5)
-	RecordReconcileDuration(controller, clusterSpec, 1.2)
-	RecordReconcileDuration(controller, clusterSpec, 0.8)
+	RecordReconcileDuration(context.Background(), controller, clusterSpec, 0.5)
+	RecordReconcileDuration(context.Background(), controller, clusterSpec, 1.2)
+	RecordReconcileDuration(context.Background(), controller, clusterSpec, 0.8)
 
 	t.Log("Successfully recorded reconcile durations")
 }
@@ -375,8 +428,8 @@ func TestRecordScanDuration(t *testing.T) {
 	clusterName := "test-cluster"
 	clusterSpec := "test-spec"
 
-	RecordScanDuration(clusterName, clusterSpec, 2.5)
-	RecordScanDuration(clusterName, clusterSpec, 3.1)
+	RecordScanDuration(context.Background(), clusterName, clusterSpec, 2.5)
+	RecordScanDuration(context.Background(), clusterName, clusterSpec, 3.1)
 
 	t.Log("Successfully recorded scan durations")
 }
@@ -471,10 +524,10 @@ func TestCompleteMonitoringWorkflow(t *testing.T) {
 
 	// 2. Reconciliation happens
 	RecordReconcile("ClusterSpecification", clusterSpec)
-	RecordReconcileDuration("ClusterSpecification", clusterSpec, 1.5)
+	RecordReconcileDuration(context.Background(), "ClusterSpecification", clusterSpec, 1.5)
 
 	// 3. Compliance scan runs
-	RecordScanDuration(clusterName, clusterSpec, 3.2)
+	RecordScanDuration(context.Background(), clusterName, clusterSpec, 3.2)
 	RecordComplianceMetrics(clusterName, clusterUID, clusterSpec, 20, 18, 2)
 
 	// 4. Drift detection runs
@@ -495,3 +548,94 @@ func TestCompleteMonitoringWorkflow(t *testing.T) {
 
 	t.Log("Complete monitoring workflow executed successfully")
 }
+
+// tracedContext returns a context carrying a sampled span with the given trace ID
+func tracedContext(t *testing.T, traceID string) context.Context {
+	t.Helper()
+	id, err := trace.TraceIDFromHex(traceID)
+	if err != nil {
+		t.Fatalf("invalid trace ID: %v", err)
+	}
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    id,
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	})
+	return trace.ContextWithSpanContext(context.Background(), spanContext)
+}
+
+func TestTraceIDFromContext(t *testing.T) {
+	if got := TraceIDFromContext(context.Background()); got != "" {
+		t.Errorf("expected no trace ID without a span, got %q", got)
+	}
+
+	ctx := tracedContext(t, "4bf92f3577b34da6a3ce929d0e0e4736")
+	if got := TraceIDFromContext(ctx); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected the span's trace ID, got %q", got)
+	}
+
+	unsampled := trace.SpanContextFromContext(ctx).WithTraceFlags(0)
+	if got := TraceIDFromContext(trace.ContextWithSpanContext(context.Background(), unsampled)); got != "" {
+		t.Errorf("expected no trace ID for an unsampled span, got %q", got)
+	}
+}
+
+func TestRecordScanDurationExemplar(t *testing.T) {
+	clusterName := "exemplar-cluster"
+	clusterSpec := "exemplar-spec"
+	RecordScanDuration(tracedContext(t, "4bf92f3577b34da6a3ce929d0e0e4736"), clusterName, clusterSpec, 1.5)
+
+	histogram := ScanDuration.With(prometheus.Labels{
+		"cluster_name": clusterName,
+		"cluster_spec": clusterSpec,
+	}).(prometheus.Histogram)
+	metric := &dto.Metric{}
+	if err := histogram.Write(metric); err != nil {
+		t.Fatalf("failed to write histogram: %v", err)
+	}
+
+	found := false
+	for _, bucket := range metric.GetHistogram().GetBucket() {
+		exemplar := bucket.GetExemplar()
+		if exemplar == nil {
+			continue
+		}
+		for _, label := range exemplar.GetLabel() {
+			if label.GetName() == TraceIDExemplarLabel && label.GetValue() == "4bf92f3577b34da6a3ce929d0e0e4736" {
+				found = true
+			}
+		}
+	}
+	if !found {
+		t.Error("expected scan duration observation to carry a trace_id exemplar")
+	}
+}
+
+func TestOpenMetricsHandlerExemplar(t *testing.T) {
+	traceID := "0af7651916cd43dd8448eb211c80319c"
+	RecordReconcileDuration(tracedContext(t, traceID), "clusterspec", "openmetrics-spec", 0.25)
+
+	req := httptest.NewRequest(http.MethodGet, OpenMetricsPath, nil)
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	rec := httptest.NewRecorder()
+	OpenMetricsHandler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if contentType := rec.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "application/openmetrics-text") {
+		t.Errorf("expected OpenMetrics content type, got %q", contentType)
+	}
+
+	found := false
+	for _, line := range strings.Split(rec.Body.String(), "\n") {
+		if strings.HasPrefix(line, "kspec_reconcile_duration_seconds_bucket{") &&
+			strings.Contains(line, `cluster_spec="openmetrics-spec"`) &&
+			strings.Contains(line, `# {trace_id="`+traceID+`"}`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a reconcile duration bucket with a trace_id exemplar, got:\n%s", rec.Body.String())
+	}
+}
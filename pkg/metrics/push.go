@@ -0,0 +1,47 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// PushComplianceMetrics records the compliance metrics of a scanned cluster and
+// pushes them to a Prometheus Pushgateway, so short-lived CLI scans contribute to
+// the same series as the operator. Metrics are grouped by job and, as the
+// instance, cluster name, so each push replaces the cluster's previous metrics in
+// that job.
+func PushComplianceMetrics(ctx context.Context, gatewayURL, job, clusterName, clusterUID, clusterSpec string, total, passed, failed int, weightedScore float64) error {
+	RecordComplianceMetrics(clusterName, clusterUID, clusterSpec, total, passed, failed)
+	RecordWeightedComplianceScore(clusterName, clusterUID, clusterSpec, weightedScore)
+
+	err := push.New(gatewayURL, job).
+		Grouping("instance", clusterName).
+		Collector(ComplianceChecksTotal).
+		Collector(ComplianceChecksPassed).
+		Collector(ComplianceChecksFailed).
+		Collector(ComplianceScore).
+		Collector(ComplianceScoreWeighted).
+		PushContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to push metrics to %s: %w", gatewayURL, err)
+	}
+	return nil
+}
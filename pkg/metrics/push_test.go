@@ -0,0 +1,73 @@
+/*
+Copyright 2025 kspec contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestPushComplianceMetrics(t *testing.T) {
+	var method, path string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	err := PushComplianceMetrics(context.Background(), server.URL, "ci-scan", "push-cluster", "uid-1", "push-spec", 10, 8, 2, 75)
+	if err != nil {
+		t.Fatalf("PushComplianceMetrics() error = %v", err)
+	}
+
+	if method != http.MethodPut {
+		t.Errorf("expected a PUT so the push replaces the cluster's metrics, got %s", method)
+	}
+	if path != "/metrics/job/ci-scan/instance/push-cluster" {
+		t.Errorf("unexpected push path %s", path)
+	}
+	for _, name := range []string{"kspec_compliance_checks_total", "kspec_compliance_checks_passed", "kspec_compliance_checks_failed", "kspec_compliance_score"} {
+		if !bytes.Contains(body, []byte(name)) {
+			t.Errorf("expected pushed metrics to include %s", name)
+		}
+	}
+
+	labels := prometheus.Labels{"cluster_name": "push-cluster", "cluster_uid": "uid-1", "cluster_spec": "push-spec"}
+	if got := getGaugeValue(ComplianceScore.With(labels)); got != 80 {
+		t.Errorf("expected compliance score 80, got %v", got)
+	}
+}
+
+func TestPushComplianceMetrics_GatewayError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := PushComplianceMetrics(context.Background(), server.URL, "ci-scan", "push-cluster", "uid-1", "push-spec", 1, 1, 0, 100)
+	if err == nil {
+		t.Error("expected an error when the gateway rejects the push")
+	}
+}
@@ -25,6 +25,17 @@ var (
 		[]string{"result"},
 	)
 
+	// WebhookValidationDuration tracks how long validating a request against the
+	// active ClusterSpecs takes, excluding decoding and encoding
+	WebhookValidationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kspec_webhook_validation_duration_seconds",
+			Help:    "Webhook validation duration in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"result"}, // result: allowed, denied
+	)
+
 	// WebhookValidationResults tracks validation outcomes
 	WebhookValidationResults = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -34,6 +45,15 @@ var (
 		[]string{"result", "mode"}, // result: allowed, denied, mode: audit, enforce
 	)
 
+	// WebhookDecisionCacheRequests tracks admission decision cache lookups
+	WebhookDecisionCacheRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kspec_webhook_decision_cache_requests_total",
+			Help: "Total number of webhook decision cache lookups",
+		},
+		[]string{"result"}, // result: hit, miss, bypass
+	)
+
 	// CircuitBreakerTripped indicates if circuit breaker is currently tripped
 	CircuitBreakerTripped = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -42,6 +62,14 @@ var (
 		},
 	)
 
+	// CircuitBreakerTrips counts how often the circuit breaker has tripped
+	CircuitBreakerTrips = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "kspec_webhook_circuit_breaker_trips_total",
+			Help: "Total number of times the webhook circuit breaker has tripped",
+		},
+	)
+
 	// CircuitBreakerErrorRate tracks current error rate
 	CircuitBreakerErrorRate = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -82,8 +110,11 @@ func init() {
 	metrics.Registry.MustRegister(
 		WebhookRequestsTotal,
 		WebhookRequestDuration,
+		WebhookValidationDuration,
 		WebhookValidationResults,
+		WebhookDecisionCacheRequests,
 		CircuitBreakerTripped,
+		CircuitBreakerTrips,
 		CircuitBreakerErrorRate,
 		CircuitBreakerTotalRequests,
 		PolicyEnforcementActions,
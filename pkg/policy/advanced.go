@@ -3,11 +3,19 @@ package policy
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
 )
 
 // PolicyTemplate represents a reusable policy template with parameters
@@ -31,29 +39,57 @@ type TemplateParameter struct {
 
 // PolicyDefinition contains the actual policy rules
 type PolicyDefinition struct {
-	RequiredFields  []FieldRequirement
-	ForbiddenFields []FieldRequirement
-	Validations     []ValidationRule
+	RequiredFields  []FieldRequirement `json:"requiredFields,omitempty"`
+	ForbiddenFields []FieldRequirement `json:"forbiddenFields,omitempty"`
+	Validations     []ValidationRule   `json:"validations,omitempty"`
 }
 
 // FieldRequirement defines a required or forbidden field
 type FieldRequirement struct {
-	Key   string
-	Value string
+	Key   string `json:"key"`
+	Value string `json:"value"`
 }
 
 // ValidationRule defines custom validation logic
 type ValidationRule struct {
-	Name       string
-	Expression string // CEL expression or similar
-	Message    string
+	Name       string `json:"name"`
+	Expression string `json:"expression"` // CEL expression or similar
+	Message    string `json:"message,omitempty"`
 }
 
 // PolicyInheritance manages policy composition and inheritance
 type PolicyInheritance struct {
-	BasePolicies []string // Names of parent policies
-	Overrides    map[string]interface{}
-	Additions    PolicyDefinition
+	BasePolicies  []string // Names of parent policies
+	MergeStrategy MergeStrategy
+	Overrides     map[string]interface{}
+	Additions     PolicyDefinition
+}
+
+// MergeStrategy defines how inherited policies are combined
+type MergeStrategy string
+
+const (
+	// MergeStrategyMerge combines requirements, a later requirement with the same
+	// key (or validation with the same name) replacing the earlier one
+	MergeStrategyMerge MergeStrategy = "merge"
+	// MergeStrategyOverride replaces each inherited section (required fields,
+	// forbidden fields, validations) that a later policy defines
+	MergeStrategyOverride MergeStrategy = "override"
+	// MergeStrategyAppend concatenates requirements, keeping duplicates
+	MergeStrategyAppend MergeStrategy = "append"
+)
+
+// ParseMergeStrategy parses a policyInheritance.mergeStrategy value. An empty
+// value selects the CRD default, merge.
+func ParseMergeStrategy(value string) (MergeStrategy, error) {
+	switch strategy := MergeStrategy(value); strategy {
+	case "":
+		return MergeStrategyMerge, nil
+	case MergeStrategyMerge, MergeStrategyOverride, MergeStrategyAppend:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("unknown merge strategy %q (supported: merge, override, append)", value)
+	}
 }
 
 // NamespaceScope defines namespace-level policy scoping
@@ -66,6 +102,7 @@ type NamespaceScope struct {
 // TimeBasedActivation defines time-based policy activation
 type TimeBasedActivation struct {
 	Enabled       bool
+	Schedule      string // cron format, e.g., "* 9-17 * * MON-FRI"
 	ActivePeriods []TimePeriod
 	Timezone      string // e.g., "America/New_York"
 }
@@ -87,6 +124,7 @@ type PolicyExemption struct {
 	Namespaces []string
 	Resources  []ResourceSelector
 	Approver   string
+	TicketRef  string
 	CreatedAt  metav1.Time
 }
 
@@ -136,22 +174,66 @@ func (m *AdvancedPolicyManager) ApplyTemplate(
 	mergedParams := m.mergeParameters(template, parameters)
 
 	// Apply parameters to base policy
-	policy := m.applyParametersToPolicy(&template.BasePolicy, mergedParams)
+	policy, err := m.applyParametersToPolicy(&template.BasePolicy, mergedParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply parameters: %w", err)
+	}
 
 	log.Info("Policy template applied successfully")
 	return policy, nil
 }
 
-// InheritPolicies combines multiple policies through inheritance
+// ParseTemplateParameters converts key=value arguments into parameters of the
+// named template, typed according to each parameter's declared type
+func (m *AdvancedPolicyManager) ParseTemplateParameters(
+	templateName string,
+	args []string,
+) (map[string]interface{}, error) {
+	template, exists := m.Templates[templateName]
+	if !exists {
+		return nil, fmt.Errorf("template %s not found", templateName)
+	}
+
+	parameters := make(map[string]interface{}, len(args))
+	for _, arg := range args {
+		name, raw, ok := strings.Cut(arg, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid parameter %q: expected key=value", arg)
+		}
+
+		param := findParameter(template, name)
+		if param == nil {
+			return nil, fmt.Errorf("template %s has no parameter %s", templateName, name)
+		}
+
+		value, err := parseParameterValue(param.Type, raw)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", name, err)
+		}
+		parameters[name] = value
+	}
+
+	return parameters, nil
+}
+
+// InheritPolicies combines multiple policies through inheritance. Base policies
+// name PolicyLibrary objects or built-in templates, and are combined in order
+// using the merge strategy. Overrides then replace the value of the inherited
+// requirement with the same key, and additions are combined last.
 func (m *AdvancedPolicyManager) InheritPolicies(
 	ctx context.Context,
 	basePolicyNames []string,
+	strategy MergeStrategy,
 	overrides map[string]interface{},
 	additions *PolicyDefinition,
 ) (*PolicyDefinition, error) {
 	log := log.FromContext(ctx)
 
-	log.Info("Inheriting policies", "basePolicies", basePolicyNames)
+	log.Info("Inheriting policies", "basePolicies", basePolicyNames, "mergeStrategy", strategy)
+
+	if _, err := ParseMergeStrategy(string(strategy)); err != nil {
+		return nil, err
+	}
 
 	result := &PolicyDefinition{
 		RequiredFields:  make([]FieldRequirement, 0),
@@ -166,24 +248,50 @@ func (m *AdvancedPolicyManager) InheritPolicies(
 			return nil, fmt.Errorf("failed to get base policy %s: %w", policyName, err)
 		}
 
-		result = m.mergePolicies(result, basePolicy)
+		result = m.mergePolicies(result, basePolicy, strategy)
 	}
 
 	// Apply overrides
 	if len(overrides) > 0 {
-		result = m.applyOverrides(result, overrides)
+		var err error
+		result, err = m.applyOverrides(result, overrides)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Add additional rules
 	if additions != nil {
-		result = m.mergePolicies(result, additions)
+		result = m.mergePolicies(result, additions, strategy)
 	}
 
 	log.Info("Policy inheritance completed")
 	return result, nil
 }
 
-// IsActiveInTimeWindow checks if a policy is active based on time-based activation
+// InheritFromSpec combines the base policies of a ClusterSpecification's
+// policyInheritance using its merge strategy
+func (m *AdvancedPolicyManager) InheritFromSpec(
+	ctx context.Context,
+	inheritance *kspecv1alpha1.PolicyInheritanceSpec,
+	overrides map[string]interface{},
+	additions *PolicyDefinition,
+) (*PolicyDefinition, error) {
+	if inheritance == nil {
+		inheritance = &kspecv1alpha1.PolicyInheritanceSpec{}
+	}
+
+	strategy, err := ParseMergeStrategy(inheritance.MergeStrategy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policyInheritance: %w", err)
+	}
+
+	return m.InheritPolicies(ctx, inheritance.BasePolicies, strategy, overrides, additions)
+}
+
+// IsActiveInTimeWindow checks if a policy is active based on time-based activation.
+// A policy is active when its schedule or any of its active periods matches.
+// An invalid schedule keeps the policy active rather than silently disabling it.
 func (m *AdvancedPolicyManager) IsActiveInTimeWindow(
 	activation *TimeBasedActivation,
 	currentTime time.Time,
@@ -200,6 +308,14 @@ func (m *AdvancedPolicyManager) IsActiveInTimeWindow(
 	}
 
 	currentTime = currentTime.In(location)
+
+	if activation.Schedule != "" {
+		schedule, err := ParseSchedule(activation.Schedule)
+		if err != nil || schedule.Matches(currentTime) {
+			return true
+		}
+	}
+
 	currentDay := currentTime.Weekday().String()
 	currentTimeStr := currentTime.Format("15:04")
 
@@ -234,9 +350,23 @@ func (m *AdvancedPolicyManager) IsExempt(
 	resourceKind, resourceName, resourceNamespace string,
 	resourceLabels map[string]string,
 ) (bool, string) {
+	exemption := m.MatchExemption(ctx, exemptions, resourceKind, resourceName, resourceNamespace, resourceLabels)
+	if exemption == nil {
+		return false, ""
+	}
+	return true, exemption.Reason
+}
+
+// MatchExemption returns the first unexpired exemption covering a resource, or nil
+func (m *AdvancedPolicyManager) MatchExemption(
+	ctx context.Context,
+	exemptions []PolicyExemption,
+	resourceKind, resourceName, resourceNamespace string,
+	resourceLabels map[string]string,
+) *PolicyExemption {
 	currentTime := time.Now()
 
-	for _, exemption := range exemptions {
+	for i, exemption := range exemptions {
 		// Check if exemption has expired
 		if exemption.ExpiresAt != nil && currentTime.After(exemption.ExpiresAt.Time) {
 			continue
@@ -250,18 +380,43 @@ func (m *AdvancedPolicyManager) IsExempt(
 		// Check resource selectors
 		for _, selector := range exemption.Resources {
 			if m.matchesSelector(selector, resourceKind, resourceName, resourceNamespace, resourceLabels) {
-				return true, exemption.Reason
+				return &exemptions[i]
 			}
 		}
 	}
 
-	return false, ""
+	return nil
+}
+
+// ValidateTicketRef checks an exemption ticket reference against a required
+// pattern. An empty pattern accepts any ticket reference, including none.
+func ValidateTicketRef(pattern, ticketRef string) error {
+	if pattern == "" {
+		return nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid ticket pattern %q: %w", pattern, err)
+	}
+
+	if ticketRef == "" {
+		return fmt.Errorf("ticket reference is required to match %q", pattern)
+	}
+	if !re.MatchString(ticketRef) {
+		return fmt.Errorf("ticket reference %q does not match %q", ticketRef, pattern)
+	}
+	return nil
 }
 
-// ApplyNamespaceScope filters ClusterSpecs based on namespace scoping
+// ApplyNamespaceScope filters ClusterSpecs based on namespace scoping.
+// namespaceLabels are the labels of the target namespace, matched against the
+// scope's NamespaceSelector. Exclusions take precedence over both the include
+// list and the selector; when both are set the namespace must satisfy both.
 func (m *AdvancedPolicyManager) ApplyNamespaceScope(
 	scope *NamespaceScope,
 	targetNamespace string,
+	namespaceLabels map[string]string,
 ) bool {
 	if scope == nil {
 		return true // No scoping, applies to all namespaces
@@ -273,14 +428,19 @@ func (m *AdvancedPolicyManager) ApplyNamespaceScope(
 	}
 
 	// Check inclusions
-	if len(scope.IncludeNamespaces) > 0 {
-		return contains(scope.IncludeNamespaces, targetNamespace)
+	if len(scope.IncludeNamespaces) > 0 && !contains(scope.IncludeNamespaces, targetNamespace) {
+		return false
 	}
 
-	// TODO: Implement label selector matching
-	// if scope.NamespaceSelector != nil {
-	//     return matchesLabelSelector(targetNamespace, scope.NamespaceSelector)
-	// }
+	// Check label selector
+	if scope.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(scope.NamespaceSelector)
+		if err != nil {
+			// An invalid selector matches nothing rather than everything
+			return false
+		}
+		return selector.Matches(labels.Set(namespaceLabels))
+	}
 
 	return true
 }
@@ -331,10 +491,13 @@ func (m *AdvancedPolicyManager) mergeParameters(
 	return result
 }
 
+// templateParameterPattern matches {{paramName}} tokens in policy templates
+var templateParameterPattern = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
 func (m *AdvancedPolicyManager) applyParametersToPolicy(
 	basePolicy *PolicyDefinition,
 	parameters map[string]interface{},
-) *PolicyDefinition {
+) (*PolicyDefinition, error) {
 	// Create a copy of the base policy
 	policy := &PolicyDefinition{
 		RequiredFields:  make([]FieldRequirement, len(basePolicy.RequiredFields)),
@@ -346,44 +509,248 @@ func (m *AdvancedPolicyManager) applyParametersToPolicy(
 	copy(policy.ForbiddenFields, basePolicy.ForbiddenFields)
 	copy(policy.Validations, basePolicy.Validations)
 
-	// TODO: Apply parameter substitution to policy fields
-	// This would replace template variables like {{paramName}} with actual values
+	// Replace {{paramName}} tokens with the parameter values
+	var err error
+	for i := range policy.RequiredFields {
+		field := &policy.RequiredFields[i]
+		if field.Value, err = substituteParameters(field.Value, parameters); err != nil {
+			return nil, fmt.Errorf("required field %s: %w", field.Key, err)
+		}
+	}
+	for i := range policy.ForbiddenFields {
+		field := &policy.ForbiddenFields[i]
+		if field.Value, err = substituteParameters(field.Value, parameters); err != nil {
+			return nil, fmt.Errorf("forbidden field %s: %w", field.Key, err)
+		}
+	}
+	for i := range policy.Validations {
+		rule := &policy.Validations[i]
+		if rule.Expression, err = substituteParameters(rule.Expression, parameters); err != nil {
+			return nil, fmt.Errorf("validation %s: %w", rule.Name, err)
+		}
+		if rule.Message, err = substituteParameters(rule.Message, parameters); err != nil {
+			return nil, fmt.Errorf("validation %s: %w", rule.Name, err)
+		}
+	}
 
-	return policy
+	return policy, nil
 }
 
+// substituteParameters replaces {{paramName}} tokens in value with the rendered
+// parameter values. A token naming an unknown parameter is an error.
+func substituteParameters(value string, parameters map[string]interface{}) (string, error) {
+	var missing string
+	result := templateParameterPattern.ReplaceAllStringFunc(value, func(token string) string {
+		name := templateParameterPattern.FindStringSubmatch(token)[1]
+		paramValue, ok := parameters[name]
+		if !ok {
+			if missing == "" {
+				missing = name
+			}
+			return token
+		}
+		return renderParameter(paramValue)
+	})
+
+	if missing != "" {
+		return "", fmt.Errorf("unresolved template parameter %q", missing)
+	}
+	return result, nil
+}
+
+// renderParameter formats a parameter value for substitution into a policy
+func renderParameter(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []string:
+		return strings.Join(v, ",")
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = renderParameter(item)
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// getBasePolicy resolves a base policy by name. Policies published as
+// PolicyLibrary objects take precedence; otherwise the built-in template of the
+// same name is rendered with its default parameters. A policy found in neither
+// returns a NotFound error.
 func (m *AdvancedPolicyManager) getBasePolicy(
 	ctx context.Context,
 	policyName string,
 ) (*PolicyDefinition, error) {
-	// In a real implementation, this would fetch the policy from a storage system
-	// For now, return an empty policy
-	return &PolicyDefinition{
-		RequiredFields:  make([]FieldRequirement, 0),
-		ForbiddenFields: make([]FieldRequirement, 0),
-		Validations:     make([]ValidationRule, 0),
-	}, nil
+	var notFound error = apierrors.NewNotFound(kspecv1alpha1.GroupVersion.WithResource("policylibraries").GroupResource(), policyName)
+
+	if m.Client != nil {
+		library := &kspecv1alpha1.PolicyLibrary{}
+		err := m.Client.Get(ctx, client.ObjectKey{Name: policyName}, library)
+		if err == nil {
+			return policyFromLibrary(library), nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get policy library %s: %w", policyName, err)
+		}
+		notFound = err
+	}
+
+	if _, exists := m.Templates[policyName]; exists {
+		return m.ApplyTemplate(ctx, policyName, nil)
+	}
+
+	return nil, notFound
 }
 
+// policyFromLibrary converts a PolicyLibrary into a policy definition
+func policyFromLibrary(library *kspecv1alpha1.PolicyLibrary) *PolicyDefinition {
+	policy := &PolicyDefinition{
+		RequiredFields:  make([]FieldRequirement, 0, len(library.Spec.RequiredFields)),
+		ForbiddenFields: make([]FieldRequirement, 0, len(library.Spec.ForbiddenFields)),
+		Validations:     make([]ValidationRule, 0, len(library.Spec.Validations)),
+	}
+
+	for _, field := range library.Spec.RequiredFields {
+		policy.RequiredFields = append(policy.RequiredFields, FieldRequirement{Key: field.Key, Value: field.Value})
+	}
+	for _, field := range library.Spec.ForbiddenFields {
+		policy.ForbiddenFields = append(policy.ForbiddenFields, FieldRequirement{Key: field.Key, Value: field.Value})
+	}
+	for _, rule := range library.Spec.Validations {
+		policy.Validations = append(policy.Validations, ValidationRule{
+			Name:       rule.Name,
+			Expression: rule.Expression,
+			Message:    rule.Message,
+		})
+	}
+
+	return policy
+}
+
+// mergePolicies combines policy2 into policy1 using the merge strategy
 func (m *AdvancedPolicyManager) mergePolicies(
 	policy1, policy2 *PolicyDefinition,
+	strategy MergeStrategy,
 ) *PolicyDefinition {
-	result := &PolicyDefinition{
-		RequiredFields:  append(policy1.RequiredFields, policy2.RequiredFields...),
-		ForbiddenFields: append(policy1.ForbiddenFields, policy2.ForbiddenFields...),
-		Validations:     append(policy1.Validations, policy2.Validations...),
+	switch strategy {
+	case MergeStrategyAppend:
+		return &PolicyDefinition{
+			RequiredFields:  append(append([]FieldRequirement{}, policy1.RequiredFields...), policy2.RequiredFields...),
+			ForbiddenFields: append(append([]FieldRequirement{}, policy1.ForbiddenFields...), policy2.ForbiddenFields...),
+			Validations:     append(append([]ValidationRule{}, policy1.Validations...), policy2.Validations...),
+		}
+
+	case MergeStrategyOverride:
+		result := &PolicyDefinition{
+			RequiredFields:  append([]FieldRequirement{}, policy1.RequiredFields...),
+			ForbiddenFields: append([]FieldRequirement{}, policy1.ForbiddenFields...),
+			Validations:     append([]ValidationRule{}, policy1.Validations...),
+		}
+		if len(policy2.RequiredFields) > 0 {
+			result.RequiredFields = append([]FieldRequirement{}, policy2.RequiredFields...)
+		}
+		if len(policy2.ForbiddenFields) > 0 {
+			result.ForbiddenFields = append([]FieldRequirement{}, policy2.ForbiddenFields...)
+		}
+		if len(policy2.Validations) > 0 {
+			result.Validations = append([]ValidationRule{}, policy2.Validations...)
+		}
+		return result
+
+	default:
+		return &PolicyDefinition{
+			RequiredFields:  mergeRequirements(policy1.RequiredFields, policy2.RequiredFields),
+			ForbiddenFields: mergeRequirements(policy1.ForbiddenFields, policy2.ForbiddenFields),
+			Validations:     mergeValidations(policy1.Validations, policy2.Validations),
+		}
 	}
+}
 
+// mergeRequirements combines requirements by key, keeping the position of the
+// first occurrence and the value of the last
+func mergeRequirements(existing, incoming []FieldRequirement) []FieldRequirement {
+	result := append([]FieldRequirement{}, existing...)
+	for _, requirement := range incoming {
+		replaced := false
+		for i := range result {
+			if result[i].Key == requirement.Key {
+				result[i] = requirement
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			result = append(result, requirement)
+		}
+	}
+	return result
+}
+
+// mergeValidations combines validation rules by name, keeping the position of
+// the first occurrence and the definition of the last
+func mergeValidations(existing, incoming []ValidationRule) []ValidationRule {
+	result := append([]ValidationRule{}, existing...)
+	for _, rule := range incoming {
+		replaced := false
+		for i := range result {
+			if result[i].Name == rule.Name {
+				result[i] = rule
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			result = append(result, rule)
+		}
+	}
 	return result
 }
 
+// applyOverrides replaces the value of every inherited requirement whose key is
+// overridden. Keys that match no requirement are an error, so typos do not
+// silently leave a requirement unchanged.
 func (m *AdvancedPolicyManager) applyOverrides(
 	policy *PolicyDefinition,
 	overrides map[string]interface{},
-) *PolicyDefinition {
-	// TODO: Implement override logic
-	// This would allow specific fields to be overridden
-	return policy
+) (*PolicyDefinition, error) {
+	result := &PolicyDefinition{
+		RequiredFields:  append([]FieldRequirement{}, policy.RequiredFields...),
+		ForbiddenFields: append([]FieldRequirement{}, policy.ForbiddenFields...),
+		Validations:     append([]ValidationRule{}, policy.Validations...),
+	}
+
+	unknown := []string{}
+	for key, value := range overrides {
+		matched := false
+		for _, requirements := range [][]FieldRequirement{result.RequiredFields, result.ForbiddenFields} {
+			for i := range requirements {
+				if requirements[i].Key == key {
+					requirements[i].Value = renderParameter(value)
+					matched = true
+				}
+			}
+		}
+		if !matched {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("overrides reference unknown requirements: %s", strings.Join(unknown, ", "))
+	}
+
+	return result, nil
 }
 
 func (m *AdvancedPolicyManager) matchesSelector(
@@ -422,6 +789,43 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
+func findParameter(template *PolicyTemplate, name string) *TemplateParameter {
+	for i := range template.Parameters {
+		if template.Parameters[i].Name == name {
+			return &template.Parameters[i]
+		}
+	}
+	return nil
+}
+
+// parseParameterValue parses a raw parameter value as the given parameter type
+func parseParameterValue(paramType, raw string) (interface{}, error) {
+	switch paramType {
+	case "int":
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid int value %q", raw)
+		}
+		return value, nil
+	case "bool":
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bool value %q", raw)
+		}
+		return value, nil
+	case "array":
+		items := []interface{}{}
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	default:
+		return raw, nil
+	}
+}
+
 func containsValue(slice []interface{}, value interface{}) bool {
 	for _, v := range slice {
 		if v == value {
@@ -458,12 +862,19 @@ func initializeDefaultTemplates() map[string]*PolicyTemplate {
 		},
 		BasePolicy: PolicyDefinition{
 			RequiredFields: []FieldRequirement{
-				{Key: "securityContext.runAsNonRoot", Value: "true"},
+				{Key: "securityContext.runAsNonRoot", Value: "{{runAsNonRoot}}"},
 			},
 			ForbiddenFields: []FieldRequirement{
 				{Key: "securityContext.privileged", Value: "true"},
 				{Key: "hostNetwork", Value: "true"},
 			},
+			Validations: []ValidationRule{
+				{
+					Name:       "privilege-escalation",
+					Expression: "{{allowPrivilegeEscalation}} || object.spec.containers.all(c, !has(c.securityContext.allowPrivilegeEscalation) || !c.securityContext.allowPrivilegeEscalation)",
+					Message:    "allowPrivilegeEscalation must be false (allowPrivilegeEscalation={{allowPrivilegeEscalation}})",
+				},
+			},
 		},
 	}
 
@@ -0,0 +1,155 @@
+package policy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed five-field cron expression (minute, hour, day of month,
+// month, day of week). A policy with a schedule is active during every minute
+// the expression matches, e.g. "* 9-17 * * MON-FRI" for business hours.
+type Schedule struct {
+	minutes     map[int]bool
+	hours       map[int]bool
+	daysOfMonth map[int]bool
+	months      map[int]bool
+	daysOfWeek  map[int]bool
+
+	// Cron matches either day field when both are restricted
+	daysOfMonthRestricted bool
+	daysOfWeekRestricted  bool
+}
+
+// cronField describes the range and names accepted by a cron field
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinutes     = cronField{name: "minute", min: 0, max: 59}
+	cronHours       = cronField{name: "hour", min: 0, max: 23}
+	cronDaysOfMonth = cronField{name: "day of month", min: 1, max: 31}
+	cronMonths      = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	// Day of week 7 is Sunday, as in most cron implementations
+	cronDaysOfWeek = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+)
+
+// ParseSchedule parses a five-field cron expression. Fields accept *, single
+// values, ranges (1-5), steps (*/15, 0-30/10), lists (1,15) and, for months
+// and days of week, three-letter names.
+func ParseSchedule(expression string) (*Schedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields (minute hour day-of-month month day-of-week), got %d", len(fields))
+	}
+
+	schedule := &Schedule{
+		daysOfMonthRestricted: fields[2] != "*",
+		daysOfWeekRestricted:  fields[4] != "*",
+	}
+
+	var err error
+	if schedule.minutes, err = parseCronField(fields[0], cronMinutes); err != nil {
+		return nil, err
+	}
+	if schedule.hours, err = parseCronField(fields[1], cronHours); err != nil {
+		return nil, err
+	}
+	if schedule.daysOfMonth, err = parseCronField(fields[2], cronDaysOfMonth); err != nil {
+		return nil, err
+	}
+	if schedule.months, err = parseCronField(fields[3], cronMonths); err != nil {
+		return nil, err
+	}
+	if schedule.daysOfWeek, err = parseCronField(fields[4], cronDaysOfWeek); err != nil {
+		return nil, err
+	}
+	if schedule.daysOfWeek[7] {
+		schedule.daysOfWeek[0] = true
+	}
+
+	return schedule, nil
+}
+
+// Matches reports whether the minute containing t is covered by the schedule
+func (s *Schedule) Matches(t time.Time) bool {
+	if !s.minutes[t.Minute()] || !s.hours[t.Hour()] || !s.months[int(t.Month())] {
+		return false
+	}
+
+	dayOfMonth := s.daysOfMonth[t.Day()]
+	dayOfWeek := s.daysOfWeek[int(t.Weekday())]
+	if s.daysOfMonthRestricted && s.daysOfWeekRestricted {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
+
+// parseCronField returns the set of values a comma-separated cron field matches
+func parseCronField(value string, field cronField) (map[int]bool, error) {
+	values := make(map[int]bool)
+
+	for _, part := range strings.Split(value, ",") {
+		rangeExpr, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			rangeExpr = part[:i]
+			parsed, err := strconv.Atoi(part[i+1:])
+			if err != nil || parsed <= 0 {
+				return nil, fmt.Errorf("invalid step %q in %s field", part[i+1:], field.name)
+			}
+			step = parsed
+		}
+
+		start, end := field.min, field.max
+		if rangeExpr != "*" {
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			var err error
+			if start, err = parseCronValue(bounds[0], field); err != nil {
+				return nil, err
+			}
+			end = start
+			if len(bounds) == 2 {
+				if end, err = parseCronValue(bounds[1], field); err != nil {
+					return nil, err
+				}
+			} else if step > 1 {
+				// "5/15" runs from 5 to the end of the range
+				end = field.max
+			}
+			if start > end {
+				return nil, fmt.Errorf("invalid range %q in %s field", rangeExpr, field.name)
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			values[v] = true
+		}
+	}
+
+	return values, nil
+}
+
+// parseCronValue parses a number or name within the bounds of a cron field
+func parseCronValue(value string, field cronField) (int, error) {
+	if v, ok := field.names[strings.ToUpper(value)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", value, field.name)
+	}
+	if v < field.min || v > field.max {
+		return 0, fmt.Errorf("%s value %d out of range %d-%d", field.name, v, field.min, field.max)
+	}
+	return v, nil
+}
@@ -0,0 +1,98 @@
+package reporter
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ConfigMapReportKey is the ConfigMap key holding the latest JSON report
+	ConfigMapReportKey = "report.json"
+
+	// ConfigMapReportNameAnnotation records the object name of the stored report
+	ConfigMapReportNameAnnotation = "kspec.io/report-name"
+)
+
+// configMapSink keeps the latest report in a ConfigMap, so in-cluster scans
+// such as scheduled CronJobs leave a snapshot without an object store.
+type configMapSink struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+}
+
+// NewConfigMapSink creates a sink that stores the latest report in the named
+// ConfigMap, creating it if needed.
+func NewConfigMapSink(client kubernetes.Interface, namespace, name string) Sink {
+	return &configMapSink{client: client, namespace: namespace, name: name}
+}
+
+// ConfigMapSinkFactory returns a factory for configmap://<namespace>/<name>
+// sink URLs. Register it with RegisterSinkScheme where a Kubernetes client is
+// available; newClient is only called for configmap sinks.
+func ConfigMapSinkFactory(newClient func() (kubernetes.Interface, error)) SinkFactory {
+	return func(u *url.URL) (Sink, error) {
+		namespace := u.Host
+		name := strings.Trim(u.Path, "/")
+		if namespace == "" || name == "" || strings.Contains(name, "/") {
+			return nil, fmt.Errorf("invalid report sink %q: expected configmap://<namespace>/<name>", u.String())
+		}
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid report sink %q: invalid ConfigMap name: %s", u.String(), strings.Join(errs, ", "))
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Kubernetes client for report sink: %w", err)
+		}
+		return NewConfigMapSink(client, namespace, name), nil
+	}
+}
+
+// Write replaces the report held by the ConfigMap.
+func (s *configMapSink) Write(ctx context.Context, name string, data []byte) error {
+	configMaps := s.client.CoreV1().ConfigMaps(s.namespace)
+
+	configMap, err := configMaps.Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      s.name,
+				Namespace: s.namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/managed-by": "kspec",
+				},
+				Annotations: map[string]string{ConfigMapReportNameAnnotation: name},
+			},
+			Data: map[string]string{ConfigMapReportKey: string(data)},
+		}
+		if _, err := configMaps.Create(ctx, configMap, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create report ConfigMap: %w", err)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get report ConfigMap: %w", err)
+	}
+
+	if configMap.Annotations == nil {
+		configMap.Annotations = make(map[string]string)
+	}
+	configMap.Annotations[ConfigMapReportNameAnnotation] = name
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+	configMap.Data[ConfigMapReportKey] = string(data)
+
+	if _, err := configMaps.Update(ctx, configMap, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update report ConfigMap: %w", err)
+	}
+	return nil
+}
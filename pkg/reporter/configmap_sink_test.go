@@ -0,0 +1,62 @@
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+)
+
+func TestWriteReport_ConfigMapSink(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	factory := ConfigMapSinkFactory(func() (kubernetes.Interface, error) { return client, nil })
+
+	u, err := url.Parse("configmap://kspec-system/nightly-scan-results")
+	require.NoError(t, err)
+	sink, err := factory(u)
+	require.NoError(t, err)
+
+	for i, scanTime := range []string{"2025-01-15T10:30:00Z", "2025-01-16T10:30:00Z"} {
+		result := &scanner.ScanResult{
+			Metadata: scanner.ScanMetadata{
+				ScanTime: scanTime,
+				Spec:     scanner.SpecInfo{Name: "prod-baseline"},
+			},
+			Summary: scanner.ScanSummary{TotalChecks: 2, Passed: i + 1},
+		}
+		_, err := WriteReport(context.Background(), sink, result)
+		require.NoError(t, err)
+	}
+
+	configMap, err := client.CoreV1().ConfigMaps("kspec-system").Get(context.Background(), "nightly-scan-results", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "prod-baseline/20250116T103000Z.json", configMap.Annotations[ConfigMapReportNameAnnotation])
+
+	var written scanner.ScanResult
+	require.NoError(t, json.Unmarshal([]byte(configMap.Data[ConfigMapReportKey]), &written))
+	assert.Equal(t, 2, written.Summary.Passed, "expected the latest report to replace the previous one")
+}
+
+func TestConfigMapSinkFactory_InvalidURL(t *testing.T) {
+	factory := ConfigMapSinkFactory(func() (kubernetes.Interface, error) { return fake.NewSimpleClientset(), nil })
+
+	for _, rawURL := range []string{
+		"configmap://kspec-system",
+		"configmap:///results",
+		"configmap://kspec-system/a/b",
+		"configmap://kspec-system/Results",
+	} {
+		u, err := url.Parse(rawURL)
+		require.NoError(t, err)
+		_, err = factory(u)
+		assert.Error(t, err, rawURL)
+	}
+}
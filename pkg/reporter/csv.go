@@ -0,0 +1,58 @@
+// Package reporter provides output formatting for scan results.
+package reporter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+)
+
+// csvHeader is the header row of CSV reports. Every row starts with the cluster
+// and scan time, so exports of several scans can be concatenated.
+var csvHeader = []string{
+	"cluster", "scan_time", "check", "category", "status", "severity", "message", "remediation", "skip_reason",
+}
+
+// CSVReporter outputs scan results as a flat CSV table, one row per check.
+type CSVReporter struct {
+	writer io.Writer
+}
+
+// NewCSVReporter creates a new CSV reporter.
+func NewCSVReporter(w io.Writer) *CSVReporter {
+	return &CSVReporter{writer: w}
+}
+
+// Report writes the scan results as CSV to the configured writer.
+func (r *CSVReporter) Report(result *scanner.ScanResult) error {
+	writer := csv.NewWriter(r.writer)
+	if err := writer.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, check := range result.Results {
+		row := []string{
+			result.Metadata.Cluster.Name,
+			result.Metadata.ScanTime,
+			check.Name,
+			strings.SplitN(check.Name, ".", 2)[0],
+			string(check.Status),
+			string(check.Severity),
+			check.Message,
+			check.Remediation,
+			string(check.SkipReason),
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row for %s: %w", check.Name, err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVReporter(t *testing.T) {
+	result := &scanner.ScanResult{
+		Metadata: scanner.ScanMetadata{
+			ScanTime: "2025-01-15T10:30:00Z",
+			Cluster:  scanner.ClusterInfo{Name: "prod"},
+		},
+		Results: []scanner.CheckResult{
+			{Name: "kubernetes.version", Status: scanner.StatusPass, Message: "Version 1.29.0 is within range"},
+			{
+				Name:        "workload.security",
+				Status:      scanner.StatusFail,
+				Severity:    scanner.SeverityHigh,
+				Message:     `Found 2 violations in "payments", "orders"`,
+				Remediation: "Set runAsNonRoot: true\nDrop all capabilities",
+			},
+			{Name: "network.policies", Status: scanner.StatusSkip, Message: "Not specified", SkipReason: scanner.SkipReasonSpecSectionAbsent},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewCSVReporter(&buf).Report(result))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+
+	assert.Equal(t, []string{"cluster", "scan_time", "check", "category", "status", "severity", "message", "remediation", "skip_reason"}, rows[0])
+	assert.Equal(t, []string{"prod", "2025-01-15T10:30:00Z", "kubernetes.version", "kubernetes", "pass", "", "Version 1.29.0 is within range", "", ""}, rows[1])
+	assert.Equal(t, `Found 2 violations in "payments", "orders"`, rows[2][6])
+	assert.Equal(t, "Set runAsNonRoot: true\nDrop all capabilities", rows[2][7])
+	assert.Equal(t, "SpecSectionAbsent", rows[3][8])
+}
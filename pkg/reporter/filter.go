@@ -0,0 +1,19 @@
+package reporter
+
+import (
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+)
+
+// OnlyFailures returns a copy of result that keeps only failed, warning and error
+// check results, to shrink reports for large clusters. The summary is left as is,
+// so it still reflects the totals of the full scan.
+func OnlyFailures(result *scanner.ScanResult) *scanner.ScanResult {
+	filtered := *result
+	filtered.Results = []scanner.CheckResult{}
+	for _, r := range result.Results {
+		if r.Status == scanner.StatusFail || r.Status == scanner.StatusWarn || r.Status == scanner.StatusError {
+			filtered.Results = append(filtered.Results, r)
+		}
+	}
+	return &filtered
+}
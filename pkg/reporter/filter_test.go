@@ -0,0 +1,44 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnlyFailures(t *testing.T) {
+	result := &scanner.ScanResult{
+		Summary: scanner.ScanSummary{TotalChecks: 5, Passed: 2, Failed: 1, Warnings: 1, Errors: 1},
+		Results: []scanner.CheckResult{
+			{Name: "kubernetes.version", Status: scanner.StatusPass},
+			{Name: "workload.security", Status: scanner.StatusFail, Severity: scanner.SeverityHigh},
+			{Name: "network.policies", Status: scanner.StatusPass},
+			{Name: "rbac.validation", Status: scanner.StatusWarn},
+			{Name: "admission.controllers", Status: scanner.StatusError},
+			{Name: "capacity.pod-density", Status: scanner.StatusSkip},
+		},
+	}
+
+	filtered := OnlyFailures(result)
+
+	names := []string{}
+	for _, r := range filtered.Results {
+		names = append(names, r.Name)
+	}
+	assert.Equal(t, []string{"workload.security", "rbac.validation", "admission.controllers"}, names)
+	assert.Equal(t, result.Summary, filtered.Summary)
+	// The original result is left untouched, e.g. for archiving
+	assert.Len(t, result.Results, 6)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewJSONReporter(&buf).Report(filtered))
+	var decoded scanner.ScanResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Len(t, decoded.Results, 3)
+	assert.Equal(t, 5, decoded.Summary.TotalChecks)
+	assert.Equal(t, 2, decoded.Summary.Passed)
+}
@@ -0,0 +1,300 @@
+// Package reporter provides output formatting for scan results.
+package reporter
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+)
+
+// HTMLReporter outputs scan results as a self-contained HTML document with
+// inline styles and no external assets, so the report can be shared and
+// opened offline.
+type HTMLReporter struct {
+	writer io.Writer
+}
+
+// NewHTMLReporter creates a new HTML reporter.
+func NewHTMLReporter(w io.Writer) *HTMLReporter {
+	return &HTMLReporter{writer: w}
+}
+
+// htmlReport is the data rendered by the HTML template.
+type htmlReport struct {
+	Result          *scanner.ScanResult
+	PassRate        int
+	ComplianceClass string
+	FailureGroups   []htmlSeverityGroup
+	Results         []scanner.CheckResult
+}
+
+// htmlSeverityGroup lists the failed checks of one severity.
+type htmlSeverityGroup struct {
+	Severity string
+	Checks   []scanner.CheckResult
+}
+
+// Report writes the scan results as HTML to the configured writer.
+func (r *HTMLReporter) Report(result *scanner.ScanResult) error {
+	report := htmlReport{
+		Result:          result,
+		ComplianceClass: "compliance-low",
+		FailureGroups:   r.groupFailures(result.Results),
+		Results:         result.Results,
+	}
+	if result.Summary.TotalChecks > 0 {
+		report.PassRate = (result.Summary.Passed * 100) / result.Summary.TotalChecks
+	}
+	// Same thresholds as the web dashboard
+	if report.PassRate >= 95 {
+		report.ComplianceClass = "compliance-high"
+	} else if report.PassRate >= 80 {
+		report.ComplianceClass = "compliance-medium"
+	}
+
+	// html/template escapes check messages, evidence and remediation text
+	if err := htmlReportTemplate.Execute(r.writer, report); err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
+	return nil
+}
+
+// groupFailures groups failed checks by severity, most severe first. Failures
+// without a known severity are listed last.
+func (r *HTMLReporter) groupFailures(results []scanner.CheckResult) []htmlSeverityGroup {
+	bySeverity := map[scanner.Severity][]scanner.CheckResult{}
+	for _, result := range results {
+		if result.Status != scanner.StatusFail {
+			continue
+		}
+		severity := result.Severity
+		if severity.Rank() == 0 && severity != scanner.SeverityLow {
+			severity = ""
+		}
+		bySeverity[severity] = append(bySeverity[severity], result)
+	}
+
+	var groups []htmlSeverityGroup
+	for i := len(scanner.Severities) - 1; i >= 0; i-- {
+		severity := scanner.Severities[i]
+		if checks := bySeverity[severity]; len(checks) > 0 {
+			groups = append(groups, htmlSeverityGroup{Severity: string(severity), Checks: checks})
+		}
+	}
+	if checks := bySeverity[""]; len(checks) > 0 {
+		groups = append(groups, htmlSeverityGroup{Severity: "unspecified", Checks: checks})
+	}
+	return groups
+}
+
+// htmlStatusClass maps a check status to a dashboard status badge class.
+func htmlStatusClass(status scanner.Status) string {
+	switch status {
+	case scanner.StatusPass:
+		return "status-healthy"
+	case scanner.StatusFail, scanner.StatusError:
+		return "status-error"
+	case scanner.StatusWarn:
+		return "status-warning"
+	default:
+		return "status-skipped"
+	}
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"statusClass": htmlStatusClass,
+	"upper":       strings.ToUpper,
+}).Parse(htmlReportLayout))
+
+// htmlReportLayout follows the styling of the web dashboard.
+const htmlReportLayout = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <title>kspec Compliance Report - {{.Result.Metadata.Cluster.Name}}</title>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <style>
+        * { margin: 0; padding: 0; box-sizing: border-box; }
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif;
+            background: #f5f7fa;
+            color: #2c3e50;
+            padding: 20px;
+        }
+        .container { max-width: 1400px; margin: 0 auto; }
+        header {
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            color: white;
+            padding: 30px;
+            border-radius: 10px;
+            margin-bottom: 30px;
+            box-shadow: 0 4px 6px rgba(0,0,0,0.1);
+        }
+        h1 { font-size: 2em; margin-bottom: 10px; }
+        h2 { font-size: 1.3em; margin-bottom: 15px; }
+        .subtitle { opacity: 0.9; font-size: 0.9em; }
+        .summary-grid {
+            display: grid;
+            grid-template-columns: repeat(auto-fit, minmax(200px, 1fr));
+            gap: 20px;
+            margin-bottom: 30px;
+        }
+        .card {
+            background: white;
+            padding: 25px;
+            border-radius: 10px;
+            box-shadow: 0 2px 4px rgba(0,0,0,0.1);
+            margin-bottom: 30px;
+        }
+        .summary-grid .card { margin-bottom: 0; }
+        .card h3 {
+            font-size: 0.9em;
+            color: #7f8c8d;
+            text-transform: uppercase;
+            margin-bottom: 10px;
+            letter-spacing: 0.5px;
+        }
+        .card .value {
+            font-size: 2.5em;
+            font-weight: bold;
+            color: #2c3e50;
+        }
+        .card .subvalue {
+            color: #95a5a6;
+            font-size: 0.9em;
+            margin-top: 5px;
+        }
+        .compliance-high { color: #27ae60; }
+        .compliance-medium { color: #f39c12; }
+        .compliance-low { color: #e74c3c; }
+        table {
+            width: 100%;
+            background: white;
+            border-collapse: collapse;
+            margin-bottom: 20px;
+        }
+        th, td {
+            padding: 15px;
+            text-align: left;
+            vertical-align: top;
+            border-bottom: 1px solid #ecf0f1;
+        }
+        th {
+            background: #34495e;
+            color: white;
+            font-weight: 600;
+            text-transform: uppercase;
+            font-size: 0.85em;
+            letter-spacing: 0.5px;
+        }
+        tr:last-child td { border-bottom: none; }
+        tr:hover { background: #f8f9fa; }
+        pre {
+            white-space: pre-wrap;
+            font-family: SFMono-Regular, Menlo, Consolas, monospace;
+            font-size: 0.85em;
+        }
+        .status-badge {
+            padding: 4px 12px;
+            border-radius: 12px;
+            font-size: 0.85em;
+            font-weight: 600;
+            display: inline-block;
+        }
+        .status-healthy { background: #d4edda; color: #155724; }
+        .status-warning { background: #fff3cd; color: #856404; }
+        .status-error { background: #f8d7da; color: #721c24; }
+        .status-skipped { background: #ecf0f1; color: #7f8c8d; }
+        .severity-critical, .severity-high { color: #e74c3c; }
+        .severity-medium { color: #f39c12; }
+        .severity-low, .severity-unspecified { color: #7f8c8d; }
+        .empty { color: #95a5a6; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <header>
+            <h1>kspec Compliance Report</h1>
+            <div class="subtitle">Specification {{.Result.Metadata.Spec.Name}} v{{.Result.Metadata.Spec.Version}}</div>
+            <div class="subtitle">Cluster {{.Result.Metadata.Cluster.Name}} ({{.Result.Metadata.Cluster.Version}})</div>
+            <div class="subtitle">Scanned {{.Result.Metadata.ScanTime}} with kspec {{.Result.Metadata.KspecVersion}}</div>
+        </header>
+
+        <div class="summary-grid">
+            <div class="card">
+                <h3>Compliance</h3>
+                <div class="value {{.ComplianceClass}}">{{.PassRate}}%</div>
+                <div class="subvalue">{{.Result.Summary.Passed}} of {{.Result.Summary.TotalChecks}} checks passed</div>
+            </div>
+            <div class="card">
+                <h3>Failed</h3>
+                <div class="value">{{.Result.Summary.Failed}}</div>
+            </div>
+            <div class="card">
+                <h3>Warnings</h3>
+                <div class="value">{{.Result.Summary.Warnings}}</div>
+            </div>
+            <div class="card">
+                <h3>Errors</h3>
+                <div class="value">{{.Result.Summary.Errors}}</div>
+                <div class="subvalue">{{.Result.Summary.Skipped}} skipped</div>
+            </div>
+        </div>
+
+        <div class="card">
+            <h2>Failures</h2>
+            {{- range .FailureGroups}}
+            <h3 class="severity-{{.Severity}}">{{upper .Severity}}</h3>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Check</th>
+                        <th>Message</th>
+                        <th>Remediation</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{- range .Checks}}
+                    <tr>
+                        <td>{{.Name}}</td>
+                        <td>{{.Message}}</td>
+                        <td><pre>{{.Remediation}}</pre></td>
+                    </tr>
+                    {{- end}}
+                </tbody>
+            </table>
+            {{- else}}
+            <p class="empty">No failed checks.</p>
+            {{- end}}
+        </div>
+
+        <div class="card">
+            <h2>All Checks</h2>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Check</th>
+                        <th>Status</th>
+                        <th>Severity</th>
+                        <th>Message</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{- range .Results}}
+                    <tr>
+                        <td>{{.Name}}</td>
+                        <td><span class="status-badge {{statusClass .Status}}">{{upper (printf "%s" .Status)}}</span></td>
+                        <td>{{with .SeverityLabel}}{{.}}{{else}}{{.Severity}}{{end}}</td>
+                        <td>{{.Message}}</td>
+                    </tr>
+                    {{- end}}
+                </tbody>
+            </table>
+        </div>
+    </div>
+</body>
+</html>
+`
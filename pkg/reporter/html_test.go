@@ -0,0 +1,72 @@
+package reporter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTMLReporter(t *testing.T) {
+	result := &scanner.ScanResult{
+		Metadata: scanner.ScanMetadata{
+			ScanTime: "2025-01-15T10:30:00Z",
+			Cluster:  scanner.ClusterInfo{Name: "prod", Version: "v1.29.0"},
+			Spec:     scanner.SpecInfo{Name: "baseline", Version: "1.0.0"},
+		},
+		Summary: scanner.ScanSummary{TotalChecks: 4, Passed: 1, Failed: 3},
+		Results: []scanner.CheckResult{
+			{Name: "kubernetes.version", Status: scanner.StatusPass, Message: "Version 1.29.0 is within range"},
+			{Name: "network.policies", Status: scanner.StatusFail, Severity: scanner.SeverityMedium, Message: "Missing default deny"},
+			{
+				Name:        "workload.security",
+				Status:      scanner.StatusFail,
+				Severity:    scanner.SeverityCritical,
+				Message:     `Pod <script>alert("x")</script> runs privileged`,
+				Remediation: "Set privileged: false",
+			},
+			{Name: "rbac.validation", Status: scanner.StatusFail, Severity: scanner.SeverityHigh, Message: "Wildcard role"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewHTMLReporter(&buf).Report(result))
+	html := buf.String()
+
+	assert.True(t, strings.HasPrefix(html, "<!DOCTYPE html>"))
+	assert.Contains(t, html, "Cluster prod (v1.29.0)")
+	assert.Contains(t, html, `<div class="value compliance-low">25%</div>`)
+	assert.Contains(t, html, "Set privileged: false")
+
+	// Check messages are escaped rather than injected
+	assert.NotContains(t, html, `<script>alert`)
+	assert.Contains(t, html, "&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;")
+
+	// Failures are grouped most severe first
+	critical := strings.Index(html, ">CRITICAL<")
+	high := strings.Index(html, ">HIGH<")
+	medium := strings.Index(html, ">MEDIUM<")
+	require.True(t, critical >= 0 && high >= 0 && medium >= 0, "expected a group per failing severity")
+	assert.True(t, critical < high && high < medium, "expected groups in descending severity")
+
+	// The report is self-contained
+	for _, external := range []string{"<script", "<link", "src=", "url("} {
+		assert.NotContains(t, html, external)
+	}
+}
+
+func TestHTMLReporter_NoFailures(t *testing.T) {
+	result := &scanner.ScanResult{
+		Summary: scanner.ScanSummary{TotalChecks: 1, Passed: 1},
+		Results: []scanner.CheckResult{{Name: "kubernetes.version", Status: scanner.StatusPass}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewHTMLReporter(&buf).Report(result))
+
+	assert.Contains(t, buf.String(), "No failed checks.")
+	assert.Contains(t, buf.String(), `<div class="value compliance-high">100%</div>`)
+}
@@ -68,6 +68,8 @@ func (r *MarkdownReporter) writeSummary(sb *strings.Builder, result *scanner.Sca
 	status := "[PASS]"
 	if result.Summary.Failed > 0 {
 		status = "[FAIL]"
+	} else if result.Summary.Errors > 0 {
+		status = "[ERROR]"
 	} else if result.Summary.Warnings > 0 {
 		status = "[WARN]"
 	}
@@ -83,7 +85,8 @@ func (r *MarkdownReporter) writeSummary(sb *strings.Builder, result *scanner.Sca
 	sb.WriteString(fmt.Sprintf("| Passed | %d |\n", result.Summary.Passed))
 	sb.WriteString(fmt.Sprintf("| Failed | %d |\n", result.Summary.Failed))
 	sb.WriteString(fmt.Sprintf("| Warnings | %d |\n", result.Summary.Warnings))
-	sb.WriteString(fmt.Sprintf("| Skipped | %d |\n\n", result.Summary.Skipped))
+	sb.WriteString(fmt.Sprintf("| Skipped | %d |\n", result.Summary.Skipped))
+	sb.WriteString(fmt.Sprintf("| Errors | %d |\n\n", result.Summary.Errors))
 }
 
 // writeDetailedResults writes detailed results by category.
@@ -99,6 +102,15 @@ func (r *MarkdownReporter) writeDetailedResults(sb *strings.Builder, result *sca
 		}
 	}
 
+	// Checks that could not be evaluated
+	errored := r.filterByStatus(result.Results, scanner.StatusError)
+	if len(errored) > 0 {
+		sb.WriteString("### [ERROR] Checks Not Evaluated\n\n")
+		for _, check := range errored {
+			r.writeCheckDetail(sb, check)
+		}
+	}
+
 	// Warnings
 	warnings := r.filterByStatus(result.Results, scanner.StatusWarn)
 	if len(warnings) > 0 {
@@ -123,7 +135,11 @@ func (r *MarkdownReporter) writeDetailedResults(sb *strings.Builder, result *sca
 	if len(skipped) > 0 {
 		sb.WriteString("### [SKIP] Skipped Checks\n\n")
 		for _, check := range skipped {
-			sb.WriteString(fmt.Sprintf("- **%s**: %s\n", check.Name, check.Message))
+			if check.SkipReason != "" {
+				sb.WriteString(fmt.Sprintf("- **%s**: %s (%s)\n", check.Name, check.Message, check.SkipReason))
+			} else {
+				sb.WriteString(fmt.Sprintf("- **%s**: %s\n", check.Name, check.Message))
+			}
 		}
 		sb.WriteString("\n")
 	}
@@ -136,13 +152,25 @@ func (r *MarkdownReporter) writeCheckDetail(sb *strings.Builder, check scanner.C
 	// Severity badge
 	if check.Severity != "" {
 		severityLabel := r.getSeverityLabel(check.Severity)
-		sb.WriteString(fmt.Sprintf("**Severity**: %s %s\n\n",
-			severityLabel, strings.ToUpper(string(check.Severity))))
+		severityName := strings.ToUpper(string(check.Severity))
+		if check.SeverityLabel != "" {
+			severityName = check.SeverityLabel
+		}
+		sb.WriteString(fmt.Sprintf("**Severity**: %s %s\n\n", severityLabel, severityName))
 	}
 
 	// Message
 	sb.WriteString(fmt.Sprintf("**Finding**: %s\n\n", check.Message))
 
+	// Framework controls
+	if len(check.Controls) > 0 {
+		controls := make([]string, 0, len(check.Controls))
+		for _, control := range check.Controls {
+			controls = append(controls, fmt.Sprintf("%s %s", control.Framework, control.ID))
+		}
+		sb.WriteString(fmt.Sprintf("**Controls**: %s\n\n", strings.Join(controls, ", ")))
+	}
+
 	// Evidence
 	if len(check.Evidence) > 0 {
 		sb.WriteString("**Evidence**:\n\n")
@@ -160,9 +188,56 @@ func (r *MarkdownReporter) writeCheckDetail(sb *strings.Builder, check scanner.C
 		sb.WriteString("\n```\n\n")
 	}
 
+	// Structured remediation
+	if action := check.RemediationAction; action != nil {
+		sb.WriteString(fmt.Sprintf("**Remediation action** (`%s`)", action.Type))
+		if action.Description != "" {
+			sb.WriteString(fmt.Sprintf(": %s", action.Description))
+		}
+		sb.WriteString("\n\n")
+		if action.Type == scanner.RemediationTypeDoc {
+			sb.WriteString(fmt.Sprintf("<%s>\n\n", action.Payload))
+		} else {
+			sb.WriteString("```\n")
+			sb.WriteString(action.Payload)
+			sb.WriteString("\n```\n\n")
+		}
+	}
+
+	// Remediation steps
+	if len(check.RemediationSteps) > 0 {
+		sb.WriteString("**Remediation steps**:\n\n")
+		writeRemediationSteps(sb, check.RemediationSteps)
+	}
+
 	sb.WriteString("---\n\n")
 }
 
+// writeRemediationSteps writes remediation steps as a numbered list, with
+// commands and patches in code blocks so they can be copied as-is.
+func writeRemediationSteps(sb *strings.Builder, steps []scanner.RemediationStep) {
+	for i, step := range steps {
+		sb.WriteString(fmt.Sprintf("%d. %s\n\n", i+1, step.Description))
+		switch step.Type {
+		case scanner.RemediationStepKubectl:
+			writeIndentedCodeBlock(sb, "sh", step.Command)
+		case scanner.RemediationStepPatch:
+			writeIndentedCodeBlock(sb, "", step.Patch)
+		case scanner.RemediationStepDocLink:
+			sb.WriteString(fmt.Sprintf("   <%s>\n\n", step.URL))
+		}
+	}
+}
+
+// writeIndentedCodeBlock writes a fenced code block nested in a list item.
+func writeIndentedCodeBlock(sb *strings.Builder, language, code string) {
+	sb.WriteString("   ```" + language + "\n")
+	for _, line := range strings.Split(code, "\n") {
+		sb.WriteString("   " + line + "\n")
+	}
+	sb.WriteString("   ```\n\n")
+}
+
 // writeRemediationSection writes the remediation summary.
 func (r *MarkdownReporter) writeRemediationSection(sb *strings.Builder, result *scanner.ScanResult) {
 	failures := r.filterByStatus(result.Results, scanner.StatusFail)
@@ -180,7 +255,7 @@ func (r *MarkdownReporter) writeRemediationSection(sb *strings.Builder, result *
 	low := r.filterBySeverity(failures, scanner.SeverityLow)
 
 	if len(critical) > 0 {
-		sb.WriteString("### [CRITICAL] Critical Priority\n\n")
+		sb.WriteString(fmt.Sprintf("### [CRITICAL] %s\n\n", r.priorityTitle(critical, "Critical Priority")))
 		for i, check := range critical {
 			sb.WriteString(fmt.Sprintf("%d. **%s**: %s\n", i+1, check.Name, check.Message))
 		}
@@ -188,7 +263,7 @@ func (r *MarkdownReporter) writeRemediationSection(sb *strings.Builder, result *
 	}
 
 	if len(high) > 0 {
-		sb.WriteString("### [HIGH] High Priority\n\n")
+		sb.WriteString(fmt.Sprintf("### [HIGH] %s\n\n", r.priorityTitle(high, "High Priority")))
 		for i, check := range high {
 			sb.WriteString(fmt.Sprintf("%d. **%s**: %s\n", i+1, check.Name, check.Message))
 		}
@@ -196,7 +271,7 @@ func (r *MarkdownReporter) writeRemediationSection(sb *strings.Builder, result *
 	}
 
 	if len(medium) > 0 {
-		sb.WriteString("### [MEDIUM] Medium Priority\n\n")
+		sb.WriteString(fmt.Sprintf("### [MEDIUM] %s\n\n", r.priorityTitle(medium, "Medium Priority")))
 		for i, check := range medium {
 			sb.WriteString(fmt.Sprintf("%d. **%s**: %s\n", i+1, check.Name, check.Message))
 		}
@@ -204,7 +279,7 @@ func (r *MarkdownReporter) writeRemediationSection(sb *strings.Builder, result *
 	}
 
 	if len(low) > 0 {
-		sb.WriteString("### [LOW] Low Priority\n\n")
+		sb.WriteString(fmt.Sprintf("### [LOW] %s\n\n", r.priorityTitle(low, "Low Priority")))
 		for i, check := range low {
 			sb.WriteString(fmt.Sprintf("%d. **%s**: %s\n", i+1, check.Name, check.Message))
 		}
@@ -212,6 +287,15 @@ func (r *MarkdownReporter) writeRemediationSection(sb *strings.Builder, result *
 	}
 }
 
+// priorityTitle returns the heading for a severity group, preferring the
+// organisation-specific severity label when one is configured.
+func (r *MarkdownReporter) priorityTitle(checks []scanner.CheckResult, defaultTitle string) string {
+	if len(checks) > 0 && checks[0].SeverityLabel != "" {
+		return checks[0].SeverityLabel
+	}
+	return defaultTitle
+}
+
 // filterByStatus filters check results by status.
 func (r *MarkdownReporter) filterByStatus(results []scanner.CheckResult, status scanner.Status) []scanner.CheckResult {
 	filtered := make([]scanner.CheckResult, 0)
@@ -0,0 +1,86 @@
+package reporter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownReporter_RemediationSteps(t *testing.T) {
+	result := &scanner.ScanResult{
+		Metadata: scanner.ScanMetadata{
+			ScanTime: "2025-01-15T10:30:00Z",
+			Cluster:  scanner.ClusterInfo{Name: "prod"},
+		},
+		Summary: scanner.ScanSummary{TotalChecks: 1, Failed: 1},
+		Results: []scanner.CheckResult{
+			{
+				Name:        "workload.security",
+				Status:      scanner.StatusFail,
+				Severity:    scanner.SeverityHigh,
+				Message:     "Found 1 workload security violations across 1 workloads",
+				Remediation: "Set runAsNonRoot: true",
+				RemediationSteps: []scanner.RemediationStep{
+					{
+						Type:        scanner.RemediationStepKubectl,
+						Command:     `kubectl patch deployment web -n default --type strategic -p '{"spec":{}}'`,
+						Description: "Harden the container securityContext of Deployment default/web",
+					},
+					{
+						Type:        scanner.RemediationStepPatch,
+						Patch:       "spec:\n  hostNetwork: false",
+						Description: "Merge into the pod spec",
+					},
+					{
+						Type:        scanner.RemediationStepDocLink,
+						URL:         "https://kubernetes.io/docs/concepts/security/pod-security-standards/",
+						Description: "Fix the remaining violations in the workload manifests",
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewMarkdownReporter(&buf).Report(result))
+	out := buf.String()
+
+	assert.Contains(t, out, "**Remediation steps**:\n\n"+
+		"1. Harden the container securityContext of Deployment default/web\n\n"+
+		"   ```sh\n"+
+		"   kubectl patch deployment web -n default --type strategic -p '{\"spec\":{}}'\n"+
+		"   ```\n\n"+
+		"2. Merge into the pod spec\n\n"+
+		"   ```\n"+
+		"   spec:\n"+
+		"     hostNetwork: false\n"+
+		"   ```\n\n"+
+		"3. Fix the remaining violations in the workload manifests\n\n"+
+		"   <https://kubernetes.io/docs/concepts/security/pod-security-standards/>\n\n")
+}
+
+func TestMarkdownReporter_Controls(t *testing.T) {
+	result := &scanner.ScanResult{
+		Summary: scanner.ScanSummary{TotalChecks: 1, Failed: 1},
+		Results: []scanner.CheckResult{
+			{
+				Name:     "network.policies",
+				Status:   scanner.StatusFail,
+				Severity: scanner.SeverityHigh,
+				Message:  "Namespace default has no NetworkPolicy",
+				Controls: []scanner.ControlRef{
+					{Framework: "CIS-Kubernetes-v1.8", ID: "5.3.2"},
+					{Framework: "NIST-800-53", ID: "SC-7"},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewMarkdownReporter(&buf).Report(result))
+
+	assert.Contains(t, buf.String(), "**Controls**: CIS-Kubernetes-v1.8 5.3.2, NIST-800-53 SC-7\n\n")
+}
@@ -129,6 +129,19 @@ func (r *OSCALReporter) buildObservations(results []scanner.CheckResult) []map[s
 				"value": string(result.Severity),
 			})
 		}
+		if result.SeverityLabel != "" {
+			obs["props"] = append(obs["props"].([]map[string]interface{}), map[string]interface{}{
+				"name":  "severity-label",
+				"value": result.SeverityLabel,
+			})
+		}
+		if result.SkipReason != "" {
+			obs["props"] = append(obs["props"].([]map[string]interface{}), map[string]interface{}{
+				"name":  "skip-reason",
+				"value": string(result.SkipReason),
+			})
+		}
+		obs["props"] = append(obs["props"].([]map[string]interface{}), controlProps(result.Controls)...)
 
 		// Add evidence if present
 		if len(result.Evidence) > 0 {
@@ -166,6 +179,13 @@ func (r *OSCALReporter) buildFindings(results []scanner.CheckResult) []map[strin
 					},
 				},
 			}
+			if result.SeverityLabel != "" {
+				finding["props"] = append(finding["props"].([]map[string]interface{}), map[string]interface{}{
+					"name":  "severity-label",
+					"value": result.SeverityLabel,
+				})
+			}
+			finding["props"] = append(finding["props"].([]map[string]interface{}), controlProps(result.Controls)...)
 
 			// Add remediation if present
 			if result.Remediation != "" {
@@ -178,3 +198,17 @@ func (r *OSCALReporter) buildFindings(results []scanner.CheckResult) []map[strin
 
 	return findings
 }
+
+// controlProps describes the framework controls of a check as control-id
+// props, classed by framework.
+func controlProps(controls []scanner.ControlRef) []map[string]interface{} {
+	props := make([]map[string]interface{}, 0, len(controls))
+	for _, control := range controls {
+		props = append(props, map[string]interface{}{
+			"name":  "control-id",
+			"class": control.Framework,
+			"value": control.ID,
+		})
+	}
+	return props
+}
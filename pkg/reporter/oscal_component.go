@@ -0,0 +1,221 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/google/uuid"
+)
+
+// kspecControlSource is the control-implementation source of checks that no
+// compliance framework control of the spec maps.
+const kspecControlSource = "https://github.com/cloudcwfranck/kspec"
+
+// OSCALComponentReporter outputs an OSCAL component-definition describing the
+// controls kspec implements through its checks, for registering kspec as a
+// component in an OSCAL-based GRC pipeline.
+type OSCALComponentReporter struct {
+	writer io.Writer
+	checks []scanner.Check
+
+	// now returns the current time; tests override it
+	now func() time.Time
+}
+
+// NewOSCALComponentReporter creates a new OSCAL component-definition reporter
+// for the given checks.
+func NewOSCALComponentReporter(w io.Writer, checks []scanner.Check) *OSCALComponentReporter {
+	return &OSCALComponentReporter{writer: w, checks: checks}
+}
+
+// currentTime returns the reporter's notion of now.
+func (r *OSCALComponentReporter) currentTime() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
+
+// Report writes the component definition to the configured writer. Each
+// compliance framework of the spec becomes a control implementation whose
+// controls are implemented by their mapped checks; checks no control maps are
+// listed as controls of their own. clusterSpec may be nil.
+func (r *OSCALComponentReporter) Report(clusterSpec *spec.ClusterSpecification) error {
+	document := map[string]interface{}{
+		"component-definition": map[string]interface{}{
+			"uuid":     uuid.New().String(),
+			"metadata": r.buildMetadata(clusterSpec),
+			"components": []map[string]interface{}{
+				{
+					"uuid":        uuid.New().String(),
+					"type":        "software",
+					"title":       "kspec",
+					"description": "Kubernetes cluster compliance scanner and policy enforcer",
+					"props": []map[string]interface{}{
+						{
+							"name":  "version",
+							"value": scanner.Version,
+						},
+					},
+					"control-implementations": r.buildControlImplementations(clusterSpec),
+				},
+			},
+		},
+	}
+
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return fmt.Errorf("failed to encode OSCAL component definition: %w", err)
+	}
+	return nil
+}
+
+// buildMetadata constructs the OSCAL metadata section.
+func (r *OSCALComponentReporter) buildMetadata(clusterSpec *spec.ClusterSpecification) map[string]interface{} {
+	title := "kspec Component Definition"
+	version := scanner.Version
+	if clusterSpec != nil {
+		if clusterSpec.Metadata.Name != "" {
+			title = fmt.Sprintf("%s - %s", title, clusterSpec.Metadata.Name)
+		}
+		if clusterSpec.Metadata.Version != "" {
+			version = clusterSpec.Metadata.Version
+		}
+	}
+
+	return map[string]interface{}{
+		"title":         title,
+		"last-modified": r.currentTime().UTC().Format(time.RFC3339),
+		"version":       version,
+		"oscal-version": "1.0.4",
+	}
+}
+
+// buildControlImplementations constructs a control implementation per
+// compliance framework, followed by one for the checks no framework maps.
+func (r *OSCALComponentReporter) buildControlImplementations(clusterSpec *spec.ClusterSpecification) []map[string]interface{} {
+	checksByName := make(map[string]scanner.Check, len(r.checks))
+	for _, check := range r.checks {
+		checksByName[check.Name()] = check
+	}
+
+	implementations := []map[string]interface{}{}
+	mapped := map[string]bool{}
+	if clusterSpec != nil && clusterSpec.Spec.Compliance != nil {
+		for _, framework := range clusterSpec.Spec.Compliance.Frameworks {
+			var requirements []map[string]interface{}
+			for _, control := range framework.Controls {
+				var checks []scanner.Check
+				for _, mapping := range control.Mappings {
+					// Mappings to checks this build does not have implement nothing
+					if check, ok := checksByName[mapping.Check]; ok {
+						checks = append(checks, check)
+						mapped[check.Name()] = true
+					}
+				}
+				if len(checks) == 0 {
+					continue
+				}
+				requirements = append(requirements, r.buildImplementedRequirement(control.ID, checks))
+			}
+			if len(requirements) == 0 {
+				continue
+			}
+
+			name := framework.Name
+			if framework.Revision != "" {
+				name = fmt.Sprintf("%s %s", framework.Name, framework.Revision)
+			}
+			implementations = append(implementations, map[string]interface{}{
+				"uuid":                     uuid.New().String(),
+				"source":                   url.PathEscape(framework.Name),
+				"description":              fmt.Sprintf("Controls of %s implemented by kspec checks", name),
+				"implemented-requirements": requirements,
+			})
+		}
+	}
+
+	var requirements []map[string]interface{}
+	for _, check := range r.checks {
+		if !mapped[check.Name()] {
+			requirements = append(requirements, r.buildImplementedRequirement(check.Name(), []scanner.Check{check}))
+		}
+	}
+	if len(requirements) > 0 {
+		implementations = append(implementations, map[string]interface{}{
+			"uuid":                     uuid.New().String(),
+			"source":                   kspecControlSource,
+			"description":              "kspec compliance checks, each implementing the control of the same name",
+			"implemented-requirements": requirements,
+		})
+	}
+
+	return implementations
+}
+
+// buildImplementedRequirement constructs the implementation of a control by
+// checks, stating what each check verifies and the spec fields it enforces.
+func (r *OSCALComponentReporter) buildImplementedRequirement(controlID string, checks []scanner.Check) map[string]interface{} {
+	statements := make([]string, 0, len(checks))
+	props := []map[string]interface{}{}
+	if token := oscalToken(controlID); token != controlID {
+		props = append(props, map[string]interface{}{
+			"name":  "label",
+			"value": controlID,
+		})
+	}
+
+	for _, check := range checks {
+		statement := fmt.Sprintf("%s: %s", check.Name(), scanner.Summary(check))
+		if fields := check.SpecFields(); len(fields) > 0 {
+			specFields := make([]string, len(fields))
+			for i, field := range fields {
+				specFields[i] = "spec." + field
+			}
+			statement = fmt.Sprintf("%s Enforces %s.", statement, strings.Join(specFields, ", "))
+		}
+		statements = append(statements, statement)
+
+		props = append(props,
+			map[string]interface{}{
+				"name":  "check-id",
+				"value": check.Name(),
+			},
+			map[string]interface{}{
+				"name":  "severity",
+				"value": string(check.Severity()),
+			},
+		)
+	}
+
+	return map[string]interface{}{
+		"uuid":        uuid.New().String(),
+		"control-id":  oscalToken(controlID),
+		"description": strings.Join(statements, "\n\n"),
+		"props":       props,
+	}
+}
+
+// oscalToken converts a control ID into an OSCAL token: lower case, as in the
+// NIST catalogs (e.g. "AC-2" becomes "ac-2"), starting with a letter or
+// underscore and containing only letters, digits, '.', '-' and '_'.
+func oscalToken(id string) string {
+	token := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' || r == '_' {
+			return unicode.ToLower(r)
+		}
+		return '-'
+	}, id)
+	if first := []rune(token + "_")[0]; !unicode.IsLetter(first) && first != '_' {
+		token = "_" + token
+	}
+	return token
+}
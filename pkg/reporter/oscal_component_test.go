@@ -0,0 +1,232 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/scanner/checks"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validateOSCALComponentDefinition validates a document against the OSCAL
+// component-definition schema in testdata.
+func validateOSCALComponentDefinition(t *testing.T, document []byte) {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/oscal-component-definition-1.0.4.schema.json")
+	require.NoError(t, err)
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	var instance interface{}
+	require.NoError(t, json.Unmarshal(document, &instance))
+
+	for _, violation := range validateJSONSchema("$", schema, instance) {
+		t.Errorf("schema violation: %s", violation)
+	}
+}
+
+// validateJSONSchema validates value against the JSON Schema keywords used by
+// the testdata schemas: type, required, properties, additionalProperties,
+// items, minItems, minLength, pattern and the date-time format.
+func validateJSONSchema(path string, schema map[string]interface{}, value interface{}) []string {
+	var violations []string
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected object", path)}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				if _, ok := object[name.(string)]; !ok {
+					violations = append(violations, fmt.Sprintf("%s: missing required property %q", path, name))
+				}
+			}
+		}
+		for name, property := range object {
+			propertySchema, ok := properties[name].(map[string]interface{})
+			if !ok {
+				if schema["additionalProperties"] == false {
+					violations = append(violations, fmt.Sprintf("%s: unknown property %q", path, name))
+				}
+				continue
+			}
+			violations = append(violations, validateJSONSchema(path+"."+name, propertySchema, property)...)
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected array", path)}
+		}
+		if minItems, ok := schema["minItems"].(float64); ok && len(array) < int(minItems) {
+			violations = append(violations, fmt.Sprintf("%s: fewer than %v items", path, minItems))
+		}
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range array {
+				violations = append(violations, validateJSONSchema(fmt.Sprintf("%s[%d]", path, i), items, item)...)
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected string", path)}
+		}
+		if minLength, ok := schema["minLength"].(float64); ok && len(str) < int(minLength) {
+			violations = append(violations, fmt.Sprintf("%s: shorter than %v", path, minLength))
+		}
+		if pattern, ok := schema["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(str) {
+			violations = append(violations, fmt.Sprintf("%s: %q does not match %s", path, str, pattern))
+		}
+		if schema["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339, str); err != nil {
+				violations = append(violations, fmt.Sprintf("%s: %q is not a date-time", path, str))
+			}
+		}
+	}
+	return violations
+}
+
+func TestOSCALComponentReporter(t *testing.T) {
+	checkList := []scanner.Check{
+		&checks.WorkloadSecurityCheck{},
+		&checks.NetworkPolicyCheck{},
+		&checks.RBACCheck{},
+	}
+	clusterSpec := &spec.ClusterSpecification{
+		Metadata: spec.Metadata{Name: "production", Version: "2.1.0"},
+		Spec: spec.SpecFields{
+			Compliance: &spec.ComplianceSpec{
+				Frameworks: []spec.ComplianceFramework{
+					{
+						Name:     "NIST-800-53",
+						Revision: "Rev5",
+						Controls: []spec.ComplianceControl{
+							{ID: "SC-7", Title: "Boundary Protection", Mappings: []spec.ControlMapping{
+								{Check: "network.policies"},
+								{Check: "workload.security"},
+							}},
+							// Controls mapping only unknown checks are not implemented
+							{ID: "SI-4", Title: "System Monitoring", Mappings: []spec.ControlMapping{
+								{Check: "observability.unknown"},
+							}},
+						},
+					},
+					{
+						Name: "CIS Kubernetes",
+						Controls: []spec.ComplianceControl{
+							{ID: "5.2.1", Title: "Minimize admission of privileged containers", Mappings: []spec.ControlMapping{
+								{Check: "workload.security"},
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	r := NewOSCALComponentReporter(&buf, checkList)
+	r.now = func() time.Time { return time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC) }
+	require.NoError(t, r.Report(clusterSpec))
+
+	validateOSCALComponentDefinition(t, buf.Bytes())
+
+	var document struct {
+		ComponentDefinition struct {
+			Metadata struct {
+				Title        string `json:"title"`
+				LastModified string `json:"last-modified"`
+				Version      string `json:"version"`
+			} `json:"metadata"`
+			Components []struct {
+				ControlImplementations []struct {
+					Source                  string `json:"source"`
+					ImplementedRequirements []struct {
+						ControlID   string `json:"control-id"`
+						Description string `json:"description"`
+						Props       []struct {
+							Name  string `json:"name"`
+							Value string `json:"value"`
+						} `json:"props"`
+					} `json:"implemented-requirements"`
+				} `json:"control-implementations"`
+			} `json:"components"`
+		} `json:"component-definition"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+
+	definition := document.ComponentDefinition
+	assert.Equal(t, "kspec Component Definition - production", definition.Metadata.Title)
+	assert.Equal(t, "2025-01-15T10:30:00Z", definition.Metadata.LastModified)
+	assert.Equal(t, "2.1.0", definition.Metadata.Version)
+
+	require.Len(t, definition.Components, 1)
+	implementations := definition.Components[0].ControlImplementations
+	require.Len(t, implementations, 3)
+
+	nist := implementations[0]
+	assert.Equal(t, "NIST-800-53", nist.Source)
+	require.Len(t, nist.ImplementedRequirements, 1)
+	sc7 := nist.ImplementedRequirements[0]
+	assert.Equal(t, "sc-7", sc7.ControlID)
+	assert.Contains(t, sc7.Description, "network.policies: ")
+	assert.Contains(t, sc7.Description, "workload.security: ")
+	assert.Contains(t, sc7.Description, "Enforces spec.workloads.")
+	assert.Equal(t, "label", sc7.Props[0].Name)
+	assert.Equal(t, "SC-7", sc7.Props[0].Value)
+
+	cis := implementations[1]
+	assert.Equal(t, "CIS%20Kubernetes", cis.Source)
+	assert.Equal(t, "_5.2.1", cis.ImplementedRequirements[0].ControlID)
+
+	// Checks no framework maps implement controls of their own
+	unmapped := implementations[2]
+	assert.Equal(t, kspecControlSource, unmapped.Source)
+	require.Len(t, unmapped.ImplementedRequirements, 1)
+	assert.Equal(t, "rbac.validation", unmapped.ImplementedRequirements[0].ControlID)
+}
+
+func TestOSCALComponentReporter_WithoutSpec(t *testing.T) {
+	var buf bytes.Buffer
+	checkList := []scanner.Check{&checks.WorkloadSecurityCheck{}, &checks.RBACCheck{}}
+	require.NoError(t, NewOSCALComponentReporter(&buf, checkList).Report(nil))
+
+	validateOSCALComponentDefinition(t, buf.Bytes())
+	assert.Contains(t, buf.String(), `"control-id": "workload.security"`)
+	assert.Contains(t, buf.String(), `"control-id": "rbac.validation"`)
+}
+
+func TestOSCALToken(t *testing.T) {
+	tests := map[string]string{
+		"AC-2":         "ac-2",
+		"ac-2.1":       "ac-2.1",
+		"5.2.1":        "_5.2.1",
+		"CC6.1 (SOC2)": "cc6.1--soc2-",
+	}
+	for id, want := range tests {
+		assert.Equal(t, want, oscalToken(id), id)
+	}
+}
+
+func TestValidateJSONSchema_RejectsInvalidDocument(t *testing.T) {
+	data, err := os.ReadFile("testdata/oscal-component-definition-1.0.4.schema.json")
+	require.NoError(t, err)
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	var instance interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"component-definition": {"uuid": "not-a-uuid", "metdata": {}}}`), &instance))
+
+	violations := validateJSONSchema("$", schema, instance)
+	assert.Len(t, violations, 3)
+}
@@ -0,0 +1,27 @@
+package reporter
+
+import (
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOSCALReporter_ControlProps(t *testing.T) {
+	r := NewOSCALReporter(nil)
+	results := []scanner.CheckResult{
+		{
+			Name:     "network.policies",
+			Status:   scanner.StatusFail,
+			Severity: scanner.SeverityHigh,
+			Controls: []scanner.ControlRef{{Framework: "NIST-800-53", ID: "SC-7"}},
+		},
+	}
+	want := map[string]interface{}{"name": "control-id", "class": "NIST-800-53", "value": "SC-7"}
+
+	observations := r.buildObservations(results)
+	assert.Contains(t, observations[0]["props"], want)
+
+	findings := r.buildFindings(results)
+	assert.Contains(t, findings[0]["props"], want)
+}
@@ -2,21 +2,108 @@
 package reporter
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 
 	"github.com/cloudcwfranck/kspec/pkg/scanner"
 )
 
+// SARIF result levels.
+const (
+	SARIFLevelError   = "error"
+	SARIFLevelWarning = "warning"
+	SARIFLevelNote    = "note"
+	SARIFLevelNone    = "none"
+)
+
+// SARIFFingerprintKey is the partialFingerprints key identifying a result across
+// scans, so that code scanning tools update existing alerts instead of opening new ones.
+const SARIFFingerprintKey = "kspecCheckId"
+
+// sarifInformationURI is the kspec project page, used as the tool information URI
+// and as the help URI of rules without their own documentation.
+const sarifInformationURI = "https://github.com/cloudcwfranck/kspec"
+
+// sarifSecuritySeverity maps kspec severities to the CVSS-style scores code
+// scanning uses to rank security alerts.
+var sarifSecuritySeverity = map[scanner.Severity]string{
+	scanner.SeverityCritical: "9.0",
+	scanner.SeverityHigh:     "7.0",
+	scanner.SeverityMedium:   "5.0",
+	scanner.SeverityLow:      "3.0",
+}
+
+// DefaultSARIFLevels is the default mapping of kspec severities to SARIF levels,
+// in the format accepted by ParseSARIFLevelMapping.
+const DefaultSARIFLevels = "critical=error,high=error,medium=warning,low=note"
+
+// SARIFLevelMapping maps kspec severities to SARIF result levels.
+type SARIFLevelMapping map[scanner.Severity]string
+
+// DefaultSARIFLevelMapping returns the default severity to SARIF level mapping.
+func DefaultSARIFLevelMapping() SARIFLevelMapping {
+	return SARIFLevelMapping{
+		scanner.SeverityCritical: SARIFLevelError,
+		scanner.SeverityHigh:     SARIFLevelError,
+		scanner.SeverityMedium:   SARIFLevelWarning,
+		scanner.SeverityLow:      SARIFLevelNote,
+	}
+}
+
+// ParseSARIFLevelMapping parses a comma-separated list of severity=level pairs
+// (e.g. "medium=error,low=warning"). Severities that are not listed keep their
+// default level.
+func ParseSARIFLevelMapping(value string) (SARIFLevelMapping, error) {
+	mapping := DefaultSARIFLevelMapping()
+
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid SARIF level mapping %q (expected severity=level)", pair)
+		}
+
+		severity := scanner.Severity(strings.ToLower(strings.TrimSpace(parts[0])))
+		if _, ok := mapping[severity]; !ok {
+			return nil, fmt.Errorf("unknown severity %q (supported: critical, high, medium, low)", parts[0])
+		}
+
+		level := strings.ToLower(strings.TrimSpace(parts[1]))
+		switch level {
+		case SARIFLevelError, SARIFLevelWarning, SARIFLevelNote:
+		default:
+			return nil, fmt.Errorf("unknown SARIF level %q (supported: error, warning, note)", parts[1])
+		}
+
+		mapping[severity] = level
+	}
+
+	return mapping, nil
+}
+
 // SARIFReporter outputs scan results in SARIF (Static Analysis Results Interchange Format) format.
 type SARIFReporter struct {
 	writer io.Writer
+	levels SARIFLevelMapping
 }
 
-// NewSARIFReporter creates a new SARIF reporter.
+// NewSARIFReporter creates a new SARIF reporter using the default level mapping.
 func NewSARIFReporter(w io.Writer) *SARIFReporter {
-	return &SARIFReporter{writer: w}
+	return NewSARIFReporterWithLevels(w, DefaultSARIFLevelMapping())
+}
+
+// NewSARIFReporterWithLevels creates a new SARIF reporter with a custom severity to level mapping.
+func NewSARIFReporterWithLevels(w io.Writer, levels SARIFLevelMapping) *SARIFReporter {
+	return &SARIFReporter{writer: w, levels: levels}
 }
 
 // Report writes the scan results in SARIF format to the configured writer.
@@ -44,16 +131,18 @@ func (r *SARIFReporter) buildSARIF(result *scanner.ScanResult) map[string]interf
 
 // buildRun constructs a SARIF run.
 func (r *SARIFReporter) buildRun(result *scanner.ScanResult) map[string]interface{} {
+	rules, ruleIndex := r.buildRules(result.Results)
+
 	return map[string]interface{}{
 		"tool": map[string]interface{}{
 			"driver": map[string]interface{}{
 				"name":           "kspec",
 				"version":        result.Metadata.KspecVersion,
-				"informationUri": "https://github.com/cloudcwfranck/kspec",
-				"rules":          r.buildRules(result.Results),
+				"informationUri": sarifInformationURI,
+				"rules":          rules,
 			},
 		},
-		"results": r.buildResults(result.Results),
+		"results": r.buildResults(result.Results, ruleIndex),
 		"properties": map[string]interface{}{
 			"cluster-name":    result.Metadata.Cluster.Name,
 			"cluster-version": result.Metadata.Cluster.Version,
@@ -64,60 +153,83 @@ func (r *SARIFReporter) buildRun(result *scanner.ScanResult) map[string]interfac
 	}
 }
 
-// buildRules constructs SARIF rules from check results.
-func (r *SARIFReporter) buildRules(results []scanner.CheckResult) []map[string]interface{} {
+// buildRules constructs one SARIF rule per distinct check, sorted by check name,
+// and returns the index of each rule for results to reference.
+func (r *SARIFReporter) buildRules(results []scanner.CheckResult) ([]map[string]interface{}, map[string]int) {
 	rulesMap := make(map[string]map[string]interface{})
 
 	for _, result := range results {
-		if _, exists := rulesMap[result.Name]; !exists {
-			rule := map[string]interface{}{
-				"id": result.Name,
-				"shortDescription": map[string]interface{}{
-					"text": result.Name,
-				},
-				"fullDescription": map[string]interface{}{
-					"text": r.getRuleDescription(result.Name),
-				},
-				"defaultConfiguration": map[string]interface{}{
-					"level": r.mapSeverityToLevel(result.Severity),
-				},
-				"help": map[string]interface{}{
-					"text": result.Message,
-				},
-			}
+		if _, exists := rulesMap[result.Name]; exists {
+			continue
+		}
 
-			// Add remediation if available
-			if result.Remediation != "" {
-				rule["help"].(map[string]interface{})["text"] = fmt.Sprintf("%s\n\nRemediation:\n%s",
-					result.Message, result.Remediation)
-			}
+		description := r.getRuleDescription(result.Name)
+		help := description
+		if result.Remediation != "" {
+			help = fmt.Sprintf("%s\n\nRemediation:\n%s", description, result.Remediation)
+		}
 
-			rulesMap[result.Name] = rule
+		properties := map[string]interface{}{
+			"tags": []string{"kubernetes", "compliance", strings.SplitN(result.Name, ".", 2)[0]},
 		}
+		if score, ok := sarifSecuritySeverity[result.Severity]; ok {
+			properties["security-severity"] = score
+		}
+
+		rulesMap[result.Name] = map[string]interface{}{
+			"id":   result.Name,
+			"name": result.Name,
+			"shortDescription": map[string]interface{}{
+				"text": result.Name,
+			},
+			"fullDescription": map[string]interface{}{
+				"text": description,
+			},
+			"defaultConfiguration": map[string]interface{}{
+				"level": r.mapSeverityToLevel(result.Severity),
+			},
+			"helpUri": r.getRuleHelpURI(result),
+			"help": map[string]interface{}{
+				"text": help,
+			},
+			"properties": properties,
+		}
+	}
+
+	// Sort rules so their order, and the indexes results reference, are stable
+	names := make([]string, 0, len(rulesMap))
+	for name := range rulesMap {
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
-	// Convert map to slice
-	rules := make([]map[string]interface{}, 0, len(rulesMap))
-	for _, rule := range rulesMap {
-		rules = append(rules, rule)
+	rules := make([]map[string]interface{}, 0, len(names))
+	ruleIndex := make(map[string]int, len(names))
+	for i, name := range names {
+		rules = append(rules, rulesMap[name])
+		ruleIndex[name] = i
 	}
 
-	return rules
+	return rules, ruleIndex
 }
 
-// buildResults constructs SARIF results from check results.
-func (r *SARIFReporter) buildResults(results []scanner.CheckResult) []map[string]interface{} {
+// buildResults constructs SARIF results from check results, referencing their
+// rules by ID and index.
+func (r *SARIFReporter) buildResults(results []scanner.CheckResult, ruleIndex map[string]int) []map[string]interface{} {
 	sarifResults := make([]map[string]interface{}, 0)
 
 	for _, result := range results {
-		// Only report failures and warnings in SARIF
-		if result.Status != scanner.StatusFail && result.Status != scanner.StatusWarn {
+		// Only report failures, warnings, checks that could not be evaluated and
+		// skipped checks in SARIF
+		if result.Status == scanner.StatusPass {
 			continue
 		}
 
+		resourcePath := fmt.Sprintf("cluster://%s", result.Name)
 		sarifResult := map[string]interface{}{
-			"ruleId": result.Name,
-			"level":  r.mapStatusToLevel(result.Status, result.Severity),
+			"ruleId":    result.Name,
+			"ruleIndex": ruleIndex[result.Name],
+			"level":     r.mapStatusToLevel(result.Status, result.Severity),
 			"message": map[string]interface{}{
 				"text": result.Message,
 			},
@@ -125,16 +237,38 @@ func (r *SARIFReporter) buildResults(results []scanner.CheckResult) []map[string
 				{
 					"physicalLocation": map[string]interface{}{
 						"artifactLocation": map[string]interface{}{
-							"uri": fmt.Sprintf("cluster://%s", result.Name),
+							"uri": resourcePath,
 						},
 					},
 				},
 			},
+			"partialFingerprints": map[string]interface{}{
+				SARIFFingerprintKey: sarifFingerprint(result.Name, resourcePath),
+			},
+		}
+
+		// Skipped checks are recorded as not applicable, which code scanning
+		// tools do not raise as alerts
+		if result.Status == scanner.StatusSkip {
+			sarifResult["kind"] = "notApplicable"
 		}
 
-		// Add evidence as properties
-		if len(result.Evidence) > 0 {
-			sarifResult["properties"] = result.Evidence
+		// Add evidence and structured remediation as properties
+		properties := make(map[string]interface{})
+		for key, value := range result.Evidence {
+			properties[key] = value
+		}
+		if result.RemediationAction != nil {
+			properties["remediationAction"] = result.RemediationAction
+		}
+		if result.SeverityLabel != "" {
+			properties["severityLabel"] = result.SeverityLabel
+		}
+		if result.SkipReason != "" {
+			properties["skipReason"] = string(result.SkipReason)
+		}
+		if len(properties) > 0 {
+			sarifResult["properties"] = properties
 		}
 
 		sarifResults = append(sarifResults, sarifResult)
@@ -146,9 +280,22 @@ func (r *SARIFReporter) buildResults(results []scanner.CheckResult) []map[string
 // getRuleDescription returns a description for a given check rule.
 func (r *SARIFReporter) getRuleDescription(ruleName string) string {
 	descriptions := map[string]string{
-		"kubernetes.version":    "Validates Kubernetes cluster version is within specified range",
-		"podsecurity.standards": "Validates Pod Security Standards labels on namespaces",
-		"network.policies":      "Validates network policy requirements",
+		"kubernetes.version":           "Validates Kubernetes cluster version is within specified range",
+		"kubernetes.deprecated-apis":   "Detects resources using APIs removed in the target Kubernetes version",
+		"podsecurity.standards":        "Validates Pod Security Standards labels on namespaces",
+		"network.policies":             "Validates network policy requirements",
+		"workload.security":            "Validates container security requirements of workloads",
+		"workload.image-signatures":    "Verifies that workload images carry a valid cosign signature",
+		"workload.probes":              "Validates liveness and readiness probes of workloads",
+		"workload.resource-efficiency": "Flags containers with missing or oversized resource requests and limits",
+		"rbac.validation":              "Validates RBAC requirements",
+		"admission.controllers":        "Validates admission controller requirements",
+		"observability.validation":     "Validates observability requirements",
+		"secrets.plaintext-env":        "Detects secrets exposed as plaintext environment variables",
+		"scheduling.priority-class":    "Validates that critical workloads use the required PriorityClass",
+		"availability.topology-spread": "Validates that multi-replica workloads spread across zones",
+		"capacity.pod-density":         "Validates that nodes do not run more pods than the spec allows",
+		"custom.cel-checks":            "Evaluates the CEL expressions of the spec's custom checks against cluster resources",
 	}
 
 	if desc, exists := descriptions[ruleName]; exists {
@@ -157,20 +304,27 @@ func (r *SARIFReporter) getRuleDescription(ruleName string) string {
 	return fmt.Sprintf("Validates %s compliance requirement", ruleName)
 }
 
+// getRuleHelpURI returns the documentation URL of a check's remediation, or the
+// kspec project page.
+func (r *SARIFReporter) getRuleHelpURI(result scanner.CheckResult) string {
+	if result.RemediationAction != nil && result.RemediationAction.Type == scanner.RemediationTypeDoc {
+		return result.RemediationAction.Payload
+	}
+	return sarifInformationURI
+}
+
+// sarifFingerprint returns a stable identifier for a check's result on a resource.
+func sarifFingerprint(checkName, resourcePath string) string {
+	sum := sha256.Sum256([]byte(checkName + "|" + resourcePath))
+	return hex.EncodeToString(sum[:])
+}
+
 // mapSeverityToLevel maps kspec severity to SARIF level.
 func (r *SARIFReporter) mapSeverityToLevel(severity scanner.Severity) string {
-	switch severity {
-	case scanner.SeverityCritical:
-		return "error"
-	case scanner.SeverityHigh:
-		return "error"
-	case scanner.SeverityMedium:
-		return "warning"
-	case scanner.SeverityLow:
-		return "note"
-	default:
-		return "warning"
+	if level, ok := r.levels[severity]; ok {
+		return level
 	}
+	return SARIFLevelWarning
 }
 
 // mapStatusToLevel maps kspec status and severity to SARIF level.
@@ -179,7 +333,13 @@ func (r *SARIFReporter) mapStatusToLevel(status scanner.Status, severity scanner
 		return r.mapSeverityToLevel(severity)
 	}
 	if status == scanner.StatusWarn {
-		return "warning"
+		return SARIFLevelWarning
+	}
+	if status == scanner.StatusError {
+		return SARIFLevelError
+	}
+	if status == scanner.StatusSkip {
+		return SARIFLevelNone
 	}
-	return "note"
+	return SARIFLevelNote
 }
@@ -0,0 +1,227 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sarifTestLog struct {
+	Runs []struct {
+		Tool struct {
+			Driver struct {
+				Rules []struct {
+					ID                   string `json:"id"`
+					HelpURI              string `json:"helpUri"`
+					FullDescription      struct{ Text string }
+					DefaultConfiguration struct{ Level string }
+					Properties           map[string]interface{}
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []sarifTestResult `json:"results"`
+	} `json:"runs"`
+}
+
+type sarifTestResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+func sarifTestScan() *scanner.ScanResult {
+	return &scanner.ScanResult{
+		Metadata: scanner.ScanMetadata{Cluster: scanner.ClusterInfo{Name: "prod"}},
+		Results: []scanner.CheckResult{
+			{Name: "workload.security", Status: scanner.StatusFail, Severity: scanner.SeverityCritical, Message: "Found 2 violations",
+				RemediationAction: &scanner.RemediationAction{Type: scanner.RemediationTypeDoc, Payload: "https://kubernetes.io/docs/concepts/security/pod-security-standards/"}},
+			{Name: "kubernetes.version", Status: scanner.StatusPass, Severity: scanner.SeverityHigh, Message: "Version 1.29.0 is within range"},
+			{Name: "network.policies", Status: scanner.StatusFail, Severity: scanner.SeverityMedium, Message: "Missing default deny"},
+		},
+	}
+}
+
+func decodeSARIF(t *testing.T, result *scanner.ScanResult) sarifTestLog {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, NewSARIFReporter(&buf).Report(result))
+
+	var log sarifTestLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	require.Len(t, log.Runs, 1)
+	return log
+}
+
+func TestSARIFReporter_Rules(t *testing.T) {
+	run := decodeSARIF(t, sarifTestScan()).Runs[0]
+
+	rules := run.Tool.Driver.Rules
+	require.Len(t, rules, 3)
+	assert.Equal(t, "kubernetes.version", rules[0].ID)
+	assert.Equal(t, "network.policies", rules[1].ID)
+	assert.Equal(t, "workload.security", rules[2].ID)
+
+	assert.Equal(t, "Validates network policy requirements", rules[1].FullDescription.Text)
+	assert.Equal(t, SARIFLevelWarning, rules[1].DefaultConfiguration.Level)
+	assert.Equal(t, "5.0", rules[1].Properties["security-severity"])
+	assert.Equal(t, "https://github.com/cloudcwfranck/kspec", rules[1].HelpURI)
+
+	assert.Equal(t, SARIFLevelError, rules[2].DefaultConfiguration.Level)
+	assert.Equal(t, "https://kubernetes.io/docs/concepts/security/pod-security-standards/", rules[2].HelpURI)
+}
+
+func TestSARIFReporter_ResultsReferenceRules(t *testing.T) {
+	run := decodeSARIF(t, sarifTestScan()).Runs[0]
+
+	require.Len(t, run.Results, 2)
+	for _, result := range run.Results {
+		assert.Equal(t, result.RuleID, run.Tool.Driver.Rules[result.RuleIndex].ID)
+		assert.NotEmpty(t, result.PartialFingerprints[SARIFFingerprintKey])
+	}
+	assert.NotEqual(t, run.Results[0].PartialFingerprints[SARIFFingerprintKey], run.Results[1].PartialFingerprints[SARIFFingerprintKey])
+}
+
+func TestSARIFReporter_StableFingerprints(t *testing.T) {
+	first := decodeSARIF(t, sarifTestScan()).Runs[0]
+
+	// A re-run with different messages and result order collapses onto the same alerts
+	rescan := sarifTestScan()
+	rescan.Results[0].Message = "Found 5 violations"
+	rescan.Results[0], rescan.Results[2] = rescan.Results[2], rescan.Results[0]
+	second := decodeSARIF(t, rescan).Runs[0]
+
+	fingerprints := func(results []sarifTestResult) map[string]string {
+		byRule := make(map[string]string)
+		for _, result := range results {
+			byRule[result.RuleID] = result.PartialFingerprints[SARIFFingerprintKey]
+		}
+		return byRule
+	}
+	assert.Equal(t, fingerprints(first.Results), fingerprints(second.Results))
+}
+
+func TestParseSARIFLevelMapping(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected SARIFLevelMapping
+		errMsg   string
+	}{
+		{
+			name:     "empty keeps defaults",
+			value:    "",
+			expected: DefaultSARIFLevelMapping(),
+		},
+		{
+			name:     "defaults round-trip",
+			value:    DefaultSARIFLevels,
+			expected: DefaultSARIFLevelMapping(),
+		},
+		{
+			name:  "partial override",
+			value: "medium=error, LOW=Warning",
+			expected: SARIFLevelMapping{
+				scanner.SeverityCritical: SARIFLevelError,
+				scanner.SeverityHigh:     SARIFLevelError,
+				scanner.SeverityMedium:   SARIFLevelError,
+				scanner.SeverityLow:      SARIFLevelWarning,
+			},
+		},
+		{
+			name:  "empty pairs are ignored",
+			value: "critical=note,,",
+			expected: SARIFLevelMapping{
+				scanner.SeverityCritical: SARIFLevelNote,
+				scanner.SeverityHigh:     SARIFLevelError,
+				scanner.SeverityMedium:   SARIFLevelWarning,
+				scanner.SeverityLow:      SARIFLevelNote,
+			},
+		},
+		{
+			name:   "pair without level",
+			value:  "medium",
+			errMsg: `invalid SARIF level mapping "medium"`,
+		},
+		{
+			name:   "unknown severity",
+			value:  "urgent=error",
+			errMsg: `unknown severity "urgent"`,
+		},
+		{
+			name:   "unknown level",
+			value:  "low=info",
+			errMsg: `unknown SARIF level "info"`,
+		},
+		{
+			name:   "none is not a result level",
+			value:  "low=none",
+			errMsg: `unknown SARIF level "none"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping, err := ParseSARIFLevelMapping(tt.value)
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				assert.Nil(t, mapping)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, mapping)
+		})
+	}
+}
+
+func TestNewSARIFReporterWithLevels(t *testing.T) {
+	tests := []struct {
+		name     string
+		levels   string
+		expected map[string]string
+	}{
+		{
+			name:   "default levels",
+			levels: "",
+			expected: map[string]string{
+				"workload.security": SARIFLevelError,
+				"network.policies":  SARIFLevelWarning,
+			},
+		},
+		{
+			name:   "overridden failure levels",
+			levels: "critical=warning,medium=error",
+			expected: map[string]string{
+				"workload.security": SARIFLevelWarning,
+				"network.policies":  SARIFLevelError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levels, err := ParseSARIFLevelMapping(tt.levels)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, NewSARIFReporterWithLevels(&buf, levels).Report(sarifTestScan()))
+
+			var log sarifTestLog
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+			require.Len(t, log.Runs, 1)
+
+			emitted := map[string]string{}
+			for _, result := range log.Runs[0].Results {
+				emitted[result.RuleID] = result.Level
+			}
+			// Passing checks are not reported, so only the failures carry a level
+			assert.Equal(t, tt.expected, emitted)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Package reporter provides output formatting for scan results.
+package reporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+)
+
+// Sink stores scan reports outside the cluster, e.g. on a mounted volume for
+// long-term audit archival.
+type Sink interface {
+	// Write stores a report under the given object name (relative to the sink's prefix).
+	Write(ctx context.Context, name string, data []byte) error
+}
+
+// SinkFactory creates a Sink from a sink URL such as file:///var/lib/kspec/reports.
+type SinkFactory func(u *url.URL) (Sink, error)
+
+var (
+	sinkFactoriesMu sync.RWMutex
+	sinkFactories   = map[string]SinkFactory{
+		"file": newFileSink,
+	}
+)
+
+// RegisterSinkScheme registers a factory for sink URLs with the given scheme.
+// Sinks that need extra setup, or that are not built into kspec such as object
+// stores, are registered through this hook.
+func RegisterSinkScheme(scheme string, factory SinkFactory) {
+	sinkFactoriesMu.Lock()
+	defer sinkFactoriesMu.Unlock()
+	sinkFactories[scheme] = factory
+}
+
+// NewSink creates a Sink from a sink URL, e.g. file:///var/lib/kspec/reports.
+func NewSink(rawURL string) (Sink, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid report sink %q: %w", rawURL, err)
+	}
+	if u.Scheme == "" {
+		return nil, fmt.Errorf("invalid report sink %q: missing scheme (e.g. file://)", rawURL)
+	}
+
+	sinkFactoriesMu.RLock()
+	factory, ok := sinkFactories[u.Scheme]
+	schemes := make([]string, 0, len(sinkFactories))
+	for scheme := range sinkFactories {
+		schemes = append(schemes, scheme)
+	}
+	sinkFactoriesMu.RUnlock()
+
+	if !ok {
+		sort.Strings(schemes)
+		return nil, fmt.Errorf("report sink scheme %q is not available in this build (supported: %s)",
+			u.Scheme, strings.Join(schemes, ", "))
+	}
+
+	return factory(u)
+}
+
+// ReportObjectName returns the timestamped object name of a scan report,
+// e.g. "prod-baseline/20250115T103000Z.json".
+func ReportObjectName(result *scanner.ScanResult) string {
+	timestamp := time.Now().UTC()
+	if scanTime, err := time.Parse(time.RFC3339, result.Metadata.ScanTime); err == nil {
+		timestamp = scanTime.UTC()
+	}
+
+	specName := result.Metadata.Spec.Name
+	if specName == "" {
+		specName = "unnamed"
+	}
+
+	return path.Join(specName, timestamp.Format("20060102T150405Z")+".json")
+}
+
+// WriteReport writes the scan result as a timestamped JSON report to the sink
+// and returns the object name it was stored under.
+func WriteReport(ctx context.Context, sink Sink, result *scanner.ScanResult) (string, error) {
+	var buf bytes.Buffer
+	if err := NewJSONReporter(&buf).Report(result); err != nil {
+		return "", err
+	}
+
+	name := ReportObjectName(result)
+	if err := sink.Write(ctx, name, buf.Bytes()); err != nil {
+		return "", fmt.Errorf("failed to write report %s to sink: %w", name, err)
+	}
+	return name, nil
+}
+
+// fileSink writes reports below a local directory, e.g. a mounted volume.
+type fileSink struct {
+	dir string
+}
+
+// newFileSink creates a sink for file:// URLs.
+func newFileSink(u *url.URL) (Sink, error) {
+	dir := filepath.FromSlash(path.Join(u.Host, u.Path))
+	if dir == "" || dir == "." {
+		return nil, fmt.Errorf("invalid report sink %q: missing directory", u.String())
+	}
+	return &fileSink{dir: dir}, nil
+}
+
+// Write stores the report below the sink directory.
+func (s *fileSink) Write(ctx context.Context, name string, data []byte) error {
+	target := filepath.Join(s.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+	if err := os.WriteFile(target, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+	return nil
+}
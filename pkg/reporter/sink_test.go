@@ -0,0 +1,46 @@
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteReport_FileSink(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewSink("file://" + dir)
+	require.NoError(t, err)
+
+	result := &scanner.ScanResult{
+		Metadata: scanner.ScanMetadata{
+			ScanTime: "2025-01-15T10:30:00Z",
+			Spec:     scanner.SpecInfo{Name: "prod-baseline"},
+		},
+		Summary: scanner.ScanSummary{TotalChecks: 1, Passed: 1},
+	}
+
+	name, err := WriteReport(context.Background(), sink, result)
+	require.NoError(t, err)
+	assert.Equal(t, "prod-baseline/20250115T103000Z.json", name)
+
+	data, err := os.ReadFile(filepath.Join(dir, "prod-baseline", "20250115T103000Z.json"))
+	require.NoError(t, err)
+
+	var written scanner.ScanResult
+	require.NoError(t, json.Unmarshal(data, &written))
+	assert.Equal(t, 1, written.Summary.Passed)
+}
+
+func TestNewSink_UnsupportedScheme(t *testing.T) {
+	_, err := NewSink("ftp://example.com/reports")
+	assert.ErrorContains(t, err, `scheme "ftp" is not available`)
+
+	_, err = NewSink("reports")
+	assert.ErrorContains(t, err, "missing scheme")
+}
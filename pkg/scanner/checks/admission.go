@@ -23,14 +23,44 @@ func (c *AdmissionCheck) Name() string {
 	return "admission.controllers"
 }
 
+// Description explains what the check verifies and why.
+func (c *AdmissionCheck) Description() string {
+	return "Checks that required admission webhooks and policies are installed.\n\n" +
+		"Each admission.required entry needs at least minCount validating or mutating webhook configurations matching its name pattern, and admission.policies needs at least minCount Kyverno ClusterPolicies, including every required policy by name. Admission control blocks non-compliant workloads before they run, instead of reporting them afterwards."
+}
+
+// SpecFields returns the spec fields the check reads.
+func (c *AdmissionCheck) SpecFields() []string {
+	return []string{"admission.required", "admission.policies"}
+}
+
+// Severity returns the severity assigned to failures.
+func (c *AdmissionCheck) Severity() scanner.Severity {
+	return scanner.SeverityHigh
+}
+
+// Remediation returns an example fix for failures.
+func (c *AdmissionCheck) Remediation() string {
+	return `Review and fix admission controller violations:
+1. Install required admission controllers (e.g., Kyverno)
+   helm install kyverno kyverno/kyverno --namespace kyverno --create-namespace
+2. Ensure required ValidatingWebhookConfigurations exist
+3. Deploy required policies (ClusterPolicy resources)
+4. Verify minimum policy count is met
+
+Example: Create a Kyverno policy:
+kubectl apply -f https://raw.githubusercontent.com/kyverno/policies/main/pod-security/baseline/disallow-privileged-containers/disallow-privileged-containers.yaml`
+}
+
 // Run executes the admission controller check.
 func (c *AdmissionCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.CheckResult, error) {
 	// Skip if not specified
 	if clusterSpec.Spec.Admission == nil {
 		return &scanner.CheckResult{
-			Name:    c.Name(),
-			Status:  scanner.StatusSkip,
-			Message: "Admission controller requirements not specified in cluster spec",
+			Name:       c.Name(),
+			Status:     scanner.StatusSkip,
+			SkipReason: scanner.SkipReasonSpecSectionAbsent,
+			Message:    "Admission controller requirements not specified in cluster spec",
 		}, nil
 	}
 
@@ -72,20 +102,12 @@ func (c *AdmissionCheck) Run(ctx context.Context, client kubernetes.Interface, c
 		}
 
 		return &scanner.CheckResult{
-			Name:     c.Name(),
-			Status:   scanner.StatusFail,
-			Severity: severity,
-			Message:  fmt.Sprintf("Found %d admission controller violations", len(violations)),
-			Evidence: evidence,
-			Remediation: `Review and fix admission controller violations:
-1. Install required admission controllers (e.g., Kyverno)
-   helm install kyverno kyverno/kyverno --namespace kyverno --create-namespace
-2. Ensure required ValidatingWebhookConfigurations exist
-3. Deploy required policies (ClusterPolicy resources)
-4. Verify minimum policy count is met
-
-Example: Create a Kyverno policy:
-kubectl apply -f https://raw.githubusercontent.com/kyverno/policies/main/pod-security/baseline/disallow-privileged-containers/disallow-privileged-containers.yaml`,
+			Name:        c.Name(),
+			Status:      scanner.StatusFail,
+			Severity:    severity,
+			Message:     fmt.Sprintf("Found %d admission controller violations", len(violations)),
+			Evidence:    evidence,
+			Remediation: c.Remediation(),
 		}, nil
 	}
 
@@ -214,6 +214,7 @@ func TestAdmissionCheck_Skip(t *testing.T) {
 	result, err := check.Run(context.Background(), client, clusterSpec)
 	assert.NoError(t, err)
 	assert.Equal(t, scanner.StatusSkip, result.Status)
+	assert.Equal(t, scanner.SkipReasonSpecSectionAbsent, result.SkipReason)
 }
 
 func TestAdmissionCheck_Name(t *testing.T) {
@@ -0,0 +1,33 @@
+package checks
+
+import (
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"k8s.io/client-go/dynamic"
+)
+
+// All returns every check kspec runs, in report order. It is the single list
+// shared by kspec scan, the operator and drift detection, so a new check only
+// needs to be added here. The dynamic client is used by checks that read
+// resources outside the typed client, such as deprecated APIs and custom
+// checks; targetVersion overrides the Kubernetes version deprecated APIs are
+// evaluated against (empty uses the spec's kubernetes.maxVersion).
+func All(dynamicClient dynamic.Interface, targetVersion string) []scanner.Check {
+	return []scanner.Check{
+		&KubernetesVersionCheck{},
+		&PodSecurityStandardsCheck{},
+		&NetworkPolicyCheck{},
+		&WorkloadSecurityCheck{},
+		&ImageSignatureCheck{},
+		&ProbesCheck{},
+		&ResourceEfficiencyCheck{},
+		&TopologySpreadCheck{},
+		&PriorityClassCheck{},
+		&PodDensityCheck{},
+		&SecretExposureCheck{},
+		&RBACCheck{},
+		&AdmissionCheck{},
+		&ObservabilityCheck{},
+		&DeprecatedAPICheck{DynamicClient: dynamicClient, TargetVersion: targetVersion},
+		&CustomCELCheck{DynamicClient: dynamicClient},
+	}
+}
@@ -0,0 +1,160 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// zoneTopologyKey is the well-known node label identifying the availability zone
+const zoneTopologyKey = "topology.kubernetes.io/zone"
+
+// TopologySpreadCheck validates that multi-replica workloads spread across zones.
+type TopologySpreadCheck struct{}
+
+// Name returns the check name.
+func (c *TopologySpreadCheck) Name() string {
+	return "availability.topology-spread"
+}
+
+// Description explains what the check verifies and why.
+func (c *TopologySpreadCheck) Description() string {
+	return "Checks that multi-replica workloads spread their replicas across zones.\n\n" +
+		"Deployments and StatefulSets with more than one replica need topologySpreadConstraints or podAntiAffinity on topology.kubernetes.io/zone, so that losing a zone does not take down every replica."
+}
+
+// SpecFields returns the spec fields the check reads.
+func (c *TopologySpreadCheck) SpecFields() []string {
+	return []string{"availability.requireTopologySpread"}
+}
+
+// Severity returns the severity assigned to failures.
+func (c *TopologySpreadCheck) Severity() scanner.Severity {
+	return scanner.SeverityMedium
+}
+
+// Remediation returns an example fix for failures.
+func (c *TopologySpreadCheck) Remediation() string {
+	return `Spread multi-replica workloads across availability zones using either:
+1. topologySpreadConstraints with topologyKey: topology.kubernetes.io/zone
+2. podAntiAffinity with topologyKey: topology.kubernetes.io/zone
+
+Example:
+  topologySpreadConstraints:
+  - maxSkew: 1
+    topologyKey: topology.kubernetes.io/zone
+    whenUnsatisfiable: ScheduleAnyway
+    labelSelector:
+      matchLabels:
+        app: myapp`
+}
+
+// Run executes the topology spread check.
+func (c *TopologySpreadCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.CheckResult, error) {
+	// Skip if not specified
+	if clusterSpec.Spec.Availability == nil || !clusterSpec.Spec.Availability.RequireTopologySpread {
+		return &scanner.CheckResult{
+			Name:       c.Name(),
+			Status:     scanner.StatusSkip,
+			SkipReason: scanner.SkipReasonSpecSectionAbsent,
+			Message:    "Topology spread requirements not specified in cluster spec",
+		}, nil
+	}
+
+	deployments, err := scanner.ListDeployments(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	statefulSets, err := scanner.ListStatefulSets(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+
+	violatingWorkloads := []string{}
+	checked := 0
+
+	for _, deployment := range deployments.Items {
+		if isSystemNamespace(deployment.Namespace) || replicaCount(deployment.Spec.Replicas) <= 1 {
+			continue
+		}
+		checked++
+		if !spreadsAcrossZones(&deployment.Spec.Template.Spec) {
+			violatingWorkloads = append(violatingWorkloads, fmt.Sprintf("Deployment %s/%s", deployment.Namespace, deployment.Name))
+		}
+	}
+
+	for _, statefulSet := range statefulSets.Items {
+		if isSystemNamespace(statefulSet.Namespace) || replicaCount(statefulSet.Spec.Replicas) <= 1 {
+			continue
+		}
+		checked++
+		if !spreadsAcrossZones(&statefulSet.Spec.Template.Spec) {
+			violatingWorkloads = append(violatingWorkloads, fmt.Sprintf("StatefulSet %s/%s", statefulSet.Namespace, statefulSet.Name))
+		}
+	}
+
+	if len(violatingWorkloads) > 0 {
+		return &scanner.CheckResult{
+			Name:     c.Name(),
+			Status:   scanner.StatusFail,
+			Severity: scanner.SeverityMedium,
+			Message:  fmt.Sprintf("Found %d multi-replica workloads not spread across zones", len(violatingWorkloads)),
+			Evidence: map[string]interface{}{
+				"violating_workloads": violatingWorkloads,
+				"violation_count":     len(violatingWorkloads),
+				"checked_workloads":   checked,
+			},
+			Remediation: c.Remediation(),
+		}, nil
+	}
+
+	return &scanner.CheckResult{
+		Name:    c.Name(),
+		Status:  scanner.StatusPass,
+		Message: fmt.Sprintf("All %d multi-replica workloads spread across zones", checked),
+		Evidence: map[string]interface{}{
+			"checked_workloads": checked,
+		},
+	}, nil
+}
+
+// replicaCount returns the desired replica count, which defaults to 1 when unset
+func replicaCount(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// spreadsAcrossZones reports whether a pod template spreads its replicas across zones
+// through topology spread constraints or pod anti-affinity.
+func spreadsAcrossZones(podSpec *corev1.PodSpec) bool {
+	for _, constraint := range podSpec.TopologySpreadConstraints {
+		if constraint.TopologyKey == zoneTopologyKey {
+			return true
+		}
+	}
+
+	if podSpec.Affinity == nil || podSpec.Affinity.PodAntiAffinity == nil {
+		return false
+	}
+
+	antiAffinity := podSpec.Affinity.PodAntiAffinity
+	for _, term := range antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+		if term.TopologyKey == zoneTopologyKey {
+			return true
+		}
+	}
+	for _, term := range antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		if term.PodAffinityTerm.TopologyKey == zoneTopologyKey {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,128 @@
+package checks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestDeployment(name string, replicas int32, podSpec corev1.PodSpec) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{
+				Spec: podSpec,
+			},
+		},
+	}
+}
+
+func availabilitySpec() *spec.ClusterSpecification {
+	return &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Availability: &spec.AvailabilitySpec{
+				RequireTopologySpread: true,
+			},
+		},
+	}
+}
+
+func TestTopologySpreadCheck_Pass(t *testing.T) {
+	spread := newTestDeployment("spread", 3, corev1.PodSpec{
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+			{
+				MaxSkew:           1,
+				TopologyKey:       "topology.kubernetes.io/zone",
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+			},
+		},
+	})
+	antiAffinity := newTestDeployment("anti-affinity", 2, corev1.PodSpec{
+		Affinity: &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+					{
+						Weight: 100,
+						PodAffinityTerm: corev1.PodAffinityTerm{
+							TopologyKey: "topology.kubernetes.io/zone",
+						},
+					},
+				},
+			},
+		},
+	})
+	// Single replica workloads are not required to spread
+	single := newTestDeployment("single", 1, corev1.PodSpec{})
+
+	client := fake.NewSimpleClientset(spread, antiAffinity, single)
+	check := &TopologySpreadCheck{}
+
+	result, err := check.Run(context.Background(), client, availabilitySpec())
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+	assert.Equal(t, "availability.topology-spread", result.Name)
+	assert.Equal(t, 2, result.Evidence["checked_workloads"])
+}
+
+func TestTopologySpreadCheck_Fail(t *testing.T) {
+	unspread := newTestDeployment("unspread", 3, corev1.PodSpec{})
+	// Spreading across hosts does not protect against zone failures
+	hostSpread := newTestDeployment("host-spread", 2, corev1.PodSpec{
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+			{
+				MaxSkew:           1,
+				TopologyKey:       "kubernetes.io/hostname",
+				WhenUnsatisfiable: corev1.DoNotSchedule,
+			},
+		},
+	})
+
+	replicas := int32(3)
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "default",
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+		},
+	}
+
+	client := fake.NewSimpleClientset(unspread, hostSpread, statefulSet)
+	check := &TopologySpreadCheck{}
+
+	result, err := check.Run(context.Background(), client, availabilitySpec())
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	assert.Equal(t, scanner.SeverityMedium, result.Severity)
+	assert.Equal(t, 3, result.Evidence["violation_count"])
+	assert.ElementsMatch(t, []string{
+		"Deployment default/unspread",
+		"Deployment default/host-spread",
+		"StatefulSet default/db",
+	}, result.Evidence["violating_workloads"])
+}
+
+func TestTopologySpreadCheck_Skip(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	check := &TopologySpreadCheck{}
+
+	result, err := check.Run(context.Background(), client, &spec.ClusterSpecification{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusSkip, result.Status)
+	assert.Equal(t, scanner.SkipReasonSpecSectionAbsent, result.SkipReason)
+}
@@ -0,0 +1,167 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PodDensityCheck validates that nodes do not run more pods than the spec allows.
+type PodDensityCheck struct{}
+
+// Name returns the check name.
+func (c *PodDensityCheck) Name() string {
+	return "capacity.pod-density"
+}
+
+// Description explains what the check verifies and why.
+func (c *PodDensityCheck) Description() string {
+	return "Checks that no node runs more pods than the spec allows.\n\n" +
+		"Densely packed nodes make noisy neighbours and large blast radii more likely, and can exhaust per-node IP addresses. Completed and failed pods are not counted."
+}
+
+// SpecFields returns the spec fields the check reads.
+func (c *PodDensityCheck) SpecFields() []string {
+	return []string{"capacity.maxPodsPerNode"}
+}
+
+// Severity returns the severity assigned to failures.
+func (c *PodDensityCheck) Severity() scanner.Severity {
+	return scanner.SeverityMedium
+}
+
+// Remediation returns an example fix for failures.
+func (c *PodDensityCheck) Remediation() string {
+	return c.Playbook()
+}
+
+// Run executes the pod density check.
+func (c *PodDensityCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.CheckResult, error) {
+	// Skip if not specified
+	if clusterSpec.Spec.Capacity == nil || clusterSpec.Spec.Capacity.MaxPodsPerNode <= 0 {
+		return &scanner.CheckResult{
+			Name:       c.Name(),
+			Status:     scanner.StatusSkip,
+			SkipReason: scanner.SkipReasonSpecSectionAbsent,
+			Message:    "Pod density requirements not specified in cluster spec",
+		}, nil
+	}
+
+	maxPods := clusterSpec.Spec.Capacity.MaxPodsPerNode
+
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	pods, err := scanner.ListPods(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	podsPerNode := countPodsPerNode(pods.Items)
+
+	violatingNodes := []string{}
+	nodePodCounts := map[string]int{}
+	for _, node := range nodes.Items {
+		count := podsPerNode[node.Name]
+		nodePodCounts[node.Name] = count
+		if count > maxPods {
+			violatingNodes = append(violatingNodes, node.Name)
+		}
+	}
+	sort.Strings(violatingNodes)
+
+	// Nodes configured to admit more pods than the cap can exceed it at any time
+	permissiveNodes := []string{}
+	for _, node := range nodes.Items {
+		if allocatable, ok := node.Status.Allocatable[corev1.ResourcePods]; ok && allocatable.Value() > int64(maxPods) {
+			permissiveNodes = append(permissiveNodes, node.Name)
+		}
+	}
+	sort.Strings(permissiveNodes)
+
+	if len(violatingNodes) > 0 {
+		return &scanner.CheckResult{
+			Name:     c.Name(),
+			Status:   scanner.StatusFail,
+			Severity: scanner.SeverityMedium,
+			Message:  fmt.Sprintf("Found %d nodes running more than %d pods", len(violatingNodes), maxPods),
+			Evidence: map[string]interface{}{
+				"violating_nodes":  violatingNodes,
+				"violation_count":  len(violatingNodes),
+				"max_pods":         maxPods,
+				"node_pod_counts":  nodePodCounts,
+				"permissive_nodes": permissiveNodes,
+			},
+			Remediation: fmt.Sprintf(`Reduce pod density on the violating nodes:
+1. Lower the kubelet maxPods setting to %d (e.g. via the node pool configuration)
+2. Add nodes so workloads can be spread more thinly
+3. Use topologySpreadConstraints to spread pods across nodes`, maxPods),
+		}, nil
+	}
+
+	return &scanner.CheckResult{
+		Name:    c.Name(),
+		Status:  scanner.StatusPass,
+		Message: fmt.Sprintf("All %d nodes run at most %d pods", len(nodes.Items), maxPods),
+		Evidence: map[string]interface{}{
+			"checked_nodes":    len(nodes.Items),
+			"max_pods":         maxPods,
+			"node_pod_counts":  nodePodCounts,
+			"permissive_nodes": permissiveNodes,
+		},
+	}, nil
+}
+
+// Playbook returns a step-by-step remediation playbook for failures.
+func (c *PodDensityCheck) Playbook() string {
+	return `Step-by-step: reduce pod density
+
+1. Compare the running pods per node with the limit (see node_pod_counts):
+   kubectl get pods -A --field-selector spec.nodeName=<node>,status.phase=Running
+
+2. Cap the kubelet so nodes cannot admit more pods than allowed, e.g. in the
+   kubelet configuration:
+
+   apiVersion: kubelet.config.k8s.io/v1beta1
+   kind: KubeletConfiguration
+   maxPods: <maxPodsPerNode>
+
+3. Add nodes (or enable the cluster autoscaler) so displaced pods have room.
+
+4. Spread replicas across nodes:
+
+   spec:
+     template:
+       spec:
+         topologySpreadConstraints:
+         - maxSkew: 1
+           topologyKey: kubernetes.io/hostname
+           whenUnsatisfiable: ScheduleAnyway
+           labelSelector:
+             matchLabels:
+               app: <app>
+
+5. Drain over-dense nodes one at a time to rebalance, then re-run kspec scan:
+   kubectl drain <node> --ignore-daemonsets --delete-emptydir-data`
+}
+
+// countPodsPerNode counts scheduled pods that still occupy a node, keyed by node name.
+// Completed and failed pods no longer count towards a node's pod capacity.
+func countPodsPerNode(pods []corev1.Pod) map[string]int {
+	counts := map[string]int{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		counts[pod.Spec.NodeName]++
+	}
+	return counts
+}
@@ -0,0 +1,104 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestNode(name string, allocatablePods string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourcePods: resource.MustParse(allocatablePods),
+			},
+		},
+	}
+}
+
+func newTestPodsOnNode(node string, count int, phase corev1.PodPhase) []runtime.Object {
+	pods := []runtime.Object{}
+	for i := 0; i < count; i++ {
+		pods = append(pods, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-pod-%d-%s", node, i, phase),
+				Namespace: "default",
+			},
+			Spec:   corev1.PodSpec{NodeName: node},
+			Status: corev1.PodStatus{Phase: phase},
+		})
+	}
+	return pods
+}
+
+func capacitySpec(maxPods int) *spec.ClusterSpecification {
+	return &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Capacity: &spec.CapacitySpec{
+				MaxPodsPerNode: maxPods,
+			},
+		},
+	}
+}
+
+func TestPodDensityCheck_Pass(t *testing.T) {
+	objects := []runtime.Object{newTestNode("node-1", "3")}
+	objects = append(objects, newTestPodsOnNode("node-1", 3, corev1.PodRunning)...)
+	// Completed pods do not count towards density
+	objects = append(objects, newTestPodsOnNode("node-1", 2, corev1.PodSucceeded)...)
+
+	client := fake.NewSimpleClientset(objects...)
+	check := &PodDensityCheck{}
+
+	result, err := check.Run(context.Background(), client, capacitySpec(3))
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+	assert.Equal(t, "capacity.pod-density", result.Name)
+	assert.Equal(t, 1, result.Evidence["checked_nodes"])
+	assert.Empty(t, result.Evidence["permissive_nodes"])
+}
+
+func TestPodDensityCheck_Fail(t *testing.T) {
+	objects := []runtime.Object{
+		newTestNode("node-1", "110"),
+		newTestNode("node-2", "110"),
+		newTestNode("node-3", "3"),
+	}
+	objects = append(objects, newTestPodsOnNode("node-1", 5, corev1.PodRunning)...)
+	objects = append(objects, newTestPodsOnNode("node-2", 2, corev1.PodRunning)...)
+	objects = append(objects, newTestPodsOnNode("node-3", 4, corev1.PodPending)...)
+
+	client := fake.NewSimpleClientset(objects...)
+	check := &PodDensityCheck{}
+
+	result, err := check.Run(context.Background(), client, capacitySpec(3))
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	assert.Equal(t, scanner.SeverityMedium, result.Severity)
+	assert.Equal(t, []string{"node-1", "node-3"}, result.Evidence["violating_nodes"])
+	assert.Equal(t, 2, result.Evidence["violation_count"])
+	assert.Equal(t, []string{"node-1", "node-2"}, result.Evidence["permissive_nodes"])
+}
+
+func TestPodDensityCheck_Skip(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	check := &PodDensityCheck{}
+
+	result, err := check.Run(context.Background(), client, &spec.ClusterSpecification{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusSkip, result.Status)
+	assert.Equal(t, scanner.SkipReasonSpecSectionAbsent, result.SkipReason)
+}
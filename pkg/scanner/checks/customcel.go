@@ -0,0 +1,264 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/google/cel-go/cel"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// celCostLimit bounds the cost of evaluating a custom check expression against
+// one resource, so a runaway expression cannot stall the scan.
+const celCostLimit = 1000000
+
+// severityRanks orders severities so the highest among failing custom checks
+// is reported.
+var severityRanks = map[scanner.Severity]int{
+	scanner.SeverityLow:      1,
+	scanner.SeverityMedium:   2,
+	scanner.SeverityHigh:     3,
+	scanner.SeverityCritical: 4,
+}
+
+// CustomCELCheck evaluates the spec's custom checks: CEL expressions that every
+// resource of a kind outside the system namespaces must satisfy. It lets
+// organisations add their own rules without writing Go.
+type CustomCELCheck struct {
+	// DynamicClient lists the target resources of the custom checks
+	DynamicClient dynamic.Interface
+}
+
+// Name returns the check name.
+func (c *CustomCELCheck) Name() string {
+	return "custom.cel-checks"
+}
+
+// Description explains what the check verifies and why.
+func (c *CustomCELCheck) Description() string {
+	return "Checks that resources satisfy the CEL expressions of the spec's custom checks.\n\n" +
+		"Each customChecks entry names a resource apiVersion and kind and a CEL expression evaluated with the resource bound to object; resources for which it is not true violate the check, with the entry's message and severity. Resources the expression cannot be evaluated against count as violations. Expressions that do not compile, and kinds the cluster does not serve, make the check error rather than pass."
+}
+
+// SpecFields returns the spec fields the check reads.
+func (c *CustomCELCheck) SpecFields() []string {
+	return []string{"customChecks"}
+}
+
+// Severity returns the severity assigned to failures.
+func (c *CustomCELCheck) Severity() scanner.Severity {
+	return scanner.SeverityMedium
+}
+
+// Remediation returns an example fix for failures.
+func (c *CustomCELCheck) Remediation() string {
+	return `Fix the resources listed in the evidence so the custom check expressions hold, or
+correct the custom check in spec.customChecks if the rule is wrong.
+
+Test an expression against a resource with:
+kubectl get <kind> <name> -o json, then evaluate the expression with object bound to it`
+}
+
+// customCheckRun is a compiled custom check.
+type customCheckRun struct {
+	spec     spec.CELCheckSpec
+	program  cel.Program
+	severity scanner.Severity
+}
+
+// Run executes the custom CEL checks.
+func (c *CustomCELCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.CheckResult, error) {
+	customChecks := clusterSpec.Spec.CustomChecks
+
+	// Skip if no custom checks are defined
+	if len(customChecks) == 0 {
+		return &scanner.CheckResult{
+			Name:       c.Name(),
+			Status:     scanner.StatusSkip,
+			SkipReason: scanner.SkipReasonSpecSectionAbsent,
+			Message:    "No custom checks defined in cluster spec",
+		}, nil
+	}
+	if c.DynamicClient == nil {
+		return &scanner.CheckResult{
+			Name:       c.Name(),
+			Status:     scanner.StatusSkip,
+			SkipReason: scanner.SkipReasonFeatureUnavailable,
+			Message:    "Dynamic client not available for custom checks",
+		}, nil
+	}
+
+	env, err := cel.NewEnv(cel.Variable("object", cel.DynType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	checkErrors := []string{}
+	violations := []string{}
+	failedChecks := map[string]bool{}
+	severity := scanner.Severity("")
+	evaluated := 0
+
+	for _, customCheck := range customChecks {
+		run, err := compileCustomCheck(env, customCheck)
+		if err != nil {
+			checkErrors = append(checkErrors, fmt.Sprintf("custom check %q: %v", customCheck.Name, err))
+			continue
+		}
+
+		resources, err := c.listTargets(ctx, client, customCheck)
+		if err != nil {
+			checkErrors = append(checkErrors, fmt.Sprintf("custom check %q: %v", customCheck.Name, err))
+			continue
+		}
+
+		for i := range resources {
+			resource := &resources[i]
+			if isSystemNamespace(resource.GetNamespace()) {
+				continue
+			}
+			evaluated++
+
+			problem := run.evaluate(ctx, resource)
+			if problem == "" {
+				continue
+			}
+			violations = append(violations, fmt.Sprintf("%s: %s: %s", customCheck.Name, resourceKey(customCheck.Kind, resource), problem))
+			failedChecks[customCheck.Name] = true
+			if severityRanks[run.severity] > severityRanks[severity] {
+				severity = run.severity
+			}
+		}
+	}
+
+	// A custom check that could not run must not read as a passing one
+	if len(checkErrors) > 0 {
+		evidence := map[string]interface{}{"check_errors": checkErrors}
+		if len(violations) > 0 {
+			evidence["violations"] = violations
+		}
+		return &scanner.CheckResult{
+			Name:        c.Name(),
+			Status:      scanner.StatusError,
+			Message:     fmt.Sprintf("Could not evaluate %d custom checks", len(checkErrors)),
+			Evidence:    evidence,
+			Remediation: "Fix the custom checks named in the evidence in spec.customChecks, then re-run the scan",
+		}, nil
+	}
+
+	if len(violations) > 0 {
+		return &scanner.CheckResult{
+			Name:     c.Name(),
+			Status:   scanner.StatusFail,
+			Severity: severity,
+			Message:  fmt.Sprintf("Found %d resources violating %d custom checks", len(violations), len(failedChecks)),
+			Evidence: map[string]interface{}{
+				"violations":      violations,
+				"violation_count": len(violations),
+				"failed_checks":   sortedKeys(failedChecks),
+			},
+			Remediation: c.Remediation(),
+		}, nil
+	}
+
+	return &scanner.CheckResult{
+		Name:    c.Name(),
+		Status:  scanner.StatusPass,
+		Message: fmt.Sprintf("All %d resources satisfy the %d custom checks", evaluated, len(customChecks)),
+		Evidence: map[string]interface{}{
+			"custom_checks":       len(customChecks),
+			"resources_evaluated": evaluated,
+		},
+	}, nil
+}
+
+// compileCustomCheck compiles the expression of a custom check, which must
+// return a bool.
+func compileCustomCheck(env *cel.Env, customCheck spec.CELCheckSpec) (*customCheckRun, error) {
+	ast, issues := env.Compile(customCheck.Expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid expression: %w", issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("expression must return a bool, not %s", ast.OutputType())
+	}
+
+	program, err := env.Program(ast, cel.CostLimit(celCostLimit))
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression: %w", err)
+	}
+
+	severity := scanner.Severity(customCheck.Severity)
+	if severity == "" {
+		severity = scanner.SeverityMedium
+	}
+	return &customCheckRun{spec: customCheck, program: program, severity: severity}, nil
+}
+
+// evaluate evaluates the custom check against a resource, returning why it is
+// not compliant, or "" when it is.
+func (r *customCheckRun) evaluate(ctx context.Context, resource *unstructured.Unstructured) string {
+	message := r.spec.Message
+	if message == "" {
+		message = fmt.Sprintf("expression %q is not true", r.spec.Expression)
+	}
+
+	out, _, err := r.program.ContextEval(ctx, map[string]interface{}{"object": resource.Object})
+	if err != nil {
+		return fmt.Sprintf("%s (evaluation failed: %v)", message, err)
+	}
+	compliant, ok := out.Value().(bool)
+	if !ok {
+		return fmt.Sprintf("%s (expression returned %v, not a bool)", message, out.Value())
+	}
+	if compliant {
+		return ""
+	}
+	return message
+}
+
+// listTargets lists the resources of the custom check's apiVersion and kind,
+// finding the resource name through discovery.
+func (c *CustomCELCheck) listTargets(ctx context.Context, client kubernetes.Interface, customCheck spec.CELCheckSpec) ([]unstructured.Unstructured, error) {
+	gv, err := schema.ParseGroupVersion(customCheck.APIVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apiVersion %q: %w", customCheck.APIVersion, err)
+	}
+
+	apiResources, err := client.Discovery().ServerResourcesForGroupVersion(customCheck.APIVersion)
+	if err != nil {
+		return nil, fmt.Errorf("apiVersion %s is not served by the cluster: %w", customCheck.APIVersion, err)
+	}
+	var resource string
+	for _, apiResource := range apiResources.APIResources {
+		if apiResource.Kind == customCheck.Kind && !strings.Contains(apiResource.Name, "/") {
+			resource = apiResource.Name
+			break
+		}
+	}
+	if resource == "" {
+		return nil, fmt.Errorf("kind %s is not served by %s", customCheck.Kind, customCheck.APIVersion)
+	}
+
+	list, err := c.DynamicClient.Resource(gv.WithResource(resource)).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", resource, err)
+	}
+
+	items := list.Items
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].GetNamespace() != items[j].GetNamespace() {
+			return items[i].GetNamespace() < items[j].GetNamespace()
+		}
+		return items[i].GetName() < items[j].GetName()
+	})
+	return items, nil
+}
@@ -0,0 +1,184 @@
+package checks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// newCustomCELClients creates a clientset whose discovery serves Deployments
+// and Services, and a dynamic client listing the given objects.
+func newCustomCELClients(objects ...runtime.Object) (*fake.Clientset, *dynamicfake.FakeDynamicClient) {
+	client := fake.NewSimpleClientset()
+	client.Fake.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployments/scale", Kind: "Scale", Namespaced: true},
+				{Name: "deployments", Kind: "Deployment", Namespaced: true},
+			},
+		},
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "services", Kind: "Service", Namespaced: true},
+			},
+		},
+	}
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "apps", Version: "v1", Resource: "deployments"}: "DeploymentList",
+		{Version: "v1", Resource: "services"}:                   "ServiceList",
+	}, objects...)
+	return client, dynamicClient
+}
+
+func newCustomObject(apiVersion, kind, namespace, name string, labels map[string]string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	return obj
+}
+
+func customCELSpec(customChecks ...spec.CELCheckSpec) *spec.ClusterSpecification {
+	return &spec.ClusterSpecification{Spec: spec.SpecFields{CustomChecks: customChecks}}
+}
+
+var (
+	teamLabelCheck = spec.CELCheckSpec{
+		Name:       "deployments-have-team",
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Expression: `has(object.metadata.labels) && "team" in object.metadata.labels`,
+		Message:    "Deployment has no team label",
+		Severity:   "high",
+	}
+	noNodePortCheck = spec.CELCheckSpec{
+		Name:       "no-nodeport-services",
+		APIVersion: "v1",
+		Kind:       "Service",
+		Expression: `!has(object.spec.type) || object.spec.type != "NodePort"`,
+		Message:    "Service is exposed through a NodePort",
+	}
+)
+
+func TestCustomCELCheck_Pass(t *testing.T) {
+	client, dynamicClient := newCustomCELClients(
+		newCustomObject("apps/v1", "Deployment", "default", "web", map[string]string{"team": "payments"}, map[string]interface{}{"replicas": int64(2)}),
+		newCustomObject("v1", "Service", "default", "web", nil, map[string]interface{}{"type": "ClusterIP"}),
+	)
+	check := &CustomCELCheck{DynamicClient: dynamicClient}
+
+	result, err := check.Run(context.Background(), client, customCELSpec(teamLabelCheck, noNodePortCheck))
+
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+	assert.Equal(t, "custom.cel-checks", result.Name)
+	assert.Equal(t, 2, result.Evidence["resources_evaluated"])
+}
+
+func TestCustomCELCheck_Fail(t *testing.T) {
+	client, dynamicClient := newCustomCELClients(
+		newCustomObject("apps/v1", "Deployment", "default", "web", map[string]string{"team": "payments"}, nil),
+		newCustomObject("apps/v1", "Deployment", "default", "worker", map[string]string{"app": "worker"}, nil),
+		newCustomObject("apps/v1", "Deployment", "shop", "cart", nil, nil),
+		// System namespaces are not subject to custom checks
+		newCustomObject("apps/v1", "Deployment", "kube-system", "coredns", nil, nil),
+		newCustomObject("v1", "Service", "shop", "cart", nil, map[string]interface{}{"type": "NodePort"}),
+		newCustomObject("v1", "Service", "shop", "api", nil, map[string]interface{}{}),
+	)
+	check := &CustomCELCheck{DynamicClient: dynamicClient}
+
+	result, err := check.Run(context.Background(), client, customCELSpec(teamLabelCheck, noNodePortCheck))
+
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	// The most severe failing custom check sets the result severity
+	assert.Equal(t, scanner.SeverityHigh, result.Severity)
+	assert.Equal(t, []string{
+		"deployments-have-team: Deployment default/worker: Deployment has no team label",
+		"deployments-have-team: Deployment shop/cart: Deployment has no team label",
+		"no-nodeport-services: Service shop/cart: Service is exposed through a NodePort",
+	}, result.Evidence["violations"])
+	assert.Equal(t, 3, result.Evidence["violation_count"])
+	assert.Equal(t, []string{"deployments-have-team", "no-nodeport-services"}, result.Evidence["failed_checks"])
+}
+
+func TestCustomCELCheck_CompileError(t *testing.T) {
+	client, dynamicClient := newCustomCELClients(
+		newCustomObject("v1", "Service", "shop", "cart", nil, map[string]interface{}{"type": "NodePort"}),
+	)
+	check := &CustomCELCheck{DynamicClient: dynamicClient}
+
+	broken := spec.CELCheckSpec{
+		Name:       "broken-rule",
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Expression: `object.spec.replicas >=`,
+	}
+	notBool := spec.CELCheckSpec{
+		Name:       "replica-count",
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Expression: `size(object.metadata.name)`,
+	}
+
+	result, err := check.Run(context.Background(), client, customCELSpec(broken, notBool, noNodePortCheck))
+
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusError, result.Status)
+	checkErrors, ok := result.Evidence["check_errors"].([]string)
+	require.True(t, ok)
+	require.Len(t, checkErrors, 2)
+	assert.Contains(t, checkErrors[0], `custom check "broken-rule": invalid expression`)
+	assert.Contains(t, checkErrors[1], `custom check "replica-count": expression must return a bool`)
+	// Checks that did compile still report their violations
+	assert.Equal(t, []string{
+		"no-nodeport-services: Service shop/cart: Service is exposed through a NodePort",
+	}, result.Evidence["violations"])
+}
+
+func TestCustomCELCheck_UnknownKind(t *testing.T) {
+	client, dynamicClient := newCustomCELClients()
+	check := &CustomCELCheck{DynamicClient: dynamicClient}
+
+	result, err := check.Run(context.Background(), client, customCELSpec(
+		spec.CELCheckSpec{Name: "widgets", APIVersion: "apps/v1", Kind: "Widget", Expression: "true"},
+		spec.CELCheckSpec{Name: "gadgets", APIVersion: "example.com/v1", Kind: "Gadget", Expression: "true"},
+	))
+
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusError, result.Status)
+	checkErrors, ok := result.Evidence["check_errors"].([]string)
+	require.True(t, ok)
+	require.Len(t, checkErrors, 2)
+	assert.Contains(t, checkErrors[0], `custom check "widgets": kind Widget is not served by apps/v1`)
+	assert.Contains(t, checkErrors[1], `custom check "gadgets": apiVersion example.com/v1 is not served by the cluster`)
+}
+
+func TestCustomCELCheck_Skip(t *testing.T) {
+	client, dynamicClient := newCustomCELClients()
+
+	result, err := (&CustomCELCheck{DynamicClient: dynamicClient}).Run(context.Background(), client, customCELSpec())
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusSkip, result.Status)
+	assert.Equal(t, scanner.SkipReasonSpecSectionAbsent, result.SkipReason)
+
+	result, err = (&CustomCELCheck{}).Run(context.Background(), client, customCELSpec(teamLabelCheck))
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusSkip, result.Status)
+	assert.Equal(t, scanner.SkipReasonFeatureUnavailable, result.SkipReason)
+}
@@ -0,0 +1,351 @@
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// lastAppliedConfigAnnotation records the manifest last applied with kubectl, including
+// the apiVersion it was written against
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// deprecatedAPI describes a persisted resource API that is deprecated and later removed.
+type deprecatedAPI struct {
+	Kind         string
+	Resource     string
+	GroupVersion string
+	// Replacement is the API to migrate to, empty if the resource was removed entirely
+	Replacement  string
+	DeprecatedIn string
+	RemovedIn    string
+}
+
+// deprecatedAPIs is the embedded table of deprecated APIs, based on the upstream
+// Kubernetes deprecated API migration guide.
+var deprecatedAPIs = []deprecatedAPI{
+	{Kind: "Deployment", Resource: "deployments", GroupVersion: "extensions/v1beta1", Replacement: "apps/v1", DeprecatedIn: "1.9", RemovedIn: "1.16"},
+	{Kind: "Deployment", Resource: "deployments", GroupVersion: "apps/v1beta1", Replacement: "apps/v1", DeprecatedIn: "1.9", RemovedIn: "1.16"},
+	{Kind: "Deployment", Resource: "deployments", GroupVersion: "apps/v1beta2", Replacement: "apps/v1", DeprecatedIn: "1.9", RemovedIn: "1.16"},
+	{Kind: "DaemonSet", Resource: "daemonsets", GroupVersion: "extensions/v1beta1", Replacement: "apps/v1", DeprecatedIn: "1.9", RemovedIn: "1.16"},
+	{Kind: "DaemonSet", Resource: "daemonsets", GroupVersion: "apps/v1beta2", Replacement: "apps/v1", DeprecatedIn: "1.9", RemovedIn: "1.16"},
+	{Kind: "StatefulSet", Resource: "statefulsets", GroupVersion: "apps/v1beta1", Replacement: "apps/v1", DeprecatedIn: "1.9", RemovedIn: "1.16"},
+	{Kind: "StatefulSet", Resource: "statefulsets", GroupVersion: "apps/v1beta2", Replacement: "apps/v1", DeprecatedIn: "1.9", RemovedIn: "1.16"},
+	{Kind: "ReplicaSet", Resource: "replicasets", GroupVersion: "extensions/v1beta1", Replacement: "apps/v1", DeprecatedIn: "1.9", RemovedIn: "1.16"},
+	{Kind: "NetworkPolicy", Resource: "networkpolicies", GroupVersion: "extensions/v1beta1", Replacement: "networking.k8s.io/v1", DeprecatedIn: "1.9", RemovedIn: "1.16"},
+	{Kind: "Ingress", Resource: "ingresses", GroupVersion: "extensions/v1beta1", Replacement: "networking.k8s.io/v1", DeprecatedIn: "1.14", RemovedIn: "1.22"},
+	{Kind: "Ingress", Resource: "ingresses", GroupVersion: "networking.k8s.io/v1beta1", Replacement: "networking.k8s.io/v1", DeprecatedIn: "1.19", RemovedIn: "1.22"},
+	{Kind: "IngressClass", Resource: "ingressclasses", GroupVersion: "networking.k8s.io/v1beta1", Replacement: "networking.k8s.io/v1", DeprecatedIn: "1.19", RemovedIn: "1.22"},
+	{Kind: "CustomResourceDefinition", Resource: "customresourcedefinitions", GroupVersion: "apiextensions.k8s.io/v1beta1", Replacement: "apiextensions.k8s.io/v1", DeprecatedIn: "1.16", RemovedIn: "1.22"},
+	{Kind: "ValidatingWebhookConfiguration", Resource: "validatingwebhookconfigurations", GroupVersion: "admissionregistration.k8s.io/v1beta1", Replacement: "admissionregistration.k8s.io/v1", DeprecatedIn: "1.16", RemovedIn: "1.22"},
+	{Kind: "MutatingWebhookConfiguration", Resource: "mutatingwebhookconfigurations", GroupVersion: "admissionregistration.k8s.io/v1beta1", Replacement: "admissionregistration.k8s.io/v1", DeprecatedIn: "1.16", RemovedIn: "1.22"},
+	{Kind: "ClusterRole", Resource: "clusterroles", GroupVersion: "rbac.authorization.k8s.io/v1beta1", Replacement: "rbac.authorization.k8s.io/v1", DeprecatedIn: "1.17", RemovedIn: "1.22"},
+	{Kind: "ClusterRoleBinding", Resource: "clusterrolebindings", GroupVersion: "rbac.authorization.k8s.io/v1beta1", Replacement: "rbac.authorization.k8s.io/v1", DeprecatedIn: "1.17", RemovedIn: "1.22"},
+	{Kind: "Role", Resource: "roles", GroupVersion: "rbac.authorization.k8s.io/v1beta1", Replacement: "rbac.authorization.k8s.io/v1", DeprecatedIn: "1.17", RemovedIn: "1.22"},
+	{Kind: "RoleBinding", Resource: "rolebindings", GroupVersion: "rbac.authorization.k8s.io/v1beta1", Replacement: "rbac.authorization.k8s.io/v1", DeprecatedIn: "1.17", RemovedIn: "1.22"},
+	{Kind: "PriorityClass", Resource: "priorityclasses", GroupVersion: "scheduling.k8s.io/v1beta1", Replacement: "scheduling.k8s.io/v1", DeprecatedIn: "1.14", RemovedIn: "1.22"},
+	{Kind: "StorageClass", Resource: "storageclasses", GroupVersion: "storage.k8s.io/v1beta1", Replacement: "storage.k8s.io/v1", DeprecatedIn: "1.19", RemovedIn: "1.22"},
+	{Kind: "CronJob", Resource: "cronjobs", GroupVersion: "batch/v1beta1", Replacement: "batch/v1", DeprecatedIn: "1.21", RemovedIn: "1.25"},
+	{Kind: "PodDisruptionBudget", Resource: "poddisruptionbudgets", GroupVersion: "policy/v1beta1", Replacement: "policy/v1", DeprecatedIn: "1.21", RemovedIn: "1.25"},
+	{Kind: "PodSecurityPolicy", Resource: "podsecuritypolicies", GroupVersion: "policy/v1beta1", DeprecatedIn: "1.21", RemovedIn: "1.25"},
+	{Kind: "EndpointSlice", Resource: "endpointslices", GroupVersion: "discovery.k8s.io/v1beta1", Replacement: "discovery.k8s.io/v1", DeprecatedIn: "1.21", RemovedIn: "1.25"},
+	{Kind: "RuntimeClass", Resource: "runtimeclasses", GroupVersion: "node.k8s.io/v1beta1", Replacement: "node.k8s.io/v1", DeprecatedIn: "1.20", RemovedIn: "1.25"},
+	{Kind: "HorizontalPodAutoscaler", Resource: "horizontalpodautoscalers", GroupVersion: "autoscaling/v2beta1", Replacement: "autoscaling/v2", DeprecatedIn: "1.23", RemovedIn: "1.25"},
+	{Kind: "HorizontalPodAutoscaler", Resource: "horizontalpodautoscalers", GroupVersion: "autoscaling/v2beta2", Replacement: "autoscaling/v2", DeprecatedIn: "1.23", RemovedIn: "1.26"},
+	{Kind: "FlowSchema", Resource: "flowschemas", GroupVersion: "flowcontrol.apiserver.k8s.io/v1beta1", Replacement: "flowcontrol.apiserver.k8s.io/v1", DeprecatedIn: "1.23", RemovedIn: "1.26"},
+	{Kind: "PriorityLevelConfiguration", Resource: "prioritylevelconfigurations", GroupVersion: "flowcontrol.apiserver.k8s.io/v1beta1", Replacement: "flowcontrol.apiserver.k8s.io/v1", DeprecatedIn: "1.23", RemovedIn: "1.26"},
+	{Kind: "CSIStorageCapacity", Resource: "csistoragecapacities", GroupVersion: "storage.k8s.io/v1beta1", Replacement: "storage.k8s.io/v1", DeprecatedIn: "1.24", RemovedIn: "1.27"},
+	{Kind: "FlowSchema", Resource: "flowschemas", GroupVersion: "flowcontrol.apiserver.k8s.io/v1beta2", Replacement: "flowcontrol.apiserver.k8s.io/v1", DeprecatedIn: "1.26", RemovedIn: "1.29"},
+	{Kind: "PriorityLevelConfiguration", Resource: "prioritylevelconfigurations", GroupVersion: "flowcontrol.apiserver.k8s.io/v1beta2", Replacement: "flowcontrol.apiserver.k8s.io/v1", DeprecatedIn: "1.26", RemovedIn: "1.29"},
+	{Kind: "FlowSchema", Resource: "flowschemas", GroupVersion: "flowcontrol.apiserver.k8s.io/v1beta3", Replacement: "flowcontrol.apiserver.k8s.io/v1", DeprecatedIn: "1.29", RemovedIn: "1.32"},
+	{Kind: "PriorityLevelConfiguration", Resource: "prioritylevelconfigurations", GroupVersion: "flowcontrol.apiserver.k8s.io/v1beta3", Replacement: "flowcontrol.apiserver.k8s.io/v1", DeprecatedIn: "1.29", RemovedIn: "1.32"},
+}
+
+// DeprecatedAPICheck validates that no resources use APIs deprecated or removed by
+// a target Kubernetes version.
+type DeprecatedAPICheck struct {
+	// DynamicClient lists resources across API groups
+	DynamicClient dynamic.Interface
+
+	// TargetVersion is the Kubernetes version to evaluate against
+	// (defaults to the spec's kubernetes.maxVersion)
+	TargetVersion string
+}
+
+// Name returns the check name.
+func (c *DeprecatedAPICheck) Name() string {
+	return "kubernetes.deprecated-apis"
+}
+
+// Description explains what the check verifies and why.
+func (c *DeprecatedAPICheck) Description() string {
+	return "Checks that no resources use APIs removed or deprecated by the target Kubernetes version.\n\n" +
+		"The target version is the --assume-version flag, defaulting to kubernetes.maxVersion. Resources served by APIs the target version removes fail the check, since they stop working on upgrade; resources using APIs it only deprecates produce a warning."
+}
+
+// SpecFields returns the spec fields the check reads.
+func (c *DeprecatedAPICheck) SpecFields() []string {
+	return []string{"kubernetes.maxVersion"}
+}
+
+// Severity returns the severity assigned to failures.
+func (c *DeprecatedAPICheck) Severity() scanner.Severity {
+	return scanner.SeverityHigh
+}
+
+// Remediation returns an example fix for failures.
+func (c *DeprecatedAPICheck) Remediation() string {
+	return `Migrate the listed resources to the replacement API before upgrading:
+1. Update the apiVersion (and any changed fields) in the source manifests or Helm charts
+2. Re-apply the manifests so the stored last-applied configuration is updated
+3. See https://kubernetes.io/docs/reference/using-api/deprecation-guide/ for field changes`
+}
+
+// Run executes the deprecated API check.
+func (c *DeprecatedAPICheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.CheckResult, error) {
+	targetVersion := c.TargetVersion
+	if targetVersion == "" {
+		targetVersion = clusterSpec.Spec.Kubernetes.MaxVersion
+	}
+
+	// Skip if there is nothing to evaluate against
+	if targetVersion == "" {
+		return &scanner.CheckResult{
+			Name:       c.Name(),
+			Status:     scanner.StatusSkip,
+			SkipReason: scanner.SkipReasonSpecSectionAbsent,
+			Message:    "Target Kubernetes version for deprecated API detection not specified",
+		}, nil
+	}
+	if c.DynamicClient == nil {
+		return &scanner.CheckResult{
+			Name:       c.Name(),
+			Status:     scanner.StatusSkip,
+			SkipReason: scanner.SkipReasonFeatureUnavailable,
+			Message:    "Dynamic client not available for deprecated API detection",
+		}, nil
+	}
+
+	target, err := semver.NewVersion(strings.TrimPrefix(targetVersion, "v"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse target version %s: %w", targetVersion, err)
+	}
+
+	lister := &resourceLister{client: c.DynamicClient, cache: map[schema.GroupVersionResource]*unstructured.UnstructuredList{}}
+
+	removed := []string{}
+	deprecated := []string{}
+	for _, api := range deprecatedAPIs {
+		if !versionAtLeast(target, api.DeprecatedIn) {
+			continue
+		}
+
+		resources, err := c.findUsages(ctx, lister, api)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range resources {
+			if versionAtLeast(target, api.RemovedIn) {
+				removed = append(removed, fmt.Sprintf("%s uses %s (removed in %s, %s)", resource, api.GroupVersion, api.RemovedIn, replacementHint(api)))
+			} else {
+				deprecated = append(deprecated, fmt.Sprintf("%s uses %s (deprecated in %s, removed in %s, %s)", resource, api.GroupVersion, api.DeprecatedIn, api.RemovedIn, replacementHint(api)))
+			}
+		}
+	}
+
+	evidence := map[string]interface{}{
+		"target_version": targetVersion,
+	}
+
+	if len(removed) > 0 {
+		evidence["removed_api_usages"] = removed
+		evidence["removed_count"] = len(removed)
+		if len(deprecated) > 0 {
+			evidence["deprecated_api_usages"] = deprecated
+		}
+
+		return &scanner.CheckResult{
+			Name:        c.Name(),
+			Status:      scanner.StatusFail,
+			Severity:    scanner.SeverityHigh,
+			Message:     fmt.Sprintf("Found %d resources using APIs removed by Kubernetes %s", len(removed), targetVersion),
+			Evidence:    evidence,
+			Remediation: c.Remediation(),
+		}, nil
+	}
+
+	if len(deprecated) > 0 {
+		evidence["deprecated_api_usages"] = deprecated
+		return &scanner.CheckResult{
+			Name:     c.Name(),
+			Status:   scanner.StatusWarn,
+			Severity: scanner.SeverityLow,
+			Message:  fmt.Sprintf("Found %d resources using APIs deprecated in Kubernetes %s", len(deprecated), targetVersion),
+			Evidence: evidence,
+			Remediation: "Plan migration of the listed resources to the replacement API before it is removed. " +
+				"See https://kubernetes.io/docs/reference/using-api/deprecation-guide/",
+		}, nil
+	}
+
+	return &scanner.CheckResult{
+		Name:     c.Name(),
+		Status:   scanner.StatusPass,
+		Message:  fmt.Sprintf("No resources use APIs deprecated or removed by Kubernetes %s", targetVersion),
+		Evidence: evidence,
+	}, nil
+}
+
+// Playbook returns a step-by-step remediation playbook for failures.
+func (c *DeprecatedAPICheck) Playbook() string {
+	return `Step-by-step: migrate off removed APIs
+
+1. Find the source of each resource listed in the evidence (Git repository,
+   Helm chart or operator).
+
+2. Update the apiVersion and any changed fields, e.g. for Ingress:
+
+   apiVersion: networking.k8s.io/v1   # was extensions/v1beta1
+   kind: Ingress
+   spec:
+     rules:
+     - http:
+         paths:
+         - path: /
+           pathType: Prefix           # now required
+           backend:
+             service:                 # was serviceName/servicePort
+               name: web
+               port:
+                 number: 80
+
+3. Convert manifests you cannot edit by hand with the kubectl-convert plugin:
+   kubectl convert -f old.yaml --output-version <group>/<version>
+
+4. Re-apply the manifests so the stored last-applied configuration is updated:
+   kubectl apply -f <manifest>
+
+5. Re-run kspec scan --assume-version <target> before upgrading.`
+}
+
+// findUsages returns the resources that use a deprecated API. Objects read through
+// the replacement API are identified by the apiVersion of their last applied manifest;
+// if the replacement API is not served, every object served by the deprecated API uses it.
+func (c *DeprecatedAPICheck) findUsages(ctx context.Context, lister *resourceLister, api deprecatedAPI) ([]string, error) {
+	usages := []string{}
+
+	if api.Replacement != "" {
+		items, served, err := lister.list(ctx, groupVersionResource(api.Replacement, api.Resource))
+		if err != nil {
+			return nil, err
+		}
+		if served {
+			for _, item := range items.Items {
+				if lastAppliedAPIVersion(&item, api.Kind) == api.GroupVersion {
+					usages = append(usages, resourceKey(api.Kind, &item))
+				}
+			}
+			return usages, nil
+		}
+	}
+
+	items, served, err := lister.list(ctx, groupVersionResource(api.GroupVersion, api.Resource))
+	if err != nil {
+		return nil, err
+	}
+	if served {
+		for _, item := range items.Items {
+			usages = append(usages, resourceKey(api.Kind, &item))
+		}
+	}
+	return usages, nil
+}
+
+// resourceLister lists resources through the dynamic client, caching results so
+// APIs sharing a replacement are only listed once per scan.
+type resourceLister struct {
+	client dynamic.Interface
+	cache  map[schema.GroupVersionResource]*unstructured.UnstructuredList
+}
+
+// list returns the resources for a GVR and whether the API is served by the cluster.
+func (l *resourceLister) list(ctx context.Context, gvr schema.GroupVersionResource) (*unstructured.UnstructuredList, bool, error) {
+	if items, ok := l.cache[gvr]; ok {
+		return items, items != nil, nil
+	}
+
+	items, err := l.client.Resource(gvr).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			l.cache[gvr] = nil
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to list %s: %w", gvr.String(), err)
+	}
+
+	l.cache[gvr] = items
+	return items, true, nil
+}
+
+// groupVersionResource builds a GVR from an apiVersion string such as "apps/v1".
+func groupVersionResource(groupVersion, resource string) schema.GroupVersionResource {
+	gv, _ := schema.ParseGroupVersion(groupVersion)
+	return gv.WithResource(resource)
+}
+
+// lastAppliedAPIVersion returns the apiVersion of the manifest last applied for an
+// object of the given kind, or "" if unknown.
+func lastAppliedAPIVersion(obj *unstructured.Unstructured, kind string) string {
+	lastApplied, ok := obj.GetAnnotations()[lastAppliedConfigAnnotation]
+	if !ok {
+		return ""
+	}
+
+	var manifest struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}
+	if err := json.Unmarshal([]byte(lastApplied), &manifest); err != nil || manifest.Kind != kind {
+		return ""
+	}
+	return manifest.APIVersion
+}
+
+// resourceKey formats a resource reference for evidence.
+func resourceKey(kind string, obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return fmt.Sprintf("%s %s", kind, obj.GetName())
+	}
+	return fmt.Sprintf("%s %s/%s", kind, obj.GetNamespace(), obj.GetName())
+}
+
+// replacementHint describes what to migrate a deprecated API to.
+func replacementHint(api deprecatedAPI) string {
+	if api.Replacement == "" {
+		return "no replacement API"
+	}
+	return "use " + api.Replacement
+}
+
+// versionAtLeast reports whether version is at or beyond the given major.minor release.
+func versionAtLeast(version *semver.Version, release string) bool {
+	r, err := semver.NewVersion(release)
+	if err != nil {
+		return false
+	}
+	if version.Major() != r.Major() {
+		return version.Major() > r.Major()
+	}
+	return version.Minor() >= r.Minor()
+}
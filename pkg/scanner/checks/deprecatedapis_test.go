@@ -0,0 +1,134 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// newDeprecatedAPIFakeClient creates a dynamic fake client that can list every
+// resource in the deprecation table.
+func newDeprecatedAPIFakeClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	listKinds := map[schema.GroupVersionResource]string{}
+	for _, api := range deprecatedAPIs {
+		listKinds[groupVersionResource(api.GroupVersion, api.Resource)] = api.Kind + "List"
+		if api.Replacement != "" {
+			listKinds[groupVersionResource(api.Replacement, api.Resource)] = api.Kind + "List"
+		}
+	}
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, objects...)
+}
+
+// newAppliedObject creates an object served at apiVersion whose last applied
+// manifest was written against appliedAPIVersion.
+func newAppliedObject(apiVersion, appliedAPIVersion, kind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetAnnotations(map[string]string{
+		lastAppliedConfigAnnotation: fmt.Sprintf(`{"apiVersion":%q,"kind":%q}`, appliedAPIVersion, kind),
+	})
+	return obj
+}
+
+func TestDeprecatedAPICheck_Pass(t *testing.T) {
+	dynamicClient := newDeprecatedAPIFakeClient(
+		newAppliedObject("networking.k8s.io/v1", "networking.k8s.io/v1", "Ingress", "default", "web"),
+	)
+	check := &DeprecatedAPICheck{DynamicClient: dynamicClient, TargetVersion: "1.29"}
+
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(), &spec.ClusterSpecification{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+	assert.Equal(t, "kubernetes.deprecated-apis", result.Name)
+}
+
+func TestDeprecatedAPICheck_FailRemovedAPI(t *testing.T) {
+	dynamicClient := newDeprecatedAPIFakeClient(
+		newAppliedObject("networking.k8s.io/v1", "extensions/v1beta1", "Ingress", "default", "web"),
+		newAppliedObject("batch/v1", "batch/v1beta1", "CronJob", "jobs", "nightly"),
+	)
+	check := &DeprecatedAPICheck{DynamicClient: dynamicClient, TargetVersion: "v1.24.3"}
+
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(), &spec.ClusterSpecification{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	assert.Equal(t, scanner.SeverityHigh, result.Severity)
+	assert.Equal(t, []string{
+		"Ingress default/web uses extensions/v1beta1 (removed in 1.22, use networking.k8s.io/v1)",
+	}, result.Evidence["removed_api_usages"])
+	// CronJob batch/v1beta1 is deprecated but not yet removed in 1.24
+	assert.Equal(t, []string{
+		"CronJob jobs/nightly uses batch/v1beta1 (deprecated in 1.21, removed in 1.25, use batch/v1)",
+	}, result.Evidence["deprecated_api_usages"])
+}
+
+func TestDeprecatedAPICheck_FailWithoutReplacement(t *testing.T) {
+	psp := &unstructured.Unstructured{}
+	psp.SetAPIVersion("policy/v1beta1")
+	psp.SetKind("PodSecurityPolicy")
+	psp.SetName("restricted")
+
+	dynamicClient := newDeprecatedAPIFakeClient(psp)
+	check := &DeprecatedAPICheck{DynamicClient: dynamicClient}
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Kubernetes: spec.KubernetesSpec{MaxVersion: "1.25.0"},
+		},
+	}
+
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(), clusterSpec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	assert.Equal(t, "1.25.0", result.Evidence["target_version"])
+	assert.Equal(t, []string{
+		"PodSecurityPolicy restricted uses policy/v1beta1 (removed in 1.25, no replacement API)",
+	}, result.Evidence["removed_api_usages"])
+}
+
+func TestDeprecatedAPICheck_Warn(t *testing.T) {
+	dynamicClient := newDeprecatedAPIFakeClient(
+		newAppliedObject("flowcontrol.apiserver.k8s.io/v1", "flowcontrol.apiserver.k8s.io/v1beta3", "FlowSchema", "", "custom"),
+	)
+	check := &DeprecatedAPICheck{DynamicClient: dynamicClient, TargetVersion: "1.30"}
+
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(), &spec.ClusterSpecification{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusWarn, result.Status)
+	assert.Len(t, result.Evidence["deprecated_api_usages"], 1)
+}
+
+func TestDeprecatedAPICheck_Skip(t *testing.T) {
+	check := &DeprecatedAPICheck{DynamicClient: newDeprecatedAPIFakeClient()}
+
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(), &spec.ClusterSpecification{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusSkip, result.Status)
+	assert.Equal(t, scanner.SkipReasonSpecSectionAbsent, result.SkipReason)
+}
+
+func TestDeprecatedAPICheck_SkipWithoutDynamicClient(t *testing.T) {
+	check := &DeprecatedAPICheck{TargetVersion: "1.29"}
+
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(), &spec.ClusterSpecification{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusSkip, result.Status)
+	assert.Equal(t, scanner.SkipReasonFeatureUnavailable, result.SkipReason)
+}
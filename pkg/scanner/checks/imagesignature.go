@@ -0,0 +1,339 @@
+package checks
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// cosignSignatureAnnotation holds the base64 signature of a cosign signature layer.
+const cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+// defaultRegistryTimeout bounds the registry requests made for one image.
+const defaultRegistryTimeout = 30 * time.Second
+
+// ImageSignatureCheck verifies that workload images carry a cosign signature,
+// made with the spec's public key when one is configured. Registry failures
+// are reported as errors rather than failures, since they say nothing about
+// the images. Registries are accessed with the pull secrets of each pod and of
+// its service account, like the kubelet does.
+type ImageSignatureCheck struct {
+	// Transport performs registry requests; nil uses the go-containerregistry default
+	Transport http.RoundTripper
+	// Keychain provides credentials for registries the pull secrets do not
+	// cover; nil uses the scanner's Docker config and credential helpers
+	Keychain authn.Keychain
+}
+
+// imageVerification is the cached outcome of verifying one image digest.
+type imageVerification struct {
+	problem string // why the image is not compliant, e.g. "no cosign signature"
+	err     error  // why the image could not be verified
+}
+
+// Name returns the check name.
+func (c *ImageSignatureCheck) Name() string {
+	return "workload.image-signatures"
+}
+
+// Description explains what the check verifies and why.
+func (c *ImageSignatureCheck) Description() string {
+	return "Checks that workload images carry a valid cosign signature.\n\n" +
+		"Signatures are looked up in the image registry by digest. With workloads.images.signaturePublicKey set, a signature must verify against that key; otherwise any cosign signature is accepted. Registries are accessed with the imagePullSecrets of the pod and its service account. Registries that cannot be reached make the check error rather than fail."
+}
+
+// SpecFields returns the spec fields the check reads.
+func (c *ImageSignatureCheck) SpecFields() []string {
+	return []string{"workloads.images.requireSignatures", "workloads.images.signaturePublicKey", "workloads.excludeContainers", "workloads.ignorePhases"}
+}
+
+// Severity returns the severity assigned to failures.
+func (c *ImageSignatureCheck) Severity() scanner.Severity {
+	return scanner.SeverityHigh
+}
+
+// Remediation returns an example fix for failures.
+func (c *ImageSignatureCheck) Remediation() string {
+	return `Sign images with cosign before deploying them:
+1. Generate a key pair: cosign generate-key-pair
+2. Sign each image by digest: cosign sign --key cosign.key <registry>/<image>@sha256:<digest>
+3. Set workloads.images.signaturePublicKey to the contents of cosign.pub
+
+Verify a signature with: cosign verify --key cosign.pub <image>`
+}
+
+// Run executes the image signature check.
+func (c *ImageSignatureCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.CheckResult, error) {
+	workloads := clusterSpec.Spec.Workloads
+
+	// Skip unless signatures are required
+	if workloads == nil || workloads.Images == nil || !workloads.Images.RequireSignatures {
+		return &scanner.CheckResult{
+			Name:       c.Name(),
+			Status:     scanner.StatusSkip,
+			SkipReason: scanner.SkipReasonSpecSectionAbsent,
+			Message:    "Image signatures not required in cluster spec",
+		}, nil
+	}
+
+	key, err := workloads.Images.SignatureKey()
+	if err != nil {
+		return nil, fmt.Errorf("invalid image signature key: %w", err)
+	}
+
+	pods, err := scanner.ListPods(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	registry := newRegistryClient(client, c.Transport, c.Keychain)
+
+	// Images are verified once per digest, however many containers run them.
+	// Registry errors may come from missing credentials, so they are only
+	// reused for pods with the same pull secrets.
+	digests := map[string]string{}
+	resolveErrors := map[string]error{}
+	verified := map[string]imageVerification{}
+	failed := map[string]imageVerification{}
+
+	violations := []string{}
+	unsigned := map[string]bool{}
+	verificationErrors := []string{}
+	images := map[string]bool{}
+
+	for _, pod := range pods.Items {
+		// Skip system namespaces
+		if isSystemNamespace(pod.Namespace) {
+			continue
+		}
+
+		// Skip terminal pods the spec ignores, e.g. completed Jobs
+		if workloads.IsPodPhaseIgnored(string(pod.Status.Phase)) {
+			continue
+		}
+
+		statusDigests := containerStatusDigests(&pod)
+		var credentials *registryCredentials
+		var credentialsErr error
+		containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+		for _, container := range containers {
+			if workloads.IsContainerExcluded(container.Name) {
+				continue
+			}
+			images[container.Image] = true
+			containerKey := fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, container.Name)
+
+			ref, err := parseImageReference(container.Image)
+			if err != nil {
+				violations = append(violations, fmt.Sprintf("%s: %v", containerKey, err))
+				unsigned[container.Image] = true
+				continue
+			}
+
+			if credentials == nil && credentialsErr == nil {
+				credentials, credentialsErr = registry.podCredentials(ctx, &pod)
+			}
+			if credentialsErr != nil {
+				verificationErrors = append(verificationErrors, fmt.Sprintf("%s: %v", containerKey, credentialsErr))
+				continue
+			}
+
+			// Prefer the digest the kubelet pulled over resolving the tag again
+			var digest string
+			if d, ok := ref.(name.Digest); ok {
+				digest = d.DigestStr()
+			} else {
+				digest = statusDigests[container.Name]
+			}
+			if digest == "" {
+				resolveKey := credentials.key + " " + container.Image
+				if _, seen := digests[resolveKey]; !seen {
+					digests[resolveKey], resolveErrors[resolveKey] = resolveImageDigest(ctx, registry, ref, credentials)
+				}
+				if err := resolveErrors[resolveKey]; err != nil {
+					verificationErrors = append(verificationErrors, fmt.Sprintf("%s: %v", containerKey, err))
+					continue
+				}
+				digest = digests[resolveKey]
+			}
+
+			// Signatures are stored per repository, so copies of an image are verified separately
+			cacheKey := ref.Context().Name() + "@" + digest
+			verification, seen := verified[cacheKey]
+			if !seen {
+				failureKey := credentials.key + " " + cacheKey
+				if verification, seen = failed[failureKey]; !seen {
+					verification = verifyImageSignature(ctx, registry, ref.Context(), digest, credentials, key)
+					if verification.err != nil {
+						failed[failureKey] = verification
+					} else {
+						verified[cacheKey] = verification
+					}
+				}
+			}
+			if verification.err != nil {
+				verificationErrors = append(verificationErrors, fmt.Sprintf("%s: %v", containerKey, verification.err))
+				continue
+			}
+			if verification.problem != "" {
+				violations = append(violations, fmt.Sprintf("%s: image %s has %s", containerKey, container.Image, verification.problem))
+				unsigned[container.Image] = true
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		evidence := map[string]interface{}{
+			"violations":      violations,
+			"violation_count": len(violations),
+			"unsigned_images": sortedKeys(unsigned),
+		}
+		if len(verificationErrors) > 0 {
+			evidence["verification_errors"] = verificationErrors
+		}
+		return &scanner.CheckResult{
+			Name:        c.Name(),
+			Status:      scanner.StatusFail,
+			Severity:    scanner.SeverityHigh,
+			Message:     fmt.Sprintf("Found %d unsigned images used by %d containers", len(unsigned), len(violations)),
+			Evidence:    evidence,
+			Remediation: c.Remediation(),
+		}, nil
+	}
+
+	if len(verificationErrors) > 0 {
+		return &scanner.CheckResult{
+			Name:     c.Name(),
+			Status:   scanner.StatusError,
+			Message:  fmt.Sprintf("Could not verify the signatures of %d containers", len(verificationErrors)),
+			Evidence: map[string]interface{}{"verification_errors": verificationErrors},
+			Remediation: "Check that the scanner can reach the image registries named in the evidence " +
+				"and that the pull secrets of the pods grant access to them, " +
+				"then re-run the scan",
+		}, nil
+	}
+
+	return &scanner.CheckResult{
+		Name:    c.Name(),
+		Status:  scanner.StatusPass,
+		Message: fmt.Sprintf("All %d images carry a valid cosign signature", len(images)),
+		Evidence: map[string]interface{}{
+			"total_images":     len(images),
+			"verified_digests": len(verified),
+		},
+	}, nil
+}
+
+// resolveImageDigest resolves the digest of an image tag within the registry timeout.
+func resolveImageDigest(ctx context.Context, registry *registryClient, ref name.Reference, credentials *registryCredentials) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultRegistryTimeout)
+	defer cancel()
+	return registry.resolveDigest(ctx, ref, credentials)
+}
+
+// verifyImageSignature looks up the cosign signatures of an image digest and,
+// when a key is given, checks that one of them verifies against it.
+func verifyImageSignature(ctx context.Context, registry *registryClient, repo name.Repository, digest string, credentials *registryCredentials, key crypto.PublicKey) imageVerification {
+	ctx, cancel := context.WithTimeout(ctx, defaultRegistryTimeout)
+	defer cancel()
+
+	signatures, found, err := registry.signatures(ctx, repo, digest, credentials)
+	if err != nil {
+		return imageVerification{err: err}
+	}
+	if !found || len(signatures) == 0 {
+		return imageVerification{problem: "no cosign signature"}
+	}
+	if key == nil {
+		return imageVerification{}
+	}
+
+	for _, sig := range signatures {
+		if verifyCosignSignature(key, sig, digest) == nil {
+			return imageVerification{}
+		}
+	}
+	return imageVerification{problem: "no cosign signature valid for the configured public key"}
+}
+
+// verifyCosignSignature checks a cosign signature over its simple signing
+// payload, and that the payload names the image digest.
+func verifyCosignSignature(key crypto.PublicKey, sig cosignSignature, digest string) error {
+	raw, err := base64.StdEncoding.DecodeString(sig.signature)
+	if err != nil {
+		return fmt.Errorf("signature is not base64 encoded: %w", err)
+	}
+
+	hash := sha256.Sum256(sig.payload)
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, hash[:], raw) {
+			return fmt.Errorf("invalid ECDSA signature")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], raw); err != nil {
+			return fmt.Errorf("invalid RSA signature: %w", err)
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, sig.payload, raw) {
+			return fmt.Errorf("invalid Ed25519 signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+
+	var simpleSigning struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+		} `json:"critical"`
+	}
+	if err := json.Unmarshal(sig.payload, &simpleSigning); err != nil {
+		return fmt.Errorf("failed to decode signature payload: %w", err)
+	}
+	if simpleSigning.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("signature is for digest %s, not %s", simpleSigning.Critical.Image.DockerManifestDigest, digest)
+	}
+	return nil
+}
+
+// containerStatusDigests returns the image digest each container of a pod was
+// started from, as reported in its status.
+func containerStatusDigests(pod *corev1.Pod) map[string]string {
+	digests := map[string]string{}
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if i := strings.LastIndex(status.ImageID, "@sha256:"); i >= 0 {
+			digests[status.Name] = status.ImageID[i+1:]
+		}
+	}
+	return digests
+}
+
+// sortedKeys returns the keys of a set in sorted order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,360 @@
+package checks
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// Credentials the fake registry accepts when it requires a login.
+const (
+	testRegistryUser     = "puller"
+	testRegistryPassword = "pull-password"
+)
+
+// fakeSignatureRegistry is an in-memory OCI registry holding images and their
+// cosign signatures, optionally requiring basic authentication.
+type fakeSignatureRegistry struct {
+	server       *httptest.Server
+	requireLogin atomic.Bool
+
+	manifestRequests atomic.Int32
+}
+
+func newFakeSignatureRegistry(t *testing.T) *fakeSignatureRegistry {
+	r := &fakeSignatureRegistry{}
+	handler := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if r.requireLogin.Load() {
+			user, password, ok := req.BasicAuth()
+			if !ok || user != testRegistryUser || password != testRegistryPassword {
+				w.Header().Set("WWW-Authenticate", `Basic realm="fake"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+		if strings.Contains(req.URL.Path, "/manifests/") {
+			r.manifestRequests.Add(1)
+		}
+		handler.ServeHTTP(w, req)
+	}))
+	t.Cleanup(r.server.Close)
+
+	return r
+}
+
+// host returns the registry host used in image references.
+func (r *fakeSignatureRegistry) host() string {
+	return strings.TrimPrefix(r.server.URL, "http://")
+}
+
+// write uploads an image under a tag or digest reference.
+func (r *fakeSignatureRegistry) write(t *testing.T, reference string, image v1.Image) {
+	ref, err := name.ParseReference(reference)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, image, remote.WithAuth(&authn.Basic{Username: testRegistryUser, Password: testRegistryPassword})))
+}
+
+// push uploads a random image to a repository and returns its digest. With a
+// tag the image reference is returned too; without one it is pushed by digest.
+func (r *fakeSignatureRegistry) push(t *testing.T, repo, tag string) (reference, digest string) {
+	image, err := random.Image(256, 1)
+	require.NoError(t, err)
+	hash, err := image.Digest()
+	require.NoError(t, err)
+
+	reference = fmt.Sprintf("%s/%s@%s", r.host(), repo, hash)
+	if tag != "" {
+		reference = fmt.Sprintf("%s/%s:%s", r.host(), repo, tag)
+	}
+	r.write(t, reference, image)
+	return reference, hash.String()
+}
+
+// sign stores a cosign signature of an image digest made with key.
+func (r *fakeSignatureRegistry) sign(t *testing.T, repo, digest string, key *ecdsa.PrivateKey) {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"%s/%s"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`,
+		r.host(), repo, digest))
+	hash := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	require.NoError(t, err)
+
+	image, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:       static.NewLayer(payload, "application/vnd.dev.cosign.simplesigning.v1+json"),
+		Annotations: map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+	})
+	require.NoError(t, err)
+	image = mutate.MediaType(image, types.OCIManifestSchema1)
+
+	r.write(t, fmt.Sprintf("%s/%s:%s.sig", r.host(), repo, strings.Replace(digest, ":", "-", 1)), image)
+}
+
+// pullSecret returns an image pull secret holding the fake registry's credentials.
+func (r *fakeSignatureRegistry) pullSecret(t *testing.T, name string) *corev1.Secret {
+	config, err := json.Marshal(map[string]interface{}{
+		"auths": map[string]interface{}{
+			r.host(): map[string]string{"username": testRegistryUser, "password": testRegistryPassword},
+		},
+	})
+	require.NoError(t, err)
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: config},
+	}
+}
+
+func newSigningKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(key.Public().(crypto.PublicKey))
+	require.NoError(t, err)
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// newSignatureCheck returns a check that ignores the Docker config of the
+// machine running the tests.
+func newSignatureCheck() *ImageSignatureCheck {
+	return &ImageSignatureCheck{Keychain: authn.NewMultiKeychain()}
+}
+
+func newImageTestPod(name string, images ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	for i, image := range images {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: fmt.Sprintf("c%d", i), Image: image})
+	}
+	return pod
+}
+
+func imageSignatureSpec(publicKey string) *spec.ClusterSpecification {
+	return &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{
+				Images: &spec.ImageSpec{RequireSignatures: true, SignaturePublicKey: publicKey},
+			},
+		},
+	}
+}
+
+func TestImageSignatureCheck_Skip(t *testing.T) {
+	check := newSignatureCheck()
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(), &spec.ClusterSpecification{
+		Spec: spec.SpecFields{Workloads: &spec.WorkloadsSpec{Images: &spec.ImageSpec{RequireDigests: true}}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusSkip, result.Status)
+	assert.Equal(t, scanner.SkipReasonSpecSectionAbsent, result.SkipReason)
+}
+
+func TestImageSignatureCheck_SignedWithKey(t *testing.T) {
+	registry := newFakeSignatureRegistry(t)
+	key, publicKey := newSigningKey(t)
+
+	image, digest := registry.push(t, "team/api", "v1")
+	registry.sign(t, "team/api", digest, key)
+	registry.manifestRequests.Store(0)
+
+	// Two pods share the image, so it is resolved and verified once
+	client := fake.NewSimpleClientset(newImageTestPod("api-1", image), newImageTestPod("api-2", image))
+	result, err := newSignatureCheck().Run(context.Background(), client, imageSignatureSpec(publicKey))
+
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+	assert.Equal(t, 1, result.Evidence["total_images"])
+	assert.Equal(t, 1, result.Evidence["verified_digests"])
+	assert.Equal(t, int32(2), registry.manifestRequests.Load(), "expected one tag resolution and one signature lookup")
+}
+
+func TestImageSignatureCheck_DigestFromContainerStatus(t *testing.T) {
+	registry := newFakeSignatureRegistry(t)
+	key, publicKey := newSigningKey(t)
+	_, digest := registry.push(t, "team/api", "")
+	registry.sign(t, "team/api", digest, key)
+
+	// The tag is not resolvable, but the kubelet reports the pulled digest
+	pod := newImageTestPod("api", registry.host()+"/team/api:v1")
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "c0", ImageID: registry.host() + "/team/api@" + digest}}
+
+	result, err := newSignatureCheck().Run(context.Background(), fake.NewSimpleClientset(pod), imageSignatureSpec(publicKey))
+
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+}
+
+func TestImageSignatureCheck_Unsigned(t *testing.T) {
+	registry := newFakeSignatureRegistry(t)
+	key, publicKey := newSigningKey(t)
+	otherKey, _ := newSigningKey(t)
+
+	signed, signedDigest := registry.push(t, "team/api", "v1")
+	registry.sign(t, "team/api", signedDigest, key)
+	unsigned, _ := registry.push(t, "team/worker", "v1")
+	wrongKey, wrongKeyDigest := registry.push(t, "team/api", "v2")
+	registry.sign(t, "team/api", wrongKeyDigest, otherKey)
+
+	client := fake.NewSimpleClientset(newImageTestPod("app", signed, unsigned, wrongKey))
+	result, err := newSignatureCheck().Run(context.Background(), client, imageSignatureSpec(publicKey))
+
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	assert.Equal(t, scanner.SeverityHigh, result.Severity)
+	assert.Equal(t, []string{wrongKey, unsigned}, result.Evidence["unsigned_images"])
+
+	violations := result.Evidence["violations"].([]string)
+	require.Len(t, violations, 2)
+	assert.Contains(t, violations[0], "has no cosign signature")
+	assert.Contains(t, violations[1], "no cosign signature valid for the configured public key")
+}
+
+func TestImageSignatureCheck_AnySignatureWithoutKey(t *testing.T) {
+	registry := newFakeSignatureRegistry(t)
+	otherKey, _ := newSigningKey(t)
+
+	image, digest := registry.push(t, "team/api", "v1")
+	registry.sign(t, "team/api", digest, otherKey)
+
+	result, err := newSignatureCheck().Run(context.Background(), fake.NewSimpleClientset(newImageTestPod("api", image)), imageSignatureSpec(""))
+
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+}
+
+func TestImageSignatureCheck_PullSecrets(t *testing.T) {
+	registry := newFakeSignatureRegistry(t)
+	key, publicKey := newSigningKey(t)
+
+	image, digest := registry.push(t, "private/api", "v1")
+	registry.sign(t, "private/api", digest, key)
+	registry.requireLogin.Store(true)
+
+	// Credentials come from the pod's imagePullSecrets
+	pod := newImageTestPod("api", image)
+	pod.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "registry-login"}}
+	client := fake.NewSimpleClientset(pod, registry.pullSecret(t, "registry-login"))
+
+	result, err := newSignatureCheck().Run(context.Background(), client, imageSignatureSpec(publicKey))
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+
+	// ... or from the pull secrets of the pod's service account
+	pod = newImageTestPod("worker", image)
+	pod.Spec.ServiceAccountName = "worker"
+	client = fake.NewSimpleClientset(pod, registry.pullSecret(t, "registry-login"), &corev1.ServiceAccount{
+		ObjectMeta:       metav1.ObjectMeta{Name: "worker", Namespace: "default"},
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-login"}},
+	})
+
+	result, err = newSignatureCheck().Run(context.Background(), client, imageSignatureSpec(publicKey))
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+
+	// Without credentials the registry refuses, which says nothing about the image
+	client = fake.NewSimpleClientset(newImageTestPod("api", image))
+	result, err = newSignatureCheck().Run(context.Background(), client, imageSignatureSpec(publicKey))
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusError, result.Status)
+	assert.Len(t, result.Evidence["verification_errors"], 1)
+}
+
+func TestImageSignatureCheck_Keychain(t *testing.T) {
+	registry := newFakeSignatureRegistry(t)
+	key, publicKey := newSigningKey(t)
+
+	image, digest := registry.push(t, "private/api", "v1")
+	registry.sign(t, "private/api", digest, key)
+	registry.requireLogin.Store(true)
+
+	// The check's keychain covers registries the pull secrets do not
+	check := &ImageSignatureCheck{Keychain: staticKeychain{registry.host(): &authn.Basic{Username: testRegistryUser, Password: testRegistryPassword}}}
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(newImageTestPod("api", image)), imageSignatureSpec(publicKey))
+
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+}
+
+func TestImageSignatureCheck_RegistryUnreachable(t *testing.T) {
+	registry := newFakeSignatureRegistry(t)
+	_, publicKey := newSigningKey(t)
+	image, _ := registry.push(t, "team/api", "v1")
+	registry.server.Close()
+
+	result, err := newSignatureCheck().Run(context.Background(), fake.NewSimpleClientset(newImageTestPod("api", image)), imageSignatureSpec(publicKey))
+
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusError, result.Status)
+	assert.Len(t, result.Evidence["verification_errors"], 1)
+}
+
+// staticKeychain resolves credentials by registry host.
+type staticKeychain map[string]authn.Authenticator
+
+func (k staticKeychain) Resolve(resource authn.Resource) (authn.Authenticator, error) {
+	if auth, ok := k[resource.RegistryStr()]; ok {
+		return auth, nil
+	}
+	return authn.Anonymous, nil
+}
+
+func TestParseImageReference(t *testing.T) {
+	digest := "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	tests := []struct {
+		image      string
+		registry   string
+		repository string
+		identifier string
+	}{
+		{"nginx", "index.docker.io", "library/nginx", "latest"},
+		{"bitnami/redis:7.2", "index.docker.io", "bitnami/redis", "7.2"},
+		{"ghcr.io/org/app@" + digest, "ghcr.io", "org/app", digest},
+		{"localhost:5000/app:v1", "localhost:5000", "app", "v1"},
+	}
+
+	for _, tt := range tests {
+		ref, err := parseImageReference(tt.image)
+		require.NoError(t, err, tt.image)
+		assert.Equal(t, tt.registry, ref.Context().RegistryStr(), tt.image)
+		assert.Equal(t, tt.repository, ref.Context().RepositoryStr(), tt.image)
+		assert.Equal(t, tt.identifier, ref.Identifier(), tt.image)
+	}
+
+	_, err := parseImageReference("Invalid Image")
+	assert.Error(t, err)
+}
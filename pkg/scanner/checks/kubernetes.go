@@ -20,6 +20,27 @@ func (c *KubernetesVersionCheck) Name() string {
 	return "kubernetes.version"
 }
 
+// Description explains what the check verifies and why.
+func (c *KubernetesVersionCheck) Description() string {
+	return "Checks that the cluster runs a supported Kubernetes version.\n\n" +
+		"The API server version must lie between kubernetes.minVersion and kubernetes.maxVersion and must not be one of kubernetes.excludedVersions, e.g. releases with known vulnerabilities."
+}
+
+// SpecFields returns the spec fields the check reads.
+func (c *KubernetesVersionCheck) SpecFields() []string {
+	return []string{"kubernetes.minVersion", "kubernetes.maxVersion", "kubernetes.excludedVersions"}
+}
+
+// Severity returns the severity assigned to failures.
+func (c *KubernetesVersionCheck) Severity() scanner.Severity {
+	return scanner.SeverityCritical
+}
+
+// Remediation returns an example fix for failures.
+func (c *KubernetesVersionCheck) Remediation() string {
+	return c.Playbook()
+}
+
 // Run executes the version check.
 func (c *KubernetesVersionCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.CheckResult, error) {
 	// Get cluster version
@@ -94,3 +115,23 @@ func (c *KubernetesVersionCheck) Run(ctx context.Context, client kubernetes.Inte
 		},
 	}, nil
 }
+
+// Playbook returns a step-by-step remediation playbook for failures.
+func (c *KubernetesVersionCheck) Playbook() string {
+	return `Step-by-step: bring the cluster into the supported version range
+
+1. Check the current control plane and node versions:
+   kubectl version
+   kubectl get nodes -o wide
+
+2. Check for APIs removed in the target version before upgrading:
+   kspec scan --spec cluster-spec.yaml --assume-version <target>
+
+3. Upgrade the control plane one minor version at a time, e.g.:
+   kubeadm upgrade plan && kubeadm upgrade apply v<target>
+   (managed clusters: use the provider's upgrade command or console)
+
+4. Upgrade node pools to match the control plane.
+
+5. Re-run kspec scan.`
+}
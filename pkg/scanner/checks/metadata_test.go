@@ -0,0 +1,43 @@
+package checks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckMetadata(t *testing.T) {
+	checkList := All(nil, "")
+
+	known := map[string]bool{}
+	for _, check := range checkList {
+		known[check.Name()] = true
+	}
+	for _, framework := range scanner.DefaultControlMappings().Frameworks {
+		for _, control := range framework.Controls {
+			assert.NotEmpty(t, control.Mappings, "%s %s maps no checks", framework.Name, control.ID)
+			for _, mapping := range control.Mappings {
+				assert.True(t, known[mapping.Check], "%s %s maps unknown check %s", framework.Name, control.ID, mapping.Check)
+			}
+		}
+	}
+
+	for _, check := range checkList {
+		t.Run(check.Name(), func(t *testing.T) {
+			summary := scanner.Summary(check)
+			assert.NotEmpty(t, summary)
+			assert.True(t, strings.HasSuffix(summary, "."), "summary should be a sentence: %q", summary)
+			assert.Greater(t, len(check.Description()), len(summary), "description should explain the check beyond its summary")
+
+			assert.NotEmpty(t, check.SpecFields())
+			for _, field := range check.SpecFields() {
+				assert.False(t, strings.HasPrefix(field, "spec."), "spec fields are relative to spec: %s", field)
+			}
+
+			assert.Contains(t, scanner.Severities, check.Severity())
+			assert.NotEmpty(t, check.Remediation())
+		})
+	}
+}
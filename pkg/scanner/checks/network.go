@@ -4,10 +4,14 @@ package checks
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/cloudcwfranck/kspec/pkg/scanner"
 	"github.com/cloudcwfranck/kspec/pkg/spec"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -19,47 +23,94 @@ func (c *NetworkPolicyCheck) Name() string {
 	return "network.policies"
 }
 
+// Description explains what the check verifies and why.
+func (c *NetworkPolicyCheck) Description() string {
+	return "Checks that namespaces are isolated by NetworkPolicies.\n\n" +
+		"With network.defaultDeny every non-system namespace needs a default-deny policy selecting all pods, and with network.requireDNSEgress pods whose egress is restricted must still be allowed DNS. network.namespaceOverrides change both requirements per namespace, and every policy in network.requiredPolicies must exist. Without them any pod can reach any other pod in the cluster."
+}
+
+// SpecFields returns the spec fields the check reads.
+func (c *NetworkPolicyCheck) SpecFields() []string {
+	return []string{"network.defaultDeny", "network.requireDNSEgress", "network.namespaceOverrides", "network.requiredPolicies"}
+}
+
+// Severity returns the severity assigned to failures.
+func (c *NetworkPolicyCheck) Severity() scanner.Severity {
+	return scanner.SeverityHigh
+}
+
+// Remediation returns an example fix for failures.
+func (c *NetworkPolicyCheck) Remediation() string {
+	return c.Playbook()
+}
+
 // Run executes the network policy check.
 func (c *NetworkPolicyCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.CheckResult, error) {
 	// Skip check if network policies are not specified
 	if clusterSpec.Spec.Network == nil {
 		return &scanner.CheckResult{
-			Name:    c.Name(),
-			Status:  scanner.StatusSkip,
-			Message: "Network policies not specified in cluster spec",
+			Name:       c.Name(),
+			Status:     scanner.StatusSkip,
+			SkipReason: scanner.SkipReasonSpecSectionAbsent,
+			Message:    "Network policies not specified in cluster spec",
 		}, nil
 	}
 
 	network := clusterSpec.Spec.Network
 	var violations []string
+	var audit namespaceAudit
 	evidence := make(map[string]interface{})
 
-	// Check default-deny requirement
-	if network.DefaultDeny {
-		namespacesWithoutDefaultDeny, err := c.checkDefaultDeny(ctx, client)
+	auditNamespaces := network.DefaultDeny || network.RequireDNSEgress || len(network.NamespaceOverrides) > 0
+	if !auditNamespaces && len(network.RequiredPolicies) == 0 {
+		return &scanner.CheckResult{
+			Name:     c.Name(),
+			Status:   scanner.StatusPass,
+			Message:  "All network policy requirements met",
+			Evidence: evidence,
+		}, nil
+	}
+
+	policies, err := client.NetworkingV1().NetworkPolicies("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network policies: %w", err)
+	}
+
+	// Check default-deny and DNS egress requirements namespace by namespace
+	if auditNamespaces {
+		audit, err = c.auditNamespaces(ctx, client, network, policies.Items)
 		if err != nil {
-			return nil, fmt.Errorf("failed to check default-deny policies: %w", err)
+			return nil, fmt.Errorf("failed to audit namespace network policies: %w", err)
 		}
 
-		if len(namespacesWithoutDefaultDeny) > 0 {
+		if len(audit.withoutDefaultDeny) > 0 {
 			violations = append(violations, fmt.Sprintf(
 				"%d namespaces missing default-deny NetworkPolicy: %v",
-				len(namespacesWithoutDefaultDeny),
-				namespacesWithoutDefaultDeny,
+				len(audit.withoutDefaultDeny),
+				audit.withoutDefaultDeny,
+			))
+			evidence["namespaces_without_default_deny"] = audit.withoutDefaultDeny
+		}
+		if len(audit.withoutDNSEgress) > 0 {
+			violations = append(violations, fmt.Sprintf(
+				"%d namespaces restrict egress without allowing DNS: %v",
+				len(audit.withoutDNSEgress),
+				audit.withoutDNSEgress,
 			))
-			evidence["namespaces_without_default_deny"] = namespacesWithoutDefaultDeny
+			evidence["namespaces_without_dns_egress"] = audit.withoutDNSEgress
+		}
+		if len(audit.findings) > 0 {
+			evidence["namespace_findings"] = audit.findings
 		}
-		evidence["default_deny_required"] = true
-		evidence["default_deny_violations"] = len(namespacesWithoutDefaultDeny)
+		evidence["namespaces_audited"] = audit.audited
+		evidence["default_deny_required"] = network.DefaultDeny
+		evidence["default_deny_violations"] = len(audit.withoutDefaultDeny)
+		evidence["dns_egress_required"] = network.RequireDNSEgress
 	}
 
 	// Check required policies
 	if len(network.RequiredPolicies) > 0 {
-		missingPolicies, err := c.checkRequiredPolicies(ctx, client, network.RequiredPolicies)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check required policies: %w", err)
-		}
-
+		missingPolicies := c.checkRequiredPolicies(policies.Items, network.RequiredPolicies)
 		if len(missingPolicies) > 0 {
 			for _, policyName := range missingPolicies {
 				violations = append(violations, fmt.Sprintf(
@@ -82,8 +133,9 @@ func (c *NetworkPolicyCheck) Run(ctx context.Context, client kubernetes.Interfac
 				"Found %d network policy violations",
 				len(violations),
 			),
-			Evidence:    evidence,
-			Remediation: c.buildRemediation(violations),
+			Evidence:          evidence,
+			Remediation:       c.buildRemediation(violations),
+			RemediationAction: c.buildRemediationAction(audit.withoutDefaultDeny, audit.withoutDNSEgress),
 		}, nil
 	}
 
@@ -100,81 +152,294 @@ func (c *NetworkPolicyCheck) Run(ctx context.Context, client kubernetes.Interfac
 	}, nil
 }
 
-// checkDefaultDeny checks for default-deny network policies in all user namespaces.
-func (c *NetworkPolicyCheck) checkDefaultDeny(ctx context.Context, client kubernetes.Interface) ([]string, error) {
-	// Get all namespaces
-	namespaces, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+// Playbook returns a step-by-step remediation playbook for failures.
+func (c *NetworkPolicyCheck) Playbook() string {
+	return `Step-by-step: fix network policy violations
+
+1. List namespaces without a default-deny policy (see the evidence above):
+   kubectl get networkpolicy -A
+
+2. Add a default-deny policy to each namespace so only explicitly allowed
+   traffic reaches pods:
+
+   apiVersion: networking.k8s.io/v1
+   kind: NetworkPolicy
+   metadata:
+     name: default-deny-all
+     namespace: <namespace>
+   spec:
+     podSelector: {}
+     policyTypes:
+     - Ingress
+     - Egress
+
+3. Allow the traffic each application needs, e.g. DNS egress:
+
+   apiVersion: networking.k8s.io/v1
+   kind: NetworkPolicy
+   metadata:
+     name: allow-dns
+     namespace: <namespace>
+   spec:
+     podSelector: {}
+     policyTypes:
+     - Egress
+     egress:
+     - to:
+       - namespaceSelector: {}
+       ports:
+       - protocol: UDP
+         port: 53
+
+4. Create any required policies named in the spec with the exact names listed.
+
+5. Verify the CNI plugin enforces NetworkPolicy (e.g. Calico, Cilium) and re-run
+   kspec scan.`
+}
+
+// namespaceAudit is the outcome of evaluating each namespace's NetworkPolicies
+// against its default-deny and DNS egress requirements.
+type namespaceAudit struct {
+	withoutDefaultDeny []string
+	withoutDNSEgress   []string
+
+	// findings explains, per failing namespace, what is missing
+	findings map[string][]string
+
+	audited int
+}
+
+// auditNamespaces evaluates the NetworkPolicies of every namespace against its
+// requirements. System namespaces are only audited when the spec overrides them.
+func (c *NetworkPolicyCheck) auditNamespaces(ctx context.Context, client kubernetes.Interface, network *spec.NetworkSpec, policies []networkingv1.NetworkPolicy) (namespaceAudit, error) {
+	audit := namespaceAudit{findings: map[string][]string{}}
+
+	namespaces, err := scanner.ListNamespaces(ctx, client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+		return audit, fmt.Errorf("failed to list namespaces: %w", err)
 	}
 
-	var namespacesWithoutDefaultDeny []string
+	policiesByNamespace := map[string][]networkingv1.NetworkPolicy{}
+	for _, policy := range policies {
+		policiesByNamespace[policy.Namespace] = append(policiesByNamespace[policy.Namespace], policy)
+	}
 
 	for _, ns := range namespaces.Items {
-		// Skip system namespaces
-		if isSystemNamespace(ns.Name) {
+		if isSystemNamespace(ns.Name) && network.Override(ns.Name) == nil {
 			continue
 		}
 
-		// Get network policies in this namespace
-		policies, err := client.NetworkingV1().NetworkPolicies(ns.Name).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			return nil, fmt.Errorf("failed to list network policies in namespace %s: %w", ns.Name, err)
-		}
-
-		// Check if there's a default-deny policy
-		hasDefaultDeny := false
-		for _, policy := range policies.Items {
-			// A default-deny policy typically has an empty podSelector
-			// and no ingress/egress rules, or explicit deny rules
-			if len(policy.Spec.PodSelector.MatchLabels) == 0 &&
-				len(policy.Spec.PodSelector.MatchExpressions) == 0 {
-				// Check if it denies ingress or egress
-				if len(policy.Spec.Ingress) == 0 || len(policy.Spec.Egress) == 0 {
-					hasDefaultDeny = true
-					break
+		requireDefaultDeny, requireDNSEgress := network.NamespaceRequirements(ns.Name)
+		if !requireDefaultDeny && !requireDNSEgress {
+			continue
+		}
+		audit.audited++
+
+		nsPolicies := policiesByNamespace[ns.Name]
+		if requireDefaultDeny && !hasDefaultDeny(nsPolicies) {
+			audit.withoutDefaultDeny = append(audit.withoutDefaultDeny, ns.Name)
+			audit.findings[ns.Name] = append(audit.findings[ns.Name], fmt.Sprintf(
+				"no NetworkPolicy selecting all pods denies ingress or egress by default (%d policies)", len(nsPolicies)))
+		}
+		if requireDNSEgress {
+			if blocking := dnsBlockingPolicies(nsPolicies); len(blocking) > 0 {
+				audit.withoutDNSEgress = append(audit.withoutDNSEgress, ns.Name)
+				for _, name := range blocking {
+					audit.findings[ns.Name] = append(audit.findings[ns.Name], fmt.Sprintf(
+						"NetworkPolicy %s restricts egress without allowing DNS (UDP port 53)", name))
 				}
 			}
 		}
+	}
 
-		if !hasDefaultDeny {
-			namespacesWithoutDefaultDeny = append(namespacesWithoutDefaultDeny, ns.Name)
+	return audit, nil
+}
+
+// checkRequiredPolicies returns the required policies missing from the cluster.
+func (c *NetworkPolicyCheck) checkRequiredPolicies(policies []networkingv1.NetworkPolicy, requiredPolicies []spec.RequiredPolicy) []string {
+	allPolicies := make(map[string]bool)
+	for _, policy := range policies {
+		allPolicies[policy.Name] = true
+	}
+
+	// Check which required policies are missing
+	var missingPolicies []string
+	for _, required := range requiredPolicies {
+		if !allPolicies[required.Name] {
+			missingPolicies = append(missingPolicies, required.Name)
 		}
 	}
 
-	return namespacesWithoutDefaultDeny, nil
+	return missingPolicies
 }
 
-// checkRequiredPolicies checks if all required policies exist.
-func (c *NetworkPolicyCheck) checkRequiredPolicies(ctx context.Context, client kubernetes.Interface, requiredPolicies []spec.RequiredPolicy) ([]string, error) {
-	// Get all network policies across all namespaces
-	allPolicies := make(map[string]bool)
+// hasDefaultDeny reports whether any policy selects every pod in the namespace
+// and allows no ingress or no egress.
+func hasDefaultDeny(policies []networkingv1.NetworkPolicy) bool {
+	for _, policy := range policies {
+		if !selectsAllPods(policy) {
+			continue
+		}
+		if appliesTo(policy, networkingv1.PolicyTypeIngress) && len(policy.Spec.Ingress) == 0 {
+			return true
+		}
+		if appliesTo(policy, networkingv1.PolicyTypeEgress) && len(policy.Spec.Egress) == 0 {
+			return true
+		}
+	}
+	return false
+}
 
-	namespaces, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+// dnsBlockingPolicies returns the policies that restrict egress for their pods
+// without DNS being allowed to them, either by the policy itself or by a policy
+// selecting every pod in the namespace.
+func dnsBlockingPolicies(policies []networkingv1.NetworkPolicy) []string {
+	for _, policy := range policies {
+		if selectsAllPods(policy) && appliesTo(policy, networkingv1.PolicyTypeEgress) && allowsDNSEgress(policy) {
+			return nil
+		}
 	}
 
-	for _, ns := range namespaces.Items {
-		policies, err := client.NetworkingV1().NetworkPolicies(ns.Name).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			continue // Skip namespaces we can't read
+	var blocking []string
+	for _, policy := range policies {
+		if appliesTo(policy, networkingv1.PolicyTypeEgress) && !allowsDNSEgress(policy) {
+			blocking = append(blocking, policy.Name)
 		}
+	}
+	return blocking
+}
 
-		for _, policy := range policies.Items {
-			allPolicies[policy.Name] = true
+// selectsAllPods reports whether a policy's pod selector is empty.
+func selectsAllPods(policy networkingv1.NetworkPolicy) bool {
+	return len(policy.Spec.PodSelector.MatchLabels) == 0 && len(policy.Spec.PodSelector.MatchExpressions) == 0
+}
+
+// appliesTo reports whether a policy restricts traffic in a direction. Without
+// policyTypes, a policy always applies to ingress and applies to egress when it
+// has egress rules.
+func appliesTo(policy networkingv1.NetworkPolicy, policyType networkingv1.PolicyType) bool {
+	if len(policy.Spec.PolicyTypes) == 0 {
+		return policyType == networkingv1.PolicyTypeIngress || len(policy.Spec.Egress) > 0
+	}
+	for _, t := range policy.Spec.PolicyTypes {
+		if t == policyType {
+			return true
 		}
 	}
+	return false
+}
 
-	// Check which required policies are missing
-	var missingPolicies []string
-	for _, required := range requiredPolicies {
-		if !allPolicies[required.Name] {
-			missingPolicies = append(missingPolicies, required.Name)
+// allowsDNSEgress reports whether any egress rule of a policy allows UDP port 53
+// to another namespace or address. Rules limited to pods in the policy's own
+// namespace cannot reach cluster DNS.
+func allowsDNSEgress(policy networkingv1.NetworkPolicy) bool {
+	for _, rule := range policy.Spec.Egress {
+		if !allowsDNSPort(rule.Ports) {
+			continue
+		}
+		if len(rule.To) == 0 {
+			return true
+		}
+		for _, peer := range rule.To {
+			if peer.NamespaceSelector != nil || peer.IPBlock != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// allowsDNSPort reports whether a rule's ports include UDP port 53. A rule
+// without ports allows every port.
+func allowsDNSPort(ports []networkingv1.NetworkPolicyPort) bool {
+	if len(ports) == 0 {
+		return true
+	}
+	for _, port := range ports {
+		// The protocol defaults to TCP
+		if port.Protocol == nil || *port.Protocol != corev1.ProtocolUDP {
+			continue
+		}
+		if port.Port == nil {
+			return true
+		}
+		if port.Port.Type == intstr.String {
+			// CoreDNS and kube-dns name their DNS port "dns"
+			if port.Port.StrVal == "dns" {
+				return true
+			}
+			continue
+		}
+		end := port.Port.IntVal
+		if port.EndPort != nil {
+			end = *port.EndPort
+		}
+		if port.Port.IntVal <= 53 && 53 <= end {
+			return true
+		}
+	}
+	return false
+}
+
+// buildRemediationAction generates a machine-actionable remediation. Missing default-deny
+// and DNS egress policies can be created directly; missing named policies need to be
+// written by hand.
+func (c *NetworkPolicyCheck) buildRemediationAction(namespacesWithoutDefaultDeny, namespacesWithoutDNSEgress []string) *scanner.RemediationAction {
+	if len(namespacesWithoutDefaultDeny) == 0 && len(namespacesWithoutDNSEgress) == 0 {
+		return &scanner.RemediationAction{
+			Type:        scanner.RemediationTypeDoc,
+			Payload:     "https://kubernetes.io/docs/concepts/services-networking/network-policies/",
+			Description: "Create the required NetworkPolicies",
 		}
 	}
 
-	return missingPolicies, nil
+	var commands, fixes []string
+	for _, ns := range namespacesWithoutDefaultDeny {
+		commands = append(commands, fmt.Sprintf(`kubectl apply -f - <<EOF
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: default-deny-all
+  namespace: %s
+spec:
+  podSelector: {}
+  policyTypes:
+  - Ingress
+  - Egress
+EOF`, ns))
+	}
+	for _, ns := range namespacesWithoutDNSEgress {
+		commands = append(commands, fmt.Sprintf(`kubectl apply -f - <<EOF
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: allow-dns
+  namespace: %s
+spec:
+  podSelector: {}
+  policyTypes:
+  - Egress
+  egress:
+  - to:
+    - namespaceSelector: {}
+    ports:
+    - protocol: UDP
+      port: 53
+EOF`, ns))
+	}
+
+	if len(namespacesWithoutDefaultDeny) > 0 {
+		fixes = append(fixes, fmt.Sprintf("a default-deny NetworkPolicy in %d namespaces", len(namespacesWithoutDefaultDeny)))
+	}
+	if len(namespacesWithoutDNSEgress) > 0 {
+		fixes = append(fixes, fmt.Sprintf("a DNS egress NetworkPolicy in %d namespaces", len(namespacesWithoutDNSEgress)))
+	}
+
+	return &scanner.RemediationAction{
+		Type:        scanner.RemediationTypeCommand,
+		Payload:     strings.Join(commands, "\n"),
+		Description: "Create " + strings.Join(fixes, " and "),
+	}
 }
 
 // buildRemediation generates remediation guidance.
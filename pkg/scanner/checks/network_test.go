@@ -11,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/fake"
 )
 
@@ -93,6 +94,9 @@ func TestNetworkPolicyCheck_FailMissingDefaultDeny(t *testing.T) {
 	assert.Contains(t, result.Message, "violations")
 	assert.NotEmpty(t, result.Remediation)
 	assert.Contains(t, result.Evidence, "namespaces_without_default_deny")
+	require.NotNil(t, result.RemediationAction)
+	assert.Equal(t, scanner.RemediationTypeCommand, result.RemediationAction.Type)
+	assert.Contains(t, result.RemediationAction.Payload, "namespace: app-1")
 }
 
 func TestNetworkPolicyCheck_FailMissingRequiredPolicy(t *testing.T) {
@@ -128,6 +132,8 @@ func TestNetworkPolicyCheck_FailMissingRequiredPolicy(t *testing.T) {
 	assert.Equal(t, scanner.StatusFail, result.Status)
 	assert.Contains(t, result.Message, "violations")
 	assert.Contains(t, result.Evidence, "missing_required_policies")
+	require.NotNil(t, result.RemediationAction)
+	assert.Equal(t, scanner.RemediationTypeDoc, result.RemediationAction.Type)
 }
 
 func TestNetworkPolicyCheck_PassWithRequiredPolicy(t *testing.T) {
@@ -194,6 +200,7 @@ func TestNetworkPolicyCheck_Skip(t *testing.T) {
 	// Assert
 	require.NoError(t, err)
 	assert.Equal(t, scanner.StatusSkip, result.Status)
+	assert.Equal(t, scanner.SkipReasonSpecSectionAbsent, result.SkipReason)
 	assert.Contains(t, result.Message, "not specified")
 }
 
@@ -252,3 +259,158 @@ func TestNetworkPolicyCheck_SystemNamespacesIgnored(t *testing.T) {
 	assert.Equal(t, scanner.StatusPass, result.Status)
 	// kube-system should be ignored, only app-1 checked
 }
+
+// denyAllPolicy returns a policy denying all ingress and egress in a namespace.
+func denyAllPolicy(namespace string) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-deny-all", Namespace: namespace},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+		},
+	}
+}
+
+// allowDNSPolicy returns a policy allowing DNS egress for all pods in a namespace.
+func allowDNSPolicy(namespace string) *networkingv1.NetworkPolicy {
+	udp := corev1.ProtocolUDP
+	port := intstr.FromInt(53)
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-dns", Namespace: namespace},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress: []networkingv1.NetworkPolicyEgressRule{{
+				To:    []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}},
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: &udp, Port: &port}},
+			}},
+		},
+	}
+}
+
+func TestNetworkPolicyCheck_DNSEgress(t *testing.T) {
+	check := &NetworkPolicyCheck{}
+	client := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "locked"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "resolving"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "open"}},
+		denyAllPolicy("locked"),
+		denyAllPolicy("resolving"),
+		allowDNSPolicy("resolving"),
+	)
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Network: &spec.NetworkSpec{RequireDNSEgress: true},
+		},
+	}
+
+	result, err := check.Run(context.Background(), client, clusterSpec)
+
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	// "open" does not restrict egress at all, so DNS is allowed
+	assert.Equal(t, []string{"locked"}, result.Evidence["namespaces_without_dns_egress"])
+	findings := result.Evidence["namespace_findings"].(map[string][]string)
+	assert.Equal(t, []string{"NetworkPolicy default-deny-all restricts egress without allowing DNS (UDP port 53)"}, findings["locked"])
+	assert.Equal(t, 3, result.Evidence["namespaces_audited"])
+	require.NotNil(t, result.RemediationAction)
+	assert.Equal(t, scanner.RemediationTypeCommand, result.RemediationAction.Type)
+	assert.Contains(t, result.RemediationAction.Payload, "name: allow-dns\n  namespace: locked")
+}
+
+func TestNetworkPolicyCheck_DNSEgressPortRules(t *testing.T) {
+	tcp := corev1.ProtocolTCP
+	udp := corev1.ProtocolUDP
+	dnsPort := intstr.FromInt(53)
+	namedPort := intstr.FromString("dns")
+	rangeStart := intstr.FromInt(1)
+	rangeEnd := int32(1024)
+
+	tests := []struct {
+		name string
+		rule networkingv1.NetworkPolicyEgressRule
+		want bool
+	}{
+		{name: "all traffic", rule: networkingv1.NetworkPolicyEgressRule{}, want: true},
+		{name: "udp 53", rule: networkingv1.NetworkPolicyEgressRule{Ports: []networkingv1.NetworkPolicyPort{{Protocol: &udp, Port: &dnsPort}}}, want: true},
+		{name: "named dns port", rule: networkingv1.NetworkPolicyEgressRule{Ports: []networkingv1.NetworkPolicyPort{{Protocol: &udp, Port: &namedPort}}}, want: true},
+		{name: "udp port range", rule: networkingv1.NetworkPolicyEgressRule{Ports: []networkingv1.NetworkPolicyPort{{Protocol: &udp, Port: &rangeStart, EndPort: &rangeEnd}}}, want: true},
+		{name: "tcp 53 only", rule: networkingv1.NetworkPolicyEgressRule{Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &dnsPort}}}, want: false},
+		{name: "protocol defaults to tcp", rule: networkingv1.NetworkPolicyEgressRule{Ports: []networkingv1.NetworkPolicyPort{{Port: &dnsPort}}}, want: false},
+		{
+			name: "own namespace only",
+			rule: networkingv1.NetworkPolicyEgressRule{
+				To:    []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}},
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: &udp, Port: &dnsPort}},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := networkingv1.NetworkPolicy{
+				Spec: networkingv1.NetworkPolicySpec{
+					PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+					Egress:      []networkingv1.NetworkPolicyEgressRule{tt.rule},
+				},
+			}
+			assert.Equal(t, tt.want, allowsDNSEgress(policy))
+		})
+	}
+}
+
+func TestNetworkPolicyCheck_DefaultDenyRequiresEmptyDirection(t *testing.T) {
+	check := &NetworkPolicyCheck{}
+	// Selects all pods but allows all ingress and does not restrict egress
+	allowAll := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-all", Namespace: "app-1"},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     []networkingv1.NetworkPolicyIngressRule{{}},
+		},
+	}
+	client := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app-1"}}, allowAll)
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{Network: &spec.NetworkSpec{DefaultDeny: true}},
+	}
+
+	result, err := check.Run(context.Background(), client, clusterSpec)
+
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	assert.Equal(t, []string{"app-1"}, result.Evidence["namespaces_without_default_deny"])
+}
+
+func TestNetworkPolicyCheck_NamespaceOverrides(t *testing.T) {
+	check := &NetworkPolicyCheck{}
+	enabled, disabled := true, false
+	client := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ingress"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "app-1"}},
+		denyAllPolicy("app-1"),
+	)
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Network: &spec.NetworkSpec{
+				DefaultDeny: true,
+				NamespaceOverrides: []spec.NetworkNamespaceOverride{
+					// The ingress controller namespace accepts traffic from anywhere
+					{Namespace: "ingress", DefaultDeny: &disabled},
+					// System namespaces are only audited when overridden
+					{Namespace: "kube-system", RequireDNSEgress: &enabled},
+				},
+			},
+		},
+	}
+
+	result, err := check.Run(context.Background(), client, clusterSpec)
+
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	assert.Equal(t, []string{"kube-system"}, result.Evidence["namespaces_without_default_deny"])
+	assert.NotContains(t, result.Evidence, "namespaces_without_dns_egress")
+	assert.Equal(t, 2, result.Evidence["namespaces_audited"])
+}
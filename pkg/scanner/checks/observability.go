@@ -19,14 +19,45 @@ func (c *ObservabilityCheck) Name() string {
 	return "observability.validation"
 }
 
+// Description explains what the check verifies and why.
+func (c *ObservabilityCheck) Description() string {
+	return "Checks that metrics and audit logging are in place.\n\n" +
+		"With observability.metrics.required one of the configured metrics providers (e.g. metrics-server or Prometheus) must be running, and with observability.logging.auditLog.required an audit policy ConfigMap must exist. Audit log retention cannot be verified from cluster state and is reported for manual review."
+}
+
+// SpecFields returns the spec fields the check reads.
+func (c *ObservabilityCheck) SpecFields() []string {
+	return []string{"observability.metrics", "observability.logging.auditLog"}
+}
+
+// Severity returns the severity assigned to failures.
+func (c *ObservabilityCheck) Severity() scanner.Severity {
+	return scanner.SeverityMedium
+}
+
+// Remediation returns an example fix for failures.
+func (c *ObservabilityCheck) Remediation() string {
+	return `Review and fix observability violations:
+1. Install metrics server:
+   kubectl apply -f https://github.com/kubernetes-sigs/metrics-server/releases/latest/download/components.yaml
+2. Install Prometheus:
+   helm install prometheus prometheus-community/prometheus --namespace monitoring --create-namespace
+3. Configure audit logging on the API server
+4. Ensure audit log retention meets requirements
+
+Verify metrics server:
+kubectl top nodes`
+}
+
 // Run executes the observability check.
 func (c *ObservabilityCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.CheckResult, error) {
 	// Skip if not specified
 	if clusterSpec.Spec.Observability == nil {
 		return &scanner.CheckResult{
-			Name:    c.Name(),
-			Status:  scanner.StatusSkip,
-			Message: "Observability requirements not specified in cluster spec",
+			Name:       c.Name(),
+			Status:     scanner.StatusSkip,
+			SkipReason: scanner.SkipReasonSpecSectionAbsent,
+			Message:    "Observability requirements not specified in cluster spec",
 		}, nil
 	}
 
@@ -58,21 +89,12 @@ func (c *ObservabilityCheck) Run(ctx context.Context, client kubernetes.Interfac
 		evidence["violation_count"] = len(violations)
 
 		return &scanner.CheckResult{
-			Name:     c.Name(),
-			Status:   scanner.StatusFail,
-			Severity: scanner.SeverityMedium,
-			Message:  fmt.Sprintf("Found %d observability violations", len(violations)),
-			Evidence: evidence,
-			Remediation: `Review and fix observability violations:
-1. Install metrics server:
-   kubectl apply -f https://github.com/kubernetes-sigs/metrics-server/releases/latest/download/components.yaml
-2. Install Prometheus:
-   helm install prometheus prometheus-community/prometheus --namespace monitoring --create-namespace
-3. Configure audit logging on the API server
-4. Ensure audit log retention meets requirements
-
-Verify metrics server:
-kubectl top nodes`,
+			Name:        c.Name(),
+			Status:      scanner.StatusFail,
+			Severity:    scanner.SeverityMedium,
+			Message:     fmt.Sprintf("Found %d observability violations", len(violations)),
+			Evidence:    evidence,
+			Remediation: c.Remediation(),
 		}, nil
 	}
 
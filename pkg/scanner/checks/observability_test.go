@@ -204,6 +204,7 @@ func TestObservabilityCheck_Skip(t *testing.T) {
 	result, err := check.Run(context.Background(), client, clusterSpec)
 	assert.NoError(t, err)
 	assert.Equal(t, scanner.StatusSkip, result.Status)
+	assert.Equal(t, scanner.SkipReasonSpecSectionAbsent, result.SkipReason)
 }
 
 func TestObservabilityCheck_Name(t *testing.T) {
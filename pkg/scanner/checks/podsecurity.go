@@ -7,7 +7,6 @@ import (
 
 	"github.com/cloudcwfranck/kspec/pkg/scanner"
 	"github.com/cloudcwfranck/kspec/pkg/spec"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -26,21 +25,43 @@ func (c *PodSecurityStandardsCheck) Name() string {
 	return "podsecurity.standards"
 }
 
+// Description explains what the check verifies and why.
+func (c *PodSecurityStandardsCheck) Description() string {
+	return "Checks that namespaces enforce the required Pod Security Standards levels.\n\n" +
+		"Every non-system namespace must carry pod-security.kubernetes.io enforce, audit and warn labels set to podSecurity.enforce, podSecurity.audit and podSecurity.warn. Namespaces listed in podSecurity.exemptions must enforce the exemption level instead."
+}
+
+// SpecFields returns the spec fields the check reads.
+func (c *PodSecurityStandardsCheck) SpecFields() []string {
+	return []string{"podSecurity.enforce", "podSecurity.audit", "podSecurity.warn", "podSecurity.exemptions"}
+}
+
+// Severity returns the severity assigned to failures.
+func (c *PodSecurityStandardsCheck) Severity() scanner.Severity {
+	return scanner.SeverityHigh
+}
+
+// Remediation returns an example fix for failures.
+func (c *PodSecurityStandardsCheck) Remediation() string {
+	return c.Playbook()
+}
+
 // Run executes the Pod Security Standards check.
 func (c *PodSecurityStandardsCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.CheckResult, error) {
 	// Skip check if Pod Security Standards are not specified
 	if clusterSpec.Spec.PodSecurity == nil {
 		return &scanner.CheckResult{
-			Name:    c.Name(),
-			Status:  scanner.StatusSkip,
-			Message: "Pod Security Standards not specified in cluster spec",
+			Name:       c.Name(),
+			Status:     scanner.StatusSkip,
+			SkipReason: scanner.SkipReasonSpecSectionAbsent,
+			Message:    "Pod Security Standards not specified in cluster spec",
 		}, nil
 	}
 
 	pss := clusterSpec.Spec.PodSecurity
 
 	// Get all namespaces
-	namespaces, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	namespaces, err := scanner.ListNamespaces(ctx, client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list namespaces: %w", err)
 	}
@@ -141,6 +162,38 @@ func (c *PodSecurityStandardsCheck) Run(ctx context.Context, client kubernetes.I
 	}, nil
 }
 
+// Playbook returns a step-by-step remediation playbook for failures.
+func (c *PodSecurityStandardsCheck) Playbook() string {
+	return `Step-by-step: enforce Pod Security Standards
+
+1. Preview the impact before enforcing by labelling with audit/warn only:
+   kubectl label namespace <namespace> \
+     pod-security.kubernetes.io/audit=restricted \
+     pod-security.kubernetes.io/warn=restricted
+
+2. Fix workloads that trigger warnings (see the workload.security playbook).
+
+3. Enforce the level required by the spec:
+   kubectl label --overwrite namespace <namespace> \
+     pod-security.kubernetes.io/enforce=restricted
+
+   Or declaratively:
+
+   apiVersion: v1
+   kind: Namespace
+   metadata:
+     name: <namespace>
+     labels:
+       pod-security.kubernetes.io/enforce: restricted
+       pod-security.kubernetes.io/audit: restricted
+       pod-security.kubernetes.io/warn: restricted
+
+4. For namespaces that legitimately need a lower level (e.g. node agents), add an
+   exemption with a reason to the spec instead of removing the labels.
+
+5. Re-run kspec scan.`
+}
+
 // buildRemediation generates remediation guidance.
 func (c *PodSecurityStandardsCheck) buildRemediation(pss *spec.PodSecuritySpec, violations []string) string {
 	remediation := fmt.Sprintf(
@@ -207,6 +207,7 @@ func TestPodSecurityStandardsCheck_Skip(t *testing.T) {
 	// Assert
 	require.NoError(t, err)
 	assert.Equal(t, scanner.StatusSkip, result.Status)
+	assert.Equal(t, scanner.SkipReasonSpecSectionAbsent, result.SkipReason)
 	assert.Contains(t, result.Message, "not specified")
 }
 
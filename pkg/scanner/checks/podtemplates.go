@@ -0,0 +1,149 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// podTemplateWorkload is a controller whose pod template is checked in place
+// of the pods it creates.
+type podTemplateWorkload struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Template  corev1.PodTemplateSpec
+}
+
+// Key identifies the workload in violations, e.g. "Deployment default/web".
+func (w podTemplateWorkload) Key() string {
+	return workloadKey(w.Kind, w.Namespace, w.Name)
+}
+
+// Pod returns a pod built from the workload's pod template.
+func (w podTemplateWorkload) Pod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        w.Name,
+			Namespace:   w.Namespace,
+			Labels:      w.Template.Labels,
+			Annotations: w.Template.Annotations,
+		},
+		Spec: w.Template.Spec,
+	}
+}
+
+// workloadKey formats a workload reference as "<Kind> <namespace>/<name>".
+func workloadKey(kind, namespace, name string) string {
+	return fmt.Sprintf("%s %s/%s", kind, namespace, name)
+}
+
+// listPodTemplateWorkloads returns the Deployments, StatefulSets, DaemonSets,
+// CronJobs and Jobs outside system namespaces. Jobs created by a CronJob are
+// left to the CronJob, and finished Jobs are skipped when the spec ignores the
+// matching pod phase.
+func listPodTemplateWorkloads(ctx context.Context, client kubernetes.Interface, workloads *spec.WorkloadsSpec) ([]podTemplateWorkload, error) {
+	var result []podTemplateWorkload
+	add := func(kind string, meta metav1.ObjectMeta, template corev1.PodTemplateSpec) {
+		if isSystemNamespace(meta.Namespace) {
+			return
+		}
+		result = append(result, podTemplateWorkload{Kind: kind, Namespace: meta.Namespace, Name: meta.Name, Template: template})
+	}
+
+	deployments, err := scanner.ListDeployments(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		add("Deployment", deployment.ObjectMeta, deployment.Spec.Template)
+	}
+
+	statefulSets, err := scanner.ListStatefulSets(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		add("StatefulSet", statefulSet.ObjectMeta, statefulSet.Spec.Template)
+	}
+
+	daemonSets, err := scanner.ListDaemonSets(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, daemonSet := range daemonSets.Items {
+		add("DaemonSet", daemonSet.ObjectMeta, daemonSet.Spec.Template)
+	}
+
+	cronJobs, err := client.BatchV1().CronJobs("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cronjobs: %w", err)
+	}
+	cronJobKeys := map[string]bool{}
+	for _, cronJob := range cronJobs.Items {
+		add("CronJob", cronJob.ObjectMeta, cronJob.Spec.JobTemplate.Spec.Template)
+		cronJobKeys[workloadKey("CronJob", cronJob.Namespace, cronJob.Name)] = true
+	}
+
+	jobs, err := client.BatchV1().Jobs("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if owner := metav1.GetControllerOf(job); owner != nil && cronJobKeys[workloadKey(owner.Kind, job.Namespace, owner.Name)] {
+			continue
+		}
+		if phase := jobPhase(job); phase != "" && workloads.IsPodPhaseIgnored(string(phase)) {
+			continue
+		}
+		add("Job", job.ObjectMeta, job.Spec.Template)
+	}
+
+	return result, nil
+}
+
+// podControllerKey returns the key of the workload that owns a pod, or "" for
+// pods without a supported controller. Pods of a Deployment are owned through
+// a ReplicaSet named after the Deployment and the pod-template-hash label.
+func podControllerKey(pod *corev1.Pod) string {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return ""
+	}
+
+	switch owner.Kind {
+	case "ReplicaSet":
+		hash := pod.Labels["pod-template-hash"]
+		if hash == "" || !strings.HasSuffix(owner.Name, "-"+hash) {
+			return ""
+		}
+		return workloadKey("Deployment", pod.Namespace, strings.TrimSuffix(owner.Name, "-"+hash))
+	case "StatefulSet", "DaemonSet", "Job":
+		return workloadKey(owner.Kind, pod.Namespace, owner.Name)
+	}
+	return ""
+}
+
+// jobPhase maps a finished Job to the phase of its pods, or "" while it runs.
+func jobPhase(job *batchv1.Job) corev1.PodPhase {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return corev1.PodSucceeded
+		case batchv1.JobFailed:
+			return corev1.PodFailed
+		}
+	}
+	return ""
+}
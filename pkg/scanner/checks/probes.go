@@ -0,0 +1,171 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ProbesCheck validates that app containers define the required health probes.
+type ProbesCheck struct{}
+
+// Name returns the check name.
+func (c *ProbesCheck) Name() string {
+	return "workload.probes"
+}
+
+// Description explains what the check verifies and why.
+func (c *ProbesCheck) Description() string {
+	return "Checks that app containers define the required health probes.\n\n" +
+		"Without a liveness probe hung containers are never restarted, and without a readiness probe traffic is sent to containers that are not ready yet."
+}
+
+// SpecFields returns the spec fields the check reads.
+func (c *ProbesCheck) SpecFields() []string {
+	return []string{"workloads.requireLiveness", "workloads.requireReadiness", "workloads.includeInitContainers", "workloads.excludeContainers", "workloads.ignorePhases"}
+}
+
+// Severity returns the severity assigned to failures.
+func (c *ProbesCheck) Severity() scanner.Severity {
+	return scanner.SeverityMedium
+}
+
+// Remediation returns an example fix for failures.
+func (c *ProbesCheck) Remediation() string {
+	return `Add the required health probes to every app container:
+1. livenessProbe lets the kubelet restart hung containers
+2. readinessProbe keeps traffic away from containers that are not ready
+
+Example:
+  livenessProbe:
+    httpGet:
+      path: /healthz
+      port: 8080
+  readinessProbe:
+    httpGet:
+      path: /ready
+      port: 8080`
+}
+
+// Run executes the probes check.
+func (c *ProbesCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.CheckResult, error) {
+	workloads := clusterSpec.Spec.Workloads
+
+	// Skip if not specified
+	if workloads == nil || (!workloads.RequireLiveness && !workloads.RequireReadiness) {
+		return &scanner.CheckResult{
+			Name:       c.Name(),
+			Status:     scanner.StatusSkip,
+			SkipReason: scanner.SkipReasonSpecSectionAbsent,
+			Message:    "Probe requirements not specified in cluster spec",
+		}, nil
+	}
+
+	// Get all pods
+	pods, err := scanner.ListPods(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	violations := []string{}
+	violatingPods := []string{}
+	violatingContainers := []string{}
+	totalPods := 0
+
+	for _, pod := range pods.Items {
+		// Skip system namespaces
+		if isSystemNamespace(pod.Namespace) {
+			continue
+		}
+
+		// Skip terminal pods the spec ignores, e.g. completed Jobs
+		if workloads.IsPodPhaseIgnored(string(pod.Status.Phase)) {
+			continue
+		}
+
+		totalPods++
+		podViolations, podContainers := checkPodProbes(&pod, workloads)
+		if len(podViolations) > 0 {
+			violations = append(violations, podViolations...)
+			violatingPods = append(violatingPods, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+			violatingContainers = append(violatingContainers, podContainers...)
+		}
+	}
+
+	if len(violations) > 0 {
+		return &scanner.CheckResult{
+			Name:     c.Name(),
+			Status:   scanner.StatusFail,
+			Severity: scanner.SeverityMedium,
+			Message:  fmt.Sprintf("Found %d containers missing required probes across %d pods", len(violations), len(violatingPods)),
+			Evidence: map[string]interface{}{
+				"violations":           violations,
+				"violating_pods":       violatingPods,
+				"violating_containers": violatingContainers,
+				"violation_count":      len(violations),
+			},
+			Remediation: c.Remediation(),
+		}, nil
+	}
+
+	return &scanner.CheckResult{
+		Name:    c.Name(),
+		Status:  scanner.StatusPass,
+		Message: fmt.Sprintf("All %d pods define the required probes", totalPods),
+		Evidence: map[string]interface{}{
+			"total_pods": totalPods,
+		},
+	}, nil
+}
+
+// checkPodProbes returns one violation per app container missing a required probe,
+// along with the namespace/pod/container of each offending container. Init
+// containers run to completion before probes apply, so only sidecar init
+// containers are checked, and only with workloads.includeInitContainers.
+func checkPodProbes(pod *corev1.Pod, workloads *spec.WorkloadsSpec) ([]string, []string) {
+	violations := []string{}
+	containers := []string{}
+	podKey := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+
+	check := func(container corev1.Container, containerKey string) {
+		if workloads.IsContainerExcluded(container.Name) {
+			return
+		}
+
+		missing := []string{}
+		if workloads.RequireLiveness && container.LivenessProbe == nil {
+			missing = append(missing, "livenessProbe")
+		}
+		if workloads.RequireReadiness && container.ReadinessProbe == nil {
+			missing = append(missing, "readinessProbe")
+		}
+
+		if len(missing) > 0 {
+			violations = append(violations, fmt.Sprintf("%s: missing %v", containerKey, missing))
+			containers = append(containers, fmt.Sprintf("%s/%s", podKey, container.Name))
+		}
+	}
+
+	if workloads.IncludeInitContainers {
+		for i, container := range pod.Spec.InitContainers {
+			if isSidecarContainer(container) {
+				check(container, fmt.Sprintf("%s[init %d]:%s", podKey, i, container.Name))
+			}
+		}
+	}
+	for i, container := range pod.Spec.Containers {
+		check(container, fmt.Sprintf("%s[%d]:%s", podKey, i, container.Name))
+	}
+
+	return violations, containers
+}
+
+// isSidecarContainer reports whether an init container is a sidecar, i.e. it
+// keeps running alongside the app containers and may define probes.
+func isSidecarContainer(container corev1.Container) bool {
+	return container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways
+}
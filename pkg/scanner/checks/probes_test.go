@@ -0,0 +1,173 @@
+package checks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestProbesCheck_Pass(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "probed-pod",
+			Namespace: "default",
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Name: "init", Image: "busybox"},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:           "app",
+					Image:          "nginx",
+					LivenessProbe:  &corev1.Probe{PeriodSeconds: 10},
+					ReadinessProbe: &corev1.Probe{PeriodSeconds: 10},
+				},
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset(pod)
+	check := &ProbesCheck{}
+
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{
+				RequireLiveness:  true,
+				RequireReadiness: true,
+			},
+		},
+	}
+
+	result, err := check.Run(context.Background(), client, clusterSpec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+	assert.Equal(t, "workload.probes", result.Name)
+}
+
+func TestProbesCheck_Fail(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "unprobed-pod",
+			Namespace: "default",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:          "app",
+					Image:         "nginx",
+					LivenessProbe: &corev1.Probe{PeriodSeconds: 10},
+				},
+			},
+		},
+	}
+
+	// Pods in system namespaces are ignored
+	systemPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "coredns",
+			Namespace: "kube-system",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "coredns", Image: "coredns"},
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset(pod, systemPod)
+	check := &ProbesCheck{}
+
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{
+				RequireLiveness:  true,
+				RequireReadiness: true,
+			},
+		},
+	}
+
+	result, err := check.Run(context.Background(), client, clusterSpec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	assert.Equal(t, scanner.SeverityMedium, result.Severity)
+	assert.Equal(t, 1, result.Evidence["violation_count"])
+	assert.Equal(t, []string{"default/unprobed-pod"}, result.Evidence["violating_pods"])
+	assert.Equal(t, []string{"default/unprobed-pod/app"}, result.Evidence["violating_containers"])
+	assert.Contains(t, result.Evidence["violations"].([]string)[0], "readinessProbe")
+}
+
+func TestProbesCheck_InitContainers(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sidecar-pod",
+			Namespace: "default",
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Name: "migrate", Image: "busybox"},
+				{Name: "proxy", Image: "envoy", RestartPolicy: &always},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:          "app",
+					Image:         "nginx",
+					LivenessProbe: &corev1.Probe{PeriodSeconds: 10},
+				},
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset(pod)
+	check := &ProbesCheck{}
+
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{
+				RequireLiveness: true,
+			},
+		},
+	}
+
+	// Init containers are not checked by default
+	result, err := check.Run(context.Background(), client, clusterSpec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+	assert.Equal(t, "All 1 pods define the required probes", result.Message)
+
+	// Only sidecar init containers can define probes
+	clusterSpec.Spec.Workloads.IncludeInitContainers = true
+	result, err = check.Run(context.Background(), client, clusterSpec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	assert.Equal(t, []string{"default/sidecar-pod/proxy"}, result.Evidence["violating_containers"])
+	assert.Contains(t, result.Evidence["violations"].([]string)[0], "default/sidecar-pod[init 1]:proxy")
+}
+
+func TestProbesCheck_Skip(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	check := &ProbesCheck{}
+
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{},
+		},
+	}
+
+	result, err := check.Run(context.Background(), client, clusterSpec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusSkip, result.Status)
+	assert.Equal(t, scanner.SkipReasonSpecSectionAbsent, result.SkipReason)
+}
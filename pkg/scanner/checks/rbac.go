@@ -8,10 +8,12 @@ import (
 	"github.com/cloudcwfranck/kspec/pkg/scanner"
 	"github.com/cloudcwfranck/kspec/pkg/spec"
 	rbacv1 "k8s.io/api/rbac/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
+// clusterAdminRole is the built-in ClusterRole granting full cluster access.
+const clusterAdminRole = "cluster-admin"
+
 // RBACCheck validates RBAC requirements.
 type RBACCheck struct{}
 
@@ -20,14 +22,47 @@ func (c *RBACCheck) Name() string {
 	return "rbac.validation"
 }
 
+// Description explains what the check verifies and why.
+func (c *RBACCheck) Description() string {
+	return "Checks roles and bindings against the spec's RBAC rules.\n\n" +
+		"No non-system role may grant a rule in rbac.forbiddenRules, some role must grant each rule in rbac.minimumRules, and with rbac.forbidWorkloadClusterAdmin no workload may run as a ServiceAccount with cluster-admin-equivalent permissions."
+}
+
+// SpecFields returns the spec fields the check reads.
+func (c *RBACCheck) SpecFields() []string {
+	return []string{"rbac.forbiddenRules", "rbac.minimumRules", "rbac.forbidWorkloadClusterAdmin"}
+}
+
+// Severity returns the severity assigned to failures.
+func (c *RBACCheck) Severity() scanner.Severity {
+	return scanner.SeverityHigh
+}
+
+// Remediation returns an example fix for failures.
+func (c *RBACCheck) Remediation() string {
+	return `Review and fix RBAC violations:
+1. Remove overly permissive roles (e.g., cluster-admin-like wildcard permissions)
+2. Ensure required minimum RBAC rules exist
+3. Follow principle of least privilege
+4. Audit role bindings regularly
+5. Give workloads dedicated ServiceAccounts bound only to the permissions they need
+
+Example: Remove wildcard permissions:
+kubectl delete clusterrole <role-name>
+
+Example: Create required RBAC role:
+kubectl create role <name> --verb=get,list --resource=serviceaccounts`
+}
+
 // Run executes the RBAC check.
 func (c *RBACCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.CheckResult, error) {
 	// Skip if not specified
 	if clusterSpec.Spec.RBAC == nil {
 		return &scanner.CheckResult{
-			Name:    c.Name(),
-			Status:  scanner.StatusSkip,
-			Message: "RBAC requirements not specified in cluster spec",
+			Name:       c.Name(),
+			Status:     scanner.StatusSkip,
+			SkipReason: scanner.SkipReasonSpecSectionAbsent,
+			Message:    "RBAC requirements not specified in cluster spec",
 		}, nil
 	}
 
@@ -35,12 +70,12 @@ func (c *RBACCheck) Run(ctx context.Context, client kubernetes.Interface, cluste
 	evidence := make(map[string]interface{})
 
 	// Get all ClusterRoles and Roles
-	clusterRoles, err := client.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	clusterRoles, err := scanner.ListClusterRoles(ctx, client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list cluster roles: %w", err)
 	}
 
-	roles, err := client.RbacV1().Roles("").List(ctx, metav1.ListOptions{})
+	roles, err := scanner.ListRoles(ctx, client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list roles: %w", err)
 	}
@@ -55,6 +90,20 @@ func (c *RBACCheck) Run(ctx context.Context, client kubernetes.Interface, cluste
 		violations = append(violations, minimumViolations...)
 	}
 
+	// Check workload service accounts for cluster-admin-equivalent grants
+	if clusterSpec.Spec.RBAC.ForbidWorkloadClusterAdmin {
+		privilegedWorkloads, err := c.checkWorkloadServiceAccounts(ctx, client, clusterRoles.Items, roles.Items)
+		if err != nil {
+			return nil, err
+		}
+		for _, workload := range privilegedWorkloads {
+			violations = append(violations, fmt.Sprintf("Workload %s uses a ServiceAccount with cluster-admin-equivalent permissions", workload))
+		}
+		if len(privilegedWorkloads) > 0 {
+			evidence["privileged_workloads"] = privilegedWorkloads
+		}
+	}
+
 	if len(violations) > 0 {
 		evidence["violations"] = violations
 		evidence["violation_count"] = len(violations)
@@ -62,22 +111,12 @@ func (c *RBACCheck) Run(ctx context.Context, client kubernetes.Interface, cluste
 		evidence["roles_checked"] = len(roles.Items)
 
 		return &scanner.CheckResult{
-			Name:     c.Name(),
-			Status:   scanner.StatusFail,
-			Severity: scanner.SeverityHigh,
-			Message:  fmt.Sprintf("Found %d RBAC violations", len(violations)),
-			Evidence: evidence,
-			Remediation: `Review and fix RBAC violations:
-1. Remove overly permissive roles (e.g., cluster-admin-like wildcard permissions)
-2. Ensure required minimum RBAC rules exist
-3. Follow principle of least privilege
-4. Audit role bindings regularly
-
-Example: Remove wildcard permissions:
-kubectl delete clusterrole <role-name>
-
-Example: Create required RBAC role:
-kubectl create role <name> --verb=get,list --resource=serviceaccounts`,
+			Name:        c.Name(),
+			Status:      scanner.StatusFail,
+			Severity:    scanner.SeverityHigh,
+			Message:     fmt.Sprintf("Found %d RBAC violations", len(violations)),
+			Evidence:    evidence,
+			Remediation: c.Remediation(),
 		}, nil
 	}
 
@@ -92,6 +131,32 @@ kubectl create role <name> --verb=get,list --resource=serviceaccounts`,
 	}, nil
 }
 
+// Playbook returns a step-by-step remediation playbook for failures.
+func (c *RBACCheck) Playbook() string {
+	return `Step-by-step: fix RBAC violations
+
+1. Find who is bound to the overly permissive roles named in the evidence:
+   kubectl get clusterrolebindings,rolebindings -A -o wide | grep <role>
+
+2. Replace wildcard rules with the specific resources and verbs needed:
+
+   apiVersion: rbac.authorization.k8s.io/v1
+   kind: ClusterRole
+   metadata:
+     name: <role>
+   rules:
+   - apiGroups: ["apps"]
+     resources: ["deployments"]
+     verbs: ["get", "list", "watch"]
+
+3. Check the effective permissions of a subject before and after the change:
+   kubectl auth can-i --list --as=system:serviceaccount:<namespace>:<name>
+
+4. Create any required roles from the spec that are missing.
+
+5. Re-run kspec scan and review role bindings regularly.`
+}
+
 // checkForbiddenRules checks for forbidden RBAC rules.
 func (c *RBACCheck) checkForbiddenRules(clusterRoles []rbacv1.ClusterRole, roles []rbacv1.Role, forbiddenRules []spec.RBACRule) []string {
 	violations := []string{}
@@ -265,3 +330,136 @@ func (c *RBACCheck) ruleCovers(rule rbacv1.PolicyRule, required spec.RBACRule) b
 
 	return true
 }
+
+// checkWorkloadServiceAccounts walks pod -> ServiceAccount -> binding -> role and
+// returns every non-system pod whose ServiceAccount holds cluster-admin-equivalent
+// permissions, formatted as "<namespace>/<pod> (serviceaccount: <sa>, binding: <kind>/<name>)".
+func (c *RBACCheck) checkWorkloadServiceAccounts(ctx context.Context, client kubernetes.Interface, clusterRoles []rbacv1.ClusterRole, roles []rbacv1.Role) ([]string, error) {
+	clusterRoleBindings, err := scanner.ListClusterRoleBindings(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster role bindings: %w", err)
+	}
+
+	roleBindings, err := scanner.ListRoleBindings(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role bindings: %w", err)
+	}
+
+	pods, err := scanner.ListPods(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	// Index privileged roles by name
+	privilegedClusterRoles := map[string]bool{clusterAdminRole: true}
+	for _, role := range clusterRoles {
+		if hasWildcardRule(role.Rules) {
+			privilegedClusterRoles[role.Name] = true
+		}
+	}
+	privilegedRoles := make(map[string]bool)
+	for _, role := range roles {
+		if hasWildcardRule(role.Rules) {
+			privilegedRoles[role.Namespace+"/"+role.Name] = true
+		}
+	}
+
+	// Map each privileged binding to the subjects it grants
+	type privilegedBinding struct {
+		name      string
+		namespace string // empty for ClusterRoleBindings
+		subjects  []rbacv1.Subject
+	}
+	bindings := []privilegedBinding{}
+
+	for _, binding := range clusterRoleBindings.Items {
+		if binding.RoleRef.Kind == "ClusterRole" && privilegedClusterRoles[binding.RoleRef.Name] {
+			bindings = append(bindings, privilegedBinding{
+				name:     "ClusterRoleBinding/" + binding.Name,
+				subjects: binding.Subjects,
+			})
+		}
+	}
+
+	for _, binding := range roleBindings.Items {
+		privileged := false
+		switch binding.RoleRef.Kind {
+		case "ClusterRole":
+			privileged = privilegedClusterRoles[binding.RoleRef.Name]
+		case "Role":
+			privileged = privilegedRoles[binding.Namespace+"/"+binding.RoleRef.Name]
+		}
+		if privileged {
+			bindings = append(bindings, privilegedBinding{
+				name:      fmt.Sprintf("RoleBinding/%s/%s", binding.Namespace, binding.Name),
+				namespace: binding.Namespace,
+				subjects:  binding.Subjects,
+			})
+		}
+	}
+
+	workloads := []string{}
+	for _, pod := range pods.Items {
+		// Skip system namespaces
+		if isSystemNamespace(pod.Namespace) {
+			continue
+		}
+
+		serviceAccount := pod.Spec.ServiceAccountName
+		if serviceAccount == "" {
+			serviceAccount = "default"
+		}
+
+		for _, binding := range bindings {
+			if bindingGrantsServiceAccount(binding.subjects, binding.namespace, pod.Namespace, serviceAccount) {
+				workloads = append(workloads, fmt.Sprintf("%s/%s (serviceaccount: %s, binding: %s)",
+					pod.Namespace, pod.Name, serviceAccount, binding.name))
+				break
+			}
+		}
+	}
+
+	return workloads, nil
+}
+
+// hasWildcardRule checks if any rule grants all verbs on all resources in all API groups.
+func hasWildcardRule(rules []rbacv1.PolicyRule) bool {
+	for _, rule := range rules {
+		if containsString(rule.APIGroups, "*") && containsString(rule.Resources, "*") && containsString(rule.Verbs, "*") {
+			return true
+		}
+	}
+	return false
+}
+
+// bindingGrantsServiceAccount checks if a binding's subjects include the given
+// ServiceAccount, either directly or through the system:serviceaccounts groups.
+func bindingGrantsServiceAccount(subjects []rbacv1.Subject, bindingNamespace, namespace, name string) bool {
+	for _, subject := range subjects {
+		switch subject.Kind {
+		case rbacv1.ServiceAccountKind:
+			subjectNamespace := subject.Namespace
+			if subjectNamespace == "" {
+				subjectNamespace = bindingNamespace
+			}
+			if subject.Name == name && subjectNamespace == namespace {
+				return true
+			}
+		case rbacv1.GroupKind:
+			if subject.Name == "system:serviceaccounts" || subject.Name == "system:serviceaccounts:"+namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containsString checks if a slice contains the given string.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/cloudcwfranck/kspec/pkg/scanner"
 	"github.com/cloudcwfranck/kspec/pkg/spec"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
@@ -264,6 +265,7 @@ func TestRBACCheck_Skip(t *testing.T) {
 	result, err := check.Run(context.Background(), client, clusterSpec)
 	assert.NoError(t, err)
 	assert.Equal(t, scanner.StatusSkip, result.Status)
+	assert.Equal(t, scanner.SkipReasonSpecSectionAbsent, result.SkipReason)
 }
 
 func TestRBACCheck_Name(t *testing.T) {
@@ -330,3 +332,156 @@ func TestRBACCheck_MultipleViolations(t *testing.T) {
 	// Should have violations from both roles (wildcard permissions cover minimum rule)
 	assert.Equal(t, 2, len(violations))
 }
+
+func TestRBACCheck_FailWorkloadClusterAdmin(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "default",
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "app-sa",
+		},
+	}
+
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "app-admin",
+		},
+		RoleRef: rbacv1.RoleRef{
+			Kind: "ClusterRole",
+			Name: "cluster-admin",
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      "app-sa",
+				Namespace: "default",
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset(pod, binding)
+	check := &RBACCheck{}
+
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			RBAC: &spec.RBACSpec{
+				ForbidWorkloadClusterAdmin: true,
+			},
+		},
+	}
+
+	result, err := check.Run(context.Background(), client, clusterSpec)
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	workloads := result.Evidence["privileged_workloads"].([]string)
+	assert.Len(t, workloads, 1)
+	assert.Contains(t, workloads[0], "default/app")
+	assert.Contains(t, workloads[0], "serviceaccount: app-sa")
+	assert.Contains(t, workloads[0], "ClusterRoleBinding/app-admin")
+}
+
+func TestRBACCheck_FailWorkloadWildcardRoleBinding(t *testing.T) {
+	// Pod without an explicit ServiceAccount runs as "default"
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "team-a",
+		},
+	}
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "everything",
+			Namespace: "team-a",
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{"*"},
+				Resources: []string{"*"},
+				Verbs:     []string{"*"},
+			},
+		},
+	}
+
+	// Subject namespace omitted, defaults to the binding's namespace
+	binding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default-everything",
+			Namespace: "team-a",
+		},
+		RoleRef: rbacv1.RoleRef{
+			Kind: "Role",
+			Name: "everything",
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind: rbacv1.ServiceAccountKind,
+				Name: "default",
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset(pod, role, binding)
+	check := &RBACCheck{}
+
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			RBAC: &spec.RBACSpec{
+				ForbidWorkloadClusterAdmin: true,
+			},
+		},
+	}
+
+	result, err := check.Run(context.Background(), client, clusterSpec)
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	workloads := result.Evidence["privileged_workloads"].([]string)
+	assert.Len(t, workloads, 1)
+	assert.Contains(t, workloads[0], "RoleBinding/team-a/default-everything")
+}
+
+func TestRBACCheck_PassWorkloadClusterAdminSystemNamespace(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "controller",
+			Namespace: "kube-system",
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "controller",
+		},
+	}
+
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "controller-admin",
+		},
+		RoleRef: rbacv1.RoleRef{
+			Kind: "ClusterRole",
+			Name: "cluster-admin",
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      "controller",
+				Namespace: "kube-system",
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset(pod, binding)
+	check := &RBACCheck{}
+
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			RBAC: &spec.RBACSpec{
+				ForbidWorkloadClusterAdmin: true,
+			},
+		},
+	}
+
+	result, err := check.Run(context.Background(), client, clusterSpec)
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+}
@@ -0,0 +1,173 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	k8schain "github.com/google/go-containerregistry/pkg/authn/kubernetes"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// registryMaxBlobSize bounds the signature payloads read from registries.
+const registryMaxBlobSize = 1 << 20
+
+// parseImageReference parses an image reference such as "nginx:1.25" or
+// "ghcr.io/org/app@sha256:...", applying Docker Hub defaults.
+func parseImageReference(image string) (name.Reference, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image reference %q: %w", image, err)
+	}
+	return ref, nil
+}
+
+// registryCredentials is the keychain used for the images of one pod.
+type registryCredentials struct {
+	keychain authn.Keychain
+	key      string // identifies the pull secrets the keychain was built from
+}
+
+// registryClient reads image digests and cosign signatures from OCI
+// registries, authenticating as the kubelet would for each pod: with the
+// pod's imagePullSecrets and those of its service account, falling back to
+// the scanner's own keychain.
+type registryClient struct {
+	kubeClient kubernetes.Interface
+	transport  http.RoundTripper
+	fallback   authn.Keychain
+
+	credentials map[string]*registryCredentials
+}
+
+func newRegistryClient(kubeClient kubernetes.Interface, transport http.RoundTripper, fallback authn.Keychain) *registryClient {
+	if transport == nil {
+		transport = remote.DefaultTransport
+	}
+	if fallback == nil {
+		fallback = authn.DefaultKeychain
+	}
+	return &registryClient{
+		kubeClient:  kubeClient,
+		transport:   transport,
+		fallback:    fallback,
+		credentials: map[string]*registryCredentials{},
+	}
+}
+
+// podCredentials returns the keychain for the images of a pod, built once per
+// namespace, service account and set of pull secrets.
+func (c *registryClient) podCredentials(ctx context.Context, pod *corev1.Pod) (*registryCredentials, error) {
+	serviceAccount := pod.Spec.ServiceAccountName
+	if serviceAccount == "" {
+		serviceAccount = "default"
+	}
+	secrets := make([]string, 0, len(pod.Spec.ImagePullSecrets))
+	for _, secret := range pod.Spec.ImagePullSecrets {
+		secrets = append(secrets, secret.Name)
+	}
+	sort.Strings(secrets)
+
+	key := pod.Namespace + "/" + serviceAccount + "/" + strings.Join(secrets, ",")
+	if credentials, ok := c.credentials[key]; ok {
+		return credentials, nil
+	}
+
+	keychain, err := k8schain.New(ctx, c.kubeClient, k8schain.Options{
+		Namespace:          pod.Namespace,
+		ServiceAccountName: serviceAccount,
+		ImagePullSecrets:   secrets,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load image pull secrets of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	credentials := &registryCredentials{keychain: authn.NewMultiKeychain(keychain, c.fallback), key: key}
+	c.credentials[key] = credentials
+	return credentials, nil
+}
+
+func (c *registryClient) options(ctx context.Context, credentials *registryCredentials) []remote.Option {
+	return []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithTransport(c.transport),
+		remote.WithAuthFromKeychain(credentials.keychain),
+	}
+}
+
+// resolveDigest returns the manifest digest of an image, querying the registry
+// when the reference has no digest.
+func (c *registryClient) resolveDigest(ctx context.Context, ref name.Reference, credentials *registryCredentials) (string, error) {
+	if digest, ok := ref.(name.Digest); ok {
+		return digest.DigestStr(), nil
+	}
+
+	desc, err := remote.Head(ref, c.options(ctx, credentials)...)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	return desc.Digest.String(), nil
+}
+
+// cosignSignature is one signature layer of a cosign signature image.
+type cosignSignature struct {
+	signature string // base64 signature from the layer annotation
+	payload   []byte // simple signing payload the signature covers
+}
+
+// signatures returns the cosign signatures stored for an image digest under the
+// tag sha256-<hex>.sig. found is false when the image has no signature tag.
+func (c *registryClient) signatures(ctx context.Context, repo name.Repository, digest string, credentials *registryCredentials) (signatures []cosignSignature, found bool, err error) {
+	tag := repo.Tag(strings.Replace(digest, ":", "-", 1) + ".sig")
+	image, err := remote.Image(tag, c.options(ctx, credentials)...)
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to fetch signatures %s: %w", tag, err)
+	}
+
+	manifest, err := image.Manifest()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read signatures %s: %w", tag, err)
+	}
+	for _, desc := range manifest.Layers {
+		signature, ok := desc.Annotations[cosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+		if desc.Size > registryMaxBlobSize {
+			return nil, false, fmt.Errorf("signature payload %s of %s is too large (%d bytes)", desc.Digest, tag, desc.Size)
+		}
+
+		layer, err := image.LayerByDigest(desc.Digest)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read signature payload %s: %w", desc.Digest, err)
+		}
+		payload, err := readLayer(layer.Compressed)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read signature payload %s: %w", desc.Digest, err)
+		}
+		signatures = append(signatures, cosignSignature{signature: signature, payload: payload})
+	}
+	return signatures, true, nil
+}
+
+// readLayer reads a layer blob, bounded by registryMaxBlobSize.
+func readLayer(open func() (io.ReadCloser, error)) ([]byte, error) {
+	blob, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Close()
+	return io.ReadAll(io.LimitReader(blob, registryMaxBlobSize))
+}
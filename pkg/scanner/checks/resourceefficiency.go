@@ -0,0 +1,174 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ResourceEfficiencyCheck flags containers whose limits far exceed their requests,
+// or whose requests or limits are missing, for cost governance.
+type ResourceEfficiencyCheck struct{}
+
+// efficiencyResources are the container resources the check compares.
+var efficiencyResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+// Name returns the check name.
+func (c *ResourceEfficiencyCheck) Name() string {
+	return "workload.resource-efficiency"
+}
+
+// Description explains what the check verifies and why.
+func (c *ResourceEfficiencyCheck) Description() string {
+	return "Checks that container resource requests and limits are set and proportionate.\n\n" +
+		"Containers missing requests or limits, or whose limits exceed their requests by more than the allowed ratio, waste capacity or risk being throttled or evicted. Violations produce a warning for cost governance."
+}
+
+// SpecFields returns the spec fields the check reads.
+func (c *ResourceEfficiencyCheck) SpecFields() []string {
+	return []string{"workloads.resourcePolicy", "workloads.excludeContainers", "workloads.ignorePhases"}
+}
+
+// Severity returns the severity assigned to failures.
+func (c *ResourceEfficiencyCheck) Severity() scanner.Severity {
+	return scanner.SeverityLow
+}
+
+// Remediation returns an example fix for failures.
+func (c *ResourceEfficiencyCheck) Remediation() string {
+	return `Right-size container resources:
+1. Set CPU and memory requests close to observed usage (e.g. kubectl top pods)
+2. Set limits for every requested resource
+3. Keep limits within the allowed multiple of requests
+
+Example:
+  resources:
+    requests:
+      cpu: 250m
+      memory: 256Mi
+    limits:
+      cpu: 500m
+      memory: 512Mi`
+}
+
+// Run executes the resource efficiency check.
+func (c *ResourceEfficiencyCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.CheckResult, error) {
+	workloads := clusterSpec.Spec.Workloads
+
+	// Skip if not specified
+	if workloads == nil || workloads.ResourcePolicy == nil ||
+		(workloads.ResourcePolicy.MaxLimitToRequestRatio <= 0 && !workloads.ResourcePolicy.RequireRequests && !workloads.ResourcePolicy.RequireLimits) {
+		return &scanner.CheckResult{
+			Name:       c.Name(),
+			Status:     scanner.StatusSkip,
+			SkipReason: scanner.SkipReasonSpecSectionAbsent,
+			Message:    "Resource policy not specified in cluster spec",
+		}, nil
+	}
+	policy := workloads.ResourcePolicy
+
+	// Get all pods
+	pods, err := scanner.ListPods(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	violations := []string{}
+	excessiveRatios := map[string]map[string]float64{}
+	totalContainers := 0
+
+	for _, pod := range pods.Items {
+		// Skip system namespaces
+		if isSystemNamespace(pod.Namespace) {
+			continue
+		}
+
+		// Skip terminal pods the spec ignores, e.g. completed Jobs
+		if workloads.IsPodPhaseIgnored(string(pod.Status.Phase)) {
+			continue
+		}
+
+		for _, container := range pod.Spec.Containers {
+			if workloads.IsContainerExcluded(container.Name) {
+				continue
+			}
+			totalContainers++
+
+			containerKey := fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, container.Name)
+			containerViolations, ratios := checkContainerResources(container, policy)
+			for _, violation := range containerViolations {
+				violations = append(violations, fmt.Sprintf("%s: %s", containerKey, violation))
+			}
+			if len(ratios) > 0 {
+				excessiveRatios[containerKey] = ratios
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return &scanner.CheckResult{
+			Name:     c.Name(),
+			Status:   scanner.StatusWarn,
+			Severity: scanner.SeverityLow,
+			Message:  fmt.Sprintf("Found %d resource efficiency issues across %d containers", len(violations), totalContainers),
+			Evidence: map[string]interface{}{
+				"violations":                 violations,
+				"violation_count":            len(violations),
+				"excessive_ratios":           excessiveRatios,
+				"max_limit_to_request_ratio": policy.MaxLimitToRequestRatio,
+			},
+			Remediation: c.Remediation(),
+		}, nil
+	}
+
+	return &scanner.CheckResult{
+		Name:    c.Name(),
+		Status:  scanner.StatusPass,
+		Message: fmt.Sprintf("All %d containers meet the resource policy", totalContainers),
+		Evidence: map[string]interface{}{
+			"total_containers": totalContainers,
+		},
+	}, nil
+}
+
+// checkContainerResources returns the resource policy violations of a container,
+// along with the limit to request ratios that exceed the allowed maximum.
+func checkContainerResources(container corev1.Container, policy *spec.ResourcePolicySpec) ([]string, map[string]float64) {
+	violations := []string{}
+	ratios := map[string]float64{}
+
+	for _, resourceName := range efficiencyResources {
+		request, hasRequest := container.Resources.Requests[resourceName]
+		limit, hasLimit := container.Resources.Limits[resourceName]
+		// Kubernetes defaults a missing request to the limit
+		if !hasRequest && hasLimit {
+			request, hasRequest = limit, true
+		}
+
+		if policy.RequireRequests && !hasRequest {
+			violations = append(violations, fmt.Sprintf("missing %s request", resourceName))
+			continue
+		}
+		if policy.RequireLimits && hasRequest && !hasLimit {
+			violations = append(violations, fmt.Sprintf("%s request has no limit", resourceName))
+			continue
+		}
+
+		if policy.MaxLimitToRequestRatio <= 0 || !hasRequest || !hasLimit || request.MilliValue() == 0 {
+			continue
+		}
+		ratio := float64(limit.MilliValue()) / float64(request.MilliValue())
+		if ratio > policy.MaxLimitToRequestRatio {
+			ratio = math.Round(ratio*100) / 100
+			ratios[string(resourceName)] = ratio
+			violations = append(violations, fmt.Sprintf("%s limit is %gx the request (max %g)", resourceName, ratio, policy.MaxLimitToRequestRatio))
+		}
+	}
+
+	return violations, ratios
+}
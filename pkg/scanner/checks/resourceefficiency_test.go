@@ -0,0 +1,103 @@
+package checks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func resourcePod(namespace, name string, requests, limits corev1.ResourceList) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "app",
+					Image: "nginx",
+					Resources: corev1.ResourceRequirements{
+						Requests: requests,
+						Limits:   limits,
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourcePolicySpec(policy *spec.ResourcePolicySpec) *spec.ClusterSpecification {
+	return &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{ResourcePolicy: policy},
+		},
+	}
+}
+
+func TestResourceEfficiencyCheck_Pass(t *testing.T) {
+	pod := resourcePod("default", "right-sized",
+		corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+		corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("512Mi")})
+
+	check := &ResourceEfficiencyCheck{}
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(pod),
+		resourcePolicySpec(&spec.ResourcePolicySpec{MaxLimitToRequestRatio: 4, RequireRequests: true, RequireLimits: true}))
+
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+	assert.Equal(t, "workload.resource-efficiency", result.Name)
+}
+
+func TestResourceEfficiencyCheck_ExcessiveRatio(t *testing.T) {
+	pod := resourcePod("default", "over-provisioned",
+		corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+		corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("512Mi")})
+
+	// Pods in system namespaces are ignored
+	systemPod := resourcePod("kube-system", "coredns", nil, nil)
+
+	check := &ResourceEfficiencyCheck{}
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(pod, systemPod),
+		resourcePolicySpec(&spec.ResourcePolicySpec{MaxLimitToRequestRatio: 4}))
+
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusWarn, result.Status)
+	assert.Equal(t, 1, result.Evidence["violation_count"])
+	assert.Equal(t, map[string]map[string]float64{
+		"default/over-provisioned/app": {"cpu": 10},
+	}, result.Evidence["excessive_ratios"])
+}
+
+func TestResourceEfficiencyCheck_MissingRequestsAndLimits(t *testing.T) {
+	noRequests := resourcePod("default", "no-requests", nil, nil)
+	noLimits := resourcePod("default", "no-limits",
+		corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("128Mi")}, nil)
+
+	check := &ResourceEfficiencyCheck{}
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(noRequests, noLimits),
+		resourcePolicySpec(&spec.ResourcePolicySpec{RequireRequests: true, RequireLimits: true}))
+
+	require.NoError(t, err)
+	assert.Equal(t, scanner.StatusWarn, result.Status)
+	assert.ElementsMatch(t, []string{
+		"default/no-requests/app: missing cpu request",
+		"default/no-requests/app: missing memory request",
+		"default/no-limits/app: cpu request has no limit",
+		"default/no-limits/app: memory request has no limit",
+	}, result.Evidence["violations"])
+}
+
+func TestResourceEfficiencyCheck_Skip(t *testing.T) {
+	check := &ResourceEfficiencyCheck{}
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(), resourcePolicySpec(nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusSkip, result.Status)
+	assert.Equal(t, scanner.SkipReasonSpecSectionAbsent, result.SkipReason)
+}
@@ -0,0 +1,158 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PriorityClassCheck validates that critical workloads use the required PriorityClass.
+type PriorityClassCheck struct{}
+
+// Name returns the check name.
+func (c *PriorityClassCheck) Name() string {
+	return "scheduling.priority-class"
+}
+
+// Description explains what the check verifies and why.
+func (c *PriorityClassCheck) Description() string {
+	return "Checks that critical workloads use the required PriorityClass.\n\n" +
+		"Workloads in the namespaces or matching the selector of the scheduling spec must set priorityClassName, so that they are scheduled, and not preempted, ahead of less important pods."
+}
+
+// SpecFields returns the spec fields the check reads.
+func (c *PriorityClassCheck) SpecFields() []string {
+	return []string{"scheduling.requiredPriorityClass", "scheduling.namespaces", "scheduling.selector"}
+}
+
+// Severity returns the severity assigned to failures.
+func (c *PriorityClassCheck) Severity() scanner.Severity {
+	return scanner.SeverityMedium
+}
+
+// Remediation returns an example fix for failures.
+func (c *PriorityClassCheck) Remediation() string {
+	return `Set the required PriorityClass on the pod template of each violating workload:
+
+  spec:
+    template:
+      spec:
+        priorityClassName: <scheduling.requiredPriorityClass>
+
+Make sure the PriorityClass exists: kubectl get priorityclass <name>`
+}
+
+// Run executes the priority class check.
+func (c *PriorityClassCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.CheckResult, error) {
+	scheduling := clusterSpec.Spec.Scheduling
+
+	// Skip if not specified
+	if scheduling == nil || scheduling.RequiredPriorityClass == "" {
+		return &scanner.CheckResult{
+			Name:       c.Name(),
+			Status:     scanner.StatusSkip,
+			SkipReason: scanner.SkipReasonSpecSectionAbsent,
+			Message:    "PriorityClass requirements not specified in cluster spec",
+		}, nil
+	}
+
+	deployments, err := scanner.ListDeployments(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	statefulSets, err := scanner.ListStatefulSets(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+
+	daemonSets, err := scanner.ListDaemonSets(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+
+	violatingWorkloads := []string{}
+	checked := 0
+
+	checkWorkload := func(kind, namespace, name string, template *corev1.PodTemplateSpec) {
+		if !priorityClassApplies(scheduling, namespace, template.Labels) {
+			return
+		}
+		checked++
+		if template.Spec.PriorityClassName != scheduling.RequiredPriorityClass {
+			violatingWorkloads = append(violatingWorkloads, fmt.Sprintf("%s %s/%s (priorityClassName: %q)",
+				kind, namespace, name, template.Spec.PriorityClassName))
+		}
+	}
+
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		checkWorkload("Deployment", deployment.Namespace, deployment.Name, &deployment.Spec.Template)
+	}
+	for i := range statefulSets.Items {
+		statefulSet := &statefulSets.Items[i]
+		checkWorkload("StatefulSet", statefulSet.Namespace, statefulSet.Name, &statefulSet.Spec.Template)
+	}
+	for i := range daemonSets.Items {
+		daemonSet := &daemonSets.Items[i]
+		checkWorkload("DaemonSet", daemonSet.Namespace, daemonSet.Name, &daemonSet.Spec.Template)
+	}
+
+	if len(violatingWorkloads) > 0 {
+		return &scanner.CheckResult{
+			Name:     c.Name(),
+			Status:   scanner.StatusFail,
+			Severity: scanner.SeverityMedium,
+			Message: fmt.Sprintf("Found %d critical workloads not using PriorityClass %s",
+				len(violatingWorkloads), scheduling.RequiredPriorityClass),
+			Evidence: map[string]interface{}{
+				"violating_workloads":     violatingWorkloads,
+				"violation_count":         len(violatingWorkloads),
+				"checked_workloads":       checked,
+				"required_priority_class": scheduling.RequiredPriorityClass,
+			},
+			Remediation: fmt.Sprintf(`Set the required PriorityClass on the pod template of each violating workload:
+
+  spec:
+    template:
+      spec:
+        priorityClassName: %s
+
+Make sure the PriorityClass exists: kubectl get priorityclass %s`,
+				scheduling.RequiredPriorityClass, scheduling.RequiredPriorityClass),
+		}, nil
+	}
+
+	return &scanner.CheckResult{
+		Name:    c.Name(),
+		Status:  scanner.StatusPass,
+		Message: fmt.Sprintf("All %d critical workloads use PriorityClass %s", checked, scheduling.RequiredPriorityClass),
+		Evidence: map[string]interface{}{
+			"checked_workloads":       checked,
+			"required_priority_class": scheduling.RequiredPriorityClass,
+		},
+	}, nil
+}
+
+// priorityClassApplies reports whether the PriorityClass requirement covers a
+// workload. Without explicit namespaces, system namespaces are skipped.
+func priorityClassApplies(scheduling *spec.SchedulingSpec, namespace string, labels map[string]string) bool {
+	if len(scheduling.Namespaces) > 0 {
+		if !containsString(scheduling.Namespaces, namespace) {
+			return false
+		}
+	} else if isSystemNamespace(namespace) {
+		return false
+	}
+
+	for key, value := range scheduling.Selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
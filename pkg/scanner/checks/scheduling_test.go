@@ -0,0 +1,93 @@
+package checks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func priorityClassSpec(scheduling *spec.SchedulingSpec) *spec.ClusterSpecification {
+	return &spec.ClusterSpecification{
+		Spec: spec.SpecFields{Scheduling: scheduling},
+	}
+}
+
+func newPriorityDeployment(namespace, name, priorityClass string, labels map[string]string) *appsv1.Deployment {
+	deployment := newTestDeployment(name, 1, corev1.PodSpec{PriorityClassName: priorityClass})
+	deployment.Namespace = namespace
+	deployment.Spec.Template.Labels = labels
+	return deployment
+}
+
+func TestPriorityClassCheck_Skip(t *testing.T) {
+	check := &PriorityClassCheck{}
+
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(), priorityClassSpec(nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusSkip, result.Status)
+	assert.Equal(t, scanner.SkipReasonSpecSectionAbsent, result.SkipReason)
+	assert.Equal(t, "scheduling.priority-class", result.Name)
+}
+
+func TestPriorityClassCheck_Pass(t *testing.T) {
+	critical := newPriorityDeployment("payments", "api", "business-critical", map[string]string{"tier": "critical"})
+	// Outside the selector, so not required to use the class
+	batch := newPriorityDeployment("payments", "batch", "", map[string]string{"tier": "batch"})
+	// Outside the namespaces, so not required to use the class
+	other := newPriorityDeployment("default", "web", "", map[string]string{"tier": "critical"})
+
+	client := fake.NewSimpleClientset(critical, batch, other)
+	check := &PriorityClassCheck{}
+
+	result, err := check.Run(context.Background(), client, priorityClassSpec(&spec.SchedulingSpec{
+		RequiredPriorityClass: "business-critical",
+		Namespaces:            []string{"payments"},
+		Selector:              map[string]string{"tier": "critical"},
+	}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+	assert.Equal(t, 1, result.Evidence["checked_workloads"])
+}
+
+func TestPriorityClassCheck_Fail(t *testing.T) {
+	compliant := newPriorityDeployment("default", "api", "business-critical", nil)
+	missing := newPriorityDeployment("default", "worker", "", nil)
+	wrong := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{PriorityClassName: "low"}},
+		},
+	}
+	agent := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "monitoring"},
+	}
+	// System namespaces are skipped unless listed explicitly
+	system := newPriorityDeployment("kube-system", "coredns", "", nil)
+
+	client := fake.NewSimpleClientset(compliant, missing, wrong, agent, system)
+	check := &PriorityClassCheck{}
+
+	result, err := check.Run(context.Background(), client, priorityClassSpec(&spec.SchedulingSpec{
+		RequiredPriorityClass: "business-critical",
+	}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	assert.Equal(t, scanner.SeverityMedium, result.Severity)
+	assert.Equal(t, 4, result.Evidence["checked_workloads"])
+	assert.Equal(t, 3, result.Evidence["violation_count"])
+	assert.ElementsMatch(t, []string{
+		`Deployment default/worker (priorityClassName: "")`,
+		`StatefulSet default/db (priorityClassName: "low")`,
+		`DaemonSet monitoring/agent (priorityClassName: "")`,
+	}, result.Evidence["violating_workloads"])
+}
@@ -0,0 +1,186 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// SecretExposureCheck validates that secret-like env vars are sourced from Secrets
+// rather than set as plaintext values in the pod spec.
+type SecretExposureCheck struct{}
+
+// Name returns the check name.
+func (c *SecretExposureCheck) Name() string {
+	return "secrets.plaintext-env"
+}
+
+// Description explains what the check verifies and why.
+func (c *SecretExposureCheck) Description() string {
+	return "Checks that secret-like environment variables are sourced from Secrets.\n\n" +
+		"Environment variables whose names match the secret patterns must use valueFrom.secretKeyRef rather than a literal value, which anyone able to read the pod spec can see."
+}
+
+// SpecFields returns the spec fields the check reads.
+func (c *SecretExposureCheck) SpecFields() []string {
+	return []string{"secrets.forbidPlaintextEnv", "secrets.envNamePatterns", "workloads.excludeContainers", "workloads.ignorePhases"}
+}
+
+// Severity returns the severity assigned to failures.
+func (c *SecretExposureCheck) Severity() scanner.Severity {
+	return scanner.SeverityHigh
+}
+
+// Remediation returns an example fix for failures.
+func (c *SecretExposureCheck) Remediation() string {
+	return `Source secret values from Secrets instead of literal env values:
+1. Create a Secret holding the value
+2. Reference it with env[].valueFrom.secretKeyRef, or load it with envFrom[].secretRef
+3. Rotate the exposed credential, since it is readable by anyone who can read the pod spec`
+}
+
+// Run executes the secret exposure check.
+func (c *SecretExposureCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.CheckResult, error) {
+	secrets := clusterSpec.Spec.Secrets
+
+	// Skip if not specified
+	if secrets == nil || !secrets.ForbidPlaintextEnv {
+		return &scanner.CheckResult{
+			Name:       c.Name(),
+			Status:     scanner.StatusSkip,
+			SkipReason: scanner.SkipReasonSpecSectionAbsent,
+			Message:    "Secret exposure requirements not specified in cluster spec",
+		}, nil
+	}
+
+	patterns := secrets.EnvNamePatterns
+	if len(patterns) == 0 {
+		patterns = spec.DefaultSecretEnvNamePatterns
+	}
+
+	// Get all pods
+	pods, err := scanner.ListPods(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	violations := []string{}
+	violatingPods := []string{}
+	totalPods := 0
+
+	for _, pod := range pods.Items {
+		// Skip system namespaces
+		if isSystemNamespace(pod.Namespace) {
+			continue
+		}
+
+		// Skip terminal pods the spec ignores, e.g. completed Jobs
+		if clusterSpec.Spec.Workloads.IsPodPhaseIgnored(string(pod.Status.Phase)) {
+			continue
+		}
+
+		totalPods++
+		podViolations := checkPodSecretEnv(&pod, clusterSpec.Spec.Workloads, patterns)
+		if len(podViolations) > 0 {
+			violations = append(violations, podViolations...)
+			violatingPods = append(violatingPods, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+		}
+	}
+
+	if len(violations) > 0 {
+		return &scanner.CheckResult{
+			Name:     c.Name(),
+			Status:   scanner.StatusFail,
+			Severity: scanner.SeverityHigh,
+			Message:  fmt.Sprintf("Found %d secret-like env vars set as plaintext across %d pods", len(violations), len(violatingPods)),
+			Evidence: map[string]interface{}{
+				"violations":      violations,
+				"violating_pods":  violatingPods,
+				"violation_count": len(violations),
+				"patterns":        patterns,
+			},
+			Remediation: c.Remediation(),
+		}, nil
+	}
+
+	return &scanner.CheckResult{
+		Name:    c.Name(),
+		Status:  scanner.StatusPass,
+		Message: fmt.Sprintf("No secret-like env vars set as plaintext in %d workloads", totalPods),
+		Evidence: map[string]interface{}{
+			"total_pods": totalPods,
+			"patterns":   patterns,
+		},
+	}, nil
+}
+
+// Playbook returns a step-by-step remediation playbook for failures.
+func (c *SecretExposureCheck) Playbook() string {
+	return `Step-by-step: move plaintext secrets out of pod specs
+
+1. For each violation, find the workload that owns the pod and the env var named
+   in the evidence (values are never reported):
+   kubectl get pod <pod> -n <namespace> -o jsonpath='{.metadata.ownerReferences}'
+
+2. Rotate the credential first: it has been readable by anyone with access to the
+   pod spec, including in Deployment history and GitOps repositories.
+
+3. Store the new value in a Secret:
+   kubectl create secret generic <name> -n <namespace> --from-literal=<key>=<value>
+
+4. Reference the Secret from the container instead of the literal value:
+
+   env:
+   - name: DB_PASSWORD
+     valueFrom:
+       secretKeyRef:
+         name: <name>
+         key: <key>
+
+5. Roll out the workload and re-run kspec scan.`
+}
+
+// checkPodSecretEnv returns one violation per container env var whose name matches
+// a secret-like pattern but that is set as a literal value. Violations name the
+// container and env var only, never the value.
+func checkPodSecretEnv(pod *corev1.Pod, workloads *spec.WorkloadsSpec, patterns []string) []string {
+	violations := []string{}
+	podKey := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+
+	containers := append([]corev1.Container{}, pod.Spec.InitContainers...)
+	containers = append(containers, pod.Spec.Containers...)
+
+	for _, container := range containers {
+		if workloads.IsContainerExcluded(container.Name) {
+			continue
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom != nil || env.Value == "" {
+				continue
+			}
+			if matchesSecretEnvName(env.Name, patterns) {
+				violations = append(violations, fmt.Sprintf("%s:%s: env %s is set as a plaintext value", podKey, container.Name, env.Name))
+			}
+		}
+	}
+
+	return violations
+}
+
+// matchesSecretEnvName reports whether an env var name matches any pattern,
+// ignoring case.
+func matchesSecretEnvName(name string, patterns []string) bool {
+	upper := strings.ToUpper(name)
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(strings.ToUpper(pattern), upper); matched {
+			return true
+		}
+	}
+	return false
+}
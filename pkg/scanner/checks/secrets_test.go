@@ -0,0 +1,140 @@
+package checks
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/scanner"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newSecretEnvTestPod(name string, env ...corev1.EnvVar) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "app",
+					Image: "ghcr.io/myapp:v1",
+					Env:   env,
+				},
+			},
+		},
+	}
+}
+
+func secretsSpec(patterns ...string) *spec.ClusterSpecification {
+	return &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Secrets: &spec.SecretsSpec{
+				ForbidPlaintextEnv: true,
+				EnvNamePatterns:    patterns,
+			},
+		},
+	}
+}
+
+func TestSecretExposureCheck_Pass(t *testing.T) {
+	pod := newSecretEnvTestPod("compliant-pod",
+		corev1.EnvVar{Name: "LOG_LEVEL", Value: "debug"},
+		corev1.EnvVar{Name: "DB_PASSWORD", ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "db"},
+				Key:                  "password",
+			},
+		}},
+	)
+	pod.Spec.Containers[0].EnvFrom = []corev1.EnvFromSource{
+		{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "api"}}},
+	}
+
+	check := &SecretExposureCheck{}
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(pod), secretsSpec())
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+	assert.Equal(t, 1, result.Evidence["total_pods"])
+}
+
+func TestSecretExposureCheck_FailPlaintextSecret(t *testing.T) {
+	pod := newSecretEnvTestPod("leaky-pod",
+		corev1.EnvVar{Name: "DB_PASSWORD", Value: "hunter2"},
+		corev1.EnvVar{Name: "github_token", Value: "ghp_abc123"},
+		corev1.EnvVar{Name: "LOG_LEVEL", Value: "debug"},
+	)
+	pod.Spec.InitContainers = []corev1.Container{
+		{Name: "migrate", Env: []corev1.EnvVar{{Name: "API_KEY", Value: "sk-live-xyz"}}},
+	}
+
+	check := &SecretExposureCheck{}
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(pod), secretsSpec())
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	assert.Equal(t, scanner.SeverityHigh, result.Severity)
+	assert.Equal(t, 3, result.Evidence["violation_count"])
+	assert.Equal(t, []string{"default/leaky-pod"}, result.Evidence["violating_pods"])
+
+	violations := strings.Join(result.Evidence["violations"].([]string), "\n")
+	assert.Contains(t, violations, "default/leaky-pod:app: env DB_PASSWORD")
+	assert.Contains(t, violations, "default/leaky-pod:app: env github_token")
+	assert.Contains(t, violations, "default/leaky-pod:migrate: env API_KEY")
+	assert.NotContains(t, violations, "LOG_LEVEL")
+
+	// Values must never be reported
+	for _, value := range []string{"hunter2", "ghp_abc123", "sk-live-xyz"} {
+		assert.NotContains(t, violations, value)
+		assert.NotContains(t, result.Message, value)
+	}
+}
+
+func TestSecretExposureCheck_CustomPatterns(t *testing.T) {
+	pod := newSecretEnvTestPod("custom-pod",
+		corev1.EnvVar{Name: "DB_PASSWORD", Value: "hunter2"},
+		corev1.EnvVar{Name: "STRIPE_SIGNING_SALT", Value: "abc"},
+	)
+
+	check := &SecretExposureCheck{}
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(pod), secretsSpec("*_SALT"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	assert.Equal(t, 1, result.Evidence["violation_count"])
+	assert.Contains(t, result.Evidence["violations"].([]string)[0], "STRIPE_SIGNING_SALT")
+	assert.Equal(t, []string{"*_SALT"}, result.Evidence["patterns"])
+}
+
+func TestSecretExposureCheck_ExcludedContainersAndSystemNamespaces(t *testing.T) {
+	sidecar := newSecretEnvTestPod("meshed-pod")
+	sidecar.Spec.Containers[0].Name = "istio-proxy"
+	sidecar.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "ISTIO_META_TOKEN", Value: "x"}}
+
+	system := newSecretEnvTestPod("system-pod", corev1.EnvVar{Name: "DB_PASSWORD", Value: "x"})
+	system.Namespace = "kube-system"
+
+	clusterSpec := secretsSpec()
+	clusterSpec.Spec.Workloads = &spec.WorkloadsSpec{ExcludeContainers: []string{"istio-proxy"}}
+
+	check := &SecretExposureCheck{}
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(sidecar, system), clusterSpec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+}
+
+func TestSecretExposureCheck_Skip(t *testing.T) {
+	check := &SecretExposureCheck{}
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(), &spec.ClusterSpecification{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusSkip, result.Status)
+	assert.Equal(t, scanner.SkipReasonSpecSectionAbsent, result.SkipReason)
+}
@@ -2,6 +2,7 @@ package checks
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
@@ -9,7 +10,6 @@ import (
 	"github.com/cloudcwfranck/kspec/pkg/scanner"
 	"github.com/cloudcwfranck/kspec/pkg/spec"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -21,19 +21,54 @@ func (c *WorkloadSecurityCheck) Name() string {
 	return "workload.security"
 }
 
+// Description explains what the check verifies and why.
+func (c *WorkloadSecurityCheck) Description() string {
+	return "Checks workload containers and images against the spec's security requirements.\n\n" +
+		"Every container must set the fields in workloads.containers.required and none in workloads.containers.forbidden, e.g. securityContext.runAsNonRoot, and images must come from allowed registries and be pinned by digest when required."
+}
+
+// SpecFields returns the spec fields the check reads.
+func (c *WorkloadSecurityCheck) SpecFields() []string {
+	return []string{"workloads.containers", "workloads.images", "workloads.excludeContainers", "workloads.ignorePhases"}
+}
+
+// Severity returns the severity assigned to failures.
+func (c *WorkloadSecurityCheck) Severity() scanner.Severity {
+	return scanner.SeverityHigh
+}
+
+// Remediation returns an example fix for failures.
+func (c *WorkloadSecurityCheck) Remediation() string {
+	return `Review and fix workload security violations:
+1. Ensure containers run as non-root (securityContext.runAsNonRoot: true)
+2. Disable privilege escalation (securityContext.allowPrivilegeEscalation: false)
+3. Add resource limits and requests to all containers
+4. Avoid privileged containers, hostNetwork, and hostPID
+5. Use approved container registries
+6. Use image digests instead of tags`
+}
+
 // Run executes the workload security check.
 func (c *WorkloadSecurityCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*scanner.CheckResult, error) {
 	// Skip if not specified
 	if clusterSpec.Spec.Workloads == nil {
 		return &scanner.CheckResult{
-			Name:    c.Name(),
-			Status:  scanner.StatusSkip,
-			Message: "Workload security requirements not specified in cluster spec",
+			Name:       c.Name(),
+			Status:     scanner.StatusSkip,
+			SkipReason: scanner.SkipReasonSpecSectionAbsent,
+			Message:    "Workload security requirements not specified in cluster spec",
 		}, nil
 	}
 
+	// Check controllers by their pod templates, so a bad template is reported
+	// once against its source rather than against every pod it creates
+	controllers, err := listPodTemplateWorkloads(ctx, client, clusterSpec.Spec.Workloads)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get all pods
-	pods, err := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	pods, err := scanner.ListPods(ctx, client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pods: %w", err)
 	}
@@ -41,6 +76,20 @@ func (c *WorkloadSecurityCheck) Run(ctx context.Context, client kubernetes.Inter
 	violations := []string{}
 	evidence := make(map[string]interface{})
 	violatingPods := []string{}
+	violatingWorkloads := []string{}
+	var violatingControllers []podTemplateWorkload
+	totalPods := 0
+
+	checkedControllers := make(map[string]bool, len(controllers))
+	for _, controller := range controllers {
+		checkedControllers[controller.Key()] = true
+		controllerViolations := c.checkPod(controller.Pod(), controller.Key(), clusterSpec.Spec.Workloads)
+		if len(controllerViolations) > 0 {
+			violations = append(violations, controllerViolations...)
+			violatingWorkloads = append(violatingWorkloads, controller.Key())
+			violatingControllers = append(violatingControllers, controller)
+		}
+	}
 
 	// Check each pod
 	for _, pod := range pods.Items {
@@ -49,7 +98,18 @@ func (c *WorkloadSecurityCheck) Run(ctx context.Context, client kubernetes.Inter
 			continue
 		}
 
-		podViolations := c.checkPod(&pod, clusterSpec.Spec.Workloads)
+		// Skip terminal pods the spec ignores, e.g. completed Jobs
+		if clusterSpec.Spec.Workloads.IsPodPhaseIgnored(string(pod.Status.Phase)) {
+			continue
+		}
+
+		// Skip pods whose controller template was already checked
+		if checkedControllers[podControllerKey(&pod)] {
+			continue
+		}
+
+		totalPods++
+		podViolations := c.checkPod(&pod, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name), clusterSpec.Spec.Workloads)
 		if len(podViolations) > 0 {
 			violations = append(violations, podViolations...)
 			violatingPods = append(violatingPods, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
@@ -59,43 +119,86 @@ func (c *WorkloadSecurityCheck) Run(ctx context.Context, client kubernetes.Inter
 	if len(violations) > 0 {
 		evidence["violations"] = violations
 		evidence["violating_pods"] = violatingPods
+		evidence["violating_workloads"] = violatingWorkloads
 		evidence["violation_count"] = len(violations)
 
 		return &scanner.CheckResult{
-			Name:     c.Name(),
-			Status:   scanner.StatusFail,
-			Severity: scanner.SeverityHigh,
-			Message:  fmt.Sprintf("Found %d workload security violations across %d pods", len(violations), len(violatingPods)),
-			Evidence: evidence,
-			Remediation: `Review and fix workload security violations:
-1. Ensure containers run as non-root (securityContext.runAsNonRoot: true)
-2. Disable privilege escalation (securityContext.allowPrivilegeEscalation: false)
-3. Add resource limits and requests to all containers
-4. Avoid privileged containers, hostNetwork, and hostPID
-5. Use approved container registries
-6. Use image digests instead of tags`,
+			Name:              c.Name(),
+			Status:            scanner.StatusFail,
+			Severity:          scanner.SeverityHigh,
+			Message:           fmt.Sprintf("Found %d workload security violations across %d workloads", len(violations), len(violatingPods)+len(violatingWorkloads)),
+			Evidence:          evidence,
+			Remediation:       c.Remediation(),
+			RemediationAction: c.buildRemediationAction(clusterSpec.Spec.Workloads),
+			RemediationSteps:  c.remediationSteps(violatingControllers, len(violations), clusterSpec.Spec.Workloads),
 		}, nil
 	}
 
-	totalPods := len(pods.Items) - countSystemPods(pods.Items)
 	return &scanner.CheckResult{
 		Name:    c.Name(),
 		Status:  scanner.StatusPass,
-		Message: fmt.Sprintf("All %d workloads comply with security requirements", totalPods),
+		Message: fmt.Sprintf("All %d workloads comply with security requirements", totalPods+len(controllers)),
 		Evidence: map[string]interface{}{
-			"total_pods": totalPods,
+			"total_pods":        totalPods,
+			"total_controllers": len(controllers),
 		},
 	}, nil
 }
 
-// checkPod validates a single pod against workload requirements.
-func (c *WorkloadSecurityCheck) checkPod(pod *corev1.Pod, spec *spec.WorkloadsSpec) []string {
+// Playbook returns a step-by-step remediation playbook for failures.
+func (c *WorkloadSecurityCheck) Playbook() string {
+	return `Step-by-step: fix workload security violations
+
+1. Identify the violating workloads from the evidence above. Controllers are
+   listed by kind, e.g. "Deployment default/web"; for a standalone pod, check
+   whether something else owns it:
+   kubectl get pod <pod> -n <namespace> -o jsonpath='{.metadata.ownerReferences[0].name}'
+
+2. Harden the container securityContext in the pod template:
+
+   spec:
+     template:
+       spec:
+         containers:
+         - name: app
+           securityContext:
+             runAsNonRoot: true
+             allowPrivilegeEscalation: false
+             privileged: false
+             capabilities:
+               drop: ["ALL"]
+
+3. Add resource requests and limits so the scheduler can place pods safely:
+
+           resources:
+             requests:
+               cpu: 100m
+               memory: 128Mi
+             limits:
+               cpu: 500m
+               memory: 256Mi
+
+4. Remove hostNetwork, hostPID and hostIPC from the pod spec unless the workload
+   is a node agent that genuinely needs them.
+
+5. Apply the change and wait for the rollout, then re-run kspec scan:
+   kubectl rollout status deployment/<name> -n <namespace>
+
+If the image requires root, rebuild it with a USER directive rather than
+exempting the workload.`
+}
+
+// checkPod validates a single pod, or a controller's pod template, against
+// workload requirements. Violations are prefixed with podKey.
+func (c *WorkloadSecurityCheck) checkPod(pod *corev1.Pod, podKey string, spec *spec.WorkloadsSpec) []string {
 	violations := []string{}
-	podKey := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
 
 	// Check containers
 	if spec.Containers != nil {
 		for i, container := range pod.Spec.Containers {
+			if spec.IsContainerExcluded(container.Name) {
+				continue
+			}
 			containerKey := fmt.Sprintf("%s[%d]:%s", podKey, i, container.Name)
 
 			// Check required fields
@@ -117,6 +220,9 @@ func (c *WorkloadSecurityCheck) checkPod(pod *corev1.Pod, spec *spec.WorkloadsSp
 
 		// Check init containers
 		for i, container := range pod.Spec.InitContainers {
+			if spec.IsContainerExcluded(container.Name) {
+				continue
+			}
 			containerKey := fmt.Sprintf("%s[init-%d]:%s", podKey, i, container.Name)
 
 			for _, req := range spec.Containers.Required {
@@ -138,6 +244,9 @@ func (c *WorkloadSecurityCheck) checkPod(pod *corev1.Pod, spec *spec.WorkloadsSp
 	// Check images
 	if spec.Images != nil {
 		for _, container := range append(pod.Spec.Containers, pod.Spec.InitContainers...) {
+			if spec.IsContainerExcluded(container.Name) {
+				continue
+			}
 			violation := c.checkImage(&container, spec.Images, podKey)
 			if violation != "" {
 				violations = append(violations, violation)
@@ -214,6 +323,162 @@ func (c *WorkloadSecurityCheck) checkForbiddenField(pod *corev1.Pod, container *
 	return ""
 }
 
+// buildRemediationAction generates a container securityContext patch satisfying the
+// spec's securityContext requirements. Other requirements (resources, registries)
+// can't be fixed generically, so a link to the docs is returned instead.
+func (c *WorkloadSecurityCheck) buildRemediationAction(workloads *spec.WorkloadsSpec) *scanner.RemediationAction {
+	securityContext := map[string]interface{}{}
+	if workloads.Containers != nil {
+		for _, req := range workloads.Containers.Required {
+			switch req.Key {
+			case "securityContext.runAsNonRoot":
+				securityContext["runAsNonRoot"] = true
+			case "securityContext.allowPrivilegeEscalation":
+				if req.Value == "false" {
+					securityContext["allowPrivilegeEscalation"] = false
+				}
+			}
+		}
+		for _, forbidden := range workloads.Containers.Forbidden {
+			if forbidden.Key == "securityContext.privileged" {
+				securityContext["privileged"] = false
+			}
+		}
+	}
+
+	if len(securityContext) == 0 {
+		return &scanner.RemediationAction{
+			Type:        scanner.RemediationTypeDoc,
+			Payload:     "https://kubernetes.io/docs/concepts/security/pod-security-standards/",
+			Description: "Review and fix workload security violations",
+		}
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{"securityContext": securityContext})
+	if err != nil {
+		return nil
+	}
+
+	return &scanner.RemediationAction{
+		Type:        scanner.RemediationTypePatch,
+		Payload:     string(patch),
+		Description: "Merge into the container spec of each violating workload's pod template",
+	}
+}
+
+// workloadPatchTargets maps the controller kinds that can be patched in place to
+// their kubectl resource and the path of their pod template. Jobs are left out
+// because their pod template is immutable.
+var workloadPatchTargets = map[string]struct {
+	resource     string
+	templatePath []string
+}{
+	"Deployment":  {resource: "deployment", templatePath: []string{"spec", "template"}},
+	"StatefulSet": {resource: "statefulset", templatePath: []string{"spec", "template"}},
+	"DaemonSet":   {resource: "daemonset", templatePath: []string{"spec", "template"}},
+	"CronJob":     {resource: "cronjob", templatePath: []string{"spec", "jobTemplate", "spec", "template"}},
+}
+
+// remediationSteps returns a kubectl patch command for each violating controller,
+// setting the securityContext fields its containers violate. A documentation
+// link follows when violations remain that have no generic fix, e.g. resources,
+// images, host namespaces, or those of Jobs and standalone pods.
+func (c *WorkloadSecurityCheck) remediationSteps(controllers []podTemplateWorkload, violationCount int, workloads *spec.WorkloadsSpec) []scanner.RemediationStep {
+	var steps []scanner.RemediationStep
+	fixed := 0
+	for _, controller := range controllers {
+		target, ok := workloadPatchTargets[controller.Kind]
+		if !ok {
+			continue
+		}
+
+		pod := controller.Pod()
+		podSpec := map[string]interface{}{}
+		containers, containerFixes := c.securityContextFixes(pod, pod.Spec.Containers, workloads)
+		if len(containers) > 0 {
+			podSpec["containers"] = containers
+		}
+		initContainers, initContainerFixes := c.securityContextFixes(pod, pod.Spec.InitContainers, workloads)
+		if len(initContainers) > 0 {
+			podSpec["initContainers"] = initContainers
+		}
+		if len(podSpec) == 0 {
+			continue
+		}
+
+		patch := map[string]interface{}{"spec": podSpec}
+		for i := len(target.templatePath) - 1; i >= 0; i-- {
+			patch = map[string]interface{}{target.templatePath[i]: patch}
+		}
+		data, err := json.Marshal(patch)
+		if err != nil {
+			continue
+		}
+
+		fixed += containerFixes + initContainerFixes
+		steps = append(steps, scanner.RemediationStep{
+			Type:        scanner.RemediationStepKubectl,
+			Command:     fmt.Sprintf("kubectl patch %s %s -n %s --type strategic -p '%s'", target.resource, controller.Name, controller.Namespace, data),
+			Description: fmt.Sprintf("Harden the container securityContext of %s", controller.Key()),
+		})
+	}
+
+	if fixed < violationCount {
+		steps = append(steps, scanner.RemediationStep{
+			Type:        scanner.RemediationStepDocLink,
+			URL:         "https://kubernetes.io/docs/concepts/security/pod-security-standards/",
+			Description: "Fix the remaining violations in the workload manifests",
+		})
+	}
+	return steps
+}
+
+// securityContextFixes returns strategic merge patch entries setting the
+// securityContext fields each container violates, and the number of
+// violations they fix.
+func (c *WorkloadSecurityCheck) securityContextFixes(pod *corev1.Pod, containers []corev1.Container, workloads *spec.WorkloadsSpec) ([]interface{}, int) {
+	if workloads.Containers == nil {
+		return nil, 0
+	}
+
+	var patches []interface{}
+	fixed := 0
+	for i := range containers {
+		container := &containers[i]
+		if workloads.IsContainerExcluded(container.Name) {
+			continue
+		}
+
+		securityContext := map[string]interface{}{}
+		for _, req := range workloads.Containers.Required {
+			if c.checkRequiredField(pod, container, req, container.Name) == "" {
+				continue
+			}
+			switch req.Key {
+			case "securityContext.runAsNonRoot":
+				securityContext["runAsNonRoot"] = true
+			case "securityContext.allowPrivilegeEscalation":
+				securityContext["allowPrivilegeEscalation"] = false
+			}
+		}
+		for _, forbidden := range workloads.Containers.Forbidden {
+			if forbidden.Key == "securityContext.privileged" && c.checkForbiddenField(pod, container, forbidden, container.Name) != "" {
+				securityContext["privileged"] = false
+			}
+		}
+		if len(securityContext) == 0 {
+			continue
+		}
+
+		fixed += len(securityContext)
+		patches = append(patches, map[string]interface{}{
+			"name":            container.Name,
+			"securityContext": securityContext,
+		})
+	}
+	return patches, fixed
+}
+
 // checkImage validates image registry and digest requirements.
 func (c *WorkloadSecurityCheck) checkImage(container *corev1.Container, imageSpec *spec.ImageSpec, podKey string) string {
 	image := container.Image
@@ -277,14 +542,3 @@ func matchesRegistry(image, registryPattern string) bool {
 
 	return false
 }
-
-// countSystemPods counts pods in system namespaces.
-func countSystemPods(pods []corev1.Pod) int {
-	count := 0
-	for _, pod := range pods {
-		if isSystemNamespace(pod.Namespace) {
-			count++
-		}
-	}
-	return count
-}
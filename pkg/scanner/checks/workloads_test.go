@@ -7,6 +7,8 @@ import (
 	"github.com/cloudcwfranck/kspec/pkg/scanner"
 	"github.com/cloudcwfranck/kspec/pkg/spec"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -116,6 +118,10 @@ func TestWorkloadSecurityCheck_FailMissingSecurityContext(t *testing.T) {
 	assert.Equal(t, scanner.StatusFail, result.Status)
 	assert.Equal(t, scanner.SeverityHigh, result.Severity)
 	assert.Contains(t, result.Evidence, "violations")
+	if assert.NotNil(t, result.RemediationAction) {
+		assert.Equal(t, scanner.RemediationTypePatch, result.RemediationAction.Type)
+		assert.JSONEq(t, `{"securityContext":{"runAsNonRoot":true}}`, result.RemediationAction.Payload)
+	}
 }
 
 func TestWorkloadSecurityCheck_FailPrivilegedContainer(t *testing.T) {
@@ -369,6 +375,7 @@ func TestWorkloadSecurityCheck_Skip(t *testing.T) {
 	result, err := check.Run(context.Background(), client, clusterSpec)
 	assert.NoError(t, err)
 	assert.Equal(t, scanner.StatusSkip, result.Status)
+	assert.Equal(t, scanner.SkipReasonSpecSectionAbsent, result.SkipReason)
 }
 
 func TestWorkloadSecurityCheck_Name(t *testing.T) {
@@ -413,6 +420,111 @@ func TestWorkloadSecurityCheck_SystemNamespacesIgnored(t *testing.T) {
 	assert.Equal(t, scanner.StatusPass, result.Status)
 }
 
+func TestWorkloadSecurityCheck_ExcludedContainers(t *testing.T) {
+	// Compliant app container plus an injected sidecar without a securityContext
+	runAsNonRoot := true
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "meshed-pod",
+			Namespace: "default",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "app",
+					Image: "ghcr.io/myapp@sha256:abc123",
+					SecurityContext: &corev1.SecurityContext{
+						RunAsNonRoot: &runAsNonRoot,
+					},
+				},
+				{
+					Name:  "istio-proxy",
+					Image: "docker.io/istio/proxyv2:1.20.0",
+				},
+			},
+		},
+	}
+
+	client := fake.NewSimpleClientset(pod)
+	check := &WorkloadSecurityCheck{}
+
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{
+				Containers: &spec.ContainerSpec{
+					Required: []spec.FieldRequirement{
+						{Key: "securityContext.runAsNonRoot", Value: "true"},
+					},
+				},
+				Images: &spec.ImageSpec{
+					AllowedRegistries: []string{"ghcr.io"},
+				},
+				ExcludeContainers: []string{"istio-proxy"},
+			},
+		},
+	}
+
+	result, err := check.Run(context.Background(), client, clusterSpec)
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+
+	// Without the exclusion the sidecar fails both requirements
+	clusterSpec.Spec.Workloads.ExcludeContainers = nil
+	result, err = check.Run(context.Background(), client, clusterSpec)
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	assert.Equal(t, 2, result.Evidence["violation_count"])
+}
+
+func TestWorkloadSecurityCheck_IgnoredPodPhases(t *testing.T) {
+	// Completed Job pod missing runAsNonRoot
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "migration-job-abc12",
+			Namespace: "default",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "migrate",
+					Image: "ghcr.io/myapp:latest",
+				},
+			},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+	}
+
+	check := &WorkloadSecurityCheck{}
+	workloads := &spec.WorkloadsSpec{
+		Containers: &spec.ContainerSpec{
+			Required: []spec.FieldRequirement{
+				{Key: "securityContext.runAsNonRoot", Value: "true"},
+			},
+		},
+	}
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{Workloads: workloads},
+	}
+
+	// Succeeded pods are ignored by default
+	result, err := check.Run(context.Background(), fake.NewSimpleClientset(pod), clusterSpec)
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+	assert.Equal(t, 0, result.Evidence["total_pods"])
+
+	// Explicitly ignored
+	workloads.IgnorePhases = []string{"Succeeded", "Failed"}
+	result, err = check.Run(context.Background(), fake.NewSimpleClientset(pod), clusterSpec)
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusPass, result.Status)
+
+	// An empty list checks pods in every phase
+	workloads.IgnorePhases = []string{}
+	result, err = check.Run(context.Background(), fake.NewSimpleClientset(pod), clusterSpec)
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+}
+
 func TestWorkloadSecurityCheck_InitContainers(t *testing.T) {
 	// Pod with non-compliant init container
 	pod := &corev1.Pod{
@@ -459,3 +571,165 @@ func TestWorkloadSecurityCheck_InitContainers(t *testing.T) {
 	// Should have violations for both init and regular container
 	assert.True(t, len(violations) >= 2)
 }
+
+func TestWorkloadSecurityCheck_PodTemplates(t *testing.T) {
+	isController := true
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "app",
+					Image: "ghcr.io/web:latest",
+					// Missing security context
+				},
+			},
+		},
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Template: template},
+	}
+	// Pod created by the Deployment through its ReplicaSet
+	deploymentPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-5d4f8b7c9-x2x7q",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "web", "pod-template-hash": "5d4f8b7c9"},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "web-5d4f8b7c9", Controller: &isController},
+			},
+		},
+		Spec: template.Spec,
+	}
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "default"},
+		Spec: batchv1.CronJobSpec{
+			JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: template}},
+		},
+	}
+	// Job created by the CronJob
+	cronJobJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "report-28391",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "CronJob", Name: "report", Controller: &isController},
+			},
+		},
+		Spec: batchv1.JobSpec{Template: template},
+	}
+	// Finished Jobs are ignored like their Succeeded pods
+	completedJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"},
+		Spec:       batchv1.JobSpec{Template: template},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+		},
+	}
+	systemDaemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "kube-proxy", Namespace: "kube-system"},
+		Spec:       appsv1.DaemonSetSpec{Template: template},
+	}
+
+	client := fake.NewSimpleClientset(deployment, deploymentPod, cronJob, cronJobJob, completedJob, systemDaemonSet)
+	check := &WorkloadSecurityCheck{}
+
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{
+				Containers: &spec.ContainerSpec{
+					Required: []spec.FieldRequirement{
+						{Key: "securityContext.runAsNonRoot", Value: "true"},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := check.Run(context.Background(), client, clusterSpec)
+	assert.NoError(t, err)
+	assert.Equal(t, scanner.StatusFail, result.Status)
+	assert.Equal(t, []string{"Deployment default/web", "CronJob default/report"}, result.Evidence["violating_workloads"])
+	assert.Empty(t, result.Evidence["violating_pods"])
+	assert.Equal(t, 2, result.Evidence["violation_count"])
+
+	violations := result.Evidence["violations"].([]string)
+	assert.Contains(t, violations[0], "Deployment default/web[0]:app: missing securityContext.runAsNonRoot=true")
+}
+
+func TestWorkloadSecurityCheck_RemediationSteps(t *testing.T) {
+	privileged := true
+	template := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:            "app",
+					Image:           "ghcr.io/web:latest",
+					SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+				},
+			},
+		},
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Template: template},
+	}
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "batch"},
+		Spec: batchv1.CronJobSpec{
+			JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: template}},
+		},
+	}
+	// Standalone pods can't have their securityContext patched
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "default"},
+		Spec:       template.Spec,
+	}
+
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Workloads: &spec.WorkloadsSpec{
+				Containers: &spec.ContainerSpec{
+					Required: []spec.FieldRequirement{
+						{Key: "securityContext.runAsNonRoot", Value: "true"},
+					},
+					Forbidden: []spec.FieldRequirement{
+						{Key: "securityContext.privileged", Value: "true"},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("patches controllers and links docs for the rest", func(t *testing.T) {
+		client := fake.NewSimpleClientset(deployment, cronJob, pod)
+		result, err := (&WorkloadSecurityCheck{}).Run(context.Background(), client, clusterSpec)
+		assert.NoError(t, err)
+		assert.Equal(t, scanner.StatusFail, result.Status)
+
+		if assert.Len(t, result.RemediationSteps, 3) {
+			assert.Equal(t, scanner.RemediationStep{
+				Type:        scanner.RemediationStepKubectl,
+				Command:     `kubectl patch deployment web -n default --type strategic -p '{"spec":{"template":{"spec":{"containers":[{"name":"app","securityContext":{"privileged":false,"runAsNonRoot":true}}]}}}}'`,
+				Description: "Harden the container securityContext of Deployment default/web",
+			}, result.RemediationSteps[0])
+			assert.Equal(t, scanner.RemediationStepKubectl, result.RemediationSteps[1].Type)
+			assert.Equal(t, `kubectl patch cronjob report -n batch --type strategic -p '{"spec":{"jobTemplate":{"spec":{"template":{"spec":{"containers":[{"name":"app","securityContext":{"privileged":false,"runAsNonRoot":true}}]}}}}}}'`,
+				result.RemediationSteps[1].Command)
+			assert.Equal(t, scanner.RemediationStepDocLink, result.RemediationSteps[2].Type)
+			assert.NotEmpty(t, result.RemediationSteps[2].URL)
+		}
+	})
+
+	t.Run("no doc link when every violation is patched", func(t *testing.T) {
+		client := fake.NewSimpleClientset(deployment)
+		result, err := (&WorkloadSecurityCheck{}).Run(context.Background(), client, clusterSpec)
+		assert.NoError(t, err)
+		if assert.Len(t, result.RemediationSteps, 1) {
+			assert.Equal(t, scanner.RemediationStepKubectl, result.RemediationSteps[0].Type)
+		}
+	})
+}
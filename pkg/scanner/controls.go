@@ -0,0 +1,151 @@
+package scanner
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"gopkg.in/yaml.v3"
+)
+
+// builtinControlMappings maps the built-in checks to CIS Kubernetes Benchmark,
+// NIST 800-53 and PCI-DSS controls.
+//
+//go:embed controls.yaml
+var builtinControlMappings []byte
+
+// DefaultControlMappings returns the built-in mapping of checks to compliance
+// framework controls.
+func DefaultControlMappings() *spec.ComplianceSpec {
+	mappings, err := ParseControlMappings(builtinControlMappings)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in control mappings: %v", err))
+	}
+	return mappings
+}
+
+// LoadControlMappingsFile loads a control mappings file. It has the format of a
+// spec's compliance section: a list of frameworks whose controls map to checks.
+func LoadControlMappingsFile(path string) (*spec.ComplianceSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read control mappings file %s: %w", path, err)
+	}
+	mappings, err := ParseControlMappings(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid control mappings file %s: %w", path, err)
+	}
+	return mappings, nil
+}
+
+// ParseControlMappings parses control mappings in the format of a spec's
+// compliance section.
+func ParseControlMappings(data []byte) (*spec.ComplianceSpec, error) {
+	var mappings spec.ComplianceSpec
+	if err := yaml.Unmarshal(data, &mappings); err != nil {
+		return nil, err
+	}
+	for i, framework := range mappings.Frameworks {
+		if framework.Name == "" {
+			return nil, fmt.Errorf("frameworks[%d]: name is required", i)
+		}
+		for j, control := range framework.Controls {
+			if control.ID == "" {
+				return nil, fmt.Errorf("framework %s: controls[%d]: id is required", framework.Name, j)
+			}
+			for k, mapping := range control.Mappings {
+				if mapping.Check == "" {
+					return nil, fmt.Errorf("framework %s: control %s: mappings[%d]: check is required",
+						framework.Name, control.ID, k)
+				}
+			}
+		}
+	}
+	return &mappings, nil
+}
+
+// ControlIndex maps check names to the framework controls they provide
+// evidence for.
+type ControlIndex map[string][]ControlRef
+
+// NewControlIndex indexes the controls of the given compliance sections by
+// check. A control listed by several sections is indexed once, so a spec may
+// repeat a built-in control to add checks to it. Nil sections are skipped.
+func NewControlIndex(sections ...*spec.ComplianceSpec) ControlIndex {
+	index := ControlIndex{}
+	for _, section := range sections {
+		if section == nil {
+			continue
+		}
+		for _, framework := range section.Frameworks {
+			for _, control := range framework.Controls {
+				ref := ControlRef{Framework: framework.Name, ID: control.ID, Title: control.Title}
+				for _, mapping := range control.Mappings {
+					index.add(mapping.Check, ref)
+				}
+			}
+		}
+	}
+	return index
+}
+
+// NewControlIndexForSpec indexes the built-in control mappings, then the extra
+// mappings, then the compliance section of the cluster spec.
+func NewControlIndexForSpec(clusterSpec *spec.ClusterSpecification, extra ...*spec.ComplianceSpec) ControlIndex {
+	sections := append([]*spec.ComplianceSpec{DefaultControlMappings()}, extra...)
+	if clusterSpec != nil {
+		sections = append(sections, clusterSpec.Spec.Compliance)
+	}
+	return NewControlIndex(sections...)
+}
+
+// add records that a check provides evidence for a control, once.
+func (idx ControlIndex) add(checkName string, ref ControlRef) {
+	for i, existing := range idx[checkName] {
+		if existing.Framework == ref.Framework && existing.ID == ref.ID {
+			if existing.Title == "" {
+				idx[checkName][i].Title = ref.Title
+			}
+			return
+		}
+	}
+	idx[checkName] = append(idx[checkName], ref)
+}
+
+// Annotate sets the controls of each result from the index.
+func (idx ControlIndex) Annotate(results []CheckResult) {
+	for i := range results {
+		if refs := idx[results[i].Name]; len(refs) > 0 {
+			results[i].Controls = append([]ControlRef(nil), refs...)
+		}
+	}
+}
+
+// ChecksForFramework returns the checks mapped to a control of the framework,
+// matched as by MatchesFramework. A framework no check maps to is an error, so
+// a typo does not silently run nothing.
+func (idx ControlIndex) ChecksForFramework(checks []Check, framework string) ([]Check, error) {
+	selected := []Check{}
+	for _, check := range checks {
+		for _, ref := range idx[check.Name()] {
+			if MatchesFramework(ref.Framework, framework) {
+				selected = append(selected, check)
+				break
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no checks are mapped to framework %q", framework)
+	}
+	return selected, nil
+}
+
+// MatchesFramework reports whether a framework name matches a filter. Matching
+// ignores case, and a filter also matches names it is a dash-separated prefix
+// of, so "cis" matches "CIS-Kubernetes-v1.8".
+func MatchesFramework(name, filter string) bool {
+	name, filter = strings.ToLower(name), strings.ToLower(filter)
+	return name == filter || strings.HasPrefix(name, filter+"-")
+}
@@ -0,0 +1,147 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDefaultControlMappings(t *testing.T) {
+	mappings := DefaultControlMappings()
+
+	names := []string{}
+	for _, framework := range mappings.Frameworks {
+		names = append(names, framework.Name)
+	}
+	assert.Equal(t, []string{"CIS-Kubernetes-v1.8", "NIST-800-53", "PCI-DSS-v4.0"}, names)
+
+	index := NewControlIndex(mappings)
+	assert.Contains(t, index["workload.security"], ControlRef{
+		Framework: "CIS-Kubernetes-v1.8",
+		ID:        "5.2.2",
+		Title:     "Minimize the admission of privileged containers",
+	})
+}
+
+func TestParseControlMappings(t *testing.T) {
+	mappings, err := ParseControlMappings([]byte(`frameworks:
+  - name: ACME-Baseline
+    controls:
+      - id: NET-1
+        title: Namespaces are isolated
+        mappings:
+          - check: network.policies
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, "network.policies", mappings.Frameworks[0].Controls[0].Mappings[0].Check)
+
+	_, err = ParseControlMappings([]byte(`frameworks:
+  - controls:
+      - id: NET-1
+`))
+	assert.ErrorContains(t, err, "name is required")
+
+	_, err = ParseControlMappings([]byte(`frameworks:
+  - name: ACME-Baseline
+    controls:
+      - id: NET-1
+        mappings:
+          - check: ""
+`))
+	assert.ErrorContains(t, err, "control NET-1: mappings[0]: check is required")
+}
+
+func TestLoadControlMappingsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "controls.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("frameworks: [{name: ACME}]\n"), 0o644))
+
+	mappings, err := LoadControlMappingsFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "ACME", mappings.Frameworks[0].Name)
+
+	_, err = LoadControlMappingsFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read control mappings file")
+}
+
+func TestNewControlIndex_MergesSections(t *testing.T) {
+	builtin := &spec.ComplianceSpec{Frameworks: []spec.ComplianceFramework{{
+		Name: "NIST-800-53",
+		Controls: []spec.ComplianceControl{
+			{ID: "SC-7", Title: "Boundary Protection", Mappings: []spec.ControlMapping{{Check: "network.policies"}}},
+		},
+	}}}
+	// The spec repeats SC-7 for the same check and maps it to another check
+	fromSpec := &spec.ComplianceSpec{Frameworks: []spec.ComplianceFramework{{
+		Name: "NIST-800-53",
+		Controls: []spec.ComplianceControl{
+			{ID: "SC-7", Mappings: []spec.ControlMapping{{Check: "network.policies"}, {Check: "admission.controllers"}}},
+		},
+	}}}
+
+	index := NewControlIndex(builtin, nil, fromSpec)
+
+	assert.Equal(t, []ControlRef{{Framework: "NIST-800-53", ID: "SC-7", Title: "Boundary Protection"}}, index["network.policies"])
+	assert.Equal(t, []ControlRef{{Framework: "NIST-800-53", ID: "SC-7"}}, index["admission.controllers"])
+}
+
+func TestMatchesFramework(t *testing.T) {
+	assert.True(t, MatchesFramework("CIS-Kubernetes-v1.8", "cis"))
+	assert.True(t, MatchesFramework("CIS-Kubernetes-v1.8", "CIS-Kubernetes-v1.8"))
+	assert.True(t, MatchesFramework("NIST-800-53", "nist"))
+	assert.True(t, MatchesFramework("PCI-DSS-v4.0", "pci-dss"))
+	assert.False(t, MatchesFramework("CIS-Kubernetes-v1.8", "ci"))
+	assert.False(t, MatchesFramework("NIST-800-53", "pci"))
+}
+
+func TestChecksForFramework(t *testing.T) {
+	checks := []Check{
+		&stubCheck{name: "kubernetes.version"},
+		&stubCheck{name: "network.policies"},
+		&stubCheck{name: "capacity.pod-density"},
+	}
+	index := NewControlIndexForSpec(nil)
+
+	selected, err := index.ChecksForFramework(checks, "cis")
+	assert.NoError(t, err)
+	assert.Len(t, selected, 1)
+	assert.Equal(t, "network.policies", selected[0].Name())
+
+	selected, err = index.ChecksForFramework(checks, "nist")
+	assert.NoError(t, err)
+	assert.Len(t, selected, 2)
+
+	_, err = index.ChecksForFramework(checks, "iso-27001")
+	assert.ErrorContains(t, err, `no checks are mapped to framework "iso-27001"`)
+}
+
+func TestScan_AnnotatesControls(t *testing.T) {
+	checks := []Check{
+		&stubCheck{name: "network.policies", result: &CheckResult{Name: "network.policies", Status: StatusFail}},
+		&stubCheck{name: "capacity.pod-density", result: &CheckResult{Name: "capacity.pod-density", Status: StatusPass}},
+	}
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Compliance: &spec.ComplianceSpec{Frameworks: []spec.ComplianceFramework{{
+				Name:     "ACME-Baseline",
+				Controls: []spec.ComplianceControl{{ID: "CAP-1", Mappings: []spec.ControlMapping{{Check: "capacity.pod-density"}}}},
+			}}},
+		},
+	}
+	extra := &spec.ComplianceSpec{Frameworks: []spec.ComplianceFramework{{
+		Name:     "ACME-Network",
+		Controls: []spec.ComplianceControl{{ID: "NET-1", Mappings: []spec.ControlMapping{{Check: "network.policies"}}}},
+	}}}
+
+	s := NewScanner(fake.NewSimpleClientset(), checks).WithControlMappings(extra)
+	result, err := s.Scan(context.Background(), clusterSpec)
+
+	assert.NoError(t, err)
+	assert.Contains(t, result.Results[0].Controls, ControlRef{Framework: "NIST-800-53", ID: "SC-7", Title: "Boundary Protection"})
+	assert.Contains(t, result.Results[0].Controls, ControlRef{Framework: "ACME-Network", ID: "NET-1"})
+	assert.Equal(t, []ControlRef{{Framework: "ACME-Baseline", ID: "CAP-1"}}, result.Results[1].Controls)
+}
@@ -3,64 +3,188 @@ package scanner
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/cloudcwfranck/kspec/pkg/spec"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 const (
 	// Version is the kspec version
 	Version = "1.0.0"
+
+	// DefaultCheckTimeout bounds how long a single check may run unless overridden.
+	DefaultCheckTimeout = 2 * time.Minute
+
+	// DefaultConcurrency bounds how many checks run at once unless configured.
+	DefaultConcurrency = 4
+
+	// DefaultScanTimeout is the scan deadline the operator applies unless configured.
+	DefaultScanTimeout = 10 * time.Minute
 )
 
+// errCheckTimedOut is returned by runCheck when a check exceeds its timeout.
+var errCheckTimedOut = errors.New("timed out")
+
+// errScanTimedOut is returned by runCheck when the scan's deadline passes
+// before a check finishes.
+var errScanTimedOut = errors.New("scan deadline exceeded")
+
+// CheckTimeouts bounds how long individual checks may run, so a slow check
+// cannot stall the whole scan.
+type CheckTimeouts struct {
+	// Default applies to checks without an override. Zero disables the timeout.
+	Default time.Duration
+
+	// Overrides are keyed by check name (e.g. "rbac.validation").
+	Overrides map[string]time.Duration
+}
+
+// For returns the timeout for the named check, preferring its override.
+func (t CheckTimeouts) For(checkName string) time.Duration {
+	if timeout, ok := t.Overrides[checkName]; ok {
+		return timeout
+	}
+	return t.Default
+}
+
+// ScannerOptions configures how a Scanner runs its checks.
+type ScannerOptions struct {
+	// Concurrency bounds how many checks run at once (default
+	// DefaultConcurrency). 1 runs checks one after another.
+	Concurrency int
+}
+
 // Scanner orchestrates compliance checks against a cluster.
 type Scanner struct {
-	client kubernetes.Interface
-	checks []Check
+	client      kubernetes.Interface
+	checks      []Check
+	timeouts    CheckTimeouts
+	scanTimeout time.Duration
+	concurrency int
+
+	// controlMappings extend the built-in mapping of checks to framework controls
+	controlMappings []*spec.ComplianceSpec
 }
 
 // NewScanner creates a new scanner with the given Kubernetes client.
 func NewScanner(client kubernetes.Interface, checks []Check) *Scanner {
+	return NewScannerWithOptions(client, checks, ScannerOptions{})
+}
+
+// NewScannerWithOptions creates a new scanner with the given Kubernetes client
+// and options.
+func NewScannerWithOptions(client kubernetes.Interface, checks []Check, opts ScannerOptions) *Scanner {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
 	return &Scanner{
-		client: client,
-		checks: checks,
+		client:      client,
+		checks:      checks,
+		timeouts:    CheckTimeouts{Default: DefaultCheckTimeout},
+		concurrency: concurrency,
+	}
+}
+
+// WithCheckTimeouts sets how long individual checks may run.
+func (s *Scanner) WithCheckTimeouts(timeouts CheckTimeouts) *Scanner {
+	s.timeouts = timeouts
+	return s
+}
+
+// WithScanTimeout sets how long a whole scan may run. Checks still running at
+// the deadline are reported as errors and the scan returns with the results of
+// the others. Zero disables the deadline; a deadline on the context passed to
+// Scan applies either way.
+func (s *Scanner) WithScanTimeout(timeout time.Duration) *Scanner {
+	s.scanTimeout = timeout
+	return s
+}
+
+// WithControlMappings adds to the built-in mapping of checks to compliance
+// framework controls. A spec's compliance section is applied after these.
+func (s *Scanner) WithControlMappings(mappings ...*spec.ComplianceSpec) *Scanner {
+	s.controlMappings = append(s.controlMappings, mappings...)
+	return s
+}
+
+// NewScannerFromConfig creates a new scanner whose Kubernetes client is built
+// from the given REST config, so embedders can reuse their existing auth.
+func NewScannerFromConfig(config *rest.Config, checks []Check) (*Scanner, error) {
+	if config == nil {
+		return nil, fmt.Errorf("rest config cannot be nil")
+	}
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
 	}
+
+	return NewScanner(client, checks), nil
 }
 
 // Scan runs all checks against the cluster and returns aggregated results.
+// Checks run concurrently, bounded by the scanner's concurrency, and results
+// are returned in the order the checks were given. A check that errors, panics
+// or times out is reported as an error result without stopping the others,
+// and so is every check unfinished when the scan deadline passes.
+// Cluster-wide lists are shared between checks through a ClusterSnapshot.
 func (s *Scanner) Scan(ctx context.Context, clusterSpec *spec.ClusterSpecification) (*ScanResult, error) {
 	if clusterSpec == nil {
 		return nil, fmt.Errorf("cluster spec cannot be nil")
 	}
 
+	if s.scanTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.scanTimeout)
+		defer cancel()
+	}
+
 	// Get cluster information
 	clusterInfo, err := s.getClusterInfo(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cluster info: %w", err)
 	}
 
-	// Run all checks
-	var results []CheckResult
-	for _, check := range s.checks {
-		result, err := check.Run(ctx, s.client, clusterSpec)
-		if err != nil {
-			// If a check fails to run, record it as a failure
-			results = append(results, CheckResult{
-				Name:     check.Name(),
-				Status:   StatusFail,
-				Severity: SeverityHigh,
-				Message:  fmt.Sprintf("Check failed to execute: %v", err),
-			})
-			continue
-		}
-		results = append(results, *result)
+	// Checks share one snapshot of the cluster, so each resource is listed once
+	// per scan
+	if SnapshotFrom(ctx) == nil {
+		ctx = WithSnapshot(ctx, NewClusterSnapshot(s.client))
 	}
 
+	// Run all checks, each writing its result to the slot of its check
+	results := make([]CheckResult, len(s.checks))
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, s.concurrency)
+	for i, check := range s.checks {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, check Check) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			result, err := s.runCheck(ctx, check, clusterSpec)
+			results[i] = checkResult(check, result, err)
+		}(i, check)
+	}
+	wg.Wait()
+
+	applySeverityRemapping(results, &clusterSpec.Spec)
+	NewControlIndexForSpec(clusterSpec, s.controlMappings...).Annotate(results)
+
 	// Calculate summary
-	summary := calculateSummary(results)
+	summary := calculateSummary(results, clusterSpec.Spec.Scoring)
 
 	// Build scan result
 	scanResult := &ScanResult{
@@ -80,6 +204,116 @@ func (s *Scanner) Scan(ctx context.Context, clusterSpec *spec.ClusterSpecificati
 	return scanResult, nil
 }
 
+// checkResult converts the outcome of running a check into its result.
+func checkResult(check Check, result *CheckResult, err error) CheckResult {
+	if errors.Is(err, errCheckTimedOut) {
+		// A hung check must not stall the scan or pass as compliant
+		return CheckResult{
+			Name:        check.Name(),
+			Status:      StatusError,
+			Message:     fmt.Sprintf("Check %v", err),
+			Remediation: "Investigate why the check is slow or raise its timeout with --check-timeout-override",
+		}
+	}
+	if errors.Is(err, errScanTimedOut) {
+		// Checks cut off by the scan deadline are unevaluated, not compliant
+		return CheckResult{
+			Name:        check.Name(),
+			Status:      StatusError,
+			Message:     fmt.Sprintf("Check timed out: %v", err),
+			Remediation: "Investigate why the scan is slow or raise the scan timeout with --timeout",
+		}
+	}
+	if err != nil && apierrors.IsForbidden(err) {
+		// A permissions gap must not masquerade as compliance, so record it as an
+		// error rather than a skip
+		return CheckResult{
+			Name:        check.Name(),
+			Status:      StatusError,
+			Message:     fmt.Sprintf("Insufficient permissions to run check: %v", err),
+			Remediation: "Grant the scanning identity get/list access to the resource named in the message",
+		}
+	}
+	if err != nil {
+		// A check that cannot run says nothing about compliance
+		return CheckResult{
+			Name:    check.Name(),
+			Status:  StatusError,
+			Message: fmt.Sprintf("Check failed to execute: %v", err),
+		}
+	}
+	if result == nil {
+		return CheckResult{
+			Name:    check.Name(),
+			Status:  StatusError,
+			Message: "Check failed to execute: no result returned",
+		}
+	}
+	return *result
+}
+
+// runCheck runs a check under its timeout and the scan's deadline. The check
+// runs in its own goroutine so that one ignoring context cancellation still
+// cannot hang the scan.
+func (s *Scanner) runCheck(ctx context.Context, check Check, clusterSpec *spec.ClusterSpecification) (*CheckResult, error) {
+	// Checks still waiting for a slot at the scan deadline are not started
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, errScanTimedOut
+	}
+
+	checkCtx := ctx
+	timeout := s.timeouts.For(check.Name())
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		checkCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// Without a deadline or cancellation there is nothing to stop waiting for
+	if checkCtx.Done() == nil {
+		return s.runCheckSafely(ctx, check, clusterSpec)
+	}
+
+	type outcome struct {
+		result *CheckResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := s.runCheckSafely(checkCtx, check, clusterSpec)
+		done <- outcome{result: result, err: err}
+	}()
+
+	var o outcome
+	select {
+	case o = <-done:
+	case <-checkCtx.Done():
+		o.err = checkCtx.Err()
+	}
+
+	// Only deadlines count as timeouts; cancellation of the scan does not
+	if o.err != nil {
+		switch {
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return nil, errScanTimedOut
+		case ctx.Err() == nil && errors.Is(checkCtx.Err(), context.DeadlineExceeded):
+			return nil, fmt.Errorf("%w after %s", errCheckTimedOut, timeout)
+		}
+	}
+	return o.result, o.err
+}
+
+// runCheckSafely runs a check, recovering a panic as an error so that one
+// faulty check cannot crash the scan.
+func (s *Scanner) runCheckSafely(ctx context.Context, check Check, clusterSpec *spec.ClusterSpecification) (result *CheckResult, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result, err = nil, fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	return check.Run(ctx, s.client, clusterSpec)
+}
+
 // getClusterInfo retrieves information about the cluster.
 func (s *Scanner) getClusterInfo(ctx context.Context) (*ClusterInfo, error) {
 	version, err := s.client.Discovery().ServerVersion()
@@ -104,24 +338,155 @@ func (s *Scanner) getClusterInfo(ctx context.Context) (*ClusterInfo, error) {
 	}, nil
 }
 
-// calculateSummary calculates summary statistics from check results.
-func calculateSummary(results []CheckResult) ScanSummary {
+// SelectChecks returns the checks whose names match one of the include patterns,
+// or all checks when there are none, minus those matching an exclude pattern.
+// Patterns are globs (e.g. "rbac.*"). An include pattern that matches no check
+// is an error, so a typo does not silently run nothing.
+func SelectChecks(checks []Check, include, exclude []string) ([]Check, error) {
+	for _, pattern := range append(append([]string{}, include...), exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid check pattern %q: %w", pattern, err)
+		}
+	}
+
+	unmatched := []string{}
+	for _, pattern := range include {
+		if !matchesAnyCheck(checks, pattern) {
+			unmatched = append(unmatched, pattern)
+		}
+	}
+	if len(unmatched) > 0 {
+		return nil, fmt.Errorf("unknown checks: %s", strings.Join(unmatched, ", "))
+	}
+
+	selected := []Check{}
+	for _, check := range checks {
+		if len(include) > 0 && !matchesAnyPattern(check.Name(), include) {
+			continue
+		}
+		if matchesAnyPattern(check.Name(), exclude) {
+			continue
+		}
+		selected = append(selected, check)
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no checks left to run after exclusions")
+	}
+	return selected, nil
+}
+
+// matchesAnyCheck reports whether a pattern matches the name of one of the checks
+func matchesAnyCheck(checks []Check, pattern string) bool {
+	for _, check := range checks {
+		if matched, _ := path.Match(pattern, check.Name()); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyPattern reports whether a check name matches one of the patterns
+func matchesAnyPattern(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateSeverityOverrides checks that every severityOverrides entry names one of
+// the given checks, so typos do not silently leave a severity unchanged.
+func ValidateSeverityOverrides(overrides map[string]string, checks []Check) error {
+	known := make(map[string]bool, len(checks))
+	for _, check := range checks {
+		known[check.Name()] = true
+	}
+
+	unknown := []string{}
+	for checkName := range overrides {
+		if !known[checkName] {
+			unknown = append(unknown, checkName)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("severityOverrides references unknown checks: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// ValidateScoringWeights checks that every scoring weight names one of the given
+// checks or a check category, so typos do not silently leave a weight at 1.
+func ValidateScoringWeights(scoring *spec.ScoringSpec, checks []Check) error {
+	if scoring == nil {
+		return nil
+	}
+
+	known := make(map[string]bool, len(checks)*2)
+	for _, check := range checks {
+		known[check.Name()] = true
+		known[strings.SplitN(check.Name(), ".", 2)[0]] = true
+	}
+
+	unknown := []string{}
+	for key := range scoring.Weights {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("scoring.weights references unknown checks: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// applySeverityRemapping applies the spec's per-check severity overrides to failed
+// and warning results, then labels every severity using the spec's label table.
+func applySeverityRemapping(results []CheckResult, fields *spec.SpecFields) {
+	for i := range results {
+		result := &results[i]
+		if override, ok := fields.SeverityOverrides[result.Name]; ok &&
+			(result.Status == StatusFail || result.Status == StatusWarn) {
+			result.Severity = Severity(override)
+		}
+		if result.Severity != "" {
+			result.SeverityLabel = fields.SeverityLabels[string(result.Severity)]
+		}
+	}
+}
+
+// calculateSummary calculates summary statistics from check results, weighting
+// the score by the spec's per-check scoring weights.
+func calculateSummary(results []CheckResult, scoring *spec.ScoringSpec) ScanSummary {
 	summary := ScanSummary{
 		TotalChecks: len(results),
 	}
 
+	totalWeight, passedWeight := 0, 0
 	for _, result := range results {
+		weight := scoring.Weight(result.Name)
+		totalWeight += weight
+
 		switch result.Status {
 		case StatusPass:
 			summary.Passed++
+			passedWeight += weight
 		case StatusFail:
 			summary.Failed++
 		case StatusWarn:
 			summary.Warnings++
 		case StatusSkip:
 			summary.Skipped++
+		case StatusError:
+			summary.Errors++
 		}
 	}
 
+	if totalWeight > 0 {
+		summary.WeightedScore = float64(passedWeight) / float64(totalWeight) * 100
+	}
+
 	return summary
 }
@@ -0,0 +1,547 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+// stubMetadata provides the descriptive methods of a Check.
+type stubMetadata struct {
+	description string
+}
+
+func (m stubMetadata) Description() string  { return m.description }
+func (m stubMetadata) SpecFields() []string { return nil }
+func (m stubMetadata) Severity() Severity   { return SeverityMedium }
+func (m stubMetadata) Remediation() string  { return "" }
+
+// stubCheck returns a fixed result or error.
+type stubCheck struct {
+	stubMetadata
+	name   string
+	result *CheckResult
+	err    error
+}
+
+func (c *stubCheck) Name() string { return c.name }
+
+func (c *stubCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*CheckResult, error) {
+	return c.result, c.err
+}
+
+// delayedCheck passes after a delay, optionally tracking how many checks run
+// at once.
+type delayedCheck struct {
+	stubMetadata
+	name    string
+	delay   time.Duration
+	running *atomic.Int32
+	peak    *atomic.Int32
+}
+
+func (c *delayedCheck) Name() string { return c.name }
+
+func (c *delayedCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*CheckResult, error) {
+	if c.running != nil {
+		current := c.running.Add(1)
+		defer c.running.Add(-1)
+		for {
+			peak := c.peak.Load()
+			if current <= peak || c.peak.CompareAndSwap(peak, current) {
+				break
+			}
+		}
+	}
+	time.Sleep(c.delay)
+	return &CheckResult{Name: c.name, Status: StatusPass}, nil
+}
+
+// slowCheck blocks until its context is done, or until release is closed when
+// it ignores the context.
+type slowCheck struct {
+	stubMetadata
+	name      string
+	ignoreCtx bool
+	release   chan struct{}
+}
+
+func (c *slowCheck) Name() string { return c.name }
+
+func (c *slowCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*CheckResult, error) {
+	if c.ignoreCtx {
+		<-c.release
+		return &CheckResult{Name: c.name, Status: StatusPass}, nil
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(100 * time.Millisecond):
+		return &CheckResult{Name: c.name, Status: StatusPass}, nil
+	}
+}
+
+func TestScan_CheckTimeouts(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	checks := []Check{
+		&slowCheck{name: "slow.honors-context"},
+		&slowCheck{name: "slow.ignores-context", ignoreCtx: true, release: release},
+		&slowCheck{name: "slow.overridden"},
+		&stubCheck{name: "fast", result: &CheckResult{Name: "fast", Status: StatusPass}},
+	}
+
+	s := NewScanner(fake.NewSimpleClientset(), checks).WithCheckTimeouts(CheckTimeouts{
+		Default:   20 * time.Millisecond,
+		Overrides: map[string]time.Duration{"slow.overridden": time.Minute},
+	})
+	result, err := s.Scan(context.Background(), &spec.ClusterSpecification{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, StatusError, result.Results[0].Status)
+	assert.Contains(t, result.Results[0].Message, "timed out after 20ms")
+	assert.Equal(t, StatusError, result.Results[1].Status)
+	assert.Contains(t, result.Results[1].Message, "timed out")
+	assert.Equal(t, StatusPass, result.Results[2].Status)
+	assert.Equal(t, StatusPass, result.Results[3].Status)
+	assert.Equal(t, 2, result.Summary.Errors)
+	assert.Equal(t, 2, result.Summary.Passed)
+}
+
+func TestScan_ScanTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	checks := []Check{
+		&stubCheck{name: "fast", result: &CheckResult{Name: "fast", Status: StatusPass}},
+		&slowCheck{name: "slow.ignores-context", ignoreCtx: true, release: release},
+		&slowCheck{name: "slow.queued", ignoreCtx: true, release: release},
+	}
+
+	// Run checks one at a time, so the second slow check only starts once the
+	// deadline has passed
+	s := NewScannerWithOptions(fake.NewSimpleClientset(), checks, ScannerOptions{Concurrency: 1}).
+		WithCheckTimeouts(CheckTimeouts{}).
+		WithScanTimeout(50 * time.Millisecond)
+
+	done := make(chan struct{})
+	var result *ScanResult
+	var err error
+	go func() {
+		defer close(done)
+		result, err = s.Scan(context.Background(), &spec.ClusterSpecification{})
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scan did not complete after its deadline")
+	}
+
+	assert.NoError(t, err)
+	assert.Equal(t, StatusPass, result.Results[0].Status)
+	for _, r := range result.Results[1:] {
+		assert.Equal(t, StatusError, r.Status, r.Name)
+		assert.Equal(t, "Check timed out: scan deadline exceeded", r.Message, r.Name)
+	}
+	assert.Equal(t, 2, result.Summary.Errors)
+	assert.Equal(t, 1, result.Summary.Passed)
+}
+
+func TestScan_ForbiddenIsReportedAsError(t *testing.T) {
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", fmt.Errorf("access denied"))
+
+	checks := []Check{
+		&stubCheck{name: "absent", result: &CheckResult{Name: "absent", Status: StatusSkip}},
+		&stubCheck{name: "denied", err: fmt.Errorf("failed to list pods: %w", forbidden)},
+		&stubCheck{name: "broken", err: fmt.Errorf("connection refused")},
+	}
+
+	s := NewScanner(fake.NewSimpleClientset(), checks)
+	result, err := s.Scan(context.Background(), &spec.ClusterSpecification{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, StatusSkip, result.Results[0].Status)
+	assert.Equal(t, StatusError, result.Results[1].Status)
+	assert.Contains(t, result.Results[1].Message, "Insufficient permissions")
+	assert.Equal(t, StatusError, result.Results[2].Status)
+	assert.Contains(t, result.Results[2].Message, "Check failed to execute")
+
+	assert.Equal(t, 1, result.Summary.Skipped)
+	assert.Equal(t, 2, result.Summary.Errors)
+	assert.Equal(t, 0, result.Summary.Failed)
+}
+
+// panicCheck panics when run.
+type panicCheck struct {
+	stubMetadata
+	name string
+}
+
+func (c *panicCheck) Name() string { return c.name }
+
+func (c *panicCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*CheckResult, error) {
+	panic("boom")
+}
+
+func TestScan_ConcurrentResultsKeepCheckOrder(t *testing.T) {
+	var checks []Check
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("check-%02d", i)
+		// Earlier checks finish last, so completion order is the reverse of check order
+		checks = append(checks, &delayedCheck{
+			name:  name,
+			delay: time.Duration(20-i) * time.Millisecond,
+		})
+	}
+	checks = append(checks, &panicCheck{name: "panics"}, &stubCheck{name: "nil-result"})
+
+	for _, concurrency := range []int{1, 4, len(checks)} {
+		s := NewScannerWithOptions(fake.NewSimpleClientset(), checks, ScannerOptions{Concurrency: concurrency})
+		result, err := s.Scan(context.Background(), &spec.ClusterSpecification{})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Results, len(checks))
+		for i, check := range checks {
+			assert.Equal(t, check.Name(), result.Results[i].Name, "concurrency %d", concurrency)
+		}
+		assert.Equal(t, StatusError, result.Results[20].Status)
+		assert.Contains(t, result.Results[20].Message, "panic: boom")
+		assert.Equal(t, StatusError, result.Results[21].Status)
+		assert.Equal(t, 20, result.Summary.Passed)
+		assert.Equal(t, 2, result.Summary.Errors)
+	}
+}
+
+func TestScan_ConcurrencyIsBounded(t *testing.T) {
+	var running, peak atomic.Int32
+	var checks []Check
+	for i := 0; i < 12; i++ {
+		checks = append(checks, &delayedCheck{
+			name:    fmt.Sprintf("check-%02d", i),
+			delay:   5 * time.Millisecond,
+			running: &running,
+			peak:    &peak,
+		})
+	}
+
+	s := NewScannerWithOptions(fake.NewSimpleClientset(), checks, ScannerOptions{Concurrency: 3})
+	_, err := s.Scan(context.Background(), &spec.ClusterSpecification{})
+
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+	assert.Greater(t, peak.Load(), int32(1))
+}
+
+func TestNewScannerWithOptions_DefaultConcurrency(t *testing.T) {
+	assert.Equal(t, DefaultConcurrency, NewScanner(fake.NewSimpleClientset(), nil).concurrency)
+	assert.Equal(t, DefaultConcurrency, NewScannerWithOptions(fake.NewSimpleClientset(), nil, ScannerOptions{Concurrency: -1}).concurrency)
+	assert.Equal(t, 1, NewScannerWithOptions(fake.NewSimpleClientset(), nil, ScannerOptions{Concurrency: 1}).concurrency)
+}
+
+func TestScan_SeverityRemapping(t *testing.T) {
+	checks := []Check{
+		&stubCheck{name: "workload.security", result: &CheckResult{Name: "workload.security", Status: StatusFail, Severity: SeverityMedium}},
+		&stubCheck{name: "rbac.validation", result: &CheckResult{Name: "rbac.validation", Status: StatusFail, Severity: SeverityHigh}},
+		&stubCheck{name: "network.policies", result: &CheckResult{Name: "network.policies", Status: StatusPass}},
+	}
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			SeverityOverrides: map[string]string{"workload.security": "critical", "network.policies": "low"},
+			SeverityLabels:    map[string]string{"critical": "P1", "high": "P2"},
+		},
+	}
+
+	s := NewScanner(fake.NewSimpleClientset(), checks)
+	result, err := s.Scan(context.Background(), clusterSpec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, SeverityCritical, result.Results[0].Severity)
+	assert.Equal(t, "P1", result.Results[0].SeverityLabel)
+	assert.Equal(t, SeverityHigh, result.Results[1].Severity)
+	assert.Equal(t, "P2", result.Results[1].SeverityLabel)
+	// Passing checks carry no severity, so overrides do not apply
+	assert.Empty(t, result.Results[2].Severity)
+	assert.Empty(t, result.Results[2].SeverityLabel)
+}
+
+func TestValidateSeverityOverrides(t *testing.T) {
+	checks := []Check{&stubCheck{name: "workload.security"}}
+
+	assert.NoError(t, ValidateSeverityOverrides(map[string]string{"workload.security": "critical"}, checks))
+
+	err := ValidateSeverityOverrides(map[string]string{"workload.securty": "critical"}, checks)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "workload.securty")
+}
+
+// playbookCheck is a stubCheck that provides a remediation playbook.
+type playbookCheck struct {
+	stubCheck
+	playbook string
+}
+
+func (c *playbookCheck) Playbook() string { return c.playbook }
+
+func TestPlaybook(t *testing.T) {
+	result := &CheckResult{Name: "stub", Status: StatusFail, Remediation: "Fix it"}
+
+	assert.Equal(t, "Fix it", Playbook(&stubCheck{name: "stub"}, result))
+	assert.Equal(t, "1. Step one", Playbook(&playbookCheck{stubCheck: stubCheck{name: "stub"}, playbook: "1. Step one"}, result))
+	// An empty playbook falls back to the remediation
+	assert.Equal(t, "Fix it", Playbook(&playbookCheck{stubCheck: stubCheck{name: "stub"}}, result))
+}
+
+func TestSummary(t *testing.T) {
+	check := &stubCheck{name: "stub", stubMetadata: stubMetadata{description: "Checks a thing.\n\nIn detail."}}
+	assert.Equal(t, "Checks a thing.", Summary(check))
+
+	check.description = "Single line."
+	assert.Equal(t, "Single line.", Summary(check))
+}
+
+func TestScan_WeightedScore(t *testing.T) {
+	checks := []Check{
+		&stubCheck{name: "rbac.validation", result: &CheckResult{Name: "rbac.validation", Status: StatusFail, Severity: SeverityHigh}},
+		&stubCheck{name: "observability.validation", result: &CheckResult{Name: "observability.validation", Status: StatusPass}},
+		&stubCheck{name: "network.policies", result: &CheckResult{Name: "network.policies", Status: StatusPass}},
+		&stubCheck{name: "workload.probes", result: &CheckResult{Name: "workload.probes", Status: StatusFail, Severity: SeverityMedium}},
+	}
+	s := NewScanner(fake.NewSimpleClientset(), checks)
+
+	// Default weights of 1 give the pass rate
+	result, err := s.Scan(context.Background(), &spec.ClusterSpecification{})
+	assert.NoError(t, err)
+	assert.InDelta(t, 50.0, result.Summary.WeightedScore, 0.001)
+
+	// rbac weighs 3, observability 1, workload.probes is left out, network defaults to 1
+	clusterSpec := &spec.ClusterSpecification{
+		Spec: spec.SpecFields{
+			Scoring: &spec.ScoringSpec{Weights: map[string]int{"rbac": 3, "observability": 1, "workload.probes": 0}},
+		},
+	}
+	result, err = s.Scan(context.Background(), clusterSpec)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Summary.Passed)
+	assert.InDelta(t, 40.0, result.Summary.WeightedScore, 0.001)
+}
+
+func TestValidateScoringWeights(t *testing.T) {
+	checks := []Check{&stubCheck{name: "rbac.validation"}, &stubCheck{name: "workload.security"}}
+
+	assert.NoError(t, ValidateScoringWeights(nil, checks))
+	assert.NoError(t, ValidateScoringWeights(&spec.ScoringSpec{Weights: map[string]int{"rbac": 3, "workload.security": 2}}, checks))
+
+	err := ValidateScoringWeights(&spec.ScoringSpec{Weights: map[string]int{"rbca": 3, "workload.securty": 2}}, checks)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "rbca, workload.securty")
+}
+
+func TestNewScannerFromConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/version":
+			fmt.Fprint(w, `{"gitVersion":"v1.29.0"}`)
+		case "/api/v1/namespaces/kube-system":
+			fmt.Fprint(w, `{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"kube-system","uid":"cluster-uid"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	checks := []Check{&stubCheck{name: "rbac.validation", result: &CheckResult{Name: "rbac.validation", Status: StatusPass}}}
+	s, err := NewScannerFromConfig(&rest.Config{Host: server.URL}, checks)
+	assert.NoError(t, err)
+
+	result, err := s.Scan(context.Background(), &spec.ClusterSpecification{})
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.29.0", result.Metadata.Cluster.Version)
+	assert.Equal(t, "cluster-uid", result.Metadata.Cluster.UID)
+	assert.Equal(t, 1, result.Summary.Passed)
+
+	_, err = NewScannerFromConfig(nil, checks)
+	assert.Error(t, err)
+}
+
+func TestParseSkipReasons(t *testing.T) {
+	reasons, err := ParseSkipReasons([]string{"permissiondenied", " FeatureUnavailable"})
+	assert.NoError(t, err)
+	assert.Equal(t, []SkipReason{SkipReasonPermissionDenied, SkipReasonFeatureUnavailable}, reasons)
+
+	reasons, err = ParseSkipReasons([]string{"all"})
+	assert.NoError(t, err)
+	assert.Equal(t, SkipReasons, reasons)
+
+	reasons, err = ParseSkipReasons(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, reasons)
+
+	_, err = ParseSkipReasons([]string{"Forbidden"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Forbidden")
+}
+
+func TestSkippedFor(t *testing.T) {
+	results := []CheckResult{
+		{Name: "network.policies", Status: StatusSkip, SkipReason: SkipReasonSpecSectionAbsent},
+		{Name: "kubernetes.deprecated-apis", Status: StatusSkip, SkipReason: SkipReasonFeatureUnavailable},
+		{Name: "rbac.validation", Status: StatusPass},
+	}
+
+	skipped := SkippedFor(results, []SkipReason{SkipReasonFeatureUnavailable})
+	assert.Len(t, skipped, 1)
+	assert.Equal(t, "kubernetes.deprecated-apis", skipped[0].Name)
+
+	assert.Empty(t, SkippedFor(results, nil))
+}
+
+func TestSelectChecks(t *testing.T) {
+	checks := []Check{
+		&stubCheck{name: "kubernetes.version"},
+		&stubCheck{name: "workload.security"},
+		&stubCheck{name: "rbac.validation"},
+		&stubCheck{name: "rbac.minimization"},
+	}
+
+	names := func(selected []Check) []string {
+		result := []string{}
+		for _, check := range selected {
+			result = append(result, check.Name())
+		}
+		return result
+	}
+
+	selected, err := SelectChecks(checks, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, selected, 4)
+
+	selected, err = SelectChecks(checks, []string{"kubernetes.version", "workload.security"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"kubernetes.version", "workload.security"}, names(selected))
+
+	selected, err = SelectChecks(checks, nil, []string{"rbac.*"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"kubernetes.version", "workload.security"}, names(selected))
+
+	selected, err = SelectChecks(checks, []string{"rbac.*"}, []string{"rbac.minimization"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"rbac.validation"}, names(selected))
+
+	_, err = SelectChecks(checks, []string{"kubernetes.verison"}, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "kubernetes.verison")
+
+	_, err = SelectChecks(checks, nil, []string{"*"})
+	assert.Error(t, err)
+
+	_, err = SelectChecks(checks, nil, []string{"rbac.["})
+	assert.Error(t, err)
+}
+
+func TestParseSeverity(t *testing.T) {
+	severity, err := ParseSeverity(" High")
+	assert.NoError(t, err)
+	assert.Equal(t, SeverityHigh, severity)
+
+	_, err = ParseSeverity("none")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "none")
+}
+
+func TestSeverityAtLeast(t *testing.T) {
+	assert.True(t, SeverityCritical.AtLeast(SeverityHigh))
+	assert.True(t, SeverityMedium.AtLeast(SeverityMedium))
+	assert.False(t, SeverityLow.AtLeast(SeverityMedium))
+
+	// Unset severities rank as low
+	assert.True(t, Severity("").AtLeast(SeverityLow))
+	assert.False(t, Severity("").AtLeast(SeverityMedium))
+}
+
+func TestFailedAtOrAbove(t *testing.T) {
+	results := []CheckResult{
+		{Name: "workload.security", Status: StatusFail, Severity: SeverityHigh},
+		{Name: "observability.logging", Status: StatusFail, Severity: SeverityLow},
+		{Name: "rbac.validation", Status: StatusError, Severity: SeverityCritical},
+		{Name: "kubernetes.version", Status: StatusPass},
+	}
+
+	failed := FailedAtOrAbove(results, SeverityHigh)
+	assert.Len(t, failed, 1)
+	assert.Equal(t, "workload.security", failed[0].Name)
+
+	assert.Len(t, FailedAtOrAbove(results, SeverityLow), 2)
+	assert.Empty(t, FailedAtOrAbove(results, SeverityCritical))
+}
+
+// podListCheck lists all pods and passes if none runs as privileged. Latency
+// simulates the API server round trip, which the fake clientset lacks.
+type podListCheck struct {
+	stubMetadata
+	name    string
+	latency time.Duration
+}
+
+func (c *podListCheck) Name() string { return c.name }
+
+func (c *podListCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*CheckResult, error) {
+	time.Sleep(c.latency)
+	pods, err := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			if sc := container.SecurityContext; sc != nil && sc.Privileged != nil && *sc.Privileged {
+				return &CheckResult{Name: c.name, Status: StatusFail, Severity: SeverityHigh}, nil
+			}
+		}
+	}
+	return &CheckResult{Name: c.name, Status: StatusPass}, nil
+}
+
+// BenchmarkScan compares sequential and concurrent scans of a fake cluster
+// with many pods, where each check pays a simulated API server latency.
+func BenchmarkScan(b *testing.B) {
+	var objects []runtime.Object
+	for i := 0; i < 1000; i++ {
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pod-%d", i), Namespace: fmt.Sprintf("ns-%d", i%20)},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx:1.25"}}},
+		})
+	}
+	client := fake.NewSimpleClientset(objects...)
+
+	var checks []Check
+	for i := 0; i < 15; i++ {
+		checks = append(checks, &podListCheck{name: fmt.Sprintf("check-%02d", i), latency: 20 * time.Millisecond})
+	}
+
+	for _, concurrency := range []int{1, DefaultConcurrency, len(checks)} {
+		b.Run(fmt.Sprintf("concurrency-%d", concurrency), func(b *testing.B) {
+			s := NewScannerWithOptions(client, checks, ScannerOptions{Concurrency: concurrency})
+			for i := 0; i < b.N; i++ {
+				if _, err := s.Scan(context.Background(), &spec.ClusterSpecification{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package scanner
+
+import (
+	"context"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// snapshotKey is the context key under which a scan's ClusterSnapshot is stored.
+type snapshotKey struct{}
+
+// ClusterSnapshot caches the cluster-wide lists that several checks read, so a
+// scan lists each resource once instead of once per check. Each list is loaded
+// on first use and shared by every later caller; failed loads are not cached.
+//
+// Lists returned from a snapshot are shared between checks and must be treated
+// as read-only.
+type ClusterSnapshot struct {
+	client kubernetes.Interface
+
+	pods                cachedList
+	namespaces          cachedList
+	clusterRoles        cachedList
+	roles               cachedList
+	clusterRoleBindings cachedList
+	roleBindings        cachedList
+	deployments         cachedList
+	statefulSets        cachedList
+	daemonSets          cachedList
+}
+
+// NewClusterSnapshot creates an empty snapshot that lists through client.
+func NewClusterSnapshot(client kubernetes.Interface) *ClusterSnapshot {
+	return &ClusterSnapshot{client: client}
+}
+
+// WithSnapshot returns a context carrying snapshot for checks run under it.
+func WithSnapshot(ctx context.Context, snapshot *ClusterSnapshot) context.Context {
+	return context.WithValue(ctx, snapshotKey{}, snapshot)
+}
+
+// SnapshotFrom returns the snapshot carried by ctx, or nil if there is none.
+func SnapshotFrom(ctx context.Context) *ClusterSnapshot {
+	snapshot, _ := ctx.Value(snapshotKey{}).(*ClusterSnapshot)
+	return snapshot
+}
+
+// cachedList holds one resource list, loaded at most once successfully.
+type cachedList struct {
+	mu     sync.Mutex
+	list   interface{}
+	loaded bool
+}
+
+// get returns the cached list, loading it first if needed. Concurrent callers
+// wait for a single load rather than each listing the resource.
+func (c *cachedList) get(load func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.loaded {
+		return c.list, nil
+	}
+	list, err := load()
+	if err != nil {
+		return nil, err
+	}
+	c.list, c.loaded = list, true
+	return list, nil
+}
+
+// ListPods lists pods in all namespaces, from the scan's snapshot when ctx
+// carries one and from the API server otherwise.
+func ListPods(ctx context.Context, client kubernetes.Interface) (*corev1.PodList, error) {
+	snapshot := SnapshotFrom(ctx)
+	if snapshot == nil {
+		return client.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	}
+	list, err := snapshot.pods.get(func() (interface{}, error) {
+		return snapshot.client.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.(*corev1.PodList), nil
+}
+
+// ListNamespaces lists namespaces, from the scan's snapshot when ctx carries one.
+func ListNamespaces(ctx context.Context, client kubernetes.Interface) (*corev1.NamespaceList, error) {
+	snapshot := SnapshotFrom(ctx)
+	if snapshot == nil {
+		return client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	}
+	list, err := snapshot.namespaces.get(func() (interface{}, error) {
+		return snapshot.client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.(*corev1.NamespaceList), nil
+}
+
+// ListClusterRoles lists ClusterRoles, from the scan's snapshot when ctx
+// carries one.
+func ListClusterRoles(ctx context.Context, client kubernetes.Interface) (*rbacv1.ClusterRoleList, error) {
+	snapshot := SnapshotFrom(ctx)
+	if snapshot == nil {
+		return client.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	}
+	list, err := snapshot.clusterRoles.get(func() (interface{}, error) {
+		return snapshot.client.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.(*rbacv1.ClusterRoleList), nil
+}
+
+// ListRoles lists Roles in all namespaces, from the scan's snapshot when ctx
+// carries one.
+func ListRoles(ctx context.Context, client kubernetes.Interface) (*rbacv1.RoleList, error) {
+	snapshot := SnapshotFrom(ctx)
+	if snapshot == nil {
+		return client.RbacV1().Roles("").List(ctx, metav1.ListOptions{})
+	}
+	list, err := snapshot.roles.get(func() (interface{}, error) {
+		return snapshot.client.RbacV1().Roles("").List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.(*rbacv1.RoleList), nil
+}
+
+// ListClusterRoleBindings lists ClusterRoleBindings, from the scan's snapshot
+// when ctx carries one.
+func ListClusterRoleBindings(ctx context.Context, client kubernetes.Interface) (*rbacv1.ClusterRoleBindingList, error) {
+	snapshot := SnapshotFrom(ctx)
+	if snapshot == nil {
+		return client.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	}
+	list, err := snapshot.clusterRoleBindings.get(func() (interface{}, error) {
+		return snapshot.client.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.(*rbacv1.ClusterRoleBindingList), nil
+}
+
+// ListRoleBindings lists RoleBindings in all namespaces, from the scan's
+// snapshot when ctx carries one.
+func ListRoleBindings(ctx context.Context, client kubernetes.Interface) (*rbacv1.RoleBindingList, error) {
+	snapshot := SnapshotFrom(ctx)
+	if snapshot == nil {
+		return client.RbacV1().RoleBindings("").List(ctx, metav1.ListOptions{})
+	}
+	list, err := snapshot.roleBindings.get(func() (interface{}, error) {
+		return snapshot.client.RbacV1().RoleBindings("").List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.(*rbacv1.RoleBindingList), nil
+}
+
+// ListDeployments lists Deployments in all namespaces, from the scan's
+// snapshot when ctx carries one.
+func ListDeployments(ctx context.Context, client kubernetes.Interface) (*appsv1.DeploymentList, error) {
+	snapshot := SnapshotFrom(ctx)
+	if snapshot == nil {
+		return client.AppsV1().Deployments("").List(ctx, metav1.ListOptions{})
+	}
+	list, err := snapshot.deployments.get(func() (interface{}, error) {
+		return snapshot.client.AppsV1().Deployments("").List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.(*appsv1.DeploymentList), nil
+}
+
+// ListStatefulSets lists StatefulSets in all namespaces, from the scan's
+// snapshot when ctx carries one.
+func ListStatefulSets(ctx context.Context, client kubernetes.Interface) (*appsv1.StatefulSetList, error) {
+	snapshot := SnapshotFrom(ctx)
+	if snapshot == nil {
+		return client.AppsV1().StatefulSets("").List(ctx, metav1.ListOptions{})
+	}
+	list, err := snapshot.statefulSets.get(func() (interface{}, error) {
+		return snapshot.client.AppsV1().StatefulSets("").List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.(*appsv1.StatefulSetList), nil
+}
+
+// ListDaemonSets lists DaemonSets in all namespaces, from the scan's snapshot
+// when ctx carries one.
+func ListDaemonSets(ctx context.Context, client kubernetes.Interface) (*appsv1.DaemonSetList, error) {
+	snapshot := SnapshotFrom(ctx)
+	if snapshot == nil {
+		return client.AppsV1().DaemonSets("").List(ctx, metav1.ListOptions{})
+	}
+	list, err := snapshot.daemonSets.get(func() (interface{}, error) {
+		return snapshot.client.AppsV1().DaemonSets("").List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.(*appsv1.DaemonSetList), nil
+}
@@ -0,0 +1,116 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// sharedListCheck lists pods and namespaces through the snapshot helpers.
+type sharedListCheck struct {
+	stubMetadata
+	name string
+}
+
+func (c *sharedListCheck) Name() string { return c.name }
+
+func (c *sharedListCheck) Run(ctx context.Context, client kubernetes.Interface, clusterSpec *spec.ClusterSpecification) (*CheckResult, error) {
+	pods, err := ListPods(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := ListNamespaces(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return &CheckResult{
+		Name:     c.name,
+		Status:   StatusPass,
+		Evidence: map[string]interface{}{"pods": len(pods.Items), "namespaces": len(namespaces.Items)},
+	}, nil
+}
+
+// countLists returns the number of list calls the fake client received per resource.
+func countLists(client *fake.Clientset) map[string]int {
+	counts := map[string]int{}
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "list" {
+			counts[action.GetResource().Resource]++
+		}
+	}
+	return counts
+}
+
+func TestScan_ChecksShareSnapshot(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "apps"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "apps"}},
+	)
+
+	var checks []Check
+	for i := 0; i < 6; i++ {
+		checks = append(checks, &sharedListCheck{name: fmt.Sprintf("check-%d", i)})
+	}
+
+	s := NewScanner(client, checks)
+	result, err := s.Scan(context.Background(), &spec.ClusterSpecification{})
+
+	require.NoError(t, err)
+	for _, checkResult := range result.Results {
+		assert.Equal(t, StatusPass, checkResult.Status)
+		assert.Equal(t, 2, checkResult.Evidence["pods"])
+		assert.Equal(t, 1, checkResult.Evidence["namespaces"])
+	}
+	assert.Equal(t, map[string]int{"pods": 1, "namespaces": 1}, countLists(client))
+
+	// A second scan takes a fresh snapshot
+	_, err = s.Scan(context.Background(), &spec.ClusterSpecification{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"pods": 2, "namespaces": 2}, countLists(client))
+}
+
+func TestListPods_WithoutSnapshotListsLive(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "apps"}})
+
+	for i := 0; i < 3; i++ {
+		pods, err := ListPods(context.Background(), client)
+		require.NoError(t, err)
+		assert.Len(t, pods.Items, 1)
+	}
+	assert.Equal(t, 3, countLists(client)["pods"])
+}
+
+func TestClusterSnapshot_FailedListIsRetried(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "apps"}})
+	failures := 1
+	client.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if failures > 0 {
+			failures--
+			return true, nil, fmt.Errorf("connection refused")
+		}
+		return false, nil, nil
+	})
+	ctx := WithSnapshot(context.Background(), NewClusterSnapshot(client))
+
+	_, err := ListPods(ctx, client)
+	assert.Error(t, err)
+
+	pods, err := ListPods(ctx, client)
+	require.NoError(t, err)
+	assert.Len(t, pods.Items, 1)
+
+	_, err = ListPods(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, 2, countLists(client)["pods"])
+}
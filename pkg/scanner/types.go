@@ -3,6 +3,8 @@ package scanner
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/cloudcwfranck/kspec/pkg/spec"
 	"k8s.io/client-go/kubernetes"
@@ -15,6 +17,43 @@ type Check interface {
 
 	// Run executes the check against the cluster
 	Run(ctx context.Context, client kubernetes.Interface, spec *spec.ClusterSpecification) (*CheckResult, error)
+
+	// Description explains what the check verifies and why; the first line is a
+	// one-line summary
+	Description() string
+
+	// SpecFields returns the spec fields the check reads (e.g., "workloads.containers")
+	SpecFields() []string
+
+	// Severity returns the severity assigned to failures, before severityOverrides
+	Severity() Severity
+
+	// Remediation returns an example fix for failures
+	Remediation() string
+}
+
+// Summary returns the first line of a check's description.
+func Summary(check Check) string {
+	summary, _, _ := strings.Cut(check.Description(), "\n")
+	return summary
+}
+
+// PlaybookProvider is implemented by checks that offer a step-by-step remediation
+// playbook, including example patches, for failures.
+type PlaybookProvider interface {
+	// Playbook returns the remediation playbook for a failing result
+	Playbook() string
+}
+
+// Playbook returns the remediation playbook for a check, defaulting to the result's
+// remediation when the check does not provide one.
+func Playbook(check Check, result *CheckResult) string {
+	if provider, ok := check.(PlaybookProvider); ok {
+		if playbook := provider.Playbook(); playbook != "" {
+			return playbook
+		}
+	}
+	return result.Remediation
 }
 
 // CheckResult represents the result of running a compliance check.
@@ -25,8 +64,80 @@ type CheckResult struct {
 	Message     string                 `json:"message"`
 	Evidence    map[string]interface{} `json:"evidence,omitempty"`
 	Remediation string                 `json:"remediation,omitempty"`
+
+	// SeverityLabel is the organisation-specific name for Severity (spec.severityLabels)
+	SeverityLabel string `json:"severityLabel,omitempty"`
+
+	// RemediationAction is an optional machine-actionable form of Remediation
+	RemediationAction *RemediationAction `json:"remediationAction,omitempty"`
+
+	// RemediationSteps are optional concrete steps fixing the failure, in order.
+	// Remediation remains the free-text summary.
+	RemediationSteps []RemediationStep `json:"remediationSteps,omitempty"`
+
+	// SkipReason explains why a check was skipped; set only with StatusSkip
+	SkipReason SkipReason `json:"skipReason,omitempty"`
+
+	// Controls are the compliance framework controls the check provides evidence for
+	Controls []ControlRef `json:"controls,omitempty"`
+}
+
+// ControlRef identifies a control of a compliance framework (e.g. CIS 5.2.2).
+type ControlRef struct {
+	Framework string `json:"framework"`
+	ID        string `json:"id"`
+	Title     string `json:"title,omitempty"`
+}
+
+// RemediationAction describes a machine-actionable remediation for a failed check.
+type RemediationAction struct {
+	Type        RemediationType `json:"type"`
+	Payload     string          `json:"payload"`
+	Description string          `json:"description,omitempty"`
+}
+
+// RemediationType represents the kind of remediation payload.
+type RemediationType string
+
+const (
+	// RemediationTypeCommand indicates the payload is a shell command (e.g., kubectl)
+	RemediationTypeCommand RemediationType = "command"
+	// RemediationTypePatch indicates the payload is a strategic merge patch
+	RemediationTypePatch RemediationType = "patch"
+	// RemediationTypeDoc indicates the payload is a documentation URL
+	RemediationTypeDoc RemediationType = "doc"
+)
+
+// RemediationStep is one concrete step of a remediation, e.g. a kubectl command
+// fixing a single workload.
+type RemediationStep struct {
+	Type RemediationStepType `json:"type"`
+
+	// Command is the command to run, for kubectl steps
+	Command string `json:"command,omitempty"`
+
+	// Patch is the patch body, for patch steps
+	Patch string `json:"patch,omitempty"`
+
+	// URL is the documentation link, for doc-link steps
+	URL string `json:"url,omitempty"`
+
+	// Description explains what the step does
+	Description string `json:"description"`
 }
 
+// RemediationStepType represents the kind of remediation step.
+type RemediationStepType string
+
+const (
+	// RemediationStepKubectl indicates a kubectl command to run
+	RemediationStepKubectl RemediationStepType = "kubectl"
+	// RemediationStepPatch indicates a patch body to apply to the affected resources
+	RemediationStepPatch RemediationStepType = "patch"
+	// RemediationStepDocLink indicates a link to documentation
+	RemediationStepDocLink RemediationStepType = "doc-link"
+)
+
 // Status represents the status of a check.
 type Status string
 
@@ -37,10 +148,81 @@ const (
 	StatusFail Status = "fail"
 	// StatusWarn indicates the check found a warning
 	StatusWarn Status = "warn"
-	// StatusSkip indicates the check was skipped
+	// StatusSkip indicates the check was skipped because its spec section is absent
 	StatusSkip Status = "skip"
+	// StatusError indicates the check could not be evaluated, e.g. due to insufficient permissions
+	StatusError Status = "error"
+)
+
+// SkipReason represents why a check was skipped.
+type SkipReason string
+
+const (
+	// SkipReasonSpecSectionAbsent indicates the cluster spec does not configure the check
+	SkipReasonSpecSectionAbsent SkipReason = "SpecSectionAbsent"
+	// SkipReasonNoApplicableResources indicates the cluster has no resources the check applies to
+	SkipReasonNoApplicableResources SkipReason = "NoApplicableResources"
+	// SkipReasonPermissionDenied indicates an optional input could not be read. Checks
+	// whose required reads are forbidden report StatusError instead.
+	SkipReasonPermissionDenied SkipReason = "PermissionDenied"
+	// SkipReasonFeatureUnavailable indicates a client or API the check needs is unavailable
+	SkipReasonFeatureUnavailable SkipReason = "FeatureUnavailable"
 )
 
+// SkipReasons lists the valid skip reasons.
+var SkipReasons = []SkipReason{
+	SkipReasonSpecSectionAbsent,
+	SkipReasonNoApplicableResources,
+	SkipReasonPermissionDenied,
+	SkipReasonFeatureUnavailable,
+}
+
+// ParseSkipReasons parses skip reason names, case-insensitively. "all" selects
+// every reason.
+func ParseSkipReasons(values []string) ([]SkipReason, error) {
+	reasons := []SkipReason{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if strings.EqualFold(value, "all") {
+			return SkipReasons, nil
+		}
+
+		matched := false
+		for _, reason := range SkipReasons {
+			if strings.EqualFold(value, string(reason)) {
+				reasons = append(reasons, reason)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("unknown skip reason %q (valid: all, %s, %s, %s, %s)", value,
+				SkipReasonSpecSectionAbsent, SkipReasonNoApplicableResources, SkipReasonPermissionDenied, SkipReasonFeatureUnavailable)
+		}
+	}
+	return reasons, nil
+}
+
+// SkippedFor returns the skipped results whose skip reason is one of reasons.
+func SkippedFor(results []CheckResult, reasons []SkipReason) []CheckResult {
+	var skipped []CheckResult
+	for _, result := range results {
+		if result.Status != StatusSkip {
+			continue
+		}
+		for _, reason := range reasons {
+			if result.SkipReason == reason {
+				skipped = append(skipped, result)
+				break
+			}
+		}
+	}
+	return skipped
+}
+
 // Severity represents the severity of a check failure.
 type Severity string
 
@@ -55,6 +237,48 @@ const (
 	SeverityLow Severity = "low"
 )
 
+// Severities lists the severities from least to most severe.
+var Severities = []Severity{SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+
+// Rank orders severities from low (0) to critical (3). Unset and unknown
+// severities rank as low, so they are never ignored by a threshold.
+func (s Severity) Rank() int {
+	for i, severity := range Severities {
+		if s == severity {
+			return i
+		}
+	}
+	return 0
+}
+
+// AtLeast reports whether s is as severe as threshold or more.
+func (s Severity) AtLeast(threshold Severity) bool {
+	return s.Rank() >= threshold.Rank()
+}
+
+// ParseSeverity parses a severity name, case-insensitively.
+func ParseSeverity(value string) (Severity, error) {
+	value = strings.TrimSpace(value)
+	for _, severity := range Severities {
+		if strings.EqualFold(value, string(severity)) {
+			return severity, nil
+		}
+	}
+	return "", fmt.Errorf("unknown severity %q (valid: %s, %s, %s, %s)", value,
+		SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow)
+}
+
+// FailedAtOrAbove returns the failed results at least as severe as threshold.
+func FailedAtOrAbove(results []CheckResult, threshold Severity) []CheckResult {
+	var failed []CheckResult
+	for _, result := range results {
+		if result.Status == StatusFail && result.Severity.AtLeast(threshold) {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
 // ScanResult represents the aggregated results of all checks.
 type ScanResult struct {
 	Metadata ScanMetadata  `json:"metadata"`
@@ -90,4 +314,9 @@ type ScanSummary struct {
 	Failed      int `json:"failed"`
 	Warnings    int `json:"warnings"`
 	Skipped     int `json:"skipped"`
+	Errors      int `json:"errors"`
+
+	// WeightedScore is the share of check weight (spec.scoring.weights) held by
+	// passed checks, as a percentage. With default weights it equals the pass rate.
+	WeightedScore float64 `json:"weighted_score"`
 }
@@ -33,11 +33,55 @@ func (in *SpecFields) DeepCopyInto(out *SpecFields) {
 		*out = new(ObservabilitySpec)
 		**out = **in
 	}
+	if in.Availability != nil {
+		in, out := &in.Availability, &out.Availability
+		*out = new(AvailabilitySpec)
+		**out = **in
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(CapacitySpec)
+		**out = **in
+	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(SchedulingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = new(SecretsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Compliance != nil {
 		in, out := &in.Compliance, &out.Compliance
 		*out = new(ComplianceSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CustomChecks != nil {
+		in, out := &in.CustomChecks, &out.CustomChecks
+		*out = make([]CELCheckSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.SeverityOverrides != nil {
+		in, out := &in.SeverityOverrides, &out.SeverityOverrides
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SeverityLabels != nil {
+		in, out := &in.SeverityLabels, &out.SeverityLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Scoring != nil {
+		in, out := &in.Scoring, &out.Scoring
+		*out = new(ScoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is a manually written deepcopy function for SpecFields.
@@ -56,13 +100,56 @@ func (in *PodSecuritySpec) DeepCopyInto(out *PodSecuritySpec) {
 	if in.Exemptions != nil {
 		in, out := &in.Exemptions, &out.Exemptions
 		*out = make([]PodSecurityExemption, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*out)[i] = (*in)[i]
+			if (*in)[i].ExpiresAt != nil {
+				expiresAt := *(*in)[i].ExpiresAt
+				(*out)[i].ExpiresAt = &expiresAt
+			}
+		}
 	}
 }
 
 // DeepCopyInto for NetworkSpec
 func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 	*out = *in
+	if in.RequiredPolicies != nil {
+		in, out := &in.RequiredPolicies, &out.RequiredPolicies
+		*out = make([]RequiredPolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedServiceTypes != nil {
+		in, out := &in.AllowedServiceTypes, &out.AllowedServiceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DisallowedPorts != nil {
+		in, out := &in.DisallowedPorts, &out.DisallowedPorts
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceOverrides != nil {
+		in, out := &in.NamespaceOverrides, &out.NamespaceOverrides
+		*out = make([]NetworkNamespaceOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopyInto for NetworkNamespaceOverride
+func (in *NetworkNamespaceOverride) DeepCopyInto(out *NetworkNamespaceOverride) {
+	*out = *in
+	if in.DefaultDeny != nil {
+		in, out := &in.DefaultDeny, &out.DefaultDeny
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequireDNSEgress != nil {
+		in, out := &in.RequireDNSEgress, &out.RequireDNSEgress
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopyInto for WorkloadsSpec
@@ -78,6 +165,21 @@ func (in *WorkloadsSpec) DeepCopyInto(out *WorkloadsSpec) {
 		*out = new(ImageSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExcludeContainers != nil {
+		in, out := &in.ExcludeContainers, &out.ExcludeContainers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnorePhases != nil {
+		in, out := &in.IgnorePhases, &out.IgnorePhases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourcePolicy != nil {
+		in, out := &in.ResourcePolicy, &out.ResourcePolicy
+		*out = new(ResourcePolicySpec)
+		**out = **in
+	}
 }
 
 // DeepCopyInto for ContainerSpec
@@ -128,6 +230,45 @@ func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
 	}
 }
 
+// DeepCopyInto for SchedulingSpec
+func (in *SchedulingSpec) DeepCopyInto(out *SchedulingSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopyInto for SecretsSpec
+func (in *SecretsSpec) DeepCopyInto(out *SecretsSpec) {
+	*out = *in
+	if in.EnvNamePatterns != nil {
+		in, out := &in.EnvNamePatterns, &out.EnvNamePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopyInto for ScoringSpec
+func (in *ScoringSpec) DeepCopyInto(out *ScoringSpec) {
+	*out = *in
+	if in.Weights != nil {
+		in, out := &in.Weights, &out.Weights
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
 // DeepCopyInto for ComplianceSpec
 func (in *ComplianceSpec) DeepCopyInto(out *ComplianceSpec) {
 	*out = *in
@@ -0,0 +1,264 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeType describes how a field differs between two specifications.
+type ChangeType string
+
+const (
+	// ChangeAdded marks a field or list item set only in the newer specification
+	ChangeAdded ChangeType = "added"
+
+	// ChangeRemoved marks a field or list item set only in the older specification
+	ChangeRemoved ChangeType = "removed"
+
+	// ChangeChanged marks a field set to different values
+	ChangeChanged ChangeType = "changed"
+)
+
+// Change is a field-level difference between two specifications.
+type Change struct {
+	// Path is the YAML path of the field, e.g. spec.podSecurity.enforce. List
+	// items are identified by their key or name, e.g.
+	// spec.workloads.containers.required[securityContext.runAsNonRoot].
+	Path string      `json:"path"`
+	Type ChangeType  `json:"type"`
+	From interface{} `json:"from,omitempty"`
+	To   interface{} `json:"to,omitempty"`
+}
+
+// String returns a one-line description of the change.
+func (c Change) String() string {
+	switch c.Type {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s: %s", c.Path, formatDiffValue(c.To))
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s: %s", c.Path, formatDiffValue(c.From))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Path, formatDiffValue(c.From), formatDiffValue(c.To))
+	}
+}
+
+// diffIdentityFields are the struct fields that identify list items, so items
+// are matched across specifications even when they move or change.
+var diffIdentityFields = []string{"Key", "Name", "Namespace", "ID"}
+
+// Diff returns the field-level differences between the spec fields of two
+// specifications, ordered by path. Lists of scalars are compared as sets, and
+// lists of structs are matched by key or name.
+func Diff(from, to *ClusterSpecification) []Change {
+	var changes []Change
+	diffValue(reflect.ValueOf(from.Spec), reflect.ValueOf(to.Spec), "spec", &changes)
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+// FilterChanges returns the changes to the given paths, including the items
+// of lists and maps at those paths.
+func FilterChanges(changes []Change, paths []string) []Change {
+	var filtered []Change
+	for _, change := range changes {
+		for _, path := range paths {
+			if change.Path == path || strings.HasPrefix(change.Path, path+"[") {
+				filtered = append(filtered, change)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// diffValue appends the differences between two values of the same type.
+func diffValue(from, to reflect.Value, path string, changes *[]Change) {
+	switch from.Kind() {
+	case reflect.Ptr:
+		if from.IsNil() && to.IsNil() {
+			return
+		}
+		if from.Type().Elem().Kind() != reflect.Struct {
+			diffScalar(from, to, path, changes)
+			return
+		}
+		// Compare a missing section with an empty one, so each field it sets is listed
+		diffValue(derefOrZero(from), derefOrZero(to), path, changes)
+
+	case reflect.Struct:
+		for i := 0; i < from.NumField(); i++ {
+			field := from.Type().Field(i)
+			diffValue(from.Field(i), to.Field(i), path+"."+fieldName(field), changes)
+		}
+
+	case reflect.Slice:
+		diffSlice(from, to, path, changes)
+
+	case reflect.Map:
+		keys := map[string]reflect.Value{}
+		for _, key := range append(from.MapKeys(), to.MapKeys()...) {
+			keys[fmt.Sprintf("%v", key.Interface())] = key
+		}
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			entryPath := fmt.Sprintf("%s[%s]", path, name)
+			fromEntry := from.MapIndex(keys[name])
+			toEntry := to.MapIndex(keys[name])
+			switch {
+			case !fromEntry.IsValid():
+				*changes = append(*changes, Change{Path: entryPath, Type: ChangeAdded, To: toEntry.Interface()})
+			case !toEntry.IsValid():
+				*changes = append(*changes, Change{Path: entryPath, Type: ChangeRemoved, From: fromEntry.Interface()})
+			default:
+				diffValue(fromEntry, toEntry, entryPath, changes)
+			}
+		}
+
+	default:
+		diffScalar(from, to, path, changes)
+	}
+}
+
+// diffScalar compares two scalars, or pointers to scalars, treating unset
+// values as absent.
+func diffScalar(from, to reflect.Value, path string, changes *[]Change) {
+	if reflect.DeepEqual(from.Interface(), to.Interface()) {
+		return
+	}
+
+	fromValue, toValue := scalarValue(from), scalarValue(to)
+	switch {
+	case from.Kind() == reflect.Bool:
+		*changes = append(*changes, Change{Path: path, Type: ChangeChanged, From: from.Bool(), To: to.Bool()})
+	case fromValue == nil:
+		*changes = append(*changes, Change{Path: path, Type: ChangeAdded, To: toValue})
+	case toValue == nil:
+		*changes = append(*changes, Change{Path: path, Type: ChangeRemoved, From: fromValue})
+	default:
+		*changes = append(*changes, Change{Path: path, Type: ChangeChanged, From: fromValue, To: toValue})
+	}
+}
+
+// diffSlice compares two lists. Items matched by identity are compared field by
+// field; other items are reported as added or removed.
+func diffSlice(from, to reflect.Value, path string, changes *[]Change) {
+	identity := sliceIdentityField(from.Type().Elem())
+	if identity == "" {
+		for _, item := range sliceDifference(from, to) {
+			*changes = append(*changes, Change{Path: path, Type: ChangeRemoved, From: item})
+		}
+		for _, item := range sliceDifference(to, from) {
+			*changes = append(*changes, Change{Path: path, Type: ChangeAdded, To: item})
+		}
+		return
+	}
+
+	toItems := map[string]reflect.Value{}
+	for i := 0; i < to.Len(); i++ {
+		toItems[itemIdentity(to.Index(i), identity)] = to.Index(i)
+	}
+
+	seen := map[string]bool{}
+	for i := 0; i < from.Len(); i++ {
+		id := itemIdentity(from.Index(i), identity)
+		seen[id] = true
+		itemPath := fmt.Sprintf("%s[%s]", path, id)
+		if toItem, ok := toItems[id]; ok {
+			diffValue(from.Index(i), toItem, itemPath, changes)
+			continue
+		}
+		*changes = append(*changes, Change{Path: itemPath, Type: ChangeRemoved, From: from.Index(i).Interface()})
+	}
+	for i := 0; i < to.Len(); i++ {
+		id := itemIdentity(to.Index(i), identity)
+		if !seen[id] {
+			*changes = append(*changes, Change{Path: fmt.Sprintf("%s[%s]", path, id), Type: ChangeAdded, To: to.Index(i).Interface()})
+		}
+	}
+}
+
+// sliceDifference returns the items of a that are not in b.
+func sliceDifference(a, b reflect.Value) []interface{} {
+	var items []interface{}
+	for i := 0; i < a.Len(); i++ {
+		found := false
+		for j := 0; j < b.Len(); j++ {
+			if reflect.DeepEqual(a.Index(i).Interface(), b.Index(j).Interface()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			items = append(items, a.Index(i).Interface())
+		}
+	}
+	return items
+}
+
+// sliceIdentityField returns the string field identifying items of a struct
+// list, or "" when items have no identity.
+func sliceIdentityField(elem reflect.Type) string {
+	if elem.Kind() != reflect.Struct {
+		return ""
+	}
+	for _, name := range diffIdentityFields {
+		if field, ok := elem.FieldByName(name); ok && field.Type.Kind() == reflect.String {
+			return name
+		}
+	}
+	return ""
+}
+
+// itemIdentity returns the identity of a list item.
+func itemIdentity(item reflect.Value, identity string) string {
+	return item.FieldByName(identity).String()
+}
+
+// derefOrZero returns the struct a pointer refers to, or its zero value when nil.
+func derefOrZero(value reflect.Value) reflect.Value {
+	if value.IsNil() {
+		return reflect.Zero(value.Type().Elem())
+	}
+	return value.Elem()
+}
+
+// scalarValue returns the value of a scalar, or nil when it is unset.
+func scalarValue(value reflect.Value) interface{} {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		return value.Elem().Interface()
+	}
+	if value.IsZero() {
+		return nil
+	}
+	return value.Interface()
+}
+
+// formatDiffValue formats a changed value for text output.
+func formatDiffValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case bool, int, float64:
+		return fmt.Sprintf("%v", v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(data)
+	}
+}
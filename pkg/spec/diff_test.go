@@ -0,0 +1,133 @@
+package spec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	from := &ClusterSpecification{
+		Spec: SpecFields{
+			Kubernetes: KubernetesSpec{MinVersion: "1.26.0", MaxVersion: "1.30.0"},
+			PodSecurity: &PodSecuritySpec{
+				Enforce: "baseline",
+				Audit:   "restricted",
+			},
+			Workloads: &WorkloadsSpec{
+				Containers: &ContainerSpec{
+					Required: []FieldRequirement{
+						{Key: "securityContext.runAsNonRoot", Value: "true"},
+						{Key: "resources.limits.memory"},
+					},
+				},
+				Images: &ImageSpec{
+					AllowedRegistries: []string{"gcr.io", "docker.io"},
+					RequireDigests:    true,
+				},
+			},
+			SeverityOverrides: map[string]string{"workload.security": "high"},
+		},
+	}
+	to := &ClusterSpecification{
+		Spec: SpecFields{
+			Kubernetes: KubernetesSpec{MinVersion: "1.28.0", MaxVersion: "1.30.0"},
+			PodSecurity: &PodSecuritySpec{
+				Enforce: "restricted",
+				Audit:   "restricted",
+				Warn:    "restricted",
+			},
+			Network: &NetworkSpec{DefaultDeny: true},
+			Workloads: &WorkloadsSpec{
+				Containers: &ContainerSpec{
+					Required: []FieldRequirement{
+						{Key: "resources.limits.memory"},
+						{Key: "securityContext.runAsNonRoot", Value: "false"},
+						{Key: "resources.limits.cpu"},
+					},
+				},
+				Images: &ImageSpec{
+					AllowedRegistries: []string{"docker.io", "registry.example.com"},
+				},
+			},
+			SeverityOverrides: map[string]string{"workload.security": "critical"},
+		},
+	}
+
+	expected := []Change{
+		{Path: "spec.kubernetes.minVersion", Type: ChangeChanged, From: "1.26.0", To: "1.28.0"},
+		{Path: "spec.network.defaultDeny", Type: ChangeChanged, From: false, To: true},
+		{Path: "spec.podSecurity.enforce", Type: ChangeChanged, From: "baseline", To: "restricted"},
+		{Path: "spec.podSecurity.warn", Type: ChangeAdded, To: "restricted"},
+		{Path: "spec.severityOverrides[workload.security]", Type: ChangeChanged, From: "high", To: "critical"},
+		{Path: "spec.workloads.containers.required[resources.limits.cpu]", Type: ChangeAdded, To: FieldRequirement{Key: "resources.limits.cpu"}},
+		{Path: "spec.workloads.containers.required[securityContext.runAsNonRoot].value", Type: ChangeChanged, From: "true", To: "false"},
+		{Path: "spec.workloads.images.allowedRegistries", Type: ChangeRemoved, From: "gcr.io"},
+		{Path: "spec.workloads.images.allowedRegistries", Type: ChangeAdded, To: "registry.example.com"},
+		{Path: "spec.workloads.images.requireDigests", Type: ChangeChanged, From: true, To: false},
+	}
+
+	changes := Diff(from, to)
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Diff() mismatch\ngot:  %+v\nwant: %+v", changes, expected)
+	}
+}
+
+func TestDiff_Identical(t *testing.T) {
+	clusterSpec := &ClusterSpecification{
+		Spec: SpecFields{
+			Kubernetes:  KubernetesSpec{MinVersion: "1.26.0"},
+			PodSecurity: &PodSecuritySpec{Enforce: "restricted"},
+		},
+	}
+
+	if changes := Diff(clusterSpec, clusterSpec); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %+v", changes)
+	}
+}
+
+func TestChangeString(t *testing.T) {
+	tests := []struct {
+		change   Change
+		expected string
+	}{
+		{
+			change:   Change{Path: "spec.podSecurity.warn", Type: ChangeAdded, To: "restricted"},
+			expected: `+ spec.podSecurity.warn: "restricted"`,
+		},
+		{
+			change:   Change{Path: "spec.workloads.images.allowedRegistries", Type: ChangeRemoved, From: "gcr.io"},
+			expected: `- spec.workloads.images.allowedRegistries: "gcr.io"`,
+		},
+		{
+			change:   Change{Path: "spec.network.defaultDeny", Type: ChangeChanged, From: false, To: true},
+			expected: `~ spec.network.defaultDeny: false -> true`,
+		},
+		{
+			change:   Change{Path: "spec.workloads.containers.required[a]", Type: ChangeAdded, To: FieldRequirement{Key: "a"}},
+			expected: `+ spec.workloads.containers.required[a]: {"key":"a"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.change.String(); got != tt.expected {
+			t.Errorf("String() = %q, want %q", got, tt.expected)
+		}
+	}
+}
+
+func TestFilterChanges(t *testing.T) {
+	changes := []Change{
+		{Path: "spec.kubernetes.minVersion", Type: ChangeChanged},
+		{Path: "spec.network.allowedServiceTypes", Type: ChangeAdded},
+		{Path: "spec.workloads.containers.required[a]", Type: ChangeAdded},
+		{Path: "spec.network.allowedServiceTypesExtra", Type: ChangeAdded},
+	}
+
+	filtered := FilterChanges(changes, []string{"spec.network.allowedServiceTypes", "spec.workloads.containers.required"})
+	if len(filtered) != 2 {
+		t.Fatalf("Expected 2 changes, got %+v", filtered)
+	}
+	if filtered[0].Path != "spec.network.allowedServiceTypes" || filtered[1].Path != "spec.workloads.containers.required[a]" {
+		t.Errorf("Unexpected changes: %+v", filtered)
+	}
+}
@@ -2,27 +2,119 @@
 package spec
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"gopkg.in/yaml.v3"
 )
 
-// LoadFromFile loads a cluster specification from a YAML file.
+// LoadFromFile loads a cluster specification from a YAML file. Keys that are
+// not part of the schema are ignored; see LoadFromFileStrict. A file of an
+// older apiVersion is migrated with Migrate, and an unknown apiVersion is an
+// error.
 func LoadFromFile(path string) (*ClusterSpecification, error) {
+	return loadFile(path, false)
+}
+
+// LoadFromFileStrict loads a cluster specification from a YAML file like
+// LoadFromFile, but rejects keys that are not part of the schema, so a typo
+// such as requireDigest for requireDigests is an error naming the key and its
+// line instead of a requirement that silently does not apply.
+func LoadFromFileStrict(path string) (*ClusterSpecification, error) {
+	return loadFile(path, true)
+}
+
+// loadFile reads and decodes a spec file, rejecting unknown keys when strict.
+// A file of an older apiVersion is migrated to CurrentAPIVersion first.
+func loadFile(path string, strict bool) (*ClusterSpecification, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read spec file %s: %w", path, err)
 	}
 
+	data, err = migrateFile(path, data)
+	if err != nil {
+		return nil, err
+	}
+
 	var spec ClusterSpecification
-	if err := yaml.Unmarshal(data, &spec); err != nil {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(strict)
+	if err := decoder.Decode(&spec); err != nil && !errors.Is(err, io.EOF) {
 		return nil, fmt.Errorf("failed to parse spec file %s: %w", path, err)
 	}
 
 	return &spec, nil
 }
 
+// migrateFile returns the spec file contents upgraded to CurrentAPIVersion,
+// reporting each migration applied to MigrationWarningHandler. Files that are
+// already current are returned unchanged.
+func migrateFile(path string, data []byte) ([]byte, error) {
+	var header struct {
+		APIVersion string `yaml:"apiVersion"`
+	}
+	if err := yaml.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to parse spec file %s: %w", path, err)
+	}
+	if header.APIVersion == "" || header.APIVersion == CurrentAPIVersion {
+		return data, nil
+	}
+
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse spec file %s: %w", path, err)
+	}
+	warnings, err := Migrate(doc)
+	if err != nil {
+		return nil, fmt.Errorf("spec file %s: %w", path, err)
+	}
+	for _, warning := range warnings {
+		MigrationWarningHandler(fmt.Sprintf("spec file %s: %s", path, warning))
+	}
+
+	migrated, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode migrated spec file %s: %w", path, err)
+	}
+	return migrated, nil
+}
+
+// LoadFromFiles loads several cluster specification files and merges them in
+// order, so a base spec can be layered with team-specific additions. A single
+// path behaves exactly like LoadFromFile.
+//
+// Later files fill in spec fields earlier files left unset and append to list
+// fields (e.g. Required, Forbidden, BlockedRegistries). Setting a field to a
+// different value than an earlier file is an error naming both files. Metadata
+// is the exception: later files override the name, version and other metadata,
+// so the merged spec is identified by its last layer.
+func LoadFromFiles(paths []string) (*ClusterSpecification, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no spec files given")
+	}
+	if len(paths) == 1 {
+		return LoadFromFile(paths[0])
+	}
+
+	merged := &ClusterSpecification{}
+	merger := newSpecMerger()
+	for _, path := range paths {
+		layer, err := LoadFromFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := merger.merge(merged, layer, path); err != nil {
+			return nil, err
+		}
+	}
+
+	return merged, nil
+}
+
 // MarshalYAML marshals a cluster specification to YAML format.
 func MarshalYAML(spec *ClusterSpecification) ([]byte, error) {
 	return yaml.Marshal(spec)
@@ -3,6 +3,7 @@ package spec
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -75,3 +76,192 @@ func TestLoadFromFile_InvalidYAML(t *testing.T) {
 		t.Error("Expected error for invalid YAML, got nil")
 	}
 }
+
+func TestLoadFromFileStrict_UnknownField(t *testing.T) {
+	specFile := writeSpecFile(t, t.TempDir(), "typo.yaml", `apiVersion: kspec.dev/v1
+kind: ClusterSpecification
+metadata:
+  name: typo-cluster
+spec:
+  workloads:
+    images:
+      requireDigest: true
+`)
+
+	// Lenient loading ignores the unknown key
+	clusterSpec, err := LoadFromFile(specFile)
+	if err != nil {
+		t.Fatalf("LoadFromFile failed: %v", err)
+	}
+	if clusterSpec.Metadata.Name != "typo-cluster" {
+		t.Errorf("Expected name 'typo-cluster', got '%s'", clusterSpec.Metadata.Name)
+	}
+
+	_, err = LoadFromFileStrict(specFile)
+	if err == nil {
+		t.Fatal("Expected error for unknown field, got nil")
+	}
+	for _, want := range []string{"line 8", "requireDigest"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got: %v", want, err)
+		}
+	}
+}
+
+func TestLoadFromFileStrict_ValidSpec(t *testing.T) {
+	specFile := writeSpecFile(t, t.TempDir(), "valid.yaml", `apiVersion: kspec.dev/v1
+kind: ClusterSpecification
+metadata:
+  name: strict-cluster
+spec:
+  workloads:
+    images:
+      requireDigests: true
+`)
+
+	clusterSpec, err := LoadFromFileStrict(specFile)
+	if err != nil {
+		t.Fatalf("LoadFromFileStrict failed: %v", err)
+	}
+	if clusterSpec.Spec.Workloads == nil || clusterSpec.Spec.Workloads.Images == nil || !clusterSpec.Spec.Workloads.Images.RequireDigests {
+		t.Error("Expected requireDigests to be set")
+	}
+}
+
+func writeSpecFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create temp spec file: %v", err)
+	}
+	return path
+}
+
+func TestLoadFromFiles_MergesLayers(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	base := writeSpecFile(t, tmpDir, "base.yaml", `apiVersion: kspec.dev/v1
+kind: ClusterSpecification
+metadata:
+  name: base-hardening
+  version: "1.0.0"
+spec:
+  kubernetes:
+    minVersion: "1.26.0"
+    maxVersion: "1.30.0"
+  podSecurity:
+    enforce: restricted
+    audit: restricted
+    warn: restricted
+  workloads:
+    containers:
+      required:
+        - key: securityContext.runAsNonRoot
+          value: "true"
+    images:
+      blockedRegistries:
+        - docker.io
+`)
+
+	app := writeSpecFile(t, tmpDir, "app.yaml", `apiVersion: kspec.dev/v1
+kind: ClusterSpecification
+metadata:
+  name: payments
+  version: "1.1.0"
+spec:
+  podSecurity:
+    enforce: restricted
+  workloads:
+    containers:
+      required:
+        - key: securityContext.allowPrivilegeEscalation
+          value: "false"
+      forbidden:
+        - key: securityContext.privileged
+          value: "true"
+    images:
+      blockedRegistries:
+        - quay.io
+  capacity:
+    maxPodsPerNode: 50
+`)
+
+	clusterSpec, err := LoadFromFiles([]string{base, app})
+	if err != nil {
+		t.Fatalf("LoadFromFiles failed: %v", err)
+	}
+
+	if err := Validate(clusterSpec); err != nil {
+		t.Fatalf("Merged spec failed validation: %v", err)
+	}
+
+	// Metadata comes from the last layer
+	if clusterSpec.Metadata.Name != "payments" || clusterSpec.Metadata.Version != "1.1.0" {
+		t.Errorf("Expected metadata from app.yaml, got %s %s", clusterSpec.Metadata.Name, clusterSpec.Metadata.Version)
+	}
+
+	// Fields set by only one layer are kept
+	if clusterSpec.Spec.Kubernetes.MinVersion != "1.26.0" {
+		t.Errorf("Expected minVersion from base.yaml, got '%s'", clusterSpec.Spec.Kubernetes.MinVersion)
+	}
+	if clusterSpec.Spec.PodSecurity.Audit != "restricted" {
+		t.Errorf("Expected audit level from base.yaml, got '%s'", clusterSpec.Spec.PodSecurity.Audit)
+	}
+	if clusterSpec.Spec.Capacity == nil || clusterSpec.Spec.Capacity.MaxPodsPerNode != 50 {
+		t.Errorf("Expected capacity from app.yaml, got %+v", clusterSpec.Spec.Capacity)
+	}
+
+	// Lists are appended in file order
+	containers := clusterSpec.Spec.Workloads.Containers
+	if len(containers.Required) != 2 || containers.Required[0].Key != "securityContext.runAsNonRoot" || containers.Required[1].Key != "securityContext.allowPrivilegeEscalation" {
+		t.Errorf("Expected required fields from both files, got %+v", containers.Required)
+	}
+	if len(containers.Forbidden) != 1 {
+		t.Errorf("Expected 1 forbidden field, got %+v", containers.Forbidden)
+	}
+	blocked := clusterSpec.Spec.Workloads.Images.BlockedRegistries
+	if len(blocked) != 2 || blocked[0] != "docker.io" || blocked[1] != "quay.io" {
+		t.Errorf("Expected blocked registries [docker.io quay.io], got %v", blocked)
+	}
+}
+
+func TestLoadFromFiles_ConflictingScalars(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	base := writeSpecFile(t, tmpDir, "base.yaml", `apiVersion: kspec.dev/v1
+kind: ClusterSpecification
+metadata:
+  name: base
+  version: "1.0.0"
+spec:
+  podSecurity:
+    enforce: restricted
+`)
+
+	app := writeSpecFile(t, tmpDir, "app.yaml", `apiVersion: kspec.dev/v1
+kind: ClusterSpecification
+metadata:
+  name: app
+  version: "1.0.0"
+spec:
+  podSecurity:
+    enforce: baseline
+`)
+
+	_, err := LoadFromFiles([]string{base, app})
+	if err == nil {
+		t.Fatal("Expected error for conflicting podSecurity.enforce, got nil")
+	}
+
+	for _, want := range []string{"spec.podSecurity.enforce", base, app, `"restricted"`, `"baseline"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to mention %s, got: %v", want, err)
+		}
+	}
+}
+
+func TestLoadFromFiles_NoFiles(t *testing.T) {
+	if _, err := LoadFromFiles(nil); err == nil {
+		t.Error("Expected error for no spec files, got nil")
+	}
+}
@@ -0,0 +1,165 @@
+package spec
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// specMerger merges layered specifications, remembering which file set each
+// field so conflicts can name both files.
+type specMerger struct {
+	origins map[string]string
+}
+
+func newSpecMerger() *specMerger {
+	return &specMerger{origins: map[string]string{}}
+}
+
+// merge merges layer, loaded from file, into merged.
+func (m *specMerger) merge(merged, layer *ClusterSpecification, file string) error {
+	if err := m.mergeValue(reflect.ValueOf(&merged.APIVersion).Elem(), reflect.ValueOf(layer.APIVersion), "apiVersion", file); err != nil {
+		return err
+	}
+	if err := m.mergeValue(reflect.ValueOf(&merged.Kind).Elem(), reflect.ValueOf(layer.Kind), "kind", file); err != nil {
+		return err
+	}
+
+	mergeMetadata(&merged.Metadata, &layer.Metadata)
+
+	return m.mergeValue(reflect.ValueOf(&merged.Spec).Elem(), reflect.ValueOf(layer.Spec), "spec", file)
+}
+
+// mergeMetadata overrides metadata with the values a later layer sets.
+func mergeMetadata(merged, layer *Metadata) {
+	if layer.Name != "" {
+		merged.Name = layer.Name
+	}
+	if layer.Version != "" {
+		merged.Version = layer.Version
+	}
+	if layer.Description != "" {
+		merged.Description = layer.Description
+	}
+	if layer.Owner != "" {
+		merged.Owner = layer.Owner
+	}
+	if layer.Team != "" {
+		merged.Team = layer.Team
+	}
+	for key, value := range layer.Labels {
+		if merged.Labels == nil {
+			merged.Labels = map[string]string{}
+		}
+		merged.Labels[key] = value
+	}
+}
+
+// mergeValue merges src into dst: structs field by field, slices by appending,
+// maps key by key, and scalars by filling in unset values. A scalar or map entry
+// already set to a different value is a conflict.
+func (m *specMerger) mergeValue(dst, src reflect.Value, path, file string) error {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return nil
+		}
+		if src.Elem().Kind() != reflect.Struct {
+			return m.mergeScalar(dst, src, path, file)
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(src.Elem().Type()))
+		}
+		return m.mergeValue(dst.Elem(), src.Elem(), path, file)
+
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			field := src.Type().Field(i)
+			if err := m.mergeValue(dst.Field(i), src.Field(i), path+"."+fieldName(field), file); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return nil
+		}
+		// Copy rather than append to nil so an explicitly empty list stays non-nil
+		if dst.IsNil() {
+			dst.Set(reflect.MakeSlice(src.Type(), 0, src.Len()))
+		}
+		dst.Set(reflect.AppendSlice(dst, src))
+		return nil
+
+	case reflect.Map:
+		if src.IsNil() {
+			return nil
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+		}
+		for _, key := range src.MapKeys() {
+			entryPath := fmt.Sprintf("%s[%v]", path, key.Interface())
+			value := src.MapIndex(key)
+			if existing := dst.MapIndex(key); existing.IsValid() && !reflect.DeepEqual(existing.Interface(), value.Interface()) {
+				return m.conflict(entryPath, existing, value, file)
+			}
+			dst.SetMapIndex(key, value)
+			m.record(entryPath, file)
+		}
+		return nil
+
+	default:
+		return m.mergeScalar(dst, src, path, file)
+	}
+}
+
+// mergeScalar fills in an unset scalar, rejecting a different value set earlier.
+func (m *specMerger) mergeScalar(dst, src reflect.Value, path, file string) error {
+	if src.IsZero() {
+		return nil
+	}
+	if dst.IsZero() {
+		dst.Set(src)
+		m.record(path, file)
+		return nil
+	}
+	if reflect.DeepEqual(dst.Interface(), src.Interface()) {
+		return nil
+	}
+	return m.conflict(path, dst, src, file)
+}
+
+// record remembers the first file to set a field.
+func (m *specMerger) record(path, file string) {
+	if _, ok := m.origins[path]; !ok {
+		m.origins[path] = file
+	}
+}
+
+// conflict returns an error for a field set to different values by two files.
+func (m *specMerger) conflict(path string, existing, value reflect.Value, file string) error {
+	return fmt.Errorf("conflicting values for %s: %v in %s and %v in %s",
+		path, display(existing), m.origins[path], display(value), file)
+}
+
+// display dereferences pointers and quotes strings for conflict messages.
+func display(value reflect.Value) string {
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.String {
+		return fmt.Sprintf("%q", value.String())
+	}
+	return fmt.Sprintf("%v", value.Interface())
+}
+
+// fieldName returns the YAML name of a struct field.
+func fieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
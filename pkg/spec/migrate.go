@@ -0,0 +1,72 @@
+// Package spec defines the cluster specification schema for kspec.
+package spec
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// CurrentAPIVersion is the spec apiVersion this version of kspec reads natively.
+const CurrentAPIVersion = "kspec.dev/v1"
+
+// migration upgrades a raw spec document from one apiVersion to the next.
+type migration struct {
+	// to is the apiVersion the document has after the migration.
+	to string
+
+	// migrate rewrites the document in place; apiVersion is set by Migrate.
+	migrate func(doc map[string]interface{}) error
+}
+
+// migrations maps each supported older apiVersion to the migration upgrading
+// it. Migrations are chained until CurrentAPIVersion is reached, so a schema
+// change only needs a migration from the version before it.
+var migrations = map[string]migration{}
+
+// MigrationWarningHandler is called with a warning for each spec file that is
+// migrated from an older apiVersion while loading. It prints the warning to
+// standard error by default.
+var MigrationWarningHandler = func(warning string) {
+	fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+}
+
+// Migrate upgrades a raw spec document of a known older apiVersion to
+// CurrentAPIVersion in place, returning a warning for each migration applied.
+// A document that is already current, or has no apiVersion, is left
+// unchanged. An apiVersion kspec does not know, such as one written for a
+// newer kspec, is an error rather than a partial parse.
+func Migrate(doc map[string]interface{}) ([]string, error) {
+	version, _ := doc["apiVersion"].(string)
+	if version == "" || version == CurrentAPIVersion {
+		return nil, nil
+	}
+
+	var warnings []string
+	for steps := 0; version != CurrentAPIVersion; steps++ {
+		m, ok := migrations[version]
+		if !ok || steps > len(migrations) {
+			return nil, fmt.Errorf("unsupported apiVersion %s (supported: %s)", version, strings.Join(supportedAPIVersions(), ", "))
+		}
+		if err := m.migrate(doc); err != nil {
+			return nil, fmt.Errorf("failed to migrate from apiVersion %s to %s: %w", version, m.to, err)
+		}
+		doc["apiVersion"] = m.to
+		warnings = append(warnings, fmt.Sprintf("migrated spec from apiVersion %s to %s; update the file to %s", version, m.to, CurrentAPIVersion))
+		version = m.to
+	}
+
+	return warnings, nil
+}
+
+// supportedAPIVersions returns the current apiVersion followed by the older
+// versions that can be migrated.
+func supportedAPIVersions() []string {
+	older := make([]string, 0, len(migrations))
+	for version := range migrations {
+		older = append(older, version)
+	}
+	sort.Strings(older)
+	return append([]string{CurrentAPIVersion}, older...)
+}
@@ -0,0 +1,158 @@
+package spec
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// registerV0Migration simulates an older kspec.dev/v0 schema, in which the
+// version bounds were spec.kubernetesVersion.{min,max}.
+func registerV0Migration(t *testing.T) {
+	t.Helper()
+	migrations["kspec.dev/v0"] = migration{
+		to: CurrentAPIVersion,
+		migrate: func(doc map[string]interface{}) error {
+			spec, _ := doc["spec"].(map[string]interface{})
+			if spec == nil {
+				return nil
+			}
+			bounds, ok := spec["kubernetesVersion"].(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("spec.kubernetesVersion must be a mapping")
+			}
+			spec["kubernetes"] = map[string]interface{}{
+				"minVersion": bounds["min"],
+				"maxVersion": bounds["max"],
+			}
+			delete(spec, "kubernetesVersion")
+			return nil
+		},
+	}
+	t.Cleanup(func() { delete(migrations, "kspec.dev/v0") })
+}
+
+func captureMigrationWarnings(t *testing.T) *[]string {
+	t.Helper()
+	warnings := []string{}
+	handler := MigrationWarningHandler
+	MigrationWarningHandler = func(warning string) { warnings = append(warnings, warning) }
+	t.Cleanup(func() { MigrationWarningHandler = handler })
+	return &warnings
+}
+
+const v0Spec = `apiVersion: kspec.dev/v0
+kind: ClusterSpecification
+metadata:
+  name: legacy-cluster
+  version: "1.0.0"
+spec:
+  kubernetesVersion:
+    min: "1.26.0"
+    max: "1.30.0"
+`
+
+func TestMigrate_V0ToV1(t *testing.T) {
+	registerV0Migration(t)
+
+	doc := map[string]interface{}{
+		"apiVersion": "kspec.dev/v0",
+		"spec": map[string]interface{}{
+			"kubernetesVersion": map[string]interface{}{"min": "1.26.0", "max": "1.30.0"},
+		},
+	}
+
+	warnings, err := Migrate(doc)
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if doc["apiVersion"] != CurrentAPIVersion {
+		t.Errorf("Expected apiVersion %s, got %v", CurrentAPIVersion, doc["apiVersion"])
+	}
+	kubernetes, _ := doc["spec"].(map[string]interface{})["kubernetes"].(map[string]interface{})
+	if kubernetes["minVersion"] != "1.26.0" || kubernetes["maxVersion"] != "1.30.0" {
+		t.Errorf("Expected migrated kubernetes bounds, got %v", doc["spec"])
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "kspec.dev/v0 to kspec.dev/v1") {
+		t.Errorf("Expected one migration warning, got %v", warnings)
+	}
+}
+
+func TestMigrate_CurrentVersionUnchanged(t *testing.T) {
+	doc := map[string]interface{}{"apiVersion": CurrentAPIVersion, "kind": "ClusterSpecification"}
+
+	warnings, err := Migrate(doc)
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("Expected no warnings for the current apiVersion, got %v", warnings)
+	}
+}
+
+func TestMigrate_UnknownVersion(t *testing.T) {
+	_, err := Migrate(map[string]interface{}{"apiVersion": "kspec.dev/v2"})
+	if err == nil {
+		t.Fatal("Expected error for unknown apiVersion, got nil")
+	}
+	if !strings.Contains(err.Error(), "unsupported apiVersion kspec.dev/v2") {
+		t.Errorf("Expected error naming the apiVersion, got: %v", err)
+	}
+}
+
+func TestMigrate_FailedMigration(t *testing.T) {
+	registerV0Migration(t)
+
+	_, err := Migrate(map[string]interface{}{
+		"apiVersion": "kspec.dev/v0",
+		"spec":       map[string]interface{}{"kubernetesVersion": "1.26"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "failed to migrate from apiVersion kspec.dev/v0") {
+		t.Errorf("Expected migration error, got: %v", err)
+	}
+}
+
+func TestLoadFromFile_MigratesOlderVersion(t *testing.T) {
+	registerV0Migration(t)
+	warnings := captureMigrationWarnings(t)
+	specFile := writeSpecFile(t, t.TempDir(), "legacy.yaml", v0Spec)
+
+	for _, load := range []func(string) (*ClusterSpecification, error){LoadFromFile, LoadFromFileStrict} {
+		clusterSpec, err := load(specFile)
+		if err != nil {
+			t.Fatalf("Loading a v0 spec failed: %v", err)
+		}
+		if clusterSpec.APIVersion != CurrentAPIVersion {
+			t.Errorf("Expected apiVersion %s, got %s", CurrentAPIVersion, clusterSpec.APIVersion)
+		}
+		if clusterSpec.Spec.Kubernetes.MinVersion != "1.26.0" || clusterSpec.Spec.Kubernetes.MaxVersion != "1.30.0" {
+			t.Errorf("Expected migrated kubernetes bounds, got %+v", clusterSpec.Spec.Kubernetes)
+		}
+		if err := Validate(clusterSpec); err != nil {
+			t.Errorf("Expected migrated spec to validate, got: %v", err)
+		}
+	}
+
+	if len(*warnings) != 2 || !strings.Contains((*warnings)[0], specFile) {
+		t.Errorf("Expected a warning naming the file per load, got %v", *warnings)
+	}
+}
+
+func TestLoadFromFile_UnknownVersion(t *testing.T) {
+	specFile := writeSpecFile(t, t.TempDir(), "future.yaml", `apiVersion: kspec.dev/v2
+kind: ClusterSpecification
+metadata:
+  name: future-cluster
+spec:
+  kubernetes:
+    minVersion: "1.26.0"
+`)
+
+	_, err := LoadFromFile(specFile)
+	if err == nil {
+		t.Fatal("Expected error for unknown apiVersion, got nil")
+	}
+	if !strings.Contains(err.Error(), "unsupported apiVersion kspec.dev/v2") {
+		t.Errorf("Expected error naming the apiVersion, got: %v", err)
+	}
+}
@@ -1,6 +1,15 @@
 // Package spec defines the cluster specification schema for kspec.
 package spec
 
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+)
+
 // ClusterSpecification represents the complete cluster specification.
 type ClusterSpecification struct {
 	APIVersion string     `yaml:"apiVersion" json:"apiVersion"`
@@ -15,6 +24,32 @@ type Metadata struct {
 	Version     string            `yaml:"version" json:"version"`
 	Description string            `yaml:"description,omitempty" json:"description,omitempty"`
 	Labels      map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+
+	// Owner and Team identify who owns the specification in a shared cluster.
+	// They are propagated as labels onto generated reports and policies.
+	Owner string `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Team  string `yaml:"team,omitempty" json:"team,omitempty"`
+}
+
+const (
+	// OwnerLabel is the label carrying a specification's owner
+	OwnerLabel = "kspec.io/owner"
+
+	// TeamLabel is the label carrying a specification's owning team
+	TeamLabel = "kspec.io/team"
+)
+
+// OwnershipLabels returns the owner and team labels for the metadata, omitting
+// unset values.
+func (m Metadata) OwnershipLabels() map[string]string {
+	labels := map[string]string{}
+	if m.Owner != "" {
+		labels[OwnerLabel] = m.Owner
+	}
+	if m.Team != "" {
+		labels[TeamLabel] = m.Team
+	}
+	return labels
 }
 
 // SpecFields contains all specification requirements.
@@ -26,7 +61,25 @@ type SpecFields struct {
 	RBAC          *RBACSpec          `yaml:"rbac,omitempty" json:"rbac,omitempty"`
 	Admission     *AdmissionSpec     `yaml:"admission,omitempty" json:"admission,omitempty"`
 	Observability *ObservabilitySpec `yaml:"observability,omitempty" json:"observability,omitempty"`
+	Availability  *AvailabilitySpec  `yaml:"availability,omitempty" json:"availability,omitempty"`
+	Capacity      *CapacitySpec      `yaml:"capacity,omitempty" json:"capacity,omitempty"`
+	Scheduling    *SchedulingSpec    `yaml:"scheduling,omitempty" json:"scheduling,omitempty"`
+	Secrets       *SecretsSpec       `yaml:"secrets,omitempty" json:"secrets,omitempty"`
 	Compliance    *ComplianceSpec    `yaml:"compliance,omitempty" json:"compliance,omitempty"`
+
+	// CustomChecks are organisation-specific checks written as CEL expressions
+	CustomChecks []CELCheckSpec `yaml:"customChecks,omitempty" json:"customChecks,omitempty"`
+
+	// SeverityOverrides replaces the severity reported by a check, keyed by check name
+	// (e.g. "workload.security": "critical")
+	SeverityOverrides map[string]string `yaml:"severityOverrides,omitempty" json:"severityOverrides,omitempty"`
+
+	// SeverityLabels renames severities in reports to match an organisation's
+	// taxonomy (e.g. "critical": "P1")
+	SeverityLabels map[string]string `yaml:"severityLabels,omitempty" json:"severityLabels,omitempty"`
+
+	// Scoring configures the weighted compliance score
+	Scoring *ScoringSpec `yaml:"scoring,omitempty" json:"scoring,omitempty"`
 }
 
 // KubernetesSpec defines Kubernetes version requirements.
@@ -49,6 +102,10 @@ type PodSecurityExemption struct {
 	Namespace string `yaml:"namespace" json:"namespace"`
 	Level     string `yaml:"level" json:"level"`
 	Reason    string `yaml:"reason" json:"reason"`
+
+	// ExpiresAt is when the exemption lapses. Specs with expired exemptions
+	// fail validation, so stale exemptions are revisited.
+	ExpiresAt *time.Time `yaml:"expiresAt,omitempty" json:"expiresAt,omitempty"`
 }
 
 // NetworkSpec defines network policy requirements.
@@ -57,6 +114,47 @@ type NetworkSpec struct {
 	RequiredPolicies    []RequiredPolicy `yaml:"requiredPolicies,omitempty" json:"requiredPolicies,omitempty"`
 	AllowedServiceTypes []string         `yaml:"allowedServiceTypes,omitempty" json:"allowedServiceTypes,omitempty"`
 	DisallowedPorts     []int            `yaml:"disallowedPorts,omitempty" json:"disallowedPorts,omitempty"`
+
+	// RequireDNSEgress requires pods whose egress is restricted by a NetworkPolicy
+	// to still be allowed DNS (UDP port 53)
+	RequireDNSEgress bool `yaml:"requireDNSEgress,omitempty" json:"requireDNSEgress,omitempty"`
+
+	// NamespaceOverrides replace defaultDeny and requireDNSEgress for individual
+	// namespaces. System namespaces are only audited when overridden.
+	NamespaceOverrides []NetworkNamespaceOverride `yaml:"namespaceOverrides,omitempty" json:"namespaceOverrides,omitempty"`
+}
+
+// NetworkNamespaceOverride replaces the network requirements of one namespace.
+// Unset fields keep the cluster-wide requirement.
+type NetworkNamespaceOverride struct {
+	Namespace        string `yaml:"namespace" json:"namespace"`
+	DefaultDeny      *bool  `yaml:"defaultDeny,omitempty" json:"defaultDeny,omitempty"`
+	RequireDNSEgress *bool  `yaml:"requireDNSEgress,omitempty" json:"requireDNSEgress,omitempty"`
+}
+
+// Override returns the override for a namespace, or nil if there is none.
+func (n *NetworkSpec) Override(namespace string) *NetworkNamespaceOverride {
+	for i := range n.NamespaceOverrides {
+		if n.NamespaceOverrides[i].Namespace == namespace {
+			return &n.NamespaceOverrides[i]
+		}
+	}
+	return nil
+}
+
+// NamespaceRequirements returns whether a namespace must have a default-deny
+// policy and must allow DNS egress, applying its override if any.
+func (n *NetworkSpec) NamespaceRequirements(namespace string) (defaultDeny, dnsEgress bool) {
+	defaultDeny, dnsEgress = n.DefaultDeny, n.RequireDNSEgress
+	if override := n.Override(namespace); override != nil {
+		if override.DefaultDeny != nil {
+			defaultDeny = *override.DefaultDeny
+		}
+		if override.RequireDNSEgress != nil {
+			dnsEgress = *override.RequireDNSEgress
+		}
+	}
+	return defaultDeny, dnsEgress
 }
 
 // RequiredPolicy defines a required network policy.
@@ -67,8 +165,63 @@ type RequiredPolicy struct {
 
 // WorkloadsSpec defines workload security requirements.
 type WorkloadsSpec struct {
-	Containers *ContainerSpec `yaml:"containers,omitempty" json:"containers,omitempty"`
-	Images     *ImageSpec     `yaml:"images,omitempty" json:"images,omitempty"`
+	Containers       *ContainerSpec `yaml:"containers,omitempty" json:"containers,omitempty"`
+	Images           *ImageSpec     `yaml:"images,omitempty" json:"images,omitempty"`
+	RequireLiveness  bool           `yaml:"requireLiveness,omitempty" json:"requireLiveness,omitempty"`
+	RequireReadiness bool           `yaml:"requireReadiness,omitempty" json:"requireReadiness,omitempty"`
+	// IncludeInitContainers also requires the probes on sidecar init containers
+	// (restartPolicy: Always). Other init containers run to completion and cannot
+	// define probes.
+	IncludeInitContainers bool     `yaml:"includeInitContainers,omitempty" json:"includeInitContainers,omitempty"`
+	ExcludeContainers     []string `yaml:"excludeContainers,omitempty" json:"excludeContainers,omitempty"`
+	// IgnorePhases lists pod phases skipped by the workload checks. When unset,
+	// Succeeded pods are ignored; set it to an empty list to check every pod.
+	IgnorePhases []string `yaml:"ignorePhases,omitempty" json:"ignorePhases,omitempty"`
+	// ResourcePolicy flags over-provisioned containers and missing requests or limits.
+	ResourcePolicy *ResourcePolicySpec `yaml:"resourcePolicy,omitempty" json:"resourcePolicy,omitempty"`
+}
+
+// ResourcePolicySpec defines container resource request and limit requirements.
+type ResourcePolicySpec struct {
+	// MaxLimitToRequestRatio is the largest allowed CPU or memory limit to request
+	// ratio of a container, e.g. 4. Zero disables the ratio check.
+	MaxLimitToRequestRatio float64 `yaml:"maxLimitToRequestRatio,omitempty" json:"maxLimitToRequestRatio,omitempty"`
+	// RequireRequests flags containers without CPU and memory requests.
+	RequireRequests bool `yaml:"requireRequests,omitempty" json:"requireRequests,omitempty"`
+	// RequireLimits flags containers that request CPU or memory without a limit.
+	RequireLimits bool `yaml:"requireLimits,omitempty" json:"requireLimits,omitempty"`
+}
+
+// DefaultIgnoredPodPhases are the pod phases ignored when IgnorePhases is unset.
+var DefaultIgnoredPodPhases = []string{"Succeeded"}
+
+// IsContainerExcluded reports whether a container is excluded from workload requirements
+// by name, e.g. sidecars injected by a service mesh.
+func (w *WorkloadsSpec) IsContainerExcluded(name string) bool {
+	if w == nil {
+		return false
+	}
+	for _, excluded := range w.ExcludeContainers {
+		if excluded == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPodPhaseIgnored reports whether pods in the given phase are skipped by workload
+// checks, e.g. completed Job pods that will never run again.
+func (w *WorkloadsSpec) IsPodPhaseIgnored(phase string) bool {
+	phases := DefaultIgnoredPodPhases
+	if w != nil && w.IgnorePhases != nil {
+		phases = w.IgnorePhases
+	}
+	for _, ignored := range phases {
+		if ignored == phase {
+			return true
+		}
+	}
+	return false
 }
 
 // ContainerSpec defines container security requirements.
@@ -90,12 +243,34 @@ type ImageSpec struct {
 	BlockedRegistries []string `yaml:"blockedRegistries,omitempty" json:"blockedRegistries,omitempty"`
 	RequireDigests    bool     `yaml:"requireDigests" json:"requireDigests"`
 	RequireSignatures bool     `yaml:"requireSignatures" json:"requireSignatures"`
+
+	// SignaturePublicKey is the PEM-encoded cosign public key image signatures must
+	// verify against. Without it, any cosign signature satisfies requireSignatures.
+	SignaturePublicKey string `yaml:"signaturePublicKey,omitempty" json:"signaturePublicKey,omitempty"`
+}
+
+// SignatureKey parses SignaturePublicKey, returning nil when no key is configured.
+func (i *ImageSpec) SignatureKey() (crypto.PublicKey, error) {
+	if i == nil || strings.TrimSpace(i.SignaturePublicKey) == "" {
+		return nil, nil
+	}
+
+	block, _ := pem.Decode([]byte(i.SignaturePublicKey))
+	if block == nil {
+		return nil, fmt.Errorf("signaturePublicKey is not PEM encoded")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("signaturePublicKey is not a valid public key: %w", err)
+	}
+	return key, nil
 }
 
 // RBACSpec defines RBAC requirements.
 type RBACSpec struct {
-	MinimumRules   []RBACRule `yaml:"minimumRules,omitempty" json:"minimumRules,omitempty"`
-	ForbiddenRules []RBACRule `yaml:"forbiddenRules,omitempty" json:"forbiddenRules,omitempty"`
+	MinimumRules               []RBACRule `yaml:"minimumRules,omitempty" json:"minimumRules,omitempty"`
+	ForbiddenRules             []RBACRule `yaml:"forbiddenRules,omitempty" json:"forbiddenRules,omitempty"`
+	ForbidWorkloadClusterAdmin bool       `yaml:"forbidWorkloadClusterAdmin,omitempty" json:"forbidWorkloadClusterAdmin,omitempty"`
 }
 
 // RBACRule defines an RBAC rule.
@@ -147,6 +322,68 @@ type AuditLogSpec struct {
 	MinRetentionDays int  `yaml:"minRetentionDays" json:"minRetentionDays"`
 }
 
+// AvailabilitySpec defines workload availability requirements.
+type AvailabilitySpec struct {
+	RequireTopologySpread bool `yaml:"requireTopologySpread,omitempty" json:"requireTopologySpread,omitempty"`
+}
+
+// CapacitySpec defines node capacity requirements.
+type CapacitySpec struct {
+	MaxPodsPerNode int `yaml:"maxPodsPerNode,omitempty" json:"maxPodsPerNode,omitempty"`
+}
+
+// SchedulingSpec defines workload scheduling requirements.
+type SchedulingSpec struct {
+	// RequiredPriorityClass is the priorityClassName critical workloads must use.
+	RequiredPriorityClass string `yaml:"requiredPriorityClass,omitempty" json:"requiredPriorityClass,omitempty"`
+
+	// Namespaces limits the requirement to workloads in these namespaces.
+	// Empty means every non-system namespace.
+	Namespaces []string `yaml:"namespaces,omitempty" json:"namespaces,omitempty"`
+
+	// Selector limits the requirement to workloads whose pod template carries
+	// all of these labels. Empty means every workload.
+	Selector map[string]string `yaml:"selector,omitempty" json:"selector,omitempty"`
+}
+
+// SecretsSpec defines secret handling requirements.
+type SecretsSpec struct {
+	// ForbidPlaintextEnv flags container env vars whose names look like secrets
+	// but that are set as a literal value rather than from a Secret.
+	ForbidPlaintextEnv bool `yaml:"forbidPlaintextEnv,omitempty" json:"forbidPlaintextEnv,omitempty"`
+
+	// EnvNamePatterns are case-insensitive glob patterns for secret-like env var
+	// names. Defaults to DefaultSecretEnvNamePatterns.
+	EnvNamePatterns []string `yaml:"envNamePatterns,omitempty" json:"envNamePatterns,omitempty"`
+}
+
+// DefaultSecretEnvNamePatterns are the secret-like env var name patterns used when
+// EnvNamePatterns is unset.
+var DefaultSecretEnvNamePatterns = []string{"*PASSWORD*", "*PASSWD*", "*SECRET*", "*TOKEN*", "*KEY*", "*CREDENTIAL*"}
+
+// ScoringSpec defines per-check weights for the weighted compliance score.
+type ScoringSpec struct {
+	// Weights are keyed by check name (e.g. "rbac.validation") or check category,
+	// the part of the name before the first dot (e.g. "rbac"). Checks without a
+	// weight count 1; a weight of 0 leaves a check out of the weighted score.
+	Weights map[string]int `yaml:"weights,omitempty" json:"weights,omitempty"`
+}
+
+// Weight returns the weight of a check, preferring an entry for the full check
+// name over one for its category.
+func (s *ScoringSpec) Weight(checkName string) int {
+	if s == nil {
+		return 1
+	}
+	if weight, ok := s.Weights[checkName]; ok {
+		return weight
+	}
+	if weight, ok := s.Weights[strings.SplitN(checkName, ".", 2)[0]]; ok {
+		return weight
+	}
+	return 1
+}
+
 // ComplianceSpec defines compliance framework mappings.
 type ComplianceSpec struct {
 	Frameworks []ComplianceFramework `yaml:"frameworks,omitempty" json:"frameworks,omitempty"`
@@ -170,3 +407,22 @@ type ComplianceControl struct {
 type ControlMapping struct {
 	Check string `yaml:"check" json:"check"`
 }
+
+// CELCheckSpec defines a custom check as a CEL expression evaluated against
+// every resource of a kind. The resource is bound to the variable "object",
+// and the expression returns true for compliant resources, e.g.
+// has(object.metadata.labels) && "team" in object.metadata.labels.
+type CELCheckSpec struct {
+	// Name identifies the custom check in reports
+	Name string `yaml:"name" json:"name"`
+	// APIVersion is the group/version of the target resources, e.g. "apps/v1"
+	APIVersion string `yaml:"apiVersion" json:"apiVersion"`
+	// Kind is the kind of the target resources, e.g. "Deployment"
+	Kind string `yaml:"kind" json:"kind"`
+	// Expression is the CEL expression compliant resources satisfy
+	Expression string `yaml:"expression" json:"expression"`
+	// Message describes a violation; defaults to the expression
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+	// Severity of violations: critical, high, medium or low (default: medium)
+	Severity string `yaml:"severity,omitempty" json:"severity,omitempty"`
+}
@@ -2,109 +2,348 @@
 package spec
 
 import (
+	"errors"
 	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
-// Validate checks if a cluster specification is valid.
+// FieldError is a validation problem with one field of a specification.
+type FieldError struct {
+	// Field is the path of the offending field, e.g. "spec.kubernetes.minVersion".
+	Field string
+
+	// Detail describes what is wrong with the field.
+	Detail string
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Detail)
+}
+
+// fieldErrors collects the validation problems of a specification.
+type fieldErrors []error
+
+// add records a problem with field.
+func (errs *fieldErrors) add(field, format string, args ...interface{}) {
+	*errs = append(*errs, &FieldError{Field: field, Detail: fmt.Sprintf(format, args...)})
+}
+
+// Validate checks that a cluster specification is well-formed and internally
+// consistent. Every problem found is reported as a *FieldError naming the
+// offending field, and the problems are joined into a single error.
 func Validate(spec *ClusterSpecification) error {
 	if spec == nil {
 		return fmt.Errorf("spec cannot be nil")
 	}
 
+	var errs fieldErrors
+
 	// Validate APIVersion
-	if spec.APIVersion != "kspec.dev/v1" {
-		return fmt.Errorf("unsupported apiVersion: %s (expected kspec.dev/v1)", spec.APIVersion)
+	if spec.APIVersion != CurrentAPIVersion {
+		errs.add("apiVersion", "unsupported apiVersion %s (expected %s)", spec.APIVersion, CurrentAPIVersion)
 	}
 
 	// Validate Kind
 	if spec.Kind != "ClusterSpecification" {
-		return fmt.Errorf("unsupported kind: %s (expected ClusterSpecification)", spec.Kind)
+		errs.add("kind", "unsupported kind %s (expected ClusterSpecification)", spec.Kind)
 	}
 
 	// Validate metadata
 	if spec.Metadata.Name == "" {
-		return fmt.Errorf("metadata.name is required")
+		errs.add("metadata.name", "required")
 	}
 
 	if spec.Metadata.Version == "" {
-		return fmt.Errorf("metadata.version is required")
+		errs.add("metadata.version", "required")
+	} else if _, err := semver.NewVersion(spec.Metadata.Version); err != nil {
+		errs.add("metadata.version", "must be valid semver: %v", err)
 	}
 
-	// Validate metadata version is valid semver
-	if _, err := semver.NewVersion(spec.Metadata.Version); err != nil {
-		return fmt.Errorf("metadata.version must be valid semver: %w", err)
+	// Owner and team are propagated as labels, so they must be valid label values
+	if problems := validation.IsValidLabelValue(spec.Metadata.Owner); len(problems) > 0 {
+		errs.add("metadata.owner", "must be a valid label value: %s", strings.Join(problems, "; "))
+	}
+	if problems := validation.IsValidLabelValue(spec.Metadata.Team); len(problems) > 0 {
+		errs.add("metadata.team", "must be a valid label value: %s", strings.Join(problems, "; "))
 	}
 
 	// Validate Kubernetes version requirements
-	if err := validateKubernetesSpec(&spec.Spec.Kubernetes); err != nil {
-		return fmt.Errorf("invalid kubernetes spec: %w", err)
-	}
+	validateKubernetesSpec(&errs, &spec.Spec.Kubernetes)
 
 	// Validate Pod Security Standards if specified
 	if spec.Spec.PodSecurity != nil {
-		if err := validatePodSecuritySpec(spec.Spec.PodSecurity); err != nil {
-			return fmt.Errorf("invalid podSecurity spec: %w", err)
+		validatePodSecuritySpec(&errs, spec.Spec.PodSecurity, time.Now())
+	}
+
+	// Validate network requirements if specified
+	if spec.Spec.Network != nil {
+		validateNetworkSpec(&errs, spec.Spec.Network)
+	}
+
+	// Validate workload requirements if specified
+	if spec.Spec.Workloads != nil {
+		validateWorkloadsSpec(&errs, spec.Spec.Workloads)
+	}
+
+	// Validate capacity requirements if specified
+	if spec.Spec.Capacity != nil && spec.Spec.Capacity.MaxPodsPerNode < 0 {
+		errs.add("spec.capacity.maxPodsPerNode", "must not be negative (got: %d)", spec.Spec.Capacity.MaxPodsPerNode)
+	}
+
+	// Validate scheduling requirements if specified
+	if spec.Spec.Scheduling != nil && spec.Spec.Scheduling.RequiredPriorityClass == "" &&
+		(len(spec.Spec.Scheduling.Namespaces) > 0 || len(spec.Spec.Scheduling.Selector) > 0) {
+		errs.add("spec.scheduling.requiredPriorityClass", "required when namespaces or selector is set")
+	}
+
+	// Validate secret env name patterns if specified
+	if spec.Spec.Secrets != nil {
+		for i, pattern := range spec.Spec.Secrets.EnvNamePatterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				errs.add(fmt.Sprintf("spec.secrets.envNamePatterns[%d]", i), "malformed pattern %q: %v", pattern, err)
+			}
+		}
+	}
+
+	// Validate scoring weights if specified
+	if spec.Spec.Scoring != nil {
+		var keys []string
+		for key, weight := range spec.Spec.Scoring.Weights {
+			if weight < 0 {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			errs.add(fmt.Sprintf("spec.scoring.weights[%s]", key), "must not be negative (got: %d)", spec.Spec.Scoring.Weights[key])
 		}
 	}
 
-	return nil
+	// Validate custom checks if specified; expressions are compiled by the scanner
+	validateCustomChecks(&errs, spec.Spec.CustomChecks)
+
+	// Validate severity remapping
+	validateSeverityRemapping(&errs, &spec.Spec)
+
+	return errors.Join(errs...)
+}
+
+// validPodPhases are the pod phases accepted in workloads.ignorePhases.
+var validPodPhases = map[string]bool{
+	"Pending":   true,
+	"Running":   true,
+	"Succeeded": true,
+	"Failed":    true,
+	"Unknown":   true,
+}
+
+// validSeverities are the severities checks can report.
+var validSeverities = map[string]bool{
+	"critical": true,
+	"high":     true,
+	"medium":   true,
+	"low":      true,
+}
+
+// validPodSecurityLevels are the Pod Security Standards levels.
+var validPodSecurityLevels = map[string]bool{
+	"privileged": true,
+	"baseline":   true,
+	"restricted": true,
+}
+
+// sortedKeys returns the keys of m in order, so problems are reported in a
+// stable order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateSeverityRemapping validates severityOverrides and severityLabels. Override
+// check names are validated by the scanner, which knows the registered checks.
+func validateSeverityRemapping(errs *fieldErrors, fields *SpecFields) {
+	for _, checkName := range sortedKeys(fields.SeverityOverrides) {
+		field := fmt.Sprintf("spec.severityOverrides[%s]", checkName)
+		if checkName == "" {
+			errs.add(field, "check name must not be empty")
+			continue
+		}
+		if severity := fields.SeverityOverrides[checkName]; !validSeverities[severity] {
+			errs.add(field, "invalid severity %q (must be one of: critical, high, medium, low)", severity)
+		}
+	}
+
+	for _, severity := range sortedKeys(fields.SeverityLabels) {
+		field := fmt.Sprintf("spec.severityLabels[%s]", severity)
+		if !validSeverities[severity] {
+			errs.add(field, "unknown severity %q (must be one of: critical, high, medium, low)", severity)
+			continue
+		}
+		if fields.SeverityLabels[severity] == "" {
+			errs.add(field, "label must not be empty")
+		}
+	}
+}
+
+// validateCustomChecks validates that each custom check is named uniquely and
+// names its target resources, expression and severity.
+func validateCustomChecks(errs *fieldErrors, customChecks []CELCheckSpec) {
+	names := map[string]bool{}
+	for i, check := range customChecks {
+		field := fmt.Sprintf("spec.customChecks[%d]", i)
+
+		if check.Name == "" {
+			errs.add(field+".name", "required")
+		} else if problems := validation.IsDNS1123Subdomain(check.Name); len(problems) > 0 {
+			errs.add(field+".name", "must be a lowercase DNS subdomain: %s", strings.Join(problems, "; "))
+		} else if names[check.Name] {
+			errs.add(field+".name", "duplicate custom check %q", check.Name)
+		}
+		names[check.Name] = true
+
+		if check.APIVersion == "" {
+			errs.add(field+".apiVersion", "required")
+		} else if _, err := schema.ParseGroupVersion(check.APIVersion); err != nil {
+			errs.add(field+".apiVersion", "invalid apiVersion %q: %v", check.APIVersion, err)
+		}
+		if check.Kind == "" {
+			errs.add(field+".kind", "required")
+		}
+		if strings.TrimSpace(check.Expression) == "" {
+			errs.add(field+".expression", "required")
+		}
+		if check.Severity != "" && !validSeverities[check.Severity] {
+			errs.add(field+".severity", "invalid severity %q (must be one of: critical, high, medium, low)", check.Severity)
+		}
+	}
 }
 
 // validateKubernetesSpec validates the Kubernetes version specification.
-func validateKubernetesSpec(k *KubernetesSpec) error {
+func validateKubernetesSpec(errs *fieldErrors, k *KubernetesSpec) {
+	var minVer, maxVer *semver.Version
+
 	if k.MinVersion == "" {
-		return fmt.Errorf("minVersion is required")
+		errs.add("spec.kubernetes.minVersion", "required")
+	} else if v, err := semver.NewVersion(k.MinVersion); err != nil {
+		errs.add("spec.kubernetes.minVersion", "must be valid semver: %v", err)
+	} else {
+		minVer = v
 	}
 
 	if k.MaxVersion == "" {
-		return fmt.Errorf("maxVersion is required")
+		errs.add("spec.kubernetes.maxVersion", "required")
+	} else if v, err := semver.NewVersion(k.MaxVersion); err != nil {
+		errs.add("spec.kubernetes.maxVersion", "must be valid semver: %v", err)
+	} else {
+		maxVer = v
 	}
 
-	minVer, err := semver.NewVersion(k.MinVersion)
-	if err != nil {
-		return fmt.Errorf("minVersion must be valid semver: %w", err)
+	if minVer != nil && maxVer != nil && minVer.GreaterThan(maxVer) {
+		errs.add("spec.kubernetes.minVersion", "%s cannot be greater than maxVersion %s", k.MinVersion, k.MaxVersion)
 	}
 
-	maxVer, err := semver.NewVersion(k.MaxVersion)
-	if err != nil {
-		return fmt.Errorf("maxVersion must be valid semver: %w", err)
+	// Validate excluded versions
+	for i, ver := range k.ExcludedVersions {
+		if _, err := semver.NewVersion(ver); err != nil {
+			errs.add(fmt.Sprintf("spec.kubernetes.excludedVersions[%d]", i), "%s must be valid semver: %v", ver, err)
+		}
 	}
+}
 
-	if minVer.GreaterThan(maxVer) {
-		return fmt.Errorf("minVersion (%s) cannot be greater than maxVersion (%s)", k.MinVersion, k.MaxVersion)
+// validatePodSecuritySpec validates the Pod Security Standards specification.
+// Exemptions that expired before now are rejected.
+func validatePodSecuritySpec(errs *fieldErrors, pss *PodSecuritySpec, now time.Time) {
+	levels := []struct {
+		field string
+		level string
+	}{
+		{"spec.podSecurity.enforce", pss.Enforce},
+		{"spec.podSecurity.audit", pss.Audit},
+		{"spec.podSecurity.warn", pss.Warn},
+	}
+	for _, l := range levels {
+		if !validPodSecurityLevels[l.level] {
+			errs.add(l.field, "must be one of: privileged, baseline, restricted (got: %s)", l.level)
+		}
 	}
 
-	// Validate excluded versions
-	for _, ver := range k.ExcludedVersions {
-		if _, err := semver.NewVersion(ver); err != nil {
-			return fmt.Errorf("excludedVersion %s must be valid semver: %w", ver, err)
+	for i, exemption := range pss.Exemptions {
+		field := fmt.Sprintf("spec.podSecurity.exemptions[%d]", i)
+		if exemption.Namespace == "" {
+			errs.add(field+".namespace", "required")
+		}
+		if !validPodSecurityLevels[exemption.Level] {
+			errs.add(field+".level", "must be one of: privileged, baseline, restricted (got: %s)", exemption.Level)
+		}
+		if exemption.ExpiresAt != nil && exemption.ExpiresAt.Before(now) {
+			errs.add(field+".expiresAt", "exemption for namespace %s expired at %s", exemption.Namespace,
+				exemption.ExpiresAt.UTC().Format(time.RFC3339))
 		}
 	}
+}
 
-	return nil
+// validateNetworkSpec validates the network policy requirements.
+func validateNetworkSpec(errs *fieldErrors, network *NetworkSpec) {
+	seen := map[string]bool{}
+	for i, override := range network.NamespaceOverrides {
+		field := fmt.Sprintf("spec.network.namespaceOverrides[%d].namespace", i)
+		if override.Namespace == "" {
+			errs.add(field, "required")
+			continue
+		}
+		if problems := validation.IsDNS1123Label(override.Namespace); len(problems) > 0 {
+			errs.add(field, "must be a valid namespace name: %s", strings.Join(problems, "; "))
+		}
+		if seen[override.Namespace] {
+			errs.add(field, "duplicate override for namespace %s", override.Namespace)
+		}
+		seen[override.Namespace] = true
+	}
 }
 
-// validatePodSecuritySpec validates the Pod Security Standards specification.
-func validatePodSecuritySpec(pss *PodSecuritySpec) error {
-	validLevels := map[string]bool{
-		"privileged": true,
-		"baseline":   true,
-		"restricted": true,
+// validateWorkloadsSpec validates the workload security requirements.
+func validateWorkloadsSpec(errs *fieldErrors, workloads *WorkloadsSpec) {
+	for i, phase := range workloads.IgnorePhases {
+		if !validPodPhases[phase] {
+			errs.add(fmt.Sprintf("spec.workloads.ignorePhases[%d]", i),
+				"unknown pod phase %s (expected Pending, Running, Succeeded, Failed or Unknown)", phase)
+		}
 	}
 
-	if !validLevels[pss.Enforce] {
-		return fmt.Errorf("enforce must be one of: privileged, baseline, restricted (got: %s)", pss.Enforce)
+	// Limits below requests are rejected by Kubernetes, so ratios below 1 are typos
+	if policy := workloads.ResourcePolicy; policy != nil && policy.MaxLimitToRequestRatio != 0 && policy.MaxLimitToRequestRatio < 1 {
+		errs.add("spec.workloads.resourcePolicy.maxLimitToRequestRatio", "must be 0 or at least 1 (got: %g)", policy.MaxLimitToRequestRatio)
 	}
 
-	if !validLevels[pss.Audit] {
-		return fmt.Errorf("audit must be one of: privileged, baseline, restricted (got: %s)", pss.Audit)
+	images := workloads.Images
+	if images == nil {
+		return
 	}
 
-	if !validLevels[pss.Warn] {
-		return fmt.Errorf("warn must be one of: privileged, baseline, restricted (got: %s)", pss.Warn)
+	if _, err := images.SignatureKey(); err != nil {
+		errs.add("spec.workloads.images.signaturePublicKey", "%v", err)
 	}
 
-	return nil
+	// A registry cannot be both allowed and blocked
+	allowed := map[string]bool{}
+	for _, registry := range images.AllowedRegistries {
+		allowed[registry] = true
+	}
+	for i, registry := range images.BlockedRegistries {
+		if allowed[registry] {
+			errs.add(fmt.Sprintf("spec.workloads.images.blockedRegistries[%d]", i), "registry %s is also listed in allowedRegistries", registry)
+		}
+	}
 }
@@ -1,7 +1,10 @@
 package spec
 
 import (
+	"errors"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestValidate_ValidSpec(t *testing.T) {
@@ -111,6 +114,214 @@ func TestValidate_InvalidVersionRange(t *testing.T) {
 	}
 }
 
+func TestValidate_NegativeMaxPodsPerNode(t *testing.T) {
+	clusterSpec := &ClusterSpecification{
+		APIVersion: "kspec.dev/v1",
+		Kind:       "ClusterSpecification",
+		Metadata: Metadata{
+			Name:    "test-cluster",
+			Version: "1.0.0",
+		},
+		Spec: SpecFields{
+			Kubernetes: KubernetesSpec{
+				MinVersion: "1.26.0",
+				MaxVersion: "1.30.0",
+			},
+			Capacity: &CapacitySpec{
+				MaxPodsPerNode: -1,
+			},
+		},
+	}
+
+	err := Validate(clusterSpec)
+	if err == nil {
+		t.Error("Expected validation error for negative maxPodsPerNode, got nil")
+	}
+}
+
+func TestValidate_Ownership(t *testing.T) {
+	tests := []struct {
+		name    string
+		owner   string
+		team    string
+		wantErr bool
+	}{
+		{"unset", "", "", false},
+		{"valid owner and team", "jane.doe", "payments-platform", false},
+		{"owner with spaces", "Jane Doe", "", true},
+		{"team too long", "", "team-name-that-is-much-longer-than-the-sixty-three-character-limit", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterSpec := &ClusterSpecification{
+				APIVersion: "kspec.dev/v1",
+				Kind:       "ClusterSpecification",
+				Metadata: Metadata{
+					Name:    "test-cluster",
+					Version: "1.0.0",
+					Owner:   tt.owner,
+					Team:    tt.team,
+				},
+				Spec: SpecFields{
+					Kubernetes: KubernetesSpec{
+						MinVersion: "1.26.0",
+						MaxVersion: "1.30.0",
+					},
+				},
+			}
+
+			err := Validate(clusterSpec)
+			if tt.wantErr && err == nil {
+				t.Error("Expected validation error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidate_ScoringWeights(t *testing.T) {
+	clusterSpec := &ClusterSpecification{
+		APIVersion: "kspec.dev/v1",
+		Kind:       "ClusterSpecification",
+		Metadata:   Metadata{Name: "test-cluster", Version: "1.0.0"},
+		Spec: SpecFields{
+			Kubernetes: KubernetesSpec{MinVersion: "1.26.0", MaxVersion: "1.30.0"},
+			Scoring:    &ScoringSpec{Weights: map[string]int{"rbac": 3, "observability": 0}},
+		},
+	}
+	if err := Validate(clusterSpec); err != nil {
+		t.Errorf("Unexpected validation error: %v", err)
+	}
+
+	clusterSpec.Spec.Scoring.Weights["rbac"] = -1
+	if err := Validate(clusterSpec); err == nil {
+		t.Error("Expected validation error for negative weight, got nil")
+	}
+}
+
+func TestValidate_SecretEnvNamePatterns(t *testing.T) {
+	clusterSpec := &ClusterSpecification{
+		APIVersion: "kspec.dev/v1",
+		Kind:       "ClusterSpecification",
+		Metadata:   Metadata{Name: "test-cluster", Version: "1.0.0"},
+		Spec: SpecFields{
+			Kubernetes: KubernetesSpec{MinVersion: "1.26.0", MaxVersion: "1.30.0"},
+			Secrets:    &SecretsSpec{ForbidPlaintextEnv: true, EnvNamePatterns: []string{"*_SALT"}},
+		},
+	}
+	if err := Validate(clusterSpec); err != nil {
+		t.Errorf("Unexpected validation error: %v", err)
+	}
+
+	clusterSpec.Spec.Secrets.EnvNamePatterns = []string{"[*_SALT"}
+	if err := Validate(clusterSpec); err == nil {
+		t.Error("Expected validation error for malformed pattern, got nil")
+	}
+}
+
+func TestValidate_SchedulingSelectorRequiresPriorityClass(t *testing.T) {
+	clusterSpec := &ClusterSpecification{
+		APIVersion: "kspec.dev/v1",
+		Kind:       "ClusterSpecification",
+		Metadata:   Metadata{Name: "test-cluster", Version: "1.0.0"},
+		Spec: SpecFields{
+			Kubernetes: KubernetesSpec{MinVersion: "1.26.0", MaxVersion: "1.30.0"},
+			Scheduling: &SchedulingSpec{Namespaces: []string{"payments"}},
+		},
+	}
+	if err := Validate(clusterSpec); err == nil {
+		t.Error("Expected validation error for a selector without requiredPriorityClass, got nil")
+	}
+
+	clusterSpec.Spec.Scheduling.RequiredPriorityClass = "business-critical"
+	if err := Validate(clusterSpec); err != nil {
+		t.Errorf("Unexpected validation error: %v", err)
+	}
+}
+
+func TestScoringSpec_Weight(t *testing.T) {
+	scoring := &ScoringSpec{Weights: map[string]int{"rbac": 3, "rbac.validation": 5, "workload": 2}}
+
+	tests := []struct {
+		check string
+		want  int
+	}{
+		{"rbac.validation", 5},
+		{"rbac.other", 3},
+		{"workload.security", 2},
+		{"network.policies", 1},
+	}
+	for _, tt := range tests {
+		if got := scoring.Weight(tt.check); got != tt.want {
+			t.Errorf("Weight(%s) = %d, expected %d", tt.check, got, tt.want)
+		}
+	}
+
+	var unset *ScoringSpec
+	if got := unset.Weight("rbac.validation"); got != 1 {
+		t.Errorf("Weight with no scoring spec = %d, expected 1", got)
+	}
+}
+
+func TestValidate_SeverityRemapping(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		labels    map[string]string
+		wantErr   bool
+	}{
+		{
+			name:      "valid overrides and labels",
+			overrides: map[string]string{"workload.security": "critical"},
+			labels:    map[string]string{"critical": "P1", "low": "P4"},
+		},
+		{
+			name:      "invalid override severity",
+			overrides: map[string]string{"workload.security": "P1"},
+			wantErr:   true,
+		},
+		{
+			name:    "unknown label severity",
+			labels:  map[string]string{"urgent": "P1"},
+			wantErr: true,
+		},
+		{
+			name:    "empty label",
+			labels:  map[string]string{"high": ""},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterSpec := &ClusterSpecification{
+				APIVersion: "kspec.dev/v1",
+				Kind:       "ClusterSpecification",
+				Metadata: Metadata{
+					Name:    "test-cluster",
+					Version: "1.0.0",
+				},
+				Spec: SpecFields{
+					Kubernetes: KubernetesSpec{
+						MinVersion: "1.26.0",
+						MaxVersion: "1.30.0",
+					},
+					SeverityOverrides: tt.overrides,
+					SeverityLabels:    tt.labels,
+				},
+			}
+
+			err := Validate(clusterSpec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidate_NilSpec(t *testing.T) {
 	err := Validate(nil)
 	if err == nil {
@@ -149,3 +360,279 @@ func TestValidate_WithOptionalFields(t *testing.T) {
 		t.Errorf("Validate failed for spec with optional fields: %v", err)
 	}
 }
+
+func TestValidate_ResourcePolicyRatio(t *testing.T) {
+	tests := []struct {
+		name    string
+		ratio   float64
+		wantErr bool
+	}{
+		{"disabled", 0, false},
+		{"limits equal to requests", 1, false},
+		{"fractional ratio", 2.5, false},
+		{"below one", 0.5, true},
+		{"negative", -1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterSpec := &ClusterSpecification{
+				APIVersion: "kspec.dev/v1",
+				Kind:       "ClusterSpecification",
+				Metadata: Metadata{
+					Name:    "test-cluster",
+					Version: "1.0.0",
+				},
+				Spec: SpecFields{
+					Kubernetes: KubernetesSpec{
+						MinVersion: "1.26.0",
+						MaxVersion: "1.30.0",
+					},
+					Workloads: &WorkloadsSpec{
+						ResourcePolicy: &ResourcePolicySpec{MaxLimitToRequestRatio: tt.ratio},
+					},
+				},
+			}
+
+			err := Validate(clusterSpec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_SignaturePublicKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{"unset", "", false},
+		{"ecdsa key", "-----BEGIN PUBLIC KEY-----\nMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEkWLGUlPoaMLxdH7SKvJYwuM6u7DF\nUdGw7mNMT/YEpo65UAwNexpCs9gN3YF+uFFqPoXa09C9qKYOaRwqlP+VHA==\n-----END PUBLIC KEY-----\n", false},
+		{"not pem", "cosign.pub", true},
+		{"not a public key", "-----BEGIN PUBLIC KEY-----\nbm90IGEga2V5\n-----END PUBLIC KEY-----\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterSpec := &ClusterSpecification{
+				APIVersion: "kspec.dev/v1",
+				Kind:       "ClusterSpecification",
+				Metadata: Metadata{
+					Name:    "test-cluster",
+					Version: "1.0.0",
+				},
+				Spec: SpecFields{
+					Kubernetes: KubernetesSpec{
+						MinVersion: "1.26.0",
+						MaxVersion: "1.30.0",
+					},
+					Workloads: &WorkloadsSpec{
+						Images: &ImageSpec{RequireSignatures: true, SignaturePublicKey: tt.key},
+					},
+				},
+			}
+
+			err := Validate(clusterSpec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// validationFields returns the field paths of the problems reported by Validate.
+func validationFields(t *testing.T, err error) []string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("Expected joined validation errors, got %T: %v", err, err)
+	}
+
+	var fields []string
+	for _, e := range joined.Unwrap() {
+		var fieldErr *FieldError
+		if !errors.As(e, &fieldErr) {
+			t.Fatalf("Expected *FieldError, got %T: %v", e, e)
+		}
+		fields = append(fields, fieldErr.Field)
+	}
+	return fields
+}
+
+func TestValidate_SemanticErrors(t *testing.T) {
+	past := time.Now().Add(-24 * time.Hour)
+	future := time.Now().Add(24 * time.Hour)
+
+	tests := []struct {
+		name       string
+		modify     func(s *SpecFields)
+		wantFields []string
+	}{
+		{
+			name: "minVersion greater than maxVersion",
+			modify: func(s *SpecFields) {
+				s.Kubernetes.MinVersion = "1.30.0"
+				s.Kubernetes.MaxVersion = "1.28.0"
+			},
+			wantFields: []string{"spec.kubernetes.minVersion"},
+		},
+		{
+			name: "unknown pod security levels",
+			modify: func(s *SpecFields) {
+				s.PodSecurity = &PodSecuritySpec{Enforce: "strict", Audit: "baseline", Warn: "Restricted"}
+			},
+			wantFields: []string{"spec.podSecurity.enforce", "spec.podSecurity.warn"},
+		},
+		{
+			name: "unknown exemption level",
+			modify: func(s *SpecFields) {
+				s.PodSecurity = &PodSecuritySpec{
+					Enforce: "restricted", Audit: "restricted", Warn: "restricted",
+					Exemptions: []PodSecurityExemption{{Namespace: "legacy", Level: "none"}},
+				}
+			},
+			wantFields: []string{"spec.podSecurity.exemptions[0].level"},
+		},
+		{
+			name: "registry both allowed and blocked",
+			modify: func(s *SpecFields) {
+				s.Workloads = &WorkloadsSpec{Images: &ImageSpec{
+					AllowedRegistries: []string{"ghcr.io", "docker.io"},
+					BlockedRegistries: []string{"quay.io", "docker.io"},
+				}}
+			},
+			wantFields: []string{"spec.workloads.images.blockedRegistries[1]"},
+		},
+		{
+			name: "expired exemption",
+			modify: func(s *SpecFields) {
+				s.PodSecurity = &PodSecuritySpec{
+					Enforce: "restricted", Audit: "restricted", Warn: "restricted",
+					Exemptions: []PodSecurityExemption{
+						{Namespace: "current", Level: "baseline", ExpiresAt: &future},
+						{Namespace: "stale", Level: "baseline", ExpiresAt: &past},
+					},
+				}
+			},
+			wantFields: []string{"spec.podSecurity.exemptions[1].expiresAt"},
+		},
+		{
+			name: "invalid network namespace overrides",
+			modify: func(s *SpecFields) {
+				s.Network = &NetworkSpec{NamespaceOverrides: []NetworkNamespaceOverride{
+					{Namespace: "ingress"},
+					{Namespace: ""},
+					{Namespace: "Ingress_Controllers"},
+					{Namespace: "ingress"},
+				}}
+			},
+			wantFields: []string{
+				"spec.network.namespaceOverrides[1].namespace",
+				"spec.network.namespaceOverrides[2].namespace",
+				"spec.network.namespaceOverrides[3].namespace",
+			},
+		},
+		{
+			name: "invalid custom checks",
+			modify: func(s *SpecFields) {
+				s.CustomChecks = []CELCheckSpec{
+					{Name: "team-label", APIVersion: "apps/v1", Kind: "Deployment", Expression: "true"},
+					{Name: "team-label", APIVersion: "apps/v1/extra", Kind: "Deployment", Expression: "true"},
+					{Name: "Team Label", Expression: " ", Severity: "urgent"},
+				}
+			},
+			wantFields: []string{
+				"spec.customChecks[1].name",
+				"spec.customChecks[1].apiVersion",
+				"spec.customChecks[2].name",
+				"spec.customChecks[2].apiVersion",
+				"spec.customChecks[2].kind",
+				"spec.customChecks[2].expression",
+				"spec.customChecks[2].severity",
+			},
+		},
+		{
+			name: "valid custom checks",
+			modify: func(s *SpecFields) {
+				s.CustomChecks = []CELCheckSpec{
+					{Name: "team-label", APIVersion: "apps/v1", Kind: "Deployment", Expression: `"team" in object.metadata.labels`, Severity: "high"},
+					{Name: "service-type", APIVersion: "v1", Kind: "Service", Expression: `object.spec.type != "NodePort"`},
+				}
+			},
+		},
+		{
+			name: "unexpired and open-ended exemptions",
+			modify: func(s *SpecFields) {
+				s.PodSecurity = &PodSecuritySpec{
+					Enforce: "restricted", Audit: "restricted", Warn: "restricted",
+					Exemptions: []PodSecurityExemption{
+						{Namespace: "current", Level: "baseline", ExpiresAt: &future},
+						{Namespace: "legacy", Level: "privileged"},
+					},
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterSpec := &ClusterSpecification{
+				APIVersion: "kspec.dev/v1",
+				Kind:       "ClusterSpecification",
+				Metadata: Metadata{
+					Name:    "test-cluster",
+					Version: "1.0.0",
+				},
+				Spec: SpecFields{
+					Kubernetes: KubernetesSpec{
+						MinVersion: "1.26.0",
+						MaxVersion: "1.30.0",
+					},
+				},
+			}
+			tt.modify(&clusterSpec.Spec)
+
+			fields := validationFields(t, Validate(clusterSpec))
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("Expected errors for %v, got %v", tt.wantFields, fields)
+			}
+		})
+	}
+}
+
+func TestValidate_ReportsAllErrors(t *testing.T) {
+	clusterSpec := &ClusterSpecification{
+		APIVersion: "kspec.dev/v2",
+		Kind:       "ClusterSpecification",
+		Metadata: Metadata{
+			Version: "1.0.0",
+		},
+		Spec: SpecFields{
+			Kubernetes: KubernetesSpec{
+				MinVersion: "1.30.0",
+				MaxVersion: "1.26.0",
+			},
+			Capacity: &CapacitySpec{MaxPodsPerNode: -1},
+			Scoring:  &ScoringSpec{Weights: map[string]int{"rbac.validation": -1, "network.policies": -2}},
+		},
+	}
+
+	err := Validate(clusterSpec)
+	want := []string{
+		"apiVersion",
+		"metadata.name",
+		"spec.kubernetes.minVersion",
+		"spec.capacity.maxPodsPerNode",
+		"spec.scoring.weights[network.policies]",
+		"spec.scoring.weights[rbac.validation]",
+	}
+	if fields := validationFields(t, err); !reflect.DeepEqual(fields, want) {
+		t.Errorf("Expected errors for %v, got %v", want, fields)
+	}
+}
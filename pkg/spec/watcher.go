@@ -0,0 +1,159 @@
+// Package spec defines the cluster specification schema for kspec.
+package spec
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDebounce coalesces the burst of events editors emit for a single save.
+const reloadDebounce = 100 * time.Millisecond
+
+// Source provides the current cluster specification to long-running watch modes.
+type Source interface {
+	// Current returns the specification to evaluate
+	Current() *ClusterSpecification
+
+	// Changes is signalled after the specification has changed (nil if it never changes)
+	Changes() <-chan struct{}
+}
+
+// staticSource is a Source for a specification that never changes.
+type staticSource struct {
+	spec *ClusterSpecification
+}
+
+// StaticSource returns a Source that always provides the given specification.
+func StaticSource(spec *ClusterSpecification) Source {
+	return &staticSource{spec: spec}
+}
+
+// Current returns the specification.
+func (s *staticSource) Current() *ClusterSpecification {
+	return s.spec
+}
+
+// Changes returns nil, as a static specification never changes.
+func (s *staticSource) Changes() <-chan struct{} {
+	return nil
+}
+
+// FileWatcher is a Source that reloads a specification file when it is edited.
+// Reloaded specs are validated; if validation fails the last valid spec is kept.
+type FileWatcher struct {
+	path string
+
+	// Logf receives reload messages (defaults to fmt.Printf)
+	Logf func(format string, args ...interface{})
+
+	mu      sync.RWMutex
+	current *ClusterSpecification
+	changes chan struct{}
+}
+
+// NewFileWatcher loads and validates the specification at path and returns a
+// watcher for it. Call Run to start watching for edits.
+func NewFileWatcher(path string) (*FileWatcher, error) {
+	spec, err := loadAndValidate(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileWatcher{
+		path:    filepath.Clean(path),
+		Logf:    func(format string, args ...interface{}) { fmt.Printf(format, args...) },
+		current: spec,
+		changes: make(chan struct{}, 1),
+	}, nil
+}
+
+// Current returns the last valid specification.
+func (w *FileWatcher) Current() *ClusterSpecification {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.current
+}
+
+// Changes is signalled after a successful reload.
+func (w *FileWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+// Run watches the specification file until the context is cancelled.
+func (w *FileWatcher) Run(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create spec file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	// Watch the directory rather than the file, since editors often save by
+	// replacing the file, which drops watches on the original inode
+	if err := watcher.Add(filepath.Dir(w.path)); err != nil {
+		return fmt.Errorf("failed to watch spec file %s: %w", w.path, err)
+	}
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) != w.path || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+				continue
+			}
+			debounce = time.After(reloadDebounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			w.Logf("[WARN] Spec file watch error: %v\n", err)
+		case <-debounce:
+			debounce = nil
+			w.Reload()
+		}
+	}
+}
+
+// Reload re-reads and validates the specification file. On failure the last
+// valid specification is kept and a warning is logged.
+func (w *FileWatcher) Reload() bool {
+	spec, err := loadAndValidate(w.path)
+	if err != nil {
+		w.Logf("[WARN] Spec reload failed, keeping last valid spec: %v\n", err)
+		return false
+	}
+
+	w.mu.Lock()
+	w.current = spec
+	w.mu.Unlock()
+
+	w.Logf("[OK] Reloaded spec %s (%s v%s)\n", w.path, spec.Metadata.Name, spec.Metadata.Version)
+
+	// Signal without blocking; a pending signal already covers this reload
+	select {
+	case w.changes <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// loadAndValidate loads a specification file and validates it.
+func loadAndValidate(path string) (*ClusterSpecification, error) {
+	spec, err := LoadFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := Validate(spec); err != nil {
+		return nil, fmt.Errorf("spec validation failed: %w", err)
+	}
+	return spec, nil
+}
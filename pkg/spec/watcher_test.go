@@ -0,0 +1,113 @@
+package spec
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// watcherTestSpec returns a valid spec document with the given metadata version.
+func watcherTestSpec(version string) string {
+	return fmt.Sprintf(`apiVersion: kspec.dev/v1
+kind: ClusterSpecification
+metadata:
+  name: test-cluster
+  version: %q
+spec:
+  kubernetes:
+    minVersion: "1.26.0"
+    maxVersion: "1.30.0"
+`, version)
+}
+
+func writeWatcherTestSpec(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write spec file: %v", err)
+	}
+}
+
+func TestFileWatcher_ReloadKeepsLastValidSpec(t *testing.T) {
+	specFile := filepath.Join(t.TempDir(), "spec.yaml")
+	writeWatcherTestSpec(t, specFile, watcherTestSpec("1.0.0"))
+
+	watcher, err := NewFileWatcher(specFile)
+	if err != nil {
+		t.Fatalf("NewFileWatcher failed: %v", err)
+	}
+	var logs []string
+	watcher.Logf = func(format string, args ...interface{}) {
+		logs = append(logs, fmt.Sprintf(format, args...))
+	}
+
+	// A valid edit is picked up and signalled
+	writeWatcherTestSpec(t, specFile, watcherTestSpec("1.1.0"))
+	if !watcher.Reload() {
+		t.Fatal("Expected reload of valid spec to succeed")
+	}
+	if got := watcher.Current().Metadata.Version; got != "1.1.0" {
+		t.Errorf("Expected version '1.1.0' after reload, got '%s'", got)
+	}
+	select {
+	case <-watcher.Changes():
+	default:
+		t.Error("Expected change notification after successful reload")
+	}
+
+	// An invalid edit keeps the last valid spec
+	writeWatcherTestSpec(t, specFile, watcherTestSpec("not-semver"))
+	if watcher.Reload() {
+		t.Fatal("Expected reload of invalid spec to fail")
+	}
+	if got := watcher.Current().Metadata.Version; got != "1.1.0" {
+		t.Errorf("Expected last valid version '1.1.0', got '%s'", got)
+	}
+	select {
+	case <-watcher.Changes():
+		t.Error("Expected no change notification after failed reload")
+	default:
+	}
+	if len(logs) != 2 {
+		t.Errorf("Expected 2 log messages, got %d: %v", len(logs), logs)
+	}
+}
+
+func TestNewFileWatcher_InvalidSpec(t *testing.T) {
+	specFile := filepath.Join(t.TempDir(), "spec.yaml")
+	writeWatcherTestSpec(t, specFile, watcherTestSpec("not-semver"))
+
+	if _, err := NewFileWatcher(specFile); err == nil {
+		t.Error("Expected error for invalid initial spec, got nil")
+	}
+}
+
+func TestFileWatcher_RunPicksUpEdits(t *testing.T) {
+	specFile := filepath.Join(t.TempDir(), "spec.yaml")
+	writeWatcherTestSpec(t, specFile, watcherTestSpec("1.0.0"))
+
+	watcher, err := NewFileWatcher(specFile)
+	if err != nil {
+		t.Fatalf("NewFileWatcher failed: %v", err)
+	}
+	watcher.Logf = func(format string, args ...interface{}) {}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx)
+
+	// Give the watcher time to register before editing
+	time.Sleep(100 * time.Millisecond)
+	writeWatcherTestSpec(t, specFile, watcherTestSpec("2.0.0"))
+
+	select {
+	case <-watcher.Changes():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for spec reload")
+	}
+	if got := watcher.Current().Metadata.Version; got != "2.0.0" {
+		t.Errorf("Expected version '2.0.0' after edit, got '%s'", got)
+	}
+}
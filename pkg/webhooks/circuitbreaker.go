@@ -6,24 +6,76 @@ import (
 	"sync"
 	"time"
 
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
 	"github.com/cloudcwfranck/kspec/pkg/alerts"
 	"github.com/cloudcwfranck/kspec/pkg/metrics"
 )
 
 const (
-	// ErrorRateThreshold is the error rate that triggers circuit breaker (50%)
+	// ErrorRateThreshold is the default error rate that triggers circuit breaker (50%)
 	ErrorRateThreshold = 0.5
 
-	// MinRequestsForBreaker is the minimum requests before circuit breaker activates
+	// MinRequestsForBreaker is the default minimum requests before circuit breaker activates
 	MinRequestsForBreaker = 10
 
 	// CircuitBreakerWindow is the time window for error rate calculation
 	CircuitBreakerWindow = 1 * time.Minute
 
-	// CircuitBreakerCooldown is the cooldown period before retrying after trip
+	// CircuitBreakerCooldown is the default cooldown period before retrying after trip
 	CircuitBreakerCooldown = 5 * time.Minute
 )
 
+// CircuitBreakerConfig configures when a circuit breaker trips and how requests
+// are answered while it is tripped
+type CircuitBreakerConfig struct {
+	// ErrorRateThreshold is the windowed error rate (0.0-1.0) that trips the breaker
+	ErrorRateThreshold float64
+
+	// MinRequests is the number of windowed requests needed before the breaker can trip
+	MinRequests int
+
+	// Cooldown is how long the breaker stays tripped before requests are validated again
+	Cooldown time.Duration
+
+	// FailClosed denies requests while tripped instead of allowing them with a warning
+	FailClosed bool
+}
+
+// DefaultCircuitBreakerConfig returns a configuration that trips at a 50% error
+// rate over at least 10 requests, stays tripped for 5 minutes and fails open
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		ErrorRateThreshold: ErrorRateThreshold,
+		MinRequests:        MinRequestsForBreaker,
+		Cooldown:           CircuitBreakerCooldown,
+	}
+}
+
+// WithSpec returns the configuration overridden by the fields set in a
+// ClusterSpec's circuit breaker settings
+func (c CircuitBreakerConfig) WithSpec(spec *kspecv1alpha1.CircuitBreakerSpec) CircuitBreakerConfig {
+	if spec == nil {
+		return c
+	}
+
+	if spec.ErrorRatePercent > 0 {
+		c.ErrorRateThreshold = float64(spec.ErrorRatePercent) / 100
+	}
+	if spec.MinRequests > 0 {
+		c.MinRequests = int(spec.MinRequests)
+	}
+	if spec.CooldownSeconds > 0 {
+		c.Cooldown = time.Duration(spec.CooldownSeconds) * time.Second
+	}
+	switch spec.FailureMode {
+	case "Open":
+		c.FailClosed = false
+	case "Closed":
+		c.FailClosed = true
+	}
+	return c
+}
+
 // CircuitBreaker implements a circuit breaker pattern for webhooks
 type CircuitBreaker struct {
 	mu sync.RWMutex
@@ -42,8 +94,15 @@ type CircuitBreaker struct {
 	requestWindow []requestResult
 	windowSize    int
 
+	// defaults is the configuration used when no ClusterSpec configures the breaker
+	defaults CircuitBreakerConfig
+	config   CircuitBreakerConfig
+
 	// AlertManager for sending alerts
 	alertManager *alerts.Manager
+
+	// now returns the current time; defaults to time.Now
+	now func() time.Time
 }
 
 type requestResult struct {
@@ -51,16 +110,35 @@ type requestResult struct {
 	isError   bool
 }
 
-// NewCircuitBreaker creates a new circuit breaker
-func NewCircuitBreaker(alertManager *alerts.Manager) *CircuitBreaker {
+// NewCircuitBreaker creates a new circuit breaker with the given default configuration
+func NewCircuitBreaker(alertManager *alerts.Manager, config CircuitBreakerConfig) *CircuitBreaker {
 	return &CircuitBreaker{
 		alertManager:  alertManager,
+		defaults:      config,
+		config:        config,
 		windowSize:    100, // Track last 100 requests
 		requestWindow: make([]requestResult, 0, 100),
 		lastResetTime: time.Now(),
 	}
 }
 
+// Configure applies a ClusterSpec's circuit breaker settings on top of the
+// defaults. A nil spec restores the defaults.
+func (cb *CircuitBreaker) Configure(spec *kspecv1alpha1.CircuitBreakerSpec) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.config = cb.defaults.WithSpec(spec)
+}
+
+// Config returns the configuration in effect
+func (cb *CircuitBreaker) Config() CircuitBreakerConfig {
+	cb.mu.RLock()
+	defer cb.mu.RUnlock()
+
+	return cb.config
+}
+
 // RecordSuccess records a successful webhook request
 func (cb *CircuitBreaker) RecordSuccess() {
 	cb.mu.Lock()
@@ -70,7 +148,7 @@ func (cb *CircuitBreaker) RecordSuccess() {
 	cb.successRequests++
 
 	cb.addToWindow(requestResult{
-		timestamp: time.Now(),
+		timestamp: cb.currentTime(),
 		isError:   false,
 	})
 
@@ -87,7 +165,7 @@ func (cb *CircuitBreaker) RecordError() {
 	cb.errorRequests++
 
 	cb.addToWindow(requestResult{
-		timestamp: time.Now(),
+		timestamp: cb.currentTime(),
 		isError:   true,
 	})
 
@@ -101,7 +179,7 @@ func (cb *CircuitBreaker) IsTripped() bool {
 	defer cb.mu.RUnlock()
 
 	// Check if cooldown period has passed
-	if cb.isTripped && time.Since(cb.lastTripTime) > CircuitBreakerCooldown {
+	if cb.isTripped && cb.currentTime().Sub(cb.lastTripTime) > cb.config.Cooldown {
 		return false // Allow retry after cooldown
 	}
 
@@ -141,13 +219,21 @@ func (cb *CircuitBreaker) Reset() {
 	cb.successRequests = 0
 	cb.isTripped = false
 	cb.requestWindow = make([]requestResult, 0, cb.windowSize)
-	cb.lastResetTime = time.Now()
+	cb.lastResetTime = cb.currentTime()
+}
+
+// currentTime returns the time requests are recorded at
+func (cb *CircuitBreaker) currentTime() time.Time {
+	if cb.now != nil {
+		return cb.now()
+	}
+	return time.Now()
 }
 
 // addToWindow adds a request result to the sliding window
 func (cb *CircuitBreaker) addToWindow(result requestResult) {
 	// Remove old entries outside the time window
-	cutoff := time.Now().Add(-CircuitBreakerWindow)
+	cutoff := cb.currentTime().Add(-CircuitBreakerWindow)
 	validResults := make([]requestResult, 0, cb.windowSize)
 	for _, r := range cb.requestWindow {
 		if r.timestamp.After(cutoff) {
@@ -195,12 +281,15 @@ func (cb *CircuitBreaker) checkTrip() {
 	}
 
 	// Need minimum requests before tripping
-	if len(cb.requestWindow) < MinRequestsForBreaker {
+	if len(cb.requestWindow) < cb.config.MinRequests {
 		return
 	}
 
 	errorRate := cb.calculateErrorRate()
-	if errorRate >= ErrorRateThreshold {
+	if errorRate >= cb.config.ErrorRateThreshold {
+		cb.isTripped = true
+		cb.lastTripTime = cb.currentTime()
+		metrics.CircuitBreakerTrips.Inc()
 
 		// Send circuit breaker trip alert
 		cb.sendTripAlert(errorRate)
@@ -214,13 +303,13 @@ func (cb *CircuitBreaker) checkRecovery() {
 		return
 	}
 
-	if time.Since(cb.lastTripTime) < CircuitBreakerCooldown {
+	if cb.currentTime().Sub(cb.lastTripTime) < cb.config.Cooldown {
 		return
 	}
 
 	// Check if error rate has dropped below threshold
 	errorRate := cb.calculateErrorRate()
-	if errorRate < ErrorRateThreshold {
+	if errorRate < cb.config.ErrorRateThreshold {
 		cb.isTripped = false
 	}
 }
@@ -257,10 +346,15 @@ func (cb *CircuitBreaker) sendTripAlert(errorRate float64) {
 		return
 	}
 
+	mode := "fail-open"
+	if cb.config.FailClosed {
+		mode = "fail-closed"
+	}
+
 	alert := alerts.Alert{
 		Level:       alerts.AlertLevelCritical,
 		Title:       "Webhook circuit breaker tripped",
-		Description: fmt.Sprintf("Circuit breaker has tripped due to high error rate (%.1f%%). Webhook validation is now in fail-open mode.", errorRate*100),
+		Description: fmt.Sprintf("Circuit breaker has tripped due to high error rate (%.1f%%). Webhook validation is now in %s mode.", errorRate*100, mode),
 		Source:      "Webhook/CircuitBreaker",
 		EventType:   "CircuitBreakerTripped",
 		Labels: map[string]string{
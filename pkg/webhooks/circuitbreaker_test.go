@@ -0,0 +1,146 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+)
+
+func TestCircuitBreakerTripsAtConfiguredThreshold(t *testing.T) {
+	now := time.Now()
+	cb := NewCircuitBreaker(nil, CircuitBreakerConfig{
+		ErrorRateThreshold: 0.2,
+		MinRequests:        5,
+		Cooldown:           30 * time.Second,
+	})
+	cb.now = func() time.Time { return now }
+
+	// One error in five requests is below the default threshold but at the configured one
+	for i := 0; i < 4; i++ {
+		cb.RecordSuccess()
+	}
+	if cb.IsTripped() {
+		t.Fatal("expected breaker not to trip before the minimum request count")
+	}
+	cb.RecordError()
+	if !cb.IsTripped() {
+		t.Fatalf("expected breaker to trip at a 20%% error rate, stats: %+v", cb.GetStats())
+	}
+
+	now = now.Add(10 * time.Second)
+	if !cb.IsTripped() {
+		t.Error("expected breaker to stay tripped during the cooldown")
+	}
+
+	now = now.Add(25 * time.Second)
+	if cb.IsTripped() {
+		t.Error("expected breaker to allow requests after the cooldown")
+	}
+
+	// A success after the cooldown with the error rate below the threshold recovers the breaker
+	cb.RecordSuccess()
+	if cb.GetStats().IsTripped {
+		t.Errorf("expected breaker to recover, stats: %+v", cb.GetStats())
+	}
+}
+
+func TestCircuitBreakerDefaultConfig(t *testing.T) {
+	cb := NewCircuitBreaker(nil, DefaultCircuitBreakerConfig())
+
+	for i := 0; i < 4; i++ {
+		cb.RecordSuccess()
+	}
+	cb.RecordError()
+	if cb.IsTripped() {
+		t.Error("expected default breaker not to trip after 5 requests")
+	}
+	if cb.Config().FailClosed {
+		t.Error("expected default breaker to fail open")
+	}
+}
+
+func TestCircuitBreakerConfigWithSpec(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *kspecv1alpha1.CircuitBreakerSpec
+		want CircuitBreakerConfig
+	}{
+		{
+			name: "nil spec keeps defaults",
+			want: DefaultCircuitBreakerConfig(),
+		},
+		{
+			name: "unset fields keep defaults",
+			spec: &kspecv1alpha1.CircuitBreakerSpec{MinRequests: 20},
+			want: CircuitBreakerConfig{ErrorRateThreshold: 0.5, MinRequests: 20, Cooldown: 5 * time.Minute},
+		},
+		{
+			name: "all fields",
+			spec: &kspecv1alpha1.CircuitBreakerSpec{ErrorRatePercent: 80, MinRequests: 50, CooldownSeconds: 60, FailureMode: "Closed"},
+			want: CircuitBreakerConfig{ErrorRateThreshold: 0.8, MinRequests: 50, Cooldown: time.Minute, FailClosed: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultCircuitBreakerConfig().WithSpec(tt.spec); got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerSpecSelection(t *testing.T) {
+	clusterSpec := func(name string, enabled bool, breaker *kspecv1alpha1.CircuitBreakerSpec) kspecv1alpha1.ClusterSpecification {
+		return kspecv1alpha1.ClusterSpecification{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kspecv1alpha1.ClusterSpecificationSpec{
+				Webhooks: &kspecv1alpha1.WebhooksSpec{Enabled: enabled, CircuitBreaker: breaker},
+			},
+		}
+	}
+	dev := &kspecv1alpha1.CircuitBreakerSpec{MinRequests: 5}
+	prod := &kspecv1alpha1.CircuitBreakerSpec{FailureMode: "Closed"}
+
+	specs := []kspecv1alpha1.ClusterSpecification{
+		clusterSpec("prod", true, prod),
+		clusterSpec("default", true, nil),
+		clusterSpec("dev", true, dev),
+		clusterSpec("archived", false, &kspecv1alpha1.CircuitBreakerSpec{MinRequests: 1}),
+	}
+	if got := circuitBreakerSpec(specs); got != dev {
+		t.Errorf("expected settings of the first configured ClusterSpec by name, got %+v", got)
+	}
+	if got := circuitBreakerSpec(specs[1:2]); got != nil {
+		t.Errorf("expected no settings, got %+v", got)
+	}
+}
+
+func TestHandleValidateFailClosed(t *testing.T) {
+	server := newMetricsTestServer(t)
+	server.CircuitBreaker.Configure(&kspecv1alpha1.CircuitBreakerSpec{FailureMode: "Closed"})
+
+	for i := 0; i < MinRequestsForBreaker; i++ {
+		server.CircuitBreaker.RecordError()
+	}
+
+	recorder := httptest.NewRecorder()
+	server.handleValidate(recorder, httptest.NewRequest(http.MethodPost, "/validate",
+		strings.NewReader(admissionReviewBody(t, "ghcr.io/org/web:1.0"))))
+
+	var review admissionv1.AdmissionReview
+	if err := json.Unmarshal(recorder.Body.Bytes(), &review); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if review.Response.Allowed {
+		t.Error("expected a tripped fail-closed circuit breaker to deny requests")
+	}
+}
@@ -0,0 +1,249 @@
+package webhooks
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+)
+
+const (
+	// DefaultDecisionCacheSize is the default maximum number of cached decisions
+	DefaultDecisionCacheSize = 1024
+
+	// DefaultDecisionCacheTTL is the default lifetime of a cached decision
+	DefaultDecisionCacheTTL = 30 * time.Second
+)
+
+// admissionDecision is the outcome of validating a pod, including the metric labels
+// to record so cache hits are counted the same way as evaluations
+type admissionDecision struct {
+	response *admissionv1.AdmissionResponse
+
+	// WebhookValidationResults labels
+	validationResult string
+	validationMode   string
+
+	// PolicyEnforcementActions labels
+	actions []enforcementAction
+
+	// warnings are returned with an allowed response, e.g. the exemptions used
+	warnings []string
+}
+
+type enforcementAction struct {
+	clusterSpec string
+	action      string
+}
+
+// DecisionCache is an LRU cache of admission decisions for structurally identical pods.
+// Entries expire after a TTL and the whole cache is flushed whenever the set of
+// ClusterSpecifications (or any of their generations) changes.
+type DecisionCache struct {
+	mu sync.Mutex
+
+	maxEntries int
+	ttl        time.Duration
+
+	entries map[string]*list.Element
+	order   *list.List
+
+	// specsFingerprint identifies the ClusterSpecifications the cached decisions were made against
+	specsFingerprint string
+
+	now func() time.Time
+}
+
+type decisionCacheEntry struct {
+	key       string
+	decision  *admissionDecision
+	expiresAt time.Time
+}
+
+// NewDecisionCache creates a new decision cache
+func NewDecisionCache(maxEntries int, ttl time.Duration) *DecisionCache {
+	return &DecisionCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+// Get returns the cached decision for a key if it is still valid
+func (c *DecisionCache) Get(key, specsFingerprint string) (*admissionDecision, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.invalidateIfChanged(specsFingerprint)
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*decisionCacheEntry)
+	if c.now().After(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.decision, true
+}
+
+// Add caches a decision, evicting the least recently used entry when full
+func (c *DecisionCache) Add(key, specsFingerprint string, decision *admissionDecision) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.invalidateIfChanged(specsFingerprint)
+
+	expiresAt := c.now().Add(c.ttl)
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*decisionCacheEntry)
+		entry.decision = decision
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&decisionCacheEntry{
+		key:       key,
+		decision:  decision,
+		expiresAt: expiresAt,
+	})
+
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*decisionCacheEntry).key)
+	}
+}
+
+// Len returns the number of cached decisions
+func (c *DecisionCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// invalidateIfChanged flushes the cache when the ClusterSpecifications have changed.
+// Must be called with the lock held.
+func (c *DecisionCache) invalidateIfChanged(specsFingerprint string) {
+	if specsFingerprint == c.specsFingerprint {
+		return
+	}
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+	c.specsFingerprint = specsFingerprint
+}
+
+// clusterSpecsFingerprint identifies a set of ClusterSpecifications by UID and generation
+func clusterSpecsFingerprint(clusterSpecs []kspecv1alpha1.ClusterSpecification) string {
+	parts := make([]string, 0, len(clusterSpecs))
+	for _, cs := range clusterSpecs {
+		parts = append(parts, fmt.Sprintf("%s:%d", cs.UID, cs.Generation))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+// decisionsCacheable reports whether decisions only depend on the pod and the specs.
+// Time-based activation and expiring exemptions change the outcome over time, and a
+// namespace selector makes it depend on the namespace labels, which can change
+// without any spec changing, so decisions are not cached while any is in play.
+func decisionsCacheable(clusterSpecs []kspecv1alpha1.ClusterSpecification) bool {
+	for _, cs := range clusterSpecs {
+		if cs.Spec.TimeBasedActivation != nil && cs.Spec.TimeBasedActivation.Enabled {
+			return false
+		}
+		if cs.Spec.NamespaceScope != nil && cs.Spec.NamespaceScope.NamespaceSelector != nil {
+			return false
+		}
+		for _, exemption := range cs.Spec.PolicyExemptions {
+			if exemption.ExpiresAt != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hasPolicyExemptions reports whether any ClusterSpecification exempts resources,
+// in which case pod identity (name and labels) affects the decision
+func hasPolicyExemptions(clusterSpecs []kspecv1alpha1.ClusterSpecification) bool {
+	for _, cs := range clusterSpecs {
+		if len(cs.Spec.PolicyExemptions) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// podDecisionKey hashes the pod fields read by validatePodAgainstSpec. Fields that
+// differ between otherwise identical pods (generated names, token volumes) are left
+// out so that e.g. pods created by the same Job share a key.
+func podDecisionKey(pod *corev1.Pod, includeIdentity bool) (string, error) {
+	type containerFields struct {
+		Name            string                      `json:"name"`
+		Image           string                      `json:"image"`
+		SecurityContext *corev1.SecurityContext     `json:"securityContext,omitempty"`
+		Resources       corev1.ResourceRequirements `json:"resources"`
+		HasLiveness     bool                        `json:"hasLiveness"`
+		HasReadiness    bool                        `json:"hasReadiness"`
+	}
+
+	fields := struct {
+		Namespace       string                     `json:"namespace"`
+		Name            string                     `json:"name,omitempty"`
+		Labels          map[string]string          `json:"labels,omitempty"`
+		SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+		HostNetwork     bool                       `json:"hostNetwork"`
+		HostPID         bool                       `json:"hostPID"`
+		HostIPC         bool                       `json:"hostIPC"`
+		Containers      []containerFields          `json:"containers"`
+	}{
+		Namespace:       pod.Namespace,
+		SecurityContext: pod.Spec.SecurityContext,
+		HostNetwork:     pod.Spec.HostNetwork,
+		HostPID:         pod.Spec.HostPID,
+		HostIPC:         pod.Spec.HostIPC,
+	}
+
+	if includeIdentity {
+		fields.Name = pod.Name
+		fields.Labels = pod.Labels
+	}
+
+	for _, container := range pod.Spec.Containers {
+		fields.Containers = append(fields.Containers, containerFields{
+			Name:            container.Name,
+			Image:           container.Image,
+			SecurityContext: container.SecurityContext,
+			Resources:       container.Resources,
+			HasLiveness:     container.LivenessProbe != nil,
+			HasReadiness:    container.ReadinessProbe != nil,
+		})
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,155 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+)
+
+func allowedDecision() *admissionDecision {
+	return &admissionDecision{
+		response:         &admissionv1.AdmissionResponse{Allowed: true},
+		validationResult: "allowed",
+		validationMode:   "valid",
+	}
+}
+
+func TestDecisionCacheExpiry(t *testing.T) {
+	now := time.Now()
+	cache := NewDecisionCache(10, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	cache.Add("pod", "specs", allowedDecision())
+	if _, ok := cache.Get("pod", "specs"); !ok {
+		t.Fatal("expected cache hit")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := cache.Get("pod", "specs"); ok {
+		t.Error("expected expired entry to miss")
+	}
+}
+
+func TestDecisionCacheInvalidatesOnSpecChange(t *testing.T) {
+	cache := NewDecisionCache(10, time.Minute)
+
+	cache.Add("pod", "uid:1", allowedDecision())
+	if _, ok := cache.Get("pod", "uid:2"); ok {
+		t.Error("expected miss after ClusterSpecification change")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("expected cache to be flushed, got %d entries", cache.Len())
+	}
+}
+
+func TestDecisionCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewDecisionCache(2, time.Minute)
+
+	cache.Add("a", "specs", allowedDecision())
+	cache.Add("b", "specs", allowedDecision())
+	cache.Get("a", "specs")
+	cache.Add("c", "specs", allowedDecision())
+
+	if _, ok := cache.Get("b", "specs"); ok {
+		t.Error("expected least recently used entry to be evicted")
+	}
+	if _, ok := cache.Get("a", "specs"); !ok {
+		t.Error("expected recently used entry to be kept")
+	}
+}
+
+func TestPodDecisionKey(t *testing.T) {
+	newPod := func(name, tokenVolume string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:         "worker",
+					Image:        "worker:v1",
+					VolumeMounts: []corev1.VolumeMount{{Name: tokenVolume}},
+				}},
+			},
+		}
+	}
+
+	first, _ := podDecisionKey(newPod("job-abcde", "kube-api-access-abcde"), false)
+	second, _ := podDecisionKey(newPod("job-fghij", "kube-api-access-fghij"), false)
+	if first != second {
+		t.Error("expected pods of the same template to share a key")
+	}
+
+	first, _ = podDecisionKey(newPod("job-abcde", "kube-api-access-abcde"), true)
+	second, _ = podDecisionKey(newPod("job-fghij", "kube-api-access-fghij"), true)
+	if first == second {
+		t.Error("expected pod names to be part of the key when exemptions exist")
+	}
+
+	changed := newPod("job-abcde", "kube-api-access-abcde")
+	changed.Spec.Containers[0].Image = "worker:v2"
+	first, _ = podDecisionKey(newPod("job-abcde", "kube-api-access-abcde"), false)
+	second, _ = podDecisionKey(changed, false)
+	if first == second {
+		t.Error("expected different images to produce different keys")
+	}
+}
+
+func TestDecisionsCacheable(t *testing.T) {
+	expiresAt := metav1.Now()
+	tests := []struct {
+		name     string
+		spec     kspecv1alpha1.ClusterSpecificationSpec
+		expected bool
+	}{
+		{
+			name:     "plain spec",
+			expected: true,
+		},
+		{
+			name: "time-based activation",
+			spec: kspecv1alpha1.ClusterSpecificationSpec{
+				TimeBasedActivation: &kspecv1alpha1.TimeBasedActivationSpec{Enabled: true},
+			},
+			expected: false,
+		},
+		{
+			name: "expiring exemption",
+			spec: kspecv1alpha1.ClusterSpecificationSpec{
+				PolicyExemptions: []kspecv1alpha1.PolicyExemptionSpec{{Name: "temp", ExpiresAt: &expiresAt}},
+			},
+			expected: false,
+		},
+		{
+			name: "namespace selector",
+			spec: kspecv1alpha1.ClusterSpecificationSpec{
+				NamespaceScope: &kspecv1alpha1.NamespaceScopeSpec{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "namespace lists",
+			spec: kspecv1alpha1.ClusterSpecificationSpec{
+				NamespaceScope: &kspecv1alpha1.NamespaceScopeSpec{
+					IncludeNamespaces: []string{"payments"},
+					ExcludeNamespaces: []string{"payments-sandbox"},
+				},
+			},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterSpecs := []kspecv1alpha1.ClusterSpecification{{Spec: tt.spec}}
+			if cacheable := decisionsCacheable(clusterSpecs); cacheable != tt.expected {
+				t.Errorf("decisionsCacheable() = %v, expected %v", cacheable, tt.expected)
+			}
+		})
+	}
+}
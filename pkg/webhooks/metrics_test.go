@@ -0,0 +1,192 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+	"github.com/cloudcwfranck/kspec/pkg/metrics"
+	"github.com/cloudcwfranck/kspec/pkg/policy"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+)
+
+// metricValue returns the value of a counter or the sample count of a histogram
+func metricValue(t *testing.T, collector prometheus.Metric) float64 {
+	t.Helper()
+
+	metric := &dto.Metric{}
+	if err := collector.Write(metric); err != nil {
+		t.Fatalf("failed to read metric: %v", err)
+	}
+	if metric.Histogram != nil {
+		return float64(metric.GetHistogram().GetSampleCount())
+	}
+	return metric.GetCounter().GetValue()
+}
+
+// newMetricsTestServer returns a server with a ClusterSpec that blocks docker.io images
+func newMetricsTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := kspecv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	clusterSpec := &kspecv1alpha1.ClusterSpecification{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: kspecv1alpha1.ClusterSpecificationSpec{
+			Enforcement: &kspecv1alpha1.EnforcementSpec{Enabled: true, Mode: "enforce"},
+			Webhooks:    &kspecv1alpha1.WebhooksSpec{Enabled: true},
+			SpecFields: spec.SpecFields{
+				Workloads: &spec.WorkloadsSpec{
+					Images: &spec.ImageSpec{BlockedRegistries: []string{"docker.io/"}},
+				},
+			},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(clusterSpec).Build()
+
+	return &Server{
+		Client:         client,
+		CircuitBreaker: NewCircuitBreaker(nil, DefaultCircuitBreakerConfig()),
+		PolicyManager:  policy.NewAdvancedPolicyManager(client),
+	}
+}
+
+// admissionReviewBody encodes an admission review for a pod running image
+func admissionReviewBody(t *testing.T, image string) string {
+	t.Helper()
+
+	pod := &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: image}}},
+	}
+	raw, err := json.Marshal(pod)
+	if err != nil {
+		t.Fatalf("failed to marshal pod: %v", err)
+	}
+	review, err := json.Marshal(&admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request: &admissionv1.AdmissionRequest{
+			UID:       "uid",
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			Namespace: "apps",
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal admission review: %v", err)
+	}
+	return string(review)
+}
+
+func TestHandleValidateMetrics(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantAllowed bool
+		result      string
+		validation  string
+		mode        string
+	}{
+		{name: "allow", body: admissionReviewBody(t, "ghcr.io/org/web:1.0"), wantStatus: http.StatusOK, wantAllowed: true, result: "success", validation: "allowed", mode: "valid"},
+		{name: "deny", body: admissionReviewBody(t, "docker.io/library/nginx"), wantStatus: http.StatusOK, result: "success", validation: "denied", mode: "enforce"},
+		{name: "error", body: "not an admission review", wantStatus: http.StatusBadRequest, result: "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMetricsTestServer(t)
+
+			requests := metrics.WebhookRequestsTotal.WithLabelValues(tt.result)
+			latency := metrics.WebhookRequestDuration.WithLabelValues(tt.result).(prometheus.Histogram)
+			requestsBefore, latencyBefore := metricValue(t, requests), metricValue(t, latency)
+
+			var validations prometheus.Counter
+			var validationLatency prometheus.Histogram
+			var validationsBefore, validationLatencyBefore float64
+			if tt.validation != "" {
+				validations = metrics.WebhookValidationResults.WithLabelValues(tt.validation, tt.mode)
+				validationLatency = metrics.WebhookValidationDuration.WithLabelValues(tt.validation).(prometheus.Histogram)
+				validationsBefore, validationLatencyBefore = metricValue(t, validations), metricValue(t, validationLatency)
+			}
+
+			recorder := httptest.NewRecorder()
+			server.handleValidate(recorder, httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(tt.body)))
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, recorder.Code, recorder.Body.String())
+			}
+			if got := metricValue(t, requests) - requestsBefore; got != 1 {
+				t.Errorf("expected kspec_webhook_requests_total{result=%q} to increase by 1, got %v", tt.result, got)
+			}
+			if got := metricValue(t, latency) - latencyBefore; got != 1 {
+				t.Errorf("expected one request duration observation, got %v", got)
+			}
+			if tt.validation == "" {
+				return
+			}
+
+			var review admissionv1.AdmissionReview
+			if err := json.Unmarshal(recorder.Body.Bytes(), &review); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if review.Response.Allowed != tt.wantAllowed {
+				t.Errorf("expected allowed=%v, got %+v", tt.wantAllowed, review.Response)
+			}
+			if got := metricValue(t, validations) - validationsBefore; got != 1 {
+				t.Errorf("expected kspec_webhook_validation_results_total{result=%q} to increase by 1, got %v", tt.validation, got)
+			}
+			if got := metricValue(t, validationLatency) - validationLatencyBefore; got != 1 {
+				t.Errorf("expected one validation duration observation, got %v", got)
+			}
+		})
+	}
+}
+
+func TestHandleValidateCircuitBreakerMetrics(t *testing.T) {
+	server := newMetricsTestServer(t)
+	tripsBefore := metricValue(t, metrics.CircuitBreakerTrips)
+
+	for i := 0; i < MinRequestsForBreaker; i++ {
+		server.CircuitBreaker.RecordError()
+	}
+	if !server.CircuitBreaker.IsTripped() {
+		t.Fatal("expected circuit breaker to trip at 100% error rate")
+	}
+	if got := metricValue(t, metrics.CircuitBreakerTrips) - tripsBefore; got != 1 {
+		t.Errorf("expected kspec_webhook_circuit_breaker_trips_total to increase by 1, got %v", got)
+	}
+
+	bypassed := metrics.WebhookRequestsTotal.WithLabelValues("circuit_breaker_tripped")
+	bypassedBefore := metricValue(t, bypassed)
+
+	recorder := httptest.NewRecorder()
+	server.handleValidate(recorder, httptest.NewRequest(http.MethodPost, "/validate",
+		strings.NewReader(admissionReviewBody(t, "docker.io/library/nginx"))))
+
+	var review admissionv1.AdmissionReview
+	if err := json.Unmarshal(recorder.Body.Bytes(), &review); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !review.Response.Allowed {
+		t.Error("expected a tripped circuit breaker to fail open")
+	}
+	if got := metricValue(t, bypassed) - bypassedBefore; got != 1 {
+		t.Errorf("expected kspec_webhook_requests_total{result=\"circuit_breaker_tripped\"} to increase by 1, got %v", got)
+	}
+}
@@ -0,0 +1,228 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+	"github.com/cloudcwfranck/kspec/pkg/metrics"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+)
+
+// EnforcementModeMutate patches missing required security context fields at
+// admission and denies violations that cannot be patched
+const EnforcementModeMutate = "mutate"
+
+// jsonPatchOperation is a single RFC 6902 JSON Patch operation
+type jsonPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// mutate patches a pod to satisfy all ClusterSpecs in mutate mode
+func (s *Server) mutate(ctx context.Context, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	log := log.FromContext(ctx)
+
+	// Only mutate Pods
+	if request.Kind.Kind != "Pod" {
+		return &admissionv1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	// Decode pod
+	pod := &corev1.Pod{}
+	deserializer := codecs.UniversalDeserializer()
+	if _, _, err := deserializer.Decode(request.Object.Raw, nil, pod); err != nil {
+		log.Error(err, "Failed to decode pod")
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: fmt.Sprintf("Failed to decode pod: %v", err),
+			},
+		}
+	}
+
+	var clusterSpecs kspecv1alpha1.ClusterSpecificationList
+	if err := s.Client.List(ctx, &clusterSpecs); err != nil {
+		log.Error(err, "Failed to list ClusterSpecs")
+		// Fail open - admit the pod unchanged if we can't check ClusterSpecs
+		return &admissionv1.AdmissionResponse{
+			Allowed:  true,
+			Warnings: []string{"Failed to check cluster specifications, allowing without mutation"},
+		}
+	}
+
+	return s.mutatePod(ctx, pod, clusterSpecs.Items)
+}
+
+// mutatePod builds the JSON patch that makes a pod satisfy every ClusterSpec in
+// mutate mode. Pods that still violate a ClusterSpec after patching are denied.
+func (s *Server) mutatePod(ctx context.Context, pod *corev1.Pod, clusterSpecs []kspecv1alpha1.ClusterSpecification) *admissionv1.AdmissionResponse {
+	log := log.FromContext(ctx)
+
+	mutated := pod.DeepCopy()
+	var patch []jsonPatchOperation
+
+	for _, clusterSpec := range clusterSpecs {
+		if clusterSpec.Spec.Enforcement == nil || !clusterSpec.Spec.Enforcement.Enabled ||
+			clusterSpec.Spec.Enforcement.Mode != EnforcementModeMutate {
+			continue
+		}
+
+		if clusterSpec.Spec.Webhooks == nil || !clusterSpec.Spec.Webhooks.Enabled {
+			continue
+		}
+
+		if !s.inScope(ctx, pod, &clusterSpec) || !s.isActive(&clusterSpec) {
+			continue
+		}
+
+		if exemption := s.matchExemption(ctx, "Pod", pod, &clusterSpec); exemption != nil {
+			log.Info("Pod is exempt from mutation",
+				"pod", pod.Name,
+				"namespace", pod.Namespace,
+				"clusterSpec", clusterSpec.Name,
+				"exemption", exemption.Name,
+				"ticketRef", exemption.TicketRef)
+			continue
+		}
+
+		operations := securityContextPatch(mutated, clusterSpec.Spec.Workloads)
+		if len(operations) > 0 {
+			log.Info("Mutating pod to satisfy ClusterSpec",
+				"pod", pod.Name,
+				"namespace", pod.Namespace,
+				"clusterSpec", clusterSpec.Name,
+				"operations", len(operations))
+			metrics.PolicyEnforcementActions.WithLabelValues(clusterSpec.Name, "mutated").Inc()
+			patch = append(patch, operations...)
+		}
+
+		// Violations that cannot be patched, e.g. privileged containers, are denied
+		if allowed, reason := EvaluatePod(mutated, clusterSpec.Spec.Workloads); !allowed {
+			log.Info("Pod violates ClusterSpec (mutate mode)",
+				"pod", pod.Name,
+				"namespace", pod.Namespace,
+				"clusterSpec", clusterSpec.Name,
+				"reason", reason)
+			metrics.PolicyEnforcementActions.WithLabelValues(clusterSpec.Name, "denied").Inc()
+			return &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: fmt.Sprintf("Pod violates cluster specification %s: %s", clusterSpec.Name, reason),
+				},
+			}
+		}
+	}
+
+	response := &admissionv1.AdmissionResponse{
+		Allowed: true,
+	}
+	if len(patch) == 0 {
+		return response
+	}
+
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		log.Error(err, "Failed to marshal pod patch")
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Message: fmt.Sprintf("Failed to build pod patch: %v", err),
+			},
+		}
+	}
+
+	patchType := admissionv1.PatchTypeJSONPatch
+	response.Patch = patchBytes
+	response.PatchType = &patchType
+	return response
+}
+
+// securityContextPatch sets the required security context fields of the workloads
+// spec that a pod is missing, applying each change to pod and returning the
+// equivalent JSON patch operations
+func securityContextPatch(pod *corev1.Pod, workloads *spec.WorkloadsSpec) []jsonPatchOperation {
+	if workloads == nil || workloads.Containers == nil {
+		return nil
+	}
+
+	var operations []jsonPatchOperation
+	for _, req := range workloads.Containers.Required {
+		value := req.Value == "true"
+
+		switch req.Key {
+		case "securityContext.runAsNonRoot":
+			// A pod-level setting takes precedence, so fix it rather than the containers
+			if pod.Spec.SecurityContext != nil && pod.Spec.SecurityContext.RunAsNonRoot != nil {
+				if *pod.Spec.SecurityContext.RunAsNonRoot != value {
+					pod.Spec.SecurityContext.RunAsNonRoot = &value
+					operations = append(operations, jsonPatchOperation{
+						Op:    "replace",
+						Path:  "/spec/securityContext/runAsNonRoot",
+						Value: value,
+					})
+				}
+				continue
+			}
+			operations = append(operations, setContainerSecurityContext(pod, workloads, "runAsNonRoot", value,
+				func(sc *corev1.SecurityContext) **bool { return &sc.RunAsNonRoot })...)
+
+		case "securityContext.allowPrivilegeEscalation":
+			operations = append(operations, setContainerSecurityContext(pod, workloads, "allowPrivilegeEscalation", value,
+				func(sc *corev1.SecurityContext) **bool { return &sc.AllowPrivilegeEscalation })...)
+		}
+	}
+
+	return operations
+}
+
+// setContainerSecurityContext sets a boolean security context field on every
+// non-excluded container where it is unset or differs from value
+func setContainerSecurityContext(
+	pod *corev1.Pod,
+	workloads *spec.WorkloadsSpec,
+	field string,
+	value bool,
+	fieldOf func(*corev1.SecurityContext) **bool,
+) []jsonPatchOperation {
+	var operations []jsonPatchOperation
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		if workloads.IsContainerExcluded(container.Name) {
+			continue
+		}
+
+		path := fmt.Sprintf("/spec/containers/%d/securityContext", i)
+		if container.SecurityContext == nil {
+			container.SecurityContext = &corev1.SecurityContext{}
+			operations = append(operations, jsonPatchOperation{
+				Op:    "add",
+				Path:  path,
+				Value: map[string]interface{}{},
+			})
+		}
+
+		current := fieldOf(container.SecurityContext)
+		if *current != nil && **current == value {
+			continue
+		}
+
+		fieldValue := value
+		*current = &fieldValue
+		operations = append(operations, jsonPatchOperation{
+			Op:    "add",
+			Path:  path + "/" + field,
+			Value: value,
+		})
+	}
+	return operations
+}
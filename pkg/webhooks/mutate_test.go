@@ -0,0 +1,171 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+	"github.com/cloudcwfranck/kspec/pkg/policy"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+)
+
+func mutateClusterSpec(workloads *spec.WorkloadsSpec) kspecv1alpha1.ClusterSpecification {
+	return kspecv1alpha1.ClusterSpecification{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: kspecv1alpha1.ClusterSpecificationSpec{
+			Enforcement: &kspecv1alpha1.EnforcementSpec{Enabled: true, Mode: EnforcementModeMutate},
+			Webhooks:    &kspecv1alpha1.WebhooksSpec{Enabled: true},
+			SpecFields:  spec.SpecFields{Workloads: workloads},
+		},
+	}
+}
+
+// applyResponsePatch round-trips the response through JSON, as the API server
+// receives it, and applies its patch to the pod
+func applyResponsePatch(t *testing.T, pod *corev1.Pod, response *admissionv1.AdmissionResponse) *corev1.Pod {
+	t.Helper()
+
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("failed to marshal response: %v", err)
+	}
+	if !strings.Contains(string(encoded), `"patchType":"JSONPatch"`) {
+		t.Fatalf("expected patchType JSONPatch in response, got %s", encoded)
+	}
+
+	var decoded admissionv1.AdmissionResponse
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	patch, err := jsonpatch.DecodePatch(decoded.Patch)
+	if err != nil {
+		t.Fatalf("failed to decode patch %s: %v", decoded.Patch, err)
+	}
+
+	original, err := json.Marshal(pod)
+	if err != nil {
+		t.Fatalf("failed to marshal pod: %v", err)
+	}
+	patched, err := patch.Apply(original)
+	if err != nil {
+		t.Fatalf("failed to apply patch %s: %v", decoded.Patch, err)
+	}
+
+	result := &corev1.Pod{}
+	if err := json.Unmarshal(patched, result); err != nil {
+		t.Fatalf("failed to unmarshal patched pod: %v", err)
+	}
+	return result
+}
+
+func TestMutatePodPatchesSecurityContext(t *testing.T) {
+	server := &Server{PolicyManager: policy.NewAdvancedPolicyManager(nil)}
+	allowEscalation := true
+	workloads := &spec.WorkloadsSpec{
+		Containers: &spec.ContainerSpec{
+			Required: []spec.FieldRequirement{
+				{Key: "securityContext.runAsNonRoot", Value: "true"},
+				{Key: "securityContext.allowPrivilegeEscalation", Value: "false"},
+			},
+		},
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", Image: "registry.example.com/app:1.0"},
+				{
+					Name:            "sidecar",
+					Image:           "registry.example.com/proxy:1.0",
+					SecurityContext: &corev1.SecurityContext{AllowPrivilegeEscalation: &allowEscalation},
+				},
+			},
+		},
+	}
+
+	if allowed, _ := EvaluatePod(pod, workloads); allowed {
+		t.Fatal("expected the unpatched pod to violate the spec")
+	}
+
+	response := server.mutatePod(context.Background(), pod, []kspecv1alpha1.ClusterSpecification{mutateClusterSpec(workloads)})
+	if !response.Allowed {
+		t.Fatalf("expected pod to be admitted with a patch, got %+v", response.Result)
+	}
+	if response.PatchType == nil || *response.PatchType != admissionv1.PatchTypeJSONPatch {
+		t.Fatalf("expected PatchType JSONPatch, got %v", response.PatchType)
+	}
+
+	patched := applyResponsePatch(t, pod, response)
+	if allowed, reason := EvaluatePod(patched, workloads); !allowed {
+		t.Errorf("expected patched pod to be compliant, got: %s", reason)
+	}
+	if image := patched.Spec.Containers[1].Image; image != "registry.example.com/proxy:1.0" {
+		t.Errorf("expected patch to preserve the container image, got %s", image)
+	}
+}
+
+func TestMutatePodDeniesUnpatchableViolations(t *testing.T) {
+	server := &Server{PolicyManager: policy.NewAdvancedPolicyManager(nil)}
+	privileged := true
+	workloads := &spec.WorkloadsSpec{
+		Containers: &spec.ContainerSpec{
+			Required: []spec.FieldRequirement{
+				{Key: "securityContext.runAsNonRoot", Value: "true"},
+			},
+			Forbidden: []spec.FieldRequirement{
+				{Key: "securityContext.privileged", Value: "true"},
+			},
+		},
+	}
+
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:            "app",
+					Image:           "registry.example.com/app:1.0",
+					SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+				},
+			},
+		},
+	}
+
+	response := server.mutatePod(context.Background(), pod, []kspecv1alpha1.ClusterSpecification{mutateClusterSpec(workloads)})
+	if response.Allowed {
+		t.Fatal("expected privileged pod to be denied rather than patched")
+	}
+	if !strings.Contains(response.Result.Message, "securityContext.privileged") {
+		t.Errorf("expected denial to name the forbidden field, got %q", response.Result.Message)
+	}
+}
+
+func TestMutatePodIgnoresOtherModes(t *testing.T) {
+	server := &Server{PolicyManager: policy.NewAdvancedPolicyManager(nil)}
+	workloads := &spec.WorkloadsSpec{
+		Containers: &spec.ContainerSpec{
+			Required: []spec.FieldRequirement{
+				{Key: "securityContext.runAsNonRoot", Value: "true"},
+			},
+		},
+	}
+	clusterSpec := mutateClusterSpec(workloads)
+	clusterSpec.Spec.Enforcement.Mode = "enforce"
+
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "app:1.0"}}},
+	}
+
+	response := server.mutatePod(context.Background(), pod, []kspecv1alpha1.ClusterSpecification{clusterSpec})
+	if !response.Allowed || response.Patch != nil {
+		t.Errorf("expected enforce-mode specs to leave the pod unpatched, got %+v", response)
+	}
+}
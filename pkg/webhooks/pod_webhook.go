@@ -101,6 +101,9 @@ func (v *PodValidator) validatePod(ctx context.Context, pod *corev1.Pod) (admiss
 		return nil, nil
 	}
 
+	// Excluded containers (e.g. injected sidecars) are not subject to workload requirements
+	pod = withoutExcludedContainers(pod, clusterSpec.Spec.Workloads)
+
 	// Validate workload security requirements
 	if clusterSpec.Spec.Workloads != nil {
 		if err := v.validateWorkloadSecurity(pod, clusterSpec); err != nil {
@@ -199,6 +202,26 @@ func (v *PodValidator) validateWorkloadSecurity(pod *corev1.Pod, clusterSpec *ks
 		}
 	}
 
+	// Check required probes
+	if err := v.validateProbes(pod, workloads); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateProbes checks that app containers, and sidecar init containers if
+// configured, define the required probes
+func (v *PodValidator) validateProbes(pod *corev1.Pod, workloads *spec.WorkloadsSpec) error {
+	for _, container := range probedContainers(pod, workloads) {
+		if workloads.RequireLiveness && container.LivenessProbe == nil {
+			return fmt.Errorf("container %s must define a livenessProbe", container.Name)
+		}
+		if workloads.RequireReadiness && container.ReadinessProbe == nil {
+			return fmt.Errorf("container %s must define a readinessProbe", container.Name)
+		}
+	}
+
 	return nil
 }
 
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -20,6 +22,7 @@ import (
 	"github.com/cloudcwfranck/kspec/pkg/alerts"
 	"github.com/cloudcwfranck/kspec/pkg/metrics"
 	"github.com/cloudcwfranck/kspec/pkg/policy"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
 )
 
 var (
@@ -29,6 +32,8 @@ var (
 
 func init() {
 	_ = admissionv1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 }
 
@@ -38,15 +43,21 @@ type Server struct {
 	Port           int
 	CircuitBreaker *CircuitBreaker
 	PolicyManager  *policy.AdvancedPolicyManager
+	DecisionCache  *DecisionCache
+
+	// now returns the current time for time-based activation; defaults to time.Now
+	now func() time.Time
 }
 
-// NewServer creates a new webhook server
-func NewServer(client client.Client, port int, alertManager *alerts.Manager) *Server {
+// NewServer creates a new webhook server. breakerConfig is the circuit breaker
+// configuration used unless a ClusterSpec configures the breaker.
+func NewServer(client client.Client, port int, alertManager *alerts.Manager, breakerConfig CircuitBreakerConfig) *Server {
 	return &Server{
 		Client:         client,
 		Port:           port,
-		CircuitBreaker: NewCircuitBreaker(alertManager),
+		CircuitBreaker: NewCircuitBreaker(alertManager, breakerConfig),
 		PolicyManager:  policy.NewAdvancedPolicyManager(client),
+		DecisionCache:  NewDecisionCache(DefaultDecisionCacheSize, DefaultDecisionCacheTTL),
 	}
 }
 
@@ -56,6 +67,7 @@ func (s *Server) Start(ctx context.Context) error {
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/validate", s.handleValidate)
+	mux.HandleFunc("/mutate", s.handleMutate)
 	mux.HandleFunc("/healthz", s.handleHealthz)
 	mux.HandleFunc("/readyz", s.handleReadyz)
 	mux.HandleFunc("/metrics", s.handleMetrics)
@@ -86,6 +98,31 @@ func (s *Server) Start(ctx context.Context) error {
 
 // handleValidate handles admission review requests for pod validation
 func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	s.handleAdmission(w, r, func(ctx context.Context, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+		startTime := time.Now()
+		response := s.validate(ctx, request)
+
+		result := "allowed"
+		if !response.Allowed {
+			result = "denied"
+		}
+		metrics.WebhookValidationDuration.WithLabelValues(result).Observe(time.Since(startTime).Seconds())
+		return response
+	})
+}
+
+// handleMutate handles admission review requests for pod mutation
+func (s *Server) handleMutate(w http.ResponseWriter, r *http.Request) {
+	s.handleAdmission(w, r, s.mutate)
+}
+
+// handleAdmission decodes an admission review, answers it with review and
+// writes the response
+func (s *Server) handleAdmission(
+	w http.ResponseWriter,
+	r *http.Request,
+	review func(context.Context, *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse,
+) {
 	startTime := time.Now()
 	ctx := r.Context()
 	log := log.FromContext(ctx)
@@ -95,17 +132,28 @@ func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
 		metrics.WebhookRequestsTotal.WithLabelValues("circuit_breaker_tripped").Inc()
 		metrics.WebhookRequestDuration.WithLabelValues("circuit_breaker_tripped").Observe(time.Since(startTime).Seconds())
 
-		log.Info("Circuit breaker tripped, allowing request with warning")
-		// Fail-open: allow request but warn
 		response := &admissionv1.AdmissionReview{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: "admission.k8s.io/v1",
 				Kind:       "AdmissionReview",
 			},
-			Response: &admissionv1.AdmissionResponse{
+		}
+		if s.CircuitBreaker.Config().FailClosed {
+			log.Info("Circuit breaker tripped, denying request")
+			// Fail-closed: deny request until the breaker recovers
+			response.Response = &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: "Webhook validation temporarily unavailable due to high error rate",
+				},
+			}
+		} else {
+			log.Info("Circuit breaker tripped, allowing request with warning")
+			// Fail-open: allow request but warn
+			response.Response = &admissionv1.AdmissionResponse{
 				Allowed:  true,
 				Warnings: []string{"Webhook validation temporarily disabled due to high error rate"},
-			},
+			}
 		}
 		responseBytes, _ := json.Marshal(response)
 		w.Header().Set("Content-Type", "application/json")
@@ -148,8 +196,8 @@ func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate the request
-	response := s.validate(ctx, admissionReview.Request)
+	// Review the request
+	response := review(ctx, admissionReview.Request)
 
 	// Create response admission review
 	responseReview := &admissionv1.AdmissionReview{
@@ -182,26 +230,25 @@ func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
 	w.Write(responseBytes)
 }
 
-// validate validates a pod against all active ClusterSpecs
+// validate validates a pod, or the pod template of a workload controller,
+// against all active ClusterSpecs
 func (s *Server) validate(ctx context.Context, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
 	log := log.FromContext(ctx)
+	kind := request.Kind.Kind
 
-	// Only validate Pods
-	if request.Kind.Kind != "Pod" {
+	// Only validate Pods and controllers with a pod template
+	pod, ok, err := podFromAdmissionRequest(request)
+	if !ok {
 		return &admissionv1.AdmissionResponse{
 			Allowed: true,
 		}
 	}
-
-	// Decode pod
-	pod := &corev1.Pod{}
-	deserializer := codecs.UniversalDeserializer()
-	if _, _, err := deserializer.Decode(request.Object.Raw, nil, pod); err != nil {
-		log.Error(err, "Failed to decode pod")
+	if err != nil {
+		log.Error(err, "Failed to decode object", "kind", kind)
 		return &admissionv1.AdmissionResponse{
 			Allowed: false,
 			Result: &metav1.Status{
-				Message: fmt.Sprintf("Failed to decode pod: %v", err),
+				Message: fmt.Sprintf("Failed to decode %s: %v", kind, err),
 			},
 		}
 	}
@@ -216,9 +263,50 @@ func (s *Server) validate(ctx context.Context, request *admissionv1.AdmissionReq
 			Warnings: []string{"Failed to check cluster specifications, allowing by default"},
 		}
 	}
+	s.CircuitBreaker.Configure(circuitBreakerSpec(clusterSpecs.Items))
+
+	// Structurally identical pods (e.g. pods of the same Job) get the same decision,
+	// so reuse it instead of re-evaluating every ClusterSpec
+	cacheKey := ""
+	fingerprint := clusterSpecsFingerprint(clusterSpecs.Items)
+	if s.DecisionCache != nil && decisionsCacheable(clusterSpecs.Items) {
+		key, err := podDecisionKey(pod, hasPolicyExemptions(clusterSpecs.Items))
+		if err != nil {
+			log.Error(err, "Failed to compute decision cache key")
+		} else {
+			// Decisions name the kind, so a Deployment never reuses a Pod's decision
+			cacheKey = kind + "/" + key
+		}
+	}
+
+	if cacheKey == "" {
+		metrics.WebhookDecisionCacheRequests.WithLabelValues("bypass").Inc()
+	} else if decision, ok := s.DecisionCache.Get(cacheKey, fingerprint); ok {
+		metrics.WebhookDecisionCacheRequests.WithLabelValues("hit").Inc()
+		log.V(1).Info("Using cached admission decision", "kind", kind, "name", pod.Name, "namespace", pod.Namespace)
+		recordDecisionMetrics(decision)
+		// The caller sets the response UID, so never hand out the cached response itself
+		return decision.response.DeepCopy()
+	} else {
+		metrics.WebhookDecisionCacheRequests.WithLabelValues("miss").Inc()
+	}
+
+	decision := s.evaluate(ctx, kind, pod, clusterSpecs.Items)
+	if cacheKey != "" {
+		s.DecisionCache.Add(cacheKey, fingerprint, decision)
+	}
+
+	recordDecisionMetrics(decision)
+	return decision.response.DeepCopy()
+}
 
-	// Validate pod against each active ClusterSpec
-	for _, clusterSpec := range clusterSpecs.Items {
+// evaluate validates a pod against each active ClusterSpec. kind is the kind of
+// the admitted object the pod was taken from, e.g. Pod or Deployment.
+func (s *Server) evaluate(ctx context.Context, kind string, pod *corev1.Pod, clusterSpecs []kspecv1alpha1.ClusterSpecification) *admissionDecision {
+	log := log.FromContext(ctx)
+	decision := &admissionDecision{}
+
+	for _, clusterSpec := range clusterSpecs {
 		// Skip if enforcement not enabled
 		if clusterSpec.Spec.Enforcement == nil || !clusterSpec.Spec.Enforcement.Enabled {
 			continue
@@ -234,124 +322,217 @@ func (s *Server) validate(ctx context.Context, request *admissionv1.AdmissionReq
 			continue
 		}
 
-		// Phase 7: Check namespace scoping
-		if clusterSpec.Spec.NamespaceScope != nil {
-			scopeConfig := &policy.NamespaceScope{
-				IncludeNamespaces: clusterSpec.Spec.NamespaceScope.IncludeNamespaces,
-				ExcludeNamespaces: clusterSpec.Spec.NamespaceScope.ExcludeNamespaces,
-				NamespaceSelector: clusterSpec.Spec.NamespaceScope.NamespaceSelector,
-			}
-			if !s.PolicyManager.ApplyNamespaceScope(scopeConfig, pod.Namespace) {
-				log.V(1).Info("Pod namespace not in scope", "namespace", pod.Namespace, "clusterSpec", clusterSpec.Name)
-				continue
-			}
+		if !s.inScope(ctx, pod, &clusterSpec) {
+			continue
 		}
 
-		// Phase 7: Check time-based activation
-		if clusterSpec.Spec.TimeBasedActivation != nil && clusterSpec.Spec.TimeBasedActivation.Enabled {
-			timeConfig := &policy.TimeBasedActivation{
-				Enabled:       true,
-				Timezone:      clusterSpec.Spec.TimeBasedActivation.Timezone,
-				ActivePeriods: convertTimePeriods(clusterSpec.Spec.TimeBasedActivation.ActivePeriods),
-			}
-			if !s.PolicyManager.IsActiveInTimeWindow(timeConfig, time.Now()) {
-				log.V(1).Info("Policy not active in current time window", "clusterSpec", clusterSpec.Name)
-				continue
-			}
+		// Phase 7: Outside its time window the ClusterSpec is not enforced
+		if !s.isActive(&clusterSpec) {
+			log.V(1).Info("Policy not active in current time window", "clusterSpec", clusterSpec.Name)
+			decision.warnings = append(decision.warnings,
+				fmt.Sprintf("%s is not enforced outside its active time window", clusterSpec.Name))
+			continue
 		}
 
 		// Phase 7: Check policy exemptions
-		if len(clusterSpec.Spec.PolicyExemptions) > 0 {
-			exemptions := convertExemptions(clusterSpec.Spec.PolicyExemptions)
-			if exempt, reason := s.PolicyManager.IsExempt(
-				ctx,
-				exemptions,
-				"Pod",
-				pod.Name,
-				pod.Namespace,
-				pod.Labels,
-			); exempt {
-				log.Info("Pod is exempt from policy",
-					"pod", pod.Name,
-					"namespace", pod.Namespace,
-					"reason", reason)
-				metrics.PolicyEnforcementActions.WithLabelValues(clusterSpec.Name, "exempted").Inc()
-				continue
-			}
+		if exemption := s.matchExemption(ctx, kind, pod, &clusterSpec); exemption != nil {
+			log.Info("Object is exempt from policy",
+				"kind", kind,
+				"name", pod.Name,
+				"namespace", pod.Namespace,
+				"clusterSpec", clusterSpec.Name,
+				"exemption", exemption.Name,
+				"ticketRef", exemption.TicketRef,
+				"approver", exemption.Approver,
+				"reason", exemption.Reason,
+				"expiresAt", exemption.ExpiresAt)
+			decision.actions = append(decision.actions, enforcementAction{clusterSpec: clusterSpec.Name, action: "exempted"})
+			decision.warnings = append(decision.warnings, exemptionWarning(clusterSpec.Name, exemption))
+			continue
 		}
 
 		// Validate pod against this ClusterSpec
 		if allowed, reason := s.validatePodAgainstSpec(ctx, pod, &clusterSpec); !allowed {
 			// In audit mode, allow but warn
 			if clusterSpec.Spec.Enforcement.Mode == "audit" {
-				metrics.WebhookValidationResults.WithLabelValues("allowed", "audit").Inc()
-				metrics.PolicyEnforcementActions.WithLabelValues(clusterSpec.Name, "warned").Inc()
-				log.Info("Pod violates ClusterSpec (audit mode)",
-					"pod", pod.Name,
+				log.Info("Object violates ClusterSpec (audit mode)",
+					"kind", kind,
+					"name", pod.Name,
 					"namespace", pod.Namespace,
 					"clusterSpec", clusterSpec.Name,
 					"reason", reason)
-				return &admissionv1.AdmissionResponse{
+				decision.validationResult, decision.validationMode = "allowed", "audit"
+				decision.actions = append(decision.actions, enforcementAction{clusterSpec: clusterSpec.Name, action: "warned"})
+				decision.response = &admissionv1.AdmissionResponse{
 					Allowed:  true,
-					Warnings: []string{fmt.Sprintf("Policy violation (audit): %s", reason)},
+					Warnings: append(decision.warnings, fmt.Sprintf("Policy violation (audit): %s", reason)),
 				}
+				return decision
 			}
 
 			// In enforce mode, deny
-			metrics.WebhookValidationResults.WithLabelValues("denied", "enforce").Inc()
-			metrics.PolicyEnforcementActions.WithLabelValues(clusterSpec.Name, "denied").Inc()
-			log.Info("Pod violates ClusterSpec (enforce mode)",
-				"pod", pod.Name,
+			log.Info("Object violates ClusterSpec (enforce mode)",
+				"kind", kind,
+				"name", pod.Name,
 				"namespace", pod.Namespace,
 				"clusterSpec", clusterSpec.Name,
 				"reason", reason)
-			return &admissionv1.AdmissionResponse{
+			decision.validationResult, decision.validationMode = "denied", "enforce"
+			decision.actions = append(decision.actions, enforcementAction{clusterSpec: clusterSpec.Name, action: "denied"})
+			decision.response = &admissionv1.AdmissionResponse{
 				Allowed: false,
 				Result: &metav1.Status{
-					Message: fmt.Sprintf("Pod violates cluster specification %s: %s", clusterSpec.Name, reason),
+					Message: fmt.Sprintf("%s violates cluster specification %s: %s", kind, clusterSpec.Name, reason),
 				},
 			}
+			return decision
 		}
 	}
 
 	// Pod is valid
-	metrics.WebhookValidationResults.WithLabelValues("allowed", "valid").Inc()
-	return &admissionv1.AdmissionResponse{
-		Allowed: true,
+	decision.validationResult, decision.validationMode = "allowed", "valid"
+	decision.response = &admissionv1.AdmissionResponse{
+		Allowed:  true,
+		Warnings: decision.warnings,
+	}
+	return decision
+}
+
+// circuitBreakerSpec returns the circuit breaker settings of the first ClusterSpec,
+// by name, that has webhooks enabled and configures the breaker, or nil
+func circuitBreakerSpec(clusterSpecs []kspecv1alpha1.ClusterSpecification) *kspecv1alpha1.CircuitBreakerSpec {
+	var selected *kspecv1alpha1.ClusterSpecification
+	for i := range clusterSpecs {
+		clusterSpec := &clusterSpecs[i]
+		webhookSpec := clusterSpec.Spec.Webhooks
+		if webhookSpec == nil || !webhookSpec.Enabled || webhookSpec.CircuitBreaker == nil {
+			continue
+		}
+		if selected == nil || clusterSpec.Name < selected.Name {
+			selected = clusterSpec
+		}
+	}
+
+	if selected == nil {
+		return nil
+	}
+	return selected.Spec.Webhooks.CircuitBreaker
+}
+
+// inScope reports whether a ClusterSpec applies to a pod given its namespace
+// scoping
+func (s *Server) inScope(ctx context.Context, pod *corev1.Pod, clusterSpec *kspecv1alpha1.ClusterSpecification) bool {
+	log := log.FromContext(ctx)
+
+	// Phase 7: Check namespace scoping
+	if clusterSpec.Spec.NamespaceScope != nil {
+		scopeConfig := &policy.NamespaceScope{
+			IncludeNamespaces: clusterSpec.Spec.NamespaceScope.IncludeNamespaces,
+			ExcludeNamespaces: clusterSpec.Spec.NamespaceScope.ExcludeNamespaces,
+			NamespaceSelector: clusterSpec.Spec.NamespaceScope.NamespaceSelector,
+		}
+		var namespaceLabels map[string]string
+		if scopeConfig.NamespaceSelector != nil {
+			namespaceLabels = s.namespaceLabels(ctx, pod.Namespace)
+		}
+		if !s.PolicyManager.ApplyNamespaceScope(scopeConfig, pod.Namespace, namespaceLabels) {
+			log.V(1).Info("Pod namespace not in scope", "namespace", pod.Namespace, "clusterSpec", clusterSpec.Name)
+			return false
+		}
 	}
+
+	return true
+}
+
+// isActive reports whether a ClusterSpec's time-based activation, if any,
+// enforces it at the current time
+func (s *Server) isActive(clusterSpec *kspecv1alpha1.ClusterSpecification) bool {
+	activation := clusterSpec.Spec.TimeBasedActivation
+	if activation == nil || !activation.Enabled {
+		return true
+	}
+
+	return s.PolicyManager.IsActiveInTimeWindow(&policy.TimeBasedActivation{
+		Enabled:       true,
+		Schedule:      activation.Schedule,
+		Timezone:      activation.Timezone,
+		ActivePeriods: convertTimePeriods(activation.ActivePeriods),
+	}, s.currentTime())
+}
+
+// currentTime returns the time admission decisions are made at
+func (s *Server) currentTime() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
+
+// matchExemption returns the policy exemption of a ClusterSpec covering a pod, or
+// the controller of the given kind it was taken from, or nil
+func (s *Server) matchExemption(ctx context.Context, kind string, pod *corev1.Pod, clusterSpec *kspecv1alpha1.ClusterSpecification) *policy.PolicyExemption {
+	if len(clusterSpec.Spec.PolicyExemptions) == 0 {
+		return nil
+	}
+
+	return s.PolicyManager.MatchExemption(
+		ctx,
+		ticketedExemptions(ctx, clusterSpec),
+		kind,
+		pod.Name,
+		pod.Namespace,
+		pod.Labels,
+	)
+}
+
+// recordDecisionMetrics records the validation metrics of a decision
+func recordDecisionMetrics(decision *admissionDecision) {
+	for _, action := range decision.actions {
+		metrics.PolicyEnforcementActions.WithLabelValues(action.clusterSpec, action.action).Inc()
+	}
+	metrics.WebhookValidationResults.WithLabelValues(decision.validationResult, decision.validationMode).Inc()
 }
 
 // validatePodAgainstSpec validates a pod against a ClusterSpec
 func (s *Server) validatePodAgainstSpec(ctx context.Context, pod *corev1.Pod, clusterSpec *kspecv1alpha1.ClusterSpecification) (bool, string) {
+	return EvaluatePod(pod, clusterSpec.Spec.Workloads)
+}
+
+// EvaluatePod evaluates a pod against workload requirements exactly as the
+// admission webhook does, returning whether it would be allowed and, if not, why.
+func EvaluatePod(pod *corev1.Pod, workloads *spec.WorkloadsSpec) (bool, string) {
+	// Excluded containers (e.g. injected sidecars) are not subject to workload requirements
+	pod = withoutExcludedContainers(pod, workloads)
+
 	// Check workload requirements
-	if clusterSpec.Spec.Workloads != nil && clusterSpec.Spec.Workloads.Containers != nil {
+	if workloads != nil && workloads.Containers != nil {
 		// Check required fields
-		for _, req := range clusterSpec.Spec.Workloads.Containers.Required {
-			if !s.checkRequiredField(pod, req.Key, req.Value) {
+		for _, req := range workloads.Containers.Required {
+			if !checkRequiredField(pod, req.Key, req.Value) {
 				return false, fmt.Sprintf("Required field %s=%s not satisfied", req.Key, req.Value)
 			}
 		}
 
 		// Check forbidden fields
-		for _, forbidden := range clusterSpec.Spec.Workloads.Containers.Forbidden {
-			if s.checkForbiddenField(pod, forbidden.Key, forbidden.Value) {
+		for _, forbidden := range workloads.Containers.Forbidden {
+			if checkForbiddenField(pod, forbidden.Key, forbidden.Value) {
 				return false, fmt.Sprintf("Forbidden field %s=%s found", forbidden.Key, forbidden.Value)
 			}
 		}
 	}
 
 	// Check image requirements
-	if clusterSpec.Spec.Workloads != nil && clusterSpec.Spec.Workloads.Images != nil {
+	if workloads != nil && workloads.Images != nil {
 		for _, container := range pod.Spec.Containers {
 			// Check image digest requirement
-			if clusterSpec.Spec.Workloads.Images.RequireDigests {
+			if workloads.Images.RequireDigests {
 				if !hasDigest(container.Image) {
 					return false, fmt.Sprintf("Container %s must use image digest", container.Name)
 				}
 			}
 
 			// Check blocked registries
-			for _, blockedRegistry := range clusterSpec.Spec.Workloads.Images.BlockedRegistries {
+			for _, blockedRegistry := range workloads.Images.BlockedRegistries {
 				if matchesRegistry(container.Image, blockedRegistry) {
 					return false, fmt.Sprintf("Container %s uses blocked registry %s", container.Name, blockedRegistry)
 				}
@@ -359,11 +540,63 @@ func (s *Server) validatePodAgainstSpec(ctx context.Context, pod *corev1.Pod, cl
 		}
 	}
 
+	// Check probe requirements
+	if workloads != nil {
+		for _, container := range probedContainers(pod, workloads) {
+			if workloads.RequireLiveness && container.LivenessProbe == nil {
+				return false, fmt.Sprintf("Container %s must define a livenessProbe", container.Name)
+			}
+			if workloads.RequireReadiness && container.ReadinessProbe == nil {
+				return false, fmt.Sprintf("Container %s must define a readinessProbe", container.Name)
+			}
+		}
+	}
+
 	return true, ""
 }
 
+// withoutExcludedContainers returns a shallow copy of the pod without the containers
+// excluded by name in the workloads spec
+func withoutExcludedContainers(pod *corev1.Pod, workloads *spec.WorkloadsSpec) *corev1.Pod {
+	if workloads == nil || len(workloads.ExcludeContainers) == 0 {
+		return pod
+	}
+
+	filtered := *pod
+	filtered.Spec.Containers = nil
+	filtered.Spec.InitContainers = nil
+	for _, container := range pod.Spec.Containers {
+		if !workloads.IsContainerExcluded(container.Name) {
+			filtered.Spec.Containers = append(filtered.Spec.Containers, container)
+		}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		if !workloads.IsContainerExcluded(container.Name) {
+			filtered.Spec.InitContainers = append(filtered.Spec.InitContainers, container)
+		}
+	}
+	return &filtered
+}
+
+// probedContainers returns the containers that must define the required probes:
+// the app containers and, with workloads.includeInitContainers, sidecar init
+// containers (restartPolicy: Always). Other init containers cannot define probes.
+func probedContainers(pod *corev1.Pod, workloads *spec.WorkloadsSpec) []corev1.Container {
+	if !workloads.IncludeInitContainers {
+		return pod.Spec.Containers
+	}
+
+	containers := []corev1.Container{}
+	for _, container := range pod.Spec.InitContainers {
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			containers = append(containers, container)
+		}
+	}
+	return append(containers, pod.Spec.Containers...)
+}
+
 // checkRequiredField checks if a required field is satisfied
-func (s *Server) checkRequiredField(pod *corev1.Pod, key, value string) bool {
+func checkRequiredField(pod *corev1.Pod, key, value string) bool {
 	switch key {
 	case "securityContext.runAsNonRoot":
 		// Check pod-level security context
@@ -408,7 +641,7 @@ func (s *Server) checkRequiredField(pod *corev1.Pod, key, value string) bool {
 }
 
 // checkForbiddenField checks if a forbidden field is present
-func (s *Server) checkForbiddenField(pod *corev1.Pod, key, value string) bool {
+func checkForbiddenField(pod *corev1.Pod, key, value string) bool {
 	switch key {
 	case "securityContext.privileged":
 		for _, container := range pod.Spec.Containers {
@@ -498,6 +731,21 @@ func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 	w.Write(responseBytes)
 }
 
+// namespaceLabels returns the labels of a namespace for namespace selector
+// matching. A namespace that cannot be read is treated as unlabeled.
+func (s *Server) namespaceLabels(ctx context.Context, name string) map[string]string {
+	if s.Client == nil {
+		return nil
+	}
+
+	var namespace corev1.Namespace
+	if err := s.Client.Get(ctx, client.ObjectKey{Name: name}, &namespace); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to get namespace labels", "namespace", name)
+		return nil
+	}
+	return namespace.Labels
+}
+
 // convertTimePeriods converts CRD TimePeriodSpec to policy TimePeriod
 func convertTimePeriods(specs []kspecv1alpha1.TimePeriodSpec) []policy.TimePeriod {
 	result := make([]policy.TimePeriod, len(specs))
@@ -534,8 +782,47 @@ func convertExemptions(specs []kspecv1alpha1.PolicyExemptionSpec) []policy.Polic
 			Namespaces: spec.Namespaces,
 			Resources:  resources,
 			Approver:   spec.Approver,
+			TicketRef:  spec.TicketRef,
 			CreatedAt:  metav1.Now(),
 		}
 	}
 	return result
 }
+
+// ticketedExemptions converts the exemptions of a ClusterSpec, dropping those
+// whose ticket reference does not match the spec's exemption ticket pattern
+func ticketedExemptions(ctx context.Context, clusterSpec *kspecv1alpha1.ClusterSpecification) []policy.PolicyExemption {
+	log := log.FromContext(ctx)
+
+	exemptions := convertExemptions(clusterSpec.Spec.PolicyExemptions)
+	result := make([]policy.PolicyExemption, 0, len(exemptions))
+	for _, exemption := range exemptions {
+		if err := policy.ValidateTicketRef(clusterSpec.Spec.ExemptionTicketPattern, exemption.TicketRef); err != nil {
+			log.Info("Ignoring policy exemption with invalid ticket reference",
+				"clusterSpec", clusterSpec.Name,
+				"exemption", exemption.Name,
+				"error", err.Error())
+			continue
+		}
+		result = append(result, exemption)
+	}
+	return result
+}
+
+// exemptionWarning describes an exemption that allowed a pod, for auditing
+func exemptionWarning(clusterSpecName string, exemption *policy.PolicyExemption) string {
+	warning := fmt.Sprintf("Exempted from %s by %s", clusterSpecName, exemption.Name)
+	if exemption.TicketRef != "" {
+		warning += fmt.Sprintf(" (ticket %s)", exemption.TicketRef)
+	}
+	if exemption.Approver != "" {
+		warning += fmt.Sprintf(", approved by %s", exemption.Approver)
+	}
+	if exemption.ExpiresAt != nil {
+		warning += fmt.Sprintf(", expires %s", exemption.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	if exemption.Reason != "" {
+		warning += fmt.Sprintf(": %s", exemption.Reason)
+	}
+	return warning
+}
@@ -0,0 +1,317 @@
+package webhooks
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+	"github.com/cloudcwfranck/kspec/pkg/policy"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+)
+
+func TestEvaluatePod(t *testing.T) {
+	privileged := true
+	workloads := &spec.WorkloadsSpec{
+		Containers: &spec.ContainerSpec{
+			Forbidden: []spec.FieldRequirement{
+				{Key: "securityContext.privileged", Value: "true"},
+			},
+		},
+		Images: &spec.ImageSpec{
+			BlockedRegistries: []string{"docker.io/"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		container  corev1.Container
+		wantAllow  bool
+		wantReason string
+	}{
+		{
+			name:      "compliant pod",
+			container: corev1.Container{Name: "app", Image: "registry.example.com/app:1.0"},
+			wantAllow: true,
+		},
+		{
+			name: "privileged container",
+			container: corev1.Container{
+				Name:            "app",
+				Image:           "registry.example.com/app:1.0",
+				SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+			},
+			wantReason: "Forbidden field securityContext.privileged=true found",
+		},
+		{
+			name:       "blocked registry",
+			container:  corev1.Container{Name: "app", Image: "docker.io/library/nginx"},
+			wantReason: "uses blocked registry docker.io/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{tt.container}}}
+
+			allowed, reason := EvaluatePod(pod, workloads)
+			if allowed != tt.wantAllow {
+				t.Fatalf("expected allowed=%v, got %v (reason %q)", tt.wantAllow, allowed, reason)
+			}
+			if !strings.Contains(reason, tt.wantReason) {
+				t.Errorf("expected reason containing %q, got %q", tt.wantReason, reason)
+			}
+		})
+	}
+}
+
+func TestEvaluatePodProbes(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	probe := &corev1.Probe{PeriodSeconds: 10}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		InitContainers: []corev1.Container{
+			{Name: "migrate", Image: "registry.example.com/migrate:1.0"},
+			{Name: "proxy", Image: "registry.example.com/proxy:1.0", RestartPolicy: &always},
+		},
+		Containers: []corev1.Container{
+			{Name: "app", Image: "registry.example.com/app:1.0", LivenessProbe: probe},
+		},
+	}}
+	workloads := &spec.WorkloadsSpec{RequireLiveness: true}
+
+	if allowed, reason := EvaluatePod(pod, workloads); !allowed {
+		t.Errorf("expected init containers to be ignored by default, got %q", reason)
+	}
+
+	workloads.IncludeInitContainers = true
+	allowed, reason := EvaluatePod(pod, workloads)
+	if allowed {
+		t.Fatal("expected the sidecar init container without a livenessProbe to be denied")
+	}
+	if !strings.Contains(reason, "Container proxy must define a livenessProbe") {
+		t.Errorf("expected the sidecar to be named, got %q", reason)
+	}
+
+	pod.Spec.InitContainers[1].LivenessProbe = probe
+	if allowed, reason := EvaluatePod(pod, workloads); !allowed {
+		t.Errorf("expected run-to-completion init containers to be ignored, got %q", reason)
+	}
+}
+
+func TestEvaluateExemptionTicketRef(t *testing.T) {
+	server := &Server{PolicyManager: policy.NewAdvancedPolicyManager(nil)}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "apps"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: "docker.io/library/nginx"}},
+		},
+	}
+
+	newClusterSpec := func(ticketRef string) kspecv1alpha1.ClusterSpecification {
+		return kspecv1alpha1.ClusterSpecification{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+			Spec: kspecv1alpha1.ClusterSpecificationSpec{
+				Enforcement:            &kspecv1alpha1.EnforcementSpec{Enabled: true, Mode: "enforce"},
+				Webhooks:               &kspecv1alpha1.WebhooksSpec{Enabled: true},
+				ExemptionTicketPattern: `^CHG-\d+$`,
+				PolicyExemptions: []kspecv1alpha1.PolicyExemptionSpec{
+					{
+						Name:      "legacy-nginx",
+						Reason:    "migration in progress",
+						Approver:  "security-team",
+						TicketRef: ticketRef,
+						Resources: []kspecv1alpha1.ResourceSelectorSpec{{Kind: "Pod", Name: "legacy"}},
+					},
+				},
+				SpecFields: spec.SpecFields{
+					Workloads: &spec.WorkloadsSpec{
+						Images: &spec.ImageSpec{BlockedRegistries: []string{"docker.io/"}},
+					},
+				},
+			},
+		}
+	}
+
+	decision := server.evaluate(context.Background(), "Pod", pod, []kspecv1alpha1.ClusterSpecification{newClusterSpec("CHG-1234")})
+	if !decision.response.Allowed {
+		t.Fatalf("expected exempted pod to be allowed, got %+v", decision.response.Result)
+	}
+	if len(decision.response.Warnings) != 1 ||
+		!strings.Contains(decision.response.Warnings[0], "ticket CHG-1234") ||
+		!strings.Contains(decision.response.Warnings[0], "approved by security-team") {
+		t.Errorf("expected a warning with the ticket and approver, got %v", decision.response.Warnings)
+	}
+
+	// An exemption whose ticket does not match the pattern is not honored
+	decision = server.evaluate(context.Background(), "Pod", pod, []kspecv1alpha1.ClusterSpecification{newClusterSpec("see slack")})
+	if decision.response.Allowed {
+		t.Error("expected pod to be denied when the exemption ticket does not match the pattern")
+	}
+}
+
+func TestEvaluateExemptions(t *testing.T) {
+	server := &Server{PolicyManager: policy.NewAdvancedPolicyManager(nil)}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "legacy",
+			Namespace: "apps",
+			Labels:    map[string]string{"team": "payments"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: "docker.io/library/nginx"}},
+		},
+	}
+	expired := metav1.NewTime(time.Now().Add(-time.Hour))
+	unexpired := metav1.NewTime(time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name      string
+		exemption kspecv1alpha1.PolicyExemptionSpec
+		wantAllow bool
+	}{
+		{
+			name: "name match",
+			exemption: kspecv1alpha1.PolicyExemptionSpec{
+				Name:      "legacy-pod",
+				Reason:    "vendor image pending rebuild",
+				Resources: []kspecv1alpha1.ResourceSelectorSpec{{Kind: "Pod", Name: "legacy"}},
+			},
+			wantAllow: true,
+		},
+		{
+			name: "label match",
+			exemption: kspecv1alpha1.PolicyExemptionSpec{
+				Name:      "payments-team",
+				Reason:    "vendor image pending rebuild",
+				ExpiresAt: &unexpired,
+				Resources: []kspecv1alpha1.ResourceSelectorSpec{{Kind: "Pod", LabelSelector: map[string]string{"team": "payments"}}},
+			},
+			wantAllow: true,
+		},
+		{
+			name: "label mismatch",
+			exemption: kspecv1alpha1.PolicyExemptionSpec{
+				Name:      "search-team",
+				Reason:    "vendor image pending rebuild",
+				Resources: []kspecv1alpha1.ResourceSelectorSpec{{Kind: "Pod", LabelSelector: map[string]string{"team": "search"}}},
+			},
+		},
+		{
+			name: "expired exemption",
+			exemption: kspecv1alpha1.PolicyExemptionSpec{
+				Name:      "legacy-pod",
+				Reason:    "vendor image pending rebuild",
+				ExpiresAt: &expired,
+				Resources: []kspecv1alpha1.ResourceSelectorSpec{{Kind: "Pod", Name: "legacy"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterSpec := kspecv1alpha1.ClusterSpecification{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+				Spec: kspecv1alpha1.ClusterSpecificationSpec{
+					Enforcement:      &kspecv1alpha1.EnforcementSpec{Enabled: true, Mode: "enforce"},
+					Webhooks:         &kspecv1alpha1.WebhooksSpec{Enabled: true},
+					PolicyExemptions: []kspecv1alpha1.PolicyExemptionSpec{tt.exemption},
+					SpecFields: spec.SpecFields{
+						Workloads: &spec.WorkloadsSpec{
+							Images: &spec.ImageSpec{BlockedRegistries: []string{"docker.io/"}},
+						},
+					},
+				},
+			}
+
+			decision := server.evaluate(context.Background(), "Pod", pod, []kspecv1alpha1.ClusterSpecification{clusterSpec})
+			if decision.response.Allowed != tt.wantAllow {
+				t.Fatalf("expected allowed=%v, got %+v", tt.wantAllow, decision.response)
+			}
+			if !tt.wantAllow {
+				return
+			}
+			if len(decision.response.Warnings) != 1 ||
+				!strings.Contains(decision.response.Warnings[0], tt.exemption.Name) ||
+				!strings.Contains(decision.response.Warnings[0], "vendor image pending rebuild") {
+				t.Errorf("expected a warning naming the exemption and its reason, got %v", decision.response.Warnings)
+			}
+		})
+	}
+}
+
+func TestEvaluateTimeBasedActivation(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: "docker.io/library/nginx"}},
+		},
+	}
+	newClusterSpec := func(activation *kspecv1alpha1.TimeBasedActivationSpec) kspecv1alpha1.ClusterSpecification {
+		return kspecv1alpha1.ClusterSpecification{
+			ObjectMeta: metav1.ObjectMeta{Name: "business-hours"},
+			Spec: kspecv1alpha1.ClusterSpecificationSpec{
+				Enforcement:         &kspecv1alpha1.EnforcementSpec{Enabled: true, Mode: "enforce"},
+				Webhooks:            &kspecv1alpha1.WebhooksSpec{Enabled: true},
+				TimeBasedActivation: activation,
+				SpecFields: spec.SpecFields{
+					Workloads: &spec.WorkloadsSpec{
+						Images: &spec.ImageSpec{BlockedRegistries: []string{"docker.io/"}},
+					},
+				},
+			},
+		}
+	}
+
+	periods := &kspecv1alpha1.TimeBasedActivationSpec{
+		Enabled:  true,
+		Timezone: "UTC",
+		ActivePeriods: []kspecv1alpha1.TimePeriodSpec{
+			{StartTime: "09:00", EndTime: "17:00", DaysOfWeek: []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"}},
+		},
+	}
+	schedule := &kspecv1alpha1.TimeBasedActivationSpec{
+		Enabled:  true,
+		Schedule: "* 9-16 * * MON-FRI",
+		Timezone: "Not/AZone", // falls back to UTC
+	}
+
+	insideWindow := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC) // Monday 2:30 PM
+	outsideWindow := time.Date(2024, 1, 16, 3, 0, 0, 0, time.UTC)  // Tuesday 3:00 AM
+
+	tests := []struct {
+		name       string
+		activation *kspecv1alpha1.TimeBasedActivationSpec
+		now        time.Time
+		wantAllow  bool
+	}{
+		{name: "active periods inside window", activation: periods, now: insideWindow},
+		{name: "active periods outside window", activation: periods, now: outsideWindow, wantAllow: true},
+		{name: "schedule inside window", activation: schedule, now: insideWindow},
+		{name: "schedule outside window", activation: schedule, now: outsideWindow, wantAllow: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &Server{
+				PolicyManager: policy.NewAdvancedPolicyManager(nil),
+				now:           func() time.Time { return tt.now },
+			}
+
+			decision := server.evaluate(context.Background(), "Pod", pod, []kspecv1alpha1.ClusterSpecification{newClusterSpec(tt.activation)})
+			if decision.response.Allowed != tt.wantAllow {
+				t.Fatalf("expected allowed=%v, got %+v", tt.wantAllow, decision.response)
+			}
+			if !tt.wantAllow {
+				return
+			}
+			if len(decision.response.Warnings) != 1 ||
+				!strings.Contains(decision.response.Warnings[0], "not enforced outside its active time window") {
+				t.Errorf("expected an inactive time window warning, got %v", decision.response.Warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package webhooks
+
+import (
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// podFromAdmissionRequest returns the pod an admission request creates: the
+// pod itself, or a pod built from the template of a Deployment, StatefulSet,
+// DaemonSet, Job or CronJob. ok is false for kinds without a pod template.
+func podFromAdmissionRequest(request *admissionv1.AdmissionRequest) (pod *corev1.Pod, ok bool, err error) {
+	var (
+		meta     metav1.ObjectMeta
+		template corev1.PodTemplateSpec
+	)
+
+	deserializer := codecs.UniversalDeserializer()
+	switch request.Kind.Kind {
+	case "Pod":
+		pod = &corev1.Pod{}
+		if _, _, err := deserializer.Decode(request.Object.Raw, nil, pod); err != nil {
+			return nil, true, fmt.Errorf("failed to decode pod: %w", err)
+		}
+		if pod.Namespace == "" {
+			pod.Namespace = request.Namespace
+		}
+		return pod, true, nil
+
+	case "Deployment":
+		deployment := &appsv1.Deployment{}
+		if _, _, err := deserializer.Decode(request.Object.Raw, nil, deployment); err != nil {
+			return nil, true, fmt.Errorf("failed to decode deployment: %w", err)
+		}
+		meta, template = deployment.ObjectMeta, deployment.Spec.Template
+
+	case "StatefulSet":
+		statefulSet := &appsv1.StatefulSet{}
+		if _, _, err := deserializer.Decode(request.Object.Raw, nil, statefulSet); err != nil {
+			return nil, true, fmt.Errorf("failed to decode statefulset: %w", err)
+		}
+		meta, template = statefulSet.ObjectMeta, statefulSet.Spec.Template
+
+	case "DaemonSet":
+		daemonSet := &appsv1.DaemonSet{}
+		if _, _, err := deserializer.Decode(request.Object.Raw, nil, daemonSet); err != nil {
+			return nil, true, fmt.Errorf("failed to decode daemonset: %w", err)
+		}
+		meta, template = daemonSet.ObjectMeta, daemonSet.Spec.Template
+
+	case "Job":
+		job := &batchv1.Job{}
+		if _, _, err := deserializer.Decode(request.Object.Raw, nil, job); err != nil {
+			return nil, true, fmt.Errorf("failed to decode job: %w", err)
+		}
+		meta, template = job.ObjectMeta, job.Spec.Template
+
+	case "CronJob":
+		cronJob := &batchv1.CronJob{}
+		if _, _, err := deserializer.Decode(request.Object.Raw, nil, cronJob); err != nil {
+			return nil, true, fmt.Errorf("failed to decode cronjob: %w", err)
+		}
+		meta, template = cronJob.ObjectMeta, cronJob.Spec.JobTemplate.Spec.Template
+
+	default:
+		return nil, false, nil
+	}
+
+	// The pod carries the controller's name and namespace, so exemptions and
+	// namespace scoping match the controller, and the template's labels
+	pod = &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        meta.Name,
+			Namespace:   meta.Namespace,
+			Labels:      template.Labels,
+			Annotations: template.Annotations,
+		},
+		Spec: template.Spec,
+	}
+	if pod.Namespace == "" {
+		pod.Namespace = request.Namespace
+	}
+	return pod, true, nil
+}
@@ -0,0 +1,130 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	kspecv1alpha1 "github.com/cloudcwfranck/kspec/api/v1alpha1"
+	"github.com/cloudcwfranck/kspec/pkg/policy"
+	"github.com/cloudcwfranck/kspec/pkg/spec"
+)
+
+func newAdmissionRequest(t *testing.T, kind, namespace string, obj interface{}) *admissionv1.AdmissionRequest {
+	t.Helper()
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("failed to marshal %s: %v", kind, err)
+	}
+	return &admissionv1.AdmissionRequest{
+		Kind:      metav1.GroupVersionKind{Kind: kind},
+		Namespace: namespace,
+		Object:    runtime.RawExtension{Raw: raw},
+	}
+}
+
+func TestPodFromAdmissionRequest(t *testing.T) {
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: "docker.io/library/nginx"}},
+		},
+	}
+	meta := metav1.ObjectMeta{Name: "web"}
+
+	tests := []struct {
+		kind string
+		obj  interface{}
+	}{
+		{kind: "Pod", obj: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Labels: template.Labels}, Spec: template.Spec}},
+		{kind: "Deployment", obj: &appsv1.Deployment{ObjectMeta: meta, Spec: appsv1.DeploymentSpec{Template: template}}},
+		{kind: "StatefulSet", obj: &appsv1.StatefulSet{ObjectMeta: meta, Spec: appsv1.StatefulSetSpec{Template: template}}},
+		{kind: "DaemonSet", obj: &appsv1.DaemonSet{ObjectMeta: meta, Spec: appsv1.DaemonSetSpec{Template: template}}},
+		{kind: "Job", obj: &batchv1.Job{ObjectMeta: meta, Spec: batchv1.JobSpec{Template: template}}},
+		{kind: "CronJob", obj: &batchv1.CronJob{ObjectMeta: meta, Spec: batchv1.CronJobSpec{
+			JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: template}},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			pod, ok, err := podFromAdmissionRequest(newAdmissionRequest(t, tt.kind, "apps", tt.obj))
+			if err != nil || !ok {
+				t.Fatalf("expected a pod, got ok=%v err=%v", ok, err)
+			}
+			if pod.Name != "web" || pod.Namespace != "apps" {
+				t.Errorf("expected pod apps/web, got %s/%s", pod.Namespace, pod.Name)
+			}
+			if pod.Labels["app"] != "web" {
+				t.Errorf("expected template labels, got %v", pod.Labels)
+			}
+			if len(pod.Spec.Containers) != 1 || pod.Spec.Containers[0].Image != "docker.io/library/nginx" {
+				t.Errorf("expected template containers, got %+v", pod.Spec.Containers)
+			}
+		})
+	}
+
+	// Kinds without a pod template are not validated
+	if _, ok, _ := podFromAdmissionRequest(newAdmissionRequest(t, "ConfigMap", "apps", &corev1.ConfigMap{})); ok {
+		t.Error("expected ConfigMap to be skipped")
+	}
+}
+
+func TestEvaluateWorkloadKind(t *testing.T) {
+	server := &Server{PolicyManager: policy.NewAdvancedPolicyManager(nil)}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "app", Image: "docker.io/library/nginx"}},
+			},
+		}},
+	}
+	clusterSpec := kspecv1alpha1.ClusterSpecification{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: kspecv1alpha1.ClusterSpecificationSpec{
+			Enforcement: &kspecv1alpha1.EnforcementSpec{Enabled: true, Mode: "enforce"},
+			Webhooks:    &kspecv1alpha1.WebhooksSpec{Enabled: true},
+			SpecFields: spec.SpecFields{
+				Workloads: &spec.WorkloadsSpec{
+					Images: &spec.ImageSpec{BlockedRegistries: []string{"docker.io/"}},
+				},
+			},
+		},
+	}
+
+	pod, _, err := podFromAdmissionRequest(newAdmissionRequest(t, "Deployment", "apps", deployment))
+	if err != nil {
+		t.Fatalf("failed to extract pod template: %v", err)
+	}
+
+	decision := server.evaluate(context.Background(), "Deployment", pod, []kspecv1alpha1.ClusterSpecification{clusterSpec})
+	if decision.response.Allowed {
+		t.Fatal("expected Deployment with a blocked image to be denied")
+	}
+	if !strings.HasPrefix(decision.response.Result.Message, "Deployment violates cluster specification prod") {
+		t.Errorf("expected the denial to name the Deployment, got %q", decision.response.Result.Message)
+	}
+
+	// Exemptions match the controller kind and name
+	clusterSpec.Spec.PolicyExemptions = []kspecv1alpha1.PolicyExemptionSpec{
+		{
+			Name:      "legacy-web",
+			Reason:    "migration in progress",
+			Approver:  "security-team",
+			Resources: []kspecv1alpha1.ResourceSelectorSpec{{Kind: "Deployment", Name: "web"}},
+		},
+	}
+	decision = server.evaluate(context.Background(), "Deployment", pod, []kspecv1alpha1.ClusterSpecification{clusterSpec})
+	if !decision.response.Allowed {
+		t.Errorf("expected exempted Deployment to be allowed, got %+v", decision.response.Result)
+	}
+}